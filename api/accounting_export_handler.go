@@ -0,0 +1,184 @@
+package api
+
+import (
+	"errors"
+	"maicare_go/service/finance"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+// accountingExportError maps the errors of the accounting export to a status code
+func accountingExportError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+	case errors.Is(err, finance.ErrInvalidExportPeriod), errors.Is(err, finance.ErrInvalidLedgerAccount):
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+	case errors.Is(err, finance.ErrReexportNotConfirmed):
+		ctx.JSON(http.StatusConflict, errorResponse(err))
+	case errors.Is(err, finance.ErrNothingToExport), errors.Is(err, finance.ErrMissingLedgerAccount):
+		ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	}
+}
+
+// ListLedgerAccountsApi returns the ledger account mappings of an organisation
+// @Summary List ledger account mappings
+// @Tags organisations
+// @Produce json
+// @Param id path int64 true "Organisation ID"
+// @Success 200 {object} Response[[]finance.LedgerAccountResponse]
+// @Failure 400,401,500 {object} Response[any]
+// @Router /organisations/{id}/ledger_accounts [get]
+func (server *Server) ListLedgerAccountsApi(ctx *gin.Context) {
+	organisationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	accounts, err := server.businessService.FinanceService.ListLedgerAccounts(ctx, organisationID)
+	if err != nil {
+		accountingExportError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, SuccessResponse(accounts, "Ledger accounts retrieved successfully"))
+}
+
+// UpdateLedgerAccountsApi replaces the ledger account mappings of an organisation
+// @Summary Update ledger account mappings
+// @Description Revenue accounts can be set per care type and financing act and VAT accounts per rate, mappings without them are the fallback
+// @Tags organisations
+// @Accept json
+// @Produce json
+// @Param id path int64 true "Organisation ID"
+// @Param request body finance.UpdateLedgerAccountsRequest true "Update Ledger Accounts Request"
+// @Success 200 {object} Response[[]finance.LedgerAccountResponse]
+// @Failure 400,401,500 {object} Response[any]
+// @Router /organisations/{id}/ledger_accounts [put]
+func (server *Server) UpdateLedgerAccountsApi(ctx *gin.Context) {
+	organisationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req finance.UpdateLedgerAccountsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	accounts, err := server.businessService.FinanceService.UpdateLedgerAccounts(ctx, organisationID, req, payload.EmployeeID)
+	if err != nil {
+		accountingExportError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, SuccessResponse(accounts, "Ledger accounts updated successfully"))
+}
+
+// CreateAccountingExportApi records an export batch of the invoices, credit notes and payments of a period
+// @Summary Create accounting export
+// @Description Everything of the period that was not exported before is added to a new batch, download the journal with the download endpoint
+// @Tags Finance
+// @Accept json
+// @Produce json
+// @Param request body finance.CreateAccountingExportRequest true "Create Accounting Export Request"
+// @Success 201 {object} Response[finance.AccountingExportBatchResponse]
+// @Failure 400,401,422,500 {object} Response[any]
+// @Router /finance/accounting_exports [post]
+func (server *Server) CreateAccountingExportApi(ctx *gin.Context) {
+	var req finance.CreateAccountingExportRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	batch, err := server.businessService.FinanceService.CreateAccountingExport(ctx, req, payload.EmployeeID)
+	if err != nil {
+		accountingExportError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, SuccessResponse(batch, "Accounting export created successfully"))
+}
+
+// ListAccountingExportsApi lists the export batches of an organisation
+// @Summary List accounting exports
+// @Tags Finance
+// @Produce json
+// @Param organisation_id query int64 true "Organisation ID"
+// @Success 200 {object} Response[[]finance.AccountingExportBatchResponse]
+// @Failure 400,401,500 {object} Response[any]
+// @Router /finance/accounting_exports [get]
+func (server *Server) ListAccountingExportsApi(ctx *gin.Context) {
+	var req finance.ListAccountingExportsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	batches, err := server.businessService.FinanceService.ListAccountingExports(ctx, req)
+	if err != nil {
+		accountingExportError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, SuccessResponse(batches, "Accounting exports retrieved successfully"))
+}
+
+// DownloadAccountingExportApi downloads the journal file of an export batch
+// @Summary Download accounting export
+// @Description Downloading a batch that was downloaded before needs confirm=true
+// @Tags Finance
+// @Produce text/csv
+// @Param id path int64 true "Export batch ID"
+// @Param confirm query bool false "Confirm downloading the batch again"
+// @Success 200 {file} file
+// @Failure 400,401,404,409,422,500 {object} Response[any]
+// @Router /finance/accounting_exports/{id}/download [get]
+func (server *Server) DownloadAccountingExportApi(ctx *gin.Context) {
+	batchID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req finance.DownloadAccountingExportRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	file, err := server.businessService.FinanceService.DownloadAccountingExport(ctx, batchID, req, payload.EmployeeID)
+	if err != nil {
+		accountingExportError(ctx, err)
+		return
+	}
+
+	sendCSV(ctx, file.Name, file.Data)
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-json"
+
+	db "maicare_go/db/sqlc"
+	"maicare_go/service/finance"
+	"maicare_go/token"
+
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomWorker creates an employee whose user only has the permissions of the Worker role
+func createRandomWorker(t *testing.T) *db.CustomUser {
+	_, user := createRandomEmployee(t)
+	err := testStore.AssignRoleToUser(context.Background(), db.AssignRoleToUserParams{
+		UserID: user.ID,
+		RoleID: 2,
+	})
+	require.NoError(t, err)
+	err = testStore.DeleteUserPermissions(context.Background(), user.ID)
+	require.NoError(t, err)
+	err = testStore.GrantRolePermissionsToUser(context.Background(), db.GrantRolePermissionsToUserParams{
+		UserID: user.ID,
+		RoleID: 2,
+	})
+	require.NoError(t, err)
+	return user
+}
+
+func TestUpdateLedgerAccountsApi(t *testing.T) {
+	_, user := createRandomEmployee(t)
+	worker := createRandomWorker(t)
+	organisation := createRandomOrganisation(t)
+
+	testCases := []struct {
+		name          string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var res Response[[]finance.LedgerAccountResponse]
+				err := json.NewDecoder(recorder.Body).Decode(&res)
+				require.NoError(t, err)
+				require.Len(t, res.Data, 1)
+				require.Equal(t, "1300", res.Data[0].AccountCode)
+			},
+		},
+		{
+			name: "WithoutExportPermission",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, worker.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			reqBody, err := json.Marshal(finance.UpdateLedgerAccountsRequest{
+				Accounts: []finance.LedgerAccountRequest{{AccountType: "debtor", AccountCode: "1300"}},
+			})
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/organisations/%d/ledger_accounts", organisation.ID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(reqBody))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			recorder := httptest.NewRecorder()
+			tc.setupAuth(t, request, testServer.tokenMaker)
+			testServer.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
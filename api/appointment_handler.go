@@ -1,6 +1,7 @@
 package api
 
 import (
+	"errors"
 	"fmt"
 	"maicare_go/service/appointment"
 	"net/http"
@@ -40,6 +41,10 @@ func (server *Server) CreateAppointmentApi(ctx *gin.Context) {
 	}
 	response, err := server.businessService.AppointmentService.CreateAppointment(&req, userID, ctx)
 	if err != nil {
+		if errors.Is(err, appointment.ErrInvalidRecurrence) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(fmt.Errorf("failed to create appointment")))
 		return
 	}
@@ -242,7 +247,7 @@ func (server *Server) GetAppointmentApi(ctx *gin.Context) {
 
 // UpdateAppointmentApi updates an appointment
 // @Summary Update an appointment
-// @Description Update an appointment
+// @Description Update an appointment. For an occurrence of a series the scope selects this occurrence (default), this and the following occurrences or the entire series; occurrences that have started are not changed.
 // @Tags appointments
 // @Accept json
 // @Produce json
@@ -270,6 +275,10 @@ func (server *Server) UpdateAppointmentApi(ctx *gin.Context) {
 
 	response, err := server.businessService.AppointmentService.UpdateAppointment(ctx, appointmentID, &req)
 	if err != nil {
+		if status := appointmentSeriesError(err); status != http.StatusInternalServerError {
+			ctx.JSON(status, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(fmt.Errorf("failed to update appointment")))
 		return
 	}
@@ -281,10 +290,11 @@ func (server *Server) UpdateAppointmentApi(ctx *gin.Context) {
 
 // DeleteAppointmentApi deletes an appointment
 // @Summary Delete an appointment
-// @Description Delete an appointment
+// @Description Delete an appointment. For an occurrence of a series the scope selects this occurrence (default), this and the following occurrences or the entire series; occurrences that have started are kept.
 // @Tags appointments
 // @Produce json
 // @Param id path string true "Appointment ID (UUID)"
+// @Param scope query string false "this, following or all"
 // @Success 200 {object} Response[any]
 // @Failure 400 {object} Response[any] "Bad request - Invalid input"
 // @Failure 401 {object} Response[any] "Unauthorized - Invalid credentials"
@@ -299,8 +309,18 @@ func (server *Server) DeleteAppointmentApi(ctx *gin.Context) {
 		return
 	}
 
-	err = server.businessService.AppointmentService.DeleteAppointment(ctx, appointmentID)
+	var req appointment.DeleteAppointmentRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid scope")))
+		return
+	}
+
+	err = server.businessService.AppointmentService.DeleteAppointment(ctx, appointmentID, req)
 	if err != nil {
+		if status := appointmentSeriesError(err); status != http.StatusInternalServerError {
+			ctx.JSON(status, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(fmt.Errorf("failed to delete appointment")))
 		return
 	}
@@ -345,3 +365,52 @@ func (server *Server) ConfirmAppointmentApi(ctx *gin.Context) {
 	res := SuccessResponse[any](nil, "Appointment confirmed successfully")
 	ctx.JSON(http.StatusOK, res)
 }
+
+// appointmentSeriesError maps the errors of appointment series to a status code
+func appointmentSeriesError(err error) int {
+	switch {
+	case errors.Is(err, appointment.ErrSeriesNotFound), errors.Is(err, appointment.ErrAppointmentNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, appointment.ErrInvalidRange), errors.Is(err, appointment.ErrInvalidRecurrence),
+		errors.Is(err, appointment.ErrNotSeriesOccurrence):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// ListAppointmentSeriesOccurrencesApi lists the occurrences of an appointment series
+// @Summary List the occurrences of an appointment series
+// @Description Expand a recurring appointment for a date range. Occurrences beyond the stored window have no appointment ID yet.
+// @Tags appointments
+// @Produce json
+// @Param id path string true "Appointment series ID (UUID)"
+// @Param start_date query string true "Start of the range (RFC 3339)"
+// @Param end_date query string true "End of the range (RFC 3339), at most a year after the start"
+// @Success 200 {object} Response[[]appointment.SeriesOccurrenceResponse]
+// @Failure 400 {object} Response[any] "Bad request - Invalid input"
+// @Failure 404 {object} Response[any] "Not found - Appointment series not found"
+// @Failure 500 {object} Response[any] "Internal server error"
+// @Router /appointments/series/{id}/occurrences [get]
+func (server *Server) ListAppointmentSeriesOccurrencesApi(ctx *gin.Context) {
+	seriesID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid appointment series ID parameter")))
+		return
+	}
+
+	var req appointment.ListSeriesOccurrencesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	occurrences, err := server.businessService.AppointmentService.ListAppointmentSeriesOccurrences(ctx, seriesID, req)
+	if err != nil {
+		ctx.JSON(appointmentSeriesError(err), errorResponse(err))
+		return
+	}
+
+	res := SuccessResponse(occurrences, "Appointment series occurrences retrieved successfully")
+	ctx.JSON(http.StatusOK, res)
+}
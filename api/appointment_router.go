@@ -14,5 +14,7 @@ func (server *Server) setupAppointmentRoutes(baseRouter *gin.RouterGroup) {
 		appointmentsRouter.POST("/:id/clients", server.RBACMiddleware("APPOINTMENT.UPDATE"), server.AddClientToAppointmentApi)
 
 		appointmentsRouter.POST("/:id/confirm", server.RBACMiddleware("APPOINTMENT.CONFIRM"), server.ConfirmAppointmentApi)
+
+		appointmentsRouter.GET("/series/:id/occurrences", server.RBACMiddleware("APPOINTMENT.VIEW"), server.ListAppointmentSeriesOccurrencesApi)
 	}
 }
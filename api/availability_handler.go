@@ -0,0 +1,292 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"maicare_go/service/availability"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func availabilityError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, availability.ErrEmployeeNotFound), errors.Is(err, availability.ErrUnavailabilityNotFound):
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+	case errors.Is(err, availability.ErrInvalidAvailability), errors.Is(err, availability.ErrInvalidPreferences):
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	}
+}
+
+// GetMyAvailabilityApi returns the availability of the logged in employee
+// @Summary Get my availability
+// @Description Get the weekly availability, the coming unavailability and the shift preferences of the logged in employee
+// @Tags Availability
+// @Produce json
+// @Success 200 {object} Response[availability.AvailabilityResponse]
+// @Failure 401 {object} Response[any] "Unauthorized"
+// @Failure 404 {object} Response[any] "Employee not found"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /availability/me [get]
+func (server *Server) GetMyAvailabilityApi(ctx *gin.Context) {
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(fmt.Errorf("failed to get auth payload")))
+		return
+	}
+	server.getAvailability(ctx, payload.EmployeeID)
+}
+
+// SetMyWeeklyAvailabilityApi replaces the weekly availability of the logged in employee
+// @Summary Set my weekly availability
+// @Description Replace the weekly availability of the logged in employee. A window that ends at or before its start runs into the next day, no windows means always available.
+// @Tags Availability
+// @Accept json
+// @Produce json
+// @Param request body availability.SetWeeklyAvailabilityRequest true "Weekly availability"
+// @Success 200 {object} Response[availability.AvailabilityResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 401 {object} Response[any] "Unauthorized"
+// @Failure 404 {object} Response[any] "Employee not found"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /availability/me/weekly [put]
+func (server *Server) SetMyWeeklyAvailabilityApi(ctx *gin.Context) {
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(fmt.Errorf("failed to get auth payload")))
+		return
+	}
+	server.setWeeklyAvailability(ctx, payload.EmployeeID)
+}
+
+// CreateMyUnavailabilityApi records that the logged in employee is unavailable
+// @Summary Add my unavailability
+// @Description Record a one-off period in which the logged in employee cannot work
+// @Tags Availability
+// @Accept json
+// @Produce json
+// @Param request body availability.CreateUnavailabilityRequest true "Unavailability"
+// @Success 201 {object} Response[availability.UnavailabilityResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 401 {object} Response[any] "Unauthorized"
+// @Failure 404 {object} Response[any] "Employee not found"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /availability/me/unavailability [post]
+func (server *Server) CreateMyUnavailabilityApi(ctx *gin.Context) {
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(fmt.Errorf("failed to get auth payload")))
+		return
+	}
+	server.createUnavailability(ctx, payload.EmployeeID)
+}
+
+// DeleteMyUnavailabilityApi removes unavailability of the logged in employee
+// @Summary Delete my unavailability
+// @Description Remove a period of unavailability of the logged in employee
+// @Tags Availability
+// @Produce json
+// @Param id path int true "Unavailability ID"
+// @Success 200 {object} Response[any]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 401 {object} Response[any] "Unauthorized"
+// @Failure 404 {object} Response[any] "Unavailability not found"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /availability/me/unavailability/{id} [delete]
+func (server *Server) DeleteMyUnavailabilityApi(ctx *gin.Context) {
+	unavailabilityID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid unavailability ID")))
+		return
+	}
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(fmt.Errorf("failed to get auth payload")))
+		return
+	}
+	server.deleteUnavailability(ctx, payload.EmployeeID, unavailabilityID)
+}
+
+// UpdateMyShiftPreferencesApi sets the shift preferences of the logged in employee
+// @Summary Set my shift preferences
+// @Description Set the preferred locations and shifts and the most night shifts a month of the logged in employee. The roster generator favours the preferences and never plans more night shifts.
+// @Tags Availability
+// @Accept json
+// @Produce json
+// @Param request body availability.ShiftPreferencesRequest true "Shift preferences"
+// @Success 200 {object} Response[availability.ShiftPreferencesResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 401 {object} Response[any] "Unauthorized"
+// @Failure 404 {object} Response[any] "Employee not found"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /availability/me/preferences [put]
+func (server *Server) UpdateMyShiftPreferencesApi(ctx *gin.Context) {
+	var req availability.ShiftPreferencesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(fmt.Errorf("failed to get auth payload")))
+		return
+	}
+
+	preferences, err := server.businessService.AvailabilityService.UpdateShiftPreferences(ctx, payload.EmployeeID, &req)
+	if err != nil {
+		availabilityError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(preferences, "Shift preferences updated successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// GetEmployeeAvailabilityApi returns the availability of an employee
+// @Summary Get the availability of an employee
+// @Description Get the weekly availability, the coming unavailability and the shift preferences of an employee
+// @Tags Availability
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Success 200 {object} Response[availability.AvailabilityResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 404 {object} Response[any] "Employee not found"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /employees/{id}/availability [get]
+func (server *Server) GetEmployeeAvailabilityApi(ctx *gin.Context) {
+	employeeID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid employee ID")))
+		return
+	}
+	server.getAvailability(ctx, employeeID)
+}
+
+// SetEmployeeWeeklyAvailabilityApi replaces the weekly availability of an employee
+// @Summary Set the weekly availability of an employee
+// @Description Replace the weekly availability of an employee. A window that ends at or before its start runs into the next day, no windows means always available.
+// @Tags Availability
+// @Accept json
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Param request body availability.SetWeeklyAvailabilityRequest true "Weekly availability"
+// @Success 200 {object} Response[availability.AvailabilityResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 404 {object} Response[any] "Employee not found"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /employees/{id}/availability/weekly [put]
+func (server *Server) SetEmployeeWeeklyAvailabilityApi(ctx *gin.Context) {
+	employeeID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid employee ID")))
+		return
+	}
+	server.setWeeklyAvailability(ctx, employeeID)
+}
+
+// CreateEmployeeUnavailabilityApi records that an employee is unavailable
+// @Summary Add unavailability of an employee
+// @Description Record a one-off period in which an employee cannot work
+// @Tags Availability
+// @Accept json
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Param request body availability.CreateUnavailabilityRequest true "Unavailability"
+// @Success 201 {object} Response[availability.UnavailabilityResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 404 {object} Response[any] "Employee not found"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /employees/{id}/availability/unavailability [post]
+func (server *Server) CreateEmployeeUnavailabilityApi(ctx *gin.Context) {
+	employeeID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid employee ID")))
+		return
+	}
+	server.createUnavailability(ctx, employeeID)
+}
+
+// DeleteEmployeeUnavailabilityApi removes unavailability of an employee
+// @Summary Delete unavailability of an employee
+// @Description Remove a period of unavailability of an employee
+// @Tags Availability
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Param unavailability_id path int true "Unavailability ID"
+// @Success 200 {object} Response[any]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 404 {object} Response[any] "Unavailability not found"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /employees/{id}/availability/unavailability/{unavailability_id} [delete]
+func (server *Server) DeleteEmployeeUnavailabilityApi(ctx *gin.Context) {
+	employeeID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid employee ID")))
+		return
+	}
+	unavailabilityID, err := strconv.ParseInt(ctx.Param("unavailability_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid unavailability ID")))
+		return
+	}
+	server.deleteUnavailability(ctx, employeeID, unavailabilityID)
+}
+
+func (server *Server) getAvailability(ctx *gin.Context, employeeID int64) {
+	result, err := server.businessService.AvailabilityService.GetAvailability(ctx, employeeID)
+	if err != nil {
+		availabilityError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(result, "Availability retrieved successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (server *Server) setWeeklyAvailability(ctx *gin.Context, employeeID int64) {
+	var req availability.SetWeeklyAvailabilityRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	result, err := server.businessService.AvailabilityService.SetWeeklyAvailability(ctx, employeeID, &req)
+	if err != nil {
+		availabilityError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(result, "Weekly availability updated successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (server *Server) createUnavailability(ctx *gin.Context, employeeID int64) {
+	var req availability.CreateUnavailabilityRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	unavailability, err := server.businessService.AvailabilityService.CreateUnavailability(ctx, employeeID, &req)
+	if err != nil {
+		availabilityError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(unavailability, "Unavailability created successfully")
+	ctx.JSON(http.StatusCreated, res)
+}
+
+func (server *Server) deleteUnavailability(ctx *gin.Context, employeeID, unavailabilityID int64) {
+	err := server.businessService.AvailabilityService.DeleteUnavailability(ctx, employeeID, unavailabilityID)
+	if err != nil {
+		availabilityError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse[any](nil, "Unavailability deleted successfully")
+	ctx.JSON(http.StatusOK, res)
+}
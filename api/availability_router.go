@@ -0,0 +1,21 @@
+package api
+
+import "github.com/gin-gonic/gin"
+
+func (server *Server) setupAvailabilityRoutes(baseRouter *gin.RouterGroup) {
+	availability := baseRouter.Group("")
+	availability.Use(server.AuthMiddleware())
+	{
+		// every employee keeps their own availability and preferences
+		availability.GET("/availability/me", server.GetMyAvailabilityApi)
+		availability.PUT("/availability/me/weekly", server.SetMyWeeklyAvailabilityApi)
+		availability.POST("/availability/me/unavailability", server.CreateMyUnavailabilityApi)
+		availability.DELETE("/availability/me/unavailability/:id", server.DeleteMyUnavailabilityApi)
+		availability.PUT("/availability/me/preferences", server.UpdateMyShiftPreferencesApi)
+
+		availability.GET("/employees/:id/availability", server.RBACMiddleware("EMPLOYEE.VIEW"), server.GetEmployeeAvailabilityApi)
+		availability.PUT("/employees/:id/availability/weekly", server.RBACMiddleware("EMPLOYEE.UPDATE"), server.SetEmployeeWeeklyAvailabilityApi)
+		availability.POST("/employees/:id/availability/unavailability", server.RBACMiddleware("EMPLOYEE.UPDATE"), server.CreateEmployeeUnavailabilityApi)
+		availability.DELETE("/employees/:id/availability/unavailability/:unavailability_id", server.RBACMiddleware("EMPLOYEE.UPDATE"), server.DeleteEmployeeUnavailabilityApi)
+	}
+}
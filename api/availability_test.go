@@ -0,0 +1,93 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"maicare_go/service/availability"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAvailabilityApi(t *testing.T) {
+	employee, user := createRandomEmployee(t)
+
+	employeeAvailability := func(recorder *httptest.ResponseRecorder) availability.AvailabilityResponse {
+		var res Response[availability.AvailabilityResponse]
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+		return res.Data
+	}
+
+	recorder := sendRequest(t, user.ID, http.MethodGet, "/availability/me", nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	result := employeeAvailability(recorder)
+	require.Equal(t, employee.ID, result.EmployeeID)
+	require.Empty(t, result.Weekly)
+
+	weekly := availability.SetWeeklyAvailabilityRequest{Windows: []availability.WeeklyAvailabilityWindow{
+		{Weekday: 1, StartTime: "07:00", EndTime: "15:30"},
+		{Weekday: 5, StartTime: "23:00", EndTime: "07:00"},
+	}}
+	recorder = sendRequest(t, user.ID, http.MethodPut, "/availability/me/weekly", weekly)
+	t.Log(recorder.Body.String())
+	require.Equal(t, http.StatusOK, recorder.Code)
+	result = employeeAvailability(recorder)
+	require.Len(t, result.Weekly, 2)
+	require.Equal(t, "15:30", result.Weekly[0].EndTime)
+
+	weekly.Windows[0].StartTime = "7 o'clock"
+	recorder = sendRequest(t, user.ID, http.MethodPut, "/availability/me/weekly", weekly)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	start := time.Now().AddDate(0, 0, 7).Truncate(time.Hour).UTC()
+	reason := "Tandarts"
+	unavailable := availability.CreateUnavailabilityRequest{StartDatetime: start, EndDatetime: start.Add(-time.Hour), Reason: &reason}
+	recorder = sendRequest(t, user.ID, http.MethodPost, "/availability/me/unavailability", unavailable)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	unavailable.EndDatetime = start.Add(2 * time.Hour)
+	recorder = sendRequest(t, user.ID, http.MethodPost, "/availability/me/unavailability", unavailable)
+	t.Log(recorder.Body.String())
+	require.Equal(t, http.StatusCreated, recorder.Code)
+	var created Response[availability.UnavailabilityResponse]
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &created))
+	require.Equal(t, employee.ID, created.Data.EmployeeID)
+
+	maxNights := int32(2)
+	recorder = sendRequest(t, user.ID, http.MethodPut, "/availability/me/preferences", availability.ShiftPreferencesRequest{
+		PreferredShiftNames:    []string{"Ochtenddienst"},
+		MaxNightShiftsPerMonth: &maxNights,
+	})
+	t.Log(recorder.Body.String())
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	// planners see the availability of the employee
+	recorder = sendRequest(t, 1, http.MethodGet, fmt.Sprintf("/employees/%d/availability", employee.ID), nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	result = employeeAvailability(recorder)
+	require.Len(t, result.Weekly, 2)
+	require.Len(t, result.Unavailability, 1)
+	require.Equal(t, []string{"Ochtenddienst"}, result.Preferences.PreferredShiftNames)
+	require.Equal(t, maxNights, *result.Preferences.MaxNightShiftsPerMonth)
+
+	// the unavailability of one employee cannot be removed through another
+	other, _ := createRandomEmployee(t)
+	recorder = sendRequest(t, 1, http.MethodDelete, fmt.Sprintf("/employees/%d/availability/unavailability/%d", other.ID, created.Data.ID), nil)
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+
+	recorder = sendRequest(t, user.ID, http.MethodDelete, fmt.Sprintf("/availability/me/unavailability/%d", created.Data.ID), nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	recorder = sendRequest(t, user.ID, http.MethodDelete, fmt.Sprintf("/availability/me/unavailability/%d", created.Data.ID), nil)
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+
+	recorder = sendRequest(t, 1, http.MethodPut, fmt.Sprintf("/employees/%d/availability/weekly", employee.ID), availability.SetWeeklyAvailabilityRequest{})
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Empty(t, employeeAvailability(recorder).Weekly)
+
+	recorder = sendRequest(t, 1, http.MethodGet, "/employees/0/availability", nil)
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+}
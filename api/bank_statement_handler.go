@@ -0,0 +1,198 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"maicare_go/bankstatement"
+	invserv "maicare_go/service/invoice"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+// bank statements of a month stay well below this size
+const maxBankStatementSize = 10 << 20
+
+// ImportBankStatementApi imports a bank statement and books the payments it can match
+// @Summary Import a bank statement
+// @Description Parses a CAMT.053 or MT940 statement, books confidently matched credit lines as payments and queues the rest for review
+// @Tags bank_statements
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CAMT.053 or MT940 statement"
+// @Param format formData string false "Statement format, detected from the content when empty" Enums(camt053, mt940)
+// @Success 201 {object} Response[invserv.ImportBankStatementResponse]
+// @Failure 400,413,500 {object} Response[any]
+// @Router /bank_statements/import [post]
+func (server *Server) ImportBankStatementApi(ctx *gin.Context) {
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxBankStatementSize)
+	file, header, err := ctx.Request.FormFile("file")
+	if err != nil {
+		if strings.Contains(err.Error(), "request body too large") {
+			ctx.JSON(http.StatusRequestEntityTooLarge, errorResponse(fmt.Errorf("file size exceeds maximum limit of 10MB")))
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	defer file.Close()
+
+	format := ctx.PostForm("format")
+	if format != "" && format != bankstatement.FormatCAMT053 && format != bankstatement.FormatMT940 {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("unsupported format %q", format)))
+		return
+	}
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	result, err := server.businessService.InvoiceService.ImportBankStatement(ctx, invserv.ImportBankStatementRequest{
+		FileName: header.Filename,
+		Format:   format,
+		Content:  content,
+	}, payload.EmployeeID)
+	if err != nil {
+		if errors.Is(err, bankstatement.ErrUnknownFormat) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := SuccessResponse(result, "Bank statement imported successfully")
+	ctx.JSON(http.StatusCreated, res)
+}
+
+// ListBankStatementLinesApi lists imported statement lines
+// @Summary List bank statement lines
+// @Description Lists imported statement lines, filter on status=unmatched for the review queue
+// @Tags bank_statements
+// @Produce json
+// @Param page query int false "Page number"
+// @Param page_size query int false "Page size"
+// @Param status query string false "Line status" Enums(matched, unmatched, ignored)
+// @Param import_id query int false "Import ID"
+// @Success 200 {object} Response[pagination.Response[invserv.BankStatementLineResponse]]
+// @Failure 400,500 {object} Response[any]
+// @Router /bank_statements/lines [get]
+func (server *Server) ListBankStatementLinesApi(ctx *gin.Context) {
+	var req invserv.ListBankStatementLinesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	lines, err := server.businessService.InvoiceService.ListBankStatementLines(ctx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := SuccessResponse(lines, "Bank statement lines retrieved successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// MatchBankStatementLineApi books an unmatched statement line on an invoice
+// @Summary Match a bank statement line
+// @Tags bank_statements
+// @Accept json
+// @Produce json
+// @Param line_id path int true "Bank statement line ID"
+// @Param request body invserv.MatchBankStatementLineRequest true "Invoice to book the line on"
+// @Success 200 {object} Response[invserv.BankStatementMatch]
+// @Failure 400,404,409,500 {object} Response[any]
+// @Router /bank_statements/lines/{line_id}/match [post]
+func (server *Server) MatchBankStatementLineApi(ctx *gin.Context) {
+	lineID, err := strconv.ParseInt(ctx.Param("line_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req invserv.MatchBankStatementLineRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	match, err := server.businessService.InvoiceService.MatchBankStatementLine(ctx, lineID, req, payload.EmployeeID)
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+		case errors.Is(err, invserv.ErrStatementLineNotOpen), errors.Is(err, invserv.ErrInvoiceNotPayable):
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	res := SuccessResponse(match, "Bank statement line matched successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// IgnoreBankStatementLineApi removes an unmatched statement line from the review queue
+// @Summary Ignore a bank statement line
+// @Tags bank_statements
+// @Accept json
+// @Produce json
+// @Param line_id path int true "Bank statement line ID"
+// @Param request body invserv.IgnoreBankStatementLineRequest false "Reason"
+// @Success 200 {object} Response[invserv.BankStatementLineResponse]
+// @Failure 400,409,500 {object} Response[any]
+// @Router /bank_statements/lines/{line_id}/ignore [post]
+func (server *Server) IgnoreBankStatementLineApi(ctx *gin.Context) {
+	lineID, err := strconv.ParseInt(ctx.Param("line_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req invserv.IgnoreBankStatementLineRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+
+	line, err := server.businessService.InvoiceService.IgnoreBankStatementLine(ctx, lineID, req, payload.EmployeeID)
+	if err != nil {
+		if errors.Is(err, invserv.ErrStatementLineNotOpen) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := SuccessResponse(line, "Bank statement line ignored successfully")
+	ctx.JSON(http.StatusOK, res)
+}
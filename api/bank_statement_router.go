@@ -0,0 +1,14 @@
+package api
+
+import "github.com/gin-gonic/gin"
+
+func (server *Server) setupBankStatementRoutes(baseRouter *gin.RouterGroup) {
+	bankStatementGroup := baseRouter.Group("/bank_statements")
+	bankStatementGroup.Use(server.AuthMiddleware())
+	{
+		bankStatementGroup.POST("/import", server.RBACMiddleware("INVOICE.PAYMENT.CREATE"), server.ImportBankStatementApi)
+		bankStatementGroup.GET("/lines", server.RBACMiddleware("INVOICE.PAYMENT.VIEW"), server.ListBankStatementLinesApi)
+		bankStatementGroup.POST("/lines/:line_id/match", server.RBACMiddleware("INVOICE.PAYMENT.CREATE"), server.MatchBankStatementLineApi)
+		bankStatementGroup.POST("/lines/:line_id/ignore", server.RBACMiddleware("INVOICE.PAYMENT.UPDATE"), server.IgnoreBankStatementLineApi)
+	}
+}
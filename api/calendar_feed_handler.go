@@ -0,0 +1,141 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"maicare_go/service/calendar"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateCalendarFeedApi creates the calendar feed of the current user
+// @Summary Create or regenerate the calendar feed
+// @Description Create the iCalendar feed of the shifts and appointments of the current user. An existing feed gets a new URL and the old URL stops working. The URL is only returned once.
+// @Tags calendar
+// @Produce json
+// @Success 201 {object} Response[calendar.CalendarFeedResponse]
+// @Failure 401 {object} Response[any] "Unauthorized"
+// @Failure 500 {object} Response[any] "Internal server error"
+// @Router /calendar_feed [post]
+func (server *Server) CreateCalendarFeedApi(ctx *gin.Context) {
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(fmt.Errorf("failed to get auth payload")))
+		return
+	}
+
+	feed, err := server.businessService.CalendarService.CreateFeedToken(ctx, payload.UserId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	feed.URL = server.calendarFeedURL(ctx, feed.Token)
+
+	res := SuccessResponse(feed, "Calendar feed created successfully")
+	ctx.JSON(http.StatusCreated, res)
+}
+
+// GetCalendarFeedApi returns the calendar feed of the current user
+// @Summary Get the calendar feed
+// @Description Get when the calendar feed of the current user was created and last fetched. The URL is not returned.
+// @Tags calendar
+// @Produce json
+// @Success 200 {object} Response[calendar.CalendarFeedResponse]
+// @Failure 401 {object} Response[any] "Unauthorized"
+// @Failure 404 {object} Response[any] "The user has no calendar feed"
+// @Failure 500 {object} Response[any] "Internal server error"
+// @Router /calendar_feed [get]
+func (server *Server) GetCalendarFeedApi(ctx *gin.Context) {
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(fmt.Errorf("failed to get auth payload")))
+		return
+	}
+
+	feed, err := server.businessService.CalendarService.GetFeedToken(ctx, payload.UserId)
+	if err != nil {
+		if errors.Is(err, calendar.ErrFeedNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := SuccessResponse(feed, "Calendar feed retrieved successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// RevokeCalendarFeedApi revokes the calendar feed of the current user
+// @Summary Revoke the calendar feed
+// @Description Revoke the calendar feed of the current user, its URL stops working
+// @Tags calendar
+// @Produce json
+// @Success 200 {object} Response[any]
+// @Failure 401 {object} Response[any] "Unauthorized"
+// @Failure 404 {object} Response[any] "The user has no calendar feed"
+// @Failure 500 {object} Response[any] "Internal server error"
+// @Router /calendar_feed [delete]
+func (server *Server) RevokeCalendarFeedApi(ctx *gin.Context) {
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(fmt.Errorf("failed to get auth payload")))
+		return
+	}
+
+	err = server.businessService.CalendarService.RevokeFeedToken(ctx, payload.UserId)
+	if err != nil {
+		if errors.Is(err, calendar.ErrFeedNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := SuccessResponse[any](nil, "Calendar feed revoked successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// CalendarFeedIcsApi serves a calendar feed to calendar apps
+// @Summary Get a calendar feed
+// @Description iCalendar feed of the shifts and appointments of the user the token belongs to. No authentication header is needed, the token is the credential.
+// @Tags calendar
+// @Produce text/calendar
+// @Param token path string true "Feed token followed by .ics"
+// @Success 200 {string} string "iCalendar data"
+// @Failure 404 {string} string "Unknown or revoked token"
+// @Failure 500 {string} string "Internal server error"
+// @Router /calendar/{token} [get]
+func (server *Server) CalendarFeedIcsApi(ctx *gin.Context) {
+	token := strings.TrimSuffix(ctx.Param("token"), ".ics")
+
+	data, err := server.businessService.CalendarService.RenderFeed(ctx, token)
+	if err != nil {
+		if errors.Is(err, calendar.ErrFeedNotFound) {
+			ctx.String(http.StatusNotFound, "calendar feed not found")
+			return
+		}
+		ctx.String(http.StatusInternalServerError, "failed to render calendar feed")
+		return
+	}
+
+	ctx.Header("Cache-Control", "private, max-age=900")
+	ctx.Header("Content-Disposition", `inline; filename="maicare.ics"`)
+	ctx.Data(http.StatusOK, "text/calendar; charset=utf-8", data)
+}
+
+// calendarFeedURL returns the URL calendar apps subscribe to
+func (server *Server) calendarFeedURL(ctx *gin.Context, token string) string {
+	host := server.config.Host
+	if host == "" {
+		host = ctx.Request.Host
+	}
+	scheme := "https"
+	if ctx.Request.TLS == nil && server.config.Environment != "production" {
+		scheme = "http"
+	}
+	return fmt.Sprintf("%s://%s/calendar/%s.ics", scheme, host, token)
+}
@@ -0,0 +1,15 @@
+package api
+
+import "github.com/gin-gonic/gin"
+
+func (server *Server) setupCalendarFeedRoutes(baseRouter *gin.RouterGroup) {
+	feedRouter := baseRouter.Group("/calendar_feed").Use(server.AuthMiddleware())
+	{
+		feedRouter.GET("", server.GetCalendarFeedApi)
+		feedRouter.POST("", server.CreateCalendarFeedApi)
+		feedRouter.DELETE("", server.RevokeCalendarFeedApi)
+	}
+
+	// calendar apps cannot authenticate, the secret token in the URL is the credential
+	baseRouter.GET("/calendar/:token", server.CalendarFeedIcsApi)
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"maicare_go/service/calendar"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarFeedApi(t *testing.T) {
+	_, user := createRandomEmployee(t)
+
+	createFeed := func() calendar.CalendarFeedResponse {
+		recorder := httptest.NewRecorder()
+		request, err := http.NewRequest(http.MethodPost, "/calendar_feed", nil)
+		require.NoError(t, err)
+		addAuthorization(t, request, testServer.tokenMaker, authorizationTypeBearer, user.ID, time.Minute)
+		testServer.router.ServeHTTP(recorder, request)
+		require.Equal(t, http.StatusCreated, recorder.Code)
+
+		var response Response[calendar.CalendarFeedResponse]
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		require.NotEmpty(t, response.Data.Token)
+		require.True(t, strings.HasSuffix(response.Data.URL, "/calendar/"+response.Data.Token+".ics"))
+		return response.Data
+	}
+	fetchFeed := func(token string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		request, err := http.NewRequest(http.MethodGet, "/calendar/"+token+".ics", nil)
+		require.NoError(t, err)
+		testServer.router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	feed := createFeed()
+	recorder := fetchFeed(feed.Token)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Contains(t, recorder.Header().Get("Content-Type"), "text/calendar")
+	require.True(t, strings.HasPrefix(recorder.Body.String(), "BEGIN:VCALENDAR\r\n"))
+
+	// regenerating replaces the old URL
+	regenerated := createFeed()
+	require.NotEqual(t, feed.Token, regenerated.Token)
+	require.Equal(t, http.StatusNotFound, fetchFeed(feed.Token).Code)
+	require.Equal(t, http.StatusOK, fetchFeed(regenerated.Token).Code)
+
+	recorder = httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodDelete, "/calendar_feed", nil)
+	require.NoError(t, err)
+	addAuthorization(t, request, testServer.tokenMaker, authorizationTypeBearer, user.ID, time.Minute)
+	testServer.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, http.StatusNotFound, fetchFeed(regenerated.Token).Code)
+}
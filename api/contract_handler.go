@@ -184,12 +184,14 @@ func (server *Server) UpdateContractApi(ctx *gin.Context) {
 
 // UpdateContractStatusApi updates the status of a contract
 // @Summary Update the status of a contract
+// @Description Changes the status from now on or, with effective_from, from an earlier date. The status timeline of the contract is updated accordingly.
 // @Tags contracts
 // @Accept json
 // @Produce json
 // @Param id path string true "Contract ID"
 // @Param request body contract.UpdateContractStatusRequest true "Update Contract Status Request"
 // @Success 200 {object} Response[contract.UpdateContractStatusResponse]
+// @Failure 400,404,422,500 {object} Response[any]
 // @Router /contracts/{id}/status [put]
 func (server *Server) UpdateContractStatusApi(ctx *gin.Context) {
 	id := ctx.Param("id")
@@ -213,7 +215,7 @@ func (server *Server) UpdateContractStatusApi(ctx *gin.Context) {
 
 	updatedContract, err := server.businessService.ContractService.UpdateContractStatus(ctx, req, contractID, payload.EmployeeID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		contractStatusError(ctx, err)
 		return
 	}
 
@@ -0,0 +1,143 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"maicare_go/pagination"
+	"maicare_go/service/contract"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetContractHoursBudgetApi returns the indicated versus consumed hours of a contract
+// @Summary Get the hours budget of a contract
+// @Description Compares the indication of an ambulante contract with the delivered appointments and registered working hours of the current week or contract period
+// @Tags contracts
+// @Produce json
+// @Param id path int true "Contract ID"
+// @Success 200 {object} Response[contract.ContractHoursBudgetResponse]
+// @Failure 400,404,422,500 {object} Response[any]
+// @Router /contracts/{id}/hours_budget [get]
+func (server *Server) GetContractHoursBudgetApi(ctx *gin.Context) {
+	contractID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	budget, err := server.businessService.ContractService.GetContractHoursBudget(ctx, contractID)
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			ctx.JSON(http.StatusNotFound, errorResponse(fmt.Errorf("contract not found")))
+		case errors.Is(err, contract.ErrNoHoursBudget):
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, SuccessResponse(budget, "Contract hours budget retrieved successfully"))
+}
+
+// CreateContractWorkingHoursApi registers delivered care on a contract
+// @Summary Register working hours on a contract
+// @Tags contracts
+// @Accept json
+// @Produce json
+// @Param id path int true "Contract ID"
+// @Param request body contract.CreateContractWorkingHoursRequest true "Working hours"
+// @Success 201 {object} Response[contract.ContractWorkingHoursResponse]
+// @Failure 400,500 {object} Response[any]
+// @Router /contracts/{id}/working_hours [post]
+func (server *Server) CreateContractWorkingHoursApi(ctx *gin.Context) {
+	contractID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req contract.CreateContractWorkingHoursRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	workingHours, err := server.businessService.ContractService.CreateContractWorkingHours(ctx, req, contractID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, SuccessResponse(workingHours, "Working hours registered successfully"))
+}
+
+// ListContractWorkingHoursApi lists the working hours registered on a contract
+// @Summary List working hours of a contract
+// @Tags contracts
+// @Produce json
+// @Param id path int true "Contract ID"
+// @Param page query int false "Page number"
+// @Param page_size query int false "Page size"
+// @Success 200 {object} Response[pagination.Response[contract.ContractWorkingHoursResponse]]
+// @Failure 400,500 {object} Response[any]
+// @Router /contracts/{id}/working_hours [get]
+func (server *Server) ListContractWorkingHoursApi(ctx *gin.Context) {
+	contractID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req pagination.Request
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	workingHours, err := server.businessService.ContractService.ListContractWorkingHours(ctx, req, contractID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, SuccessResponse(workingHours, "Working hours retrieved successfully"))
+}
+
+// DeleteContractWorkingHoursApi removes registered working hours from a contract
+// @Summary Delete working hours of a contract
+// @Tags contracts
+// @Produce json
+// @Param id path int true "Contract ID"
+// @Param working_hours_id path int true "Working hours ID"
+// @Success 200 {object} Response[any]
+// @Failure 400,404,500 {object} Response[any]
+// @Router /contracts/{id}/working_hours/{working_hours_id} [delete]
+func (server *Server) DeleteContractWorkingHoursApi(ctx *gin.Context) {
+	contractID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	workingHoursID, err := strconv.ParseInt(ctx.Param("working_hours_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	err = server.businessService.ContractService.DeleteContractWorkingHours(ctx, contractID, workingHoursID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(fmt.Errorf("working hours not found")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, SuccessResponse[any](nil, "Working hours deleted successfully"))
+}
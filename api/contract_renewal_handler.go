@@ -0,0 +1,90 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"maicare_go/service/contract"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+// contractRenewalError maps the errors of contract renewals to a status code
+func contractRenewalError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		ctx.JSON(http.StatusNotFound, errorResponse(fmt.Errorf("contract not found")))
+	case errors.Is(err, contract.ErrContractAlreadyRenewed):
+		ctx.JSON(http.StatusConflict, errorResponse(err))
+	case errors.Is(err, contract.ErrContractNotRenewable), errors.Is(err, contract.ErrInvalidRenewalPeriod):
+		ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	}
+}
+
+// RenewContractApi creates a draft renewal of a contract
+// @Summary Renew a contract
+// @Description Creates a draft copy of an approved or expired contract for a new period with an indexed price, linked as its successor. The contract is closed when the draft is approved.
+// @Tags contracts
+// @Accept json
+// @Produce json
+// @Param id path int true "Contract ID"
+// @Param request body contract.RenewContractRequest true "Renewal"
+// @Success 201 {object} Response[contract.RenewContractResponse]
+// @Failure 400,404,409,422,500 {object} Response[any]
+// @Router /contracts/{id}/renewal [post]
+func (server *Server) RenewContractApi(ctx *gin.Context) {
+	contractID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req contract.RenewContractRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	renewal, err := server.businessService.ContractService.RenewContract(ctx, req, contractID, payload.EmployeeID)
+	if err != nil {
+		contractRenewalError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, SuccessResponse(renewal, "Contract renewal created successfully"))
+}
+
+// ListContractVersionsApi returns the renewal chain of a contract
+// @Summary List the versions of a contract
+// @Description Returns every contract in the renewal chain of the contract, from the first contract to the latest renewal
+// @Tags contracts
+// @Produce json
+// @Param id path int true "Contract ID"
+// @Success 200 {object} Response[[]contract.ContractVersionResponse]
+// @Failure 400,500 {object} Response[any]
+// @Router /contracts/{id}/versions [get]
+func (server *Server) ListContractVersionsApi(ctx *gin.Context) {
+	contractID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	versions, err := server.businessService.ContractService.ListContractVersions(ctx, contractID)
+	if err != nil {
+		contractRenewalError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, SuccessResponse(versions, "Contract versions retrieved successfully"))
+}
@@ -19,7 +19,20 @@ func (server *Server) setupContractRoutes(baseRouter *gin.RouterGroup) {
 
 	baseRouter.GET("/contracts", server.AuthMiddleware(), server.RBACMiddleware("CONTRACT.VIEW"), server.ListContractsApi)
 	baseRouter.PUT("/contracts/:id", server.AuthMiddleware(), server.RBACMiddleware("CONTRACT.UPDATE"), server.UpdateContractApi)
+	baseRouter.PUT("/contracts/:id/status", server.AuthMiddleware(), server.RBACMiddleware("CONTRACT.UPDATE"), server.UpdateContractStatusApi)
 
 	baseRouter.GET("/contracts/:id/audit", server.AuthMiddleware(), server.RBACMiddleware("CONTRACT.VIEW"), server.GetContractAuditLogApi)
 
+	baseRouter.GET("/contracts/:id/hours_budget", server.AuthMiddleware(), server.RBACMiddleware("CONTRACT.VIEW"), server.GetContractHoursBudgetApi)
+	baseRouter.POST("/contracts/:id/working_hours", server.AuthMiddleware(), server.RBACMiddleware("CONTRACT.UPDATE"), server.CreateContractWorkingHoursApi)
+	baseRouter.GET("/contracts/:id/working_hours", server.AuthMiddleware(), server.RBACMiddleware("CONTRACT.VIEW"), server.ListContractWorkingHoursApi)
+	baseRouter.DELETE("/contracts/:id/working_hours/:working_hours_id", server.AuthMiddleware(), server.RBACMiddleware("CONTRACT.UPDATE"), server.DeleteContractWorkingHoursApi)
+
+	baseRouter.GET("/contracts/:id/status_periods", server.AuthMiddleware(), server.RBACMiddleware("CONTRACT.VIEW"), server.ListContractStatusPeriodsApi)
+	baseRouter.POST("/contracts/:id/suspensions", server.AuthMiddleware(), server.RBACMiddleware("CONTRACT.UPDATE"), server.CreateContractSuspensionApi)
+	baseRouter.DELETE("/contracts/:id/suspensions/:period_id", server.AuthMiddleware(), server.RBACMiddleware("CONTRACT.UPDATE"), server.DeleteContractSuspensionApi)
+
+	baseRouter.POST("/contracts/:id/renewal", server.AuthMiddleware(), server.RBACMiddleware("CONTRACT.CREATE"), server.RenewContractApi)
+	baseRouter.GET("/contracts/:id/versions", server.AuthMiddleware(), server.RBACMiddleware("CONTRACT.VIEW"), server.ListContractVersionsApi)
+
 }
@@ -0,0 +1,127 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"maicare_go/service/contract"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+// contractStatusError maps the errors of status timeline changes to a status code
+func contractStatusError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		ctx.JSON(http.StatusNotFound, errorResponse(fmt.Errorf("contract not found")))
+	case errors.Is(err, contract.ErrSuspensionNotFound):
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+	case errors.Is(err, contract.ErrInvalidStatusPeriod):
+		ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	}
+}
+
+// ListContractStatusPeriodsApi returns the status timeline of a contract
+// @Summary List the status periods of a contract
+// @Description Returns the status timeline of a contract including suspensions, invoicing and the revenue forecast bill the approved periods
+// @Tags contracts
+// @Produce json
+// @Param id path int true "Contract ID"
+// @Success 200 {object} Response[[]contract.ContractStatusPeriodResponse]
+// @Failure 400,500 {object} Response[any]
+// @Router /contracts/{id}/status_periods [get]
+func (server *Server) ListContractStatusPeriodsApi(ctx *gin.Context) {
+	contractID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	periods, err := server.businessService.ContractService.ListContractStatusPeriods(ctx, contractID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, SuccessResponse(periods, "Contract status periods retrieved successfully"))
+}
+
+// CreateContractSuspensionApi suspends an approved contract
+// @Summary Suspend a contract
+// @Description Suspends an approved contract for a period, for example during a hospital stay. Suspended days are not invoiced.
+// @Tags contracts
+// @Accept json
+// @Produce json
+// @Param id path int true "Contract ID"
+// @Param request body contract.CreateContractSuspensionRequest true "Suspension"
+// @Success 201 {object} Response[[]contract.ContractStatusPeriodResponse]
+// @Failure 400,404,422,500 {object} Response[any]
+// @Router /contracts/{id}/suspensions [post]
+func (server *Server) CreateContractSuspensionApi(ctx *gin.Context) {
+	contractID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req contract.CreateContractSuspensionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	periods, err := server.businessService.ContractService.CreateContractSuspension(ctx, req, contractID, payload.EmployeeID)
+	if err != nil {
+		contractStatusError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, SuccessResponse(periods, "Contract suspended successfully"))
+}
+
+// DeleteContractSuspensionApi removes a suspension from a contract
+// @Summary Remove a suspension of a contract
+// @Description Removes a suspension, the status before it continues over its period
+// @Tags contracts
+// @Produce json
+// @Param id path int true "Contract ID"
+// @Param period_id path int true "Status period ID of the suspension"
+// @Success 200 {object} Response[[]contract.ContractStatusPeriodResponse]
+// @Failure 400,404,500 {object} Response[any]
+// @Router /contracts/{id}/suspensions/{period_id} [delete]
+func (server *Server) DeleteContractSuspensionApi(ctx *gin.Context) {
+	contractID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	periodID, err := strconv.ParseInt(ctx.Param("period_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	periods, err := server.businessService.ContractService.DeleteContractSuspension(ctx, contractID, periodID, payload.EmployeeID)
+	if err != nil {
+		contractStatusError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, SuccessResponse(periods, "Contract suspension removed successfully"))
+}
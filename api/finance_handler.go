@@ -0,0 +1,169 @@
+package api
+
+import (
+	"fmt"
+	"maicare_go/service/finance"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// sendCSV writes a CSV export as a file download
+func sendCSV(ctx *gin.Context, name string, data []byte) {
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("%s_%s.csv", name, time.Now().Format("20060102"))))
+	ctx.Data(http.StatusOK, "text/csv; charset=utf-8", data)
+}
+
+// ReceivablesAgingApi returns the open invoice balances in aging buckets
+// @Summary Receivables aging report
+// @Description Open balances per sender or client in 0-30, 31-60, 61-90 and 90+ days past the due date
+// @Tags Finance
+// @Produce json,text/csv
+// @Param as_of query string false "Reference date (YYYY-MM-DD), defaults to today"
+// @Param group_by query string false "Group by" Enums(sender, client)
+// @Param format query string false "Response format" Enums(json, csv)
+// @Success 200 {object} Response[finance.ReceivablesAgingResponse]
+// @Failure 400,401,500 {object} Response[any]
+// @Router /finance/receivables_aging [get]
+func (server *Server) ReceivablesAgingApi(ctx *gin.Context) {
+	var req finance.ReceivablesAgingRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	report, err := server.businessService.FinanceService.ReceivablesAging(ctx, req)
+	if err != nil {
+		server.logBusinessEvent(LogLevelError, "ReceivablesAgingApi", "Failed to get receivables aging", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(fmt.Errorf("failed to get receivables aging")))
+		return
+	}
+
+	if req.Format == finance.FormatCSV {
+		data, err := report.CSV()
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		sendCSV(ctx, "receivables_aging", data)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, SuccessResponse(report, "Receivables aging retrieved successfully"))
+}
+
+// FinanceSummaryApi returns the finance totals and DSO of a period
+// @Summary Finance summary
+// @Description Invoiced, received and credited amounts of a period, the open balance at its end and the days sales outstanding
+// @Tags Finance
+// @Produce json,text/csv
+// @Param start_date query string true "Start date (YYYY-MM-DD)"
+// @Param end_date query string true "End date (YYYY-MM-DD)"
+// @Param format query string false "Response format" Enums(json, csv)
+// @Success 200 {object} Response[finance.FinanceSummaryResponse]
+// @Failure 400,401,500 {object} Response[any]
+// @Router /finance/summary [get]
+func (server *Server) FinanceSummaryApi(ctx *gin.Context) {
+	req, ok := bindFinancePeriod(ctx)
+	if !ok {
+		return
+	}
+
+	summary, err := server.businessService.FinanceService.FinanceSummary(ctx, req)
+	if err != nil {
+		server.logBusinessEvent(LogLevelError, "FinanceSummaryApi", "Failed to get finance summary", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(fmt.Errorf("failed to get finance summary")))
+		return
+	}
+
+	if req.Format == finance.FormatCSV {
+		data, err := summary.CSV()
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		sendCSV(ctx, "finance_summary", data)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, SuccessResponse(summary, "Finance summary retrieved successfully"))
+}
+
+// MonthlyInvoicedReceivedApi returns the invoiced and received amounts per month
+// @Summary Monthly invoiced versus received
+// @Description Invoiced, received and credited amounts for every month of the period
+// @Tags Finance
+// @Produce json,text/csv
+// @Param start_date query string true "Start date (YYYY-MM-DD)"
+// @Param end_date query string true "End date (YYYY-MM-DD)"
+// @Param format query string false "Response format" Enums(json, csv)
+// @Success 200 {object} Response[[]finance.MonthlyInvoicedReceivedResponse]
+// @Failure 400,401,500 {object} Response[any]
+// @Router /finance/monthly [get]
+func (server *Server) MonthlyInvoicedReceivedApi(ctx *gin.Context) {
+	req, ok := bindFinancePeriod(ctx)
+	if !ok {
+		return
+	}
+
+	months, err := server.businessService.FinanceService.MonthlyInvoicedReceived(ctx, req)
+	if err != nil {
+		server.logBusinessEvent(LogLevelError, "MonthlyInvoicedReceivedApi", "Failed to get monthly invoiced and received amounts", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(fmt.Errorf("failed to get monthly overview")))
+		return
+	}
+
+	if req.Format == finance.FormatCSV {
+		data, err := finance.MonthlyInvoicedReceivedCSV(months)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		sendCSV(ctx, "invoiced_received", data)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, SuccessResponse(months, "Monthly overview retrieved successfully"))
+}
+
+// RevenueForecastApi projects the revenue of the approved contracts
+// @Summary Revenue forecast
+// @Description Projected revenue excluding VAT for the next 3, 6 and 12 months per location, financing act or sender, both when expiring contracts end and when they are renewed
+// @Tags Finance
+// @Produce json
+// @Param start_date query string false "Start of the forecast (YYYY-MM-DD), defaults to today"
+// @Param group_by query string false "Group by" Enums(location, financing_act, sender)
+// @Success 200 {object} Response[finance.RevenueForecastResponse]
+// @Failure 400,401,500 {object} Response[any]
+// @Router /finance/forecast [get]
+func (server *Server) RevenueForecastApi(ctx *gin.Context) {
+	var req finance.RevenueForecastRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	forecast, err := server.businessService.FinanceService.RevenueForecast(ctx, req)
+	if err != nil {
+		server.logBusinessEvent(LogLevelError, "RevenueForecastApi", "Failed to get revenue forecast", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(fmt.Errorf("failed to get revenue forecast")))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, SuccessResponse(forecast, "Revenue forecast retrieved successfully"))
+}
+
+func bindFinancePeriod(ctx *gin.Context) (finance.FinancePeriodRequest, bool) {
+	var req finance.FinancePeriodRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return req, false
+	}
+	if req.EndDate.Before(req.StartDate) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("end_date must not be before start_date")))
+		return req, false
+	}
+	return req, true
+}
@@ -0,0 +1,26 @@
+package api
+
+import "github.com/gin-gonic/gin"
+
+func (server *Server) setupFinanceRoutes(baseRouter *gin.RouterGroup) {
+	financeGroup := baseRouter.Group("/finance")
+	financeGroup.Use(server.AuthMiddleware())
+	{
+		financeGroup.GET("/receivables_aging", server.RBACMiddleware("FINANCE.VIEW"), server.ReceivablesAgingApi)
+		financeGroup.GET("/summary", server.RBACMiddleware("FINANCE.VIEW"), server.FinanceSummaryApi)
+		financeGroup.GET("/monthly", server.RBACMiddleware("FINANCE.VIEW"), server.MonthlyInvoicedReceivedApi)
+		financeGroup.GET("/forecast", server.RBACMiddleware("FINANCE.VIEW"), server.RevenueForecastApi)
+		financeGroup.GET("/accounting_exports", server.RBACMiddleware("FINANCE.VIEW"), server.ListAccountingExportsApi)
+		financeGroup.POST("/accounting_exports", server.RBACMiddleware("FINANCE.EXPORT"), server.CreateAccountingExportApi)
+		financeGroup.GET("/accounting_exports/:id/download", server.RBACMiddleware("FINANCE.EXPORT"), server.DownloadAccountingExportApi)
+		financeGroup.POST("/self_billing_statements", server.RBACMiddleware("INVOICE.CREATE"), server.GenerateSelfBillingStatementsApi)
+		financeGroup.GET("/self_billing_statements", server.RBACMiddleware("FINANCE.VIEW"), server.ListSelfBillingStatementsApi)
+		financeGroup.GET("/self_billing_statements/export", server.RBACMiddleware("FINANCE.EXPORT"), server.ExportSelfBillingStatementsApi)
+		financeGroup.GET("/self_billing_statements/:id", server.RBACMiddleware("FINANCE.VIEW"), server.GetSelfBillingStatementApi)
+		financeGroup.GET("/self_billing_statements/:id/download", server.RBACMiddleware("FINANCE.VIEW"), server.DownloadSelfBillingStatementApi)
+		financeGroup.POST("/self_billing_statements/:id/approve", server.RBACMiddleware("INVOICE.APPROVE"), server.ApproveSelfBillingStatementApi)
+		financeGroup.POST("/self_billing_statements/:id/reject", server.RBACMiddleware("INVOICE.APPROVE"), server.RejectSelfBillingStatementApi)
+		financeGroup.GET("/payroll", server.RBACMiddleware("FINANCE.VIEW"), server.GetPayrollApi)
+		financeGroup.GET("/payroll/export", server.RBACMiddleware("FINANCE.EXPORT"), server.ExportPayrollApi)
+	}
+}
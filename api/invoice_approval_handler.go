@@ -0,0 +1,256 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	invserv "maicare_go/service/invoice"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+// invoiceApprovalError maps the errors of the approval steps to a status code
+func invoiceApprovalError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		ctx.JSON(http.StatusNotFound, errorResponse(fmt.Errorf("invoice not found")))
+	case errors.Is(err, invserv.ErrFourEyesViolation):
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+	case errors.Is(err, invserv.ErrInvalidStatusTransition):
+		ctx.JSON(http.StatusConflict, errorResponse(err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	}
+}
+
+// SubmitInvoiceApi submits a concept invoice for approval
+// @Summary Submit invoice for approval
+// @Description Moves a concept invoice to pending_approval
+// @Tags Invoice
+// @Produce json
+// @Param id path int64 true "Invoice ID"
+// @Success 200 {object} Response[invserv.InvoiceApprovalResponse]
+// @Failure 400,401,404,409,500 {object} Response[any]
+// @Router /invoices/{id}/submit [post]
+func (server *Server) SubmitInvoiceApi(ctx *gin.Context) {
+	invoiceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid invoice ID: %s", ctx.Param("id"))))
+		return
+	}
+
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	result, err := server.businessService.InvoiceService.SubmitInvoiceForApproval(ctx, invoiceID, payload.EmployeeID)
+	if err != nil {
+		invoiceApprovalError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, SuccessResponse(result, "Invoice submitted for approval"))
+}
+
+// ApproveInvoiceApi approves an invoice that is pending approval
+// @Summary Approve invoice
+// @Description Approves a pending invoice. Invoices at or above the threshold of the organisation need another employee than the one who created or submitted them.
+// @Tags Invoice
+// @Produce json
+// @Param id path int64 true "Invoice ID"
+// @Success 200 {object} Response[invserv.InvoiceApprovalResponse]
+// @Failure 400,401,403,404,409,500 {object} Response[any]
+// @Router /invoices/{id}/approve [post]
+func (server *Server) ApproveInvoiceApi(ctx *gin.Context) {
+	invoiceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid invoice ID: %s", ctx.Param("id"))))
+		return
+	}
+
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	result, err := server.businessService.InvoiceService.ApproveInvoice(ctx, invoiceID, payload.EmployeeID)
+	if err != nil {
+		invoiceApprovalError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, SuccessResponse(result, "Invoice approved successfully"))
+}
+
+// RejectInvoiceApi sends a pending invoice back to concept
+// @Summary Reject invoice
+// @Tags Invoice
+// @Accept json
+// @Produce json
+// @Param id path int64 true "Invoice ID"
+// @Param request body invserv.RejectInvoiceRequest true "Reject Invoice Request"
+// @Success 200 {object} Response[invserv.InvoiceApprovalResponse]
+// @Failure 400,401,404,409,500 {object} Response[any]
+// @Router /invoices/{id}/reject [post]
+func (server *Server) RejectInvoiceApi(ctx *gin.Context) {
+	invoiceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid invoice ID: %s", ctx.Param("id"))))
+		return
+	}
+
+	var req invserv.RejectInvoiceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	result, err := server.businessService.InvoiceService.RejectInvoice(ctx, invoiceID, req, payload.EmployeeID)
+	if err != nil {
+		invoiceApprovalError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, SuccessResponse(result, "Invoice rejected successfully"))
+}
+
+// GetInvoiceApprovalSettingsApi returns the approval threshold of an organisation
+// @Summary Get invoice approval settings
+// @Tags organisations
+// @Produce json
+// @Param id path int64 true "Organisation ID"
+// @Success 200 {object} Response[invserv.InvoiceApprovalSettingsResponse]
+// @Failure 400,401,500 {object} Response[any]
+// @Router /organisations/{id}/invoice_approval [get]
+func (server *Server) GetInvoiceApprovalSettingsApi(ctx *gin.Context) {
+	organisationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	settings, err := server.businessService.InvoiceService.GetInvoiceApprovalSettings(ctx, organisationID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, SuccessResponse(settings, "Invoice approval settings retrieved successfully"))
+}
+
+// UpdateInvoiceApprovalSettingsApi sets the approval threshold of an organisation
+// @Summary Update invoice approval settings
+// @Description Invoices with a total at or above the threshold need to be approved by a second employee, a threshold of 0 applies this to every invoice
+// @Tags organisations
+// @Accept json
+// @Produce json
+// @Param id path int64 true "Organisation ID"
+// @Param request body invserv.UpdateInvoiceApprovalSettingsRequest true "Update Invoice Approval Settings Request"
+// @Success 200 {object} Response[invserv.InvoiceApprovalSettingsResponse]
+// @Failure 400,401,500 {object} Response[any]
+// @Router /organisations/{id}/invoice_approval [put]
+func (server *Server) UpdateInvoiceApprovalSettingsApi(ctx *gin.Context) {
+	organisationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req invserv.UpdateInvoiceApprovalSettingsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	settings, err := server.businessService.InvoiceService.UpdateInvoiceApprovalSettings(ctx, organisationID, req, payload.EmployeeID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, SuccessResponse(settings, "Invoice approval settings updated successfully"))
+}
+
+// GetInvoiceNumberSeriesApi returns the invoice number series of an organisation
+// @Summary Get invoice number series
+// @Tags organisations
+// @Produce json
+// @Param id path int64 true "Organisation ID"
+// @Success 200 {object} Response[invserv.InvoiceNumberSeriesResponse]
+// @Failure 400,401,500 {object} Response[any]
+// @Router /organisations/{id}/invoice_number_series [get]
+func (server *Server) GetInvoiceNumberSeriesApi(ctx *gin.Context) {
+	organisationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	series, err := server.businessService.InvoiceService.GetInvoiceNumberSeries(ctx, organisationID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, SuccessResponse(series, "Invoice number series retrieved successfully"))
+}
+
+// UpdateInvoiceNumberSeriesApi sets the prefix and format of the invoice numbers of an organisation
+// @Summary Update invoice number series
+// @Description The format supports {prefix}, {year} and {seq}. A yearly series restarts at 1 in the first invoice of a new year and needs {year} in the format. The sequence itself can't be changed, so the series stays consecutive.
+// @Tags organisations
+// @Accept json
+// @Produce json
+// @Param id path int64 true "Organisation ID"
+// @Param request body invserv.UpdateInvoiceNumberSeriesRequest true "Update Invoice Number Series Request"
+// @Success 200 {object} Response[invserv.InvoiceNumberSeriesResponse]
+// @Failure 400,401,500 {object} Response[any]
+// @Router /organisations/{id}/invoice_number_series [put]
+func (server *Server) UpdateInvoiceNumberSeriesApi(ctx *gin.Context) {
+	organisationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req invserv.UpdateInvoiceNumberSeriesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	series, err := server.businessService.InvoiceService.UpdateInvoiceNumberSeries(ctx, organisationID, req, payload.EmployeeID)
+	if err != nil {
+		if errors.Is(err, invserv.ErrInvalidNumberFormat) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, SuccessResponse(series, "Invoice number series updated successfully"))
+}
@@ -0,0 +1,143 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	invserv "maicare_go/service/invoice"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// SendInvoiceApi emails an approved invoice to its sender
+// @Summary Send invoice
+// @Description Emails the invoice with its PDF and UBL attachments to the sender. The first successful delivery makes an approved invoice outstanding.
+// @Tags Invoice
+// @Produce json
+// @Param id path int64 true "Invoice ID"
+// @Success 200 {object} Response[invserv.InvoiceDeliveryResponse]
+// @Failure 400,401,404,409,422,500 {object} Response[any]
+// @Router /invoices/{id}/send [post]
+func (server *Server) SendInvoiceApi(ctx *gin.Context) {
+	invoiceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid invoice ID: %s", ctx.Param("id"))))
+		return
+	}
+
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	delivery, err := server.businessService.InvoiceService.SendInvoice(ctx, invoiceID, payload.EmployeeID)
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			ctx.JSON(http.StatusNotFound, errorResponse(fmt.Errorf("invoice not found")))
+		case errors.Is(err, invserv.ErrInvoiceNotApproved):
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+		case errors.Is(err, invserv.ErrNoInvoiceRecipients):
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(fmt.Errorf("failed to send invoice: %w", err)))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, SuccessResponse(delivery, "Invoice sent successfully"))
+}
+
+// ListInvoiceDeliveriesApi lists the send attempts of an invoice
+// @Summary List invoice deliveries
+// @Description Lists every attempt to email the invoice, including reminders
+// @Tags Invoice
+// @Produce json
+// @Param id path int64 true "Invoice ID"
+// @Success 200 {object} Response[[]invserv.InvoiceDeliveryResponse]
+// @Failure 400,401,500 {object} Response[any]
+// @Router /invoices/{id}/deliveries [get]
+func (server *Server) ListInvoiceDeliveriesApi(ctx *gin.Context) {
+	invoiceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid invoice ID: %s", ctx.Param("id"))))
+		return
+	}
+
+	deliveries, err := server.businessService.InvoiceService.ListInvoiceDeliveries(ctx, invoiceID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, SuccessResponse(deliveries, "Invoice deliveries retrieved successfully"))
+}
+
+// SendInvoiceBatchApi queues the invoices of an invoice run for sending
+// @Summary Send invoice batch
+// @Description Queues the given invoices, or all approved and unsent invoices issued between start_date and end_date. Invoices that cannot be sent are reported as skipped.
+// @Tags Invoice
+// @Accept json
+// @Produce json
+// @Param request body invserv.SendInvoiceBatchRequest true "Batch"
+// @Success 202 {object} Response[invserv.SendInvoiceBatchResponse]
+// @Failure 400,401,500 {object} Response[any]
+// @Router /invoices/send_batch [post]
+func (server *Server) SendInvoiceBatchApi(ctx *gin.Context) {
+	var req invserv.SendInvoiceBatchRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if len(req.InvoiceIDs) == 0 && req.EndDate.Before(req.StartDate) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("end_date must not be before start_date")))
+		return
+	}
+
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	result, err := server.businessService.InvoiceService.SendInvoiceBatch(ctx, req, payload.EmployeeID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, SuccessResponse(result, "Invoice batch queued successfully"))
+}
+
+// GetInvoiceDeliveryBatchApi reports the progress of a batch
+// @Summary Get invoice delivery batch
+// @Description Returns the deliveries of a batch with the number of queued, sent and failed invoices
+// @Tags Invoice
+// @Produce json
+// @Param batch_id path string true "Batch ID"
+// @Success 200 {object} Response[invserv.InvoiceDeliveryBatchResponse]
+// @Failure 400,401,404,500 {object} Response[any]
+// @Router /invoices/deliveries/batches/{batch_id} [get]
+func (server *Server) GetInvoiceDeliveryBatchApi(ctx *gin.Context) {
+	batchID, err := uuid.Parse(ctx.Param("batch_id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid batch ID: %s", ctx.Param("batch_id"))))
+		return
+	}
+
+	batch, err := server.businessService.InvoiceService.GetInvoiceDeliveryBatch(ctx, batchID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if batch.Total == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(fmt.Errorf("batch not found")))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, SuccessResponse(batch, "Invoice batch retrieved successfully"))
+}
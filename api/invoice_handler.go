@@ -2,7 +2,9 @@ package api
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	db "maicare_go/db/sqlc"
 	"maicare_go/invoice"
@@ -17,6 +19,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/goccy/go-json"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"go.uber.org/zap"
 )
@@ -101,7 +104,7 @@ func (server *Server) CreateInvoiceApi(ctx *gin.Context) {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
-	invoiceNumber, invoiceSequence, err := invoice.GenerateInvoiceNumber(ctx, time.Now(), server.store)
+	invoiceNumber, invoiceSequence, err := invserv.AllocateInvoiceNumber(ctx, qtx, req.ClientID, req.IssueDate)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
@@ -224,18 +227,16 @@ func (server *Server) GenerateInvoiceApi(ctx *gin.Context) {
 
 }
 
-// CreditInvoiceResponse represents the response body for crediting an invoice.
-type CreditInvoiceResponse struct {
-	ID int64 `json:"id"`
-}
-
 // @Summary Credit Invoice
-// @Description Create a credit note for an existing invoice.
+// @Description Create a credit note for an existing invoice. Without a body everything that was not credited yet is credited,
+// @Description otherwise the selected contract lines, accommodation periods or amount. The open balance and status of the invoice are recalculated.
 // @Tags Invoice
+// @Accept json
 // @Produce json
 // @Param id path int64 true "Invoice ID"
-// @Success 200 {object} Response[CreditInvoiceResponse] "Successful response with credit note details"
-// @Failure 400,401,404,500 {object} Response[any]
+// @Param request body invserv.CreditInvoiceRequest false "Lines, periods or amount to credit"
+// @Success 200 {object} Response[invserv.CreditInvoiceResponse] "Successful response with credit note details"
+// @Failure 400,401,404,409,422,500 {object} Response[any]
 // @Router /invoices/{id}/credit [post]
 func (server *Server) CreditInvoiceApi(ctx *gin.Context) {
 	invoiceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
@@ -243,110 +244,44 @@ func (server *Server) CreditInvoiceApi(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid invoice ID: %s", ctx.Param("id"))))
 		return
 	}
-	payload, err := GetAuthPayload(ctx)
-	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
-		return
-	}
 
-	tx, err := server.store.ConnPool.Begin(ctx)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	var req invserv.CreditInvoiceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
-	defer tx.Rollback(ctx)
-	qtx := server.store.WithTx(tx)
 
-	_, err = tx.Exec(ctx, fmt.Sprintf("SET LOCAL myapp.current_employee_id = %d", payload.EmployeeID))
+	payload, err := GetAuthPayload(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
 		return
 	}
 
-	originalInvoice, err := qtx.GetInvoice(ctx.Request.Context(), invoiceID)
+	result, err := server.businessService.InvoiceService.CreditInvoice(ctx, invoiceID, req, payload.EmployeeID)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
 			ctx.JSON(http.StatusNotFound, errorResponse(fmt.Errorf("invoice with ID %d not found", invoiceID)))
-			return
-		}
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
-	}
-	// Check if the invoice is already a credit note
-	if originalInvoice.InvoiceType == "credit_note" {
-		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invoice with ID %d is already a credit note", invoiceID)))
-		return
-	}
-	// Get the original invoice details
-	var invoiceDetails []invoice.InvoiceDetails
-	if err := json.Unmarshal(originalInvoice.InvoiceDetails, &invoiceDetails); err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
-	}
-
-	// Create a new credit note invoice
-	creditNoteInvoicedetails := make([]invoice.InvoiceDetails, len(invoiceDetails))
-	for i, detail := range invoiceDetails {
-		creditNoteInvoicedetails[i] = invoice.InvoiceDetails{
-			ContractID:    detail.ContractID,
-			ContractType:  detail.ContractType,
-			Price:         -detail.Price, // Negate the price for credit note
-			PriceTimeUnit: detail.PriceTimeUnit,
-			PreVatTotal:   -detail.PreVatTotal, // Negate the pre-VAT total for credit note
-			Total:         -detail.Total,       // Negate the total for credit note
-			Periods:       detail.Periods,
+		case errors.Is(err, invserv.ErrInvalidCreditRequest):
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		case errors.Is(err, invserv.ErrInvoiceNotApproved), errors.Is(err, invserv.ErrInvalidStatusTransition):
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+		case errors.Is(err, invserv.ErrCreditExceedsInvoice), errors.Is(err, invserv.ErrNothingToCredit):
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		}
-	}
-	invoiceDetailsBytes, err := json.Marshal(creditNoteInvoicedetails)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
-	}
-	invoiceNumber, invoiceSequence, err := invoice.GenerateInvoiceNumber(ctx, time.Now(), server.store)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	creditNoteInvoice, err := server.store.CreateInvoice(ctx.Request.Context(), db.CreateInvoiceParams{
-		ClientID:        originalInvoice.ClientID,
-		SenderID:        &originalInvoice.ID,
-		DueDate:         pgtype.Date{Time: time.Now().Add(30 * 24 * time.Hour), Valid: true},
-		TotalAmount:     -originalInvoice.TotalAmount,
-		InvoiceDetails:  invoiceDetailsBytes,
-		InvoiceNumber:   invoiceNumber,
-		InvoiceSequence: invoiceSequence,
-		InvoiceType:     "credit_note",
-		ExtraContent:    []byte("{}"), // Assuming no extra content for credit notes
-		WarningCount:    0,
-		IssueDate:       pgtype.Date{Time: time.Now(), Valid: true},
-	})
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
-	}
-	// update the original invoice status to 'canceled'
-	_, err = qtx.UpdateInvoiceStatus(ctx.Request.Context(), db.UpdateInvoiceStatusParams{
-		ID:     originalInvoice.ID,
-		Status: "canceled",
-	})
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
-	}
-	// Commit the transaction
-	if err := tx.Commit(ctx); err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
-	}
-	ctx.JSON(http.StatusOK, SuccessResponse(CreditInvoiceResponse{ID: creditNoteInvoice.ID}, "Credit note created successfully"))
+	ctx.JSON(http.StatusOK, SuccessResponse(result, "Credit note created successfully"))
 }
 
 // ListInvoicesRequest represents the request parameters for listing invoices.
 type ListInvoicesRequest struct {
 	ClientID  *int64    `form:"client_id"`
 	SenderID  *int64    `form:"sender_id"`
-	Status    *string   `form:"status" binding:"omitempty,oneof=outstanding partially_paid paid expired overpaid imported concept"`
+	Status    *string   `form:"status" binding:"omitempty,oneof=outstanding partially_paid paid expired overpaid imported concept pending_approval approved canceled"`
 	StartDate time.Time `form:"start_date"`
 	EndDate   time.Time `form:"end_date"`
 	pagination.Request
@@ -555,6 +490,33 @@ func (server *Server) UpdateInvoiceApi(ctx *gin.Context) {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
+
+	// status changes have to follow the invoice state machine, the approval steps
+	// have their own endpoints so they end up in the audit log
+	var status *string
+	if req.Status != "" {
+		currentInvoice, err := qtx.GetInvoice(ctx.Request.Context(), invoiceID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				ctx.JSON(http.StatusNotFound, errorResponse(fmt.Errorf("invoice with ID %d not found", invoiceID)))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		if req.Status != currentInvoice.Status {
+			if req.Status == string(invserv.InvoiceStatusPendingApproval) || req.Status == string(invserv.InvoiceStatusApproved) {
+				ctx.JSON(http.StatusConflict, errorResponse(fmt.Errorf("%w: use the submit and approve endpoints", invserv.ErrInvalidStatusTransition)))
+				return
+			}
+			if err := invserv.ValidateStatusTransition(currentInvoice.Status, req.Status); err != nil {
+				ctx.JSON(http.StatusConflict, errorResponse(err))
+				return
+			}
+		}
+		status = &req.Status
+	}
+
 	invoiceDetailsBytes, err := json.Marshal(req.InvoiceDetails)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
@@ -567,7 +529,7 @@ func (server *Server) UpdateInvoiceApi(ctx *gin.Context) {
 		InvoiceDetails: invoiceDetailsBytes,
 		TotalAmount:    &req.TotalAmount,
 		ExtraContent:   util.ParseObjectToJSON(req.ExtraContent),
-		Status:         &req.Status,
+		Status:         status,
 		WarningCount:   &req.WarningCount,
 	})
 
@@ -756,6 +718,15 @@ func (server *Server) GenerateInvoicePdfApi(ctx *gin.Context) {
 		}
 	}
 
+	var extraItemOrder []string
+	if invoiceData.SenderID != nil && len(extraItems) > 0 {
+		extraItemOrder, err = server.store.GetSenderTemplateLabels(ctx, *invoiceData.SenderID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+
 	arg := pdf.InvoicePDFData{
 		ID:                   invoiceData.ID,
 		SenderName:           util.DerefString(invoiceData.SenderName),
@@ -767,6 +738,7 @@ func (server *Server) GenerateInvoicePdfApi(ctx *gin.Context) {
 		DueDate:              invoiceData.DueDate.Time,
 		InvoiceDetails:       pdfInvoiceDetails,
 		ExtraItems:           extraItems,
+		ExtraItemOrder:       extraItemOrder,
 	}
 
 	fileKey, filesize, err := pdf.GenerateAndUploadInvoicePDF(ctx, arg, server.b2Client)
@@ -799,6 +771,49 @@ func (server *Server) GenerateInvoicePdfApi(ctx *gin.Context) {
 
 }
 
+// DownloadInvoiceApi handles downloading an invoice as PDF or UBL 2.1 (NLCIUS) XML
+// @Summary Download Invoice
+// @Description Download an invoice by its ID as PDF or as UBL 2.1 (SI-UBL / NLCIUS) electronic invoice with the PDF embedded.
+// @Tags Invoice
+// @Produce application/pdf
+// @Produce application/xml
+// @Param id path int64 true "Invoice ID"
+// @Param format query string false "File format: pdf (default) or ubl"
+// @Success 200 {file} file "Invoice document"
+// @Failure 400,401,404,500 {object} Response[any]
+// @Router /invoices/{id}/download [get]
+func (server *Server) DownloadInvoiceApi(ctx *gin.Context) {
+	invoiceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid invoice ID: %s", ctx.Param("id"))))
+		return
+	}
+
+	format := ctx.DefaultQuery("format", invserv.InvoiceFormatPDF)
+	if format != invserv.InvoiceFormatPDF && format != invserv.InvoiceFormatUBL {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid format %q, expected pdf or ubl", format)))
+		return
+	}
+
+	document, err := server.businessService.InvoiceService.ExportInvoice(ctx.Request.Context(), invoiceID, format)
+	if err != nil {
+		var validationErr *invserv.UBLValidationError
+		if errors.As(err, &validationErr) {
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+		if errors.Is(err, invserv.ErrInvoiceNotApproved) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", document.FileName))
+	ctx.Data(http.StatusOK, document.ContentType, document.Content)
+}
+
 // GetInvoiceTemplateItemsResponse represents the response body for getting invoice template items.
 type GetInvoiceTemplateItemsResponse struct {
 	ID           int64  `json:"id"`
@@ -860,6 +875,14 @@ func (server *Server) SendInvoiceReminderApi(ctx *gin.Context) {
 
 	err = server.businessService.InvoiceService.SendInvoiceReminder(ctx, invoiceID)
 	if err != nil {
+		if errors.Is(err, invserv.ErrInvoiceNotApproved) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		if errors.Is(err, invserv.ErrNoInvoiceRecipients) {
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(fmt.Errorf("failed to send invoice reminder: %w", err)))
 		return
 	}
@@ -1003,6 +1026,10 @@ func (server *Server) CreatePaymentApi(ctx *gin.Context) {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
+	if !invserv.IsApproved(getInvoice.Status) {
+		ctx.JSON(http.StatusConflict, errorResponse(invserv.ErrInvoiceNotApproved))
+		return
+	}
 
 	payment, err := qtx.CreatePayment(ctx, db.CreatePaymentParams{
 		InvoiceID:        invoiceID,
@@ -1030,13 +1057,24 @@ func (server *Server) CreatePaymentApi(ctx *gin.Context) {
 			return
 		}
 
-		newStatus, err := invoice.DetermineInvoiceStatus(getInvoice.TotalAmount, totalPaid)
+		// Credit notes lower what is left to pay on the invoice
+		creditedAmount, err := qtx.GetInvoiceCreditedAmount(ctx.Request.Context(), &invoiceID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		newStatus, err := invoice.DetermineInvoiceStatus(getInvoice.TotalAmount-creditedAmount, totalPaid)
 		if err != nil {
 			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 			return
 		}
 
 		if string(newStatus) != getInvoice.Status {
+			if err := invserv.ValidateStatusTransition(getInvoice.Status, string(newStatus)); err != nil {
+				ctx.JSON(http.StatusConflict, errorResponse(err))
+				return
+			}
 			updatedInvoice, err := qtx.UpdateInvoice(ctx, db.UpdateInvoiceParams{
 				ID:     invoiceID,
 				Status: util.StringPtr(string(newStatus)),
@@ -1330,13 +1368,24 @@ func (server *Server) UpdatePaymentApi(ctx *gin.Context) {
 			return
 		}
 
-		newStatus, err := invoice.DetermineInvoiceStatus(currentPayment.InvoiceTotalAmount, totalPaid)
+		// Credit notes lower what is left to pay on the invoice
+		creditedAmount, err := qtx.GetInvoiceCreditedAmount(ctx.Request.Context(), &invoiceID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		newStatus, err := invoice.DetermineInvoiceStatus(currentPayment.InvoiceTotalAmount-creditedAmount, totalPaid)
 		if err != nil {
 			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 			return
 		}
 
 		if string(newStatus) != originalInvoiceStatus {
+			if err := invserv.ValidateStatusTransition(originalInvoiceStatus, string(newStatus)); err != nil {
+				ctx.JSON(http.StatusConflict, errorResponse(err))
+				return
+			}
 			updatedInvoice, err := qtx.UpdateInvoice(ctx, db.UpdateInvoiceParams{
 				ID:     invoiceID,
 				Status: util.StringPtr(string(newStatus)),
@@ -1474,13 +1523,24 @@ func (server *Server) DeletePaymentApi(ctx *gin.Context) {
 			return
 		}
 
-		newStatus, err := invoice.DetermineInvoiceStatus(paymentToDelete.InvoiceTotalAmount, totalPaid)
+		// Credit notes lower what is left to pay on the invoice
+		creditedAmount, err := qtx.GetInvoiceCreditedAmount(ctx.Request.Context(), &invoiceID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		newStatus, err := invoice.DetermineInvoiceStatus(paymentToDelete.InvoiceTotalAmount-creditedAmount, totalPaid)
 		if err != nil {
 			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 			return
 		}
 
 		if string(newStatus) != originalInvoiceStatus {
+			if err := invserv.ValidateStatusTransition(originalInvoiceStatus, string(newStatus)); err != nil {
+				ctx.JSON(http.StatusConflict, errorResponse(err))
+				return
+			}
 			updatedInvoice, err := qtx.UpdateInvoice(ctx, db.UpdateInvoiceParams{
 				ID:     invoiceID,
 				Status: util.StringPtr(string(newStatus)),
@@ -8,12 +8,20 @@ func (server *Server) setupInvoiceRoutes(baseRouter *gin.RouterGroup) {
 	{
 		invoiceGroup.POST("", server.RBACMiddleware("INVOICE.CREATE"), server.CreateInvoiceApi)
 		invoiceGroup.POST("/generate", server.RBACMiddleware("INVOICE.CREATE"), server.GenerateInvoiceApi)
+		invoiceGroup.POST("/send_batch", server.RBACMiddleware("INVOICE.CREATE"), server.SendInvoiceBatchApi)
+		invoiceGroup.GET("/deliveries/batches/:batch_id", server.RBACMiddleware("INVOICE.VIEW"), server.GetInvoiceDeliveryBatchApi)
 		invoiceGroup.GET("", server.RBACMiddleware("INVOICE.VIEW"), server.ListInvoicesApi)
 		invoiceGroup.GET("/:id", server.RBACMiddleware("INVOICE.VIEW"), server.GetInvoiceByIDApi)
 		invoiceGroup.PUT("/:id", server.RBACMiddleware("INVOICE.UPDATE"), server.UpdateInvoiceApi)
 		invoiceGroup.DELETE("/:id", server.RBACMiddleware("INVOICE.DELETE"), server.DeleteInvoiceApi)
 		invoiceGroup.POST("/:id/credit", server.RBACMiddleware("INVOICE.UPDATE"), server.CreditInvoiceApi)
+		invoiceGroup.POST("/:id/submit", server.RBACMiddleware("INVOICE.UPDATE"), server.SubmitInvoiceApi)
+		invoiceGroup.POST("/:id/approve", server.RBACMiddleware("INVOICE.APPROVE"), server.ApproveInvoiceApi)
+		invoiceGroup.POST("/:id/reject", server.RBACMiddleware("INVOICE.APPROVE"), server.RejectInvoiceApi)
 		invoiceGroup.GET("/:id/generate_pdf", server.RBACMiddleware("INVOICE.VIEW"), server.GenerateInvoicePdfApi)
+		invoiceGroup.GET("/:id/download", server.RBACMiddleware("INVOICE.VIEW"), server.DownloadInvoiceApi)
+		invoiceGroup.POST("/:id/send", server.RBACMiddleware("INVOICE.CREATE"), server.SendInvoiceApi)
+		invoiceGroup.GET("/:id/deliveries", server.RBACMiddleware("INVOICE.VIEW"), server.ListInvoiceDeliveriesApi)
 		invoiceGroup.POST("/:id/send_reminder", server.RBACMiddleware("INVOICE.CREATE"), server.SendInvoiceReminderApi)
 
 		invoiceGroup.POST("/:id/payments", server.RBACMiddleware("INVOICE.PAYMENT.CREATE"), server.CreatePaymentApi)
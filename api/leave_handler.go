@@ -0,0 +1,422 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"maicare_go/service/leave"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func leaveError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, leave.ErrLeaveTypeNotFound), errors.Is(err, leave.ErrLeaveRequestNotFound), errors.Is(err, leave.ErrEmployeeNotFound),
+		errors.Is(err, leave.ErrSickReportNotFound), errors.Is(err, leave.ErrUnknownMilestone):
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+	case errors.Is(err, leave.ErrNotOwnLeaveRequest), errors.Is(err, leave.ErrOwnLeaveRequest):
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+	case errors.Is(err, leave.ErrLeaveTypeExists), errors.Is(err, leave.ErrLeaveOverlap), errors.Is(err, leave.ErrInsufficientLeaveBalance),
+		errors.Is(err, leave.ErrLeaveRequestClosed), errors.Is(err, leave.ErrSickReportOpen), errors.Is(err, leave.ErrSickReportClosed):
+		ctx.JSON(http.StatusConflict, errorResponse(err))
+	case errors.Is(err, leave.ErrInvalidLeaveRequest), errors.Is(err, leave.ErrInvalidSickReport):
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	}
+}
+
+// ListLeaveTypesApi lists the leave types
+// @Summary List leave types
+// @Description List the kinds of leave, also the inactive ones that can no longer be requested
+// @Tags Leave
+// @Produce json
+// @Success 200 {object} Response[[]leave.LeaveTypeResponse]
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /leave_types [get]
+func (server *Server) ListLeaveTypesApi(ctx *gin.Context) {
+	types, err := server.businessService.LeaveService.ListLeaveTypes(ctx)
+	if err != nil {
+		leaveError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(types, "Leave types retrieved successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// CreateLeaveTypeApi creates a leave type
+// @Summary Create a leave type
+// @Description Add a kind of leave. Leave of a type that deducts from the balance is taken from the yearly leave hours of the employee.
+// @Tags Leave
+// @Accept json
+// @Produce json
+// @Param request body leave.CreateLeaveTypeRequest true "Leave type"
+// @Success 201 {object} Response[leave.LeaveTypeResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 409 {object} Response[any] "A leave type with the code exists"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /leave_types [post]
+func (server *Server) CreateLeaveTypeApi(ctx *gin.Context) {
+	var req leave.CreateLeaveTypeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	leaveType, err := server.businessService.LeaveService.CreateLeaveType(ctx, &req)
+	if err != nil {
+		leaveError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(leaveType, "Leave type created successfully")
+	ctx.JSON(http.StatusCreated, res)
+}
+
+// UpdateLeaveTypeApi updates a leave type
+// @Summary Update a leave type
+// @Description Change a kind of leave. Inactive leave types can no longer be requested.
+// @Tags Leave
+// @Accept json
+// @Produce json
+// @Param id path int true "Leave type ID"
+// @Param request body leave.UpdateLeaveTypeRequest true "Leave type"
+// @Success 200 {object} Response[leave.LeaveTypeResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 404 {object} Response[any] "Leave type not found"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /leave_types/{id} [put]
+func (server *Server) UpdateLeaveTypeApi(ctx *gin.Context) {
+	leaveTypeID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid leave type ID")))
+		return
+	}
+	var req leave.UpdateLeaveTypeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	leaveType, err := server.businessService.LeaveService.UpdateLeaveType(ctx, leaveTypeID, &req)
+	if err != nil {
+		leaveError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(leaveType, "Leave type updated successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// CreateLeaveRequestApi requests leave for the logged in employee
+// @Summary Request leave
+// @Description Request leave from the start date up to and including the end date, within one year. The leave takes the contract hours spread over Monday to Friday, hours can only be given for part of a single day. Leave that deducts from the balance cannot take more hours than are available.
+// @Tags Leave
+// @Accept json
+// @Produce json
+// @Param request body leave.CreateLeaveRequestRequest true "Leave request"
+// @Success 201 {object} Response[leave.LeaveRequestResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 409 {object} Response[any] "Overlapping leave or not enough leave hours"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /leave_requests [post]
+func (server *Server) CreateLeaveRequestApi(ctx *gin.Context) {
+	var req leave.CreateLeaveRequestRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(fmt.Errorf("failed to get auth payload")))
+		return
+	}
+
+	request, err := server.businessService.LeaveService.CreateLeaveRequest(ctx, payload.EmployeeID, &req)
+	if err != nil {
+		leaveError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(request, "Leave requested successfully")
+	ctx.JSON(http.StatusCreated, res)
+}
+
+// ListMyLeaveRequestsApi lists the leave requests of the logged in employee
+// @Summary List my leave requests
+// @Description List the leave requests of the logged in employee that overlap the period, all of them without dates
+// @Tags Leave
+// @Produce json
+// @Param status query string false "Status" Enums(pending, approved, rejected, cancelled)
+// @Param start_date query string false "First day (YYYY-MM-DD)"
+// @Param end_date query string false "Last day (YYYY-MM-DD)"
+// @Success 200 {object} Response[[]leave.LeaveRequestResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /leave_requests/me [get]
+func (server *Server) ListMyLeaveRequestsApi(ctx *gin.Context) {
+	var req leave.ListLeaveRequestsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(fmt.Errorf("failed to get auth payload")))
+		return
+	}
+	req.EmployeeID = &payload.EmployeeID
+	req.LocationID = nil
+
+	requests, err := server.businessService.LeaveService.ListLeaveRequests(ctx, &req)
+	if err != nil {
+		leaveError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(requests, "Leave requests retrieved successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// ListLeaveRequestsApi lists the leave requests of the employees
+// @Summary List leave requests
+// @Description List the leave requests that overlap the period, all of them without dates
+// @Tags Leave
+// @Produce json
+// @Param employee_id query int false "Only this employee"
+// @Param location_id query int false "Only the employees of the location"
+// @Param status query string false "Status" Enums(pending, approved, rejected, cancelled)
+// @Param start_date query string false "First day (YYYY-MM-DD)"
+// @Param end_date query string false "Last day (YYYY-MM-DD)"
+// @Success 200 {object} Response[[]leave.LeaveRequestResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /leave_requests [get]
+func (server *Server) ListLeaveRequestsApi(ctx *gin.Context) {
+	var req leave.ListLeaveRequestsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	requests, err := server.businessService.LeaveService.ListLeaveRequests(ctx, &req)
+	if err != nil {
+		leaveError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(requests, "Leave requests retrieved successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// CancelLeaveRequestApi cancels a leave request of the logged in employee
+// @Summary Cancel my leave request
+// @Description Withdraw one of your own leave requests. Approved leave can be cancelled until it starts.
+// @Tags Leave
+// @Produce json
+// @Param id path int true "Leave request ID"
+// @Success 200 {object} Response[leave.LeaveRequestResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 403 {object} Response[any] "Not your leave request"
+// @Failure 404 {object} Response[any] "Leave request not found"
+// @Failure 409 {object} Response[any] "The leave request can no longer be cancelled"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /leave_requests/{id}/cancel [post]
+func (server *Server) CancelLeaveRequestApi(ctx *gin.Context) {
+	leaveRequestID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid leave request ID")))
+		return
+	}
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(fmt.Errorf("failed to get auth payload")))
+		return
+	}
+
+	request, err := server.businessService.LeaveService.CancelLeaveRequest(ctx, leaveRequestID, payload.EmployeeID)
+	if err != nil {
+		leaveError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(request, "Leave request cancelled successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// reviewLeaveRequest handles the approval and rejection of a leave request
+func (server *Server) reviewLeaveRequest(ctx *gin.Context, message string, review func(leaveRequestID, employeeID int64, req *leave.ReviewLeaveRequestRequest) (*leave.LeaveRequestResponse, error)) {
+	leaveRequestID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid leave request ID")))
+		return
+	}
+	var req leave.ReviewLeaveRequestRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(fmt.Errorf("failed to get auth payload")))
+		return
+	}
+
+	request, err := review(leaveRequestID, payload.EmployeeID, &req)
+	if err != nil {
+		leaveError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(request, message)
+	ctx.JSON(http.StatusOK, res)
+}
+
+// ApproveLeaveRequestApi approves a leave request
+// @Summary Approve a leave request
+// @Description Approve a pending leave request of another employee. The shifts the employee is scheduled for during the leave are returned as affected_shifts and the planners of their locations are notified that they need a replacement.
+// @Tags Leave
+// @Accept json
+// @Produce json
+// @Param id path int true "Leave request ID"
+// @Param request body leave.ReviewLeaveRequestRequest false "Note"
+// @Success 200 {object} Response[leave.LeaveRequestResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 403 {object} Response[any] "Own leave request"
+// @Failure 404 {object} Response[any] "Leave request not found"
+// @Failure 409 {object} Response[any] "Not pending or not enough leave hours"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /leave_requests/{id}/approve [post]
+func (server *Server) ApproveLeaveRequestApi(ctx *gin.Context) {
+	server.reviewLeaveRequest(ctx, "Leave request approved successfully", func(leaveRequestID, employeeID int64, req *leave.ReviewLeaveRequestRequest) (*leave.LeaveRequestResponse, error) {
+		return server.businessService.LeaveService.ApproveLeaveRequest(ctx, leaveRequestID, employeeID, req)
+	})
+}
+
+// RejectLeaveRequestApi rejects a leave request
+// @Summary Reject a leave request
+// @Description Reject a pending leave request of another employee
+// @Tags Leave
+// @Accept json
+// @Produce json
+// @Param id path int true "Leave request ID"
+// @Param request body leave.ReviewLeaveRequestRequest false "Note"
+// @Success 200 {object} Response[leave.LeaveRequestResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 403 {object} Response[any] "Own leave request"
+// @Failure 404 {object} Response[any] "Leave request not found"
+// @Failure 409 {object} Response[any] "The leave request is not pending"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /leave_requests/{id}/reject [post]
+func (server *Server) RejectLeaveRequestApi(ctx *gin.Context) {
+	server.reviewLeaveRequest(ctx, "Leave request rejected successfully", func(leaveRequestID, employeeID int64, req *leave.ReviewLeaveRequestRequest) (*leave.LeaveRequestResponse, error) {
+		return server.businessService.LeaveService.RejectLeaveRequest(ctx, leaveRequestID, employeeID, req)
+	})
+}
+
+// leaveBalance returns the leave balance of an employee in the requested year
+func (server *Server) leaveBalance(ctx *gin.Context, employeeID int64) {
+	var req leave.LeaveBalanceRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	year := int32(time.Now().Year())
+	if req.Year != nil {
+		year = *req.Year
+	}
+
+	balance, err := server.businessService.LeaveService.GetLeaveBalance(ctx, employeeID, year)
+	if err != nil {
+		leaveError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(balance, "Leave balance retrieved successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// GetMyLeaveBalanceApi returns the leave balance of the logged in employee
+// @Summary Get my leave balance
+// @Description Get the leave hours of the logged in employee in a year: four times the weekly contract hours in proportion to the part of the year the contract runs, plus adjustments, minus approved and pending leave
+// @Tags Leave
+// @Produce json
+// @Param year query int false "Year, the current year when not given"
+// @Success 200 {object} Response[leave.LeaveBalanceResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /leave_balance [get]
+func (server *Server) GetMyLeaveBalanceApi(ctx *gin.Context) {
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(fmt.Errorf("failed to get auth payload")))
+		return
+	}
+	server.leaveBalance(ctx, payload.EmployeeID)
+}
+
+// GetLeaveBalanceApi returns the leave balance of an employee
+// @Summary Get the leave balance of an employee
+// @Description Get the leave hours of an employee in a year: four times the weekly contract hours in proportion to the part of the year the contract runs, plus adjustments, minus approved and pending leave
+// @Tags Leave
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Param year query int false "Year, the current year when not given"
+// @Success 200 {object} Response[leave.LeaveBalanceResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 404 {object} Response[any] "Employee not found"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /employees/{id}/leave_balance [get]
+func (server *Server) GetLeaveBalanceApi(ctx *gin.Context) {
+	employeeID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid employee ID")))
+		return
+	}
+	server.leaveBalance(ctx, employeeID)
+}
+
+// AdjustLeaveBalanceApi corrects the leave balance of an employee
+// @Summary Adjust the leave balance of an employee
+// @Description Add or remove leave hours of a year, such as hours carried over from the year before
+// @Tags Leave
+// @Accept json
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Param request body leave.LeaveBalanceAdjustmentRequest true "Adjustment"
+// @Success 201 {object} Response[leave.LeaveBalanceResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 404 {object} Response[any] "Employee not found"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /employees/{id}/leave_balance/adjustments [post]
+func (server *Server) AdjustLeaveBalanceApi(ctx *gin.Context) {
+	employeeID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid employee ID")))
+		return
+	}
+	var req leave.LeaveBalanceAdjustmentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(fmt.Errorf("failed to get auth payload")))
+		return
+	}
+
+	balance, err := server.businessService.LeaveService.AdjustLeaveBalance(ctx, employeeID, payload.EmployeeID, &req)
+	if err != nil {
+		leaveError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(balance, "Leave balance adjusted successfully")
+	ctx.JSON(http.StatusCreated, res)
+}
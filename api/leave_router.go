@@ -0,0 +1,31 @@
+package api
+
+import "github.com/gin-gonic/gin"
+
+func (server *Server) setupLeaveRoutes(baseRouter *gin.RouterGroup) {
+	leave := baseRouter.Group("")
+	leave.Use(server.AuthMiddleware())
+	{
+		// every employee requests their own leave
+		leave.GET("/leave_types", server.ListLeaveTypesApi)
+		leave.POST("/leave_requests", server.CreateLeaveRequestApi)
+		leave.GET("/leave_requests/me", server.ListMyLeaveRequestsApi)
+		leave.POST("/leave_requests/:id/cancel", server.CancelLeaveRequestApi)
+		leave.GET("/leave_balance", server.GetMyLeaveBalanceApi)
+
+		leave.POST("/leave_types", server.RBACMiddleware("EMPLOYEE.UPDATE"), server.CreateLeaveTypeApi)
+		leave.PUT("/leave_types/:id", server.RBACMiddleware("EMPLOYEE.UPDATE"), server.UpdateLeaveTypeApi)
+		leave.GET("/leave_requests", server.RBACMiddleware("EMPLOYEE.VIEW"), server.ListLeaveRequestsApi)
+		leave.POST("/leave_requests/:id/approve", server.RBACMiddleware("EMPLOYEE.UPDATE"), server.ApproveLeaveRequestApi)
+		leave.POST("/leave_requests/:id/reject", server.RBACMiddleware("EMPLOYEE.UPDATE"), server.RejectLeaveRequestApi)
+		leave.GET("/employees/:id/leave_balance", server.RBACMiddleware("EMPLOYEE.VIEW"), server.GetLeaveBalanceApi)
+		leave.POST("/employees/:id/leave_balance/adjustments", server.RBACMiddleware("EMPLOYEE.UPDATE"), server.AdjustLeaveBalanceApi)
+
+		leave.POST("/employees/:id/sick_reports", server.RBACMiddleware("EMPLOYEE.UPDATE"), server.CreateSickReportApi)
+		leave.GET("/sick_reports", server.RBACMiddleware("EMPLOYEE.VIEW"), server.ListSickReportsApi)
+		leave.GET("/sick_reports/:id", server.RBACMiddleware("EMPLOYEE.VIEW"), server.GetSickReportApi)
+		leave.PUT("/sick_reports/:id", server.RBACMiddleware("EMPLOYEE.UPDATE"), server.UpdateSickReportApi)
+		leave.POST("/sick_reports/:id/recover", server.RBACMiddleware("EMPLOYEE.UPDATE"), server.RecoverSickReportApi)
+		leave.POST("/sick_reports/:id/milestones/:milestone", server.RBACMiddleware("EMPLOYEE.UPDATE"), server.CompleteSickReportMilestoneApi)
+	}
+}
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"maicare_go/service/leave"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeaveRequestApi(t *testing.T) {
+	employee, user := createRandomEmployee(t)
+
+	leaveRequest := func(recorder *httptest.ResponseRecorder) leave.LeaveRequestResponse {
+		var res Response[leave.LeaveRequestResponse]
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+		return res.Data
+	}
+
+	recorder := sendRequest(t, user.ID, http.MethodGet, "/leave_types", nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var types Response[[]leave.LeaveTypeResponse]
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &types))
+	var special int64
+	for _, leaveType := range types.Data {
+		if leaveType.Code == "special" {
+			special = leaveType.ID
+		}
+	}
+	require.NotZero(t, special)
+
+	year := time.Now().Year() + 1
+	req := leave.CreateLeaveRequestRequest{
+		LeaveTypeID: special,
+		StartDate:   fmt.Sprintf("%d-01-12", year),
+		EndDate:     fmt.Sprintf("%d-01-10", year),
+	}
+	recorder = sendRequest(t, user.ID, http.MethodPost, "/leave_requests", req)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	// hours are only given for part of a single day
+	hours := 4.0
+	req.EndDate = fmt.Sprintf("%d-01-13", year)
+	req.Hours = &hours
+	recorder = sendRequest(t, user.ID, http.MethodPost, "/leave_requests", req)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	noHours := 0.0
+	req.EndDate = req.StartDate
+	req.Hours = &noHours
+	recorder = sendRequest(t, user.ID, http.MethodPost, "/leave_requests", req)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	req.Hours = &hours
+	recorder = sendRequest(t, user.ID, http.MethodPost, "/leave_requests", req)
+	t.Log(recorder.Body.String())
+	require.Equal(t, http.StatusCreated, recorder.Code)
+	created := leaveRequest(recorder)
+	require.Equal(t, employee.ID, created.EmployeeID)
+	require.Equal(t, leave.StatusPending, created.Status)
+	require.Equal(t, 4.0, created.Hours)
+
+	// the same days cannot be requested twice
+	recorder = sendRequest(t, user.ID, http.MethodPost, "/leave_requests", req)
+	require.Equal(t, http.StatusConflict, recorder.Code)
+
+	// employees do not approve their own leave
+	recorder = sendRequest(t, user.ID, http.MethodPost, fmt.Sprintf("/leave_requests/%d/approve", created.ID), nil)
+	require.Equal(t, http.StatusForbidden, recorder.Code)
+
+	recorder = sendRequest(t, 1, http.MethodPost, fmt.Sprintf("/leave_requests/%d/approve", created.ID), leave.ReviewLeaveRequestRequest{})
+	t.Log(recorder.Body.String())
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, leave.StatusApproved, leaveRequest(recorder).Status)
+
+	recorder = sendRequest(t, 1, http.MethodPost, fmt.Sprintf("/leave_requests/%d/reject", created.ID), nil)
+	require.Equal(t, http.StatusConflict, recorder.Code)
+
+	recorder = sendRequest(t, user.ID, http.MethodGet, fmt.Sprintf("/leave_requests/me?start_date=%d-01-01&end_date=%d-01-31", year, year), nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var mine Response[[]leave.LeaveRequestResponse]
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &mine))
+	require.Len(t, mine.Data, 1)
+
+	recorder = sendRequest(t, user.ID, http.MethodPost, fmt.Sprintf("/leave_requests/%d/cancel", created.ID), nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, leave.StatusCancelled, leaveRequest(recorder).Status)
+
+	recorder = sendRequest(t, 1, http.MethodGet, fmt.Sprintf("/employees/%d/leave_balance?year=%d", employee.ID, year), nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestSickReportApi(t *testing.T) {
+	employee, _ := createRandomEmployee(t)
+
+	sickReport := func(recorder *httptest.ResponseRecorder) leave.SickReportResponse {
+		var res Response[leave.SickReportResponse]
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+		return res.Data
+	}
+
+	url := fmt.Sprintf("/employees/%d/sick_reports", employee.ID)
+	startDate := time.Now().AddDate(0, 0, -3).Format("2006-01-02")
+	recorder := sendRequest(t, 1, http.MethodPost, url, leave.CreateSickReportRequest{StartDate: &startDate})
+	t.Log(recorder.Body.String())
+	require.Equal(t, http.StatusCreated, recorder.Code)
+	report := sickReport(recorder)
+	require.True(t, report.Open)
+	require.Len(t, report.Milestones, len(leave.PoortwachterMilestones))
+
+	// one running sick report per employee
+	recorder = sendRequest(t, 1, http.MethodPost, url, nil)
+	require.Equal(t, http.StatusConflict, recorder.Code)
+
+	recorder = sendRequest(t, 1, http.MethodPost, fmt.Sprintf("/sick_reports/%d/milestones/unknown", report.ID), nil)
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+
+	recorder = sendRequest(t, 1, http.MethodPost, fmt.Sprintf("/sick_reports/%d/milestones/occupational_health_report", report.ID), nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, leave.MilestoneCompleted, sickReport(recorder).Milestones[0].Status)
+
+	recorder = sendRequest(t, 1, http.MethodPost, fmt.Sprintf("/sick_reports/%d/recover", report.ID), nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.False(t, sickReport(recorder).Open)
+
+	recorder = sendRequest(t, 1, http.MethodPost, fmt.Sprintf("/sick_reports/%d/recover", report.ID), nil)
+	require.Equal(t, http.StatusConflict, recorder.Code)
+
+	recorder = sendRequest(t, 1, http.MethodGet, fmt.Sprintf("/sick_reports?employee_id=%d", employee.ID), nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var reports Response[[]leave.SickReportResponse]
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &reports))
+	require.Len(t, reports.Data, 1)
+}
@@ -20,6 +20,7 @@ type CreateOrganisationRequest struct {
 	Email      *string `json:"email"`
 	KvkNumber  *string `json:"kvk_number"`
 	BtwNumber  *string `json:"btw_number"`
+	Iban       *string `json:"iban"`
 }
 
 // CreateOrganisationResponse represents a response for CreateOrganisationApi
@@ -32,6 +33,7 @@ type CreateOrganisationResponse struct {
 	Email      *string `json:"email"`
 	KvkNumber  *string `json:"kvk_number"`
 	BtwNumber  *string `json:"btw_number"`
+	Iban       *string `json:"iban"`
 }
 
 // @Summary Create an organisation
@@ -59,6 +61,7 @@ func (server *Server) CreateOrganisationApi(ctx *gin.Context) {
 		Email:      req.Email,
 		KvkNumber:  req.KvkNumber,
 		BtwNumber:  req.BtwNumber,
+		Iban:       req.Iban,
 	})
 	if err != nil {
 		server.logBusinessEvent(LogLevelError, "CreateOrganisationApi", "Failed to create organisation", zap.Error(err))
@@ -75,6 +78,7 @@ func (server *Server) CreateOrganisationApi(ctx *gin.Context) {
 		Email:      organisation.Email,
 		KvkNumber:  organisation.KvkNumber,
 		BtwNumber:  organisation.BtwNumber,
+		Iban:       organisation.Iban,
 	}, "Organisation created successfully")
 	ctx.JSON(http.StatusOK, res)
 }
@@ -89,6 +93,7 @@ type ListOrganisationsResponse struct {
 	Email         *string `json:"email"`
 	KvkNumber     *string `json:"kvk_number"`
 	BtwNumber     *string `json:"btw_number"`
+	Iban          *string `json:"iban"`
 	LocationCount int64   `json:"location_count"`
 }
 
@@ -125,6 +130,7 @@ func (server *Server) ListOrganisationsApi(ctx *gin.Context) {
 			Email:         organisation.Email,
 			KvkNumber:     organisation.KvkNumber,
 			BtwNumber:     organisation.BtwNumber,
+			Iban:          organisation.Iban,
 			LocationCount: organisation.LocationCount,
 		}
 	}
@@ -144,6 +150,7 @@ type GetOrganisationResponse struct {
 	Email         *string   `json:"email"`
 	KvkNumber     *string   `json:"kvk_number"`
 	BtwNumber     *string   `json:"btw_number"`
+	Iban          *string   `json:"iban"`
 	LocationCount int64     `json:"location_count"`
 	CreatedAt     time.Time `json:"created_at"`
 	UpdatedAt     time.Time `json:"updated_at"`
@@ -183,6 +190,7 @@ func (server *Server) GetOrganisationApi(ctx *gin.Context) {
 		Email:         organisation.Email,
 		KvkNumber:     organisation.KvkNumber,
 		BtwNumber:     organisation.BtwNumber,
+		Iban:          organisation.Iban,
 		LocationCount: organisation.LocationCount,
 		CreatedAt:     organisation.CreatedAt.Time,
 		UpdatedAt:     organisation.UpdatedAt.Time,
@@ -242,6 +250,7 @@ type UpdateOrganisationRequest struct {
 	Email      *string `json:"email"`
 	KvkNumber  *string `json:"kvk_number"`
 	BtwNumber  *string `json:"btw_number"`
+	Iban       *string `json:"iban"`
 }
 
 // UpdateOrganisationResponse represents a response for UpdateOrganisationApi
@@ -254,6 +263,7 @@ type UpdateOrganisationResponse struct {
 	Email      *string `json:"email"`
 	KvkNumber  *string `json:"kvk_number"`
 	BtwNumber  *string `json:"btw_number"`
+	Iban       *string `json:"iban"`
 }
 
 // @Summary Update an organisation
@@ -291,6 +301,7 @@ func (server *Server) UpdateOrganisationApi(ctx *gin.Context) {
 		Email:      req.Email,
 		KvkNumber:  req.KvkNumber,
 		BtwNumber:  req.BtwNumber,
+		Iban:       req.Iban,
 	})
 	if err != nil {
 		server.logBusinessEvent(LogLevelError, "UpdateOrganisationApi", "Failed to update organisation", zap.Error(err))
@@ -307,6 +318,7 @@ func (server *Server) UpdateOrganisationApi(ctx *gin.Context) {
 		Email:      organisation.Email,
 		KvkNumber:  organisation.KvkNumber,
 		BtwNumber:  organisation.BtwNumber,
+		Iban:       organisation.Iban,
 	}, "Organisation updated successfully")
 	ctx.JSON(http.StatusOK, res)
 }
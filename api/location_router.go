@@ -14,6 +14,12 @@ func (server *Server) setupLocationRoutes(baseRouter *gin.RouterGroup) {
 		organisationGroup.GET("/organisations/:id/counts", server.RBACMiddleware("LOCATION.VIEW"), server.GetOrganisationCountApi)
 		organisationGroup.PUT("/organisations/:id", server.RBACMiddleware("LOCATION.UPDATE"), server.UpdateOrganisationApi)
 		organisationGroup.DELETE("/organisations/:id", server.RBACMiddleware("LOCATION.DELETE"), server.DeleteOrganisationApi)
+		organisationGroup.GET("/organisations/:id/invoice_approval", server.RBACMiddleware("INVOICE.VIEW"), server.GetInvoiceApprovalSettingsApi)
+		organisationGroup.PUT("/organisations/:id/invoice_approval", server.RBACMiddleware("INVOICE.APPROVE"), server.UpdateInvoiceApprovalSettingsApi)
+		organisationGroup.GET("/organisations/:id/invoice_number_series", server.RBACMiddleware("INVOICE.VIEW"), server.GetInvoiceNumberSeriesApi)
+		organisationGroup.PUT("/organisations/:id/invoice_number_series", server.RBACMiddleware("INVOICE.APPROVE"), server.UpdateInvoiceNumberSeriesApi)
+		organisationGroup.GET("/organisations/:id/ledger_accounts", server.RBACMiddleware("FINANCE.VIEW"), server.ListLedgerAccountsApi)
+		organisationGroup.PUT("/organisations/:id/ledger_accounts", server.RBACMiddleware("FINANCE.EXPORT"), server.UpdateLedgerAccountsApi)
 
 		organisationGroup.POST("/organisations/:id/locations", server.RBACMiddleware("LOCATION.CREATE"), server.CreateLocationApi)
 		organisationGroup.GET("/organisations/:id/locations", server.RBACMiddleware("LOCATION.VIEW"), server.ListLocationsApi)
@@ -68,7 +68,7 @@ func TestMain(m *testing.M) {
 		log.Fatalf("cannot setup logger: %v", err)
 	}
 
-	businessService := service.NewBusinessService(testStore, tokenMaker, logger, &config, testb2Client)
+	businessService := service.NewBusinessService(testStore, tokenMaker, logger, &config, testb2Client, testasynqClient, hubInstance)
 
 	testServer, err = NewServer(testStore, testb2Client, testasynqClient, config.OpenRouterAPIKey,
 		hubInstance, testNotifService, testGrpcClient,
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"maicare_go/token"
@@ -9,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/goccy/go-json"
 	"github.com/stretchr/testify/require"
 )
 
@@ -30,6 +32,24 @@ func addAuthorization(
 	request.Header.Set(authorizationHeaderKey, authorizationHeader)
 }
 
+// sendRequest sends a JSON request as the user and returns the recorded response. body is
+// left out of the request when it is nil.
+func sendRequest(t *testing.T, userID int64, method, url string, body any) *httptest.ResponseRecorder {
+	var data []byte
+	if body != nil {
+		var err error
+		data, err = json.Marshal(body)
+		require.NoError(t, err)
+	}
+	request, err := http.NewRequest(method, url, bytes.NewBuffer(data))
+	require.NoError(t, err)
+	request.Header.Set("Content-Type", "application/json")
+	addAuthorization(t, request, testServer.tokenMaker, authorizationTypeBearer, userID, time.Minute)
+	recorder := httptest.NewRecorder()
+	testServer.router.ServeHTTP(recorder, request)
+	return recorder
+}
+
 func TestAuthMiddleware(t *testing.T) {
 	_, user := createRandomEmployee(t)
 	testCases := []struct {
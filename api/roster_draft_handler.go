@@ -0,0 +1,260 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"maicare_go/service/schedule"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GenerateRosterDraftApi generates a roster draft for a location
+// @Summary Generate a roster draft
+// @Description Propose a roster for the shifts of a location in a month and save it as a draft. Positions are filled with the employees of the location, respecting their contract hours, their other shifts, the minimum rest between shifts and the required certifications, and spreading night and weekend shifts fairly. Shifts that are already scheduled count towards the required staff. Positions that cannot be filled are kept without employee and with the reason.
+// @Tags Schedule
+// @Accept json
+// @Produce json
+// @Param id path int true "Location ID"
+// @Param request body schedule.GenerateRosterRequest true "Staffing requirements"
+// @Success 201 {object} Response[schedule.RosterDraftResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 401 {object} Response[any] "Unauthorized"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /locations/{id}/roster_drafts [post]
+func (server *Server) GenerateRosterDraftApi(ctx *gin.Context) {
+	locationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid location ID")))
+		return
+	}
+
+	var req schedule.GenerateRosterRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(fmt.Errorf("failed to get auth payload")))
+		return
+	}
+
+	draft, err := server.businessService.ScheduleService.GenerateRosterDraft(ctx, locationID, payload.EmployeeID, &req)
+	if err != nil {
+		if errors.Is(err, schedule.ErrInvalidMonth) || errors.Is(err, schedule.ErrInvalidRosterRequirements) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := SuccessResponse(draft, "Roster draft generated successfully")
+	ctx.JSON(http.StatusCreated, res)
+}
+
+// ListRosterDraftsApi lists the roster drafts of a location
+// @Summary List roster drafts
+// @Description List the roster drafts of a location, newest month first
+// @Tags Schedule
+// @Produce json
+// @Param id path int true "Location ID"
+// @Success 200 {object} Response[[]schedule.RosterDraftListItem]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /locations/{id}/roster_drafts [get]
+func (server *Server) ListRosterDraftsApi(ctx *gin.Context) {
+	locationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid location ID")))
+		return
+	}
+
+	drafts, err := server.businessService.ScheduleService.ListRosterDrafts(ctx, locationID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := SuccessResponse(drafts, "Roster drafts retrieved successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// GetRosterDraftApi returns a roster draft
+// @Summary Get a roster draft
+// @Description Get a roster draft with its positions and the shifts, hours, night and weekend shifts per employee
+// @Tags Schedule
+// @Produce json
+// @Param id path int true "Roster draft ID"
+// @Success 200 {object} Response[schedule.RosterDraftResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 404 {object} Response[any] "Roster draft not found"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /roster_drafts/{id} [get]
+func (server *Server) GetRosterDraftApi(ctx *gin.Context) {
+	draftID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid roster draft ID")))
+		return
+	}
+
+	draft, err := server.businessService.ScheduleService.GetRosterDraft(ctx, draftID)
+	if err != nil {
+		if errors.Is(err, schedule.ErrRosterDraftNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := SuccessResponse(draft, "Roster draft retrieved successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// UpdateRosterAssignmentApi changes the employee of a position of a roster draft
+// @Summary Change a position of a roster draft
+// @Description Give a position of a roster draft to another employee, or leave it open with a null employee_id
+// @Tags Schedule
+// @Accept json
+// @Produce json
+// @Param id path int true "Roster draft ID"
+// @Param assignment_id path int true "Assignment ID"
+// @Param request body schedule.UpdateRosterAssignmentRequest true "Employee"
+// @Success 200 {object} Response[schedule.RosterDraftResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 404 {object} Response[any] "Roster draft or assignment not found"
+// @Failure 409 {object} Response[any] "The draft is published or the employee has an overlapping position"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /roster_drafts/{id}/assignments/{assignment_id} [put]
+func (server *Server) UpdateRosterAssignmentApi(ctx *gin.Context) {
+	draftID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid roster draft ID")))
+		return
+	}
+	assignmentID, err := strconv.ParseInt(ctx.Param("assignment_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid assignment ID")))
+		return
+	}
+
+	var req schedule.UpdateRosterAssignmentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	draft, err := server.businessService.ScheduleService.UpdateRosterAssignment(ctx, draftID, assignmentID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, schedule.ErrRosterDraftNotFound), errors.Is(err, schedule.ErrRosterAssignmentNotFound):
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+		case errors.Is(err, schedule.ErrRosterDraftPublished), errors.Is(err, schedule.ErrRosterAssignmentOverlap):
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	res := SuccessResponse(draft, "Roster assignment updated successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// DeleteRosterDraftApi discards a roster draft
+// @Summary Delete a roster draft
+// @Description Discard a roster draft that has not been published
+// @Tags Schedule
+// @Produce json
+// @Param id path int true "Roster draft ID"
+// @Success 200 {object} Response[any]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 404 {object} Response[any] "Roster draft not found"
+// @Failure 409 {object} Response[any] "The draft is published"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /roster_drafts/{id} [delete]
+func (server *Server) DeleteRosterDraftApi(ctx *gin.Context) {
+	draftID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid roster draft ID")))
+		return
+	}
+
+	err = server.businessService.ScheduleService.DeleteRosterDraft(ctx, draftID)
+	if err != nil {
+		switch {
+		case errors.Is(err, schedule.ErrRosterDraftNotFound):
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+		case errors.Is(err, schedule.ErrRosterDraftPublished):
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	res := SuccessResponse[any](nil, "Roster draft deleted successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// PublishRosterDraftApi publishes a roster draft
+// @Summary Publish a roster draft
+// @Description Create a schedule for every filled position of a roster draft and send the new schedule notifications. Positions that overlap shifts the employees got since the draft was generated are returned with 409, set override to publish anyway.
+// @Tags Schedule
+// @Accept json
+// @Produce json
+// @Param id path int true "Roster draft ID"
+// @Param request body schedule.PublishRosterDraftRequest false "Publish options"
+// @Success 200 {object} Response[schedule.RosterDraftResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 404 {object} Response[any] "Roster draft not found"
+// @Failure 409 {object} Response[[]schedule.RosterAssignmentConflicts] "Conflicting positions, or the draft is already published"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /roster_drafts/{id}/publish [post]
+func (server *Server) PublishRosterDraftApi(ctx *gin.Context) {
+	draftID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid roster draft ID")))
+		return
+	}
+
+	var req schedule.PublishRosterDraftRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(fmt.Errorf("failed to get auth payload")))
+		return
+	}
+
+	draft, conflicts, err := server.businessService.ScheduleService.PublishRosterDraft(ctx, draftID, payload.EmployeeID, req.Override)
+	if err != nil {
+		switch {
+		case errors.Is(err, schedule.ErrRosterDraftNotFound):
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+		case errors.Is(err, schedule.ErrRosterConflict):
+			ctx.JSON(http.StatusConflict, Response[[]schedule.RosterAssignmentConflicts]{
+				Success: false,
+				Message: err.Error(),
+				Data:    conflicts,
+			})
+		case errors.Is(err, schedule.ErrRosterDraftPublished):
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	res := SuccessResponse(draft, "Roster draft published successfully")
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"maicare_go/service/schedule"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRosterDraftApi(t *testing.T) {
+	employee, _ := createRandomEmployee(t)
+	location := createRandomLocation(t)
+	shifts, err := testStore.GetShiftsByLocationID(context.Background(), location.ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, shifts)
+
+	year := int32(time.Now().Year() + 1)
+	days := time.Date(int(year), time.February+1, 0, 0, 0, 0, 0, time.UTC).Day()
+
+	recorder := sendRequest(t, 1, http.MethodPost, fmt.Sprintf("/locations/%d/roster_drafts", location.ID), schedule.GenerateRosterRequest{
+		Year:         year,
+		Month:        2,
+		Requirements: []schedule.ShiftRequirement{{LocationShiftID: shifts[0].ID, Staff: 1}},
+		EmployeeIDs:  []int64{employee.ID},
+	})
+	t.Log(recorder.Body.String())
+	require.Equal(t, http.StatusCreated, recorder.Code)
+	var generated Response[schedule.RosterDraftResponse]
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &generated))
+	require.Equal(t, schedule.RosterStatusDraft, generated.Data.Status)
+	require.Equal(t, days, generated.Data.Positions)
+	require.Len(t, generated.Data.Employees, 1)
+
+	// leave the first position open
+	first := generated.Data.Assignments[0]
+	recorder = sendRequest(t, 1, http.MethodPut, fmt.Sprintf("/roster_drafts/%d/assignments/%d", generated.Data.ID, first.ID), schedule.UpdateRosterAssignmentRequest{})
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var updated Response[schedule.RosterDraftResponse]
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &updated))
+	require.Equal(t, generated.Data.Unfilled+1, updated.Data.Unfilled)
+
+	filled := updated.Data.Positions - updated.Data.Unfilled
+	testasynqClient.EXPECT().EnqueueNotificationTask(gomock.Any(), gomock.Any(), gomock.Any()).Times(filled).Return(nil)
+	recorder = sendRequest(t, 1, http.MethodPost, fmt.Sprintf("/roster_drafts/%d/publish", generated.Data.ID), nil)
+	t.Log(recorder.Body.String())
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var published Response[schedule.RosterDraftResponse]
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &published))
+	require.Equal(t, schedule.RosterStatusPublished, published.Data.Status)
+	require.Nil(t, published.Data.Assignments[0].ScheduleID)
+	require.NotNil(t, published.Data.Assignments[1].ScheduleID)
+
+	recorder = sendRequest(t, 1, http.MethodPost, fmt.Sprintf("/roster_drafts/%d/publish", generated.Data.ID), nil)
+	require.Equal(t, http.StatusConflict, recorder.Code)
+	recorder = sendRequest(t, 1, http.MethodDelete, fmt.Sprintf("/roster_drafts/%d", generated.Data.ID), nil)
+	require.Equal(t, http.StatusConflict, recorder.Code)
+}
@@ -2,9 +2,11 @@ package api
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	db "maicare_go/db/sqlc"
 	"maicare_go/notification"
+	"maicare_go/service/schedule"
 	"maicare_go/util"
 	"net/http"
 	"strconv"
@@ -29,6 +31,9 @@ type CreateScheduleRequest struct {
 	// For preset shift-based schedules (required when is_custom = false)
 	LocationShiftID *int64  `json:"location_shift_id,omitempty" example:"1"`
 	ShiftDate       *string `json:"shift_date,omitempty" example:"2023-10-01"` // Date to apply the shift
+
+	// Save the schedule even if it overlaps another shift of the employee
+	Override bool `json:"override" example:"false"`
 }
 
 // CreateScheduleResponse represents the response body after creating a schedule.
@@ -45,6 +50,10 @@ type CreateScheduleResponse struct {
 	// Additional info if created from preset shift
 	LocationShiftID *int64  `json:"location_shift_id,omitempty"`
 	ShiftName       *string `json:"shift_name,omitempty"`
+
+	// Shifts, appointments, absences and unavailability of the employee that overlap the
+	// schedule, the working time rules it breaks and whether it is outside their availability
+	Conflicts []schedule.Conflict `json:"conflicts"`
 }
 
 // @Summary Create a new schedule
@@ -57,6 +66,7 @@ type CreateScheduleResponse struct {
 // @Param request body CreateScheduleRequest true "Create Schedule Request"
 // @Success 200 {object} Response[CreateScheduleResponse] "Schedule created successfully"
 // @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 409 {object} Response[[]schedule.Conflict] "The schedule overlaps another shift or an absence of the employee or breaks the working time rules, set override to save it anyway"
 // @Failure 500 {object} Response[any] "Internal Server Error"
 // @Router /schedules [post]
 func (server *Server) CreateScheduleApi(ctx *gin.Context) {
@@ -156,9 +166,19 @@ func (server *Server) CreateScheduleApi(ctx *gin.Context) {
 		shiftName = &locationShift.ShiftName
 	}
 
+	conflicts, ok := server.checkScheduleConflicts(ctx, "CreateScheduleApi", schedule.Slot{
+		EmployeeID: req.EmployeeID,
+		LocationID: req.LocationID,
+		Start:      startDatetime,
+		End:        endDatetime,
+	}, req.Override)
+	if !ok {
+		return
+	}
+
 	// Create the schedule
 	arg := db.CreateScheduleParams{
-		EmployeeID:          req.EmployeeID,
+		EmployeeID:          &req.EmployeeID,
 		LocationID:          req.LocationID,
 		LocationShiftID:     locationShiftID,
 		IsCustom:            req.IsCustom,
@@ -194,7 +214,7 @@ func (server *Server) CreateScheduleApi(ctx *gin.Context) {
 
 	res := SuccessResponse(CreateScheduleResponse{
 		ID:              schedule.ID,
-		EmployeeID:      schedule.EmployeeID,
+		EmployeeID:      req.EmployeeID,
 		LocationID:      schedule.LocationID,
 		StartDatetime:   schedule.StartDatetime.Time,
 		EndDatetime:     schedule.EndDatetime.Time,
@@ -203,6 +223,7 @@ func (server *Server) CreateScheduleApi(ctx *gin.Context) {
 		UpdatedAt:       schedule.UpdatedAt.Time,
 		LocationShiftID: locationShiftID,
 		ShiftName:       shiftName,
+		Conflicts:       conflicts,
 	}, "Schedule created successfully")
 	ctx.JSON(http.StatusOK, res)
 }
@@ -226,16 +247,21 @@ type Shift struct {
 	ShiftName         *string   `json:"shift_name,omitempty"`
 	LocationShiftID   *int64    `json:"location_shift_id,omitempty"` // Optional field for preset shift
 	IsCustom          bool      `json:"is_custom"`                   // Indicates if this is a custom schedule
+	// Unavailability of the employee during the shift, or the shift being outside their
+	// weekly availability
+	AvailabilityConflicts []schedule.Conflict `json:"availability_conflicts,omitempty"`
 }
 
 // GetMonthlySchedulesByLocationResponse represents the response body for monthly schedules.
 type GetMonthlySchedulesByLocationResponse struct {
 	Date   string  `json:"date"`
 	Shifts []Shift `json:"shifts"`
+	// When the employees of the location, and the others with shifts that day, can work
+	Availability []schedule.EmployeeAvailability `json:"availability"`
 }
 
 // @Summary Get monthly schedules by location
-// @Description Get all schedules for a specific location for a given month and year
+// @Description Get all schedules for a specific location for a given month and year, with the availability of the employees on every day of the month
 // @Tags Schedule
 // @Produce json
 // @Param id path int true "Location ID"
@@ -297,12 +323,48 @@ func (server *Server) GetMonthlySchedulesByLocationApi(ctx *gin.Context) {
 
 	}
 
+	var availability *schedule.LocationAvailability
+	monthStart := time.Date(int(req.Year), time.Month(req.Month), 1, 0, 0, 0, 0, time.UTC)
+	if req.Year > 0 && req.Month >= 1 && req.Month <= 12 {
+		var shifts []Shift
+		for _, dayShifts := range calendar {
+			shifts = append(shifts, dayShifts...)
+		}
+		availability, err = server.locationAvailability(ctx, locationID, monthStart, monthStart.AddDate(0, 1, 0), shifts)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		for _, dayShifts := range calendar {
+			for i := range dayShifts {
+				dayShifts[i].AvailabilityConflicts = shiftAvailability(availability, dayShifts[i])
+			}
+		}
+	}
+
 	var response []GetMonthlySchedulesByLocationResponse
-	for date, shifts := range calendar {
-		response = append(response, GetMonthlySchedulesByLocationResponse{
-			Date:   date,
-			Shifts: shifts,
-		})
+	if availability == nil {
+		for date, shifts := range calendar {
+			response = append(response, GetMonthlySchedulesByLocationResponse{
+				Date:   date,
+				Shifts: shifts,
+			})
+		}
+	} else {
+		// every day of the month is listed, the availability matters most on the days
+		// without shifts yet
+		for day := monthStart; day.Month() == monthStart.Month(); day = day.AddDate(0, 0, 1) {
+			date := day.Format("2006-01-02")
+			shifts := calendar[date]
+			if shifts == nil {
+				shifts = []Shift{}
+			}
+			response = append(response, GetMonthlySchedulesByLocationResponse{
+				Date:         date,
+				Shifts:       shifts,
+				Availability: availability.Day(day),
+			})
+		}
 	}
 	res := SuccessResponse(response, "Schedules retrieved successfully")
 	ctx.JSON(http.StatusOK, res)
@@ -319,10 +381,12 @@ type GetDailySchedulesByLocationRequest struct {
 type GetDailySchedulesByLocationResponse struct {
 	Date   string  `json:"date"`
 	Shifts []Shift `json:"shifts"`
+	// When the employees of the location, and the others with shifts that day, can work
+	Availability []schedule.EmployeeAvailability `json:"availability"`
 }
 
 // @Summary Get daily schedules by location
-// @Description Get all schedules for a specific location for a given day
+// @Description Get all schedules for a specific location for a given day, with the availability of the employees that day
 // @Tags Schedule
 // @Produce json
 // @Param id path int true "Location ID"
@@ -389,26 +453,65 @@ func (server *Server) GetDailySchedulesByLocationApi(ctx *gin.Context) {
 		shifts = append(shifts, shift)
 	}
 
+	day := time.Date(int(req.Year), time.Month(req.Month), int(req.Day), 0, 0, 0, 0, time.UTC)
+	availability, err := server.locationAvailability(ctx, locationID, day, day.AddDate(0, 0, 1), shifts)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	for i := range shifts {
+		shifts[i].AvailabilityConflicts = shiftAvailability(availability, shifts[i])
+	}
+
 	// If no schedules found, still create the target date
 	if targetDate == "" {
-		targetDate = time.Date(int(req.Year), time.Month(req.Month), int(req.Day), 0, 0, 0, 0, time.UTC).Format("2006-01-02")
+		targetDate = day.Format("2006-01-02")
 	}
 
 	response := GetDailySchedulesByLocationResponse{
-		Date:   targetDate,
-		Shifts: shifts,
+		Date:         targetDate,
+		Shifts:       shifts,
+		Availability: availability.Day(day),
 	}
 
 	res := SuccessResponse(response, "Daily schedules retrieved successfully")
 	ctx.JSON(http.StatusOK, res)
 }
 
+// locationAvailability returns the availability of the employees of the location and of
+// the employees of the shifts, from start until end or the end of the last shift
+func (server *Server) locationAvailability(ctx *gin.Context, locationID int64, start, end time.Time, shifts []Shift) (*schedule.LocationAvailability, error) {
+	var employeeIDs []int64
+	for _, shift := range shifts {
+		employeeIDs = append(employeeIDs, shift.EmployeeID)
+		if shift.StartTime.Before(start) {
+			start = shift.StartTime
+		}
+		if shift.EndTime.After(end) {
+			end = shift.EndTime
+		}
+	}
+	return server.businessService.ScheduleService.GetLocationAvailability(ctx, locationID, employeeIDs, start, end)
+}
+
+// shiftAvailability returns the availability conflicts of a shift
+func shiftAvailability(availability *schedule.LocationAvailability, shift Shift) []schedule.Conflict {
+	return availability.Conflicts(schedule.Slot{
+		ScheduleID: &shift.ShiftID,
+		EmployeeID: shift.EmployeeID,
+		LocationID: shift.LocationID,
+		Start:      shift.StartTime,
+		End:        shift.EndTime,
+	})
+}
+
 // GetScheduleByIdResponse represents the response body for retrieving a schedule by ID.
+// The employee is empty for an open shift.
 type GetScheduleByIdResponse struct {
 	ID                uuid.UUID `json:"id"`
-	EmployeeID        int64     `json:"employee_id"`
-	EmployeeFirstName string    `json:"employee_first_name"`
-	EmployeeLastName  string    `json:"employee_last_name"`
+	EmployeeID        *int64    `json:"employee_id"`
+	EmployeeFirstName *string   `json:"employee_first_name"`
+	EmployeeLastName  *string   `json:"employee_last_name"`
 	LocationID        int64     `json:"location_id"`
 	LocationName      string    `json:"location_name"`
 	LocationShiftID   *int64    `json:"location_shift_id,omitempty"` // Optional field for preset shift
@@ -477,12 +580,16 @@ type UpdateScheduleRequest struct {
 	ShiftDate       *string `json:"shift_date,omitempty" example:"2023-10-01"` // Date to apply the shift
 
 	Color *string `json:"color,omitempty" example:"#FF5733"`
+
+	// Save the schedule even if it overlaps another shift of the employee
+	Override bool `json:"override" example:"false"`
 }
 
-// UpdateScheduleResponse represents the response body after updating a schedule.
+// UpdateScheduleResponse represents the response body after updating a schedule. The
+// employee is empty for an open shift.
 type UpdateScheduleResponse struct {
 	ID            uuid.UUID `json:"id"`
-	EmployeeID    int64     `json:"employee_id"`
+	EmployeeID    *int64    `json:"employee_id"`
 	LocationID    int64     `json:"location_id"`
 	StartDatetime time.Time `json:"start_datetime"`
 	EndDatetime   time.Time `json:"end_datetime"`
@@ -493,6 +600,10 @@ type UpdateScheduleResponse struct {
 	// Additional info if updated from preset shift
 	LocationShiftID *int64  `json:"location_shift_id,omitempty"`
 	ShiftName       *string `json:"shift_name,omitempty"`
+
+	// Shifts, appointments, absences and unavailability of the employee that overlap the
+	// schedule, the working time rules it breaks and whether it is outside their availability
+	Conflicts []schedule.Conflict `json:"conflicts"`
 }
 
 // @Summary Update an existing schedule
@@ -507,6 +618,7 @@ type UpdateScheduleResponse struct {
 // @Success 200 {object} Response[UpdateScheduleResponse] "Schedule updated successfully"
 // @Failure 400 {object} Response[any] "Bad Request"
 // @Failure 404 {object} Response[any] "Schedule not found"
+// @Failure 409 {object} Response[[]schedule.Conflict] "The schedule overlaps another shift or an absence of the employee or breaks the working time rules, set override to save it anyway"
 // @Failure 500 {object} Response[any] "Internal Server Error"
 // @Router /schedules/{id} [put]
 func (server *Server) UpdateScheduleApi(ctx *gin.Context) {
@@ -572,13 +684,13 @@ func (server *Server) UpdateScheduleApi(ctx *gin.Context) {
 	var startDatetime, endDatetime time.Time
 	var locationShiftID *int64
 	var shiftName *string
-	var employeeID int64 = existingSchedule.EmployeeID
+	var employeeID *int64 = existingSchedule.EmployeeID
 	var locationID int64 = existingSchedule.LocationID
 	var color *string = existingSchedule.Color
 
 	// Update fields if provided
 	if req.EmployeeID != nil {
-		employeeID = *req.EmployeeID
+		employeeID = req.EmployeeID
 	}
 	if req.LocationID != nil {
 		locationID = *req.LocationID
@@ -674,6 +786,22 @@ func (server *Server) UpdateScheduleApi(ctx *gin.Context) {
 		shiftName = &locationShift.ShiftName
 	}
 
+	// an open shift stays open until it is assigned or claimed
+	conflicts := []schedule.Conflict{}
+	if employeeID != nil {
+		var ok bool
+		conflicts, ok = server.checkScheduleConflicts(ctx, "UpdateScheduleApi", schedule.Slot{
+			ScheduleID: &scheduleID,
+			EmployeeID: *employeeID,
+			LocationID: locationID,
+			Start:      startDatetime,
+			End:        endDatetime,
+		}, req.Override)
+		if !ok {
+			return
+		}
+	}
+
 	// Update the schedule
 	arg := db.UpdateScheduleParams{
 		ID:              scheduleID,
@@ -691,22 +819,24 @@ func (server *Server) UpdateScheduleApi(ctx *gin.Context) {
 		return
 	}
 
-	notifData := &notification.NewScheduleNotificationData{
-		ScheduleID: schedule.ID,
-		CreatedBy:  existingSchedule.EmployeeID,
-		StartTime:  startDatetime,
-		EndTime:    endDatetime,
-		Location:   schedule.LocationName,
-	}
-	err = server.asynqClient.EnqueueNotificationTask(ctx, notification.NotificationPayload{
-		RecipientUserIDs: []int64{employeeID},
-		Type:             notification.TypeNewScheduleNotification,
-		Data:             notification.NotificationData{NewScheduleNotification: notifData},
-		CreatedAt:        time.Now(),
-		Message:          notifData.UpdatedScheduleMessage(),
-	})
-	if err != nil {
-		server.logBusinessEvent(LogLevelError, "UpdateScheduleApi", "Failed to enqueue notification task", zap.Error(err))
+	if employeeID != nil {
+		notifData := &notification.NewScheduleNotificationData{
+			ScheduleID: schedule.ID,
+			CreatedBy:  util.DerefInt64(existingSchedule.EmployeeID),
+			StartTime:  startDatetime,
+			EndTime:    endDatetime,
+			Location:   schedule.LocationName,
+		}
+		err = server.asynqClient.EnqueueNotificationTask(ctx, notification.NotificationPayload{
+			RecipientUserIDs: []int64{*employeeID},
+			Type:             notification.TypeNewScheduleNotification,
+			Data:             notification.NotificationData{NewScheduleNotification: notifData},
+			CreatedAt:        time.Now(),
+			Message:          notifData.UpdatedScheduleMessage(),
+		})
+		if err != nil {
+			server.logBusinessEvent(LogLevelError, "UpdateScheduleApi", "Failed to enqueue notification task", zap.Error(err))
+		}
 	}
 
 	res := SuccessResponse(UpdateScheduleResponse{
@@ -720,6 +850,7 @@ func (server *Server) UpdateScheduleApi(ctx *gin.Context) {
 		UpdatedAt:       schedule.UpdatedAt.Time,
 		LocationShiftID: locationShiftID,
 		ShiftName:       shiftName,
+		Conflicts:       conflicts,
 	}, "Schedule updated successfully")
 	ctx.JSON(http.StatusOK, res)
 }
@@ -750,3 +881,65 @@ func (server *Server) DeleteScheduleApi(ctx *gin.Context) {
 	res := SuccessResponse[any](nil, "Schedule deleted successfully")
 	ctx.JSON(http.StatusOK, res)
 }
+
+// checkScheduleConflicts returns the conflicts of a schedule that is about to be saved. When
+// a conflict blocks scheduling and override is not set, it responds with the conflicts and
+// returns false.
+func (server *Server) checkScheduleConflicts(ctx *gin.Context, operation string, slot schedule.Slot, override bool) ([]schedule.Conflict, bool) {
+	conflicts, err := server.businessService.ScheduleService.CheckScheduleConflicts(ctx, slot)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return nil, false
+	}
+	if schedule.HasErrors(conflicts) {
+		if !override {
+			ctx.JSON(http.StatusConflict, Response[[]schedule.Conflict]{
+				Success: false,
+				Message: schedule.ErrScheduleConflict.Error(),
+				Data:    conflicts,
+			})
+			return nil, false
+		}
+		server.logBusinessEvent(LogLevelInfo, operation, "Schedule conflicts overridden",
+			zap.Int64("employee_id", slot.EmployeeID), zap.Int("conflicts", len(conflicts)))
+	}
+	return conflicts, true
+}
+
+// @Summary Check the roster of a location for conflicts
+// @Description Check every shift of a location in a month against the other shifts and the appointments of its employee, at any location. Only shifts with conflicts are listed.
+// @Tags Schedule
+// @Produce json
+// @Param id path int true "Location ID"
+// @Param year query int true "Year"
+// @Param month query int true "Month"
+// @Success 200 {object} Response[schedule.RosterConflictsResponse] "Roster checked successfully"
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /locations/{id}/schedule_conflicts [get]
+func (server *Server) CheckRosterConflictsApi(ctx *gin.Context) {
+	locationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid location ID")))
+		return
+	}
+
+	var req GetMonthlySchedulesByLocationRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	report, err := server.businessService.ScheduleService.CheckRosterConflicts(ctx, locationID, req.Year, req.Month)
+	if err != nil {
+		if errors.Is(err, schedule.ErrInvalidMonth) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := SuccessResponse(report, "Roster checked successfully")
+	ctx.JSON(http.StatusOK, res)
+}
@@ -10,9 +10,41 @@ func (server *Server) setupScheduleRoutes(baseRouter *gin.RouterGroup) {
 
 		schedule.GET("/locations/:id/monthly_schedules", server.RBACMiddleware("SCHEDULE.VIEW"), server.GetMonthlySchedulesByLocationApi)
 		schedule.GET("/locations/:id/daily_schedules", server.RBACMiddleware("SCHEDULE.VIEW"), server.GetDailySchedulesByLocationApi)
+		schedule.GET("/locations/:id/schedule_conflicts", server.RBACMiddleware("SCHEDULE.VIEW"), server.CheckRosterConflictsApi)
 
 		schedule.DELETE("/schedules/:id", server.RBACMiddleware("SCHEDULE.DELETE"), server.DeleteScheduleApi)
 		schedule.GET("/schedules/:id", server.RBACMiddleware("SCHEDULE.VIEW"), server.GetScheduleByIDApi)
 		schedule.PUT("/schedules/:id", server.RBACMiddleware("SCHEDULE.UPDATE"), server.UpdateScheduleApi)
+
+		schedule.POST("/locations/:id/roster_drafts", server.RBACMiddleware("SCHEDULE.CREATE"), server.GenerateRosterDraftApi)
+		schedule.GET("/locations/:id/roster_drafts", server.RBACMiddleware("SCHEDULE.VIEW"), server.ListRosterDraftsApi)
+		schedule.GET("/roster_drafts/:id", server.RBACMiddleware("SCHEDULE.VIEW"), server.GetRosterDraftApi)
+		schedule.PUT("/roster_drafts/:id/assignments/:assignment_id", server.RBACMiddleware("SCHEDULE.CREATE"), server.UpdateRosterAssignmentApi)
+		schedule.DELETE("/roster_drafts/:id", server.RBACMiddleware("SCHEDULE.CREATE"), server.DeleteRosterDraftApi)
+		schedule.POST("/roster_drafts/:id/publish", server.RBACMiddleware("SCHEDULE.CREATE"), server.PublishRosterDraftApi)
+
+		schedule.POST("/locations/:id/staffing_norms", server.RBACMiddleware("SHIFT.CREATE"), server.CreateStaffingNormApi)
+		schedule.GET("/locations/:id/staffing_norms", server.RBACMiddleware("SHIFT.VIEW"), server.ListStaffingNormsApi)
+		schedule.PUT("/locations/:id/staffing_norms/:norm_id", server.RBACMiddleware("SHIFT.UPDATE"), server.UpdateStaffingNormApi)
+		schedule.DELETE("/locations/:id/staffing_norms/:norm_id", server.RBACMiddleware("SHIFT.DELETE"), server.DeleteStaffingNormApi)
+		schedule.GET("/locations/:id/staffing_coverage", server.RBACMiddleware("SCHEDULE.VIEW"), server.GetStaffingCoverageApi)
+
+		schedule.POST("/schedules/:id/clock_in", server.RBACMiddleware("SCHEDULE.VIEW"), server.ClockInApi)
+		schedule.POST("/schedules/:id/clock_out", server.RBACMiddleware("SCHEDULE.VIEW"), server.ClockOutApi)
+		schedule.POST("/schedules/:id/breaks/start", server.RBACMiddleware("SCHEDULE.VIEW"), server.StartBreakApi)
+		schedule.POST("/schedules/:id/breaks/end", server.RBACMiddleware("SCHEDULE.VIEW"), server.EndBreakApi)
+		schedule.GET("/schedules/:id/time_entry", server.RBACMiddleware("SCHEDULE.VIEW"), server.GetScheduleTimeEntryApi)
+		schedule.GET("/time_entries", server.RBACMiddleware("SCHEDULE.VIEW"), server.ListTimeEntriesApi)
+		schedule.POST("/time_entries/:id/approve", server.RBACMiddleware("SCHEDULE.UPDATE"), server.ApproveTimeEntryApi)
+		schedule.POST("/time_entries/:id/reject", server.RBACMiddleware("SCHEDULE.UPDATE"), server.RejectTimeEntryApi)
+
+		schedule.GET("/working_time_rules", server.RBACMiddleware("SCHEDULE.VIEW"), server.ListWorkingTimeRulesApi)
+		schedule.PUT("/working_time_rules/:contract_type", server.RBACMiddleware("SHIFT.UPDATE"), server.UpdateWorkingTimeRulesApi)
+		schedule.GET("/working_time_compliance", server.RBACMiddleware("SCHEDULE.VIEW"), server.GetWorkingTimeComplianceApi)
+
+		schedule.GET("/locations/:id/clock_settings", server.RBACMiddleware("LOCATION.VIEW"), server.GetLocationClockSettingsApi)
+		schedule.PUT("/locations/:id/clock_settings", server.RBACMiddleware("LOCATION.UPDATE"), server.UpdateLocationClockSettingsApi)
+		schedule.POST("/locations/:id/clock_settings/qr_token", server.RBACMiddleware("LOCATION.UPDATE"), server.RotateLocationClockQRTokenApi)
+		schedule.DELETE("/locations/:id/clock_settings/qr_token", server.RBACMiddleware("LOCATION.UPDATE"), server.RemoveLocationClockQRTokenApi)
 	}
 }
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	db "maicare_go/db/sqlc"
+	"maicare_go/service/schedule"
 	"maicare_go/token"
 	"maicare_go/util"
 	"net/http"
@@ -22,7 +23,7 @@ func createRandomSchedule(t *testing.T, employeeID int64) db.CreateScheduleRow {
 	location := createRandomLocation(t)
 
 	arg := db.CreateScheduleParams{
-		EmployeeID:    employeeID,
+		EmployeeID:    &employeeID,
 		LocationID:    location.ID,
 		StartDatetime: pgtype.Timestamp{Time: time.Now(), Valid: true},
 		EndDatetime:   pgtype.Timestamp{Time: time.Now().Add(24 * time.Hour), Valid: true},
@@ -226,3 +227,91 @@ func TestGetDailySchedulesByLocationApi(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateScheduleConflictApi(t *testing.T) {
+	testasynqClient.EXPECT().EnqueueNotificationTask(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+	employee, _ := createRandomEmployee(t)
+	existing := createRandomSchedule(t, employee.ID)
+	location := createRandomLocation(t)
+
+	testCases := []struct {
+		name          string
+		override      bool
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Overlapping shift",
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+				var response Response[[]schedule.Conflict]
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Len(t, response.Data, 1)
+				require.Equal(t, existing.ID, *response.Data[0].ScheduleID)
+				require.Equal(t, schedule.SeverityError, response.Data[0].Severity)
+			},
+		},
+		{
+			name:     "Overridden",
+			override: true,
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var response Response[CreateScheduleResponse]
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Len(t, response.Data.Conflicts, 1)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			start := existing.StartDatetime.Time.Add(time.Hour)
+			end := start.Add(8 * time.Hour)
+			data, err := json.Marshal(CreateScheduleRequest{
+				EmployeeID:    employee.ID,
+				LocationID:    location.ID,
+				IsCustom:      true,
+				StartDatetime: &start,
+				EndDatetime:   &end,
+				Override:      tc.override,
+			})
+			require.NoError(t, err)
+			req, err := http.NewRequest(http.MethodPost, "/schedules", bytes.NewBuffer(data))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			recorder := httptest.NewRecorder()
+			addAuthorization(t, req, testServer.tokenMaker, authorizationTypeBearer, 1, time.Minute)
+			testServer.router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestCheckRosterConflictsApi(t *testing.T) {
+	employee, _ := createRandomEmployee(t)
+	first := createRandomSchedule(t, employee.ID)
+	createRandomSchedule(t, employee.ID)
+
+	url := fmt.Sprintf("/locations/%d/schedule_conflicts?year=%d&month=%d", first.LocationID,
+		first.StartDatetime.Time.Year(), first.StartDatetime.Time.Month())
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	addAuthorization(t, req, testServer.tokenMaker, authorizationTypeBearer, 1, time.Minute)
+	testServer.router.ServeHTTP(recorder, req)
+
+	t.Log(recorder.Body.String())
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var response Response[schedule.RosterConflictsResponse]
+	err = json.Unmarshal(recorder.Body.Bytes(), &response)
+	require.NoError(t, err)
+	require.Equal(t, 1, response.Data.ShiftsChecked)
+	require.Len(t, response.Data.Shifts, 1)
+	require.Equal(t, first.ID, response.Data.Shifts[0].ScheduleID)
+	require.Equal(t, 1, response.Data.Errors)
+}
@@ -0,0 +1,231 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"maicare_go/service/employees"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+// selfBillingError maps the errors of self-billing statements to a status code
+func selfBillingError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+	case errors.Is(err, employees.ErrInvalidStatementMonth):
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+	case errors.Is(err, employees.ErrStatementNotConcept):
+		ctx.JSON(http.StatusConflict, errorResponse(err))
+	case errors.Is(err, employees.ErrNoSubcontractorShifts), errors.Is(err, employees.ErrNoStatementsToExport):
+		ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	}
+}
+
+// GenerateSelfBillingStatementsApi generates the monthly statements of subcontractors
+// @Summary Generate self-billing statements
+// @Description Prices the shifts of ZZP employees and subcontractors in a month at their contract rate, one statement per organisation. Concept and rejected statements are replaced, approved statements are kept.
+// @Tags Finance
+// @Accept json
+// @Produce json
+// @Param request body employees.GenerateSelfBillingStatementsRequest true "Generate Self-Billing Statements Request"
+// @Success 201 {object} Response[employees.GenerateSelfBillingStatementsResponse]
+// @Failure 400,401,422,500 {object} Response[any]
+// @Router /finance/self_billing_statements [post]
+func (server *Server) GenerateSelfBillingStatementsApi(ctx *gin.Context) {
+	var req employees.GenerateSelfBillingStatementsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	result, err := server.businessService.EmployeeService.GenerateSelfBillingStatements(req, payload.EmployeeID, ctx)
+	if err != nil {
+		selfBillingError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, SuccessResponse(result, "Self-billing statements generated successfully"))
+}
+
+// ListSelfBillingStatementsApi lists the statements of a month
+// @Summary List self-billing statements
+// @Tags Finance
+// @Produce json
+// @Param month query string true "Month (YYYY-MM)"
+// @Param status query string false "Status" Enums(concept, approved, rejected, exported)
+// @Param employee_id query int false "Employee ID"
+// @Success 200 {object} Response[[]employees.SelfBillingStatementResponse]
+// @Failure 400,401,500 {object} Response[any]
+// @Router /finance/self_billing_statements [get]
+func (server *Server) ListSelfBillingStatementsApi(ctx *gin.Context) {
+	var req employees.ListSelfBillingStatementsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	statements, err := server.businessService.EmployeeService.ListSelfBillingStatements(req, ctx)
+	if err != nil {
+		selfBillingError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, SuccessResponse(statements, "Self-billing statements retrieved successfully"))
+}
+
+// GetSelfBillingStatementApi returns a statement with its shifts
+// @Summary Get self-billing statement
+// @Tags Finance
+// @Produce json
+// @Param id path int64 true "Statement ID"
+// @Success 200 {object} Response[employees.SelfBillingStatementResponse]
+// @Failure 400,401,404,500 {object} Response[any]
+// @Router /finance/self_billing_statements/{id} [get]
+func (server *Server) GetSelfBillingStatementApi(ctx *gin.Context) {
+	statementID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid statement ID: %s", ctx.Param("id"))))
+		return
+	}
+
+	statement, err := server.businessService.EmployeeService.GetSelfBillingStatement(statementID, ctx)
+	if err != nil {
+		selfBillingError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, SuccessResponse(statement, "Self-billing statement retrieved successfully"))
+}
+
+// ApproveSelfBillingStatementApi approves a concept statement
+// @Summary Approve self-billing statement
+// @Tags Finance
+// @Produce json
+// @Param id path int64 true "Statement ID"
+// @Success 200 {object} Response[employees.SelfBillingStatementResponse]
+// @Failure 400,401,404,409,500 {object} Response[any]
+// @Router /finance/self_billing_statements/{id}/approve [post]
+func (server *Server) ApproveSelfBillingStatementApi(ctx *gin.Context) {
+	statementID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid statement ID: %s", ctx.Param("id"))))
+		return
+	}
+
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	statement, err := server.businessService.EmployeeService.ApproveSelfBillingStatement(statementID, payload.EmployeeID, ctx)
+	if err != nil {
+		selfBillingError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, SuccessResponse(statement, "Self-billing statement approved successfully"))
+}
+
+// RejectSelfBillingStatementApi rejects a concept statement
+// @Summary Reject self-billing statement
+// @Description A rejected statement is replaced the next time its month is generated
+// @Tags Finance
+// @Accept json
+// @Produce json
+// @Param id path int64 true "Statement ID"
+// @Param request body employees.RejectSelfBillingStatementRequest true "Reject Self-Billing Statement Request"
+// @Success 200 {object} Response[employees.SelfBillingStatementResponse]
+// @Failure 400,401,404,409,500 {object} Response[any]
+// @Router /finance/self_billing_statements/{id}/reject [post]
+func (server *Server) RejectSelfBillingStatementApi(ctx *gin.Context) {
+	statementID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid statement ID: %s", ctx.Param("id"))))
+		return
+	}
+
+	var req employees.RejectSelfBillingStatementRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	statement, err := server.businessService.EmployeeService.RejectSelfBillingStatement(req, statementID, ctx)
+	if err != nil {
+		selfBillingError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, SuccessResponse(statement, "Self-billing statement rejected successfully"))
+}
+
+// DownloadSelfBillingStatementApi downloads a statement as PDF
+// @Summary Download self-billing statement
+// @Tags Finance
+// @Produce application/pdf
+// @Param id path int64 true "Statement ID"
+// @Success 200 {file} file "Statement PDF"
+// @Failure 400,401,404,500 {object} Response[any]
+// @Router /finance/self_billing_statements/{id}/download [get]
+func (server *Server) DownloadSelfBillingStatementApi(ctx *gin.Context) {
+	statementID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid statement ID: %s", ctx.Param("id"))))
+		return
+	}
+
+	document, err := server.businessService.EmployeeService.DownloadSelfBillingStatementPDF(statementID, ctx)
+	if err != nil {
+		selfBillingError(ctx, err)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", document.FileName))
+	ctx.Data(http.StatusOK, document.ContentType, document.Content)
+}
+
+// ExportSelfBillingStatementsApi exports the approved statements of a month to accounts payable
+// @Summary Export self-billing statements
+// @Description Writes the approved statements of a month as purchase invoices and marks them exported. Set include_exported to export statements again.
+// @Tags Finance
+// @Produce text/csv
+// @Param month query string true "Month (YYYY-MM)"
+// @Param include_exported query bool false "Include statements that were exported before"
+// @Success 200 {file} file "Accounts payable CSV"
+// @Failure 400,401,422,500 {object} Response[any]
+// @Router /finance/self_billing_statements/export [get]
+func (server *Server) ExportSelfBillingStatementsApi(ctx *gin.Context) {
+	var req employees.ExportSelfBillingStatementsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	document, err := server.businessService.EmployeeService.ExportSelfBillingStatements(req, payload.EmployeeID, ctx)
+	if err != nil {
+		selfBillingError(ctx, err)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", document.FileName))
+	ctx.Data(http.StatusOK, document.ContentType, document.Content)
+}
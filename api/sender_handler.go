@@ -1,17 +1,25 @@
 package api
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"log"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/goccy/go-json"
 
 	db "maicare_go/db/sqlc"
 	"maicare_go/pagination"
+	"maicare_go/pdf"
+	"maicare_go/util"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
 )
 
 // Contact represents a contact information.
@@ -276,7 +284,7 @@ func (server *Server) GetSenderByIdAPI(ctx *gin.Context) {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
-	tmplids, err := server.store.GetTemplateItemsBySourceTable(ctx, sender.InvoiceTemplate)
+	tmplids, err := server.store.GetSenderTemplateItems(ctx, sender.ID)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
@@ -496,16 +504,28 @@ func (server *Server) CreateSenderInvoiceTemplateApi(ctx *gin.Context) {
 		return
 	}
 
+	// keep the order of the request, the items are printed on the invoice in that order
+	var templateIDs []int64
+	for _, id := range req.InvoiceTemplateIDs {
+		if !slices.Contains(templateIDs, id) {
+			templateIDs = append(templateIDs, id)
+		}
+	}
+
 	// verify that the ids exist in the template table
-	tmplids, err := server.store.GetTemplateItemsByIds(ctx, req.InvoiceTemplateIDs)
+	tmplids, err := server.store.GetTemplateItemsByIds(ctx, templateIDs)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
+	if len(tmplids) != len(templateIDs) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("unknown invoice template item")))
+		return
+	}
 
 	_, err = server.store.CreateSenderInvoiceTemplate(ctx, db.CreateSenderInvoiceTemplateParams{
 		ID:              senderID,
-		InvoiceTemplate: tmplids,
+		InvoiceTemplate: templateIDs,
 	})
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
@@ -515,3 +535,125 @@ func (server *Server) CreateSenderInvoiceTemplateApi(ctx *gin.Context) {
 	res := SuccessResponse[any](nil, "Sender invoice template created successfully")
 	ctx.JSON(http.StatusOK, res)
 }
+
+// PreviewSenderInvoiceTemplateRequest represents the query of an invoice template preview.
+type PreviewSenderInvoiceTemplateRequest struct {
+	ClientID   int64  `form:"client_id" binding:"required"`
+	ContractID int64  `form:"contract_id"`
+	Format     string `form:"format" binding:"omitempty,oneof=json pdf"`
+}
+
+// InvoiceTemplatePreviewItem represents a template item resolved for a client.
+type InvoiceTemplatePreviewItem struct {
+	ItemTag string `json:"item_tag"`
+	Label   string `json:"label"`
+	Value   string `json:"value"`
+}
+
+// PreviewSenderInvoiceTemplateResponse represents the response of an invoice template preview.
+type PreviewSenderInvoiceTemplateResponse struct {
+	SenderID int64                        `json:"sender_id"`
+	Items    []InvoiceTemplatePreviewItem `json:"items"`
+}
+
+// PreviewSenderInvoiceTemplateApi resolves the invoice template of a sender for a client.
+// @Summary Preview a sender invoice template
+// @Description Resolve the invoice template items of a sender for a client and contract, as JSON or as a sample invoice PDF
+// @Tags senders
+// @Produce json,application/pdf
+// @Param id path int true "Sender ID"
+// @Param client_id query int true "Client ID"
+// @Param contract_id query int false "Contract ID"
+// @Param format query string false "Preview format" Enums(json, pdf)
+// @Success 200 {object} Response[PreviewSenderInvoiceTemplateResponse]
+// @Failure 400,404,500 {object} Response[any]
+// @Router /senders/{id}/invoice_template/preview [get]
+func (server *Server) PreviewSenderInvoiceTemplateApi(ctx *gin.Context) {
+	id := ctx.Param("id")
+	senderID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req PreviewSenderInvoiceTemplateRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	sender, err := server.store.GetSenderById(ctx, senderID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	values, err := server.store.FetchInvoiceTemplateValues(ctx, db.FetchQueryData{
+		ClientID:   req.ClientID,
+		ContractID: req.ContractID,
+		SenderID:   senderID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if req.Format != "pdf" {
+		items := make([]InvoiceTemplatePreviewItem, 0, len(values))
+		for _, value := range values {
+			items = append(items, InvoiceTemplatePreviewItem{
+				ItemTag: value.ItemTag,
+				Label:   value.Label,
+				Value:   value.Value,
+			})
+		}
+		res := SuccessResponse(PreviewSenderInvoiceTemplateResponse{
+			SenderID: senderID,
+			Items:    items,
+		}, "Sender invoice template preview generated successfully")
+		ctx.JSON(http.StatusOK, res)
+		return
+	}
+
+	extraItems := make(map[string]string, len(values))
+	extraItemOrder := make([]string, 0, len(values))
+	for _, value := range values {
+		extraItems[value.Label] = value.Value
+		extraItemOrder = append(extraItemOrder, value.Label)
+	}
+
+	var contactPerson string
+	var senderContacts []SenderContact
+	if err := json.Unmarshal(sender.Contacts, &senderContacts); err == nil && len(senderContacts) > 0 {
+		contactPerson = util.DerefString(senderContacts[0].Name)
+	}
+
+	now := time.Now()
+	file, err := pdf.GenerateInvoicePDF(pdf.InvoicePDFData{
+		SenderName:           sender.Name,
+		SenderContactPerson:  contactPerson,
+		SenderAddressLine1:   util.DerefString(sender.Address),
+		SenderPostalCodeCity: strings.TrimSpace(util.DerefString(sender.PostalCode) + " " + util.DerefString(sender.Place)),
+		InvoiceNumber:        "PREVIEW",
+		InvoiceDate:          now,
+		DueDate:              now.AddDate(0, 0, 14),
+		ExtraItems:           extraItems,
+		ExtraItemOrder:       extraItemOrder,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(fmt.Errorf("failed to generate invoice preview PDF: %w", err)))
+		return
+	}
+	content, err := io.ReadAll(file)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", fmt.Sprintf("invoice_preview_%d.pdf", senderID)))
+	ctx.Data(http.StatusOK, "application/pdf", content)
+}
@@ -14,7 +14,8 @@ func (server *Server) setupSenderRoutes(baseRouter *gin.RouterGroup) {
 		// Future endpoints:
 		// senders.GET("/:id", server.GetSenderAPI)    // GET /senders/:id
 		// senders.PUT("/:id", server.UpdateSenderAPI) // PUT /senders/:id
-		senders.DELETE("/:id", server.RBACMiddleware("SENDER.DELETE"), server.DeleteSenderApi)                               // DELETE /senders/:id
-		senders.POST("/:id/invoice_template", server.RBACMiddleware("SENDER.CREATE"), server.CreateSenderInvoiceTemplateApi) // POST /senders/:id/invoice_template
+		senders.DELETE("/:id", server.RBACMiddleware("SENDER.DELETE"), server.DeleteSenderApi)                                     // DELETE /senders/:id
+		senders.POST("/:id/invoice_template", server.RBACMiddleware("SENDER.CREATE"), server.CreateSenderInvoiceTemplateApi)       // POST /senders/:id/invoice_template
+		senders.GET("/:id/invoice_template/preview", server.RBACMiddleware("SENDER.VIEW"), server.PreviewSenderInvoiceTemplateApi) // GET /senders/:id/invoice_template/preview
 	}
 }
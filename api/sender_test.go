@@ -496,3 +496,59 @@ func TestGetSenderByIdAPI(t *testing.T) {
 	}
 
 }
+
+func TestPreviewSenderInvoiceTemplateApi(t *testing.T) {
+	sender := createRandomSender(t)
+	client := createRandomClientDetails(t)
+	contract := createRandomContract(t, client.ID, &sender.ID)
+
+	testCases := []struct {
+		name          string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildRequest  func() (*http.Request, error)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, sender.ID, time.Minute)
+			},
+			buildRequest: func() (*http.Request, error) {
+				url := fmt.Sprintf("/senders/%d/invoice_template/preview?client_id=%d&contract_id=%d", sender.ID, client.ID, contract.ID)
+				return http.NewRequest(http.MethodGet, url, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var response Response[PreviewSenderInvoiceTemplateResponse]
+				err := json.NewDecoder(recorder.Body).Decode(&response)
+				require.NoError(t, err)
+				require.Equal(t, sender.ID, response.Data.SenderID)
+				require.NotEmpty(t, response.Data.Items)
+				require.Equal(t, "client.date_of_birth", response.Data.Items[0].ItemTag)
+			},
+		},
+		{
+			name: "Missing client",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, sender.ID, time.Minute)
+			},
+			buildRequest: func() (*http.Request, error) {
+				url := fmt.Sprintf("/senders/%d/invoice_template/preview", sender.ID)
+				return http.NewRequest(http.MethodGet, url, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			request, err := tc.buildRequest()
+			require.NoError(t, err)
+			tc.setupAuth(t, request, testServer.tokenMaker)
+			testServer.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -95,11 +95,13 @@ func NewServer(store *db.Store, b2Client bucket.ObjectStorageInterface,
 	docs.SwaggerInfo.BasePath = "/"
 	docs.SwaggerInfo.Schemes = []string{"http", "https"}
 
-	server.setupRoutes()
+	if err := server.setupRoutes(); err != nil {
+		return nil, err
+	}
 	return server, nil
 }
 
-func (server *Server) setupRoutes() {
+func (server *Server) setupRoutes() error {
 	gin.SetMode(func() string {
 		if server.config.Environment == "production" {
 			return gin.ReleaseMode
@@ -107,6 +109,14 @@ func (server *Server) setupRoutes() {
 		return gin.DebugMode
 	}())
 	router := gin.New()
+	// employees clock in from the networks of their location, so the client IP is only
+	// taken from forwarding headers when the request comes from one of our own proxies
+	if err := router.SetTrustedProxies(server.config.TrustedProxies); err != nil {
+		return fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+	if len(server.config.TrustedProxies) == 0 && server.config.Environment == "production" {
+		server.logger.Warn("TRUSTED_PROXIES is not set, every request is logged and audited with the IP of the proxy in front of the API and clock-in networks will not match")
+	}
 
 	corsConf := cors.DefaultConfig()
 	corsConf.AllowOrigins = []string{"*"}
@@ -149,12 +159,19 @@ func (server *Server) setupRoutes() {
 	server.setupShiftsRoutes(baseRouter)
 	server.setupWorkingHours(baseRouter)
 	server.setupInvoiceRoutes(baseRouter)
+	server.setupBankStatementRoutes(baseRouter)
+	server.setupFinanceRoutes(baseRouter)
 	server.setupNotificationRoutes(baseRouter)
+	server.setupCalendarFeedRoutes(baseRouter)
+	server.setupLeaveRoutes(baseRouter)
+	server.setupAvailabilityRoutes(baseRouter)
+	server.setupShiftMarketplaceRoutes(baseRouter)
 	// Add more route setups as needed
 
 	server.setupWebsocketRoutes(baseRouter)
 
 	server.router = router
+	return nil
 }
 
 func (server *Server) Start() error {
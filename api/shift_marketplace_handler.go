@@ -0,0 +1,434 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"maicare_go/service/schedule"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func shiftMarketplaceError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, schedule.ErrScheduleNotFound), errors.Is(err, schedule.ErrEmployeeNotFound), errors.Is(err, schedule.ErrSwapRequestNotFound):
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+	case errors.Is(err, schedule.ErrNotScheduledEmployee), errors.Is(err, schedule.ErrNotOwnSwapRequest), errors.Is(err, schedule.ErrOwnSwapRequest):
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+	case errors.Is(err, schedule.ErrShiftTaken), errors.Is(err, schedule.ErrShiftAlreadyOpen), errors.Is(err, schedule.ErrShiftStarted),
+		errors.Is(err, schedule.ErrNotEligible), errors.Is(err, schedule.ErrSwapRequestClosed):
+		ctx.JSON(http.StatusConflict, errorResponse(err))
+	case errors.Is(err, schedule.ErrInvalidOpenShift), errors.Is(err, schedule.ErrInvalidSwapRequest):
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	}
+}
+
+// CreateOpenShiftApi adds an open shift to a location
+// @Summary Create an open shift
+// @Description Add a shift without an employee to a location, for eligible employees to claim. Give location_shift_id and shift_date for a preset shift, or start_datetime and end_datetime for a custom shift. Open shifts do not appear in the monthly and daily schedules of the location.
+// @Tags Shift Marketplace
+// @Accept json
+// @Produce json
+// @Param id path int true "Location ID"
+// @Param request body schedule.CreateOpenShiftRequest true "Open shift"
+// @Success 201 {object} Response[schedule.OpenShiftResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 409 {object} Response[any] "The shift starts in the past"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /locations/{id}/open_shifts [post]
+func (server *Server) CreateOpenShiftApi(ctx *gin.Context) {
+	locationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid location ID")))
+		return
+	}
+	var req schedule.CreateOpenShiftRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(fmt.Errorf("failed to get auth payload")))
+		return
+	}
+
+	shift, err := server.businessService.ScheduleService.CreateOpenShift(ctx, locationID, payload.EmployeeID, &req)
+	if err != nil {
+		shiftMarketplaceError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(shift, "Open shift created successfully")
+	ctx.JSON(http.StatusCreated, res)
+}
+
+// ListOpenShiftsApi lists the open shifts of a location
+// @Summary List the open shifts of a location
+// @Description List the open shifts of a location that have not started yet
+// @Tags Shift Marketplace
+// @Produce json
+// @Param id path int true "Location ID"
+// @Success 200 {object} Response[[]schedule.OpenShiftResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /locations/{id}/open_shifts [get]
+func (server *Server) ListOpenShiftsApi(ctx *gin.Context) {
+	locationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid location ID")))
+		return
+	}
+
+	shifts, err := server.businessService.ScheduleService.ListOpenShifts(ctx, locationID)
+	if err != nil {
+		shiftMarketplaceError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(shifts, "Open shifts retrieved successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// ListMyOpenShiftsApi lists the open shifts the logged in employee can see
+// @Summary List my open shifts
+// @Description List the open shifts at the location of the logged in employee and the locations they prefer, with whether they can claim each of them and why not
+// @Tags Shift Marketplace
+// @Produce json
+// @Success 200 {object} Response[[]schedule.OpenShiftResponse]
+// @Failure 404 {object} Response[any] "Employee not found"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /open_shifts [get]
+func (server *Server) ListMyOpenShiftsApi(ctx *gin.Context) {
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(fmt.Errorf("failed to get auth payload")))
+		return
+	}
+
+	shifts, err := server.businessService.ScheduleService.ListEmployeeOpenShifts(ctx, payload.EmployeeID)
+	if err != nil {
+		shiftMarketplaceError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(shifts, "Open shifts retrieved successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// ClaimOpenShiftApi assigns an open shift to the logged in employee
+// @Summary Claim an open shift
+// @Description Take over an open shift. The employee has to work at its location or prefer to, hold the certifications the staffing norm of the shift would lack and be free of conflicting shifts, absences and working time violations. The employee and the planners of the location are notified.
+// @Tags Shift Marketplace
+// @Produce json
+// @Param id path string true "Schedule ID"
+// @Success 200 {object} Response[schedule.OpenShiftResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 404 {object} Response[any] "Shift not found"
+// @Failure 409 {object} Response[any] "Taken, started or the employee is not eligible"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /open_shifts/{id}/claim [post]
+func (server *Server) ClaimOpenShiftApi(ctx *gin.Context) {
+	scheduleID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid schedule ID format")))
+		return
+	}
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(fmt.Errorf("failed to get auth payload")))
+		return
+	}
+
+	shift, err := server.businessService.ScheduleService.ClaimOpenShift(ctx, scheduleID, payload.EmployeeID)
+	if err != nil {
+		shiftMarketplaceError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(shift, "Open shift claimed successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// ReleaseShiftApi turns a shift into an open shift
+// @Summary Release a shift
+// @Description Take a shift that has not started off its employee, for example after a sick report, and offer it as an open shift. Open swap requests of the shift are cancelled.
+// @Tags Shift Marketplace
+// @Produce json
+// @Param id path string true "Schedule ID"
+// @Success 200 {object} Response[schedule.OpenShiftResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 404 {object} Response[any] "Schedule not found"
+// @Failure 409 {object} Response[any] "Already open or started"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /schedules/{id}/release [post]
+func (server *Server) ReleaseShiftApi(ctx *gin.Context) {
+	scheduleID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid schedule ID format")))
+		return
+	}
+
+	shift, err := server.businessService.ScheduleService.ReleaseShift(ctx, scheduleID)
+	if err != nil {
+		shiftMarketplaceError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(shift, "Shift released successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// CreateShiftSwapRequestApi asks a colleague to take over a shift
+// @Summary Request a shift swap
+// @Description Ask a colleague to take over one of your shifts, in exchange for one of their shifts when target_schedule_id is given. Both have to be eligible for the shift they get. The colleague is notified and accepts or declines, then a planner approves or rejects the swap.
+// @Tags Shift Marketplace
+// @Accept json
+// @Produce json
+// @Param request body schedule.CreateShiftSwapRequestRequest true "Swap request"
+// @Success 201 {object} Response[schedule.ShiftSwapRequestResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 403 {object} Response[any] "Not your shift"
+// @Failure 404 {object} Response[any] "Shift or colleague not found"
+// @Failure 409 {object} Response[any] "Started or not eligible"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /shift_swaps [post]
+func (server *Server) CreateShiftSwapRequestApi(ctx *gin.Context) {
+	var req schedule.CreateShiftSwapRequestRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(fmt.Errorf("failed to get auth payload")))
+		return
+	}
+
+	swap, err := server.businessService.ScheduleService.CreateShiftSwapRequest(ctx, payload.EmployeeID, &req)
+	if err != nil {
+		shiftMarketplaceError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(swap, "Swap requested successfully")
+	ctx.JSON(http.StatusCreated, res)
+}
+
+// ListMyShiftSwapRequestsApi lists the swap requests of the logged in employee
+// @Summary List my swap requests
+// @Description List the swap requests the logged in employee made or is asked to answer
+// @Tags Shift Marketplace
+// @Produce json
+// @Param status query string false "Status" Enums(pending, accepted, declined, approved, rejected, cancelled)
+// @Success 200 {object} Response[[]schedule.ShiftSwapRequestResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /shift_swaps/me [get]
+func (server *Server) ListMyShiftSwapRequestsApi(ctx *gin.Context) {
+	var req schedule.ListShiftSwapRequestsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(fmt.Errorf("failed to get auth payload")))
+		return
+	}
+	req.EmployeeID = &payload.EmployeeID
+	req.LocationID = nil
+
+	swaps, err := server.businessService.ScheduleService.ListShiftSwapRequests(ctx, &req)
+	if err != nil {
+		shiftMarketplaceError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(swaps, "Swap requests retrieved successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// ListShiftSwapRequestsApi lists the swap requests for planners
+// @Summary List swap requests
+// @Description List the swap requests, accepted ones wait for approval
+// @Tags Shift Marketplace
+// @Produce json
+// @Param employee_id query int false "Only the requests this employee made or is asked to answer"
+// @Param location_id query int false "Only the requests for shifts of the location"
+// @Param status query string false "Status" Enums(pending, accepted, declined, approved, rejected, cancelled)
+// @Success 200 {object} Response[[]schedule.ShiftSwapRequestResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /shift_swaps [get]
+func (server *Server) ListShiftSwapRequestsApi(ctx *gin.Context) {
+	var req schedule.ListShiftSwapRequestsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	swaps, err := server.businessService.ScheduleService.ListShiftSwapRequests(ctx, &req)
+	if err != nil {
+		shiftMarketplaceError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(swaps, "Swap requests retrieved successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// answerShiftSwapRequest handles the steps the requester and target of a swap take
+func (server *Server) answerShiftSwapRequest(ctx *gin.Context, message string, answer func(swapRequestID, employeeID int64) (*schedule.ShiftSwapRequestResponse, error)) {
+	swapRequestID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid swap request ID")))
+		return
+	}
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(fmt.Errorf("failed to get auth payload")))
+		return
+	}
+
+	swap, err := answer(swapRequestID, payload.EmployeeID)
+	if err != nil {
+		shiftMarketplaceError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(swap, message)
+	ctx.JSON(http.StatusOK, res)
+}
+
+// AcceptShiftSwapRequestApi accepts a swap request
+// @Summary Accept a swap request
+// @Description Agree to take over the shift of the colleague that asked you. The swap then waits for a planner, who is notified.
+// @Tags Shift Marketplace
+// @Produce json
+// @Param id path int true "Swap request ID"
+// @Success 200 {object} Response[schedule.ShiftSwapRequestResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 403 {object} Response[any] "Meant for another employee"
+// @Failure 404 {object} Response[any] "Swap request not found"
+// @Failure 409 {object} Response[any] "Not pending, the shifts changed or not eligible"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /shift_swaps/{id}/accept [post]
+func (server *Server) AcceptShiftSwapRequestApi(ctx *gin.Context) {
+	server.answerShiftSwapRequest(ctx, "Swap request accepted successfully", func(swapRequestID, employeeID int64) (*schedule.ShiftSwapRequestResponse, error) {
+		return server.businessService.ScheduleService.AcceptShiftSwapRequest(ctx, swapRequestID, employeeID)
+	})
+}
+
+// DeclineShiftSwapRequestApi declines a swap request
+// @Summary Decline a swap request
+// @Description Turn down the request of a colleague to take over their shift
+// @Tags Shift Marketplace
+// @Produce json
+// @Param id path int true "Swap request ID"
+// @Success 200 {object} Response[schedule.ShiftSwapRequestResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 403 {object} Response[any] "Meant for another employee"
+// @Failure 404 {object} Response[any] "Swap request not found"
+// @Failure 409 {object} Response[any] "The swap request is not pending"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /shift_swaps/{id}/decline [post]
+func (server *Server) DeclineShiftSwapRequestApi(ctx *gin.Context) {
+	server.answerShiftSwapRequest(ctx, "Swap request declined successfully", func(swapRequestID, employeeID int64) (*schedule.ShiftSwapRequestResponse, error) {
+		return server.businessService.ScheduleService.DeclineShiftSwapRequest(ctx, swapRequestID, employeeID)
+	})
+}
+
+// CancelShiftSwapRequestApi cancels a swap request of the logged in employee
+// @Summary Cancel my swap request
+// @Description Withdraw one of your own swap requests before a planner reviews it
+// @Tags Shift Marketplace
+// @Produce json
+// @Param id path int true "Swap request ID"
+// @Success 200 {object} Response[schedule.ShiftSwapRequestResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 403 {object} Response[any] "Not your swap request"
+// @Failure 404 {object} Response[any] "Swap request not found"
+// @Failure 409 {object} Response[any] "The swap request can no longer be cancelled"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /shift_swaps/{id}/cancel [post]
+func (server *Server) CancelShiftSwapRequestApi(ctx *gin.Context) {
+	server.answerShiftSwapRequest(ctx, "Swap request cancelled successfully", func(swapRequestID, employeeID int64) (*schedule.ShiftSwapRequestResponse, error) {
+		return server.businessService.ScheduleService.CancelShiftSwapRequest(ctx, swapRequestID, employeeID)
+	})
+}
+
+func (server *Server) reviewShiftSwapRequest(ctx *gin.Context, message string, review func(swapRequestID, employeeID int64, req *schedule.ReviewShiftSwapRequestRequest) (*schedule.ShiftSwapRequestResponse, error)) {
+	swapRequestID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid swap request ID")))
+		return
+	}
+	var req schedule.ReviewShiftSwapRequestRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(fmt.Errorf("failed to get auth payload")))
+		return
+	}
+
+	swap, err := review(swapRequestID, payload.EmployeeID, &req)
+	if err != nil {
+		shiftMarketplaceError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(swap, message)
+	ctx.JSON(http.StatusOK, res)
+}
+
+// ApproveShiftSwapRequestApi approves a swap request
+// @Summary Approve a swap request
+// @Description Approve an accepted swap of other employees. Eligibility is checked again, then the shifts move to their new employees, other open swap requests of the shifts are cancelled and the employees and planners are notified.
+// @Tags Shift Marketplace
+// @Accept json
+// @Produce json
+// @Param id path int true "Swap request ID"
+// @Param request body schedule.ReviewShiftSwapRequestRequest false "Note"
+// @Success 200 {object} Response[schedule.ShiftSwapRequestResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 403 {object} Response[any] "Own swap request"
+// @Failure 404 {object} Response[any] "Swap request not found"
+// @Failure 409 {object} Response[any] "Not accepted, the shifts changed or not eligible"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /shift_swaps/{id}/approve [post]
+func (server *Server) ApproveShiftSwapRequestApi(ctx *gin.Context) {
+	server.reviewShiftSwapRequest(ctx, "Swap request approved successfully", func(swapRequestID, employeeID int64, req *schedule.ReviewShiftSwapRequestRequest) (*schedule.ShiftSwapRequestResponse, error) {
+		return server.businessService.ScheduleService.ApproveShiftSwapRequest(ctx, swapRequestID, employeeID, req)
+	})
+}
+
+// RejectShiftSwapRequestApi rejects a swap request
+// @Summary Reject a swap request
+// @Description Reject an accepted swap of other employees, the shifts stay with their employees
+// @Tags Shift Marketplace
+// @Accept json
+// @Produce json
+// @Param id path int true "Swap request ID"
+// @Param request body schedule.ReviewShiftSwapRequestRequest false "Note"
+// @Success 200 {object} Response[schedule.ShiftSwapRequestResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 403 {object} Response[any] "Own swap request"
+// @Failure 404 {object} Response[any] "Swap request not found"
+// @Failure 409 {object} Response[any] "The swap request is not accepted"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /shift_swaps/{id}/reject [post]
+func (server *Server) RejectShiftSwapRequestApi(ctx *gin.Context) {
+	server.reviewShiftSwapRequest(ctx, "Swap request rejected successfully", func(swapRequestID, employeeID int64, req *schedule.ReviewShiftSwapRequestRequest) (*schedule.ShiftSwapRequestResponse, error) {
+		return server.businessService.ScheduleService.RejectShiftSwapRequest(ctx, swapRequestID, employeeID, req)
+	})
+}
@@ -0,0 +1,25 @@
+package api
+
+import "github.com/gin-gonic/gin"
+
+func (server *Server) setupShiftMarketplaceRoutes(baseRouter *gin.RouterGroup) {
+	marketplace := baseRouter.Group("")
+	marketplace.Use(server.AuthMiddleware())
+	{
+		// every employee claims open shifts and swaps their own shifts
+		marketplace.GET("/open_shifts", server.ListMyOpenShiftsApi)
+		marketplace.POST("/open_shifts/:id/claim", server.ClaimOpenShiftApi)
+		marketplace.POST("/shift_swaps", server.CreateShiftSwapRequestApi)
+		marketplace.GET("/shift_swaps/me", server.ListMyShiftSwapRequestsApi)
+		marketplace.POST("/shift_swaps/:id/accept", server.AcceptShiftSwapRequestApi)
+		marketplace.POST("/shift_swaps/:id/decline", server.DeclineShiftSwapRequestApi)
+		marketplace.POST("/shift_swaps/:id/cancel", server.CancelShiftSwapRequestApi)
+
+		marketplace.POST("/locations/:id/open_shifts", server.RBACMiddleware("SCHEDULE.CREATE"), server.CreateOpenShiftApi)
+		marketplace.GET("/locations/:id/open_shifts", server.RBACMiddleware("SCHEDULE.VIEW"), server.ListOpenShiftsApi)
+		marketplace.POST("/schedules/:id/release", server.RBACMiddleware("SCHEDULE.UPDATE"), server.ReleaseShiftApi)
+		marketplace.GET("/shift_swaps", server.RBACMiddleware("SCHEDULE.VIEW"), server.ListShiftSwapRequestsApi)
+		marketplace.POST("/shift_swaps/:id/approve", server.RBACMiddleware("SCHEDULE.UPDATE"), server.ApproveShiftSwapRequestApi)
+		marketplace.POST("/shift_swaps/:id/reject", server.RBACMiddleware("SCHEDULE.UPDATE"), server.RejectShiftSwapRequestApi)
+	}
+}
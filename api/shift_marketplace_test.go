@@ -0,0 +1,67 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"maicare_go/service/schedule"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestShiftMarketplaceApi(t *testing.T) {
+	employee, user := createRandomEmployee(t)
+	_, other := createRandomEmployee(t)
+	locationID := *employee.LocationID
+
+	start := time.Now().AddDate(0, 0, 10).Truncate(24 * time.Hour).Add(7 * time.Hour).UTC()
+	end := start.Add(8 * time.Hour)
+	recorder := sendRequest(t, 1, http.MethodPost, fmt.Sprintf("/locations/%d/open_shifts", locationID), schedule.CreateOpenShiftRequest{StartDatetime: &end, EndDatetime: &start})
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	recorder = sendRequest(t, 1, http.MethodPost, fmt.Sprintf("/locations/%d/open_shifts", locationID), schedule.CreateOpenShiftRequest{StartDatetime: &start, EndDatetime: &end})
+	t.Log(recorder.Body.String())
+	require.Equal(t, http.StatusCreated, recorder.Code)
+	var created Response[schedule.OpenShiftResponse]
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &created))
+	require.Nil(t, created.Data.EmployeeID)
+	require.Equal(t, locationID, created.Data.LocationID)
+
+	recorder = sendRequest(t, 1, http.MethodGet, fmt.Sprintf("/locations/%d/open_shifts", locationID), nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var listed Response[[]schedule.OpenShiftResponse]
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &listed))
+	require.Len(t, listed.Data, 1)
+	require.Equal(t, created.Data.ScheduleID, listed.Data[0].ScheduleID)
+
+	recorder = sendRequest(t, user.ID, http.MethodGet, "/open_shifts", nil)
+	t.Log(recorder.Body.String())
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var mine Response[[]schedule.OpenShiftResponse]
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &mine))
+	require.Len(t, mine.Data, 1)
+	require.NotNil(t, mine.Data[0].Eligibility)
+	require.True(t, mine.Data[0].Eligibility.Eligible)
+
+	testasynqClient.EXPECT().EnqueueNotificationTask(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+	claim := fmt.Sprintf("/open_shifts/%s/claim", created.Data.ScheduleID)
+	recorder = sendRequest(t, user.ID, http.MethodPost, claim, nil)
+	t.Log(recorder.Body.String())
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var claimed Response[schedule.OpenShiftResponse]
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &claimed))
+	require.Equal(t, employee.ID, *claimed.Data.EmployeeID)
+
+	// a shift is claimed only once
+	recorder = sendRequest(t, other.ID, http.MethodPost, claim, nil)
+	require.Equal(t, http.StatusConflict, recorder.Code)
+
+	recorder = sendRequest(t, user.ID, http.MethodPost, "/open_shifts/not-a-uuid/claim", nil)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	recorder = sendRequest(t, user.ID, http.MethodGet, "/shift_swaps/me?status=unknown", nil)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
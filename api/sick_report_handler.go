@@ -0,0 +1,226 @@
+package api
+
+import (
+	"fmt"
+	"maicare_go/service/leave"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateSickReportApi reports an employee sick
+// @Summary Report an employee sick
+// @Description Report an employee sick from the start date, today when not given. The shifts of the employee until the expected return, or in the coming week, are returned as affected_shifts and the planners of their locations are notified that they need a replacement.
+// @Tags Sick Reports
+// @Accept json
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Param request body leave.CreateSickReportRequest true "Sick report"
+// @Success 201 {object} Response[leave.SickReportResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 404 {object} Response[any] "Employee not found"
+// @Failure 409 {object} Response[any] "The employee is already reported sick"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /employees/{id}/sick_reports [post]
+func (server *Server) CreateSickReportApi(ctx *gin.Context) {
+	employeeID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid employee ID")))
+		return
+	}
+	var req leave.CreateSickReportRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(fmt.Errorf("failed to get auth payload")))
+		return
+	}
+
+	report, err := server.businessService.LeaveService.CreateSickReport(ctx, employeeID, payload.EmployeeID, &req)
+	if err != nil {
+		leaveError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(report, "Sick report created successfully")
+	ctx.JSON(http.StatusCreated, res)
+}
+
+// ListSickReportsApi lists the sick reports
+// @Summary List sick reports
+// @Description List the sick reports with their poortwachter milestones, the latest first
+// @Tags Sick Reports
+// @Produce json
+// @Param employee_id query int false "Only this employee"
+// @Param open query bool false "Only running (true) or ended (false) sick reports"
+// @Success 200 {object} Response[[]leave.SickReportResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /sick_reports [get]
+func (server *Server) ListSickReportsApi(ctx *gin.Context) {
+	var req leave.ListSickReportsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	reports, err := server.businessService.LeaveService.ListSickReports(ctx, &req)
+	if err != nil {
+		leaveError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(reports, "Sick reports retrieved successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// GetSickReportApi returns a sick report
+// @Summary Get a sick report
+// @Description Get a sick report with the steps of the Wet verbetering poortwachter and when they are due
+// @Tags Sick Reports
+// @Produce json
+// @Param id path int true "Sick report ID"
+// @Success 200 {object} Response[leave.SickReportResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 404 {object} Response[any] "Sick report not found"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /sick_reports/{id} [get]
+func (server *Server) GetSickReportApi(ctx *gin.Context) {
+	sickReportID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid sick report ID")))
+		return
+	}
+
+	report, err := server.businessService.LeaveService.GetSickReport(ctx, sickReportID)
+	if err != nil {
+		leaveError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(report, "Sick report retrieved successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// UpdateSickReportApi updates a running sick report
+// @Summary Update a sick report
+// @Description Change the expected return and notes of a running sick report. When the employee is expected back later, the shifts that now need a replacement are returned as affected_shifts and their planners are notified.
+// @Tags Sick Reports
+// @Accept json
+// @Produce json
+// @Param id path int true "Sick report ID"
+// @Param request body leave.UpdateSickReportRequest true "Sick report"
+// @Success 200 {object} Response[leave.SickReportResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 404 {object} Response[any] "Sick report not found"
+// @Failure 409 {object} Response[any] "The employee has already recovered"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /sick_reports/{id} [put]
+func (server *Server) UpdateSickReportApi(ctx *gin.Context) {
+	sickReportID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid sick report ID")))
+		return
+	}
+	var req leave.UpdateSickReportRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	report, err := server.businessService.LeaveService.UpdateSickReport(ctx, sickReportID, &req)
+	if err != nil {
+		leaveError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(report, "Sick report updated successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// RecoverSickReportApi ends a sick report
+// @Summary Report an employee recovered
+// @Description End a running sick report, recovered_on is the first day the employee works again, today when not given
+// @Tags Sick Reports
+// @Accept json
+// @Produce json
+// @Param id path int true "Sick report ID"
+// @Param request body leave.RecoverSickReportRequest false "Recovery"
+// @Success 200 {object} Response[leave.SickReportResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 404 {object} Response[any] "Sick report not found"
+// @Failure 409 {object} Response[any] "The employee has already recovered"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /sick_reports/{id}/recover [post]
+func (server *Server) RecoverSickReportApi(ctx *gin.Context) {
+	sickReportID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid sick report ID")))
+		return
+	}
+	var req leave.RecoverSickReportRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+
+	report, err := server.businessService.LeaveService.RecoverSickReport(ctx, sickReportID, &req)
+	if err != nil {
+		leaveError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(report, "Sick report ended successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// CompleteSickReportMilestoneApi records a poortwachter step of a sick report
+// @Summary Complete a poortwachter milestone
+// @Description Record that a step of the Wet verbetering poortwachter has been taken for a sick report
+// @Tags Sick Reports
+// @Accept json
+// @Produce json
+// @Param id path int true "Sick report ID"
+// @Param milestone path string true "Milestone" Enums(occupational_health_report, problem_analysis, action_plan, uwv_notification, first_year_evaluation, wia_application, end_of_wage_continuation)
+// @Param request body leave.CompleteMilestoneRequest false "Completion"
+// @Success 200 {object} Response[leave.SickReportResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 404 {object} Response[any] "Sick report or milestone not found"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /sick_reports/{id}/milestones/{milestone} [post]
+func (server *Server) CompleteSickReportMilestoneApi(ctx *gin.Context) {
+	sickReportID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid sick report ID")))
+		return
+	}
+	var req leave.CompleteMilestoneRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(fmt.Errorf("failed to get auth payload")))
+		return
+	}
+
+	report, err := server.businessService.LeaveService.CompleteSickReportMilestone(ctx, sickReportID, ctx.Param("milestone"), payload.EmployeeID, &req)
+	if err != nil {
+		leaveError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(report, "Milestone completed successfully")
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,211 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"maicare_go/service/schedule"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateStaffingNormApi creates a staffing norm for a shift of a location
+// @Summary Create a staffing norm
+// @Description Set the minimum staff of a shift of a location and how many of them need a certification. Without weekday the norm applies to every day, a norm for a weekday (0 is Sunday) overrides it on that day.
+// @Tags Schedule
+// @Accept json
+// @Produce json
+// @Param id path int true "Location ID"
+// @Param request body schedule.StaffingNormRequest true "Staffing norm"
+// @Success 201 {object} Response[schedule.StaffingNormResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 409 {object} Response[any] "The shift already has a norm for the weekday"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /locations/{id}/staffing_norms [post]
+func (server *Server) CreateStaffingNormApi(ctx *gin.Context) {
+	locationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid location ID")))
+		return
+	}
+
+	var req schedule.StaffingNormRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	norm, err := server.businessService.ScheduleService.CreateStaffingNorm(ctx, locationID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, schedule.ErrInvalidStaffingNorm):
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		case errors.Is(err, schedule.ErrStaffingNormExists):
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	res := SuccessResponse(norm, "Staffing norm created successfully")
+	ctx.JSON(http.StatusCreated, res)
+}
+
+// ListStaffingNormsApi lists the staffing norms of a location
+// @Summary List staffing norms
+// @Description List the staffing norms of the shifts of a location
+// @Tags Schedule
+// @Produce json
+// @Param id path int true "Location ID"
+// @Success 200 {object} Response[[]schedule.StaffingNormResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /locations/{id}/staffing_norms [get]
+func (server *Server) ListStaffingNormsApi(ctx *gin.Context) {
+	locationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid location ID")))
+		return
+	}
+
+	norms, err := server.businessService.ScheduleService.ListStaffingNorms(ctx, locationID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := SuccessResponse(norms, "Staffing norms retrieved successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// UpdateStaffingNormApi updates a staffing norm
+// @Summary Update a staffing norm
+// @Description Change the weekday, minimum staff and certifications of a staffing norm
+// @Tags Schedule
+// @Accept json
+// @Produce json
+// @Param id path int true "Location ID"
+// @Param norm_id path int true "Staffing norm ID"
+// @Param request body schedule.UpdateStaffingNormRequest true "Staffing norm"
+// @Success 200 {object} Response[schedule.StaffingNormResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 404 {object} Response[any] "Staffing norm not found"
+// @Failure 409 {object} Response[any] "The shift already has a norm for the weekday"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /locations/{id}/staffing_norms/{norm_id} [put]
+func (server *Server) UpdateStaffingNormApi(ctx *gin.Context) {
+	locationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid location ID")))
+		return
+	}
+	normID, err := strconv.ParseInt(ctx.Param("norm_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid staffing norm ID")))
+		return
+	}
+
+	var req schedule.UpdateStaffingNormRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	norm, err := server.businessService.ScheduleService.UpdateStaffingNorm(ctx, locationID, normID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, schedule.ErrInvalidStaffingNorm):
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		case errors.Is(err, schedule.ErrStaffingNormNotFound):
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+		case errors.Is(err, schedule.ErrStaffingNormExists):
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	res := SuccessResponse(norm, "Staffing norm updated successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// DeleteStaffingNormApi deletes a staffing norm
+// @Summary Delete a staffing norm
+// @Description Delete a staffing norm of a location
+// @Tags Schedule
+// @Produce json
+// @Param id path int true "Location ID"
+// @Param norm_id path int true "Staffing norm ID"
+// @Success 200 {object} Response[any]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 404 {object} Response[any] "Staffing norm not found"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /locations/{id}/staffing_norms/{norm_id} [delete]
+func (server *Server) DeleteStaffingNormApi(ctx *gin.Context) {
+	locationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid location ID")))
+		return
+	}
+	normID, err := strconv.ParseInt(ctx.Param("norm_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid staffing norm ID")))
+		return
+	}
+
+	err = server.businessService.ScheduleService.DeleteStaffingNorm(ctx, locationID, normID)
+	if err != nil {
+		if errors.Is(err, schedule.ErrStaffingNormNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := SuccessResponse[any](nil, "Staffing norm deleted successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// GetStaffingCoverageApi reports the staffing of the shifts of a location
+// @Summary Get the staffing coverage of a location
+// @Description Compare the scheduled staff of every shift with a staffing norm with the norm, for each day from start_date to end_date (at most 92 days later). Shifts with fewer staff than the norm are understaffed, shifts with too few certified staff list the missing certifications.
+// @Tags Schedule
+// @Produce json
+// @Param id path int true "Location ID"
+// @Param start_date query string true "First day (YYYY-MM-DD)"
+// @Param end_date query string true "Last day (YYYY-MM-DD)"
+// @Param only_gaps query bool false "Only return shifts with a gap"
+// @Success 200 {object} Response[schedule.StaffingCoverageResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /locations/{id}/staffing_coverage [get]
+func (server *Server) GetStaffingCoverageApi(ctx *gin.Context) {
+	locationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid location ID")))
+		return
+	}
+
+	var req schedule.StaffingCoverageRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	coverage, err := server.businessService.ScheduleService.GetStaffingCoverage(ctx, locationID, &req)
+	if err != nil {
+		if errors.Is(err, schedule.ErrInvalidDateRange) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := SuccessResponse(coverage, "Staffing coverage retrieved successfully")
+	ctx.JSON(http.StatusOK, res)
+}
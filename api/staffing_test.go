@@ -0,0 +1,66 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"maicare_go/service/schedule"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaffingNormsApi(t *testing.T) {
+	location := createRandomLocation(t)
+	shifts, err := testStore.GetShiftsByLocationID(context.Background(), location.ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, shifts)
+
+	norms := fmt.Sprintf("/locations/%d/staffing_norms", location.ID)
+	recorder := sendRequest(t, 1, http.MethodPost, norms, schedule.StaffingNormRequest{
+		LocationShiftID: shifts[0].ID,
+		MinStaff:        2,
+		Certifications:  []schedule.CertificationRequirement{{Name: "BHV", Count: 1}},
+	})
+	t.Log(recorder.Body.String())
+	require.Equal(t, http.StatusCreated, recorder.Code)
+	var created Response[schedule.StaffingNormResponse]
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &created))
+	require.Equal(t, int32(2), created.Data.MinStaff)
+	require.Nil(t, created.Data.Weekday)
+
+	// a second norm for every day of the same shift
+	recorder = sendRequest(t, 1, http.MethodPost, norms, schedule.StaffingNormRequest{LocationShiftID: shifts[0].ID, MinStaff: 1})
+	require.Equal(t, http.StatusConflict, recorder.Code)
+
+	recorder = sendRequest(t, 1, http.MethodPut, fmt.Sprintf("%s/%d", norms, created.Data.ID), schedule.UpdateStaffingNormRequest{MinStaff: 3})
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	recorder = sendRequest(t, 1, http.MethodGet, norms, nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var list Response[[]schedule.StaffingNormResponse]
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &list))
+	require.Len(t, list.Data, 1)
+	require.Equal(t, int32(3), list.Data[0].MinStaff)
+	require.Empty(t, list.Data[0].Certifications)
+
+	// nobody is scheduled, so every day is understaffed
+	start := time.Now().AddDate(1, 0, 0)
+	recorder = sendRequest(t, 1, http.MethodGet, fmt.Sprintf("/locations/%d/staffing_coverage?start_date=%s&end_date=%s&only_gaps=true",
+		location.ID, start.Format("2006-01-02"), start.AddDate(0, 0, 6).Format("2006-01-02")), nil)
+	t.Log(recorder.Body.String())
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var coverage Response[schedule.StaffingCoverageResponse]
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &coverage))
+	require.Equal(t, 7, coverage.Data.Gaps)
+	require.Len(t, coverage.Data.Shifts, 7)
+	require.True(t, coverage.Data.Shifts[0].Understaffed)
+
+	recorder = sendRequest(t, 1, http.MethodDelete, fmt.Sprintf("%s/%d", norms, created.Data.ID), nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	recorder = sendRequest(t, 1, http.MethodDelete, fmt.Sprintf("%s/%d", norms, created.Data.ID), nil)
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+}
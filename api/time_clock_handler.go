@@ -0,0 +1,460 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"maicare_go/service/schedule"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func timeClockError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, schedule.ErrScheduleNotFound), errors.Is(err, schedule.ErrTimeEntryNotFound):
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+	case errors.Is(err, schedule.ErrNotScheduledEmployee), errors.Is(err, schedule.ErrLocationNotVerified), errors.Is(err, schedule.ErrOwnTimeEntry):
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+	case errors.Is(err, schedule.ErrOutsideClockWindow), errors.Is(err, schedule.ErrAlreadyClockedIn), errors.Is(err, schedule.ErrAlreadyClockedOut),
+		errors.Is(err, schedule.ErrNotClockedIn), errors.Is(err, schedule.ErrBreakRunning), errors.Is(err, schedule.ErrNoBreakRunning),
+		errors.Is(err, schedule.ErrTimeEntryNotPending):
+		ctx.JSON(http.StatusConflict, errorResponse(err))
+	case errors.Is(err, schedule.ErrInvalidTimeEntry), errors.Is(err, schedule.ErrInvalidIPRange), errors.Is(err, schedule.ErrInvalidDateRange),
+		errors.Is(err, schedule.ErrInvalidPayrollMonth):
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	}
+}
+
+// clockEvent handles the clock and break events of the employee on one of their schedules
+func (server *Server) clockEvent(ctx *gin.Context, message string, event func(scheduleID uuid.UUID, employeeID int64, req *schedule.ClockRequest) (*schedule.TimeEntryResponse, error)) {
+	scheduleID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid schedule ID format")))
+		return
+	}
+
+	var req schedule.ClockRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(fmt.Errorf("failed to get auth payload")))
+		return
+	}
+
+	entry, err := event(scheduleID, payload.EmployeeID, &req)
+	if err != nil {
+		timeClockError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(entry, message)
+	ctx.JSON(http.StatusOK, res)
+}
+
+// ClockInApi registers the start of the working time of a schedule
+// @Summary Clock in
+// @Description Clock in for one of your own schedules, from an hour before its start until its end. When the location has IP ranges or a QR code, clock in from its network or send the scanned qr_token.
+// @Tags Time Registration
+// @Accept json
+// @Produce json
+// @Param id path string true "Schedule ID"
+// @Param request body schedule.ClockRequest false "QR token of the location"
+// @Success 200 {object} Response[schedule.TimeEntryResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 403 {object} Response[any] "Not your schedule or not at the location"
+// @Failure 404 {object} Response[any] "Schedule not found"
+// @Failure 409 {object} Response[any] "Already clocked in or outside the clock in window"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /schedules/{id}/clock_in [post]
+func (server *Server) ClockInApi(ctx *gin.Context) {
+	server.clockEvent(ctx, "Clocked in successfully", func(scheduleID uuid.UUID, employeeID int64, req *schedule.ClockRequest) (*schedule.TimeEntryResponse, error) {
+		return server.businessService.ScheduleService.ClockIn(ctx, scheduleID, employeeID, req, ctx.ClientIP())
+	})
+}
+
+// ClockOutApi registers the end of the working time of a schedule
+// @Summary Clock out
+// @Description Clock out of one of your own schedules. A running break ends. When the registered times differ more than 15 minutes from the planned times the time entry waits for approval, otherwise the planned times count.
+// @Tags Time Registration
+// @Accept json
+// @Produce json
+// @Param id path string true "Schedule ID"
+// @Param request body schedule.ClockRequest false "QR token of the location"
+// @Success 200 {object} Response[schedule.TimeEntryResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 403 {object} Response[any] "Not your schedule or not at the location"
+// @Failure 404 {object} Response[any] "Schedule not found"
+// @Failure 409 {object} Response[any] "Not clocked in or already clocked out"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /schedules/{id}/clock_out [post]
+func (server *Server) ClockOutApi(ctx *gin.Context) {
+	server.clockEvent(ctx, "Clocked out successfully", func(scheduleID uuid.UUID, employeeID int64, req *schedule.ClockRequest) (*schedule.TimeEntryResponse, error) {
+		return server.businessService.ScheduleService.ClockOut(ctx, scheduleID, employeeID, req, ctx.ClientIP())
+	})
+}
+
+// StartBreakApi starts a break
+// @Summary Start a break
+// @Description Start a break during one of your own schedules you are clocked in for
+// @Tags Time Registration
+// @Produce json
+// @Param id path string true "Schedule ID"
+// @Success 200 {object} Response[schedule.TimeEntryResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 403 {object} Response[any] "Not your schedule"
+// @Failure 404 {object} Response[any] "Schedule not found"
+// @Failure 409 {object} Response[any] "Not clocked in or a break is running"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /schedules/{id}/breaks/start [post]
+func (server *Server) StartBreakApi(ctx *gin.Context) {
+	server.clockEvent(ctx, "Break started successfully", func(scheduleID uuid.UUID, employeeID int64, _ *schedule.ClockRequest) (*schedule.TimeEntryResponse, error) {
+		return server.businessService.ScheduleService.StartBreak(ctx, scheduleID, employeeID)
+	})
+}
+
+// EndBreakApi ends the running break
+// @Summary End a break
+// @Description End the running break of one of your own schedules
+// @Tags Time Registration
+// @Produce json
+// @Param id path string true "Schedule ID"
+// @Success 200 {object} Response[schedule.TimeEntryResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 403 {object} Response[any] "Not your schedule"
+// @Failure 404 {object} Response[any] "Schedule not found"
+// @Failure 409 {object} Response[any] "Not clocked in or no break is running"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /schedules/{id}/breaks/end [post]
+func (server *Server) EndBreakApi(ctx *gin.Context) {
+	server.clockEvent(ctx, "Break ended successfully", func(scheduleID uuid.UUID, employeeID int64, _ *schedule.ClockRequest) (*schedule.TimeEntryResponse, error) {
+		return server.businessService.ScheduleService.EndBreak(ctx, scheduleID, employeeID)
+	})
+}
+
+// GetScheduleTimeEntryApi returns the time entry of a schedule
+// @Summary Get the time entry of a schedule
+// @Description Get the registered times and breaks of a schedule next to its planned times
+// @Tags Time Registration
+// @Produce json
+// @Param id path string true "Schedule ID"
+// @Success 200 {object} Response[schedule.TimeEntryResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 404 {object} Response[any] "Schedule or time entry not found"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /schedules/{id}/time_entry [get]
+func (server *Server) GetScheduleTimeEntryApi(ctx *gin.Context) {
+	scheduleID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid schedule ID format")))
+		return
+	}
+
+	entry, err := server.businessService.ScheduleService.GetScheduleTimeEntry(ctx, scheduleID)
+	if err != nil {
+		timeClockError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(entry, "Time entry retrieved successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// ListTimeEntriesApi lists time entries with their planned times
+// @Summary List time entries
+// @Description List the time entries of the schedules that start from start_date to end_date, planned next to registered times. Filter on status pending for the deviations waiting for approval.
+// @Tags Time Registration
+// @Produce json
+// @Param start_date query string true "First day (YYYY-MM-DD)"
+// @Param end_date query string true "Last day (YYYY-MM-DD)"
+// @Param location_id query int false "Location ID"
+// @Param employee_id query int false "Employee ID"
+// @Param status query string false "Status" Enums(open, pending, approved, rejected)
+// @Success 200 {object} Response[[]schedule.TimeEntryResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /time_entries [get]
+func (server *Server) ListTimeEntriesApi(ctx *gin.Context) {
+	var req schedule.ListTimeEntriesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	entries, err := server.businessService.ScheduleService.ListTimeEntries(ctx, &req)
+	if err != nil {
+		timeClockError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(entries, "Time entries retrieved successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// ApproveTimeEntryApi approves the deviation of a time entry
+// @Summary Approve a time entry
+// @Description Approve the registered times of a time entry that deviate from the planned times, optionally with corrected times and break. Employees cannot approve their own time entries.
+// @Tags Time Registration
+// @Accept json
+// @Produce json
+// @Param id path int true "Time entry ID"
+// @Param request body schedule.ApproveTimeEntryRequest false "Corrections"
+// @Success 200 {object} Response[schedule.TimeEntryResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 403 {object} Response[any] "Own time entry"
+// @Failure 404 {object} Response[any] "Time entry not found"
+// @Failure 409 {object} Response[any] "The time entry is not waiting for approval"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /time_entries/{id}/approve [post]
+func (server *Server) ApproveTimeEntryApi(ctx *gin.Context) {
+	timeEntryID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid time entry ID")))
+		return
+	}
+
+	var req schedule.ApproveTimeEntryRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(fmt.Errorf("failed to get auth payload")))
+		return
+	}
+
+	entry, err := server.businessService.ScheduleService.ApproveTimeEntry(ctx, timeEntryID, payload.EmployeeID, &req)
+	if err != nil {
+		timeClockError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(entry, "Time entry approved successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// RejectTimeEntryApi rejects the deviation of a time entry
+// @Summary Reject a time entry
+// @Description Reject the registered times of a time entry that deviate from the planned times, the planned times count instead
+// @Tags Time Registration
+// @Accept json
+// @Produce json
+// @Param id path int true "Time entry ID"
+// @Param request body schedule.RejectTimeEntryRequest true "Reason"
+// @Success 200 {object} Response[schedule.TimeEntryResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 403 {object} Response[any] "Own time entry"
+// @Failure 404 {object} Response[any] "Time entry not found"
+// @Failure 409 {object} Response[any] "The time entry is not waiting for approval"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /time_entries/{id}/reject [post]
+func (server *Server) RejectTimeEntryApi(ctx *gin.Context) {
+	timeEntryID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid time entry ID")))
+		return
+	}
+
+	var req schedule.RejectTimeEntryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(fmt.Errorf("failed to get auth payload")))
+		return
+	}
+
+	entry, err := server.businessService.ScheduleService.RejectTimeEntry(ctx, timeEntryID, payload.EmployeeID, &req)
+	if err != nil {
+		timeClockError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(entry, "Time entry rejected successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// GetLocationClockSettingsApi returns how employees clock in at a location
+// @Summary Get the clock settings of a location
+// @Description Get the IP ranges and the QR token employees of the location clock in with
+// @Tags Time Registration
+// @Produce json
+// @Param id path int true "Location ID"
+// @Success 200 {object} Response[schedule.LocationClockSettingsResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /locations/{id}/clock_settings [get]
+func (server *Server) GetLocationClockSettingsApi(ctx *gin.Context) {
+	locationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid location ID")))
+		return
+	}
+
+	settings, err := server.businessService.ScheduleService.GetLocationClockSettings(ctx, locationID)
+	if err != nil {
+		timeClockError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(settings, "Clock settings retrieved successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// UpdateLocationClockSettingsApi sets the networks of a location
+// @Summary Set the IP ranges of a location
+// @Description Set the networks employees clock in from at the location, as CIDR ranges or single IP addresses. An empty list removes the IP check.
+// @Tags Time Registration
+// @Accept json
+// @Produce json
+// @Param id path int true "Location ID"
+// @Param request body schedule.UpdateLocationClockSettingsRequest true "IP ranges"
+// @Success 200 {object} Response[schedule.LocationClockSettingsResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /locations/{id}/clock_settings [put]
+func (server *Server) UpdateLocationClockSettingsApi(ctx *gin.Context) {
+	locationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid location ID")))
+		return
+	}
+
+	var req schedule.UpdateLocationClockSettingsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	settings, err := server.businessService.ScheduleService.UpdateLocationClockSettings(ctx, locationID, &req)
+	if err != nil {
+		timeClockError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(settings, "Clock settings updated successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// RotateLocationClockQRTokenApi gives a location a new QR code
+// @Summary Create a new QR code for a location
+// @Description Create a new QR token for the location, to be shown as QR code at the location. The previous code stops working.
+// @Tags Time Registration
+// @Produce json
+// @Param id path int true "Location ID"
+// @Success 200 {object} Response[schedule.LocationClockSettingsResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /locations/{id}/clock_settings/qr_token [post]
+func (server *Server) RotateLocationClockQRTokenApi(ctx *gin.Context) {
+	locationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid location ID")))
+		return
+	}
+
+	settings, err := server.businessService.ScheduleService.RotateLocationClockQRToken(ctx, locationID)
+	if err != nil {
+		timeClockError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(settings, "QR code created successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// RemoveLocationClockQRTokenApi removes the QR code of a location
+// @Summary Remove the QR code of a location
+// @Description Stop clocking in with the QR code of the location
+// @Tags Time Registration
+// @Produce json
+// @Param id path int true "Location ID"
+// @Success 200 {object} Response[schedule.LocationClockSettingsResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /locations/{id}/clock_settings/qr_token [delete]
+func (server *Server) RemoveLocationClockQRTokenApi(ctx *gin.Context) {
+	locationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid location ID")))
+		return
+	}
+
+	settings, err := server.businessService.ScheduleService.RemoveLocationClockQRToken(ctx, locationID)
+	if err != nil {
+		timeClockError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(settings, "QR code removed successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// GetPayrollApi returns the hours of the employees on the payroll in a month
+// @Summary Get the payroll hours of a month
+// @Description Planned and worked hours per employee, ZZP employees and subcontractors are left out. Worked hours are the approved registered hours without breaks, or the planned hours of shifts without an approved time entry.
+// @Tags Finance
+// @Produce json
+// @Param month query string true "Month (YYYY-MM)"
+// @Success 200 {object} Response[schedule.PayrollResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /finance/payroll [get]
+func (server *Server) GetPayrollApi(ctx *gin.Context) {
+	var req schedule.PayrollRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	payroll, err := server.businessService.ScheduleService.GetPayroll(ctx, &req)
+	if err != nil {
+		timeClockError(ctx, err)
+		return
+	}
+
+	res := SuccessResponse(payroll, "Payroll retrieved successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// ExportPayrollApi exports the payroll hours of a month
+// @Summary Export the payroll hours of a month
+// @Description The payroll hours of a month as CSV, one line per employee
+// @Tags Finance
+// @Produce text/csv
+// @Param month query string true "Month (YYYY-MM)"
+// @Success 200 {file} file "Payroll CSV"
+// @Failure 400,401,500 {object} Response[any]
+// @Router /finance/payroll/export [get]
+func (server *Server) ExportPayrollApi(ctx *gin.Context) {
+	var req schedule.PayrollRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	document, err := server.businessService.ScheduleService.ExportPayroll(ctx, &req)
+	if err != nil {
+		timeClockError(ctx, err)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", document.FileName))
+	ctx.Data(http.StatusOK, document.ContentType, document.Content)
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"maicare_go/service/schedule"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocationClockSettingsApi(t *testing.T) {
+	location := createRandomLocation(t)
+
+	settings := func(recorder *httptest.ResponseRecorder) schedule.LocationClockSettingsResponse {
+		var res Response[schedule.LocationClockSettingsResponse]
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+		return res.Data
+	}
+
+	url := fmt.Sprintf("/locations/%d/clock_settings", location.ID)
+	recorder := sendRequest(t, 1, http.MethodGet, url, nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Empty(t, settings(recorder).IPRanges)
+	require.Nil(t, settings(recorder).QRToken)
+
+	recorder = sendRequest(t, 1, http.MethodPut, url, schedule.UpdateLocationClockSettingsRequest{IPRanges: []string{"not an ip"}})
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	recorder = sendRequest(t, 1, http.MethodPut, url, schedule.UpdateLocationClockSettingsRequest{IPRanges: []string{"192.168.1.0/24"}})
+	t.Log(recorder.Body.String())
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, []string{"192.168.1.0/24"}, settings(recorder).IPRanges)
+
+	recorder = sendRequest(t, 1, http.MethodPost, url+"/qr_token", nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	rotated := settings(recorder)
+	require.NotNil(t, rotated.QRToken)
+	require.Equal(t, []string{"192.168.1.0/24"}, rotated.IPRanges)
+
+	recorder = sendRequest(t, 1, http.MethodDelete, url+"/qr_token", nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Nil(t, settings(recorder).QRToken)
+}
+
+func TestClockInForwardedForApi(t *testing.T) {
+	employee, user := createRandomEmployee(t)
+	shift := createRandomSchedule(t, employee.ID)
+
+	recorder := sendRequest(t, 1, http.MethodPut, fmt.Sprintf("/locations/%d/clock_settings", shift.LocationID),
+		schedule.UpdateLocationClockSettingsRequest{IPRanges: []string{"203.0.113.0/24"}})
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	clockIn := func(remoteAddr, forwardedFor string) *httptest.ResponseRecorder {
+		request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/schedules/%s/clock_in", shift.ID), nil)
+		require.NoError(t, err)
+		request.RemoteAddr = remoteAddr
+		request.Header.Set("X-Forwarded-For", forwardedFor)
+		addAuthorization(t, request, testServer.tokenMaker, authorizationTypeBearer, user.ID, time.Minute)
+		recorder := httptest.NewRecorder()
+		testServer.router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	// no proxies are trusted, so a forwarded address from the location network is ignored
+	recorder = clockIn("198.51.100.7:40000", "203.0.113.7")
+	require.Equal(t, http.StatusForbidden, recorder.Code)
+
+	recorder = clockIn("203.0.113.7:40000", "")
+	t.Log(recorder.Body.String())
+	require.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestPayrollApi(t *testing.T) {
+	recorder := sendRequest(t, 1, http.MethodGet, "/finance/payroll?month=2025-13", nil)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	recorder = sendRequest(t, 1, http.MethodGet, "/finance/payroll/export?month=2025-03", nil)
+	t.Log(recorder.Body.String())
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Contains(t, recorder.Header().Get("Content-Disposition"), "payroll_2025-03.csv")
+
+	// the export needs FINANCE.EXPORT, which the admin role has and the Worker role has not
+	_, user := createRandomEmployee(t)
+	recorder = sendRequest(t, user.ID, http.MethodGet, "/finance/payroll/export?month=2025-03", nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	worker := createRandomWorker(t)
+	recorder = sendRequest(t, worker.ID, http.MethodGet, "/finance/payroll/export?month=2025-03", nil)
+	require.Equal(t, http.StatusForbidden, recorder.Code)
+}
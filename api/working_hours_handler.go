@@ -24,6 +24,12 @@ type WorkingHourItem struct {
 	Description   *string   `json:"description,omitempty"`
 	Status        *string   `json:"status,omitempty"`
 	Color         string    `json:"color"`
+	// Registered times of a schedule, present once the employee clocked in
+	ActualStartTime *time.Time `json:"actual_start_time,omitempty"`
+	ActualEndTime   *time.Time `json:"actual_end_time,omitempty"`
+	BreakMinutes    *int32     `json:"break_minutes,omitempty"`
+	WorkedHours     *float64   `json:"worked_hours,omitempty"`
+	TimeEntryStatus *string    `json:"time_entry_status,omitempty"`
 }
 
 // Summary contains the summary of working hours for an employee in a given period.
@@ -33,6 +39,10 @@ type Summary struct {
 	ShiftHours       float64 `json:"shift_hours"`
 	TotalDaysWorked  int     `json:"total_days_worked"`
 	OverTime         float64 `json:"over_time"` // Optional field for overtime hours
+	// Hours worked on shifts according to approved time registrations
+	ApprovedShiftHours float64 `json:"approved_shift_hours"`
+	// Shifts without an approved time registration
+	UnapprovedShifts int `json:"unapproved_shifts"`
 }
 
 // Period information
@@ -127,8 +137,30 @@ func (server *Server) ListWorkingHours(ctx *gin.Context) {
 		return
 	}
 
+	timeEntries, err := server.store.ListEmployeeTimeEntriesInRange(ctx,
+		db.ListEmployeeTimeEntriesInRangeParams{
+			EmployeeID: employeeID,
+			PeriodStart: pgtype.Timestamp{
+				Time:  periodStart,
+				Valid: true,
+			},
+			PeriodEnd: pgtype.Timestamp{
+				Time:  periodEnd,
+				Valid: true,
+			},
+		})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	entries := make(map[uuid.UUID]db.ListEmployeeTimeEntriesInRangeRow, len(timeEntries))
+	for _, entry := range timeEntries {
+		entries[entry.ScheduleID] = entry
+	}
+
 	workingHours := make([]WorkingHourItem, len(employeeSchedules)+len(employeeAppointments))
-	var appointmentHours, shiftHours float64
+	var appointmentHours, shiftHours, approvedShiftHours float64
+	var unapprovedShifts int
 	uniqueDays := make(map[string]bool)
 
 	for i, schedule := range employeeSchedules {
@@ -149,6 +181,30 @@ func (server *Server) ListWorkingHours(ctx *gin.Context) {
 			Description:   nil,
 			Status:        nil,
 		}
+
+		entry, ok := entries[schedule.ID]
+		if !ok {
+			unapprovedShifts++
+			continue
+		}
+		item := &workingHours[i]
+		item.TimeEntryStatus = &entry.Status
+		item.BreakMinutes = &entry.BreakMinutes
+		start, end := entry.ClockInAt, entry.ClockOutAt
+		if entry.ActualStart.Valid && entry.ActualEnd.Valid {
+			start, end = entry.ActualStart, entry.ActualEnd
+		}
+		item.ActualStartTime = &start.Time
+		if end.Valid {
+			worked := end.Time.Sub(start.Time).Hours() - float64(entry.BreakMinutes)/60
+			item.ActualEndTime = &end.Time
+			item.WorkedHours = &worked
+		}
+		if entry.Status == "approved" && item.WorkedHours != nil {
+			approvedShiftHours += *item.WorkedHours
+		} else {
+			unapprovedShifts++
+		}
 	}
 
 	for i, appointment := range employeeAppointments {
@@ -199,6 +255,9 @@ func (server *Server) ListWorkingHours(ctx *gin.Context) {
 		ShiftHours:       shiftHours,
 		TotalDaysWorked:  len(uniqueDays),
 		OverTime:         overTime,
+
+		ApprovedShiftHours: approvedShiftHours,
+		UnapprovedShifts:   unapprovedShifts,
 	}
 
 	currentYear, currentWeek := time.Now().ISOWeek()
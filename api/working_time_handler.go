@@ -0,0 +1,96 @@
+package api
+
+import (
+	"errors"
+	"maicare_go/service/schedule"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListWorkingTimeRulesApi lists the working time rules per contract type
+// @Summary List working time rules
+// @Description List the limits of the Arbeidstijdenwet that are checked for each contract type of employees. Exempt contract types are not checked.
+// @Tags Schedule
+// @Produce json
+// @Success 200 {object} Response[[]schedule.WorkingTimeRulesResponse]
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /working_time_rules [get]
+func (server *Server) ListWorkingTimeRulesApi(ctx *gin.Context) {
+	rules, err := server.businessService.ScheduleService.ListWorkingTimeRules(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := SuccessResponse(rules, "Working time rules retrieved successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// UpdateWorkingTimeRulesApi updates the working time rules of a contract type
+// @Summary Update working time rules
+// @Description Replace the limits of the Arbeidstijdenwet for a contract type, durations in minutes
+// @Tags Schedule
+// @Accept json
+// @Produce json
+// @Param contract_type path string true "Contract type" Enums(loondienst, ZZP, none)
+// @Param request body schedule.UpdateWorkingTimeRulesRequest true "Working time rules"
+// @Success 200 {object} Response[schedule.WorkingTimeRulesResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 404 {object} Response[any] "Unknown contract type"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /working_time_rules/{contract_type} [put]
+func (server *Server) UpdateWorkingTimeRulesApi(ctx *gin.Context) {
+	var req schedule.UpdateWorkingTimeRulesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rules, err := server.businessService.ScheduleService.UpdateWorkingTimeRules(ctx, ctx.Param("contract_type"), &req)
+	if err != nil {
+		if errors.Is(err, schedule.ErrWorkingTimeRulesNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := SuccessResponse(rules, "Working time rules updated successfully")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// GetWorkingTimeComplianceApi reports the working time compliance of employees in a period
+// @Summary Working time compliance report
+// @Description Check the shifts of the employees that work in a period against the Arbeidstijdenwet rules of their contract type, per employee. Shifts before and after the period count towards rest and 16 week averages.
+// @Tags Schedule
+// @Produce json
+// @Param start_date query string true "First day (YYYY-MM-DD)"
+// @Param end_date query string true "Last day (YYYY-MM-DD)"
+// @Param location_id query int false "Only employees with shifts at the location"
+// @Param employee_id query int false "Only this employee"
+// @Success 200 {object} Response[schedule.WorkingTimeComplianceResponse]
+// @Failure 400 {object} Response[any] "Bad Request"
+// @Failure 500 {object} Response[any] "Internal Server Error"
+// @Router /working_time_compliance [get]
+func (server *Server) GetWorkingTimeComplianceApi(ctx *gin.Context) {
+	var req schedule.WorkingTimeComplianceRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	report, err := server.businessService.ScheduleService.GetWorkingTimeCompliance(ctx, &req)
+	if err != nil {
+		if errors.Is(err, schedule.ErrInvalidDateRange) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := SuccessResponse(report, "Working time compliance retrieved successfully")
+	ctx.JSON(http.StatusOK, res)
+}
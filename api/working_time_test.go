@@ -0,0 +1,78 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"maicare_go/service/schedule"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkingTimeRulesApi(t *testing.T) {
+
+	recorder := sendRequest(t, 1, http.MethodGet, "/working_time_rules", nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var list Response[[]schedule.WorkingTimeRulesResponse]
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &list))
+	require.Len(t, list.Data, 3)
+	for _, rules := range list.Data {
+		require.Equal(t, rules.ContractType == "ZZP", rules.Exempt)
+	}
+
+	update := schedule.UpdateWorkingTimeRulesRequest{
+		Exempt:                         true,
+		MaxShiftMinutes:                720,
+		MaxWeekMinutes:                 3600,
+		MaxAverageWeekMinutes:          2880,
+		MinDailyRestMinutes:            660,
+		MinWeeklyRestMinutes:           2160,
+		MaxNightShiftMinutes:           600,
+		MaxConsecutiveNightShifts:      5,
+		MaxNightShifts16Weeks:          36,
+		MinRestAfterNightSeriesMinutes: 2760,
+	}
+	recorder = sendRequest(t, 1, http.MethodPut, "/working_time_rules/ZZP", update)
+	t.Log(recorder.Body.String())
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	recorder = sendRequest(t, 1, http.MethodPut, "/working_time_rules/freelance", update)
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+
+	update.MaxShiftMinutes = 0
+	recorder = sendRequest(t, 1, http.MethodPut, "/working_time_rules/ZZP", update)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestWorkingTimeComplianceApi(t *testing.T) {
+	employee, _ := createRandomEmployee(t)
+	shift := createRandomSchedule(t, employee.ID)
+
+	get := func(url string) *httptest.ResponseRecorder {
+		request, err := http.NewRequest(http.MethodGet, url, nil)
+		require.NoError(t, err)
+		addAuthorization(t, request, testServer.tokenMaker, authorizationTypeBearer, 1, time.Minute)
+		recorder := httptest.NewRecorder()
+		testServer.router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	day := shift.StartDatetime.Time.Format("2006-01-02")
+	recorder := get(fmt.Sprintf("/working_time_compliance?start_date=%s&end_date=%s&employee_id=%d", day, day, employee.ID))
+	t.Log(recorder.Body.String())
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var report Response[schedule.WorkingTimeComplianceResponse]
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &report))
+	require.Len(t, report.Data.Employees, 1)
+	require.Equal(t, 1, report.Data.Employees[0].Shifts)
+	// the random schedule lasts 24 hours
+	require.NotZero(t, report.Data.Employees[0].Errors)
+	require.Equal(t, schedule.RuleShiftLength, report.Data.Employees[0].Violations[0].Rule)
+
+	recorder = get("/working_time_compliance?start_date=2025-03-31&end_date=2025-03-01")
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
@@ -133,6 +133,25 @@ func (mr *MockAsynqClientInterfaceMockRecorder) EnqueueIncident(payload, ctx any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueIncident", reflect.TypeOf((*MockAsynqClientInterface)(nil).EnqueueIncident), varargs...)
 }
 
+// EnqueueInvoiceDelivery mocks base method.
+func (m *MockAsynqClientInterface) EnqueueInvoiceDelivery(ctx context.Context, payload aclient.InvoiceDeliveryPayload, opts ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, payload}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "EnqueueInvoiceDelivery", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnqueueInvoiceDelivery indicates an expected call of EnqueueInvoiceDelivery.
+func (mr *MockAsynqClientInterfaceMockRecorder) EnqueueInvoiceDelivery(ctx, payload any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, payload}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueInvoiceDelivery", reflect.TypeOf((*MockAsynqClientInterface)(nil).EnqueueInvoiceDelivery), varargs...)
+}
+
 // EnqueueNotificationTask mocks base method.
 func (m *MockAsynqClientInterface) EnqueueNotificationTask(ctx context.Context, payload notification.NotificationPayload, opts ...asynq.Option) error {
 	m.ctrl.T.Helper()
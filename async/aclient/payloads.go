@@ -71,7 +71,9 @@ type AcceptedRegistrationFormPayload struct {
 }
 
 type AppointmentPayload struct {
-	AppointmentTemplateID  uuid.UUID `json:"appointment_template_id"`
-	ParticipantEmployeeIDs []int64   `json:"participant_employee_ids"`
-	ClientIDs              []int64   `json:"client_ids"`
+	AppointmentTemplateID uuid.UUID `json:"appointment_template_id"`
+}
+
+type InvoiceDeliveryPayload struct {
+	DeliveryID int64 `json:"delivery_id"`
 }
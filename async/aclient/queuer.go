@@ -22,6 +22,7 @@ const (
 	TypeNotificationSend     = "notification:send"     // Renamed for clarity
 	TypeAppointmentCreate    = "appointment:create"    // Renamed for clarity
 	TypeAcceptedRegistration = "accepted:registration" // Renamed for clarity
+	TypeInvoiceDelivery      = "invoice:deliver"
 )
 
 func (c *AsynqClient) EnqueueEmailDelivery(
@@ -133,3 +134,28 @@ func (c *AsynqClient) EnqueueAcceptedRegistration(
 	log.Printf("Accepted Registration task enqueued: id=%s queue=%s", info.ID, info.Queue)
 	return nil
 }
+
+func (c *AsynqClient) EnqueueInvoiceDelivery(
+	ctx context.Context,
+	payload InvoiceDeliveryPayload,
+	opts ...asynq.Option) error {
+
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("EnqueueInvoiceDelivery: json.Marshal failed: %w", err)
+	}
+
+	// Default options if none are provided, a failed delivery is recorded and not retried
+	if len(opts) == 0 {
+		opts = append(opts, asynq.Queue(QueueDefault), asynq.MaxRetry(0))
+	}
+
+	task := asynq.NewTask(TypeInvoiceDelivery, jsonPayload)
+	info, err := c.client.EnqueueContext(ctx, task, opts...)
+	if err != nil {
+		return fmt.Errorf("EnqueueInvoiceDelivery: client.EnqueueContext failed: %w", err)
+	}
+
+	log.Printf("Invoice delivery task enqueued: id=%s queue=%s", info.ID, info.Queue)
+	return nil
+}
@@ -31,6 +31,10 @@ type AsynqClientInterface interface {
 		ctx context.Context,
 		payload AcceptedRegistrationFormPayload,
 		opts ...asynq.Option) error
+	EnqueueInvoiceDelivery(
+		ctx context.Context,
+		payload InvoiceDeliveryPayload,
+		opts ...asynq.Option) error
 	GetClient() *asynq.Client
 	Close() error
 }
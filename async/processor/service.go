@@ -66,6 +66,11 @@ func (a *AsynqServer) Start() error {
 	mux.HandleFunc(aclient.TypeAppointmentCreate, a.ProcessAppointmentTask)
 	mux.HandleFunc(aclient.TypeAcceptedRegistration, a.ProcessRegistrationFormTask)
 	mux.HandleFunc(scheduler.TypeContractReminder, a.ProcessContractRemiderTask)
+	mux.HandleFunc(scheduler.TypeContractHoursBudget, a.ProcessContractHoursBudgetTask)
+	mux.HandleFunc(scheduler.TypeAppointmentSeries, a.ProcessAppointmentSeriesTask)
+	mux.HandleFunc(scheduler.TypeStaffingGaps, a.ProcessStaffingGapsTask)
+	mux.HandleFunc(scheduler.TypeRenewedContracts, a.ProcessRenewedContractsTask)
+	mux.HandleFunc(aclient.TypeInvoiceDelivery, a.ProcessInvoiceDeliveryTask)
 
 	return a.server.Start(mux)
 }
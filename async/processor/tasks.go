@@ -2,7 +2,6 @@ package processor
 
 import (
 	"context"
-	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -12,10 +11,12 @@ import (
 	"maicare_go/email"
 	"maicare_go/notification"
 	"maicare_go/pdf"
+	"maicare_go/service/appointment"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
@@ -158,109 +159,38 @@ func (c *AsynqServer) ProcessAppointmentTask(ctx context.Context, t *asynq.Task)
 		return fmt.Errorf("invalid appointment payload: missing required fields: %w", asynq.SkipRetry)
 	}
 
-	appointemntTemplate, err := c.store.GetAppointmentTemplate(ctx, p.AppointmentTemplateID)
+	// only the first window of the series is stored, the scheduler moves it forward
+	created, err := c.businessService.AppointmentService.MaterializeAppointmentSeries(ctx, p.AppointmentTemplateID, time.Now().Add(appointment.SeriesWindow))
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			log.Printf("Appointment template not found: %v", err)
-			return fmt.Errorf("appointment template not found: %v: %w", err, asynq.SkipRetry)
+		if errors.Is(err, appointment.ErrSeriesNotFound) || errors.Is(err, appointment.ErrInvalidRecurrence) {
+			log.Printf("Invalid appointment template %s: %v", p.AppointmentTemplateID, err)
+			return fmt.Errorf("invalid appointment template: %v: %w", err, asynq.SkipRetry)
 		}
-		log.Printf("Failed to get appointment template: %v", err)
-		return fmt.Errorf("failed to get appointment template: %v", err)
+		log.Printf("Failed to materialize appointment series: %v", err)
+		return fmt.Errorf("failed to materialize appointment series: %w", err)
 	}
 
-	if appointemntTemplate.RecurrenceType == nil {
-		return fmt.Errorf("invalid appointment template: missing recurrence type: %w", asynq.SkipRetry)
-	}
-
-	if !appointemntTemplate.StartTime.Valid || !appointemntTemplate.EndTime.Valid {
-		return fmt.Errorf("invalid appointment template: missing start or end time: %w", asynq.SkipRetry)
-	}
+	log.Printf("Finished generating appointments for template %s. Created %d occurrences", p.AppointmentTemplateID, created)
+	return nil
+}
 
-	startTime := appointemntTemplate.StartTime.Time
-	endTime := appointemntTemplate.EndTime.Time
-	duration := endTime.Sub(startTime)
-	recurenceType := *appointemntTemplate.RecurrenceType
-	interval := int(*appointemntTemplate.RecurrenceInterval)
-	endDate := time.Time{}
-	hasEndDate := appointemntTemplate.RecurrenceEndDate.Valid
-	if hasEndDate {
-		endDate = appointemntTemplate.RecurrenceEndDate.Time
+func (c *AsynqServer) ProcessAppointmentSeriesTask(ctx context.Context, t *asynq.Task) error {
+	created, err := c.businessService.AppointmentService.ExtendAppointmentSeries(ctx)
+	if err != nil {
+		log.Printf("Failed to extend appointment series: %v", err)
+		return fmt.Errorf("failed to extend appointment series: %w", err)
 	}
+	log.Printf("Extended appointment series, created %d occurrences", created)
+	return nil
+}
 
-	safetyHorizonDate := time.Now().AddDate(2, 0, 0)
-
-	maxOccurrences := 750
-
-	currentStartTime := startTime
-	occurrenceCount := 0
-
-	log.Printf("Starting generation for template %d. EndDate: %v, SafetyHorizon: %v",
-		appointemntTemplate.ID, appointemntTemplate.RecurrenceEndDate, safetyHorizonDate)
-
-	for occurrenceCount < maxOccurrences {
-		if hasEndDate && currentStartTime.After(endDate) {
-			break
-		}
-		if currentStartTime.After(safetyHorizonDate) {
-			break
-		}
-
-		currentEndTime := currentStartTime.Add(duration)
-
-		scheduledAppt, err := c.store.CreateAppointment(ctx, db.CreateAppointmentParams{
-			CreatorEmployeeID: &appointemntTemplate.CreatorEmployeeID,
-			StartTime:         pgtype.Timestamp{Time: currentStartTime, Valid: true},
-			EndTime:           pgtype.Timestamp{Time: currentEndTime, Valid: true},
-			Location:          appointemntTemplate.Location,
-			Description:       appointemntTemplate.Description,
-			Color:             appointemntTemplate.Color,
-		})
-		if err != nil {
-			log.Printf("Failed to create appointment: %v", err)
-			return fmt.Errorf("failed to create appointment: %v: %w", err, asynq.SkipRetry)
-		}
-		occurrenceCount++
-		log.Printf("Created appointment %d for template %d", scheduledAppt.ID, appointemntTemplate.ID)
-
-		if len(p.ClientIDs) > 0 {
-			err = c.store.BulkAddAppointmentClients(ctx, db.BulkAddAppointmentClientsParams{
-				AppointmentID: scheduledAppt.ID,
-				ClientIds:     p.ClientIDs,
-			})
-			if err != nil {
-				log.Printf("Failed to add clients to appointment: %v", err)
-				return fmt.Errorf("failed to add clients to appointment: %v: %w", err, asynq.SkipRetry)
-			}
-		}
-		if len(p.ParticipantEmployeeIDs) > 0 {
-			err = c.store.BulkAddAppointmentParticipants(ctx, db.BulkAddAppointmentParticipantsParams{
-				AppointmentID: scheduledAppt.ID,
-				EmployeeIds:   p.ParticipantEmployeeIDs,
-			})
-			if err != nil {
-				log.Printf("Failed to add participants to appointment: %v", err)
-				return fmt.Errorf("failed to add participants to appointment: %v: %w", err, asynq.SkipRetry)
-			}
-		}
-
-		switch recurenceType {
-		case "DAILY":
-			currentStartTime = currentStartTime.AddDate(0, 0, interval)
-		case "WEEKLY":
-			currentStartTime = currentStartTime.AddDate(0, 0, interval*7)
-		case "MONTHLY":
-			currentStartTime = currentStartTime.AddDate(0, interval, 0)
-		default:
-			log.Printf("Unknown recurrence type: %s", recurenceType)
-			return fmt.Errorf("unknown recurrence type: %s: %w", recurenceType, asynq.SkipRetry)
-		}
-
-	}
-	if occurrenceCount >= maxOccurrences {
-		log.Printf("Max occurrences reached: %d", maxOccurrences)
+func (c *AsynqServer) ProcessRenewedContractsTask(ctx context.Context, t *asynq.Task) error {
+	expired, err := c.businessService.ContractService.ExpireRenewedContracts(ctx)
+	if err != nil {
+		log.Printf("Failed to expire renewed contracts: %v", err)
+		return fmt.Errorf("failed to expire renewed contracts: %w", err)
 	}
-
-	log.Printf("Finished generating appointments for template %d. Created %d occurrences", appointemntTemplate.ID, occurrenceCount)
+	log.Printf("Expired %d renewed contracts", len(expired))
 	return nil
 }
 
@@ -326,6 +256,15 @@ func (c *AsynqServer) ProcessContractRemiderTask(ctx context.Context, t *asynq.T
 			ContractEnd:        contract.EndDate.Time,
 			ReminderType:       reminder.ReminderType,
 			LastReminderSentAt: &reminder.ReminderSentAt.Time,
+			RenewalPath:        fmt.Sprintf("/contracts/%d/renewal", contract.ID),
+		}
+
+		renewal, err := c.store.GetContractRenewalByPredecessor(ctx, contract.ID)
+		if err == nil {
+			notificationData.RenewalContractID = &renewal.SuccessorID
+		} else if !errors.Is(err, pgx.ErrNoRows) {
+			log.Printf("Failed to get renewal of contract ID %d: %v", contract.ID, err)
+			return fmt.Errorf("failed to get renewal of contract ID %d: %v: %w", contract.ID, err, asynq.SkipRetry)
 		}
 
 		adminUsers, err := c.store.GetAllAdminUsers(ctx)
@@ -346,6 +285,7 @@ func (c *AsynqServer) ProcessContractRemiderTask(ctx context.Context, t *asynq.T
 				ClientContractReminder: &notificationData,
 			},
 			CreatedAt: time.Now(),
+			Message:   notificationData.ClientContractReminderMessage(),
 		}
 		for i, user := range adminUsers {
 			notificationPayload.RecipientUserIDs[i] = user.ID
@@ -364,3 +304,132 @@ func (c *AsynqServer) ProcessContractRemiderTask(ctx context.Context, t *asynq.T
 	return nil
 
 }
+
+func (processor *AsynqServer) ProcessInvoiceDeliveryTask(ctx context.Context, t *asynq.Task) error {
+	var p aclient.InvoiceDeliveryPayload
+	if err := json.Unmarshal(t.Payload(), &p); err != nil {
+		log.Printf("Failed to unmarshal invoice delivery task payload: %v", err)
+		return fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+
+	// the outcome is stored on the delivery, so a failed send is not retried blindly
+	delivery, err := processor.businessService.InvoiceService.ProcessInvoiceDelivery(ctx, p.DeliveryID)
+	if err != nil {
+		log.Printf("Failed to deliver invoice for delivery %d: %v", p.DeliveryID, err)
+		return fmt.Errorf("failed to deliver invoice for delivery %d: %v: %w", p.DeliveryID, err, asynq.SkipRetry)
+	}
+
+	log.Printf("Delivered invoice %d with delivery %d", delivery.InvoiceID, delivery.ID)
+	return nil
+}
+
+func (c *AsynqServer) ProcessContractHoursBudgetTask(ctx context.Context, t *asynq.Task) error {
+	alerts, err := c.businessService.ContractService.CheckContractHoursBudgets(ctx)
+	if err != nil {
+		log.Printf("Failed to check contract hours budgets: %v", err)
+		return fmt.Errorf("failed to check contract hours budgets: %v: %w", err, asynq.SkipRetry)
+	}
+
+	for _, alert := range alerts {
+		recipients := alert.RecipientUserIDs
+		if len(recipients) == 0 {
+			// fall back to the admins when the client has no case manager
+			adminUsers, err := c.store.GetAllAdminUsers(ctx)
+			if err != nil {
+				log.Printf("Failed to get admin users: %v", err)
+				return fmt.Errorf("failed to get admin users: %v: %w", err, asynq.SkipRetry)
+			}
+			for _, user := range adminUsers {
+				recipients = append(recipients, user.ID)
+			}
+		}
+
+		notificationData := notification.ContractHoursBudgetData{
+			ClientID:          alert.ClientID,
+			ClientFirstName:   alert.ClientFirstName,
+			ClientLastName:    alert.ClientLastName,
+			ContractID:        alert.ContractID,
+			Threshold:         alert.Threshold,
+			PeriodStart:       alert.PeriodStart,
+			PeriodEnd:         alert.PeriodEnd,
+			AuthorizedMinutes: alert.AuthorizedMinutes,
+			ConsumedMinutes:   alert.ConsumedMinutes,
+		}
+
+		err = c.notificationService.CreateAndDeliver(ctx, notification.NotificationPayload{
+			RecipientUserIDs: recipients,
+			Type:             notification.TypeContractHoursBudget,
+			Data: notification.NotificationData{
+				ContractHoursBudget: &notificationData,
+			},
+			CreatedAt: time.Now(),
+			Message:   notificationData.ContractHoursBudgetMessage(),
+		})
+		if err != nil {
+			log.Printf("Failed to deliver hours budget notification for contract ID %d: %v", alert.ContractID, err)
+			return fmt.Errorf("failed to deliver hours budget notification for contract ID %d: %v: %w", alert.ContractID, err, asynq.SkipRetry)
+		}
+	}
+
+	log.Printf("Contract hours budgets checked, %d alerts sent", len(alerts))
+	return nil
+}
+
+func (c *AsynqServer) ProcessStaffingGapsTask(ctx context.Context, t *asynq.Task) error {
+	alerts, err := c.businessService.ScheduleService.CheckStaffingGaps(ctx)
+	if err != nil {
+		log.Printf("Failed to check staffing gaps: %v", err)
+		return fmt.Errorf("failed to check staffing gaps: %v: %w", err, asynq.SkipRetry)
+	}
+
+	for _, alert := range alerts {
+		recipients := alert.RecipientUserIDs
+		if len(recipients) == 0 {
+			// fall back to the admins when nobody can plan schedules
+			adminUsers, err := c.store.GetAllAdminUsers(ctx)
+			if err != nil {
+				log.Printf("Failed to get admin users: %v", err)
+				return fmt.Errorf("failed to get admin users: %v: %w", err, asynq.SkipRetry)
+			}
+			for _, user := range adminUsers {
+				recipients = append(recipients, user.ID)
+			}
+		}
+
+		notificationData := notification.StaffingGapData{
+			LocationID:   alert.LocationID,
+			LocationName: alert.LocationName,
+		}
+		for _, gap := range alert.Gaps {
+			item := notification.StaffingGapItem{
+				Date:            gap.Date,
+				LocationShiftID: gap.LocationShiftID,
+				ShiftName:       gap.ShiftName,
+				StartTime:       gap.StartTime,
+				MinStaff:        gap.MinStaff,
+				Scheduled:       gap.Scheduled,
+			}
+			for _, missing := range gap.MissingCertifications {
+				item.MissingCertifications = append(item.MissingCertifications, missing.Name)
+			}
+			notificationData.Gaps = append(notificationData.Gaps, item)
+		}
+
+		err = c.notificationService.CreateAndDeliver(ctx, notification.NotificationPayload{
+			RecipientUserIDs: recipients,
+			Type:             notification.TypeStaffingGap,
+			Data: notification.NotificationData{
+				StaffingGap: &notificationData,
+			},
+			CreatedAt: time.Now(),
+			Message:   notificationData.StaffingGapMessage(),
+		})
+		if err != nil {
+			log.Printf("Failed to deliver staffing gap notification for location ID %d: %v", alert.LocationID, err)
+			return fmt.Errorf("failed to deliver staffing gap notification for location ID %d: %v: %w", alert.LocationID, err, asynq.SkipRetry)
+		}
+	}
+
+	log.Printf("Staffing gaps checked, %d alerts sent", len(alerts))
+	return nil
+}
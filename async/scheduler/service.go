@@ -9,7 +9,11 @@ import (
 )
 
 const (
-	TypeContractReminder = "contract:reminder"
+	TypeContractReminder    = "contract:reminder"
+	TypeContractHoursBudget = "contract:hours_budget"
+	TypeAppointmentSeries   = "appointment:series"
+	TypeStaffingGaps        = "schedule:staffing_gaps"
+	TypeRenewedContracts    = "contract:renewed"
 )
 
 type Scheduler struct {
@@ -44,6 +48,57 @@ func (s *Scheduler) ScheduleContractReminder() error {
 	return nil
 }
 
+func (s *Scheduler) ScheduleContractHoursBudget() error {
+	task := asynq.NewTask(TypeContractHoursBudget, nil)
+
+	entryID, err := s.Scheduler.Register("0 * * * *", task)
+	if err != nil {
+		return err
+	}
+	log.Printf("Scheduled contract hours budget check with entry ID: %s", entryID)
+
+	return nil
+}
+
+// ScheduleAppointmentSeries moves the stored window of recurring appointments forward
+func (s *Scheduler) ScheduleAppointmentSeries() error {
+	task := asynq.NewTask(TypeAppointmentSeries, nil)
+
+	entryID, err := s.Scheduler.Register("30 0 * * *", task)
+	if err != nil {
+		return err
+	}
+	log.Printf("Scheduled appointment series extension with entry ID: %s", entryID)
+
+	return nil
+}
+
+// ScheduleStaffingGaps warns planners about understaffed shifts in the coming two weeks
+func (s *Scheduler) ScheduleStaffingGaps() error {
+	task := asynq.NewTask(TypeStaffingGaps, nil)
+
+	entryID, err := s.Scheduler.Register("0 6 * * *", task)
+	if err != nil {
+		return err
+	}
+	log.Printf("Scheduled staffing gap check with entry ID: %s", entryID)
+
+	return nil
+}
+
+// ScheduleRenewedContracts expires renewed contracts once the renewal has started
+func (s *Scheduler) ScheduleRenewedContracts() error {
+	task := asynq.NewTask(TypeRenewedContracts, nil)
+
+	entryID, err := s.Scheduler.Register("5 * * * *", task)
+	if err != nil {
+		return err
+	}
+	log.Printf("Scheduled renewed contract expiry with entry ID: %s", entryID)
+
+	return nil
+}
+
 // func (s *)
 
 func (s *Scheduler) Start() error {
@@ -52,6 +107,22 @@ func (s *Scheduler) Start() error {
 		return err
 	}
 
+	if err := s.ScheduleContractHoursBudget(); err != nil {
+		return err
+	}
+
+	if err := s.ScheduleAppointmentSeries(); err != nil {
+		return err
+	}
+
+	if err := s.ScheduleStaffingGaps(); err != nil {
+		return err
+	}
+
+	if err := s.ScheduleRenewedContracts(); err != nil {
+		return err
+	}
+
 	if err := s.Scheduler.Run(); err != nil {
 		return err
 	}
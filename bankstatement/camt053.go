@@ -0,0 +1,203 @@
+package bankstatement
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// The structs only map the CAMT.053 elements we need. Element names are matched
+// without namespace so the parser accepts camt.053.001.02 up to .001.08.
+type camtDocument struct {
+	Statements []camtStatement `xml:"BkToCstmrStmt>Stmt"`
+}
+
+type camtStatement struct {
+	ID      string      `xml:"Id"`
+	IBAN    string      `xml:"Acct>Id>IBAN"`
+	Entries []camtEntry `xml:"Ntry"`
+}
+
+type camtAmount struct {
+	Currency string `xml:"Ccy,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type camtDate struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+// camtStatus is a plain code up to camt.053.001.06 and wrapped in <Cd> from .001.07 on
+type camtStatus struct {
+	Code  string `xml:"Cd"`
+	Value string `xml:",chardata"`
+}
+
+type camtParty struct {
+	Name    string `xml:"Nm"`
+	PtyName string `xml:"Pty>Nm"`
+}
+
+type camtTransaction struct {
+	EndToEndID     string      `xml:"Refs>EndToEndId"`
+	AccountRef     string      `xml:"Refs>AcctSvcrRef"`
+	Amount         *camtAmount `xml:"Amt"`
+	TxAmount       *camtAmount `xml:"AmtDtls>TxAmt>Amt"`
+	CreditDebit    string      `xml:"CdtDbtInd"`
+	Debtor         camtParty   `xml:"RltdPties>Dbtr"`
+	DebtorIBAN     string      `xml:"RltdPties>DbtrAcct>Id>IBAN"`
+	Creditor       camtParty   `xml:"RltdPties>Cdtr"`
+	CreditorIBAN   string      `xml:"RltdPties>CdtrAcct>Id>IBAN"`
+	Unstructured   []string    `xml:"RmtInf>Ustrd"`
+	StructuredRefs []string    `xml:"RmtInf>Strd>CdtrRefInf>Ref"`
+}
+
+type camtEntry struct {
+	Amount       camtAmount        `xml:"Amt"`
+	CreditDebit  string            `xml:"CdtDbtInd"`
+	Status       camtStatus        `xml:"Sts"`
+	BookingDate  camtDate          `xml:"BookgDt"`
+	ValueDate    camtDate          `xml:"ValDt"`
+	AccountRef   string            `xml:"AcctSvcrRef"`
+	Reference    string            `xml:"NtryRef"`
+	Transactions []camtTransaction `xml:"NtryDtls>TxDtls"`
+	AddtlInfo    string            `xml:"AddtlNtryInf"`
+}
+
+func (d camtDate) parse() (time.Time, error) {
+	if d.Date != "" {
+		return time.Parse("2006-01-02", strings.TrimSpace(d.Date))
+	}
+	if d.DateTime != "" {
+		value := strings.TrimSpace(d.DateTime)
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			return t, nil
+		}
+		return time.Parse("2006-01-02T15:04:05", value)
+	}
+	return time.Time{}, fmt.Errorf("missing date")
+}
+
+func (p camtParty) name() string {
+	if p.Name != "" {
+		return p.Name
+	}
+	return p.PtyName
+}
+
+// ParseCAMT053 parses an ISO 20022 BankToCustomerStatement. Entries with several
+// transaction details (batch bookings) are split into one entry per transaction.
+func ParseCAMT053(data []byte) (*Statement, error) {
+	var doc camtDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse CAMT.053 document: %w", err)
+	}
+	if len(doc.Statements) == 0 {
+		return nil, fmt.Errorf("CAMT.053 document contains no statements")
+	}
+
+	statement := &Statement{
+		Format:      FormatCAMT053,
+		AccountIBAN: doc.Statements[0].IBAN,
+		Reference:   doc.Statements[0].ID,
+	}
+
+	for _, stmt := range doc.Statements {
+		for i, ntry := range stmt.Entries {
+			// only booked entries are final, pending ones show up again later
+			status := ntry.Status.Code
+			if status == "" {
+				status = strings.TrimSpace(ntry.Status.Value)
+			}
+			if status != "" && status != "BOOK" {
+				continue
+			}
+
+			bookingDate, err := ntry.BookingDate.parse()
+			if err != nil {
+				bookingDate, err = ntry.ValueDate.parse()
+				if err != nil {
+					return nil, fmt.Errorf("entry %d: invalid booking date", i+1)
+				}
+			}
+
+			entryReference := ntry.AccountRef
+			if entryReference == "" {
+				entryReference = ntry.Reference
+			}
+
+			if len(ntry.Transactions) == 0 {
+				amount, err := parseAmount(ntry.Amount.Value)
+				if err != nil {
+					return nil, fmt.Errorf("entry %d: %w", i+1, err)
+				}
+				statement.Entries = append(statement.Entries, Entry{
+					Reference:      entryReference,
+					BookingDate:    bookingDate,
+					Amount:         amount,
+					Currency:       ntry.Amount.Currency,
+					CreditDebit:    ntry.CreditDebit,
+					RemittanceInfo: collapseSpaces(ntry.AddtlInfo),
+				})
+				continue
+			}
+
+			for j, tx := range ntry.Transactions {
+				amountValue, currency := ntry.Amount.Value, ntry.Amount.Currency
+				if len(ntry.Transactions) > 1 {
+					switch {
+					case tx.Amount != nil:
+						amountValue, currency = tx.Amount.Value, tx.Amount.Currency
+					case tx.TxAmount != nil:
+						amountValue, currency = tx.TxAmount.Value, tx.TxAmount.Currency
+					}
+				}
+				amount, err := parseAmount(amountValue)
+				if err != nil {
+					return nil, fmt.Errorf("entry %d transaction %d: %w", i+1, j+1, err)
+				}
+
+				creditDebit := tx.CreditDebit
+				if creditDebit == "" {
+					creditDebit = ntry.CreditDebit
+				}
+
+				// the counterparty is the debtor for incoming and the creditor for outgoing money
+				name, iban := tx.Debtor.name(), tx.DebtorIBAN
+				if creditDebit == Debit {
+					name, iban = tx.Creditor.name(), tx.CreditorIBAN
+				}
+
+				remittance := append([]string{}, tx.Unstructured...)
+				remittance = append(remittance, tx.StructuredRefs...)
+				if len(remittance) == 0 && ntry.AddtlInfo != "" {
+					remittance = append(remittance, ntry.AddtlInfo)
+				}
+
+				reference := tx.AccountRef
+				if reference == "" {
+					reference = entryReference
+				}
+				if tx.EndToEndID != "" && tx.EndToEndID != "NOTPROVIDED" {
+					reference = strings.TrimSpace(reference + " " + tx.EndToEndID)
+				}
+
+				statement.Entries = append(statement.Entries, Entry{
+					Reference:        reference,
+					BookingDate:      bookingDate,
+					Amount:           amount,
+					Currency:         currency,
+					CreditDebit:      creditDebit,
+					CounterpartyName: collapseSpaces(name),
+					CounterpartyIBAN: strings.ReplaceAll(iban, " ", ""),
+					RemittanceInfo:   collapseSpaces(strings.Join(remittance, " ")),
+				})
+			}
+		}
+	}
+	statement.countOccurrences()
+
+	return statement, nil
+}
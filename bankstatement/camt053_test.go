@@ -0,0 +1,117 @@
+package bankstatement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testCAMT053 = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02">
+  <BkToCstmrStmt>
+    <GrpHdr><MsgId>MSG-1</MsgId></GrpHdr>
+    <Stmt>
+      <Id>STMT-2025-001</Id>
+      <Acct><Id><IBAN>NL91ABNA0417164300</IBAN></Id></Acct>
+      <Ntry>
+        <Amt Ccy="EUR">1210.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Sts>BOOK</Sts>
+        <BookgDt><Dt>2025-01-15</Dt></BookgDt>
+        <ValDt><Dt>2025-01-15</Dt></ValDt>
+        <AcctSvcrRef>REF-001</AcctSvcrRef>
+        <NtryDtls>
+          <TxDtls>
+            <Refs><EndToEndId>E2E-1</EndToEndId></Refs>
+            <RltdPties>
+              <Dbtr><Nm>Gemeente Utrecht</Nm></Dbtr>
+              <DbtrAcct><Id><IBAN>NL02 RABO 0123 4567 89</IBAN></Id></DbtrAcct>
+            </RltdPties>
+            <RmtInf><Ustrd>Factuur INV-20250101-0001</Ustrd></RmtInf>
+          </TxDtls>
+        </NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="EUR">300.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Sts>BOOK</Sts>
+        <BookgDt><Dt>2025-01-16</Dt></BookgDt>
+        <NtryDtls>
+          <TxDtls>
+            <AmtDtls><TxAmt><Amt Ccy="EUR">100.00</Amt></TxAmt></AmtDtls>
+            <RltdPties><Dbtr><Nm>Zorgkantoor A</Nm></Dbtr></RltdPties>
+            <RmtInf><Strd><CdtrRefInf><Ref>INV-20250101-0002</Ref></CdtrRefInf></Strd></RmtInf>
+          </TxDtls>
+          <TxDtls>
+            <AmtDtls><TxAmt><Amt Ccy="EUR">200.00</Amt></TxAmt></AmtDtls>
+            <RltdPties><Dbtr><Nm>Zorgkantoor B</Nm></Dbtr></RltdPties>
+            <RmtInf><Ustrd>INV-20250101-0003</Ustrd></RmtInf>
+          </TxDtls>
+        </NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="EUR">50.00</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts><Cd>BOOK</Cd></Sts>
+        <BookgDt><DtTm>2025-01-17T10:00:00+01:00</DtTm></BookgDt>
+        <AddtlNtryInf>Bankkosten</AddtlNtryInf>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="EUR">75.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Sts>PDNG</Sts>
+        <BookgDt><Dt>2025-01-18</Dt></BookgDt>
+      </Ntry>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>`
+
+func TestParseCAMT053(t *testing.T) {
+	statement, err := Parse([]byte(testCAMT053), "")
+	require.NoError(t, err)
+	require.Equal(t, FormatCAMT053, statement.Format)
+	require.Equal(t, "NL91ABNA0417164300", statement.AccountIBAN)
+	require.Equal(t, "STMT-2025-001", statement.Reference)
+
+	// the pending entry is skipped, the batch entry is split in two
+	require.Len(t, statement.Entries, 4)
+
+	first := statement.Entries[0]
+	require.True(t, first.IsCredit())
+	require.Equal(t, 1210.00, first.Amount)
+	require.Equal(t, "EUR", first.Currency)
+	require.Equal(t, time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC), first.BookingDate)
+	require.Equal(t, "Gemeente Utrecht", first.CounterpartyName)
+	require.Equal(t, "NL02RABO0123456789", first.CounterpartyIBAN)
+	require.Equal(t, "Factuur INV-20250101-0001", first.RemittanceInfo)
+	require.Equal(t, "REF-001 E2E-1", first.Reference)
+
+	require.Equal(t, 100.00, statement.Entries[1].Amount)
+	require.Equal(t, "INV-20250101-0002", statement.Entries[1].RemittanceInfo)
+	require.Equal(t, 200.00, statement.Entries[2].Amount)
+	require.Equal(t, "Zorgkantoor B", statement.Entries[2].CounterpartyName)
+
+	debit := statement.Entries[3]
+	require.False(t, debit.IsCredit())
+	require.Equal(t, "Bankkosten", debit.RemittanceInfo)
+}
+
+func TestParseCAMT053Invalid(t *testing.T) {
+	_, err := ParseCAMT053([]byte("<Document><BkToCstmrStmt></BkToCstmrStmt></Document>"))
+	require.Error(t, err)
+
+	_, err = Parse([]byte("not a statement"), "")
+	require.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+func TestEntryHash(t *testing.T) {
+	statement, err := ParseCAMT053([]byte(testCAMT053))
+	require.NoError(t, err)
+
+	again, err := ParseCAMT053([]byte(testCAMT053))
+	require.NoError(t, err)
+
+	require.Equal(t, statement.Entries[0].Hash(statement.AccountIBAN), again.Entries[0].Hash(again.AccountIBAN))
+	require.NotEqual(t, statement.Entries[1].Hash(statement.AccountIBAN), statement.Entries[2].Hash(statement.AccountIBAN))
+}
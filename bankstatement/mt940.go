@@ -0,0 +1,197 @@
+package bankstatement
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// :61: value date (YYMMDD), optional entry date (MMDD), mark (C, D, RC, RD),
+// optional funds code, amount, transaction type and reference
+var mt940StatementLine = regexp.MustCompile(`^(\d{6})(\d{4})?(RC|RD|C|D)([A-Z])?([\d,]+)([A-Z][A-Z0-9]{3})?([^/\n]*)(?://(.*))?`)
+
+// Structured :86: codes used by the Dutch banks (ING, ABN AMRO, Rabobank)
+var mt940InfoCodes = []string{"TRTP", "IBAN", "BIC", "NAME", "REMI", "EREF", "MARF", "CSID", "ORDP", "BENM", "ID", "ADDR", "ISDT", "CDTRREF", "CDTRREFTP", "CD", "PREF", "RTRN", "ULTC", "ULTD", "PURP", "SVCL", "EXCH", "CHGS"}
+
+var mt940Tag = regexp.MustCompile(`^:(\d{2}[A-Z]?):(.*)$`)
+
+type mt940Field struct {
+	tag   string
+	value string
+}
+
+// splitMT940Fields splits the message into :tag:value fields, continuation lines
+// are appended to the previous field.
+func splitMT940Fields(data string) []mt940Field {
+	var fields []mt940Field
+
+	for _, rawLine := range strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n") {
+		line := strings.TrimRight(rawLine, "\r ")
+		if line == "" || line == "-" || strings.HasPrefix(line, "{") {
+			continue
+		}
+		if match := mt940Tag.FindStringSubmatch(line); match != nil {
+			fields = append(fields, mt940Field{tag: match[1], value: match[2]})
+			continue
+		}
+		if len(fields) > 0 {
+			fields[len(fields)-1].value += "\n" + line
+		}
+	}
+	return fields
+}
+
+// parseMT940Info parses the :86: information to account owner field. Structured
+// content (/NAME/.../REMI/...) is split into its parts, anything else is kept as
+// remittance information.
+func parseMT940Info(value string) (name, iban, remittance string) {
+	joined := strings.ReplaceAll(value, "\n", "")
+	if !strings.HasPrefix(joined, "/") {
+		return "", "", collapseSpaces(strings.ReplaceAll(value, "\n", " "))
+	}
+
+	parts := make(map[string]string)
+	rest := joined
+	for len(rest) > 0 {
+		found := false
+		for _, code := range mt940InfoCodes {
+			prefix := "/" + code + "/"
+			if !strings.HasPrefix(rest, prefix) {
+				continue
+			}
+			rest = rest[len(prefix):]
+			end := len(rest)
+			for _, next := range mt940InfoCodes {
+				if idx := strings.Index(rest, "/"+next+"/"); idx >= 0 && idx < end {
+					end = idx
+				}
+			}
+			parts[code] = strings.TrimSpace(rest[:end])
+			rest = rest[end:]
+			found = true
+			break
+		}
+		if !found {
+			break
+		}
+	}
+
+	if len(parts) == 0 {
+		return "", "", collapseSpaces(joined)
+	}
+
+	remittance = parts["REMI"]
+	// Rabobank and ABN structure the REMI field itself as /USTD//text/ or /STRD/CUR/ref
+	remittance = strings.TrimPrefix(remittance, "USTD//")
+	remittance = strings.TrimPrefix(remittance, "STRD/CUR/")
+	remittance = strings.Trim(remittance, "/")
+	if remittance == "" {
+		remittance = parts["EREF"]
+	}
+	return collapseSpaces(parts["NAME"]), strings.ReplaceAll(parts["IBAN"], " ", ""), collapseSpaces(remittance)
+}
+
+// ParseMT940 parses a SWIFT MT940 customer statement. Multiple statements in a
+// single file are merged into one statement.
+func ParseMT940(data []byte) (*Statement, error) {
+	fields := splitMT940Fields(string(data))
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("MT940 file contains no fields")
+	}
+
+	statement := &Statement{Format: FormatMT940}
+	var currency string
+	var current *Entry
+
+	flush := func() {
+		if current != nil {
+			statement.Entries = append(statement.Entries, *current)
+			current = nil
+		}
+	}
+
+	for _, field := range fields {
+		switch field.tag {
+		case "20":
+			if statement.Reference == "" {
+				statement.Reference = strings.TrimSpace(field.value)
+			}
+		case "25":
+			if statement.AccountIBAN == "" {
+				account := strings.TrimSpace(field.value)
+				// some banks append the currency to the account number
+				account = strings.TrimSuffix(account, "EUR")
+				statement.AccountIBAN = strings.ReplaceAll(account, " ", "")
+			}
+		case "60F", "60M":
+			// C250101EUR1000,00, the opening balance carries the statement currency
+			if len(field.value) >= 10 {
+				currency = field.value[7:10]
+			}
+		case "61":
+			flush()
+			match := mt940StatementLine.FindStringSubmatch(strings.SplitN(field.value, "\n", 2)[0])
+			if match == nil {
+				return nil, fmt.Errorf("invalid :61: statement line %q", field.value)
+			}
+			valueDate, err := time.Parse("060102", match[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid value date in %q", field.value)
+			}
+			bookingDate := valueDate
+			if match[2] != "" {
+				entryDate, err := time.Parse("0102", match[2])
+				if err == nil {
+					bookingDate = time.Date(valueDate.Year(), entryDate.Month(), entryDate.Day(), 0, 0, 0, 0, time.UTC)
+					// the entry date can fall in the next or previous year around new year
+					if bookingDate.Sub(valueDate) > 180*24*time.Hour {
+						bookingDate = bookingDate.AddDate(-1, 0, 0)
+					} else if valueDate.Sub(bookingDate) > 180*24*time.Hour {
+						bookingDate = bookingDate.AddDate(1, 0, 0)
+					}
+				}
+			}
+			amount, err := parseAmount(match[5])
+			if err != nil {
+				return nil, err
+			}
+
+			// a reversal of a debit is money coming in and vice versa
+			creditDebit := Credit
+			if match[3] == "D" || match[3] == "RC" {
+				creditDebit = Debit
+			}
+
+			reference := strings.TrimSpace(match[7])
+			if reference == "NONREF" {
+				reference = ""
+			}
+			if bankRef := strings.TrimSpace(match[8]); bankRef != "" {
+				reference = strings.TrimSpace(reference + " " + bankRef)
+			}
+
+			current = &Entry{
+				Reference:   reference,
+				BookingDate: bookingDate,
+				Amount:      amount,
+				Currency:    currency,
+				CreditDebit: creditDebit,
+			}
+		case "86":
+			if current == nil {
+				continue
+			}
+			name, iban, remittance := parseMT940Info(field.value)
+			current.CounterpartyName = name
+			current.CounterpartyIBAN = iban
+			current.RemittanceInfo = remittance
+		case "62F", "62M":
+			flush()
+		}
+	}
+	flush()
+	statement.countOccurrences()
+
+	return statement, nil
+}
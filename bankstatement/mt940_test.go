@@ -0,0 +1,84 @@
+package bankstatement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testMT940 = `{1:F01INGBNL2AXXXX0000000000}{2:I940INGBNL2AXXXXN}{4:
+:20:P250115000000001
+:25:NL20INGB0001234567EUR
+:28C:00015
+:60F:C250114EUR1000,00
+:61:2501150115C1210,00NTRFNONREF//00000012345
+/TRCD/00100/
+:86:/EREF/E2E-1/IBAN/NL02RABO0123456789/BIC/RABONL2U/NAME/Gemeente U
+trecht/REMI/USTD//Factuur INV-20250101-0001/
+:61:250116D50,00NMSCNONREF
+:86:Kosten betalingsverkeer
+:61:2512310101C300,00NTRFREF123
+:86:/NAME/Zorgkantoor A/REMI/INV-20250101-0002 INV-20250101-0003/
+:62F:C250116EUR2460,00
+-}`
+
+func TestParseMT940(t *testing.T) {
+	statement, err := Parse([]byte(testMT940), "")
+	require.NoError(t, err)
+	require.Equal(t, FormatMT940, statement.Format)
+	require.Equal(t, "NL20INGB0001234567", statement.AccountIBAN)
+	require.Equal(t, "P250115000000001", statement.Reference)
+	require.Len(t, statement.Entries, 3)
+
+	first := statement.Entries[0]
+	require.True(t, first.IsCredit())
+	require.Equal(t, 1210.00, first.Amount)
+	require.Equal(t, "EUR", first.Currency)
+	require.Equal(t, time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC), first.BookingDate)
+	require.Equal(t, "Gemeente Utrecht", first.CounterpartyName)
+	require.Equal(t, "NL02RABO0123456789", first.CounterpartyIBAN)
+	require.Equal(t, "Factuur INV-20250101-0001", first.RemittanceInfo)
+	require.Equal(t, "00000012345", first.Reference)
+
+	second := statement.Entries[1]
+	require.False(t, second.IsCredit())
+	require.Equal(t, 50.00, second.Amount)
+	require.Equal(t, "Kosten betalingsverkeer", second.RemittanceInfo)
+
+	// the entry date rolls over into the next year
+	third := statement.Entries[2]
+	require.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), third.BookingDate)
+	require.Equal(t, "Zorgkantoor A", third.CounterpartyName)
+	require.Equal(t, "INV-20250101-0002 INV-20250101-0003", third.RemittanceInfo)
+	require.Equal(t, "REF123", third.Reference)
+}
+
+func TestEntryHashOfIdenticalPayments(t *testing.T) {
+	// the same amount paid twice on one day without a bank reference
+	data := `:20:P250115000000002
+:25:NL20INGB0001234567
+:60F:C250114EUR1000,00
+:61:2501150115C100,00NTRFNONREF
+:86:/IBAN/NL02RABO0123456789/NAME/J. Jansen/REMI/Eigen bijdrage/
+:61:2501150115C100,00NTRFNONREF
+:86:/IBAN/NL02RABO0123456789/NAME/J. Jansen/REMI/Eigen bijdrage/
+:62F:C250115EUR1200,00
+`
+	statement, err := ParseMT940([]byte(data))
+	require.NoError(t, err)
+	require.Len(t, statement.Entries, 2)
+	require.Equal(t, 0, statement.Entries[0].Occurrence)
+	require.Equal(t, 1, statement.Entries[1].Occurrence)
+	require.NotEqual(t, statement.Entries[0].Hash(statement.AccountIBAN), statement.Entries[1].Hash(statement.AccountIBAN))
+
+	// importing the statement again gives the same hashes
+	again, err := ParseMT940([]byte(data))
+	require.NoError(t, err)
+	require.Equal(t, statement.Entries[1].Hash(statement.AccountIBAN), again.Entries[1].Hash(again.AccountIBAN))
+}
+
+func TestParseMT940InvalidLine(t *testing.T) {
+	_, err := ParseMT940([]byte(":20:REF\n:61:garbage\n"))
+	require.Error(t, err)
+}
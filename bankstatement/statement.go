@@ -0,0 +1,132 @@
+// Package bankstatement parses bank statement exports (CAMT.053 XML and MT940)
+// into a common set of statement entries.
+package bankstatement
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	FormatCAMT053 = "camt053"
+	FormatMT940   = "mt940"
+
+	Credit = "CRDT"
+	Debit  = "DBIT"
+)
+
+var ErrUnknownFormat = errors.New("unknown bank statement format, expected CAMT.053 or MT940")
+
+// Statement is a single bank statement with its booked entries
+type Statement struct {
+	Format      string
+	AccountIBAN string
+	Reference   string
+	Entries     []Entry
+}
+
+// Entry is one booked transaction on a statement
+type Entry struct {
+	Reference        string
+	BookingDate      time.Time
+	Amount           float64
+	Currency         string
+	CreditDebit      string
+	CounterpartyName string
+	CounterpartyIBAN string
+	RemittanceInfo   string
+	// Occurrence counts the earlier entries on the statement that look the same, so two
+	// identical payments on one day without a bank reference are kept apart
+	Occurrence int
+}
+
+// IsCredit reports whether money was received on the account
+func (e Entry) IsCredit() bool {
+	return e.CreditDebit == Credit
+}
+
+// Hash identifies an entry so that importing the same statement twice can be detected
+func (e Entry) Hash(accountIBAN string) string {
+	sum := sha256.Sum256([]byte(e.key(accountIBAN)))
+	return hex.EncodeToString(sum[:])
+}
+
+func (e Entry) key(accountIBAN string) string {
+	parts := []string{
+		accountIBAN,
+		e.Reference,
+		e.BookingDate.Format("2006-01-02"),
+		strconv.FormatFloat(e.Amount, 'f', 2, 64),
+		e.CreditDebit,
+		e.CounterpartyIBAN,
+		e.RemittanceInfo,
+	}
+	// the first occurrence keeps the hash of the lines imported before it was counted
+	if e.Occurrence > 0 {
+		parts = append(parts, strconv.Itoa(e.Occurrence))
+	}
+	return strings.Join(parts, "|")
+}
+
+// countOccurrences numbers the entries that look the same. The position in the file is
+// not used, a later export that overlaps the statement has to give the same hashes.
+func (s *Statement) countOccurrences() {
+	seen := make(map[string]int)
+	for i := range s.Entries {
+		key := s.Entries[i].key(s.AccountIBAN)
+		s.Entries[i].Occurrence = seen[key]
+		seen[key]++
+	}
+}
+
+// DetectFormat guesses the statement format from the file contents
+func DetectFormat(data []byte) (string, error) {
+	trimmed := bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("<")) && bytes.Contains(trimmed, []byte("BkToCstmrStmt")):
+		return FormatCAMT053, nil
+	case bytes.Contains(trimmed, []byte(":20:")) && bytes.Contains(trimmed, []byte(":61:")):
+		return FormatMT940, nil
+	}
+	return "", ErrUnknownFormat
+}
+
+// Parse parses a statement in the given format, or detects it when format is empty
+func Parse(data []byte, format string) (*Statement, error) {
+	if format == "" {
+		detected, err := DetectFormat(data)
+		if err != nil {
+			return nil, err
+		}
+		format = detected
+	}
+
+	switch format {
+	case FormatCAMT053:
+		return ParseCAMT053(data)
+	case FormatMT940:
+		return ParseMT940(data)
+	}
+	return nil, fmt.Errorf("unsupported bank statement format: %s", format)
+}
+
+// parseAmount parses both "1234.56" and "1234,56" notations
+func parseAmount(value string) (float64, error) {
+	value = strings.TrimSpace(strings.ReplaceAll(value, ",", "."))
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q: %w", value, err)
+	}
+	return math.Round(amount*100) / 100, nil
+}
+
+func collapseSpaces(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
@@ -0,0 +1,39 @@
+-- Imported bank statements (CAMT.053 / MT940)
+CREATE TABLE bank_statement_imports (
+    id BIGSERIAL PRIMARY KEY,
+    file_name VARCHAR(255) NOT NULL,
+    format VARCHAR(10) NOT NULL CHECK (format IN ('camt053', 'mt940')),
+    account_iban VARCHAR(34) NULL,
+    statement_reference VARCHAR(100) NULL,
+    total_lines INTEGER NOT NULL DEFAULT 0,
+    matched_lines INTEGER NOT NULL DEFAULT 0,
+    imported_by BIGINT NULL REFERENCES employee_profile(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Credit lines of an imported statement, unmatched lines form the review queue
+CREATE TABLE bank_statement_lines (
+    id BIGSERIAL PRIMARY KEY,
+    import_id BIGINT NOT NULL REFERENCES bank_statement_imports(id) ON DELETE CASCADE,
+    line_hash VARCHAR(64) NOT NULL UNIQUE,
+    entry_reference VARCHAR(255) NULL,
+    booking_date DATE NOT NULL,
+    amount DECIMAL(20,2) NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'EUR',
+    counterparty_name VARCHAR(255) NULL,
+    counterparty_iban VARCHAR(34) NULL,
+    remittance_info TEXT NULL,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('matched', 'unmatched', 'ignored')) DEFAULT 'unmatched',
+    match_method VARCHAR(20) NULL CHECK (match_method IN ('invoice_number', 'amount_sender', 'manual')),
+    suggested_invoice_id BIGINT NULL REFERENCES invoice(id) ON DELETE SET NULL,
+    invoice_id BIGINT NULL REFERENCES invoice(id) ON DELETE SET NULL,
+    payment_id BIGINT NULL REFERENCES invoice_payment_history(id) ON DELETE SET NULL,
+    review_note TEXT NULL,
+    reviewed_by BIGINT NULL REFERENCES employee_profile(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_bank_statement_lines_import_id ON bank_statement_lines(import_id);
+CREATE INDEX idx_bank_statement_lines_status ON bank_statement_lines(status);
+CREATE INDEX idx_bank_statement_lines_invoice_id ON bank_statement_lines(invoice_id);
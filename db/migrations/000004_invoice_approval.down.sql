@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS invoice_approval_settings;
+
+DELETE FROM invoice_audit WHERE operation IN ('SUBMIT', 'APPROVE', 'REJECT');
+ALTER TABLE invoice_audit DROP CONSTRAINT invoice_audit_operation_check;
+ALTER TABLE invoice_audit ADD CONSTRAINT invoice_audit_operation_check CHECK (operation IN ('INSERT', 'UPDATE', 'DELETE'));
+
+UPDATE invoice SET status = 'concept' WHERE status IN ('pending_approval', 'approved');
+ALTER TABLE invoice DROP CONSTRAINT invoice_status_check;
+ALTER TABLE invoice ADD CONSTRAINT invoice_status_check CHECK (status IN (
+    'outstanding', 'partially_paid', 'paid', 'expired',
+    'overpaid', 'imported', 'concept', 'canceled'
+));
@@ -0,0 +1,21 @@
+-- Invoices have to be approved before they can be sent
+ALTER TABLE invoice DROP CONSTRAINT invoice_status_check;
+ALTER TABLE invoice ADD CONSTRAINT invoice_status_check CHECK (status IN (
+    'outstanding', 'partially_paid', 'paid', 'expired',
+    'overpaid', 'imported', 'concept', 'canceled',
+    'pending_approval', 'approved'
+));
+
+-- Approval events are recorded next to the row changes of the audit trigger
+ALTER TABLE invoice_audit DROP CONSTRAINT invoice_audit_operation_check;
+ALTER TABLE invoice_audit ADD CONSTRAINT invoice_audit_operation_check CHECK (operation IN (
+    'INSERT', 'UPDATE', 'DELETE', 'SUBMIT', 'APPROVE', 'REJECT'
+));
+
+-- Invoices at or above the threshold need a second employee to approve them
+CREATE TABLE invoice_approval_settings (
+    organisation_id BIGINT PRIMARY KEY REFERENCES organisations(id) ON DELETE CASCADE,
+    approval_threshold DECIMAL(20,2) NOT NULL DEFAULT 0 CHECK (approval_threshold >= 0),
+    updated_by BIGINT NULL REFERENCES employee_profile(id) ON DELETE SET NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS invoice_deliveries;
+
+-- email_address keeps its width, addresses saved since the upgrade would not fit VARCHAR(20)
@@ -0,0 +1,20 @@
+-- Invoice addresses of senders are regular email addresses
+ALTER TABLE sender ALTER COLUMN email_address TYPE VARCHAR(254);
+
+-- Every attempt to email an invoice, batch_id groups the invoices of one run
+CREATE TABLE invoice_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    invoice_id BIGINT NOT NULL REFERENCES invoice(id) ON DELETE CASCADE,
+    batch_id UUID NULL,
+    recipients TEXT[] NOT NULL DEFAULT '{}',
+    is_reminder BOOLEAN NOT NULL DEFAULT FALSE,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('queued', 'sent', 'failed')) DEFAULT 'queued',
+    message_id VARCHAR(255) NULL,
+    error_message TEXT NULL,
+    sent_by BIGINT NULL REFERENCES employee_profile(id) ON DELETE SET NULL,
+    sent_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_invoice_deliveries_invoice_id ON invoice_deliveries(invoice_id);
+CREATE INDEX idx_invoice_deliveries_batch_id ON invoice_deliveries(batch_id);
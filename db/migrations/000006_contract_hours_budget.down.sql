@@ -0,0 +1,9 @@
+DELETE FROM notifications WHERE type = 'contract_hours_budget';
+ALTER TABLE notifications DROP CONSTRAINT IF EXISTS notifications_type_check;
+ALTER TABLE notifications ADD CONSTRAINT notifications_type_check CHECK (type IN (
+    'new_appointment', 'appointment_update', 'appointment_cancellation', 'new_client_assigned',
+    'client_goal_update', 'incident_report', 'new_incident_report', 'client_contract_reminder',
+    'new_schedule_notification'
+));
+
+DROP TABLE IF EXISTS contract_budget_alerts;
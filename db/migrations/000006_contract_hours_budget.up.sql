@@ -0,0 +1,22 @@
+-- Alerts sent when the consumed hours of an ambulante contract reach a share of its indication.
+-- Weekly indications are tracked per week, so a contract gets an alert per threshold per period.
+CREATE TABLE contract_budget_alerts (
+    id BIGSERIAL PRIMARY KEY,
+    contract_id BIGINT NOT NULL REFERENCES contract(id) ON DELETE CASCADE,
+    threshold INTEGER NOT NULL CHECK (threshold IN (80, 100)),
+    period_start DATE NOT NULL,
+    authorized_minutes DECIMAL(10,2) NOT NULL,
+    consumed_minutes DECIMAL(10,2) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (contract_id, threshold, period_start)
+);
+
+CREATE INDEX contract_budget_alerts_contract_id_idx ON contract_budget_alerts(contract_id);
+
+-- allow the budget alerts, and the notification types that were added since the table was created
+ALTER TABLE notifications DROP CONSTRAINT IF EXISTS notifications_type_check;
+ALTER TABLE notifications ADD CONSTRAINT notifications_type_check CHECK (type IN (
+    'new_appointment', 'appointment_update', 'appointment_cancellation', 'new_client_assigned',
+    'client_goal_update', 'incident_report', 'new_incident_report', 'client_contract_reminder',
+    'new_schedule_notification', 'contract_hours_budget'
+));
@@ -0,0 +1,61 @@
+-- Status timeline of a contract. Periods of a contract don't overlap, a period without
+-- effective_to runs until the next change. Suspensions such as hospital stays are
+-- periods with the suspended status inside an approved period.
+CREATE TABLE contract_status_periods (
+    id BIGSERIAL PRIMARY KEY,
+    contract_id BIGINT NOT NULL REFERENCES contract(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('approved', 'draft', 'terminated', 'stopped', 'expired', 'suspended')),
+    effective_from TIMESTAMPTZ NOT NULL,
+    effective_to TIMESTAMPTZ NULL,
+    reason VARCHAR(255) NULL,
+    created_by BIGINT NULL REFERENCES employee_profile(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (effective_to IS NULL OR effective_to > effective_from)
+);
+
+CREATE INDEX contract_status_periods_contract_id_idx ON contract_status_periods(contract_id, effective_from);
+
+-- Rebuild the timeline of existing contracts from the audit history. The current status
+-- of the contract is added as well, so contracts with missing audit rows still end on
+-- the status they have now.
+WITH status_changes AS (
+    SELECT
+        a.contract_id,
+        a.new_values->>'status' AS status,
+        a.changed_at AS effective_from
+    FROM contract_audit a
+    JOIN contract c ON c.id = a.contract_id
+    WHERE a.operation IN ('INSERT', 'UPDATE')
+      AND a.new_values->>'status' IS NOT NULL
+      AND (a.old_values IS NULL OR a.old_values->>'status' IS DISTINCT FROM a.new_values->>'status')
+
+    UNION ALL
+
+    SELECT
+        c.id,
+        c.status,
+        CASE WHEN c.status = 'approved' AND c.approved_at IS NOT NULL THEN c.approved_at ELSE c.updated_at END
+    FROM contract c
+),
+ordered_changes AS (
+    SELECT
+        contract_id,
+        status,
+        effective_from,
+        LAG(status) OVER (PARTITION BY contract_id ORDER BY effective_from) AS previous_status
+    FROM status_changes
+),
+timeline AS (
+    SELECT
+        contract_id,
+        status,
+        effective_from,
+        LEAD(effective_from) OVER (PARTITION BY contract_id ORDER BY effective_from) AS effective_to
+    FROM ordered_changes
+    WHERE previous_status IS DISTINCT FROM status
+)
+INSERT INTO contract_status_periods (contract_id, status, effective_from, effective_to, reason)
+SELECT contract_id, status, effective_from, effective_to, 'migrated from the contract audit history'
+FROM timeline
+-- changes in the same instant leave nothing to bill, the last one wins
+WHERE effective_to IS NULL OR effective_to > effective_from;
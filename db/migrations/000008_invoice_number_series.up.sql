@@ -0,0 +1,18 @@
+-- Invoice numbers are issued from a consecutive series per organisation. The series row
+-- is locked while a number is allocated, so concurrent invoice runs wait for each other
+-- and a rolled back invoice gives its number back.
+CREATE TABLE invoice_number_series (
+    organisation_id BIGINT PRIMARY KEY REFERENCES organisations(id) ON DELETE CASCADE,
+    prefix VARCHAR(20) NOT NULL UNIQUE,
+    number_format VARCHAR(50) NOT NULL DEFAULT '{prefix}-{year}-{seq}',
+    sequence_padding INT NOT NULL DEFAULT 5 CHECK (sequence_padding BETWEEN 1 AND 10),
+    reset_period VARCHAR(20) NOT NULL DEFAULT 'yearly' CHECK (reset_period IN ('yearly', 'continuous')),
+    sequence_year INT NULL,
+    last_sequence BIGINT NOT NULL DEFAULT 0 CHECK (last_sequence >= 0),
+    updated_by BIGINT NULL REFERENCES employee_profile(id) ON DELETE SET NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO invoice_number_series (organisation_id, prefix)
+SELECT id, 'INV' || id
+FROM organisations;
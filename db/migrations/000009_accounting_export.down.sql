@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS accounting_export_items;
+DROP TABLE IF EXISTS accounting_export_batches;
+DROP TABLE IF EXISTS ledger_account_mappings;
@@ -0,0 +1,54 @@
+-- Ledger accounts the journal lines of an organisation are booked on. Revenue accounts
+-- can be narrowed down to a care type and financing act and VAT accounts to a rate, a
+-- mapping without them applies to everything that has no more specific mapping.
+CREATE TABLE ledger_account_mappings (
+    id BIGSERIAL PRIMARY KEY,
+    organisation_id BIGINT NOT NULL REFERENCES organisations(id) ON DELETE CASCADE,
+    account_type VARCHAR(20) NOT NULL CHECK (account_type IN ('debtor', 'revenue', 'vat', 'bank')),
+    care_type VARCHAR(20) NULL CHECK (care_type IN ('ambulante', 'accommodation')),
+    financing_act VARCHAR(50) NULL CHECK (financing_act IN ('WMO', 'ZVW', 'WLZ', 'JW', 'WPG')),
+    vat_rate DECIMAL(5,2) NULL,
+    account_code VARCHAR(20) NOT NULL,
+    vat_code VARCHAR(10) NULL,
+    description VARCHAR(255) NULL,
+    updated_by BIGINT NULL REFERENCES employee_profile(id) ON DELETE SET NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (account_type = 'revenue' OR (care_type IS NULL AND financing_act IS NULL)),
+    CHECK (account_type = 'vat' OR vat_rate IS NULL)
+);
+
+CREATE UNIQUE INDEX ledger_account_mappings_key_idx ON ledger_account_mappings(
+    organisation_id, account_type, COALESCE(care_type, ''), COALESCE(financing_act, ''), COALESCE(vat_rate, -1)
+);
+
+-- Every export is recorded as a batch. An invoice or payment can only be part of one
+-- batch, so a new export never contains anything that was exported before.
+CREATE TABLE accounting_export_batches (
+    id BIGSERIAL PRIMARY KEY,
+    organisation_id BIGINT NOT NULL REFERENCES organisations(id) ON DELETE CASCADE,
+    export_format VARCHAR(20) NOT NULL CHECK (export_format IN ('csv', 'exact_online', 'afas')),
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    sales_journal VARCHAR(20) NOT NULL,
+    bank_journal VARCHAR(20) NOT NULL,
+    invoice_count INTEGER NOT NULL DEFAULT 0,
+    payment_count INTEGER NOT NULL DEFAULT 0,
+    download_count INTEGER NOT NULL DEFAULT 0,
+    last_downloaded_at TIMESTAMPTZ NULL,
+    last_downloaded_by BIGINT NULL REFERENCES employee_profile(id) ON DELETE SET NULL,
+    created_by BIGINT NULL REFERENCES employee_profile(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (end_date >= start_date)
+);
+
+CREATE INDEX accounting_export_batches_organisation_id_idx ON accounting_export_batches(organisation_id, created_at);
+
+CREATE TABLE accounting_export_items (
+    id BIGSERIAL PRIMARY KEY,
+    batch_id BIGINT NOT NULL REFERENCES accounting_export_batches(id) ON DELETE CASCADE,
+    invoice_id BIGINT NULL UNIQUE REFERENCES invoice(id) ON DELETE CASCADE,
+    payment_id BIGINT NULL UNIQUE REFERENCES invoice_payment_history(id) ON DELETE CASCADE,
+    CHECK ((invoice_id IS NULL) <> (payment_id IS NULL))
+);
+
+CREATE INDEX accounting_export_items_batch_id_idx ON accounting_export_items(batch_id);
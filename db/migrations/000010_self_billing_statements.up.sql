@@ -0,0 +1,32 @@
+-- Monthly self-billing statements of subcontractors. The organisation issues the
+-- statement on behalf of the subcontractor, one per organisation the subcontractor
+-- worked for in the month.
+CREATE TABLE self_billing_statements (
+    id BIGSERIAL PRIMARY KEY,
+    statement_number VARCHAR(50) NOT NULL UNIQUE,
+    employee_id BIGINT NOT NULL REFERENCES employee_profile(id) ON DELETE CASCADE,
+    organisation_id BIGINT NOT NULL REFERENCES organisations(id) ON DELETE CASCADE,
+    employee_name VARCHAR(201) NOT NULL,
+    period_start DATE NOT NULL,
+    period_end DATE NOT NULL,
+    hours DECIMAL(10,2) NOT NULL DEFAULT 0,
+    rate DECIMAL(10,2) NOT NULL DEFAULT 0,
+    subtotal DECIMAL(20,2) NOT NULL DEFAULT 0,
+    vat_rate DECIMAL(5,2) NOT NULL DEFAULT 0,
+    vat_amount DECIMAL(20,2) NOT NULL DEFAULT 0,
+    total_amount DECIMAL(20,2) NOT NULL DEFAULT 0,
+    lines JSONB NOT NULL DEFAULT '[]',
+    status VARCHAR(20) NOT NULL CHECK (status IN ('concept', 'approved', 'rejected', 'exported')) DEFAULT 'concept',
+    rejection_reason TEXT NULL,
+    approved_by BIGINT NULL REFERENCES employee_profile(id) ON DELETE SET NULL,
+    approved_at TIMESTAMPTZ NULL,
+    exported_by BIGINT NULL REFERENCES employee_profile(id) ON DELETE SET NULL,
+    exported_at TIMESTAMPTZ NULL,
+    created_by BIGINT NULL REFERENCES employee_profile(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (employee_id, organisation_id, period_start),
+    CHECK (period_end >= period_start)
+);
+
+CREATE INDEX self_billing_statements_period_idx ON self_billing_statements(period_start, status);
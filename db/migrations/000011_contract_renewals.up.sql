@@ -0,0 +1,12 @@
+-- Links a contract to the draft that renews it. A contract has at most one successor
+-- and the predecessor is closed when the successor is approved.
+CREATE TABLE contract_renewals (
+    id BIGSERIAL PRIMARY KEY,
+    predecessor_id BIGINT NOT NULL UNIQUE REFERENCES contract(id) ON DELETE CASCADE,
+    successor_id BIGINT NOT NULL UNIQUE REFERENCES contract(id) ON DELETE CASCADE,
+    price_index_percentage DECIMAL(5,2) NULL,
+    closed_at TIMESTAMPTZ NULL,
+    created_by BIGINT NULL REFERENCES employee_profile(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (predecessor_id <> successor_id)
+);
@@ -0,0 +1,13 @@
+DELETE FROM template_items WHERE item_tag IN (
+    'client.bsn',
+    'client.first_name',
+    'client.last_name',
+    'contract.care_name',
+    'contract.care_type',
+    'contract.start_date',
+    'contract.end_date',
+    'contract.indication_number',
+    'contract_type.name'
+);
+
+ALTER TABLE contract DROP COLUMN IF EXISTS indication_number;
@@ -0,0 +1,12 @@
+ALTER TABLE contract ADD COLUMN indication_number VARCHAR(50) NULL;
+
+INSERT INTO template_items (item_tag, description, source_table, source_column) VALUES
+('client.bsn', 'BSN', 'client_details', 'bsn'),
+('client.first_name', 'First name', 'client_details', 'first_name'),
+('client.last_name', 'Last name', 'client_details', 'last_name'),
+('contract.care_name', 'Care name', 'contract', 'care_name'),
+('contract.care_type', 'Care type', 'contract', 'care_type'),
+('contract.start_date', 'Contract start date', 'contract', 'start_date'),
+('contract.end_date', 'Contract end date', 'contract', 'end_date'),
+('contract.indication_number', 'Indication number', 'contract', 'indication_number'),
+('contract_type.name', 'Contract type', 'contract_type', 'name');
@@ -0,0 +1,17 @@
+DROP INDEX IF EXISTS idx_scheduled_appointments_recurrence;
+
+ALTER TABLE scheduled_appointments
+    DROP COLUMN IF EXISTS is_exception,
+    DROP COLUMN IF EXISTS recurrence_id;
+
+ALTER TABLE appointment_templates
+    DROP COLUMN IF EXISTS materialized_until,
+    DROP COLUMN IF EXISTS client_ids,
+    DROP COLUMN IF EXISTS participant_employee_ids,
+    DROP COLUMN IF EXISTS rdates,
+    DROP COLUMN IF EXISTS exdates,
+    DROP COLUMN IF EXISTS rrule;
+
+DELETE FROM appointment_templates WHERE recurrence_type = 'YEARLY';
+ALTER TABLE appointment_templates DROP CONSTRAINT appointment_templates_recurrence_type_check;
+ALTER TABLE appointment_templates ADD CONSTRAINT appointment_templates_recurrence_type_check CHECK (recurrence_type IN ('DAILY', 'WEEKLY', 'MONTHLY'));
@@ -0,0 +1,53 @@
+-- Appointment series use RFC 5545 recurrence rules. The legacy recurrence columns are kept
+-- for reading and filled from the rule where it maps onto them.
+ALTER TABLE appointment_templates DROP CONSTRAINT appointment_templates_recurrence_type_check;
+ALTER TABLE appointment_templates ADD CONSTRAINT appointment_templates_recurrence_type_check CHECK (recurrence_type IN ('DAILY', 'WEEKLY', 'MONTHLY', 'YEARLY'));
+
+ALTER TABLE appointment_templates
+    ADD COLUMN rrule TEXT NULL,
+    ADD COLUMN exdates TIMESTAMP[] NOT NULL DEFAULT '{}',
+    ADD COLUMN rdates TIMESTAMP[] NOT NULL DEFAULT '{}',
+    ADD COLUMN participant_employee_ids BIGINT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN client_ids BIGINT[] NOT NULL DEFAULT '{}',
+    -- occurrences before this moment exist in scheduled_appointments
+    ADD COLUMN materialized_until TIMESTAMP NULL;
+
+-- recurrence_id is the start of the occurrence according to the series, it stays the same
+-- when a single occurrence is moved
+ALTER TABLE scheduled_appointments
+    ADD COLUMN recurrence_id TIMESTAMP NULL,
+    ADD COLUMN is_exception BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE scheduled_appointments SET recurrence_id = start_time WHERE appointment_templates_id IS NOT NULL;
+
+CREATE UNIQUE INDEX idx_scheduled_appointments_recurrence ON scheduled_appointments (appointment_templates_id, recurrence_id);
+
+UPDATE appointment_templates t SET
+    rrule = 'FREQ=' || t.recurrence_type
+        || CASE WHEN COALESCE(t.recurrence_interval, 1) > 1 THEN ';INTERVAL=' || t.recurrence_interval ELSE '' END
+        || CASE WHEN t.recurrence_end_date IS NOT NULL THEN ';UNTIL=' || to_char(t.recurrence_end_date, 'YYYYMMDD') || 'T235959' ELSE '' END,
+    -- Occurrences used to be created up front, two years ahead or 750 of them, without a link
+    -- to their template. The series continues where that expansion stopped, so they are not
+    -- created a second time.
+    materialized_until = GREATEST(
+        (SELECT MAX(sa.start_time) FROM scheduled_appointments sa WHERE sa.appointment_templates_id = t.id),
+        LEAST(
+            COALESCE(t.created_at, t.start_time) + INTERVAL '2 years',
+            t.start_time + 749 * COALESCE(t.recurrence_interval, 1) * CASE t.recurrence_type
+                WHEN 'DAILY' THEN INTERVAL '1 day'
+                WHEN 'WEEKLY' THEN INTERVAL '7 days'
+                ELSE INTERVAL '1 month'
+            END
+        )
+    ) + INTERVAL '1 second',
+    participant_employee_ids = COALESCE((
+        SELECT array_agg(DISTINCT ap.employee_id) FROM appointment_participants ap
+        JOIN scheduled_appointments sa ON sa.id = ap.appointment_id
+        WHERE sa.appointment_templates_id = t.id
+    ), '{}'),
+    client_ids = COALESCE((
+        SELECT array_agg(DISTINCT ac.client_id) FROM appointment_clients ac
+        JOIN scheduled_appointments sa ON sa.id = ac.appointment_id
+        WHERE sa.appointment_templates_id = t.id
+    ), '{}')
+WHERE t.recurrence_type IS NOT NULL;
@@ -0,0 +1,8 @@
+-- Secret tokens of the iCalendar subscription feeds, one per user. Only a SHA-256 hash of
+-- the token is stored; regenerating replaces it and revoking deletes it.
+CREATE TABLE calendar_feed_tokens (
+    user_id BIGINT PRIMARY KEY REFERENCES custom_user(id) ON DELETE CASCADE,
+    token_hash TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_used_at TIMESTAMPTZ
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS roster_draft_assignments;
+DROP TABLE IF EXISTS roster_drafts;
@@ -0,0 +1,33 @@
+-- Rosters proposed by the roster generator. A draft is reviewed and adjusted by a planner
+-- and becomes schedules when it is published.
+CREATE TABLE roster_drafts (
+    id BIGSERIAL PRIMARY KEY,
+    location_id BIGINT NOT NULL REFERENCES location(id) ON DELETE CASCADE,
+    year INTEGER NOT NULL,
+    month INTEGER NOT NULL CHECK (month BETWEEN 1 AND 12),
+    status VARCHAR(20) NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'published')),
+    -- the staffing requirements and settings the draft was generated with
+    requirements JSONB NOT NULL,
+    created_by_employee_id BIGINT NULL REFERENCES employee_profile(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    published_by_employee_id BIGINT NULL REFERENCES employee_profile(id) ON DELETE SET NULL,
+    published_at TIMESTAMPTZ NULL
+);
+
+CREATE INDEX idx_roster_drafts_location ON roster_drafts (location_id, year, month);
+
+-- One position on a shift of a draft. Positions the generator could not fill have no
+-- employee and the reason why; schedule_id is set when the draft is published.
+CREATE TABLE roster_draft_assignments (
+    id BIGSERIAL PRIMARY KEY,
+    draft_id BIGINT NOT NULL REFERENCES roster_drafts(id) ON DELETE CASCADE,
+    location_shift_id BIGINT NOT NULL REFERENCES location_shift(id) ON DELETE CASCADE,
+    employee_id BIGINT NULL REFERENCES employee_profile(id) ON DELETE SET NULL,
+    start_datetime TIMESTAMP NOT NULL,
+    end_datetime TIMESTAMP NOT NULL,
+    unfilled_reason TEXT NULL,
+    schedule_id UUID NULL REFERENCES schedules(id) ON DELETE SET NULL,
+    CONSTRAINT valid_timeframe CHECK (end_datetime > start_datetime)
+);
+
+CREATE INDEX idx_roster_draft_assignments_draft ON roster_draft_assignments (draft_id, start_datetime);
@@ -0,0 +1,25 @@
+-- Minimum staffing of the shifts of a location. A norm for a weekday replaces the norm
+-- without weekday on that day. Weekdays count from 0 for Sunday, like EXTRACT(DOW).
+CREATE TABLE staffing_norms (
+    id BIGSERIAL PRIMARY KEY,
+    location_id BIGINT NOT NULL REFERENCES location(id) ON DELETE CASCADE,
+    location_shift_id BIGINT NOT NULL REFERENCES location_shift(id) ON DELETE CASCADE,
+    weekday SMALLINT NULL CHECK (weekday BETWEEN 0 AND 6),
+    min_staff INTEGER NOT NULL CHECK (min_staff > 0),
+    -- how many of the staff need a certification: [{"name": "BHV", "count": 1}]
+    certifications JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_staffing_norms_location ON staffing_norms (location_id);
+CREATE UNIQUE INDEX uq_staffing_norms_shift_weekday ON staffing_norms (location_shift_id, weekday) WHERE weekday IS NOT NULL;
+CREATE UNIQUE INDEX uq_staffing_norms_shift ON staffing_norms (location_shift_id) WHERE weekday IS NULL;
+
+-- allow the staffing gap alerts
+ALTER TABLE notifications DROP CONSTRAINT IF EXISTS notifications_type_check;
+ALTER TABLE notifications ADD CONSTRAINT notifications_type_check CHECK (type IN (
+    'new_appointment', 'appointment_update', 'appointment_cancellation', 'new_client_assigned',
+    'client_goal_update', 'incident_report', 'new_incident_report', 'client_contract_reminder',
+    'new_schedule_notification', 'contract_hours_budget', 'staffing_gap'
+));
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS time_entry_breaks;
+DROP TABLE IF EXISTS time_entries;
+DROP TABLE IF EXISTS location_clock_settings;
@@ -0,0 +1,49 @@
+-- How employees prove they are at a location when they clock in or out: from one of the
+-- IP ranges of the location's network or by scanning the QR code shown at the location.
+-- Locations without settings accept any clock event.
+CREATE TABLE location_clock_settings (
+    location_id BIGINT PRIMARY KEY REFERENCES location(id) ON DELETE CASCADE,
+    ip_ranges TEXT[] NOT NULL DEFAULT '{}',
+    qr_token VARCHAR(64) NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- The registered working time of a schedule. clock_in_at and clock_out_at are what the
+-- employee registered, actual_start, actual_end and break_minutes are the times that
+-- count: set on clock out and corrected by the manager who approves a deviation.
+CREATE TABLE time_entries (
+    id BIGSERIAL PRIMARY KEY,
+    schedule_id UUID NOT NULL UNIQUE REFERENCES schedules(id) ON DELETE CASCADE,
+    employee_id BIGINT NOT NULL REFERENCES employee_profile(id) ON DELETE CASCADE,
+    clock_in_at TIMESTAMP NOT NULL,
+    clock_in_method VARCHAR(10) NOT NULL CHECK (clock_in_method IN ('none', 'ip', 'qr')),
+    clock_in_ip VARCHAR(45) NULL,
+    clock_out_at TIMESTAMP NULL,
+    clock_out_method VARCHAR(10) NULL CHECK (clock_out_method IN ('none', 'ip', 'qr')),
+    clock_out_ip VARCHAR(45) NULL,
+    actual_start TIMESTAMP NULL,
+    actual_end TIMESTAMP NULL,
+    break_minutes INTEGER NOT NULL DEFAULT 0 CHECK (break_minutes >= 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'pending', 'approved', 'rejected')),
+    review_note TEXT NULL,
+    reviewed_by_employee_id BIGINT NULL REFERENCES employee_profile(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT valid_clock_times CHECK (clock_out_at IS NULL OR clock_out_at >= clock_in_at),
+    CONSTRAINT valid_actual_times CHECK (actual_end IS NULL OR actual_end >= actual_start)
+);
+
+CREATE INDEX idx_time_entries_employee ON time_entries (employee_id, clock_in_at);
+CREATE INDEX idx_time_entries_status ON time_entries (status);
+
+CREATE TABLE time_entry_breaks (
+    id BIGSERIAL PRIMARY KEY,
+    time_entry_id BIGINT NOT NULL REFERENCES time_entries(id) ON DELETE CASCADE,
+    start_at TIMESTAMP NOT NULL,
+    end_at TIMESTAMP NULL,
+    CONSTRAINT valid_break_times CHECK (end_at IS NULL OR end_at >= start_at)
+);
+
+-- at most one break is running
+CREATE UNIQUE INDEX idx_time_entry_breaks_open ON time_entry_breaks (time_entry_id) WHERE end_at IS NULL;
@@ -0,0 +1,30 @@
+-- Limits of the Arbeidstijdenwet per contract type of employees. Durations are in
+-- minutes. Exempt contract types, like ZZP, are not checked at all.
+CREATE TABLE working_time_rules (
+    contract_type VARCHAR(50) PRIMARY KEY CHECK (contract_type IN ('loondienst', 'ZZP', 'none')),
+    exempt BOOLEAN NOT NULL DEFAULT FALSE,
+    max_shift_minutes INTEGER NOT NULL CHECK (max_shift_minutes > 0),
+    max_week_minutes INTEGER NOT NULL CHECK (max_week_minutes > 0),
+    -- the average over any 16 consecutive weeks
+    max_average_week_minutes INTEGER NOT NULL CHECK (max_average_week_minutes > 0),
+    -- uninterrupted rest within 24 hours of the start of a shift
+    min_daily_rest_minutes INTEGER NOT NULL CHECK (min_daily_rest_minutes >= 0),
+    -- uninterrupted rest within 7 days of the start of a shift
+    min_weekly_rest_minutes INTEGER NOT NULL CHECK (min_weekly_rest_minutes >= 0),
+    max_night_shift_minutes INTEGER NOT NULL CHECK (max_night_shift_minutes > 0),
+    max_consecutive_night_shifts INTEGER NOT NULL CHECK (max_consecutive_night_shifts > 0),
+    -- night shifts in any 16 consecutive weeks
+    max_night_shifts_16_weeks INTEGER NOT NULL CHECK (max_night_shifts_16_weeks > 0),
+    -- rest after a series of 3 or more night shifts
+    min_rest_after_night_series_minutes INTEGER NOT NULL CHECK (min_rest_after_night_series_minutes >= 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO working_time_rules (
+    contract_type, exempt, max_shift_minutes, max_week_minutes, max_average_week_minutes,
+    min_daily_rest_minutes, min_weekly_rest_minutes, max_night_shift_minutes,
+    max_consecutive_night_shifts, max_night_shifts_16_weeks, min_rest_after_night_series_minutes
+) VALUES
+    ('loondienst', FALSE, 720, 3600, 2880, 660, 2160, 600, 5, 36, 2760),
+    ('none', FALSE, 720, 3600, 2880, 660, 2160, 600, 5, 36, 2760),
+    ('ZZP', TRUE, 720, 3600, 2880, 660, 2160, 600, 5, 36, 2760);
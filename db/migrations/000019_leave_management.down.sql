@@ -0,0 +1,13 @@
+DELETE FROM notifications WHERE type = 'shift_replacement';
+ALTER TABLE notifications DROP CONSTRAINT IF EXISTS notifications_type_check;
+ALTER TABLE notifications ADD CONSTRAINT notifications_type_check CHECK (type IN (
+    'new_appointment', 'appointment_update', 'appointment_cancellation', 'new_client_assigned',
+    'client_goal_update', 'incident_report', 'new_incident_report', 'client_contract_reminder',
+    'new_schedule_notification', 'contract_hours_budget', 'staffing_gap'
+));
+
+DROP TABLE IF EXISTS sick_report_milestones;
+DROP TABLE IF EXISTS sick_reports;
+DROP TABLE IF EXISTS leave_balance_adjustments;
+DROP TABLE IF EXISTS leave_requests;
+DROP TABLE IF EXISTS leave_types;
@@ -0,0 +1,94 @@
+-- Kinds of leave employees can take. Leave of a type that deducts from the balance is
+-- taken from the yearly leave hours the employee accrues with their contract hours.
+CREATE TABLE leave_types (
+    id BIGSERIAL PRIMARY KEY,
+    code VARCHAR(50) NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL,
+    paid BOOLEAN NOT NULL DEFAULT TRUE,
+    deducts_balance BOOLEAN NOT NULL DEFAULT TRUE,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO leave_types (code, name, paid, deducts_balance) VALUES
+    ('vacation', 'Vakantieverlof', TRUE, TRUE),
+    ('special', 'Bijzonder verlof', TRUE, FALSE),
+    ('short_term_care', 'Kortdurend zorgverlof', TRUE, FALSE),
+    ('long_term_care', 'Langdurend zorgverlof', FALSE, FALSE),
+    ('parental', 'Ouderschapsverlof', FALSE, FALSE),
+    ('unpaid', 'Onbetaald verlof', FALSE, FALSE);
+
+-- Leave from start_date up to and including end_date. hours are the working hours the
+-- leave takes, deducted from the balance when the type deducts.
+CREATE TABLE leave_requests (
+    id BIGSERIAL PRIMARY KEY,
+    employee_id BIGINT NOT NULL REFERENCES employee_profile(id) ON DELETE CASCADE,
+    leave_type_id BIGINT NOT NULL REFERENCES leave_types(id),
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    hours DOUBLE PRECISION NOT NULL CHECK (hours >= 0),
+    reason TEXT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected', 'cancelled')),
+    review_note TEXT NULL,
+    reviewed_by_employee_id BIGINT NULL REFERENCES employee_profile(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT valid_leave_dates CHECK (end_date >= start_date)
+);
+
+CREATE INDEX idx_leave_requests_employee ON leave_requests (employee_id, start_date);
+CREATE INDEX idx_leave_requests_status ON leave_requests (status);
+
+-- Corrections of the leave balance of a year, such as hours carried over from the year
+-- before or bought or sold leave. Negative hours lower the balance.
+CREATE TABLE leave_balance_adjustments (
+    id BIGSERIAL PRIMARY KEY,
+    employee_id BIGINT NOT NULL REFERENCES employee_profile(id) ON DELETE CASCADE,
+    year INTEGER NOT NULL,
+    hours DOUBLE PRECISION NOT NULL,
+    reason TEXT NOT NULL,
+    created_by_employee_id BIGINT NULL REFERENCES employee_profile(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_leave_balance_adjustments_employee ON leave_balance_adjustments (employee_id, year);
+
+-- An employee is sick from start_date until the day they recovered on, the first day
+-- they work again
+CREATE TABLE sick_reports (
+    id BIGSERIAL PRIMARY KEY,
+    employee_id BIGINT NOT NULL REFERENCES employee_profile(id) ON DELETE CASCADE,
+    start_date DATE NOT NULL,
+    expected_return_date DATE NULL,
+    recovered_on DATE NULL,
+    notes TEXT NULL,
+    reported_by_employee_id BIGINT NULL REFERENCES employee_profile(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT valid_expected_return CHECK (expected_return_date IS NULL OR expected_return_date > start_date),
+    CONSTRAINT valid_recovery CHECK (recovered_on IS NULL OR recovered_on > start_date)
+);
+
+-- an employee has at most one running sick report
+CREATE UNIQUE INDEX idx_sick_reports_open ON sick_reports (employee_id) WHERE recovered_on IS NULL;
+
+-- The steps of the Wet verbetering poortwachter that have been taken for a sick report.
+-- When they are due follows from the start of the sick report.
+CREATE TABLE sick_report_milestones (
+    sick_report_id BIGINT NOT NULL REFERENCES sick_reports(id) ON DELETE CASCADE,
+    milestone VARCHAR(50) NOT NULL,
+    completed_on DATE NOT NULL,
+    notes TEXT NULL,
+    completed_by_employee_id BIGINT NULL REFERENCES employee_profile(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (sick_report_id, milestone)
+);
+
+ALTER TABLE notifications DROP CONSTRAINT IF EXISTS notifications_type_check;
+ALTER TABLE notifications ADD CONSTRAINT notifications_type_check CHECK (type IN (
+    'new_appointment', 'appointment_update', 'appointment_cancellation', 'new_client_assigned',
+    'client_goal_update', 'incident_report', 'new_incident_report', 'client_contract_reminder',
+    'new_schedule_notification', 'contract_hours_budget', 'staffing_gap', 'shift_replacement'
+));
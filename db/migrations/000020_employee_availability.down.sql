@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS employee_shift_preferences;
+DROP TABLE IF EXISTS employee_unavailability;
+DROP TABLE IF EXISTS employee_weekly_availability;
@@ -0,0 +1,37 @@
+-- Recurring weekly availability. An employee with weekly availability is only available
+-- within these windows, an employee without it at any time. weekday counts from Sunday,
+-- 0, to Saturday, 6. A window that ends at or before its start runs into the next day.
+CREATE TABLE employee_weekly_availability (
+    id BIGSERIAL PRIMARY KEY,
+    employee_id BIGINT NOT NULL REFERENCES employee_profile(id) ON DELETE CASCADE,
+    weekday INTEGER NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+    start_time TIME NOT NULL,
+    end_time TIME NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX employee_weekly_availability_employee_id_idx ON employee_weekly_availability(employee_id);
+
+-- One-off periods in which an employee cannot work
+CREATE TABLE employee_unavailability (
+    id BIGSERIAL PRIMARY KEY,
+    employee_id BIGINT NOT NULL REFERENCES employee_profile(id) ON DELETE CASCADE,
+    start_datetime TIMESTAMP NOT NULL,
+    end_datetime TIMESTAMP NOT NULL,
+    reason TEXT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT valid_unavailability_period CHECK (end_datetime > start_datetime)
+);
+
+CREATE INDEX employee_unavailability_employee_id_idx ON employee_unavailability(employee_id, start_datetime);
+
+-- What an employee prefers to work. The roster generator favours the preferred locations
+-- and shifts and does not plan more night shifts in a month than the maximum.
+CREATE TABLE employee_shift_preferences (
+    employee_id BIGINT PRIMARY KEY REFERENCES employee_profile(id) ON DELETE CASCADE,
+    preferred_location_ids BIGINT[] NOT NULL DEFAULT '{}',
+    preferred_shift_names TEXT[] NOT NULL DEFAULT '{}',
+    max_night_shifts_per_month INTEGER NULL CHECK (max_night_shifts_per_month >= 0),
+    notes TEXT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
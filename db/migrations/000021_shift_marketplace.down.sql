@@ -0,0 +1,13 @@
+DELETE FROM notifications WHERE type = 'shift_marketplace';
+ALTER TABLE notifications DROP CONSTRAINT IF EXISTS notifications_type_check;
+ALTER TABLE notifications ADD CONSTRAINT notifications_type_check CHECK (type IN (
+    'new_appointment', 'appointment_update', 'appointment_cancellation', 'new_client_assigned',
+    'client_goal_update', 'incident_report', 'new_incident_report', 'client_contract_reminder',
+    'new_schedule_notification', 'contract_hours_budget', 'staffing_gap', 'shift_replacement'
+));
+
+DROP TABLE IF EXISTS shift_swap_requests;
+
+DROP INDEX IF EXISTS idx_schedules_open;
+DELETE FROM schedules WHERE employee_id IS NULL;
+ALTER TABLE schedules ALTER COLUMN employee_id SET NOT NULL;
@@ -0,0 +1,40 @@
+-- A schedule without an employee is an open shift, which eligible employees can claim
+ALTER TABLE schedules ALTER COLUMN employee_id DROP NOT NULL;
+
+CREATE INDEX idx_schedules_open ON schedules (location_id, start_datetime) WHERE employee_id IS NULL;
+
+-- An employee asks a colleague to take over their shift, in exchange for a shift of the
+-- colleague when target_schedule_id is set. The colleague accepts or declines, a planner
+-- approves or rejects an accepted swap.
+CREATE TABLE shift_swap_requests (
+    id BIGSERIAL PRIMARY KEY,
+    schedule_id UUID NOT NULL REFERENCES schedules(id) ON DELETE CASCADE,
+    requester_employee_id BIGINT NOT NULL REFERENCES employee_profile(id) ON DELETE CASCADE,
+    target_employee_id BIGINT NOT NULL REFERENCES employee_profile(id) ON DELETE CASCADE,
+    target_schedule_id UUID NULL REFERENCES schedules(id) ON DELETE CASCADE,
+    message TEXT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'declined', 'approved', 'rejected', 'cancelled')),
+    responded_at TIMESTAMPTZ NULL,
+    review_note TEXT NULL,
+    reviewed_by_employee_id BIGINT NULL REFERENCES employee_profile(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT different_swap_employees CHECK (requester_employee_id <> target_employee_id),
+    CONSTRAINT different_swap_schedules CHECK (target_schedule_id IS NULL OR target_schedule_id <> schedule_id)
+);
+
+CREATE INDEX idx_shift_swap_requests_requester ON shift_swap_requests (requester_employee_id);
+CREATE INDEX idx_shift_swap_requests_target ON shift_swap_requests (target_employee_id);
+CREATE INDEX idx_shift_swap_requests_status ON shift_swap_requests (status);
+-- a shift is offered to a colleague once at a time
+CREATE UNIQUE INDEX idx_shift_swap_requests_open ON shift_swap_requests (schedule_id, target_employee_id)
+    WHERE status IN ('pending', 'accepted');
+
+ALTER TABLE notifications DROP CONSTRAINT IF EXISTS notifications_type_check;
+ALTER TABLE notifications ADD CONSTRAINT notifications_type_check CHECK (type IN (
+    'new_appointment', 'appointment_update', 'appointment_cancellation', 'new_client_assigned',
+    'client_goal_update', 'incident_report', 'new_incident_report', 'client_contract_reminder',
+    'new_schedule_notification', 'contract_hours_budget', 'staffing_gap', 'shift_replacement',
+    'shift_marketplace'
+));
@@ -0,0 +1 @@
+ALTER TABLE organisations DROP COLUMN IF EXISTS iban;
@@ -0,0 +1,2 @@
+-- the bank account that invoices ask to be paid into
+ALTER TABLE organisations ADD COLUMN iban VARCHAR(34) NULL;
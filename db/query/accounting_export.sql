@@ -0,0 +1,172 @@
+-- name: ListLedgerAccountMappings :many
+SELECT * FROM ledger_account_mappings
+WHERE organisation_id = $1
+ORDER BY account_type, care_type NULLS FIRST, financing_act NULLS FIRST, vat_rate NULLS FIRST;
+
+
+-- name: DeleteLedgerAccountMappings :exec
+DELETE FROM ledger_account_mappings
+WHERE organisation_id = $1;
+
+
+-- name: CreateLedgerAccountMapping :one
+INSERT INTO ledger_account_mappings (
+    organisation_id,
+    account_type,
+    care_type,
+    financing_act,
+    vat_rate,
+    account_code,
+    vat_code,
+    description,
+    updated_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING *;
+
+
+-- name: ListUnexportedInvoicesForExport :many
+-- Issued invoices and credit notes of an organisation that are not part of an export
+-- batch yet. Canceled invoices are included when a credit note was issued against them,
+-- so the credit note is never booked without the invoice it reverses.
+SELECT
+    i.id,
+    i.invoice_number,
+    i.invoice_type,
+    i.issue_date,
+    i.total_amount,
+    i.invoice_details,
+    i.sender_id,
+    s.name AS sender_name,
+    (cd.first_name || ' ' || cd.last_name)::TEXT AS client_name
+FROM invoice i
+JOIN client_details cd ON i.client_id = cd.id
+LEFT JOIN location l ON cd.location_id = l.id
+LEFT JOIN sender s ON i.sender_id = s.id
+WHERE COALESCE(cd.organization_id, l.organisation_id, (SELECT MIN(id) FROM organisations)) = sqlc.arg('organisation_id')
+  AND i.issue_date BETWEEN sqlc.arg('start_date') AND sqlc.arg('end_date')
+  AND (
+    i.status IN ('outstanding', 'partially_paid', 'expired', 'paid', 'overpaid')
+    OR (i.invoice_type = 'credit_note' AND i.status NOT IN ('concept', 'pending_approval', 'canceled'))
+    OR (i.status = 'canceled' AND EXISTS (
+        SELECT 1 FROM invoice c
+        WHERE c.original_invoice_id = i.id
+          AND c.invoice_type = 'credit_note'
+          AND c.status NOT IN ('concept', 'pending_approval', 'canceled')
+    ))
+  )
+  AND NOT EXISTS (SELECT 1 FROM accounting_export_items e WHERE e.invoice_id = i.id)
+ORDER BY i.issue_date, i.invoice_sequence, i.id;
+
+
+-- name: ListUnexportedPaymentsForExport :many
+-- Completed payments of an organisation that are not part of an export batch yet
+SELECT
+    p.id,
+    p.invoice_id,
+    i.invoice_number,
+    p.payment_date,
+    p.amount,
+    p.payment_reference,
+    i.sender_id,
+    s.name AS sender_name
+FROM invoice_payment_history p
+JOIN invoice i ON p.invoice_id = i.id
+JOIN client_details cd ON i.client_id = cd.id
+LEFT JOIN location l ON cd.location_id = l.id
+LEFT JOIN sender s ON i.sender_id = s.id
+WHERE COALESCE(cd.organization_id, l.organisation_id, (SELECT MIN(id) FROM organisations)) = sqlc.arg('organisation_id')
+  AND p.payment_date BETWEEN sqlc.arg('start_date') AND sqlc.arg('end_date')
+  AND p.payment_status = 'completed'
+  AND NOT EXISTS (SELECT 1 FROM accounting_export_items e WHERE e.payment_id = p.id)
+ORDER BY p.payment_date, p.id;
+
+
+-- name: ListAccountingExportBatchInvoices :many
+SELECT
+    i.id,
+    i.invoice_number,
+    i.invoice_type,
+    i.issue_date,
+    i.total_amount,
+    i.invoice_details,
+    i.sender_id,
+    s.name AS sender_name,
+    (cd.first_name || ' ' || cd.last_name)::TEXT AS client_name
+FROM accounting_export_items e
+JOIN invoice i ON e.invoice_id = i.id
+JOIN client_details cd ON i.client_id = cd.id
+LEFT JOIN sender s ON i.sender_id = s.id
+WHERE e.batch_id = $1
+ORDER BY i.issue_date, i.invoice_sequence, i.id;
+
+
+-- name: ListAccountingExportBatchPayments :many
+SELECT
+    p.id,
+    p.invoice_id,
+    i.invoice_number,
+    p.payment_date,
+    p.amount,
+    p.payment_reference,
+    i.sender_id,
+    s.name AS sender_name
+FROM accounting_export_items e
+JOIN invoice_payment_history p ON e.payment_id = p.id
+JOIN invoice i ON p.invoice_id = i.id
+LEFT JOIN sender s ON i.sender_id = s.id
+WHERE e.batch_id = $1
+ORDER BY p.payment_date, p.id;
+
+
+-- name: ListContractLedgerKeys :many
+SELECT id, care_type, financing_act
+FROM contract
+WHERE id = ANY(sqlc.arg('contract_ids')::BIGINT[]);
+
+
+-- name: CreateAccountingExportBatch :one
+INSERT INTO accounting_export_batches (
+    organisation_id,
+    export_format,
+    start_date,
+    end_date,
+    sales_journal,
+    bank_journal,
+    invoice_count,
+    payment_count,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING *;
+
+
+-- name: AddAccountingExportInvoices :exec
+INSERT INTO accounting_export_items (batch_id, invoice_id)
+SELECT sqlc.arg('batch_id'), unnest(sqlc.arg('invoice_ids')::BIGINT[]);
+
+
+-- name: AddAccountingExportPayments :exec
+INSERT INTO accounting_export_items (batch_id, payment_id)
+SELECT sqlc.arg('batch_id'), unnest(sqlc.arg('payment_ids')::BIGINT[]);
+
+
+-- name: GetAccountingExportBatch :one
+SELECT * FROM accounting_export_batches
+WHERE id = $1
+LIMIT 1;
+
+
+-- name: ListAccountingExportBatches :many
+SELECT * FROM accounting_export_batches
+WHERE organisation_id = $1
+ORDER BY created_at DESC, id DESC;
+
+
+-- name: RecordAccountingExportDownload :one
+UPDATE accounting_export_batches
+SET download_count = download_count + 1,
+    last_downloaded_at = CURRENT_TIMESTAMP,
+    last_downloaded_by = $2
+WHERE id = $1
+RETURNING *;
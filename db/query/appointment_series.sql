@@ -0,0 +1,87 @@
+-- name: ListAppointmentTemplatesToMaterialize :many
+SELECT * FROM appointment_templates
+WHERE rrule IS NOT NULL
+  AND (materialized_until IS NULL OR materialized_until < sqlc.arg(horizon))
+ORDER BY created_at;
+
+-- name: GetAppointmentTemplateForUpdate :one
+SELECT * FROM appointment_templates
+WHERE id = $1
+FOR UPDATE;
+
+-- name: SetAppointmentTemplateMaterializedUntil :exec
+UPDATE appointment_templates
+SET materialized_until = sqlc.arg(materialized_until)
+WHERE id = sqlc.arg(id);
+
+-- name: CreateSeriesOccurrence :one
+-- Occurrences that already exist, also when they were moved or overridden, are left alone
+INSERT INTO scheduled_appointments (
+    appointment_templates_id,
+    creator_employee_id,
+    recurrence_id,
+    start_time,
+    end_time,
+    location,
+    description,
+    color
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+)
+ON CONFLICT (appointment_templates_id, recurrence_id) DO NOTHING
+RETURNING id;
+
+-- name: AddAppointmentTemplateExdate :exec
+UPDATE appointment_templates
+SET exdates = array_append(exdates, sqlc.arg(exdate)::timestamp),
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+  AND NOT (sqlc.arg(exdate)::timestamp = ANY(exdates));
+
+-- name: GetScheduledAppointment :one
+SELECT * FROM scheduled_appointments
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListSeriesOccurrencesInRange :many
+-- Materialized occurrences of a series that belong to the range by their place in the
+-- series or by their (moved) time
+SELECT * FROM scheduled_appointments
+WHERE appointment_templates_id = sqlc.arg(template_id)
+  AND ((recurrence_id >= sqlc.arg(start_date) AND recurrence_id < sqlc.arg(end_date))
+    OR (start_time < sqlc.arg(end_date) AND end_time > sqlc.arg(start_date)))
+ORDER BY start_time;
+
+-- name: UpdateAppointmentTemplate :one
+UPDATE appointment_templates
+SET start_time = $2,
+    end_time = $3,
+    location = $4,
+    description = $5,
+    color = $6,
+    recurrence_type = $7,
+    recurrence_interval = $8,
+    recurrence_end_date = $9,
+    rrule = $10,
+    exdates = $11,
+    rdates = $12,
+    participant_employee_ids = $13,
+    client_ids = $14,
+    materialized_until = $15,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteSeriesOccurrencesFrom :execrows
+-- Removes the occurrences of a series from a place in the series on, all of them when
+-- from is NULL. Occurrences that have started are kept.
+DELETE FROM scheduled_appointments
+WHERE appointment_templates_id = sqlc.arg(template_id)
+  AND (sqlc.narg('from')::timestamp IS NULL OR recurrence_id >= sqlc.narg('from'))
+  AND start_time >= sqlc.arg(not_before);
+
+-- name: GetSeriesOccurrence :one
+SELECT * FROM scheduled_appointments
+WHERE appointment_templates_id = $1
+  AND recurrence_id = $2
+LIMIT 1;
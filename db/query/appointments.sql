@@ -20,9 +20,14 @@ INSERT INTO appointment_templates (
     color,        
     recurrence_type,
     recurrence_interval,
-    recurrence_end_date
+    recurrence_end_date,
+    rrule,
+    exdates,
+    rdates,
+    participant_employee_ids,
+    client_ids
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
 ) RETURNING *;
 
 
@@ -211,6 +216,8 @@ SET
     location = COALESCE ($4, location),
     description = COALESCE ($5, description),
     color = COALESCE ($6, color),
+    -- an edited occurrence of a series overrides the series
+    is_exception = appointment_templates_id IS NOT NULL,
     updated_at = NOW()
 WHERE id = $1
 RETURNING *;
@@ -0,0 +1,83 @@
+-- name: ListEmployeesWeeklyAvailability :many
+SELECT * FROM employee_weekly_availability
+WHERE employee_id = ANY(sqlc.arg(employee_ids)::bigint[])
+ORDER BY employee_id, weekday, start_time;
+
+-- name: DeleteEmployeeWeeklyAvailability :exec
+DELETE FROM employee_weekly_availability
+WHERE employee_id = $1;
+
+-- name: CreateEmployeeWeeklyAvailability :exec
+INSERT INTO employee_weekly_availability (
+    employee_id,
+    weekday,
+    start_time,
+    end_time
+)
+SELECT
+    sqlc.arg(employee_id),
+    w.weekday,
+    w.start_time,
+    w.end_time
+FROM unnest(
+    sqlc.arg(weekdays)::integer[],
+    sqlc.arg(start_times)::time[],
+    sqlc.arg(end_times)::time[]
+) AS w(weekday, start_time, end_time);
+
+-- name: CreateEmployeeUnavailability :one
+INSERT INTO employee_unavailability (
+    employee_id,
+    start_datetime,
+    end_datetime,
+    reason
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: ListEmployeeUnavailability :many
+-- The unavailability of an employee that has not ended at the given time
+SELECT * FROM employee_unavailability
+WHERE employee_id = sqlc.arg(employee_id)
+  AND end_datetime > sqlc.arg(from_datetime)
+ORDER BY start_datetime;
+
+-- name: ListEmployeesUnavailability :many
+-- The unavailability of the employees that overlaps the period
+SELECT * FROM employee_unavailability
+WHERE employee_id = ANY(sqlc.arg(employee_ids)::bigint[])
+  AND start_datetime < sqlc.arg(end_date)
+  AND end_datetime > sqlc.arg(start_date)
+ORDER BY employee_id, start_datetime;
+
+-- name: DeleteEmployeeUnavailability :one
+DELETE FROM employee_unavailability
+WHERE id = $1 AND employee_id = $2
+RETURNING *;
+
+-- name: GetEmployeeShiftPreferences :one
+SELECT * FROM employee_shift_preferences
+WHERE employee_id = $1;
+
+-- name: ListEmployeesShiftPreferences :many
+SELECT * FROM employee_shift_preferences
+WHERE employee_id = ANY(sqlc.arg(employee_ids)::bigint[]);
+
+-- name: UpsertEmployeeShiftPreferences :one
+INSERT INTO employee_shift_preferences (
+    employee_id,
+    preferred_location_ids,
+    preferred_shift_names,
+    max_night_shifts_per_month,
+    notes
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (employee_id) DO UPDATE
+SET preferred_location_ids = EXCLUDED.preferred_location_ids,
+    preferred_shift_names = EXCLUDED.preferred_shift_names,
+    max_night_shifts_per_month = EXCLUDED.max_night_shifts_per_month,
+    notes = EXCLUDED.notes,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
@@ -0,0 +1,117 @@
+-- name: CreateBankStatementImport :one
+INSERT INTO bank_statement_imports (
+    file_name,
+    format,
+    account_iban,
+    statement_reference,
+    imported_by
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+
+-- name: UpdateBankStatementImportCounts :one
+UPDATE bank_statement_imports
+SET
+    total_lines = $2,
+    matched_lines = $3
+WHERE id = $1
+RETURNING *;
+
+
+-- name: CreateBankStatementLine :one
+-- Lines that were imported before (same hash) are skipped, no row is returned for them.
+INSERT INTO bank_statement_lines (
+    import_id,
+    line_hash,
+    entry_reference,
+    booking_date,
+    amount,
+    currency,
+    counterparty_name,
+    counterparty_iban,
+    remittance_info,
+    suggested_invoice_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+)
+ON CONFLICT (line_hash) DO NOTHING
+RETURNING *;
+
+
+-- name: GetBankStatementLine :one
+SELECT * FROM bank_statement_lines
+WHERE id = $1
+LIMIT 1;
+
+
+-- name: ListBankStatementLines :many
+SELECT
+    bsl.*,
+    COUNT(*) OVER() AS total_count,
+    si.invoice_number AS suggested_invoice_number,
+    mi.invoice_number AS invoice_number
+FROM
+    bank_statement_lines bsl
+LEFT JOIN
+    invoice si ON bsl.suggested_invoice_id = si.id
+LEFT JOIN
+    invoice mi ON bsl.invoice_id = mi.id
+WHERE
+    (bsl.status = sqlc.narg('status') OR sqlc.narg('status') IS NULL)
+    AND (bsl.import_id = sqlc.narg('import_id') OR sqlc.narg('import_id') IS NULL)
+ORDER BY
+    bsl.booking_date DESC, bsl.id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+
+-- name: MatchBankStatementLine :one
+UPDATE bank_statement_lines
+SET
+    status = 'matched',
+    match_method = $2,
+    invoice_id = $3,
+    payment_id = $4,
+    reviewed_by = $5,
+    reviewed_at = $6
+WHERE id = $1
+RETURNING *;
+
+
+-- name: IgnoreBankStatementLine :one
+UPDATE bank_statement_lines
+SET
+    status = 'ignored',
+    review_note = $2,
+    reviewed_by = $3,
+    reviewed_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND status = 'unmatched'
+RETURNING *;
+
+
+-- name: ListOpenInvoicesForMatching :many
+SELECT
+    i.id,
+    i.invoice_number,
+    i.total_amount,
+    i.status,
+    i.sender_id,
+    s.name AS sender_name,
+    COALESCE((
+        SELECT SUM(p.amount)
+        FROM invoice_payment_history p
+        WHERE p.invoice_id = i.id AND p.payment_status = 'completed'
+    ), 0)::DECIMAL(20,2) AS paid_amount,
+    COALESCE((
+        SELECT SUM(-c.total_amount)
+        FROM invoice c
+        WHERE c.original_invoice_id = i.id AND c.invoice_type = 'credit_note' AND c.status <> 'canceled'
+    ), 0)::DECIMAL(20,2) AS credited_amount
+FROM
+    invoice i
+LEFT JOIN
+    sender s ON i.sender_id = s.id
+WHERE
+    i.invoice_type = 'standard'
+    AND i.status IN ('approved', 'outstanding', 'partially_paid', 'expired');
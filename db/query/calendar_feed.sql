@@ -0,0 +1,48 @@
+-- name: UpsertCalendarFeedToken :one
+INSERT INTO calendar_feed_tokens (
+    user_id,
+    token_hash
+) VALUES (
+    $1, $2
+)
+ON CONFLICT (user_id) DO UPDATE
+SET token_hash = EXCLUDED.token_hash,
+    created_at = CURRENT_TIMESTAMP,
+    last_used_at = NULL
+RETURNING *;
+
+-- name: GetCalendarFeedToken :one
+SELECT * FROM calendar_feed_tokens
+WHERE user_id = $1
+LIMIT 1;
+
+-- name: GetCalendarFeedTokenByHash :one
+SELECT * FROM calendar_feed_tokens
+WHERE token_hash = $1
+LIMIT 1;
+
+-- name: MarkCalendarFeedTokenUsed :exec
+UPDATE calendar_feed_tokens
+SET last_used_at = CURRENT_TIMESTAMP
+WHERE user_id = $1;
+
+-- name: DeleteCalendarFeedToken :execrows
+DELETE FROM calendar_feed_tokens
+WHERE user_id = $1;
+
+-- name: ListEmployeeCalendarShifts :many
+SELECT
+    s.id,
+    s.start_datetime,
+    s.end_datetime,
+    s.updated_at,
+    l.name AS location_name,
+    l.address AS location_address,
+    ls.shift_name
+FROM schedules s
+JOIN location l ON s.location_id = l.id
+LEFT JOIN location_shift ls ON s.location_shift_id = ls.id
+WHERE s.employee_id = sqlc.arg(employee_id)
+  AND s.start_datetime < sqlc.arg(end_date)
+  AND s.end_datetime > sqlc.arg(start_date)
+ORDER BY s.start_datetime;
@@ -31,9 +31,10 @@ INSERT INTO contract (
     sender_id,
     attachment_ids,
     financing_act,
-    financing_option
+    financing_option,
+    indication_number
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18
 )
 RETURNING *;
 
@@ -77,6 +78,7 @@ SET
     attachment_ids = COALESCE(sqlc.narg('attachment_ids'), attachment_ids),
     financing_act = COALESCE(sqlc.narg('financing_act'), financing_act),
     financing_option = COALESCE(sqlc.narg('financing_option'), financing_option),
+    indication_number = COALESCE(sqlc.narg('indication_number'), indication_number),
     updated_at = NOW()
 WHERE id = $1
 RETURNING *;
@@ -215,47 +217,17 @@ RETURNING *;
 
 
 -- name: GetBillablePeriodsForContract :many
-WITH raw_status_changes AS (
-  SELECT
-    new_values->>'status' AS status,
-    changed_at AS effective_date
-  FROM contract_audit
-  WHERE contract_id = sqlc.arg(contract_id)
-    AND new_values->>'status' IS NOT NULL
-  
-  UNION ALL
-  
-  SELECT
-    status,
-    updated_at
-  FROM contract
-  WHERE id = sqlc.arg(contract_id)
-),
-
--- Deduplicate identical status within 1 second windows
-status_history AS (
-  SELECT
-    status,
-    MIN(effective_date) AS effective_date
-  FROM raw_status_changes
-  GROUP BY status, EXTRACT(EPOCH FROM effective_date)::INTEGER
-  ORDER BY MIN(effective_date)
-),
-
-approved_periods AS (
-  SELECT
-    effective_date AS period_start,
-    LEAD(effective_date, 1) OVER (ORDER BY effective_date) AS period_end
-  FROM status_history
-  WHERE status = 'approved'
-)
-
+-- Approved periods of the status timeline clipped to the invoice period. Suspensions
+-- split an approved period, so they are left out.
 SELECT
-  GREATEST(period_start, sqlc.arg(invoice_start_date))::TIMESTAMPTZ AS billable_start,
-  LEAST(COALESCE(period_end, sqlc.arg(invoice_end_date)), sqlc.arg(invoice_end_date))::TIMESTAMPTZ AS billable_end
-FROM approved_periods
-WHERE period_start < sqlc.arg(invoice_end_date)
-  AND COALESCE(period_end, 'infinity'::TIMESTAMPTZ) > sqlc.arg(invoice_start_date);
+  GREATEST(effective_from, sqlc.arg(invoice_start_date))::TIMESTAMPTZ AS billable_start,
+  LEAST(COALESCE(effective_to, sqlc.arg(invoice_end_date)), sqlc.arg(invoice_end_date))::TIMESTAMPTZ AS billable_end
+FROM contract_status_periods
+WHERE contract_id = sqlc.arg(contract_id)
+  AND status = 'approved'
+  AND effective_from < sqlc.arg(invoice_end_date)
+  AND COALESCE(effective_to, 'infinity'::TIMESTAMPTZ) > sqlc.arg(invoice_start_date)
+ORDER BY effective_from;
 
 
 
@@ -0,0 +1,153 @@
+-- name: CreateContractWorkingHours :one
+INSERT INTO contract_working_hours (
+    contract_id,
+    minutes,
+    "datetime",
+    notes
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: ListContractWorkingHours :many
+SELECT
+    *,
+    COUNT(*) OVER() AS total_count
+FROM
+    contract_working_hours
+WHERE
+    contract_id = $1
+ORDER BY
+    "datetime" DESC
+LIMIT $2 OFFSET $3;
+
+-- name: DeleteContractWorkingHours :one
+DELETE FROM contract_working_hours
+WHERE id = $1 AND contract_id = $2
+RETURNING *;
+
+-- name: GetContractConsumedMinutes :one
+-- Care delivered in a period: finished appointments of the client that were not
+-- cancelled plus the minutes registered on the contract. An appointment counts for one
+-- contract only, the newest ambulante contract that is approved on the status timeline
+-- when it starts. Registered minutes that overlap a counted appointment are the same
+-- care and are not counted again.
+WITH counted_appointments AS (
+    SELECT DISTINCT sa.id, sa.start_time, sa.end_time
+    FROM scheduled_appointments sa
+    JOIN appointment_clients ac ON sa.id = ac.appointment_id
+    JOIN contract c ON c.client_id = ac.client_id
+    WHERE c.id = sqlc.arg('contract_id')
+      AND sa.status <> 'CANCELLED'
+      AND sa.end_time <= NOW()
+      AND sa.start_time >= sqlc.arg('period_start')::timestamp
+      AND sa.start_time < sqlc.arg('period_end')::timestamp
+      AND EXISTS (
+          SELECT 1 FROM contract_status_periods sp
+          WHERE sp.contract_id = c.id
+            AND sp.status = 'approved'
+            AND sp.effective_from <= sa.start_time
+            AND COALESCE(sp.effective_to, 'infinity'::TIMESTAMPTZ) > sa.start_time
+      )
+      AND NOT EXISTS (
+          SELECT 1
+          FROM contract other
+          JOIN contract_status_periods osp ON osp.contract_id = other.id
+          WHERE other.client_id = c.client_id
+            AND other.id <> c.id
+            AND other.care_type = 'ambulante'
+            AND (other.start_date, other.id) > (c.start_date, c.id)
+            AND osp.status = 'approved'
+            AND osp.effective_from <= sa.start_time
+            AND COALESCE(osp.effective_to, 'infinity'::TIMESTAMPTZ) > sa.start_time
+      )
+)
+SELECT
+    COALESCE((
+        SELECT SUM(EXTRACT(EPOCH FROM (ca.end_time - ca.start_time)) / 60)
+        FROM counted_appointments ca
+    ), 0)::DECIMAL(10,2) AS appointment_minutes,
+    COALESCE((
+        SELECT SUM(cwh.minutes)
+        FROM contract_working_hours cwh
+        WHERE cwh.contract_id = sqlc.arg('contract_id')
+          AND cwh."datetime" >= sqlc.arg('period_start')::timestamp
+          AND cwh."datetime" < sqlc.arg('period_end')::timestamp
+          AND NOT EXISTS (
+              SELECT 1 FROM counted_appointments ca
+              WHERE ca.start_time < (cwh."datetime" AT TIME ZONE 'Europe/Amsterdam') + make_interval(mins => cwh.minutes)
+                AND ca.end_time > (cwh."datetime" AT TIME ZONE 'Europe/Amsterdam')
+          )
+    ), 0)::DECIMAL(10,2) AS registered_minutes;
+
+-- name: ListContractsWithHoursBudget :many
+-- Running ambulante contracts that have an indication of hours and are approved on the
+-- status timeline, suspended contracts are skipped.
+SELECT
+    c.id,
+    c.client_id,
+    cd.first_name AS client_first_name,
+    cd.last_name AS client_last_name,
+    c.hours,
+    c.hours_type,
+    c.start_date,
+    c.end_date
+FROM
+    contract c
+JOIN
+    client_details cd ON c.client_id = cd.id
+WHERE
+    c.care_type = 'ambulante'
+    AND c.hours > 0
+    AND c.hours_type IS NOT NULL
+    AND c.start_date <= NOW()
+    AND c.end_date > NOW()
+    AND EXISTS (
+        SELECT 1 FROM contract_status_periods sp
+        WHERE sp.contract_id = c.id
+          AND sp.status = 'approved'
+          AND sp.effective_from <= NOW()
+          AND COALESCE(sp.effective_to, 'infinity'::TIMESTAMPTZ) > NOW()
+    );
+
+-- name: GetContractBilledMinutes :one
+-- Ambulante minutes billed on a contract by the invoices that were not canceled. Credit
+-- notes that mirror a whole line subtract its minutes.
+SELECT
+    COALESCE(SUM(
+        CASE WHEN i.invoice_type = 'credit_note' THEN -1 ELSE 1 END
+        * (p->>'ambulante_total_minutes')::DECIMAL
+    ), 0)::DECIMAL(10,2) AS billed_minutes
+FROM invoice i
+CROSS JOIN LATERAL jsonb_array_elements(COALESCE(i.invoice_details, '[]'::jsonb)) d
+CROSS JOIN LATERAL jsonb_array_elements(COALESCE(d->'periods', '[]'::jsonb)) p
+WHERE (d->>'contract_id')::BIGINT = $1
+  AND i.status <> 'canceled'
+  AND p->>'ambulante_total_minutes' IS NOT NULL;
+
+-- name: CreateContractBudgetAlert :one
+-- Returns no rows when the alert was already sent for the period.
+INSERT INTO contract_budget_alerts (
+    contract_id,
+    threshold,
+    period_start,
+    authorized_minutes,
+    consumed_minutes
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (contract_id, threshold, period_start) DO NOTHING
+RETURNING *;
+
+-- name: ListContractCaseManagerUserIDs :many
+-- User accounts of the employees assigned to the client as case manager.
+SELECT DISTINCT
+    ep.user_id
+FROM
+    assigned_employee ae
+JOIN
+    employee_profile ep ON ae.employee_id = ep.id
+JOIN
+    contract c ON c.client_id = ae.client_id
+WHERE
+    c.id = $1
+    AND regexp_replace(lower(ae.role), '[^a-z]', '', 'g') = 'casemanager';
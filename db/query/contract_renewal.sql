@@ -0,0 +1,88 @@
+-- name: CreateContractRenewal :one
+INSERT INTO contract_renewals (
+    predecessor_id,
+    successor_id,
+    price_index_percentage,
+    created_by
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+
+-- name: GetContractRenewalByPredecessor :one
+SELECT * FROM contract_renewals
+WHERE predecessor_id = $1
+LIMIT 1;
+
+
+-- name: GetContractRenewalBySuccessor :one
+SELECT * FROM contract_renewals
+WHERE successor_id = $1
+LIMIT 1;
+
+
+-- name: CloseContractRenewal :exec
+UPDATE contract_renewals
+SET closed_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+
+-- name: ExpireRenewedContracts :many
+-- Expires the renewed contracts whose expired period on the status timeline has started
+UPDATE contract c
+SET status = 'expired'
+WHERE c.status <> 'expired'
+  AND EXISTS (
+      SELECT 1 FROM contract_renewals r
+      WHERE r.predecessor_id = c.id
+        AND r.closed_at IS NOT NULL
+  )
+  AND EXISTS (
+      SELECT 1 FROM contract_status_periods sp
+      WHERE sp.contract_id = c.id
+        AND sp.status = 'expired'
+        AND sp.effective_from <= NOW()
+        AND COALESCE(sp.effective_to, 'infinity'::TIMESTAMPTZ) > NOW()
+  )
+RETURNING c.id;
+
+
+-- name: ListContractVersions :many
+-- All versions of a contract from the first contract to the latest renewal
+WITH RECURSIVE first_version AS (
+    SELECT sqlc.arg('contract_id')::BIGINT AS id
+    UNION ALL
+    SELECT r.predecessor_id
+    FROM contract_renewals r
+    JOIN first_version f ON r.successor_id = f.id
+),
+versions AS (
+    SELECT f.id, 1 AS version
+    FROM first_version f
+    WHERE NOT EXISTS (SELECT 1 FROM contract_renewals r WHERE r.successor_id = f.id)
+    UNION ALL
+    SELECT r.successor_id, v.version + 1
+    FROM contract_renewals r
+    JOIN versions v ON r.predecessor_id = v.id
+)
+SELECT
+    v.version::INT AS version,
+    c.id,
+    c.status,
+    c.start_date,
+    c.end_date,
+    c.price,
+    c.price_time_unit,
+    r.price_index_percentage,
+    r.closed_at
+FROM versions v
+JOIN contract c ON c.id = v.id
+LEFT JOIN contract_renewals r ON r.successor_id = c.id
+ORDER BY v.version;
+
+
+-- name: GetContractForUpdate :one
+-- Locks the contract while it is renewed or closed
+SELECT * FROM contract
+WHERE id = $1
+FOR UPDATE;
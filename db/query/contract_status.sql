@@ -0,0 +1,35 @@
+-- name: ListContractStatusPeriods :many
+SELECT * FROM contract_status_periods
+WHERE contract_id = $1
+ORDER BY effective_from ASC;
+
+
+-- name: CreateContractStatusPeriod :one
+INSERT INTO contract_status_periods (
+    contract_id,
+    status,
+    effective_from,
+    effective_to,
+    reason,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+
+-- name: DeleteContractStatusPeriods :exec
+DELETE FROM contract_status_periods
+WHERE contract_id = $1;
+
+
+-- name: ListApprovedContractPeriods :many
+-- Approved periods of all contracts that overlap a window.
+SELECT
+    contract_id,
+    effective_from,
+    effective_to
+FROM contract_status_periods
+WHERE status = 'approved'
+  AND effective_from < sqlc.arg('window_end')
+  AND COALESCE(effective_to, 'infinity'::TIMESTAMPTZ) > sqlc.arg('window_start')
+ORDER BY contract_id, effective_from;
@@ -79,6 +79,10 @@ WHERE
 SELECT user_id FROM employee_profile
 WHERE id = $1 LIMIT 1;
 
+-- name: ListEmployeeUserIDs :many
+SELECT user_id FROM employee_profile
+WHERE id = ANY(sqlc.arg(employee_ids)::bigint[]);
+
 -- name: GetEmployeeProfileByUserID :one
 SELECT 
     cu.id           AS user_id,
@@ -0,0 +1,236 @@
+-- name: ListReceivablesAgingBySender :many
+-- Open balances as of a date, bucketed by the days past the due date. Payments and
+-- issued credit notes up to the date reduce the balance.
+WITH open_invoices AS (
+    SELECT
+        i.sender_id,
+        GREATEST(sqlc.arg('as_of')::date - i.due_date, 0) AS days_overdue,
+        i.total_amount - COALESCE((
+            SELECT SUM(p.amount)
+            FROM invoice_payment_history p
+            WHERE p.invoice_id = i.id
+              AND p.payment_status = 'completed'
+              AND p.payment_date <= sqlc.arg('as_of')::date
+        ), 0) - COALESCE((
+            SELECT SUM(-c.total_amount)
+            FROM invoice c
+            WHERE c.original_invoice_id = i.id
+              AND c.invoice_type = 'credit_note'
+              AND c.status <> 'canceled'
+              AND c.issue_date <= sqlc.arg('as_of')::date
+        ), 0) AS balance
+    FROM
+        invoice i
+    WHERE
+        i.invoice_type = 'standard'
+        AND i.status IN ('outstanding', 'partially_paid', 'expired', 'paid', 'overpaid', 'imported')
+        AND i.issue_date <= sqlc.arg('as_of')::date
+)
+SELECT
+    o.sender_id,
+    s.name AS sender_name,
+    COUNT(*) AS invoice_count,
+    COALESCE(SUM(o.balance) FILTER (WHERE o.days_overdue <= 30), 0)::DECIMAL(20,2) AS days_0_30,
+    COALESCE(SUM(o.balance) FILTER (WHERE o.days_overdue BETWEEN 31 AND 60), 0)::DECIMAL(20,2) AS days_31_60,
+    COALESCE(SUM(o.balance) FILTER (WHERE o.days_overdue BETWEEN 61 AND 90), 0)::DECIMAL(20,2) AS days_61_90,
+    COALESCE(SUM(o.balance) FILTER (WHERE o.days_overdue > 90), 0)::DECIMAL(20,2) AS days_over_90,
+    COALESCE(SUM(o.balance), 0)::DECIMAL(20,2) AS total_balance
+FROM
+    open_invoices o
+LEFT JOIN
+    sender s ON o.sender_id = s.id
+WHERE
+    o.balance > 0
+GROUP BY
+    o.sender_id, s.name
+ORDER BY
+    total_balance DESC;
+
+-- name: ListReceivablesAgingByClient :many
+WITH open_invoices AS (
+    SELECT
+        i.client_id,
+        GREATEST(sqlc.arg('as_of')::date - i.due_date, 0) AS days_overdue,
+        i.total_amount - COALESCE((
+            SELECT SUM(p.amount)
+            FROM invoice_payment_history p
+            WHERE p.invoice_id = i.id
+              AND p.payment_status = 'completed'
+              AND p.payment_date <= sqlc.arg('as_of')::date
+        ), 0) - COALESCE((
+            SELECT SUM(-c.total_amount)
+            FROM invoice c
+            WHERE c.original_invoice_id = i.id
+              AND c.invoice_type = 'credit_note'
+              AND c.status <> 'canceled'
+              AND c.issue_date <= sqlc.arg('as_of')::date
+        ), 0) AS balance
+    FROM
+        invoice i
+    WHERE
+        i.invoice_type = 'standard'
+        AND i.status IN ('outstanding', 'partially_paid', 'expired', 'paid', 'overpaid', 'imported')
+        AND i.issue_date <= sqlc.arg('as_of')::date
+)
+SELECT
+    o.client_id,
+    c.first_name,
+    c.last_name,
+    COUNT(*) AS invoice_count,
+    COALESCE(SUM(o.balance) FILTER (WHERE o.days_overdue <= 30), 0)::DECIMAL(20,2) AS days_0_30,
+    COALESCE(SUM(o.balance) FILTER (WHERE o.days_overdue BETWEEN 31 AND 60), 0)::DECIMAL(20,2) AS days_31_60,
+    COALESCE(SUM(o.balance) FILTER (WHERE o.days_overdue BETWEEN 61 AND 90), 0)::DECIMAL(20,2) AS days_61_90,
+    COALESCE(SUM(o.balance) FILTER (WHERE o.days_overdue > 90), 0)::DECIMAL(20,2) AS days_over_90,
+    COALESCE(SUM(o.balance), 0)::DECIMAL(20,2) AS total_balance
+FROM
+    open_invoices o
+JOIN
+    client_details c ON o.client_id = c.id
+WHERE
+    o.balance > 0
+GROUP BY
+    o.client_id, c.first_name, c.last_name
+ORDER BY
+    total_balance DESC;
+
+-- name: GetFinanceSummary :one
+-- Totals of a period, the open balance is taken at the end of the period.
+SELECT
+    COALESCE((
+        SELECT SUM(i.total_amount)
+        FROM invoice i
+        WHERE i.invoice_type = 'standard'
+          AND i.status IN ('outstanding', 'partially_paid', 'expired', 'paid', 'overpaid', 'imported')
+          AND i.issue_date BETWEEN sqlc.arg('start_date')::date AND sqlc.arg('end_date')::date
+    ), 0)::DECIMAL(20,2) AS invoiced_amount,
+    COALESCE((
+        SELECT SUM(p.amount)
+        FROM invoice_payment_history p
+        WHERE p.payment_status = 'completed'
+          AND p.payment_date BETWEEN sqlc.arg('start_date')::date AND sqlc.arg('end_date')::date
+    ), 0)::DECIMAL(20,2) AS received_amount,
+    (
+        SELECT COUNT(*)
+        FROM invoice i
+        WHERE i.invoice_type = 'credit_note'
+          AND i.status <> 'canceled'
+          AND i.issue_date BETWEEN sqlc.arg('start_date')::date AND sqlc.arg('end_date')::date
+    ) AS credit_note_count,
+    COALESCE((
+        SELECT SUM(ABS(i.total_amount))
+        FROM invoice i
+        WHERE i.invoice_type = 'credit_note'
+          AND i.status <> 'canceled'
+          AND i.issue_date BETWEEN sqlc.arg('start_date')::date AND sqlc.arg('end_date')::date
+    ), 0)::DECIMAL(20,2) AS credited_amount,
+    COALESCE((
+        SELECT SUM(i.total_amount - COALESCE((
+            SELECT SUM(p.amount)
+            FROM invoice_payment_history p
+            WHERE p.invoice_id = i.id
+              AND p.payment_status = 'completed'
+              AND p.payment_date <= sqlc.arg('end_date')::date
+        ), 0) - COALESCE((
+            SELECT SUM(-c.total_amount)
+            FROM invoice c
+            WHERE c.original_invoice_id = i.id
+              AND c.invoice_type = 'credit_note'
+              AND c.status <> 'canceled'
+              AND c.issue_date <= sqlc.arg('end_date')::date
+        ), 0))
+        FROM invoice i
+        WHERE i.invoice_type = 'standard'
+          AND i.status IN ('outstanding', 'partially_paid', 'expired', 'paid', 'overpaid', 'imported')
+          AND i.issue_date <= sqlc.arg('end_date')::date
+    ), 0)::DECIMAL(20,2) AS open_balance;
+
+-- name: ListMonthlyInvoicedReceived :many
+SELECT
+    m.month::date AS month,
+    COALESCE((
+        SELECT SUM(i.total_amount)
+        FROM invoice i
+        WHERE i.invoice_type = 'standard'
+          AND i.status IN ('outstanding', 'partially_paid', 'expired', 'paid', 'overpaid', 'imported')
+          AND date_trunc('month', i.issue_date) = m.month
+    ), 0)::DECIMAL(20,2) AS invoiced_amount,
+    COALESCE((
+        SELECT SUM(p.amount)
+        FROM invoice_payment_history p
+        WHERE p.payment_status = 'completed'
+          AND date_trunc('month', p.payment_date) = m.month
+    ), 0)::DECIMAL(20,2) AS received_amount,
+    (
+        SELECT COUNT(*)
+        FROM invoice i
+        WHERE i.invoice_type = 'credit_note'
+          AND i.status <> 'canceled'
+          AND date_trunc('month', i.issue_date) = m.month
+    ) AS credit_note_count,
+    COALESCE((
+        SELECT SUM(ABS(i.total_amount))
+        FROM invoice i
+        WHERE i.invoice_type = 'credit_note'
+          AND i.status <> 'canceled'
+          AND date_trunc('month', i.issue_date) = m.month
+    ), 0)::DECIMAL(20,2) AS credited_amount
+FROM
+    generate_series(
+        date_trunc('month', sqlc.arg('start_date')::date),
+        date_trunc('month', sqlc.arg('end_date')::date),
+        INTERVAL '1 month'
+    ) AS m(month)
+ORDER BY
+    m.month;
+
+-- name: ListContractsForForecast :many
+-- Contracts that run and are approved at some point in the forecast window, with the
+-- renewal that continues them when there is one.
+SELECT
+    c.id,
+    c.client_id,
+    cd.first_name AS client_first_name,
+    cd.last_name AS client_last_name,
+    c.care_type,
+    c.price,
+    c.price_time_unit,
+    c.vat,
+    c.hours,
+    c.hours_type,
+    c.start_date,
+    c.end_date,
+    c.reminder_period,
+    c.financing_act,
+    c.sender_id,
+    s.name AS sender_name,
+    cd.location_id,
+    l.name AS location_name,
+    r.successor_id AS renewal_contract_id,
+    r.closed_at AS renewal_closed_at,
+    sc.start_date AS renewal_start_date,
+    sc.end_date AS renewal_end_date,
+    sc.price AS renewal_price
+FROM
+    contract c
+JOIN
+    client_details cd ON c.client_id = cd.id
+LEFT JOIN
+    sender s ON c.sender_id = s.id
+LEFT JOIN
+    location l ON cd.location_id = l.id
+LEFT JOIN
+    contract_renewals r ON r.predecessor_id = c.id
+LEFT JOIN
+    contract sc ON sc.id = r.successor_id
+WHERE
+    EXISTS (
+        SELECT 1 FROM contract_status_periods sp
+        WHERE sp.contract_id = c.id
+          AND sp.status = 'approved'
+          AND sp.effective_from < sqlc.arg('window_end')
+          AND COALESCE(sp.effective_to, 'infinity'::TIMESTAMPTZ) > sqlc.arg('window_start')
+    )
+    AND c.start_date < sqlc.arg('window_end')
+    AND c.end_date >= sqlc.arg('window_start')
+ORDER BY
+    c.id;
@@ -66,6 +66,10 @@ SELECT
     s.kvknumber AS sender_kvknumber,
     s.btwnumber AS sender_btwnumber,
     s.address AS sender_address,
+    s.place AS sender_place,
+    s.land AS sender_land,
+    s.email_address AS sender_email_address,
+    s.types AS sender_types,
     cd.first_name AS client_first_name,
     cd.last_name AS client_last_name
 FROM
@@ -78,13 +82,6 @@ WHERE
     i.id = $1
 LIMIT 1;
 
--- name: GetMaxInvoiceSequenceForDate :one
-SELECT COALESCE(MAX(invoice_sequence), 0)::BIGINT as max_sequence
-FROM invoice
-WHERE DATE(created_at) = DATE($1);
-
-
-
 -- name: UpdateInvoice :one
 UPDATE invoice
 SET
@@ -242,4 +239,60 @@ RETURNING *;
 -- name: GetInvoiceSenderID :one
 SELECT sender_id
 FROM invoice
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+
+
+-- name: GetInvoiceIssuer :one
+SELECT o.*
+FROM invoice i
+JOIN client_details cd ON i.client_id = cd.id
+LEFT JOIN location l ON cd.location_id = l.id
+JOIN organisations o ON o.id = COALESCE(cd.organization_id, l.organisation_id)
+WHERE i.id = $1
+LIMIT 1;
+
+
+-- name: CreateCreditNote :one
+INSERT INTO invoice (
+    invoice_number,
+    invoice_sequence,
+    due_date,
+    issue_date,
+    invoice_details,
+    total_amount,
+    extra_content,
+    client_id,
+    sender_id,
+    original_invoice_id,
+    invoice_type
+    ) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, 'credit_note'
+) RETURNING *;
+
+
+-- name: ListInvoiceCreditNotes :many
+SELECT
+    id,
+    invoice_number,
+    issue_date,
+    status,
+    invoice_details,
+    total_amount,
+    created_at
+FROM
+    invoice
+WHERE
+    original_invoice_id = $1
+    AND invoice_type = 'credit_note'
+    AND status <> 'canceled'
+ORDER BY
+    created_at ASC;
+
+
+-- name: GetInvoiceCreditedAmount :one
+SELECT
+    COALESCE(SUM(-total_amount), 0)::FLOAT AS credited_amount
+FROM invoice
+WHERE original_invoice_id = $1
+  AND invoice_type = 'credit_note'
+  AND status <> 'canceled';
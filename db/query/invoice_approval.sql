@@ -0,0 +1,59 @@
+-- name: CreateInvoiceApprovalEvent :one
+INSERT INTO invoice_audit (
+    invoice_id,
+    operation,
+    changed_by,
+    old_values,
+    new_values,
+    changed_fields
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+
+-- name: GetInvoiceApprovalActors :one
+-- The employee that created the invoice and the one that last submitted it for approval.
+SELECT
+    i.id,
+    created.changed_by AS created_by,
+    submitted.changed_by AS submitted_by
+FROM
+    invoice i
+LEFT JOIN LATERAL (
+    SELECT ia.changed_by
+    FROM invoice_audit ia
+    WHERE ia.invoice_id = i.id AND ia.operation = 'INSERT'
+    ORDER BY ia.changed_at ASC
+    LIMIT 1
+) created ON true
+LEFT JOIN LATERAL (
+    SELECT ia.changed_by
+    FROM invoice_audit ia
+    WHERE ia.invoice_id = i.id AND ia.operation = 'SUBMIT'
+    ORDER BY ia.changed_at DESC
+    LIMIT 1
+) submitted ON true
+WHERE
+    i.id = $1
+LIMIT 1;
+
+
+-- name: GetInvoiceApprovalSettings :one
+SELECT * FROM invoice_approval_settings
+WHERE organisation_id = $1
+LIMIT 1;
+
+
+-- name: UpsertInvoiceApprovalSettings :one
+INSERT INTO invoice_approval_settings (
+    organisation_id,
+    approval_threshold,
+    updated_by
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (organisation_id) DO UPDATE SET
+    approval_threshold = EXCLUDED.approval_threshold,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
@@ -0,0 +1,61 @@
+-- name: CreateInvoiceDelivery :one
+INSERT INTO invoice_deliveries (
+    invoice_id,
+    batch_id,
+    recipients,
+    is_reminder,
+    sent_by
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+
+-- name: GetInvoiceDelivery :one
+SELECT * FROM invoice_deliveries
+WHERE id = $1
+LIMIT 1;
+
+
+-- name: UpdateInvoiceDeliveryStatus :one
+UPDATE invoice_deliveries
+SET
+    status = $2,
+    message_id = $3,
+    error_message = $4,
+    sent_at = CASE WHEN $2 = 'sent' THEN CURRENT_TIMESTAMP ELSE sent_at END
+WHERE id = $1
+RETURNING *;
+
+
+-- name: ListInvoiceDeliveries :many
+SELECT * FROM invoice_deliveries
+WHERE invoice_id = $1
+ORDER BY created_at DESC;
+
+
+-- name: ListInvoiceDeliveriesByBatch :many
+SELECT
+    d.*,
+    i.invoice_number
+FROM
+    invoice_deliveries d
+JOIN
+    invoice i ON d.invoice_id = i.id
+WHERE
+    d.batch_id = sqlc.arg('batch_id')::UUID
+ORDER BY
+    d.id;
+
+
+-- name: ListApprovedInvoicesForDelivery :many
+-- Approved invoices of a run that were not emailed successfully yet.
+SELECT i.id
+FROM invoice i
+WHERE
+    i.status = 'approved'
+    AND i.issue_date BETWEEN sqlc.arg('start_date') AND sqlc.arg('end_date')
+    AND NOT EXISTS (
+        SELECT 1 FROM invoice_deliveries d
+        WHERE d.invoice_id = i.id AND d.status = 'sent' AND d.is_reminder = FALSE
+    )
+ORDER BY i.id;
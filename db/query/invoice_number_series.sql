@@ -0,0 +1,65 @@
+-- name: GetClientInvoiceOrganisationID :one
+-- The organisation that issues the invoices of a client, clients without an organisation
+-- fall back to the first organisation.
+SELECT o.id
+FROM organisations o
+WHERE o.id = COALESCE(
+    (SELECT COALESCE(cd.organization_id, l.organisation_id)
+     FROM client_details cd
+     LEFT JOIN location l ON cd.location_id = l.id
+     WHERE cd.id = sqlc.arg('client_id')),
+    (SELECT MIN(id) FROM organisations)
+)
+LIMIT 1;
+
+
+-- name: AllocateInvoiceNumber :one
+-- Takes the next number of the series of an organisation. The row stays locked until the
+-- surrounding transaction ends, so the invoice has to be created in the same transaction.
+INSERT INTO invoice_number_series (
+    organisation_id,
+    prefix,
+    sequence_year,
+    last_sequence
+) VALUES (
+    sqlc.arg('organisation_id'),
+    'INV' || sqlc.arg('organisation_id')::BIGINT,
+    sqlc.arg('sequence_year')::INT,
+    1
+)
+ON CONFLICT (organisation_id) DO UPDATE SET
+    last_sequence = CASE
+        WHEN invoice_number_series.reset_period = 'yearly'
+         AND invoice_number_series.sequence_year IS NOT NULL
+         AND EXCLUDED.sequence_year > invoice_number_series.sequence_year THEN 1
+        ELSE invoice_number_series.last_sequence + 1
+    END,
+    sequence_year = GREATEST(invoice_number_series.sequence_year, EXCLUDED.sequence_year)
+RETURNING *;
+
+
+-- name: GetInvoiceNumberSeries :one
+SELECT * FROM invoice_number_series
+WHERE organisation_id = $1
+LIMIT 1;
+
+
+-- name: UpsertInvoiceNumberSeries :one
+INSERT INTO invoice_number_series (
+    organisation_id,
+    prefix,
+    number_format,
+    sequence_padding,
+    reset_period,
+    updated_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (organisation_id) DO UPDATE SET
+    prefix = EXCLUDED.prefix,
+    number_format = EXCLUDED.number_format,
+    sequence_padding = EXCLUDED.sequence_padding,
+    reset_period = EXCLUDED.reset_period,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
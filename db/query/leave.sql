@@ -0,0 +1,256 @@
+-- name: ListLeaveTypes :many
+SELECT * FROM leave_types
+ORDER BY name;
+
+-- name: GetLeaveType :one
+SELECT * FROM leave_types
+WHERE id = $1;
+
+-- name: CreateLeaveType :one
+INSERT INTO leave_types (
+    code,
+    name,
+    paid,
+    deducts_balance
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: UpdateLeaveType :one
+UPDATE leave_types
+SET name = $2,
+    paid = $3,
+    deducts_balance = $4,
+    is_active = $5,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateLeaveRequest :one
+INSERT INTO leave_requests (
+    employee_id,
+    leave_type_id,
+    start_date,
+    end_date,
+    hours,
+    reason
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: GetLeaveRequest :one
+SELECT
+    lr.id,
+    lr.employee_id,
+    lr.leave_type_id,
+    lr.start_date,
+    lr.end_date,
+    lr.hours,
+    lr.reason,
+    lr.status,
+    lr.review_note,
+    lr.reviewed_by_employee_id,
+    lr.reviewed_at,
+    lr.created_at,
+    lt.code AS leave_type_code,
+    lt.name AS leave_type_name,
+    lt.deducts_balance,
+    e.first_name AS employee_first_name,
+    e.last_name AS employee_last_name
+FROM leave_requests lr
+JOIN leave_types lt ON lr.leave_type_id = lt.id
+JOIN employee_profile e ON lr.employee_id = e.id
+WHERE lr.id = $1;
+
+-- name: ListLeaveRequests :many
+-- Leave requests that overlap the period, of the employees of the location when one is given
+SELECT
+    lr.id,
+    lr.employee_id,
+    lr.leave_type_id,
+    lr.start_date,
+    lr.end_date,
+    lr.hours,
+    lr.reason,
+    lr.status,
+    lr.review_note,
+    lr.reviewed_by_employee_id,
+    lr.reviewed_at,
+    lr.created_at,
+    lt.code AS leave_type_code,
+    lt.name AS leave_type_name,
+    lt.deducts_balance,
+    e.first_name AS employee_first_name,
+    e.last_name AS employee_last_name
+FROM leave_requests lr
+JOIN leave_types lt ON lr.leave_type_id = lt.id
+JOIN employee_profile e ON lr.employee_id = e.id
+WHERE (sqlc.narg(employee_id)::bigint IS NULL OR lr.employee_id = sqlc.narg(employee_id))
+  AND (sqlc.narg(location_id)::bigint IS NULL OR e.location_id = sqlc.narg(location_id))
+  AND (sqlc.narg(status)::text IS NULL OR lr.status = sqlc.narg(status))
+  AND (sqlc.narg(start_date)::date IS NULL OR lr.end_date >= sqlc.narg(start_date))
+  AND (sqlc.narg(end_date)::date IS NULL OR lr.start_date <= sqlc.narg(end_date))
+ORDER BY lr.start_date, lr.id;
+
+-- name: ReviewLeaveRequest :one
+UPDATE leave_requests
+SET status = sqlc.arg(status),
+    review_note = sqlc.arg(review_note),
+    reviewed_by_employee_id = sqlc.arg(reviewed_by_employee_id),
+    reviewed_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg(id) AND status = 'pending'
+RETURNING *;
+
+-- name: CancelLeaveRequest :one
+UPDATE leave_requests
+SET status = 'cancelled',
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND status IN ('pending', 'approved')
+RETURNING *;
+
+-- name: GetLeaveHoursTaken :one
+-- Hours of leave that deducts from the balance, by the year the leave starts in
+SELECT
+    COALESCE(SUM(lr.hours) FILTER (WHERE lr.status = 'approved'), 0)::float8 AS approved_hours,
+    COALESCE(SUM(lr.hours) FILTER (WHERE lr.status = 'pending'), 0)::float8 AS pending_hours
+FROM leave_requests lr
+JOIN leave_types lt ON lr.leave_type_id = lt.id
+WHERE lr.employee_id = sqlc.arg(employee_id)
+  AND lt.deducts_balance
+  AND EXTRACT(YEAR FROM lr.start_date)::int = sqlc.arg(year)::int;
+
+-- name: CreateLeaveBalanceAdjustment :one
+INSERT INTO leave_balance_adjustments (
+    employee_id,
+    year,
+    hours,
+    reason,
+    created_by_employee_id
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: ListLeaveBalanceAdjustments :many
+SELECT * FROM leave_balance_adjustments
+WHERE employee_id = $1 AND year = $2
+ORDER BY created_at, id;
+
+-- name: ListEmployeesAbsences :many
+-- Pending and approved leave and the sick reports of the employees on the days from
+-- start_date up to and including end_date. end_date of an absence is its last day, for a
+-- sick employee who has not recovered the day before the expected return, or NULL.
+SELECT
+    lr.id,
+    lr.employee_id,
+    'leave'::text AS kind,
+    lr.status,
+    lt.name AS description,
+    lr.start_date,
+    lr.end_date
+FROM leave_requests lr
+JOIN leave_types lt ON lr.leave_type_id = lt.id
+WHERE lr.employee_id = ANY(sqlc.arg(employee_ids)::bigint[])
+  AND lr.status IN ('pending', 'approved')
+  AND lr.start_date <= sqlc.arg(end_date)
+  AND lr.end_date >= sqlc.arg(start_date)
+UNION ALL
+SELECT
+    sr.id,
+    sr.employee_id,
+    'sick'::text AS kind,
+    (CASE WHEN sr.recovered_on IS NULL THEN 'open' ELSE 'recovered' END)::text AS status,
+    'Sick'::text AS description,
+    sr.start_date,
+    (COALESCE(sr.recovered_on, sr.expected_return_date) - 1)::date AS end_date
+FROM sick_reports sr
+WHERE sr.employee_id = ANY(sqlc.arg(employee_ids)::bigint[])
+  AND sr.start_date <= sqlc.arg(end_date)
+  AND (COALESCE(sr.recovered_on, sr.expected_return_date) IS NULL
+       OR COALESCE(sr.recovered_on, sr.expected_return_date) > sqlc.arg(start_date))
+ORDER BY employee_id, start_date;
+
+-- name: CreateSickReport :one
+INSERT INTO sick_reports (
+    employee_id,
+    start_date,
+    expected_return_date,
+    notes,
+    reported_by_employee_id
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: GetSickReport :one
+SELECT
+    sr.id,
+    sr.employee_id,
+    sr.start_date,
+    sr.expected_return_date,
+    sr.recovered_on,
+    sr.notes,
+    sr.reported_by_employee_id,
+    sr.created_at,
+    e.first_name AS employee_first_name,
+    e.last_name AS employee_last_name
+FROM sick_reports sr
+JOIN employee_profile e ON sr.employee_id = e.id
+WHERE sr.id = $1;
+
+-- name: ListSickReports :many
+SELECT
+    sr.id,
+    sr.employee_id,
+    sr.start_date,
+    sr.expected_return_date,
+    sr.recovered_on,
+    sr.notes,
+    sr.reported_by_employee_id,
+    sr.created_at,
+    e.first_name AS employee_first_name,
+    e.last_name AS employee_last_name
+FROM sick_reports sr
+JOIN employee_profile e ON sr.employee_id = e.id
+WHERE (sqlc.narg(employee_id)::bigint IS NULL OR sr.employee_id = sqlc.narg(employee_id))
+  AND (sqlc.narg(open)::boolean IS NULL OR (sr.recovered_on IS NULL) = sqlc.narg(open))
+ORDER BY sr.start_date DESC, sr.id DESC;
+
+-- name: UpdateSickReport :one
+UPDATE sick_reports
+SET expected_return_date = $2,
+    notes = $3,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: RecoverSickReport :one
+UPDATE sick_reports
+SET recovered_on = $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND recovered_on IS NULL
+RETURNING *;
+
+-- name: ListSickReportMilestones :many
+SELECT * FROM sick_report_milestones
+WHERE sick_report_id = ANY(sqlc.arg(sick_report_ids)::bigint[])
+ORDER BY sick_report_id, completed_on;
+
+-- name: CompleteSickReportMilestone :one
+INSERT INTO sick_report_milestones (
+    sick_report_id,
+    milestone,
+    completed_on,
+    notes,
+    completed_by_employee_id
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (sick_report_id, milestone) DO UPDATE SET
+    completed_on = EXCLUDED.completed_on,
+    notes = EXCLUDED.notes,
+    completed_by_employee_id = EXCLUDED.completed_by_employee_id
+RETURNING *;
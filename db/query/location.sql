@@ -7,9 +7,10 @@ INSERT INTO organisations (
     phone_number,
     email,
     kvk_number,
-    btw_number
+    btw_number,
+    iban
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
 ) RETURNING *;
 
 
@@ -59,6 +60,7 @@ SET
     email = COALESCE(sqlc.narg('email'), email),
     kvk_number = COALESCE(sqlc.narg('kvk_number'), kvk_number),
     btw_number = COALESCE(sqlc.narg('btw_number'), btw_number),
+    iban = COALESCE(sqlc.narg('iban'), iban),
     updated_at = now()
 WHERE id = $1
 RETURNING *;
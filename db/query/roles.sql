@@ -119,4 +119,13 @@ SELECT EXISTS (
     JOIN permissions p ON p.id = up.permission_id
     WHERE up.user_id = $1
       AND p.name = $2
-) AS has_permission;
\ No newline at end of file
+) AS has_permission;
+-- name: ListPermissionHolders :many
+/* Returns the users that have a permission, with the location of their employee profile. */
+SELECT up.user_id,
+       e.location_id
+FROM user_permissions up
+JOIN permissions p ON p.id = up.permission_id
+LEFT JOIN employee_profile e ON e.user_id = up.user_id
+WHERE p.name = $1
+ORDER BY up.user_id;
@@ -0,0 +1,123 @@
+-- name: CreateRosterDraft :one
+INSERT INTO roster_drafts (
+    location_id,
+    year,
+    month,
+    requirements,
+    created_by_employee_id
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: CreateRosterDraftAssignments :exec
+-- employee ID 0 and an empty reason are stored as NULL
+INSERT INTO roster_draft_assignments (
+    draft_id,
+    location_shift_id,
+    employee_id,
+    start_datetime,
+    end_datetime,
+    unfilled_reason
+)
+SELECT
+    sqlc.arg(draft_id),
+    a.location_shift_id,
+    NULLIF(a.employee_id, 0),
+    a.start_datetime,
+    a.end_datetime,
+    NULLIF(a.unfilled_reason, '')
+FROM unnest(
+    sqlc.arg(location_shift_ids)::bigint[],
+    sqlc.arg(employee_ids)::bigint[],
+    sqlc.arg(start_datetimes)::timestamp[],
+    sqlc.arg(end_datetimes)::timestamp[],
+    sqlc.arg(unfilled_reasons)::text[]
+) AS a(location_shift_id, employee_id, start_datetime, end_datetime, unfilled_reason);
+
+-- name: GetRosterDraft :one
+SELECT * FROM roster_drafts
+WHERE id = $1;
+
+-- name: GetRosterDraftForUpdate :one
+SELECT * FROM roster_drafts
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListRosterDrafts :many
+SELECT * FROM roster_drafts
+WHERE location_id = $1
+ORDER BY year DESC, month DESC, created_at DESC;
+
+-- name: ListRosterDraftAssignments :many
+SELECT
+    a.id,
+    a.location_shift_id,
+    ls.shift_name,
+    a.employee_id,
+    e.first_name AS employee_first_name,
+    e.last_name AS employee_last_name,
+    a.start_datetime,
+    a.end_datetime,
+    a.unfilled_reason,
+    a.schedule_id
+FROM roster_draft_assignments a
+JOIN location_shift ls ON a.location_shift_id = ls.id
+LEFT JOIN employee_profile e ON a.employee_id = e.id
+WHERE a.draft_id = $1
+ORDER BY a.start_datetime, ls.shift_name, a.id;
+
+-- name: GetRosterDraftAssignment :one
+SELECT * FROM roster_draft_assignments
+WHERE id = $1 AND draft_id = $2;
+
+-- name: UpdateRosterDraftAssignmentEmployee :exec
+UPDATE roster_draft_assignments
+SET employee_id = sqlc.narg(employee_id),
+    unfilled_reason = CASE WHEN sqlc.narg(employee_id)::bigint IS NULL THEN 'unassigned by the planner' END
+WHERE id = sqlc.arg(id);
+
+-- name: CountOverlappingRosterDraftAssignments :one
+SELECT COUNT(*) FROM roster_draft_assignments
+WHERE draft_id = sqlc.arg(draft_id)
+  AND employee_id = sqlc.arg(employee_id)
+  AND id <> sqlc.arg(id)
+  AND start_datetime < sqlc.arg(end_datetime)
+  AND end_datetime > sqlc.arg(start_datetime);
+
+-- name: SetRosterDraftAssignmentSchedule :exec
+UPDATE roster_draft_assignments
+SET schedule_id = $2
+WHERE id = $1;
+
+-- name: PublishRosterDraft :exec
+UPDATE roster_drafts
+SET status = 'published',
+    published_by_employee_id = $2,
+    published_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: DeleteRosterDraft :execrows
+DELETE FROM roster_drafts
+WHERE id = $1 AND status = 'draft';
+
+-- name: ListRosterCandidates :many
+-- The employees a roster is generated for: the given employees, or else the employees of
+-- the location who are in service
+SELECT
+    e.id,
+    e.first_name,
+    e.last_name,
+    e.contract_hours,
+    e.contract_type,
+    COALESCE(array_agg(lower(c.name)) FILTER (WHERE c.id IS NOT NULL), '{}')::text[] AS certifications
+FROM employee_profile e
+LEFT JOIN certification c ON c.employee_id = e.id
+WHERE e.is_archived = FALSE
+  AND COALESCE(e.out_of_service, FALSE) = FALSE
+  AND CASE
+      WHEN cardinality(sqlc.arg(employee_ids)::bigint[]) > 0 THEN e.id = ANY(sqlc.arg(employee_ids)::bigint[])
+      ELSE e.location_id = sqlc.arg(location_id)
+  END
+GROUP BY e.id
+ORDER BY e.id;
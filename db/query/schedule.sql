@@ -28,7 +28,7 @@ WITH target_month AS (
 )
 SELECT 
   s.id AS shift_id,
-  s.employee_id,
+  e.id AS employee_id,
   s.location_id,
   s.start_datetime,
   s.end_datetime,
@@ -58,7 +58,7 @@ WITH target_day AS (
 shift_days AS (
   SELECT 
     s.id AS shift_id,
-    s.employee_id,
+    e.id AS employee_id,
     s.location_id,
     s.color,
     s.start_datetime,
@@ -92,7 +92,7 @@ SELECT s.*,
     ls.id AS location_shift_id
 FROM schedules s
 LEFT JOIN location_shift ls ON s.location_shift_id = ls.id
-JOIN employee_profile e ON s.employee_id = e.id
+LEFT JOIN employee_profile e ON s.employee_id = e.id
 JOIN location l ON s.location_id = l.id
 WHERE s.id = $1
 LIMIT 1;
@@ -136,4 +136,38 @@ JOIN location l ON s.location_id = l.id
 WHERE s.employee_id = @employee_id
     AND s.start_datetime >= @period_start
     AND s.start_datetime < @period_end
-ORDER BY s.start_datetime;
\ No newline at end of file
+ORDER BY s.start_datetime;
+
+-- name: ListEmployeesSchedulesInRange :many
+-- Open shifts have no employee and never match, employee_id is cast to keep it NOT NULL
+SELECT
+    s.id,
+    s.employee_id::bigint AS employee_id,
+    s.location_id,
+    s.start_datetime,
+    s.end_datetime,
+    l.name AS location_name,
+    ls.shift_name
+FROM schedules s
+JOIN location l ON s.location_id = l.id
+LEFT JOIN location_shift ls ON s.location_shift_id = ls.id
+WHERE s.employee_id = ANY(sqlc.arg(employee_ids)::bigint[])
+  AND s.start_datetime < sqlc.arg(end_date)
+  AND s.end_datetime > sqlc.arg(start_date)
+ORDER BY s.employee_id, s.start_datetime;
+
+-- name: ListEmployeesAppointmentsInRange :many
+SELECT
+    a.id,
+    p.employee_id,
+    a.start_time,
+    a.end_time,
+    a.location,
+    a.status
+FROM scheduled_appointments a
+JOIN appointment_participants p ON p.appointment_id = a.id
+WHERE p.employee_id = ANY(sqlc.arg(employee_ids)::bigint[])
+  AND a.status <> 'CANCELLED'
+  AND a.start_time < sqlc.arg(end_date)
+  AND a.end_time > sqlc.arg(start_date)
+ORDER BY p.employee_id, a.start_time;
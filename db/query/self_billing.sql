@@ -0,0 +1,115 @@
+-- name: ListSubcontractorShiftsForPeriod :many
+-- Shifts of ZZP employees and subcontractors that start within the period, grouped by
+-- the organisation of the location they worked at. Shifts with an approved time entry
+-- count with the registered times and breaks, other shifts with the planned times.
+SELECT
+    s.id,
+    e.id AS employee_id,
+    e.first_name,
+    e.last_name,
+    e.contract_rate,
+    l.organisation_id,
+    s.location_id,
+    l.name AS location_name,
+    COALESCE(t.actual_start, s.start_datetime)::TIMESTAMP AS start_datetime,
+    COALESCE(t.actual_end, s.end_datetime)::TIMESTAMP AS end_datetime,
+    COALESCE(t.break_minutes, 0)::INTEGER AS break_minutes
+FROM schedules s
+JOIN employee_profile e ON s.employee_id = e.id
+JOIN location l ON s.location_id = l.id
+LEFT JOIN time_entries t ON t.schedule_id = s.id AND t.status = 'approved'
+WHERE (e.contract_type = 'ZZP' OR e.is_subcontractor IS TRUE)
+  AND s.start_datetime >= sqlc.arg('period_start')
+  AND s.start_datetime < sqlc.arg('period_end')
+  AND (sqlc.narg('employee_id')::BIGINT IS NULL OR s.employee_id = sqlc.narg('employee_id'))
+ORDER BY s.employee_id, l.organisation_id, s.start_datetime;
+
+
+-- name: UpsertSelfBillingStatement :one
+-- Creates the statement of a subcontractor for a month or replaces it while it is still
+-- a concept or rejected. Approved and exported statements are left alone and no row is
+-- returned for them.
+INSERT INTO self_billing_statements (
+    statement_number,
+    employee_id,
+    organisation_id,
+    employee_name,
+    period_start,
+    period_end,
+    hours,
+    rate,
+    subtotal,
+    vat_rate,
+    vat_amount,
+    total_amount,
+    lines,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
+)
+ON CONFLICT (employee_id, organisation_id, period_start) DO UPDATE SET
+    employee_name = EXCLUDED.employee_name,
+    period_end = EXCLUDED.period_end,
+    hours = EXCLUDED.hours,
+    rate = EXCLUDED.rate,
+    subtotal = EXCLUDED.subtotal,
+    vat_rate = EXCLUDED.vat_rate,
+    vat_amount = EXCLUDED.vat_amount,
+    total_amount = EXCLUDED.total_amount,
+    lines = EXCLUDED.lines,
+    status = 'concept',
+    rejection_reason = NULL,
+    created_by = EXCLUDED.created_by,
+    updated_at = CURRENT_TIMESTAMP
+WHERE self_billing_statements.status IN ('concept', 'rejected')
+RETURNING *;
+
+
+-- name: GetSelfBillingStatement :one
+SELECT * FROM self_billing_statements
+WHERE id = $1
+LIMIT 1;
+
+
+-- name: ListSelfBillingStatements :many
+SELECT * FROM self_billing_statements
+WHERE period_start = sqlc.arg('period_start')
+  AND (sqlc.narg('status')::VARCHAR IS NULL OR status = sqlc.narg('status'))
+  AND (sqlc.narg('employee_id')::BIGINT IS NULL OR employee_id = sqlc.narg('employee_id'))
+ORDER BY organisation_id, employee_name, id;
+
+
+-- name: ApproveSelfBillingStatement :one
+UPDATE self_billing_statements
+SET status = 'approved',
+    approved_by = $2,
+    approved_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND status = 'concept'
+RETURNING *;
+
+
+-- name: RejectSelfBillingStatement :one
+UPDATE self_billing_statements
+SET status = 'rejected',
+    rejection_reason = $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND status = 'concept'
+RETURNING *;
+
+
+-- name: ListSelfBillingStatementsForExport :many
+-- Approved statements of a month, exported statements are only included on request
+SELECT * FROM self_billing_statements
+WHERE period_start = sqlc.arg('period_start')
+  AND (status = 'approved' OR (sqlc.arg('include_exported')::BOOLEAN AND status = 'exported'))
+ORDER BY organisation_id, statement_number;
+
+
+-- name: MarkSelfBillingStatementsExported :exec
+UPDATE self_billing_statements
+SET status = 'exported',
+    exported_by = sqlc.arg('exported_by'),
+    exported_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ANY(sqlc.arg('ids')::BIGINT[]);
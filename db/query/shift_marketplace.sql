@@ -0,0 +1,201 @@
+-- name: ListOpenShifts :many
+-- Open shifts that start after the given time, at the given locations or at any location
+SELECT
+    s.id,
+    s.location_id,
+    l.name AS location_name,
+    s.location_shift_id,
+    ls.shift_name,
+    s.color,
+    s.start_datetime,
+    s.end_datetime,
+    s.created_at
+FROM schedules s
+JOIN location l ON s.location_id = l.id
+LEFT JOIN location_shift ls ON s.location_shift_id = ls.id
+WHERE s.employee_id IS NULL
+  AND s.start_datetime > sqlc.arg(from_datetime)
+  AND (sqlc.narg(location_ids)::bigint[] IS NULL OR s.location_id = ANY(sqlc.narg(location_ids)::bigint[]))
+ORDER BY s.start_datetime, s.id;
+
+-- name: AssignSchedule :one
+-- Moves a schedule to another employee, or opens it when employee_id is null. Nothing is
+-- returned when the schedule no longer belongs to current_employee_id, null for an open
+-- shift, so that a shift is never claimed or swapped twice.
+UPDATE schedules
+SET employee_id = sqlc.narg(employee_id),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg(id)
+  AND employee_id IS NOT DISTINCT FROM sqlc.narg(current_employee_id)::bigint
+RETURNING *;
+
+-- name: GetShiftMarketplaceEmployee :one
+-- What decides which shifts an employee can take over: their locations and certifications
+SELECT
+    e.id,
+    e.user_id,
+    e.first_name,
+    e.last_name,
+    e.location_id,
+    COALESCE(p.preferred_location_ids, '{}')::bigint[] AS preferred_location_ids,
+    COALESCE(
+        (SELECT array_agg(lower(c.name)) FROM certification c WHERE c.employee_id = e.id),
+        '{}'
+    )::text[] AS certifications
+FROM employee_profile e
+LEFT JOIN employee_shift_preferences p ON p.employee_id = e.id
+WHERE e.id = $1 AND e.is_archived = FALSE;
+
+-- name: ListLocationEmployeeUsers :many
+-- The users of the employees that work at a location or prefer to
+SELECT e.user_id
+FROM employee_profile e
+LEFT JOIN employee_shift_preferences p ON p.employee_id = e.id
+WHERE e.is_archived = FALSE
+  AND (e.location_id = sqlc.arg(location_id)::bigint OR sqlc.arg(location_id)::bigint = ANY(p.preferred_location_ids))
+ORDER BY e.user_id;
+
+-- name: CreateShiftSwapRequest :one
+INSERT INTO shift_swap_requests (
+    schedule_id,
+    requester_employee_id,
+    target_employee_id,
+    target_schedule_id,
+    message
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: GetShiftSwapRequest :one
+SELECT
+    sr.id,
+    sr.schedule_id,
+    sr.requester_employee_id,
+    sr.target_employee_id,
+    sr.target_schedule_id,
+    sr.message,
+    sr.status,
+    sr.responded_at,
+    sr.review_note,
+    sr.reviewed_by_employee_id,
+    sr.reviewed_at,
+    sr.created_at,
+    r.user_id AS requester_user_id,
+    r.first_name AS requester_first_name,
+    r.last_name AS requester_last_name,
+    t.user_id AS target_user_id,
+    t.first_name AS target_first_name,
+    t.last_name AS target_last_name,
+    s.location_id,
+    l.name AS location_name,
+    ls.shift_name,
+    s.start_datetime,
+    s.end_datetime,
+    ts.location_id AS target_location_id,
+    tl.name AS target_location_name,
+    tls.shift_name AS target_shift_name,
+    ts.start_datetime AS target_start_datetime,
+    ts.end_datetime AS target_end_datetime
+FROM shift_swap_requests sr
+JOIN employee_profile r ON sr.requester_employee_id = r.id
+JOIN employee_profile t ON sr.target_employee_id = t.id
+JOIN schedules s ON sr.schedule_id = s.id
+JOIN location l ON s.location_id = l.id
+LEFT JOIN location_shift ls ON s.location_shift_id = ls.id
+LEFT JOIN schedules ts ON sr.target_schedule_id = ts.id
+LEFT JOIN location tl ON ts.location_id = tl.id
+LEFT JOIN location_shift tls ON ts.location_shift_id = tls.id
+WHERE sr.id = $1
+LIMIT 1;
+
+-- name: ListShiftSwapRequests :many
+-- Swap requests of an employee, as requester or target, or of the shifts of a location
+SELECT
+    sr.id,
+    sr.schedule_id,
+    sr.requester_employee_id,
+    sr.target_employee_id,
+    sr.target_schedule_id,
+    sr.message,
+    sr.status,
+    sr.responded_at,
+    sr.review_note,
+    sr.reviewed_by_employee_id,
+    sr.reviewed_at,
+    sr.created_at,
+    r.user_id AS requester_user_id,
+    r.first_name AS requester_first_name,
+    r.last_name AS requester_last_name,
+    t.user_id AS target_user_id,
+    t.first_name AS target_first_name,
+    t.last_name AS target_last_name,
+    s.location_id,
+    l.name AS location_name,
+    ls.shift_name,
+    s.start_datetime,
+    s.end_datetime,
+    ts.location_id AS target_location_id,
+    tl.name AS target_location_name,
+    tls.shift_name AS target_shift_name,
+    ts.start_datetime AS target_start_datetime,
+    ts.end_datetime AS target_end_datetime
+FROM shift_swap_requests sr
+JOIN employee_profile r ON sr.requester_employee_id = r.id
+JOIN employee_profile t ON sr.target_employee_id = t.id
+JOIN schedules s ON sr.schedule_id = s.id
+JOIN location l ON s.location_id = l.id
+LEFT JOIN location_shift ls ON s.location_shift_id = ls.id
+LEFT JOIN schedules ts ON sr.target_schedule_id = ts.id
+LEFT JOIN location tl ON ts.location_id = tl.id
+LEFT JOIN location_shift tls ON ts.location_shift_id = tls.id
+WHERE (sqlc.narg(employee_id)::bigint IS NULL OR sr.requester_employee_id = sqlc.narg(employee_id) OR sr.target_employee_id = sqlc.narg(employee_id))
+  AND (sqlc.narg(location_id)::bigint IS NULL OR s.location_id = sqlc.narg(location_id) OR ts.location_id = sqlc.narg(location_id))
+  AND (sqlc.narg(status)::text IS NULL OR sr.status = sqlc.narg(status))
+ORDER BY sr.created_at DESC, sr.id DESC;
+
+-- name: RespondShiftSwapRequest :one
+-- The target accepts or declines a pending swap request
+UPDATE shift_swap_requests
+SET status = sqlc.arg(status),
+    responded_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg(id) AND status = 'pending'
+RETURNING *;
+
+-- name: ReviewShiftSwapRequest :one
+-- A planner approves or rejects a swap request the target accepted
+UPDATE shift_swap_requests
+SET status = sqlc.arg(status),
+    review_note = sqlc.arg(review_note),
+    reviewed_by_employee_id = sqlc.arg(reviewed_by_employee_id),
+    reviewed_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg(id) AND status = 'accepted'
+RETURNING *;
+
+-- name: CancelShiftSwapRequest :one
+UPDATE shift_swap_requests
+SET status = 'cancelled',
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND status IN ('pending', 'accepted')
+RETURNING *;
+
+-- name: CancelShiftSwapRequestsForSchedules :many
+-- Cancels the open swap requests of shifts that changed hands, and returns who was involved
+WITH cancelled AS (
+    UPDATE shift_swap_requests
+    SET status = 'cancelled',
+        updated_at = CURRENT_TIMESTAMP
+    WHERE status IN ('pending', 'accepted')
+      AND (schedule_id = ANY(sqlc.arg(schedule_ids)::uuid[]) OR target_schedule_id = ANY(sqlc.arg(schedule_ids)::uuid[]))
+    RETURNING id, schedule_id, requester_employee_id, target_employee_id
+)
+SELECT
+    c.id,
+    c.schedule_id,
+    r.user_id AS requester_user_id,
+    t.user_id AS target_user_id
+FROM cancelled c
+JOIN employee_profile r ON c.requester_employee_id = r.id
+JOIN employee_profile t ON c.target_employee_id = t.id;
@@ -0,0 +1,64 @@
+-- name: CreateStaffingNorm :one
+INSERT INTO staffing_norms (
+    location_id,
+    location_shift_id,
+    weekday,
+    min_staff,
+    certifications
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: ListStaffingNorms :many
+SELECT
+    n.*,
+    ls.shift_name
+FROM staffing_norms n
+JOIN location_shift ls ON n.location_shift_id = ls.id
+WHERE n.location_id = $1
+ORDER BY ls.start_time, n.location_shift_id, n.weekday NULLS FIRST;
+
+-- name: GetStaffingNorm :one
+SELECT * FROM staffing_norms
+WHERE id = $1 AND location_id = $2;
+
+-- name: UpdateStaffingNorm :one
+UPDATE staffing_norms
+SET weekday = $3,
+    min_staff = $4,
+    certifications = $5,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND location_id = $2
+RETURNING *;
+
+-- name: DeleteStaffingNorm :execrows
+DELETE FROM staffing_norms
+WHERE id = $1 AND location_id = $2;
+
+-- name: ListStaffingNormLocations :many
+SELECT DISTINCT l.id, l.name
+FROM staffing_norms n
+JOIN location l ON n.location_id = l.id
+ORDER BY l.id;
+
+-- name: ListCoverageSchedules :many
+-- The schedules of a location in a range with the certifications of their employees
+SELECT
+    s.id,
+    e.id AS employee_id,
+    s.location_shift_id,
+    s.start_datetime,
+    s.end_datetime,
+    e.first_name AS employee_first_name,
+    e.last_name AS employee_last_name,
+    COALESCE(
+        (SELECT array_agg(lower(c.name)) FROM certification c WHERE c.employee_id = s.employee_id),
+        '{}'
+    )::text[] AS certifications
+FROM schedules s
+JOIN employee_profile e ON s.employee_id = e.id
+WHERE s.location_id = sqlc.arg(location_id)
+  AND s.start_datetime < sqlc.arg(end_date)
+  AND s.end_datetime > sqlc.arg(start_date)
+ORDER BY s.start_datetime;
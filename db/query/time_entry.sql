@@ -0,0 +1,156 @@
+-- name: GetLocationClockSettings :one
+SELECT * FROM location_clock_settings
+WHERE location_id = $1;
+
+-- name: UpsertLocationClockIPRanges :one
+INSERT INTO location_clock_settings (location_id, ip_ranges)
+VALUES ($1, $2)
+ON CONFLICT (location_id) DO UPDATE SET
+    ip_ranges = EXCLUDED.ip_ranges,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: UpsertLocationClockQrToken :one
+INSERT INTO location_clock_settings (location_id, qr_token)
+VALUES ($1, $2)
+ON CONFLICT (location_id) DO UPDATE SET
+    qr_token = EXCLUDED.qr_token,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: CreateTimeEntry :one
+INSERT INTO time_entries (
+    schedule_id,
+    employee_id,
+    clock_in_at,
+    clock_in_method,
+    clock_in_ip
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: GetTimeEntry :one
+SELECT * FROM time_entries
+WHERE id = $1;
+
+-- name: GetTimeEntryBySchedule :one
+SELECT * FROM time_entries
+WHERE schedule_id = $1;
+
+-- name: ClockOutTimeEntry :one
+UPDATE time_entries
+SET clock_out_at = sqlc.arg(clock_out_at),
+    clock_out_method = sqlc.arg(clock_out_method),
+    clock_out_ip = sqlc.arg(clock_out_ip),
+    actual_start = sqlc.arg(actual_start),
+    actual_end = sqlc.arg(actual_end),
+    break_minutes = sqlc.arg(break_minutes),
+    status = sqlc.arg(status),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg(id) AND status = 'open'
+RETURNING *;
+
+-- name: ReviewTimeEntry :one
+-- Approves or rejects a deviation, an approval may correct the times that count
+UPDATE time_entries
+SET status = sqlc.arg(status),
+    actual_start = sqlc.arg(actual_start),
+    actual_end = sqlc.arg(actual_end),
+    break_minutes = sqlc.arg(break_minutes),
+    review_note = sqlc.narg(review_note),
+    reviewed_by_employee_id = sqlc.arg(reviewed_by_employee_id),
+    reviewed_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg(id) AND status = 'pending'
+RETURNING *;
+
+-- name: StartTimeEntryBreak :one
+INSERT INTO time_entry_breaks (time_entry_id, start_at)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: EndTimeEntryBreak :one
+UPDATE time_entry_breaks
+SET end_at = $2
+WHERE time_entry_id = $1 AND end_at IS NULL
+RETURNING *;
+
+-- name: ListTimeEntryBreaks :many
+SELECT * FROM time_entry_breaks
+WHERE time_entry_id = $1
+ORDER BY start_at;
+
+-- name: ListTimeEntries :many
+-- Time entries with the planned times of their schedules, by the start of the schedule
+SELECT
+    t.id,
+    t.schedule_id,
+    t.employee_id,
+    t.clock_in_at,
+    t.clock_in_method,
+    t.clock_out_at,
+    t.clock_out_method,
+    t.actual_start,
+    t.actual_end,
+    t.break_minutes,
+    t.status,
+    t.review_note,
+    t.reviewed_by_employee_id,
+    t.reviewed_at,
+    s.location_id,
+    s.start_datetime AS planned_start,
+    s.end_datetime AS planned_end,
+    e.first_name AS employee_first_name,
+    e.last_name AS employee_last_name,
+    l.name AS location_name
+FROM time_entries t
+JOIN schedules s ON t.schedule_id = s.id
+JOIN employee_profile e ON t.employee_id = e.id
+JOIN location l ON s.location_id = l.id
+WHERE s.start_datetime >= sqlc.arg(start_date)
+  AND s.start_datetime < sqlc.arg(end_date)
+  AND (sqlc.narg('location_id')::BIGINT IS NULL OR s.location_id = sqlc.narg('location_id'))
+  AND (sqlc.narg('employee_id')::BIGINT IS NULL OR t.employee_id = sqlc.narg('employee_id'))
+  AND (sqlc.narg('status')::VARCHAR IS NULL OR t.status = sqlc.narg('status'))
+ORDER BY s.start_datetime, t.id;
+
+-- name: ListEmployeeTimeEntriesInRange :many
+SELECT
+    t.schedule_id,
+    t.clock_in_at,
+    t.clock_out_at,
+    t.actual_start,
+    t.actual_end,
+    t.break_minutes,
+    t.status
+FROM time_entries t
+JOIN schedules s ON t.schedule_id = s.id
+WHERE t.employee_id = sqlc.arg(employee_id)
+  AND s.start_datetime >= sqlc.arg(period_start)
+  AND s.start_datetime < sqlc.arg(period_end);
+
+-- name: ListPayrollShifts :many
+-- Shifts of employees on the payroll that start within the period, with their time
+-- entries. ZZP employees and subcontractors are paid through self-billing.
+SELECT
+    s.id,
+    e.id AS employee_id,
+    e.first_name,
+    e.last_name,
+    e.contract_type,
+    e.contract_hours,
+    s.start_datetime,
+    s.end_datetime,
+    t.status AS time_entry_status,
+    t.actual_start,
+    t.actual_end,
+    t.break_minutes
+FROM schedules s
+JOIN employee_profile e ON s.employee_id = e.id
+LEFT JOIN time_entries t ON t.schedule_id = s.id
+WHERE (e.contract_type IS NULL OR e.contract_type <> 'ZZP')
+  AND e.is_subcontractor IS NOT TRUE
+  AND s.start_datetime >= sqlc.arg(period_start)
+  AND s.start_datetime < sqlc.arg(period_end)
+ORDER BY e.last_name, e.first_name, s.employee_id, s.start_datetime;
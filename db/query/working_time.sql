@@ -0,0 +1,38 @@
+-- name: ListWorkingTimeRules :many
+SELECT * FROM working_time_rules
+ORDER BY contract_type;
+
+-- name: GetWorkingTimeRules :one
+SELECT * FROM working_time_rules
+WHERE contract_type = $1;
+
+-- name: UpdateWorkingTimeRules :one
+UPDATE working_time_rules
+SET exempt = $2,
+    max_shift_minutes = $3,
+    max_week_minutes = $4,
+    max_average_week_minutes = $5,
+    min_daily_rest_minutes = $6,
+    min_weekly_rest_minutes = $7,
+    max_night_shift_minutes = $8,
+    max_consecutive_night_shifts = $9,
+    max_night_shifts_16_weeks = $10,
+    min_rest_after_night_series_minutes = $11,
+    updated_at = CURRENT_TIMESTAMP
+WHERE contract_type = $1
+RETURNING *;
+
+-- name: ListComplianceEmployees :many
+-- Employees with shifts that start within the period, at the location when one is given
+SELECT DISTINCT
+    e.id,
+    e.first_name,
+    e.last_name,
+    COALESCE(e.contract_type, 'none')::text AS contract_type
+FROM schedules s
+JOIN employee_profile e ON s.employee_id = e.id
+WHERE s.start_datetime >= sqlc.arg(start_date)
+  AND s.start_datetime < sqlc.arg(end_date)
+  AND (sqlc.narg(location_id)::bigint IS NULL OR s.location_id = sqlc.narg(location_id))
+  AND (sqlc.narg(employee_id)::bigint IS NULL OR s.employee_id = sqlc.narg(employee_id))
+ORDER BY e.id;
@@ -78,7 +78,7 @@ INSERT INTO scheduled_appointments (
     description       
 ) VALUES (
     $1, $2, $3, $4, $5, $6
-) RETURNING id, appointment_templates_id, creator_employee_id, start_time, end_time, location, description, status, color, is_confirmed, confirmed_by_employee_id, confirmed_at, created_at, updated_at
+) RETURNING id, appointment_templates_id, creator_employee_id, start_time, end_time, location, description, status, color, is_confirmed, confirmed_by_employee_id, confirmed_at, created_at, updated_at, recurrence_id, is_exception
 `
 
 type CreateAppointmentParams struct {
@@ -115,6 +115,8 @@ func (q *Queries) CreateAppointment(ctx context.Context, arg CreateAppointmentPa
 		&i.ConfirmedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.RecurrenceID,
+		&i.IsException,
 	)
 	return i, err
 }
@@ -129,22 +131,32 @@ INSERT INTO appointment_templates (
     color,        
     recurrence_type,
     recurrence_interval,
-    recurrence_end_date
+    recurrence_end_date,
+    rrule,
+    exdates,
+    rdates,
+    participant_employee_ids,
+    client_ids
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9
-) RETURNING id, creator_employee_id, start_time, end_time, location, description, color, recurrence_type, recurrence_interval, recurrence_end_date, created_at, updated_at
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
+) RETURNING id, creator_employee_id, start_time, end_time, location, description, color, recurrence_type, recurrence_interval, recurrence_end_date, created_at, updated_at, rrule, exdates, rdates, participant_employee_ids, client_ids, materialized_until
 `
 
 type CreateAppointmentTemplateParams struct {
-	CreatorEmployeeID  int64            `json:"creator_employee_id"`
-	StartTime          pgtype.Timestamp `json:"start_time"`
-	EndTime            pgtype.Timestamp `json:"end_time"`
-	Location           *string          `json:"location"`
-	Description        *string          `json:"description"`
-	Color              *string          `json:"color"`
-	RecurrenceType     *string          `json:"recurrence_type"`
-	RecurrenceInterval *int32           `json:"recurrence_interval"`
-	RecurrenceEndDate  pgtype.Date      `json:"recurrence_end_date"`
+	CreatorEmployeeID      int64              `json:"creator_employee_id"`
+	StartTime              pgtype.Timestamp   `json:"start_time"`
+	EndTime                pgtype.Timestamp   `json:"end_time"`
+	Location               *string            `json:"location"`
+	Description            *string            `json:"description"`
+	Color                  *string            `json:"color"`
+	RecurrenceType         *string            `json:"recurrence_type"`
+	RecurrenceInterval     *int32             `json:"recurrence_interval"`
+	RecurrenceEndDate      pgtype.Date        `json:"recurrence_end_date"`
+	Rrule                  *string            `json:"rrule"`
+	Exdates                []pgtype.Timestamp `json:"exdates"`
+	Rdates                 []pgtype.Timestamp `json:"rdates"`
+	ParticipantEmployeeIds []int64            `json:"participant_employee_ids"`
+	ClientIds              []int64            `json:"client_ids"`
 }
 
 func (q *Queries) CreateAppointmentTemplate(ctx context.Context, arg CreateAppointmentTemplateParams) (AppointmentTemplate, error) {
@@ -158,6 +170,11 @@ func (q *Queries) CreateAppointmentTemplate(ctx context.Context, arg CreateAppoi
 		arg.RecurrenceType,
 		arg.RecurrenceInterval,
 		arg.RecurrenceEndDate,
+		arg.Rrule,
+		arg.Exdates,
+		arg.Rdates,
+		arg.ParticipantEmployeeIds,
+		arg.ClientIds,
 	)
 	var i AppointmentTemplate
 	err := row.Scan(
@@ -173,6 +190,12 @@ func (q *Queries) CreateAppointmentTemplate(ctx context.Context, arg CreateAppoi
 		&i.RecurrenceEndDate,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Rrule,
+		&i.Exdates,
+		&i.Rdates,
+		&i.ParticipantEmployeeIds,
+		&i.ClientIds,
+		&i.MaterializedUntil,
 	)
 	return i, err
 }
@@ -312,7 +335,7 @@ const getAppointmentTemplate = `-- name: GetAppointmentTemplate :one
 
 
 
-SELECT id, creator_employee_id, start_time, end_time, location, description, color, recurrence_type, recurrence_interval, recurrence_end_date, created_at, updated_at FROM appointment_templates
+SELECT id, creator_employee_id, start_time, end_time, location, description, color, recurrence_type, recurrence_interval, recurrence_end_date, created_at, updated_at, rrule, exdates, rdates, participant_employee_ids, client_ids, materialized_until FROM appointment_templates
 WHERE id = $1
 LIMIT 1
 `
@@ -334,6 +357,12 @@ func (q *Queries) GetAppointmentTemplate(ctx context.Context, id uuid.UUID) (App
 		&i.RecurrenceEndDate,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Rrule,
+		&i.Exdates,
+		&i.Rdates,
+		&i.ParticipantEmployeeIds,
+		&i.ClientIds,
+		&i.MaterializedUntil,
 	)
 	return i, err
 }
@@ -687,9 +716,11 @@ SET
     location = COALESCE ($4, location),
     description = COALESCE ($5, description),
     color = COALESCE ($6, color),
+    -- an edited occurrence of a series overrides the series
+    is_exception = appointment_templates_id IS NOT NULL,
     updated_at = NOW()
 WHERE id = $1
-RETURNING id, appointment_templates_id, creator_employee_id, start_time, end_time, location, description, status, color, is_confirmed, confirmed_by_employee_id, confirmed_at, created_at, updated_at
+RETURNING id, appointment_templates_id, creator_employee_id, start_time, end_time, location, description, status, color, is_confirmed, confirmed_by_employee_id, confirmed_at, created_at, updated_at, recurrence_id, is_exception
 `
 
 type UpdateAppointmentParams struct {
@@ -726,6 +757,8 @@ func (q *Queries) UpdateAppointment(ctx context.Context, arg UpdateAppointmentPa
 		&i.ConfirmedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.RecurrenceID,
+		&i.IsException,
 	)
 	return i, err
 }
@@ -30,31 +30,33 @@ INSERT INTO contract (
     sender_id,
     attachment_ids,
     financing_act,
-    financing_option
+    financing_option,
+    indication_number
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18
 )
-RETURNING id, type_id, status, approved_at, start_date, end_date, reminder_period, vat, price, price_time_unit, hours, hours_type, care_name, care_type, client_id, sender_id, attachment_ids, financing_act, financing_option, departure_reason, departure_report, updated_at, created_at
+RETURNING id, type_id, status, approved_at, start_date, end_date, reminder_period, vat, price, price_time_unit, hours, hours_type, care_name, care_type, client_id, sender_id, attachment_ids, financing_act, financing_option, departure_reason, departure_report, updated_at, created_at, indication_number
 `
 
 type CreateContractParams struct {
-	TypeID          *int64             `json:"type_id"`
-	Status          string             `json:"status"`
-	StartDate       pgtype.Timestamptz `json:"start_date"`
-	EndDate         pgtype.Timestamptz `json:"end_date"`
-	ReminderPeriod  int32              `json:"reminder_period"`
-	Vat             *int32             `json:"vat"`
-	Price           float64            `json:"price"`
-	PriceTimeUnit   string             `json:"price_time_unit"`
-	Hours           *float64           `json:"hours"`
-	HoursType       *string            `json:"hours_type"`
-	CareName        string             `json:"care_name"`
-	CareType        string             `json:"care_type"`
-	ClientID        int64              `json:"client_id"`
-	SenderID        *int64             `json:"sender_id"`
-	AttachmentIds   []uuid.UUID        `json:"attachment_ids"`
-	FinancingAct    string             `json:"financing_act"`
-	FinancingOption string             `json:"financing_option"`
+	TypeID           *int64             `json:"type_id"`
+	Status           string             `json:"status"`
+	StartDate        pgtype.Timestamptz `json:"start_date"`
+	EndDate          pgtype.Timestamptz `json:"end_date"`
+	ReminderPeriod   int32              `json:"reminder_period"`
+	Vat              *int32             `json:"vat"`
+	Price            float64            `json:"price"`
+	PriceTimeUnit    string             `json:"price_time_unit"`
+	Hours            *float64           `json:"hours"`
+	HoursType        *string            `json:"hours_type"`
+	CareName         string             `json:"care_name"`
+	CareType         string             `json:"care_type"`
+	ClientID         int64              `json:"client_id"`
+	SenderID         *int64             `json:"sender_id"`
+	AttachmentIds    []uuid.UUID        `json:"attachment_ids"`
+	FinancingAct     string             `json:"financing_act"`
+	FinancingOption  string             `json:"financing_option"`
+	IndicationNumber *string            `json:"indication_number"`
 }
 
 func (q *Queries) CreateContract(ctx context.Context, arg CreateContractParams) (Contract, error) {
@@ -76,6 +78,7 @@ func (q *Queries) CreateContract(ctx context.Context, arg CreateContractParams)
 		arg.AttachmentIds,
 		arg.FinancingAct,
 		arg.FinancingOption,
+		arg.IndicationNumber,
 	)
 	var i Contract
 	err := row.Scan(
@@ -102,6 +105,7 @@ func (q *Queries) CreateContract(ctx context.Context, arg CreateContractParams)
 		&i.DepartureReport,
 		&i.UpdatedAt,
 		&i.CreatedAt,
+		&i.IndicationNumber,
 	)
 	return i, err
 }
@@ -165,46 +169,15 @@ func (q *Queries) DeleteContractType(ctx context.Context, id int64) error {
 }
 
 const getBillablePeriodsForContract = `-- name: GetBillablePeriodsForContract :many
-WITH raw_status_changes AS (
-  SELECT
-    new_values->>'status' AS status,
-    changed_at AS effective_date
-  FROM contract_audit
-  WHERE contract_id = $3
-    AND new_values->>'status' IS NOT NULL
-  
-  UNION ALL
-  
-  SELECT
-    status,
-    updated_at
-  FROM contract
-  WHERE id = $3
-),
-
-status_history AS (
-  SELECT
-    status,
-    MIN(effective_date) AS effective_date
-  FROM raw_status_changes
-  GROUP BY status, EXTRACT(EPOCH FROM effective_date)::INTEGER
-  ORDER BY MIN(effective_date)
-),
-
-approved_periods AS (
-  SELECT
-    effective_date AS period_start,
-    LEAD(effective_date, 1) OVER (ORDER BY effective_date) AS period_end
-  FROM status_history
-  WHERE status = 'approved'
-)
-
 SELECT
-  GREATEST(period_start, $1)::TIMESTAMPTZ AS billable_start,
-  LEAST(COALESCE(period_end, $2), $2)::TIMESTAMPTZ AS billable_end
-FROM approved_periods
-WHERE period_start < $2
-  AND COALESCE(period_end, 'infinity'::TIMESTAMPTZ) > $1
+  GREATEST(effective_from, $1)::TIMESTAMPTZ AS billable_start,
+  LEAST(COALESCE(effective_to, $2), $2)::TIMESTAMPTZ AS billable_end
+FROM contract_status_periods
+WHERE contract_id = $3
+  AND status = 'approved'
+  AND effective_from < $2
+  AND COALESCE(effective_to, 'infinity'::TIMESTAMPTZ) > $1
+ORDER BY effective_from;
 `
 
 type GetBillablePeriodsForContractParams struct {
@@ -218,7 +191,8 @@ type GetBillablePeriodsForContractRow struct {
 	BillableEnd   pgtype.Timestamptz `json:"billable_end"`
 }
 
-// Deduplicate identical status within 1 second windows
+// Approved periods of the status timeline clipped to the invoice period. Suspensions
+// split an approved period, so they are left out.
 func (q *Queries) GetBillablePeriodsForContract(ctx context.Context, arg GetBillablePeriodsForContractParams) ([]GetBillablePeriodsForContractRow, error) {
 	rows, err := q.db.Query(ctx, getBillablePeriodsForContract, arg.InvoiceStartDate, arg.InvoiceEndDate, arg.ContractID)
 	if err != nil {
@@ -240,7 +214,7 @@ func (q *Queries) GetBillablePeriodsForContract(ctx context.Context, arg GetBill
 }
 
 const getClientContract = `-- name: GetClientContract :one
-SELECT c.id, c.type_id, c.status, c.approved_at, c.start_date, c.end_date, c.reminder_period, c.vat, c.price, c.price_time_unit, c.hours, c.hours_type, c.care_name, c.care_type, c.client_id, c.sender_id, c.attachment_ids, c.financing_act, c.financing_option, c.departure_reason, c.departure_report, c.updated_at, c.created_at,
+SELECT c.id, c.type_id, c.status, c.approved_at, c.start_date, c.end_date, c.reminder_period, c.vat, c.price, c.price_time_unit, c.hours, c.hours_type, c.care_name, c.care_type, c.client_id, c.sender_id, c.attachment_ids, c.financing_act, c.financing_option, c.departure_reason, c.departure_report, c.updated_at, c.created_at, c.indication_number,
         ct.name AS contract_type_name,
         cd.first_name AS client_first_name,
         cd.last_name AS client_last_name,
@@ -277,6 +251,7 @@ type GetClientContractRow struct {
 	DepartureReport  *string            `json:"departure_report"`
 	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
 	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	IndicationNumber *string            `json:"indication_number"`
 	ContractTypeName string             `json:"contract_type_name"`
 	ClientFirstName  string             `json:"client_first_name"`
 	ClientLastName   string             `json:"client_last_name"`
@@ -310,6 +285,7 @@ func (q *Queries) GetClientContract(ctx context.Context, id int64) (GetClientCon
 		&i.DepartureReport,
 		&i.UpdatedAt,
 		&i.CreatedAt,
+		&i.IndicationNumber,
 		&i.ContractTypeName,
 		&i.ClientFirstName,
 		&i.ClientLastName,
@@ -373,7 +349,7 @@ func (q *Queries) GetContractAudit(ctx context.Context, contractID int64) ([]Get
 }
 
 const getSenderContracts = `-- name: GetSenderContracts :many
-SELECT id, type_id, status, approved_at, start_date, end_date, reminder_period, vat, price, price_time_unit, hours, hours_type, care_name, care_type, client_id, sender_id, attachment_ids, financing_act, financing_option, departure_reason, departure_report, updated_at, created_at FROM contract
+SELECT id, type_id, status, approved_at, start_date, end_date, reminder_period, vat, price, price_time_unit, hours, hours_type, care_name, care_type, client_id, sender_id, attachment_ids, financing_act, financing_option, departure_reason, departure_report, updated_at, created_at, indication_number FROM contract
 WHERE sender_id = $1
 `
 
@@ -410,6 +386,7 @@ func (q *Queries) GetSenderContracts(ctx context.Context, senderID *int64) ([]Co
 			&i.DepartureReport,
 			&i.UpdatedAt,
 			&i.CreatedAt,
+			&i.IndicationNumber,
 		); err != nil {
 			return nil, err
 		}
@@ -423,7 +400,7 @@ func (q *Queries) GetSenderContracts(ctx context.Context, senderID *int64) ([]Co
 
 const listClientContracts = `-- name: ListClientContracts :many
 WITH client_contracts AS (
-    SELECT c.id, c.type_id, c.status, c.approved_at, c.start_date, c.end_date, c.reminder_period, c.vat, c.price, c.price_time_unit, c.hours, c.hours_type, c.care_name, c.care_type, c.client_id, c.sender_id, c.attachment_ids, c.financing_act, c.financing_option, c.departure_reason, c.departure_report, c.updated_at, c.created_at, 
+    SELECT c.id, c.type_id, c.status, c.approved_at, c.start_date, c.end_date, c.reminder_period, c.vat, c.price, c.price_time_unit, c.hours, c.hours_type, c.care_name, c.care_type, c.client_id, c.sender_id, c.attachment_ids, c.financing_act, c.financing_option, c.departure_reason, c.departure_report, c.updated_at, c.created_at, c.indication_number, 
             ct.name AS contract_type_name,
             cd.first_name AS client_first_name,
             cd.last_name AS client_last_name,
@@ -436,7 +413,7 @@ WITH client_contracts AS (
 )
 SELECT
     (SELECT COUNT(*) FROM client_contracts) AS total_count,
-    id, type_id, status, approved_at, start_date, end_date, reminder_period, vat, price, price_time_unit, hours, hours_type, care_name, care_type, client_id, sender_id, attachment_ids, financing_act, financing_option, departure_reason, departure_report, updated_at, created_at, contract_type_name, client_first_name, client_last_name, sender_name
+    id, type_id, status, approved_at, start_date, end_date, reminder_period, vat, price, price_time_unit, hours, hours_type, care_name, care_type, client_id, sender_id, attachment_ids, financing_act, financing_option, departure_reason, departure_report, updated_at, created_at, indication_number, contract_type_name, client_first_name, client_last_name, sender_name
 FROM client_contracts
 ORDER BY created_at DESC
 LIMIT $2
@@ -474,6 +451,7 @@ type ListClientContractsRow struct {
 	DepartureReport  *string            `json:"departure_report"`
 	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
 	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	IndicationNumber *string            `json:"indication_number"`
 	ContractTypeName string             `json:"contract_type_name"`
 	ClientFirstName  string             `json:"client_first_name"`
 	ClientLastName   string             `json:"client_last_name"`
@@ -514,6 +492,7 @@ func (q *Queries) ListClientContracts(ctx context.Context, arg ListClientContrac
 			&i.DepartureReport,
 			&i.UpdatedAt,
 			&i.CreatedAt,
+			&i.IndicationNumber,
 			&i.ContractTypeName,
 			&i.ClientFirstName,
 			&i.ClientLastName,
@@ -780,28 +759,30 @@ SET
     attachment_ids = COALESCE($14, attachment_ids),
     financing_act = COALESCE($15, financing_act),
     financing_option = COALESCE($16, financing_option),
+    indication_number = COALESCE($17, indication_number),
     updated_at = NOW()
 WHERE id = $1
-RETURNING id, type_id, status, approved_at, start_date, end_date, reminder_period, vat, price, price_time_unit, hours, hours_type, care_name, care_type, client_id, sender_id, attachment_ids, financing_act, financing_option, departure_reason, departure_report, updated_at, created_at
+RETURNING id, type_id, status, approved_at, start_date, end_date, reminder_period, vat, price, price_time_unit, hours, hours_type, care_name, care_type, client_id, sender_id, attachment_ids, financing_act, financing_option, departure_reason, departure_report, updated_at, created_at, indication_number
 `
 
 type UpdateContractParams struct {
-	ID              int64              `json:"id"`
-	TypeID          *int64             `json:"type_id"`
-	StartDate       pgtype.Timestamptz `json:"start_date"`
-	EndDate         pgtype.Timestamptz `json:"end_date"`
-	ReminderPeriod  *int32             `json:"reminder_period"`
-	VAT             *int32             `json:"VAT"`
-	Price           *float64           `json:"price"`
-	PriceTimeUnit   *string            `json:"price_time_unit"`
-	Hours           *float64           `json:"hours"`
-	HoursType       *string            `json:"hours_type"`
-	CareName        *string            `json:"care_name"`
-	CareType        *string            `json:"care_type"`
-	SenderID        *int64             `json:"sender_id"`
-	AttachmentIds   []uuid.UUID        `json:"attachment_ids"`
-	FinancingAct    *string            `json:"financing_act"`
-	FinancingOption *string            `json:"financing_option"`
+	ID               int64              `json:"id"`
+	TypeID           *int64             `json:"type_id"`
+	StartDate        pgtype.Timestamptz `json:"start_date"`
+	EndDate          pgtype.Timestamptz `json:"end_date"`
+	ReminderPeriod   *int32             `json:"reminder_period"`
+	VAT              *int32             `json:"VAT"`
+	Price            *float64           `json:"price"`
+	PriceTimeUnit    *string            `json:"price_time_unit"`
+	Hours            *float64           `json:"hours"`
+	HoursType        *string            `json:"hours_type"`
+	CareName         *string            `json:"care_name"`
+	CareType         *string            `json:"care_type"`
+	SenderID         *int64             `json:"sender_id"`
+	AttachmentIds    []uuid.UUID        `json:"attachment_ids"`
+	FinancingAct     *string            `json:"financing_act"`
+	FinancingOption  *string            `json:"financing_option"`
+	IndicationNumber *string            `json:"indication_number"`
 }
 
 func (q *Queries) UpdateContract(ctx context.Context, arg UpdateContractParams) (Contract, error) {
@@ -822,6 +803,7 @@ func (q *Queries) UpdateContract(ctx context.Context, arg UpdateContractParams)
 		arg.AttachmentIds,
 		arg.FinancingAct,
 		arg.FinancingOption,
+		arg.IndicationNumber,
 	)
 	var i Contract
 	err := row.Scan(
@@ -848,6 +830,7 @@ func (q *Queries) UpdateContract(ctx context.Context, arg UpdateContractParams)
 		&i.DepartureReport,
 		&i.UpdatedAt,
 		&i.CreatedAt,
+		&i.IndicationNumber,
 	)
 	return i, err
 }
@@ -858,7 +841,7 @@ SET
     status = $1::text,
     approved_at = CASE WHEN $1::text = 'approved' THEN NOW() ELSE approved_at END
 WHERE id = $2::BIGINT
-RETURNING id, type_id, status, approved_at, start_date, end_date, reminder_period, vat, price, price_time_unit, hours, hours_type, care_name, care_type, client_id, sender_id, attachment_ids, financing_act, financing_option, departure_reason, departure_report, updated_at, created_at
+RETURNING id, type_id, status, approved_at, start_date, end_date, reminder_period, vat, price, price_time_unit, hours, hours_type, care_name, care_type, client_id, sender_id, attachment_ids, financing_act, financing_option, departure_reason, departure_report, updated_at, created_at, indication_number
 `
 
 type UpdateContractStatusParams struct {
@@ -893,6 +876,7 @@ func (q *Queries) UpdateContractStatus(ctx context.Context, arg UpdateContractSt
 		&i.DepartureReport,
 		&i.UpdatedAt,
 		&i.CreatedAt,
+		&i.IndicationNumber,
 	)
 	return i, err
 }
@@ -0,0 +1,53 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpireRenewedContracts(t *testing.T) {
+	client := createRandomClientDetails(t)
+	started := createRandomContract(t, client.ID, client.SenderID)
+	later := createRandomContract(t, client.ID, client.SenderID)
+
+	// started was renewed by a contract that runs already, later by one that starts next month
+	for _, tc := range []struct {
+		contract Contract
+		closeAt  time.Time
+	}{
+		{contract: started, closeAt: time.Now().Add(-time.Hour)},
+		{contract: later, closeAt: time.Now().AddDate(0, 1, 0)},
+	} {
+		successor := createRandomContract(t, client.ID, client.SenderID)
+		renewal, err := testQueries.CreateContractRenewal(context.Background(), CreateContractRenewalParams{
+			PredecessorID: tc.contract.ID,
+			SuccessorID:   successor.ID,
+		})
+		require.NoError(t, err)
+		require.NoError(t, testQueries.CloseContractRenewal(context.Background(), renewal.ID))
+
+		_, err = testQueries.CreateContractStatusPeriod(context.Background(), CreateContractStatusPeriodParams{
+			ContractID:    tc.contract.ID,
+			Status:        "expired",
+			EffectiveFrom: pgtype.Timestamptz{Time: tc.closeAt, Valid: true},
+		})
+		require.NoError(t, err)
+	}
+
+	expired, err := testQueries.ExpireRenewedContracts(context.Background())
+	require.NoError(t, err)
+	require.Contains(t, expired, started.ID)
+	require.NotContains(t, expired, later.ID)
+
+	contract, err := testQueries.GetClientContract(context.Background(), started.ID)
+	require.NoError(t, err)
+	require.Equal(t, "expired", contract.Status)
+
+	contract, err = testQueries.GetClientContract(context.Background(), later.ID)
+	require.NoError(t, err)
+	require.Equal(t, "approved", contract.Status)
+}
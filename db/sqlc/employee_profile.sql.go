@@ -837,6 +837,31 @@ func (q *Queries) ListEmployeeProfile(ctx context.Context, arg ListEmployeeProfi
 	return items, nil
 }
 
+const listEmployeeUserIDs = `-- name: ListEmployeeUserIDs :many
+SELECT user_id FROM employee_profile
+WHERE id = ANY($1::bigint[])
+`
+
+func (q *Queries) ListEmployeeUserIDs(ctx context.Context, employeeIds []int64) ([]int64, error) {
+	rows, err := q.db.Query(ctx, listEmployeeUserIDs, employeeIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int64{}
+	for rows.Next() {
+		var user_id int64
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const searchEmployeesByNameOrEmail = `-- name: SearchEmployeesByNameOrEmail :many
 SELECT
     id,
@@ -0,0 +1,84 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"maicare_go/util"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReceivablesAgingWithPartialCredit(t *testing.T) {
+	ctx := context.Background()
+	client := createRandomClientDetails(t)
+	asOf := time.Now().Truncate(24 * time.Hour)
+
+	inv, err := testQueries.CreateInvoice(ctx, CreateInvoiceParams{
+		InvoiceNumber:  "AGING-" + util.RandomString(10),
+		IssueDate:      pgtype.Date{Time: asOf.AddDate(0, 0, -75), Valid: true},
+		DueDate:        pgtype.Date{Time: asOf.AddDate(0, 0, -45), Valid: true},
+		InvoiceDetails: []byte(`[]`),
+		TotalAmount:    1000,
+		ClientID:       client.ID,
+		SenderID:       client.SenderID,
+		InvoiceType:    "standard",
+	})
+	require.NoError(t, err)
+	_, err = testQueries.UpdateInvoiceStatus(ctx, UpdateInvoiceStatusParams{ID: inv.ID, Status: "outstanding"})
+	require.NoError(t, err)
+
+	agingOf := func() ListReceivablesAgingBySenderRow {
+		rows, err := testQueries.ListReceivablesAgingBySender(ctx, pgtype.Date{Time: asOf, Valid: true})
+		require.NoError(t, err)
+		for _, row := range rows {
+			if row.SenderID != nil && *row.SenderID == *client.SenderID {
+				return row
+			}
+		}
+		return ListReceivablesAgingBySenderRow{}
+	}
+	require.Equal(t, 1000.0, agingOf().Days3160)
+
+	// a partial credit note lowers the open balance of the invoice
+	_, err = testQueries.CreateCreditNote(ctx, CreateCreditNoteParams{
+		InvoiceNumber:     "CREDIT-" + util.RandomString(10),
+		IssueDate:         pgtype.Date{Time: asOf.AddDate(0, 0, -10), Valid: true},
+		DueDate:           pgtype.Date{Time: asOf.AddDate(0, 0, 20), Valid: true},
+		InvoiceDetails:    []byte(`[]`),
+		TotalAmount:       -400,
+		ClientID:          client.ID,
+		SenderID:          client.SenderID,
+		OriginalInvoiceID: &inv.ID,
+	})
+	require.NoError(t, err)
+
+	aging := agingOf()
+	require.Equal(t, 600.0, aging.Days3160)
+	require.Equal(t, 600.0, aging.TotalBalance)
+
+	// credit notes issued after the date do not count yet
+	rows, err := testQueries.ListReceivablesAgingByClient(ctx, pgtype.Date{Time: asOf.AddDate(0, 0, -20), Valid: true})
+	require.NoError(t, err)
+	for _, row := range rows {
+		if row.ClientID == client.ID {
+			require.Equal(t, 1000.0, row.TotalBalance)
+		}
+	}
+
+	// a credit note for the whole remainder closes the invoice
+	_, err = testQueries.CreateCreditNote(ctx, CreateCreditNoteParams{
+		InvoiceNumber:     "CREDIT-" + util.RandomString(10),
+		IssueDate:         pgtype.Date{Time: asOf, Valid: true},
+		DueDate:           pgtype.Date{Time: asOf.AddDate(0, 0, 30), Valid: true},
+		InvoiceDetails:    []byte(`[]`),
+		TotalAmount:       -600,
+		ClientID:          client.ID,
+		SenderID:          client.SenderID,
+		OriginalInvoiceID: &inv.ID,
+	})
+	require.NoError(t, err)
+	require.Zero(t, agingOf().TotalBalance)
+}
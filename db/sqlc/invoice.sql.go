@@ -12,6 +12,73 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const createCreditNote = `-- name: CreateCreditNote :one
+INSERT INTO invoice (
+    invoice_number,
+    invoice_sequence,
+    due_date,
+    issue_date,
+    invoice_details,
+    total_amount,
+    extra_content,
+    client_id,
+    sender_id,
+    original_invoice_id,
+    invoice_type
+    ) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, 'credit_note'
+) RETURNING id, invoice_number, invoice_sequence, issue_date, due_date, status, invoice_type, original_invoice_id, invoice_details, total_amount, pdf_attachment_id, extra_content, client_id, sender_id, warning_count, updated_at, created_at;
+`
+
+type CreateCreditNoteParams struct {
+	InvoiceNumber     string      `json:"invoice_number"`
+	InvoiceSequence   int64       `json:"invoice_sequence"`
+	DueDate           pgtype.Date `json:"due_date"`
+	IssueDate         pgtype.Date `json:"issue_date"`
+	InvoiceDetails    []byte      `json:"invoice_details"`
+	TotalAmount       float64     `json:"total_amount"`
+	ExtraContent      []byte      `json:"extra_content"`
+	ClientID          int64       `json:"client_id"`
+	SenderID          *int64      `json:"sender_id"`
+	OriginalInvoiceID *int64      `json:"original_invoice_id"`
+}
+
+func (q *Queries) CreateCreditNote(ctx context.Context, arg CreateCreditNoteParams) (Invoice, error) {
+	row := q.db.QueryRow(ctx, createCreditNote,
+		arg.InvoiceNumber,
+		arg.InvoiceSequence,
+		arg.DueDate,
+		arg.IssueDate,
+		arg.InvoiceDetails,
+		arg.TotalAmount,
+		arg.ExtraContent,
+		arg.ClientID,
+		arg.SenderID,
+		arg.OriginalInvoiceID,
+	)
+	var i Invoice
+	err := row.Scan(
+		&i.ID,
+		&i.InvoiceNumber,
+		&i.InvoiceSequence,
+		&i.IssueDate,
+		&i.DueDate,
+		&i.Status,
+		&i.InvoiceType,
+		&i.OriginalInvoiceID,
+		&i.InvoiceDetails,
+		&i.TotalAmount,
+		&i.PdfAttachmentID,
+		&i.ExtraContent,
+		&i.ClientID,
+		&i.SenderID,
+		&i.WarningCount,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const createInvoice = `-- name: CreateInvoice :one
 INSERT INTO invoice (
     invoice_number,
@@ -195,6 +262,10 @@ SELECT
     s.kvknumber AS sender_kvknumber,
     s.btwnumber AS sender_btwnumber,
     s.address AS sender_address,
+    s.place AS sender_place,
+    s.land AS sender_land,
+    s.email_address AS sender_email_address,
+    s.types AS sender_types,
     cd.first_name AS client_first_name,
     cd.last_name AS client_last_name
 FROM
@@ -209,31 +280,35 @@ LIMIT 1
 `
 
 type GetInvoiceRow struct {
-	ID                int64              `json:"id"`
-	InvoiceNumber     string             `json:"invoice_number"`
-	InvoiceSequence   int64              `json:"invoice_sequence"`
-	IssueDate         pgtype.Date        `json:"issue_date"`
-	DueDate           pgtype.Date        `json:"due_date"`
-	Status            string             `json:"status"`
-	InvoiceType       string             `json:"invoice_type"`
-	OriginalInvoiceID *int64             `json:"original_invoice_id"`
-	InvoiceDetails    []byte             `json:"invoice_details"`
-	TotalAmount       float64            `json:"total_amount"`
-	PdfAttachmentID   *uuid.UUID         `json:"pdf_attachment_id"`
-	ExtraContent      []byte             `json:"extra_content"`
-	ClientID          int64              `json:"client_id"`
-	SenderID          *int64             `json:"sender_id"`
-	WarningCount      int32              `json:"warning_count"`
-	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
-	CreatedAt         pgtype.Timestamptz `json:"created_at"`
-	SenderName        *string            `json:"sender_name"`
-	SenderContacts    []byte             `json:"sender_contacts"`
-	SenderPostalCode  *string            `json:"sender_postal_code"`
-	SenderKvknumber   *string            `json:"sender_kvknumber"`
-	SenderBtwnumber   *string            `json:"sender_btwnumber"`
-	SenderAddress     *string            `json:"sender_address"`
-	ClientFirstName   string             `json:"client_first_name"`
-	ClientLastName    string             `json:"client_last_name"`
+	ID                 int64              `json:"id"`
+	InvoiceNumber      string             `json:"invoice_number"`
+	InvoiceSequence    int64              `json:"invoice_sequence"`
+	IssueDate          pgtype.Date        `json:"issue_date"`
+	DueDate            pgtype.Date        `json:"due_date"`
+	Status             string             `json:"status"`
+	InvoiceType        string             `json:"invoice_type"`
+	OriginalInvoiceID  *int64             `json:"original_invoice_id"`
+	InvoiceDetails     []byte             `json:"invoice_details"`
+	TotalAmount        float64            `json:"total_amount"`
+	PdfAttachmentID    *uuid.UUID         `json:"pdf_attachment_id"`
+	ExtraContent       []byte             `json:"extra_content"`
+	ClientID           int64              `json:"client_id"`
+	SenderID           *int64             `json:"sender_id"`
+	WarningCount       int32              `json:"warning_count"`
+	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
+	CreatedAt          pgtype.Timestamptz `json:"created_at"`
+	SenderName         *string            `json:"sender_name"`
+	SenderContacts     []byte             `json:"sender_contacts"`
+	SenderPostalCode   *string            `json:"sender_postal_code"`
+	SenderKvknumber    *string            `json:"sender_kvknumber"`
+	SenderBtwnumber    *string            `json:"sender_btwnumber"`
+	SenderAddress      *string            `json:"sender_address"`
+	SenderPlace        *string            `json:"sender_place"`
+	SenderLand         *string            `json:"sender_land"`
+	SenderEmailAddress *string            `json:"sender_email_address"`
+	SenderTypes        *string            `json:"sender_types"`
+	ClientFirstName    string             `json:"client_first_name"`
+	ClientLastName     string             `json:"client_last_name"`
 }
 
 func (q *Queries) GetInvoice(ctx context.Context, id int64) (GetInvoiceRow, error) {
@@ -263,6 +338,10 @@ func (q *Queries) GetInvoice(ctx context.Context, id int64) (GetInvoiceRow, erro
 		&i.SenderKvknumber,
 		&i.SenderBtwnumber,
 		&i.SenderAddress,
+		&i.SenderPlace,
+		&i.SenderLand,
+		&i.SenderEmailAddress,
+		&i.SenderTypes,
 		&i.ClientFirstName,
 		&i.ClientLastName,
 	)
@@ -328,6 +407,52 @@ func (q *Queries) GetInvoiceAuditLogs(ctx context.Context, invoiceID int64) ([]G
 	return items, nil
 }
 
+const getInvoiceCreditedAmount = `-- name: GetInvoiceCreditedAmount :one
+SELECT
+    COALESCE(SUM(-total_amount), 0)::FLOAT AS credited_amount
+FROM invoice
+WHERE original_invoice_id = $1
+  AND invoice_type = 'credit_note'
+  AND status <> 'canceled';
+`
+
+func (q *Queries) GetInvoiceCreditedAmount(ctx context.Context, originalInvoiceID *int64) (float64, error) {
+	row := q.db.QueryRow(ctx, getInvoiceCreditedAmount, originalInvoiceID)
+	var credited_amount float64
+	err := row.Scan(&credited_amount)
+	return credited_amount, err
+}
+
+const getInvoiceIssuer = `-- name: GetInvoiceIssuer :one
+SELECT o.id, o.name, o.address, o.postal_code, o.city, o.phone_number, o.email, o.kvk_number, o.btw_number, o.created_at, o.updated_at, o.iban
+FROM invoice i
+JOIN client_details cd ON i.client_id = cd.id
+LEFT JOIN location l ON cd.location_id = l.id
+JOIN organisations o ON o.id = COALESCE(cd.organization_id, l.organisation_id)
+WHERE i.id = $1
+LIMIT 1
+`
+
+func (q *Queries) GetInvoiceIssuer(ctx context.Context, id int64) (Organisation, error) {
+	row := q.db.QueryRow(ctx, getInvoiceIssuer, id)
+	var i Organisation
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Address,
+		&i.PostalCode,
+		&i.City,
+		&i.PhoneNumber,
+		&i.Email,
+		&i.KvkNumber,
+		&i.BtwNumber,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Iban,
+	)
+	return i, err
+}
+
 const getInvoiceSenderID = `-- name: GetInvoiceSenderID :one
 SELECT sender_id
 FROM invoice
@@ -341,19 +466,6 @@ func (q *Queries) GetInvoiceSenderID(ctx context.Context, id int64) (*int64, err
 	return sender_id, err
 }
 
-const getMaxInvoiceSequenceForDate = `-- name: GetMaxInvoiceSequenceForDate :one
-SELECT COALESCE(MAX(invoice_sequence), 0)::BIGINT as max_sequence
-FROM invoice
-WHERE DATE(created_at) = DATE($1)
-`
-
-func (q *Queries) GetMaxInvoiceSequenceForDate(ctx context.Context, date interface{}) (int64, error) {
-	row := q.db.QueryRow(ctx, getMaxInvoiceSequenceForDate, date)
-	var max_sequence int64
-	err := row.Scan(&max_sequence)
-	return max_sequence, err
-}
-
 const getPayment = `-- name: GetPayment :one
 SELECT
     iph.id, iph.invoice_id, iph.payment_method, iph.payment_status, iph.amount, iph.payment_date, iph.payment_reference, iph.notes, iph.recorded_by, iph.created_at, iph.updated_at,
@@ -488,6 +600,63 @@ func (q *Queries) InsertIncoicePdfUrl(ctx context.Context, arg InsertIncoicePdfU
 	return pdf_attachment_id, err
 }
 
+const listInvoiceCreditNotes = `-- name: ListInvoiceCreditNotes :many
+SELECT
+    id,
+    invoice_number,
+    issue_date,
+    status,
+    invoice_details,
+    total_amount,
+    created_at
+FROM
+    invoice
+WHERE
+    original_invoice_id = $1
+    AND invoice_type = 'credit_note'
+    AND status <> 'canceled'
+ORDER BY
+    created_at ASC;
+`
+
+type ListInvoiceCreditNotesRow struct {
+	ID             int64              `json:"id"`
+	InvoiceNumber  string             `json:"invoice_number"`
+	IssueDate      pgtype.Date        `json:"issue_date"`
+	Status         string             `json:"status"`
+	InvoiceDetails []byte             `json:"invoice_details"`
+	TotalAmount    float64            `json:"total_amount"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
+func (q *Queries) ListInvoiceCreditNotes(ctx context.Context, originalInvoiceID *int64) ([]ListInvoiceCreditNotesRow, error) {
+	rows, err := q.db.Query(ctx, listInvoiceCreditNotes, originalInvoiceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListInvoiceCreditNotesRow{}
+	for rows.Next() {
+		var i ListInvoiceCreditNotesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.InvoiceNumber,
+			&i.IssueDate,
+			&i.Status,
+			&i.InvoiceDetails,
+			&i.TotalAmount,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listInvoices = `-- name: ListInvoices :many
 SELECT
     i.id, i.invoice_number, i.invoice_sequence, i.issue_date, i.due_date, i.status, i.invoice_type, i.original_invoice_id, i.invoice_details, i.total_amount, i.pdf_attachment_id, i.extra_content, i.client_id, i.sender_id, i.warning_count, i.updated_at, i.created_at,
@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"slices"
+	"strings"
 )
 
 type Table[T any] struct {
@@ -14,10 +16,21 @@ type Table[T any] struct {
 type ClientDetailsColumns struct {
 	DateOfBirth string
 	Filenumber  string
+	Bsn         string
+	FirstName   string
+	LastName    string
 }
 type ContractColumns struct {
-	FinancingAct    string
-	FinancingOption string
+	FinancingAct     string
+	FinancingOption  string
+	CareName         string
+	CareType         string
+	StartDate        string
+	EndDate          string
+	IndicationNumber string
+}
+type ContractTypeColumns struct {
+	Name string
 }
 
 var (
@@ -26,13 +39,27 @@ var (
 		Columns: ClientDetailsColumns{
 			DateOfBirth: "date_of_birth",
 			Filenumber:  "filenumber",
+			Bsn:         "bsn",
+			FirstName:   "first_name",
+			LastName:    "last_name",
 		},
 	}
 	TableContract = Table[ContractColumns]{
 		Name: "contract",
 		Columns: ContractColumns{
-			FinancingAct:    "financing_act",
-			FinancingOption: "financing_option",
+			FinancingAct:     "financing_act",
+			FinancingOption:  "financing_option",
+			CareName:         "care_name",
+			CareType:         "care_type",
+			StartDate:        "start_date",
+			EndDate:          "end_date",
+			IndicationNumber: "indication_number",
+		},
+	}
+	TableContractType = Table[ContractTypeColumns]{
+		Name: "contract_type",
+		Columns: ContractTypeColumns{
+			Name: "name",
 		},
 	}
 )
@@ -43,37 +70,48 @@ type FetchQueryData struct {
 	SenderID   int64
 }
 
-func (store *Store) FetchInvoiceTemplateItems(ctx context.Context, data FetchQueryData) (map[string]string, error) {
-	extraContent := make(map[string]string)
-	templItemsIds, err := store.GetSenderInvoiceTemplate(ctx, data.SenderID)
+// InvoiceTemplateValue is a template item of a sender resolved for an invoice, Label is
+// the description of the item
+type InvoiceTemplateValue struct {
+	ItemTag string `json:"item_tag"`
+	Label   string `json:"label"`
+	Value   string `json:"value"`
+}
 
+func (store *Store) FetchInvoiceTemplateItems(ctx context.Context, data FetchQueryData) (map[string]string, error) {
+	values, err := store.FetchInvoiceTemplateValues(ctx, data)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("no invoice template found for sender ID %d", data.SenderID)
-		}
-		return nil, fmt.Errorf("failed to get sender invoice template: %w", err)
+		return nil, err
 	}
 
-	if len(templItemsIds) == 0 {
+	// If there are no items for the given template items, return nil
+	if len(values) == 0 {
 		return nil, nil
 	}
 
-	templItems, err := store.GetTemplateItemsBySourceTable(ctx, templItemsIds)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("no template items found for sender ID %d", data.SenderID)
-		}
-		return nil, fmt.Errorf("failed to get template items: %w", err)
+	extraContent := make(map[string]string, len(values))
+	for _, value := range values {
+		extraContent[value.Label] = value.Value
 	}
-	if len(templItems) == 0 {
-		return nil, nil
+	return extraContent, nil
+}
+
+// FetchInvoiceTemplateValues resolves the template items of a sender from their source
+// tables in the order the sender selected them. Contract items are left out when no
+// contract is given and items without a value are skipped.
+func (store *Store) FetchInvoiceTemplateValues(ctx context.Context, data FetchQueryData) ([]InvoiceTemplateValue, error) {
+	templItems, err := store.GetSenderTemplateItems(ctx, data.SenderID)
+	if err != nil || len(templItems) == 0 {
+		return nil, err
 	}
+
 	// group items by source table
 	groupedItems := make(map[string][]TemplateItem)
 	for _, item := range templItems {
 		groupedItems[item.SourceTable] = append(groupedItems[item.SourceTable], item)
 	}
 
+	resolved := make(map[int64]string)
 	for table, items := range groupedItems {
 		switch table {
 		case TableClientDetails.Name:
@@ -87,32 +125,113 @@ func (store *Store) FetchInvoiceTemplateItems(ctx context.Context, data FetchQue
 			for _, item := range items {
 				switch item.SourceColumn {
 				case TableClientDetails.Columns.DateOfBirth:
-					extraContent[item.Description] = clientDetails.DateOfBirth.Time.Format("02-01-2006")
+					if clientDetails.DateOfBirth.Valid {
+						resolved[item.ID] = clientDetails.DateOfBirth.Time.Format("02-01-2006")
+					}
 				case TableClientDetails.Columns.Filenumber:
-					extraContent[item.Description] = clientDetails.Filenumber
+					resolved[item.ID] = clientDetails.Filenumber
+				case TableClientDetails.Columns.Bsn:
+					resolved[item.ID] = derefString(clientDetails.Bsn)
+				case TableClientDetails.Columns.FirstName:
+					resolved[item.ID] = clientDetails.FirstName
+				case TableClientDetails.Columns.LastName:
+					resolved[item.ID] = clientDetails.LastName
 				}
 			}
-		case TableContract.Name:
+		case TableContract.Name, TableContractType.Name:
+			if data.ContractID == 0 {
+				continue
+			}
 			contract, err := store.Queries.GetClientContract(ctx, data.ContractID)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get contract for client ID %d: %w", data.ContractID, err)
 			}
 			for _, item := range items {
+				if table == TableContractType.Name {
+					if item.SourceColumn == TableContractType.Columns.Name {
+						resolved[item.ID] = contract.ContractTypeName
+					}
+					continue
+				}
 				switch item.SourceColumn {
 				case TableContract.Columns.FinancingAct:
-					extraContent[item.Description] = contract.FinancingAct
+					resolved[item.ID] = contract.FinancingAct
 				case TableContract.Columns.FinancingOption:
-					extraContent[item.Description] = contract.FinancingOption
+					resolved[item.ID] = contract.FinancingOption
+				case TableContract.Columns.CareName:
+					resolved[item.ID] = contract.CareName
+				case TableContract.Columns.CareType:
+					resolved[item.ID] = contract.CareType
+				case TableContract.Columns.StartDate:
+					resolved[item.ID] = contract.StartDate.Time.Format("02-01-2006")
+				case TableContract.Columns.EndDate:
+					resolved[item.ID] = contract.EndDate.Time.Format("02-01-2006")
+				case TableContract.Columns.IndicationNumber:
+					resolved[item.ID] = derefString(contract.IndicationNumber)
 				}
 			}
 		}
+	}
 
+	var values []InvoiceTemplateValue
+	for _, item := range templItems {
+		value := strings.TrimSpace(resolved[item.ID])
+		if value == "" {
+			continue
+		}
+		values = append(values, InvoiceTemplateValue{
+			ItemTag: item.ItemTag,
+			Label:   item.Description,
+			Value:   value,
+		})
 	}
+	return values, nil
+}
 
-	// If there are no items for the given template items, return nil
-	if len(extraContent) == 0 {
+// GetSenderTemplateItems returns the template items of a sender in the order they were
+// selected
+func (store *Store) GetSenderTemplateItems(ctx context.Context, senderID int64) ([]TemplateItem, error) {
+	templItemsIds, err := store.GetSenderInvoiceTemplate(ctx, senderID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("no invoice template found for sender ID %d", senderID)
+		}
+		return nil, fmt.Errorf("failed to get sender invoice template: %w", err)
+	}
+	if len(templItemsIds) == 0 {
 		return nil, nil
 	}
 
-	return extraContent, nil
+	templItems, err := store.GetTemplateItemsBySourceTable(ctx, templItemsIds)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("no template items found for sender ID %d", senderID)
+		}
+		return nil, fmt.Errorf("failed to get template items: %w", err)
+	}
+	slices.SortStableFunc(templItems, func(a, b TemplateItem) int {
+		return slices.Index(templItemsIds, a.ID) - slices.Index(templItemsIds, b.ID)
+	})
+	return templItems, nil
+}
+
+// GetSenderTemplateLabels returns the descriptions of the template items of a sender in
+// the order they are printed on the invoice
+func (store *Store) GetSenderTemplateLabels(ctx context.Context, senderID int64) ([]string, error) {
+	templItems, err := store.GetSenderTemplateItems(ctx, senderID)
+	if err != nil {
+		return nil, err
+	}
+	labels := make([]string, 0, len(templItems))
+	for _, item := range templItems {
+		labels = append(labels, item.Description)
+	}
+	return labels, nil
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
 }
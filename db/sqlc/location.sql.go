@@ -59,10 +59,11 @@ INSERT INTO organisations (
     phone_number,
     email,
     kvk_number,
-    btw_number
+    btw_number,
+    iban
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8
-) RETURNING id, name, address, postal_code, city, phone_number, email, kvk_number, btw_number, created_at, updated_at
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING id, name, address, postal_code, city, phone_number, email, kvk_number, btw_number, created_at, updated_at, iban
 `
 
 type CreateOrganisationParams struct {
@@ -74,6 +75,7 @@ type CreateOrganisationParams struct {
 	Email       *string `json:"email"`
 	KvkNumber   *string `json:"kvk_number"`
 	BtwNumber   *string `json:"btw_number"`
+	Iban        *string `json:"iban"`
 }
 
 func (q *Queries) CreateOrganisation(ctx context.Context, arg CreateOrganisationParams) (Organisation, error) {
@@ -86,6 +88,7 @@ func (q *Queries) CreateOrganisation(ctx context.Context, arg CreateOrganisation
 		arg.Email,
 		arg.KvkNumber,
 		arg.BtwNumber,
+		arg.Iban,
 	)
 	var i Organisation
 	err := row.Scan(
@@ -100,6 +103,7 @@ func (q *Queries) CreateOrganisation(ctx context.Context, arg CreateOrganisation
 		&i.BtwNumber,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Iban,
 	)
 	return i, err
 }
@@ -129,7 +133,7 @@ func (q *Queries) DeleteLocation(ctx context.Context, id int64) (Location, error
 const deleteOrganisation = `-- name: DeleteOrganisation :one
 DELETE FROM organisations
 WHERE id = $1
-RETURNING id, name, address, postal_code, city, phone_number, email, kvk_number, btw_number, created_at, updated_at
+RETURNING id, name, address, postal_code, city, phone_number, email, kvk_number, btw_number, created_at, updated_at, iban
 `
 
 func (q *Queries) DeleteOrganisation(ctx context.Context, id int64) (Organisation, error) {
@@ -147,6 +151,7 @@ func (q *Queries) DeleteOrganisation(ctx context.Context, id int64) (Organisatio
 		&i.BtwNumber,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Iban,
 	)
 	return i, err
 }
@@ -173,7 +178,7 @@ func (q *Queries) GetLocation(ctx context.Context, id int64) (Location, error) {
 }
 
 const getOrganisation = `-- name: GetOrganisation :one
-SELECT o.id, o.name, o.address, o.postal_code, o.city, o.phone_number, o.email, o.kvk_number, o.btw_number, o.created_at, o.updated_at,
+SELECT o.id, o.name, o.address, o.postal_code, o.city, o.phone_number, o.email, o.kvk_number, o.btw_number, o.created_at, o.updated_at, o.iban,
        COUNT(l.id) AS location_count
 FROM organisations o
 LEFT JOIN location l ON o.id = l.organisation_id
@@ -193,6 +198,7 @@ type GetOrganisationRow struct {
 	BtwNumber     *string            `json:"btw_number"`
 	CreatedAt     pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+	Iban          *string            `json:"iban"`
 	LocationCount int64              `json:"location_count"`
 }
 
@@ -211,6 +217,7 @@ func (q *Queries) GetOrganisation(ctx context.Context, id int64) (GetOrganisatio
 		&i.BtwNumber,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Iban,
 		&i.LocationCount,
 	)
 	return i, err
@@ -324,7 +331,7 @@ func (q *Queries) ListLocations(ctx context.Context, organisationID int64) ([]Lo
 }
 
 const listOrganisations = `-- name: ListOrganisations :many
-SELECT o.id, o.name, o.address, o.postal_code, o.city, o.phone_number, o.email, o.kvk_number, o.btw_number, o.created_at, o.updated_at,
+SELECT o.id, o.name, o.address, o.postal_code, o.city, o.phone_number, o.email, o.kvk_number, o.btw_number, o.created_at, o.updated_at, o.iban,
          COUNT(l.id) AS location_count
 FROM organisations o
 LEFT JOIN location l ON o.id = l.organisation_id
@@ -344,6 +351,7 @@ type ListOrganisationsRow struct {
 	BtwNumber     *string            `json:"btw_number"`
 	CreatedAt     pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+	Iban          *string            `json:"iban"`
 	LocationCount int64              `json:"location_count"`
 }
 
@@ -368,6 +376,7 @@ func (q *Queries) ListOrganisations(ctx context.Context) ([]ListOrganisationsRow
 			&i.BtwNumber,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Iban,
 			&i.LocationCount,
 		); err != nil {
 			return nil, err
@@ -429,9 +438,10 @@ SET
     email = COALESCE($7, email),
     kvk_number = COALESCE($8, kvk_number),
     btw_number = COALESCE($9, btw_number),
+    iban = COALESCE($10, iban),
     updated_at = now()
 WHERE id = $1
-RETURNING id, name, address, postal_code, city, phone_number, email, kvk_number, btw_number, created_at, updated_at
+RETURNING id, name, address, postal_code, city, phone_number, email, kvk_number, btw_number, created_at, updated_at, iban
 `
 
 type UpdateOrganisationParams struct {
@@ -444,6 +454,7 @@ type UpdateOrganisationParams struct {
 	Email       *string `json:"email"`
 	KvkNumber   *string `json:"kvk_number"`
 	BtwNumber   *string `json:"btw_number"`
+	Iban        *string `json:"iban"`
 }
 
 func (q *Queries) UpdateOrganisation(ctx context.Context, arg UpdateOrganisationParams) (Organisation, error) {
@@ -457,6 +468,7 @@ func (q *Queries) UpdateOrganisation(ctx context.Context, arg UpdateOrganisation
 		arg.Email,
 		arg.KvkNumber,
 		arg.BtwNumber,
+		arg.Iban,
 	)
 	var i Organisation
 	err := row.Scan(
@@ -471,6 +483,7 @@ func (q *Queries) UpdateOrganisation(ctx context.Context, arg UpdateOrganisation
 		&i.BtwNumber,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Iban,
 	)
 	return i, err
 }
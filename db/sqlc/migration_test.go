@@ -0,0 +1,108 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"maicare_go/util"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+)
+
+// migrateSchema runs the migrations up to version in a schema of its own, the schema is
+// dropped when the test ends
+func migrateSchema(t *testing.T, version uint) (*migrate.Migrate, *pgxpool.Pool) {
+	config, err := util.LoadConfig("../..")
+	require.NoError(t, err)
+
+	schema := "migration_test_" + strings.ToLower(util.RandomString(8))
+	_, err = testDB.Exec(context.Background(), "CREATE SCHEMA "+schema)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, err := testDB.Exec(context.Background(), "DROP SCHEMA "+schema+" CASCADE")
+		require.NoError(t, err)
+	})
+
+	source, err := url.Parse(config.DbSource)
+	require.NoError(t, err)
+	query := source.Query()
+	query.Set("search_path", schema)
+	source.RawQuery = query.Encode()
+
+	m, err := migrate.New("file://../migrations", source.String())
+	require.NoError(t, err)
+	t.Cleanup(func() { m.Close() })
+	require.NoError(t, m.Migrate(version))
+
+	pool, err := pgxpool.New(context.Background(), source.String())
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+	return m, pool
+}
+
+func TestAppointmentRecurrenceMigration(t *testing.T) {
+	m, pool := migrateSchema(t, 12)
+	ctx := context.Background()
+
+	var employeeID int64
+	err := pool.QueryRow(ctx, `
+		WITH u AS (
+			INSERT INTO custom_user (password, email) VALUES ('secret', $1) RETURNING id
+		)
+		INSERT INTO employee_profile (user_id, first_name, last_name, email)
+		SELECT id, 'Jan', 'Jansen', $1 FROM u
+		RETURNING id`, util.RandomEmail()).Scan(&employeeID)
+	require.NoError(t, err)
+
+	// series that were created a month and three years ago, each with the occurrences the
+	// old eager expansion stored without a link to the template
+	now := time.Now().UTC().Truncate(time.Second)
+	recent := now.AddDate(0, -1, 0)
+	old := now.AddDate(-3, 0, 0)
+	templates := map[uuid.UUID]time.Time{}
+	for _, created := range []time.Time{recent, old} {
+		var id uuid.UUID
+		err := pool.QueryRow(ctx, `
+			INSERT INTO appointment_templates (creator_employee_id, start_time, end_time, description, recurrence_type, recurrence_interval, created_at)
+			VALUES ($1, $2, $3, 'Weekly check-in', 'WEEKLY', 1, $2)
+			RETURNING id`, employeeID, created, created.Add(time.Hour)).Scan(&id)
+		require.NoError(t, err)
+		templates[id] = created
+
+		for week := 0; week < 104; week++ {
+			start := created.AddDate(0, 0, 7*week)
+			_, err := pool.Exec(ctx, `
+				INSERT INTO scheduled_appointments (creator_employee_id, start_time, end_time, description)
+				VALUES ($1, $2, $3, 'Weekly check-in')`, employeeID, start, start.Add(time.Hour))
+			require.NoError(t, err)
+		}
+	}
+
+	require.NoError(t, m.Up())
+	queries := New(pool)
+
+	for id, created := range templates {
+		template, err := queries.GetAppointmentTemplate(ctx, id)
+		require.NoError(t, err)
+		require.NotNil(t, template.Rrule)
+		require.Equal(t, "FREQ=WEEKLY", *template.Rrule)
+		require.True(t, template.MaterializedUntil.Valid)
+		require.True(t, created.AddDate(2, 0, 0).Add(time.Second).Equal(template.MaterializedUntil.Time), fmt.Sprintf("template created at %s", created))
+	}
+
+	// the series of a month ago is stored for the coming two years already
+	materialize, err := queries.ListAppointmentTemplatesToMaterialize(ctx, pgtype.Timestamp{Time: now.AddDate(0, 3, 0), Valid: true})
+	require.NoError(t, err)
+	require.Len(t, materialize, 1)
+	require.True(t, old.Equal(materialize[0].CreatedAt.Time))
+}
@@ -9,6 +9,23 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type AccountingExportBatch struct {
+	ID               int64              `json:"id"`
+	OrganisationID   int64              `json:"organisation_id"`
+	ExportFormat     string             `json:"export_format"`
+	StartDate        pgtype.Date        `json:"start_date"`
+	EndDate          pgtype.Date        `json:"end_date"`
+	SalesJournal     string             `json:"sales_journal"`
+	BankJournal      string             `json:"bank_journal"`
+	InvoiceCount     int32              `json:"invoice_count"`
+	PaymentCount     int32              `json:"payment_count"`
+	DownloadCount    int32              `json:"download_count"`
+	LastDownloadedAt pgtype.Timestamptz `json:"last_downloaded_at"`
+	LastDownloadedBy *int64             `json:"last_downloaded_by"`
+	CreatedBy        *int64             `json:"created_by"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+}
+
 type AiGeneratedReport struct {
 	ID         int64              `json:"id"`
 	ReportText string             `json:"report_text"`
@@ -52,18 +69,24 @@ type AppointmentParticipant struct {
 }
 
 type AppointmentTemplate struct {
-	ID                 uuid.UUID        `json:"id"`
-	CreatorEmployeeID  int64            `json:"creator_employee_id"`
-	StartTime          pgtype.Timestamp `json:"start_time"`
-	EndTime            pgtype.Timestamp `json:"end_time"`
-	Location           *string          `json:"location"`
-	Description        *string          `json:"description"`
-	Color              *string          `json:"color"`
-	RecurrenceType     *string          `json:"recurrence_type"`
-	RecurrenceInterval *int32           `json:"recurrence_interval"`
-	RecurrenceEndDate  pgtype.Date      `json:"recurrence_end_date"`
-	CreatedAt          pgtype.Timestamp `json:"created_at"`
-	UpdatedAt          pgtype.Timestamp `json:"updated_at"`
+	ID                     uuid.UUID          `json:"id"`
+	CreatorEmployeeID      int64              `json:"creator_employee_id"`
+	StartTime              pgtype.Timestamp   `json:"start_time"`
+	EndTime                pgtype.Timestamp   `json:"end_time"`
+	Location               *string            `json:"location"`
+	Description            *string            `json:"description"`
+	Color                  *string            `json:"color"`
+	RecurrenceType         *string            `json:"recurrence_type"`
+	RecurrenceInterval     *int32             `json:"recurrence_interval"`
+	RecurrenceEndDate      pgtype.Date        `json:"recurrence_end_date"`
+	CreatedAt              pgtype.Timestamp   `json:"created_at"`
+	UpdatedAt              pgtype.Timestamp   `json:"updated_at"`
+	Rrule                  *string            `json:"rrule"`
+	Exdates                []pgtype.Timestamp `json:"exdates"`
+	Rdates                 []pgtype.Timestamp `json:"rdates"`
+	ParticipantEmployeeIds []int64            `json:"participant_employee_ids"`
+	ClientIds              []int64            `json:"client_ids"`
+	MaterializedUntil      pgtype.Timestamp   `json:"materialized_until"`
 }
 
 type AssignedEmployee struct {
@@ -96,6 +119,47 @@ type AttachmentFile struct {
 	Created pgtype.Timestamptz `json:"created"`
 }
 
+type BankStatementImport struct {
+	ID                 int64              `json:"id"`
+	FileName           string             `json:"file_name"`
+	Format             string             `json:"format"`
+	AccountIban        *string            `json:"account_iban"`
+	StatementReference *string            `json:"statement_reference"`
+	TotalLines         int32              `json:"total_lines"`
+	MatchedLines       int32              `json:"matched_lines"`
+	ImportedBy         *int64             `json:"imported_by"`
+	CreatedAt          pgtype.Timestamptz `json:"created_at"`
+}
+
+type BankStatementLine struct {
+	ID                 int64              `json:"id"`
+	ImportID           int64              `json:"import_id"`
+	LineHash           string             `json:"line_hash"`
+	EntryReference     *string            `json:"entry_reference"`
+	BookingDate        pgtype.Date        `json:"booking_date"`
+	Amount             float64            `json:"amount"`
+	Currency           string             `json:"currency"`
+	CounterpartyName   *string            `json:"counterparty_name"`
+	CounterpartyIban   *string            `json:"counterparty_iban"`
+	RemittanceInfo     *string            `json:"remittance_info"`
+	Status             string             `json:"status"`
+	MatchMethod        *string            `json:"match_method"`
+	SuggestedInvoiceID *int64             `json:"suggested_invoice_id"`
+	InvoiceID          *int64             `json:"invoice_id"`
+	PaymentID          *int64             `json:"payment_id"`
+	ReviewNote         *string            `json:"review_note"`
+	ReviewedBy         *int64             `json:"reviewed_by"`
+	ReviewedAt         pgtype.Timestamptz `json:"reviewed_at"`
+	CreatedAt          pgtype.Timestamptz `json:"created_at"`
+}
+
+type CalendarFeedToken struct {
+	UserID     int64              `json:"user_id"`
+	TokenHash  string             `json:"token_hash"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	LastUsedAt pgtype.Timestamptz `json:"last_used_at"`
+}
+
 type CarePlan struct {
 	ID                    int64            `json:"id"`
 	AssessmentID          int64            `json:"assessment_id"`
@@ -418,29 +482,30 @@ type ContactRelationship struct {
 }
 
 type Contract struct {
-	ID              int64              `json:"id"`
-	TypeID          *int64             `json:"type_id"`
-	Status          string             `json:"status"`
-	ApprovedAt      pgtype.Timestamptz `json:"approved_at"`
-	StartDate       pgtype.Timestamptz `json:"start_date"`
-	EndDate         pgtype.Timestamptz `json:"end_date"`
-	ReminderPeriod  int32              `json:"reminder_period"`
-	Vat             *int32             `json:"vat"`
-	Price           float64            `json:"price"`
-	PriceTimeUnit   string             `json:"price_time_unit"`
-	Hours           *float64           `json:"hours"`
-	HoursType       *string            `json:"hours_type"`
-	CareName        string             `json:"care_name"`
-	CareType        string             `json:"care_type"`
-	ClientID        int64              `json:"client_id"`
-	SenderID        *int64             `json:"sender_id"`
-	AttachmentIds   []uuid.UUID        `json:"attachment_ids"`
-	FinancingAct    string             `json:"financing_act"`
-	FinancingOption string             `json:"financing_option"`
-	DepartureReason *string            `json:"departure_reason"`
-	DepartureReport *string            `json:"departure_report"`
-	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
-	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	ID               int64              `json:"id"`
+	TypeID           *int64             `json:"type_id"`
+	Status           string             `json:"status"`
+	ApprovedAt       pgtype.Timestamptz `json:"approved_at"`
+	StartDate        pgtype.Timestamptz `json:"start_date"`
+	EndDate          pgtype.Timestamptz `json:"end_date"`
+	ReminderPeriod   int32              `json:"reminder_period"`
+	Vat              *int32             `json:"vat"`
+	Price            float64            `json:"price"`
+	PriceTimeUnit    string             `json:"price_time_unit"`
+	Hours            *float64           `json:"hours"`
+	HoursType        *string            `json:"hours_type"`
+	CareName         string             `json:"care_name"`
+	CareType         string             `json:"care_type"`
+	ClientID         int64              `json:"client_id"`
+	SenderID         *int64             `json:"sender_id"`
+	AttachmentIds    []uuid.UUID        `json:"attachment_ids"`
+	FinancingAct     string             `json:"financing_act"`
+	FinancingOption  string             `json:"financing_option"`
+	DepartureReason  *string            `json:"departure_reason"`
+	DepartureReport  *string            `json:"departure_report"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	IndicationNumber *string            `json:"indication_number"`
 }
 
 type ContractAttachment struct {
@@ -462,6 +527,16 @@ type ContractAudit struct {
 	ChangedFields []string           `json:"changed_fields"`
 }
 
+type ContractBudgetAlert struct {
+	ID                int64              `json:"id"`
+	ContractID        int64              `json:"contract_id"`
+	Threshold         int32              `json:"threshold"`
+	PeriodStart       pgtype.Date        `json:"period_start"`
+	AuthorizedMinutes float64            `json:"authorized_minutes"`
+	ConsumedMinutes   float64            `json:"consumed_minutes"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+}
+
 type ContractReminder struct {
 	ID             int64              `json:"id"`
 	ContractID     int64              `json:"contract_id"`
@@ -469,6 +544,27 @@ type ContractReminder struct {
 	ReminderType   string             `json:"reminder_type"`
 }
 
+type ContractRenewal struct {
+	ID                   int64              `json:"id"`
+	PredecessorID        int64              `json:"predecessor_id"`
+	SuccessorID          int64              `json:"successor_id"`
+	PriceIndexPercentage *float64           `json:"price_index_percentage"`
+	ClosedAt             pgtype.Timestamptz `json:"closed_at"`
+	CreatedBy            *int64             `json:"created_by"`
+	CreatedAt            pgtype.Timestamptz `json:"created_at"`
+}
+
+type ContractStatusPeriod struct {
+	ID            int64              `json:"id"`
+	ContractID    int64              `json:"contract_id"`
+	Status        string             `json:"status"`
+	EffectiveFrom pgtype.Timestamptz `json:"effective_from"`
+	EffectiveTo   pgtype.Timestamptz `json:"effective_to"`
+	Reason        *string            `json:"reason"`
+	CreatedBy     *int64             `json:"created_by"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+}
+
 type ContractType struct {
 	ID   int64  `json:"id"`
 	Name string `json:"name"`
@@ -575,6 +671,33 @@ type EmployeeProfile struct {
 	ContractRate              *float64           `json:"contract_rate"`
 }
 
+type EmployeeShiftPreference struct {
+	EmployeeID             int64              `json:"employee_id"`
+	PreferredLocationIds   []int64            `json:"preferred_location_ids"`
+	PreferredShiftNames    []string           `json:"preferred_shift_names"`
+	MaxNightShiftsPerMonth *int32             `json:"max_night_shifts_per_month"`
+	Notes                  *string            `json:"notes"`
+	UpdatedAt              pgtype.Timestamptz `json:"updated_at"`
+}
+
+type EmployeeUnavailability struct {
+	ID            int64              `json:"id"`
+	EmployeeID    int64              `json:"employee_id"`
+	StartDatetime pgtype.Timestamp   `json:"start_datetime"`
+	EndDatetime   pgtype.Timestamp   `json:"end_datetime"`
+	Reason        *string            `json:"reason"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+}
+
+type EmployeeWeeklyAvailability struct {
+	ID         int64              `json:"id"`
+	EmployeeID int64              `json:"employee_id"`
+	Weekday    int32              `json:"weekday"`
+	StartTime  pgtype.Time        `json:"start_time"`
+	EndTime    pgtype.Time        `json:"end_time"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
 type FrameworkAgreement struct {
 	ID               int64              `json:"id"`
 	ClientID         int64              `json:"client_id"`
@@ -718,6 +841,13 @@ type Invoice struct {
 	CreatedAt         pgtype.Timestamptz `json:"created_at"`
 }
 
+type InvoiceApprovalSetting struct {
+	OrganisationID    int64              `json:"organisation_id"`
+	ApprovalThreshold float64            `json:"approval_threshold"`
+	UpdatedBy         *int64             `json:"updated_by"`
+	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
+}
+
 type InvoiceAudit struct {
 	AuditID       int64              `json:"audit_id"`
 	InvoiceID     int64              `json:"invoice_id"`
@@ -741,6 +871,32 @@ type InvoiceContract struct {
 	Created     pgtype.Timestamptz `json:"created"`
 }
 
+type InvoiceDelivery struct {
+	ID           int64              `json:"id"`
+	InvoiceID    int64              `json:"invoice_id"`
+	BatchID      *uuid.UUID         `json:"batch_id"`
+	Recipients   []string           `json:"recipients"`
+	IsReminder   bool               `json:"is_reminder"`
+	Status       string             `json:"status"`
+	MessageID    *string            `json:"message_id"`
+	ErrorMessage *string            `json:"error_message"`
+	SentBy       *int64             `json:"sent_by"`
+	SentAt       pgtype.Timestamptz `json:"sent_at"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
+type InvoiceNumberSeries struct {
+	OrganisationID  int64              `json:"organisation_id"`
+	Prefix          string             `json:"prefix"`
+	NumberFormat    string             `json:"number_format"`
+	SequencePadding int32              `json:"sequence_padding"`
+	ResetPeriod     string             `json:"reset_period"`
+	SequenceYear    *int32             `json:"sequence_year"`
+	LastSequence    int64              `json:"last_sequence"`
+	UpdatedBy       *int64             `json:"updated_by"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+}
+
 type InvoicePaymentHistory struct {
 	ID               int64              `json:"id"`
 	InvoiceID        int64              `json:"invoice_id"`
@@ -755,6 +911,57 @@ type InvoicePaymentHistory struct {
 	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
 }
 
+type LeaveBalanceAdjustment struct {
+	ID                  int64              `json:"id"`
+	EmployeeID          int64              `json:"employee_id"`
+	Year                int32              `json:"year"`
+	Hours               float64            `json:"hours"`
+	Reason              string             `json:"reason"`
+	CreatedByEmployeeID *int64             `json:"created_by_employee_id"`
+	CreatedAt           pgtype.Timestamptz `json:"created_at"`
+}
+
+type LeaveRequest struct {
+	ID                   int64              `json:"id"`
+	EmployeeID           int64              `json:"employee_id"`
+	LeaveTypeID          int64              `json:"leave_type_id"`
+	StartDate            pgtype.Date        `json:"start_date"`
+	EndDate              pgtype.Date        `json:"end_date"`
+	Hours                float64            `json:"hours"`
+	Reason               *string            `json:"reason"`
+	Status               string             `json:"status"`
+	ReviewNote           *string            `json:"review_note"`
+	ReviewedByEmployeeID *int64             `json:"reviewed_by_employee_id"`
+	ReviewedAt           pgtype.Timestamptz `json:"reviewed_at"`
+	CreatedAt            pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt            pgtype.Timestamptz `json:"updated_at"`
+}
+
+type LeaveType struct {
+	ID             int64              `json:"id"`
+	Code           string             `json:"code"`
+	Name           string             `json:"name"`
+	Paid           bool               `json:"paid"`
+	DeductsBalance bool               `json:"deducts_balance"`
+	IsActive       bool               `json:"is_active"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+}
+
+type LedgerAccountMapping struct {
+	ID             int64              `json:"id"`
+	OrganisationID int64              `json:"organisation_id"`
+	AccountType    string             `json:"account_type"`
+	CareType       *string            `json:"care_type"`
+	FinancingAct   *string            `json:"financing_act"`
+	VatRate        *float64           `json:"vat_rate"`
+	AccountCode    string             `json:"account_code"`
+	VatCode        *string            `json:"vat_code"`
+	Description    *string            `json:"description"`
+	UpdatedBy      *int64             `json:"updated_by"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+}
+
 type LevelHistory struct {
 	ID                               int64              `json:"id"`
 	ClientMaturityMatrixAssessmentID int64              `json:"client_maturity_matrix_assessment_id"`
@@ -775,6 +982,13 @@ type Location struct {
 	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
 }
 
+type LocationClockSetting struct {
+	LocationID int64              `json:"location_id"`
+	IpRanges   []string           `json:"ip_ranges"`
+	QrToken    *string            `json:"qr_token"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+}
+
 type LocationShift struct {
 	ID         int64            `json:"id"`
 	LocationID int64            `json:"location_id"`
@@ -814,6 +1028,7 @@ type Organisation struct {
 	BtwNumber   *string            `json:"btw_number"`
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	Iban        *string            `json:"iban"`
 }
 
 type Permission struct {
@@ -992,9 +1207,33 @@ type Room struct {
 	UpdatedAt  pgtype.Timestamp `json:"updated_at"`
 }
 
+type RosterDraft struct {
+	ID                    int64              `json:"id"`
+	LocationID            int64              `json:"location_id"`
+	Year                  int32              `json:"year"`
+	Month                 int32              `json:"month"`
+	Status                string             `json:"status"`
+	Requirements          []byte             `json:"requirements"`
+	CreatedByEmployeeID   *int64             `json:"created_by_employee_id"`
+	CreatedAt             pgtype.Timestamptz `json:"created_at"`
+	PublishedByEmployeeID *int64             `json:"published_by_employee_id"`
+	PublishedAt           pgtype.Timestamptz `json:"published_at"`
+}
+
+type RosterDraftAssignment struct {
+	ID              int64            `json:"id"`
+	DraftID         int64            `json:"draft_id"`
+	LocationShiftID int64            `json:"location_shift_id"`
+	EmployeeID      *int64           `json:"employee_id"`
+	StartDatetime   pgtype.Timestamp `json:"start_datetime"`
+	EndDatetime     pgtype.Timestamp `json:"end_datetime"`
+	UnfilledReason  *string          `json:"unfilled_reason"`
+	ScheduleID      *uuid.UUID       `json:"schedule_id"`
+}
+
 type Schedule struct {
 	ID                  uuid.UUID        `json:"id"`
-	EmployeeID          int64            `json:"employee_id"`
+	EmployeeID          *int64           `json:"employee_id"`
 	Color               *string          `json:"color"`
 	LocationID          int64            `json:"location_id"`
 	LocationShiftID     *int64           `json:"location_shift_id"`
@@ -1021,6 +1260,8 @@ type ScheduledAppointment struct {
 	ConfirmedAt            pgtype.Timestamp `json:"confirmed_at"`
 	CreatedAt              pgtype.Timestamp `json:"created_at"`
 	UpdatedAt              pgtype.Timestamp `json:"updated_at"`
+	RecurrenceID           pgtype.Timestamp `json:"recurrence_id"`
+	IsException            bool             `json:"is_exception"`
 }
 
 type ScheduledStatusChange struct {
@@ -1032,6 +1273,32 @@ type ScheduledStatusChange struct {
 	CreatedAt     pgtype.Timestamptz `json:"created_at"`
 }
 
+type SelfBillingStatement struct {
+	ID              int64              `json:"id"`
+	StatementNumber string             `json:"statement_number"`
+	EmployeeID      int64              `json:"employee_id"`
+	OrganisationID  int64              `json:"organisation_id"`
+	EmployeeName    string             `json:"employee_name"`
+	PeriodStart     pgtype.Date        `json:"period_start"`
+	PeriodEnd       pgtype.Date        `json:"period_end"`
+	Hours           float64            `json:"hours"`
+	Rate            float64            `json:"rate"`
+	Subtotal        float64            `json:"subtotal"`
+	VatRate         float64            `json:"vat_rate"`
+	VatAmount       float64            `json:"vat_amount"`
+	TotalAmount     float64            `json:"total_amount"`
+	Lines           []byte             `json:"lines"`
+	Status          string             `json:"status"`
+	RejectionReason *string            `json:"rejection_reason"`
+	ApprovedBy      *int64             `json:"approved_by"`
+	ApprovedAt      pgtype.Timestamptz `json:"approved_at"`
+	ExportedBy      *int64             `json:"exported_by"`
+	ExportedAt      pgtype.Timestamptz `json:"exported_at"`
+	CreatedBy       *int64             `json:"created_by"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+}
+
 type Sender struct {
 	ID              int64              `json:"id"`
 	Types           string             `json:"types"`
@@ -1069,6 +1336,54 @@ type Session struct {
 	UserID       int64              `json:"user_id"`
 }
 
+type ShiftSwapRequest struct {
+	ID                   int64              `json:"id"`
+	ScheduleID           uuid.UUID          `json:"schedule_id"`
+	RequesterEmployeeID  int64              `json:"requester_employee_id"`
+	TargetEmployeeID     int64              `json:"target_employee_id"`
+	TargetScheduleID     *uuid.UUID         `json:"target_schedule_id"`
+	Message              *string            `json:"message"`
+	Status               string             `json:"status"`
+	RespondedAt          pgtype.Timestamptz `json:"responded_at"`
+	ReviewNote           *string            `json:"review_note"`
+	ReviewedByEmployeeID *int64             `json:"reviewed_by_employee_id"`
+	ReviewedAt           pgtype.Timestamptz `json:"reviewed_at"`
+	CreatedAt            pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt            pgtype.Timestamptz `json:"updated_at"`
+}
+
+type SickReport struct {
+	ID                   int64              `json:"id"`
+	EmployeeID           int64              `json:"employee_id"`
+	StartDate            pgtype.Date        `json:"start_date"`
+	ExpectedReturnDate   pgtype.Date        `json:"expected_return_date"`
+	RecoveredOn          pgtype.Date        `json:"recovered_on"`
+	Notes                *string            `json:"notes"`
+	ReportedByEmployeeID *int64             `json:"reported_by_employee_id"`
+	CreatedAt            pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt            pgtype.Timestamptz `json:"updated_at"`
+}
+
+type SickReportMilestone struct {
+	SickReportID          int64              `json:"sick_report_id"`
+	Milestone             string             `json:"milestone"`
+	CompletedOn           pgtype.Date        `json:"completed_on"`
+	Notes                 *string            `json:"notes"`
+	CompletedByEmployeeID *int64             `json:"completed_by_employee_id"`
+	CreatedAt             pgtype.Timestamptz `json:"created_at"`
+}
+
+type StaffingNorm struct {
+	ID              int64              `json:"id"`
+	LocationID      int64              `json:"location_id"`
+	LocationShiftID int64              `json:"location_shift_id"`
+	Weekday         *int16             `json:"weekday"`
+	MinStaff        int32              `json:"min_staff"`
+	Certifications  []byte             `json:"certifications"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+}
+
 type TemplateItem struct {
 	ID           int64  `json:"id"`
 	ItemTag      string `json:"item_tag"`
@@ -1083,6 +1398,34 @@ type TemporaryFile struct {
 	UploadedAt pgtype.Timestamptz `json:"uploaded_at"`
 }
 
+type TimeEntry struct {
+	ID                   int64              `json:"id"`
+	ScheduleID           uuid.UUID          `json:"schedule_id"`
+	EmployeeID           int64              `json:"employee_id"`
+	ClockInAt            pgtype.Timestamp   `json:"clock_in_at"`
+	ClockInMethod        string             `json:"clock_in_method"`
+	ClockInIp            *string            `json:"clock_in_ip"`
+	ClockOutAt           pgtype.Timestamp   `json:"clock_out_at"`
+	ClockOutMethod       *string            `json:"clock_out_method"`
+	ClockOutIp           *string            `json:"clock_out_ip"`
+	ActualStart          pgtype.Timestamp   `json:"actual_start"`
+	ActualEnd            pgtype.Timestamp   `json:"actual_end"`
+	BreakMinutes         int32              `json:"break_minutes"`
+	Status               string             `json:"status"`
+	ReviewNote           *string            `json:"review_note"`
+	ReviewedByEmployeeID *int64             `json:"reviewed_by_employee_id"`
+	ReviewedAt           pgtype.Timestamptz `json:"reviewed_at"`
+	CreatedAt            pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt            pgtype.Timestamptz `json:"updated_at"`
+}
+
+type TimeEntryBreak struct {
+	ID          int64            `json:"id"`
+	TimeEntryID int64            `json:"time_entry_id"`
+	StartAt     pgtype.Timestamp `json:"start_at"`
+	EndAt       pgtype.Timestamp `json:"end_at"`
+}
+
 type UserPermission struct {
 	UserID       int64 `json:"user_id"`
 	PermissionID int32 `json:"permission_id"`
@@ -1093,6 +1436,21 @@ type UserRole struct {
 	RoleID int32 `json:"role_id"`
 }
 
+type WorkingTimeRule struct {
+	ContractType                   string             `json:"contract_type"`
+	Exempt                         bool               `json:"exempt"`
+	MaxShiftMinutes                int32              `json:"max_shift_minutes"`
+	MaxWeekMinutes                 int32              `json:"max_week_minutes"`
+	MaxAverageWeekMinutes          int32              `json:"max_average_week_minutes"`
+	MinDailyRestMinutes            int32              `json:"min_daily_rest_minutes"`
+	MinWeeklyRestMinutes           int32              `json:"min_weekly_rest_minutes"`
+	MaxNightShiftMinutes           int32              `json:"max_night_shift_minutes"`
+	MaxConsecutiveNightShifts      int32              `json:"max_consecutive_night_shifts"`
+	MaxNightShifts16Weeks          int32              `json:"max_night_shifts_16_weeks"`
+	MinRestAfterNightSeriesMinutes int32              `json:"min_rest_after_night_series_minutes"`
+	UpdatedAt                      pgtype.Timestamptz `json:"updated_at"`
+}
+
 type YouthCareIntake struct {
 	ID                          int64              `json:"id"`
 	ClientID                    int64              `json:"client_id"`
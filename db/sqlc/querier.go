@@ -8,9 +8,13 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Querier interface {
+	AddAccountingExportInvoices(ctx context.Context, arg AddAccountingExportInvoicesParams) error
+	AddAccountingExportPayments(ctx context.Context, arg AddAccountingExportPaymentsParams) error
+	AddAppointmentTemplateExdate(ctx context.Context, arg AddAppointmentTemplateExdateParams) error
 	AddEducationToEmployeeProfile(ctx context.Context, arg AddEducationToEmployeeProfileParams) (EmployeeEducation, error)
 	AddEmployeeCertification(ctx context.Context, arg AddEmployeeCertificationParams) (Certification, error)
 	AddEmployeeContractDetails(ctx context.Context, arg AddEmployeeContractDetailsParams) (EmployeeProfile, error)
@@ -18,29 +22,49 @@ type Querier interface {
 	// Bulk-insert permission IDs into a role (idempotent).
 	AddPermissionsToRole(ctx context.Context, arg AddPermissionsToRoleParams) error
 	AddUrgencyScore(ctx context.Context, arg AddUrgencyScoreParams) (IntakeForm, error)
+	// Takes the next number of the series of an organisation. The row stays locked until the
+	// surrounding transaction ends, so the invoice has to be created in the same transaction.
+	AllocateInvoiceNumber(ctx context.Context, arg AllocateInvoiceNumberParams) (InvoiceNumberSeries, error)
+	ApproveSelfBillingStatement(ctx context.Context, arg ApproveSelfBillingStatementParams) (SelfBillingStatement, error)
 	// Select the columns from the inserted row AND join to get the user_id
 	AssignEmployee(ctx context.Context, arg AssignEmployeeParams) (AssignEmployeeRow, error)
 	AssignRoleToUser(ctx context.Context, arg AssignRoleToUserParams) error
+	// Moves a schedule to another employee, or opens it when employee_id is null. Nothing is
+	// returned when the schedule no longer belongs to current_employee_id, null for an open
+	// shift, so that a shift is never claimed or swapped twice.
+	AssignSchedule(ctx context.Context, arg AssignScheduleParams) (Schedule, error)
 	AssignSender(ctx context.Context, arg AssignSenderParams) (ClientDetail, error)
 	// The array of employee_id
 	BulkAddAppointmentClients(ctx context.Context, arg BulkAddAppointmentClientsParams) error
 	BulkAddAppointmentParticipants(ctx context.Context, arg BulkAddAppointmentParticipantsParams) error
+	CancelLeaveRequest(ctx context.Context, id int64) (LeaveRequest, error)
+	CancelShiftSwapRequest(ctx context.Context, id int64) (ShiftSwapRequest, error)
+	// Cancels the open swap requests of shifts that changed hands, and returns who was involved
+	CancelShiftSwapRequestsForSchedules(ctx context.Context, scheduleIds []uuid.UUID) ([]CancelShiftSwapRequestsForSchedulesRow, error)
 	// ---------- 6. CHECK UTILITIES ----------
 	// Returns true/false whether the user has the named permission.
 	CheckUserPermission(ctx context.Context, arg CheckUserPermissionParams) (bool, error)
 	ClientsOnWaitlist(ctx context.Context) (int64, error)
+	ClockOutTimeEntry(ctx context.Context, arg ClockOutTimeEntryParams) (TimeEntry, error)
+	CloseContractRenewal(ctx context.Context, id int64) error
+	CompleteSickReportMilestone(ctx context.Context, arg CompleteSickReportMilestoneParams) (SickReportMilestone, error)
 	ConfirmAppointment(ctx context.Context, arg ConfirmAppointmentParams) error
 	ConfirmIncident(ctx context.Context, id int64) (ConfirmIncidentRow, error)
 	ContractEndCount(ctx context.Context) (int64, error)
 	CountAllIncidents(ctx context.Context, isConfirmed bool) (int64, error)
 	CountEmployeeProfile(ctx context.Context, arg CountEmployeeProfileParams) (int64, error)
+	CountOverlappingRosterDraftAssignments(ctx context.Context, arg CountOverlappingRosterDraftAssignmentsParams) (int64, error)
 	CountRegistrationForms(ctx context.Context, arg CountRegistrationFormsParams) (int64, error)
 	CountSenders(ctx context.Context, includeArchived *bool) (int64, error)
+	CreateAccountingExportBatch(ctx context.Context, arg CreateAccountingExportBatchParams) (AccountingExportBatch, error)
 	CreateAiGeneratedReport(ctx context.Context, arg CreateAiGeneratedReportParams) (AiGeneratedReport, error)
 	CreateAppointment(ctx context.Context, arg CreateAppointmentParams) (ScheduledAppointment, error)
 	CreateAppointmentCard(ctx context.Context, arg CreateAppointmentCardParams) (AppointmentCard, error)
 	CreateAppointmentTemplate(ctx context.Context, arg CreateAppointmentTemplateParams) (AppointmentTemplate, error)
 	CreateAttachment(ctx context.Context, arg CreateAttachmentParams) (AttachmentFile, error)
+	CreateBankStatementImport(ctx context.Context, arg CreateBankStatementImportParams) (BankStatementImport, error)
+	// Lines that were imported before (same hash) are skipped, no row is returned for them.
+	CreateBankStatementLine(ctx context.Context, arg CreateBankStatementLineParams) (BankStatementLine, error)
 	// ==================== new code    ====================
 	CreateCarePlan(ctx context.Context, arg CreateCarePlanParams) (CarePlan, error)
 	CreateCarePlanAction(ctx context.Context, arg CreateCarePlanActionParams) (CarePlanAction, error)
@@ -65,13 +89,27 @@ type Querier interface {
 	CreateClientMedication(ctx context.Context, arg CreateClientMedicationParams) (ClientMedication, error)
 	CreateClientStatusHistory(ctx context.Context, arg CreateClientStatusHistoryParams) (ClientStatusHistory, error)
 	CreateContract(ctx context.Context, arg CreateContractParams) (Contract, error)
+	// Returns no rows when the alert was already sent for the period.
+	CreateContractBudgetAlert(ctx context.Context, arg CreateContractBudgetAlertParams) (ContractBudgetAlert, error)
 	CreateContractReminder(ctx context.Context, arg CreateContractReminderParams) (ContractReminder, error)
+	CreateContractRenewal(ctx context.Context, arg CreateContractRenewalParams) (ContractRenewal, error)
+	CreateContractStatusPeriod(ctx context.Context, arg CreateContractStatusPeriodParams) (ContractStatusPeriod, error)
 	CreateContractType(ctx context.Context, name string) (ContractType, error)
+	CreateContractWorkingHours(ctx context.Context, arg CreateContractWorkingHoursParams) (ContractWorkingHour, error)
+	CreateCreditNote(ctx context.Context, arg CreateCreditNoteParams) (Invoice, error)
 	CreateEmemrgencyContact(ctx context.Context, arg CreateEmemrgencyContactParams) (ClientEmergencyContact, error)
 	CreateEmployeeProfile(ctx context.Context, arg CreateEmployeeProfileParams) (EmployeeProfile, error)
+	CreateEmployeeUnavailability(ctx context.Context, arg CreateEmployeeUnavailabilityParams) (EmployeeUnavailability, error)
+	CreateEmployeeWeeklyAvailability(ctx context.Context, arg CreateEmployeeWeeklyAvailabilityParams) error
 	CreateIncident(ctx context.Context, arg CreateIncidentParams) (CreateIncidentRow, error)
 	CreateIntakeForm(ctx context.Context, arg CreateIntakeFormParams) (IntakeForm, error)
 	CreateInvoice(ctx context.Context, arg CreateInvoiceParams) (Invoice, error)
+	CreateInvoiceApprovalEvent(ctx context.Context, arg CreateInvoiceApprovalEventParams) (InvoiceAudit, error)
+	CreateInvoiceDelivery(ctx context.Context, arg CreateInvoiceDeliveryParams) (InvoiceDelivery, error)
+	CreateLeaveBalanceAdjustment(ctx context.Context, arg CreateLeaveBalanceAdjustmentParams) (LeaveBalanceAdjustment, error)
+	CreateLeaveRequest(ctx context.Context, arg CreateLeaveRequestParams) (LeaveRequest, error)
+	CreateLeaveType(ctx context.Context, arg CreateLeaveTypeParams) (LeaveType, error)
+	CreateLedgerAccountMapping(ctx context.Context, arg CreateLedgerAccountMappingParams) (LedgerAccountMapping, error)
 	CreateLocation(ctx context.Context, arg CreateLocationParams) (Location, error)
 	CreateNotification(ctx context.Context, arg CreateNotificationParams) (Notification, error)
 	CreateOrganisation(ctx context.Context, arg CreateOrganisationParams) (Organisation, error)
@@ -82,19 +120,29 @@ type Querier interface {
 	// ---------- 1. ROLES ----------
 	// Insert a new role and return the created row.
 	CreateRole(ctx context.Context, name string) (Role, error)
+	CreateRosterDraft(ctx context.Context, arg CreateRosterDraftParams) (RosterDraft, error)
+	// employee ID 0 and an empty reason are stored as NULL
+	CreateRosterDraftAssignments(ctx context.Context, arg CreateRosterDraftAssignmentsParams) error
 	CreateSchedueledClientStatusChange(ctx context.Context, arg CreateSchedueledClientStatusChangeParams) (ScheduledStatusChange, error)
 	CreateSchedule(ctx context.Context, arg CreateScheduleParams) (CreateScheduleRow, error)
 	CreateSender(ctx context.Context, arg CreateSenderParams) (Sender, error)
 	CreateSenderInvoiceTemplate(ctx context.Context, arg CreateSenderInvoiceTemplateParams) ([]int64, error)
+	// Occurrences that already exist, also when they were moved or overridden, are left alone
+	CreateSeriesOccurrence(ctx context.Context, arg CreateSeriesOccurrenceParams) (uuid.UUID, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
 	CreateShift(ctx context.Context, arg CreateShiftParams) (LocationShift, error)
+	CreateShiftSwapRequest(ctx context.Context, arg CreateShiftSwapRequestParams) (ShiftSwapRequest, error)
+	CreateSickReport(ctx context.Context, arg CreateSickReportParams) (SickReport, error)
+	CreateStaffingNorm(ctx context.Context, arg CreateStaffingNormParams) (StaffingNorm, error)
 	CreateTemp2FaSecret(ctx context.Context, arg CreateTemp2FaSecretParams) error
+	CreateTimeEntry(ctx context.Context, arg CreateTimeEntryParams) (TimeEntry, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (CustomUser, error)
 	DeleteAppointment(ctx context.Context, id uuid.UUID) error
 	DeleteAppointmentClients(ctx context.Context, appointmentID uuid.UUID) error
 	DeleteAppointmentParticipants(ctx context.Context, appointmentID uuid.UUID) error
 	DeleteAssignedEmployee(ctx context.Context, id int64) (AssignedEmployee, error)
 	DeleteAttachment(ctx context.Context, argUuid uuid.UUID) (AttachmentFile, error)
+	DeleteCalendarFeedToken(ctx context.Context, userID int64) (int64, error)
 	DeleteCarePlan(ctx context.Context, id int64) error
 	DeleteCarePlanAction(ctx context.Context, id int64) error
 	DeleteCarePlanIntervention(ctx context.Context, id int64) error
@@ -107,26 +155,40 @@ type Querier interface {
 	DeleteClientDiagnosis(ctx context.Context, id int64) (ClientDiagnosis, error)
 	DeleteClientDocument(ctx context.Context, attachmentUuid *uuid.UUID) (ClientDocument, error)
 	DeleteClientMedication(ctx context.Context, id int64) error
+	DeleteContractStatusPeriods(ctx context.Context, contractID int64) error
 	DeleteContractType(ctx context.Context, id int64) error
+	DeleteContractWorkingHours(ctx context.Context, arg DeleteContractWorkingHoursParams) (ContractWorkingHour, error)
 	DeleteEmergencyContact(ctx context.Context, id int64) (ClientEmergencyContact, error)
 	DeleteEmployeeCertification(ctx context.Context, id int64) (Certification, error)
 	DeleteEmployeeEducation(ctx context.Context, id int64) (EmployeeEducation, error)
 	DeleteEmployeeExperience(ctx context.Context, id int64) (EmployeeExperience, error)
+	DeleteEmployeeUnavailability(ctx context.Context, arg DeleteEmployeeUnavailabilityParams) (EmployeeUnavailability, error)
+	DeleteEmployeeWeeklyAvailability(ctx context.Context, employeeID int64) error
 	DeleteIncident(ctx context.Context, id int64) error
 	DeleteInvoice(ctx context.Context, id int64) error
+	DeleteLedgerAccountMappings(ctx context.Context, organisationID int64) error
 	DeleteLocation(ctx context.Context, id int64) (Location, error)
 	DeleteOrganisation(ctx context.Context, id int64) (Organisation, error)
 	DeletePayment(ctx context.Context, id int64) (InvoicePaymentHistory, error)
 	DeleteProgressReport(ctx context.Context, id int64) error
 	DeleteRegistrationForm(ctx context.Context, id int64) error
+	DeleteRosterDraft(ctx context.Context, id int64) (int64, error)
 	DeleteSchedule(ctx context.Context, id uuid.UUID) error
 	DeleteSender(ctx context.Context, id int64) error
+	// Removes the occurrences of a series from a place in the series on, all of them when
+	// from is NULL. Occurrences that have started are kept.
+	DeleteSeriesOccurrencesFrom(ctx context.Context, arg DeleteSeriesOccurrencesFromParams) (int64, error)
 	DeleteSession(ctx context.Context, id uuid.UUID) error
 	DeleteShift(ctx context.Context, id int64) error
+	DeleteStaffingNorm(ctx context.Context, arg DeleteStaffingNormParams) (int64, error)
 	// Removes *all* permissions from the given user.
 	DeleteUserPermissions(ctx context.Context, userID int64) error
 	DischargeOverview(ctx context.Context, arg DischargeOverviewParams) ([]DischargeOverviewRow, error)
 	Enable2Fa(ctx context.Context, arg Enable2FaParams) error
+	EndTimeEntryBreak(ctx context.Context, arg EndTimeEntryBreakParams) (TimeEntryBreak, error)
+	// Expires the renewed contracts whose expired period on the status timeline has started
+	ExpireRenewedContracts(ctx context.Context) ([]int64, error)
+	GetAccountingExportBatch(ctx context.Context, id int64) (AccountingExportBatch, error)
 	GetAiGeneratedReport(ctx context.Context, id int64) (AiGeneratedReport, error)
 	GetAllAdminUsers(ctx context.Context) ([]CustomUser, error)
 	GetAllClientsIDs(ctx context.Context) ([]int64, error)
@@ -137,10 +199,15 @@ type Querier interface {
 	GetAppointmentParticipants(ctx context.Context, appointmentIds []uuid.UUID) ([]GetAppointmentParticipantsRow, error)
 	// The array of client_ids
 	GetAppointmentTemplate(ctx context.Context, id uuid.UUID) (AppointmentTemplate, error)
+	GetAppointmentTemplateForUpdate(ctx context.Context, id uuid.UUID) (AppointmentTemplate, error)
 	GetAssignedEmployee(ctx context.Context, id int64) (GetAssignedEmployeeRow, error)
 	GetAttachmentById(ctx context.Context, argUuid uuid.UUID) (AttachmentFile, error)
-	// Deduplicate identical status within 1 second windows
+	GetBankStatementLine(ctx context.Context, id int64) (BankStatementLine, error)
+	// Approved periods of the status timeline clipped to the invoice period. Suspensions
+	// split an approved period, so they are left out.
 	GetBillablePeriodsForContract(ctx context.Context, arg GetBillablePeriodsForContractParams) ([]GetBillablePeriodsForContractRow, error)
+	GetCalendarFeedToken(ctx context.Context, userID int64) (CalendarFeedToken, error)
+	GetCalendarFeedTokenByHash(ctx context.Context, tokenHash string) (CalendarFeedToken, error)
 	GetCarePlanActionsMaxSortOrder(ctx context.Context, objectiveID int64) (int32, error)
 	GetCarePlanInterventions(ctx context.Context, carePlanID int64) ([]CarePlanIntervention, error)
 	GetCarePlanObjectivesWithActions(ctx context.Context, carePlanID int64) ([]GetCarePlanObjectivesWithActionsRow, error)
@@ -155,11 +222,27 @@ type Querier interface {
 	GetClientCounts(ctx context.Context) (GetClientCountsRow, error)
 	GetClientDetails(ctx context.Context, id int64) (GetClientDetailsRow, error)
 	GetClientDiagnosis(ctx context.Context, id int64) (ClientDiagnosis, error)
+	// The organisation that issues the invoices of a client, clients without an organisation
+	// fall back to the first organisation.
+	GetClientInvoiceOrganisationID(ctx context.Context, clientID int64) (int64, error)
 	GetClientMaturityMatrixAssessment(ctx context.Context, id int64) (GetClientMaturityMatrixAssessmentRow, error)
 	GetClientRelatedEmails(ctx context.Context, clientID int64) ([]string, error)
 	GetClientSender(ctx context.Context, id int64) (Sender, error)
 	GetCompletedPaymentSum(ctx context.Context, invoiceID int64) (float64, error)
 	GetContractAudit(ctx context.Context, contractID int64) ([]GetContractAuditRow, error)
+	// Ambulante minutes billed on a contract by the invoices that were not canceled. Credit
+	// notes that mirror a whole line subtract its minutes.
+	GetContractBilledMinutes(ctx context.Context, contractID int64) (float64, error)
+	// Care delivered in a period: finished appointments of the client that were not
+	// cancelled plus the minutes registered on the contract. An appointment counts for one
+	// contract only, the newest ambulante contract that is approved on the status timeline
+	// when it starts. Registered minutes that overlap a counted appointment are the same
+	// care and are not counted again.
+	GetContractConsumedMinutes(ctx context.Context, arg GetContractConsumedMinutesParams) (GetContractConsumedMinutesRow, error)
+	// Locks the contract while it is renewed or closed
+	GetContractForUpdate(ctx context.Context, id int64) (Contract, error)
+	GetContractRenewalByPredecessor(ctx context.Context, predecessorID int64) (ContractRenewal, error)
+	GetContractRenewalBySuccessor(ctx context.Context, successorID int64) (ContractRenewal, error)
 	GetDailySchedulesByLocation(ctx context.Context, arg GetDailySchedulesByLocationParams) ([]GetDailySchedulesByLocationRow, error)
 	GetEmergencyContact(ctx context.Context, id int64) (ClientEmergencyContact, error)
 	GetEmployeeContractDetails(ctx context.Context, id int64) (GetEmployeeContractDetailsRow, error)
@@ -167,15 +250,29 @@ type Querier interface {
 	GetEmployeeProfileByID(ctx context.Context, id int64) (GetEmployeeProfileByIDRow, error)
 	GetEmployeeProfileByUserID(ctx context.Context, id int64) (GetEmployeeProfileByUserIDRow, error)
 	GetEmployeeSchedules(ctx context.Context, arg GetEmployeeSchedulesParams) ([]GetEmployeeSchedulesRow, error)
+	GetEmployeeShiftPreferences(ctx context.Context, employeeID int64) (EmployeeShiftPreference, error)
+	// Totals of a period, the open balance is taken at the end of the period.
+	GetFinanceSummary(ctx context.Context, arg GetFinanceSummaryParams) (GetFinanceSummaryRow, error)
 	GetIncident(ctx context.Context, id int64) (GetIncidentRow, error)
 	GetIntakeForm(ctx context.Context, id int64) (IntakeForm, error)
 	GetInvoice(ctx context.Context, id int64) (GetInvoiceRow, error)
+	// The employee that created the invoice and the one that last submitted it for approval.
+	GetInvoiceApprovalActors(ctx context.Context, id int64) (GetInvoiceApprovalActorsRow, error)
+	GetInvoiceApprovalSettings(ctx context.Context, organisationID int64) (InvoiceApprovalSetting, error)
 	GetInvoiceAuditLogs(ctx context.Context, invoiceID int64) ([]GetInvoiceAuditLogsRow, error)
+	GetInvoiceCreditedAmount(ctx context.Context, originalInvoiceID *int64) (float64, error)
+	GetInvoiceDelivery(ctx context.Context, id int64) (InvoiceDelivery, error)
+	GetInvoiceIssuer(ctx context.Context, id int64) (Organisation, error)
+	GetInvoiceNumberSeries(ctx context.Context, organisationID int64) (InvoiceNumberSeries, error)
 	GetInvoiceSenderID(ctx context.Context, id int64) (*int64, error)
+	// Hours of leave that deducts from the balance, by the year the leave starts in
+	GetLeaveHoursTaken(ctx context.Context, arg GetLeaveHoursTakenParams) (GetLeaveHoursTakenRow, error)
+	GetLeaveRequest(ctx context.Context, id int64) (GetLeaveRequestRow, error)
+	GetLeaveType(ctx context.Context, id int64) (LeaveType, error)
 	GetLevelDescription(ctx context.Context, arg GetLevelDescriptionParams) (GetLevelDescriptionRow, error)
 	GetLocation(ctx context.Context, id int64) (Location, error)
+	GetLocationClockSettings(ctx context.Context, locationID int64) (LocationClockSetting, error)
 	GetMaturityMatrix(ctx context.Context, id int64) (MaturityMatrix, error)
-	GetMaxInvoiceSequenceForDate(ctx context.Context, date interface{}) (int64, error)
 	GetMedication(ctx context.Context, id int64) (GetMedicationRow, error)
 	GetMissingClientDocuments(ctx context.Context, clientID int64) ([]string, error)
 	GetMonthlySchedulesByLocation(ctx context.Context, arg GetMonthlySchedulesByLocationParams) ([]GetMonthlySchedulesByLocationRow, error)
@@ -186,17 +283,30 @@ type Querier interface {
 	GetProgressReport(ctx context.Context, id int64) (GetProgressReportRow, error)
 	GetProgressReportsByDateRange(ctx context.Context, arg GetProgressReportsByDateRangeParams) ([]ProgressReport, error)
 	GetRegistrationForm(ctx context.Context, id int64) (RegistrationForm, error)
+	GetRosterDraft(ctx context.Context, id int64) (RosterDraft, error)
+	GetRosterDraftAssignment(ctx context.Context, arg GetRosterDraftAssignmentParams) (RosterDraftAssignment, error)
+	GetRosterDraftForUpdate(ctx context.Context, id int64) (RosterDraft, error)
 	GetScheduleById(ctx context.Context, id uuid.UUID) (GetScheduleByIdRow, error)
+	GetScheduledAppointment(ctx context.Context, id uuid.UUID) (ScheduledAppointment, error)
 	GetScheduledAppointmentByID(ctx context.Context, id uuid.UUID) (GetScheduledAppointmentByIDRow, error)
+	GetSelfBillingStatement(ctx context.Context, id int64) (SelfBillingStatement, error)
 	GetSenderById(ctx context.Context, id int64) (Sender, error)
 	GetSenderContracts(ctx context.Context, senderID *int64) ([]Contract, error)
 	GetSenderInvoiceTemplate(ctx context.Context, id int64) ([]int64, error)
+	GetSeriesOccurrence(ctx context.Context, arg GetSeriesOccurrenceParams) (ScheduledAppointment, error)
 	GetSessionByID(ctx context.Context, id uuid.UUID) (Session, error)
 	GetShiftByID(ctx context.Context, id int64) (LocationShift, error)
+	// What decides which shifts an employee can take over: their locations and certifications
+	GetShiftMarketplaceEmployee(ctx context.Context, id int64) (GetShiftMarketplaceEmployeeRow, error)
+	GetShiftSwapRequest(ctx context.Context, id int64) (GetShiftSwapRequestRow, error)
 	GetShiftsByLocationID(ctx context.Context, locationID int64) ([]LocationShift, error)
+	GetSickReport(ctx context.Context, id int64) (GetSickReportRow, error)
+	GetStaffingNorm(ctx context.Context, arg GetStaffingNormParams) (StaffingNorm, error)
 	GetTemp2FaSecret(ctx context.Context, id int64) (*string, error)
 	GetTemplateItemsByIds(ctx context.Context, dollar_1 []int64) ([]int64, error)
 	GetTemplateItemsBySourceTable(ctx context.Context, dollar_1 []int64) ([]TemplateItem, error)
+	GetTimeEntry(ctx context.Context, id int64) (TimeEntry, error)
+	GetTimeEntryBySchedule(ctx context.Context, scheduleID uuid.UUID) (TimeEntry, error)
 	GetTotalPaidAmountByInvoice(ctx context.Context, invoiceID int64) (float64, error)
 	GetUserByEmail(ctx context.Context, email string) (GetUserByEmailRow, error)
 	GetUserByID(ctx context.Context, id int64) (GetUserByIDRow, error)
@@ -204,10 +314,15 @@ type Querier interface {
 	// ---------- 4. USER-ROLE MAPPING ----------
 	// Returns every role granted to a user.
 	GetUserRoles(ctx context.Context, userID int64) (Role, error)
+	GetWorkingTimeRules(ctx context.Context, contractType string) (WorkingTimeRule, error)
 	GrantRolePermissionsToUser(ctx context.Context, arg GrantRolePermissionsToUserParams) error
 	// Bulk-insert permission IDs for a user (idempotent).
 	GrantUserPermissions(ctx context.Context, arg GrantUserPermissionsParams) error
+	IgnoreBankStatementLine(ctx context.Context, arg IgnoreBankStatementLineParams) (BankStatementLine, error)
 	InsertIncoicePdfUrl(ctx context.Context, arg InsertIncoicePdfUrlParams) (*uuid.UUID, error)
+	ListAccountingExportBatchInvoices(ctx context.Context, batchID int64) ([]ListAccountingExportBatchInvoicesRow, error)
+	ListAccountingExportBatchPayments(ctx context.Context, batchID int64) ([]ListAccountingExportBatchPaymentsRow, error)
+	ListAccountingExportBatches(ctx context.Context, organisationID int64) ([]AccountingExportBatch, error)
 	ListAiGeneratedReports(ctx context.Context, arg ListAiGeneratedReportsParams) ([]ListAiGeneratedReportsRow, error)
 	ListAllIncidents(ctx context.Context, arg ListAllIncidentsParams) ([]ListAllIncidentsRow, error)
 	ListAllLocations(ctx context.Context) ([]Location, error)
@@ -217,8 +332,14 @@ type Querier interface {
 	// ---------- 3. ROLE-PERMISSION MAPPING ----------
 	// Returns all permissions attached to a single role.
 	ListAllRolePermissions(ctx context.Context, roleID int32) ([]ListAllRolePermissionsRow, error)
+	ListAppointmentTemplatesToMaterialize(ctx context.Context, horizon pgtype.Timestamp) ([]AppointmentTemplate, error)
+	// Approved periods of all contracts that overlap a window.
+	ListApprovedContractPeriods(ctx context.Context, arg ListApprovedContractPeriodsParams) ([]ListApprovedContractPeriodsRow, error)
+	// Approved invoices of a run that were not emailed successfully yet.
+	ListApprovedInvoicesForDelivery(ctx context.Context, arg ListApprovedInvoicesForDeliveryParams) ([]int64, error)
 	// Join to get the client location name
 	ListAssignedEmployees(ctx context.Context, arg ListAssignedEmployeesParams) ([]ListAssignedEmployeesRow, error)
+	ListBankStatementLines(ctx context.Context, arg ListBankStatementLinesParams) ([]ListBankStatementLinesRow, error)
 	ListCarePlanReports(ctx context.Context, arg ListCarePlanReportsParams) ([]ListCarePlanReportsRow, error)
 	// Define the parameters for the query
 	// client_id: The ID of the client whose appointments are being queried.
@@ -238,9 +359,25 @@ type Querier interface {
 	ListClientDocuments(ctx context.Context, arg ListClientDocumentsParams) ([]ListClientDocumentsRow, error)
 	ListClientMaturityMatrixAssessments(ctx context.Context, arg ListClientMaturityMatrixAssessmentsParams) ([]ListClientMaturityMatrixAssessmentsRow, error)
 	ListClientStatusHistory(ctx context.Context, arg ListClientStatusHistoryParams) ([]ClientStatusHistory, error)
+	// Employees with shifts that start within the period, at the location when one is given
+	ListComplianceEmployees(ctx context.Context, arg ListComplianceEmployeesParams) ([]ListComplianceEmployeesRow, error)
+	// User accounts of the employees assigned to the client as case manager.
+	ListContractCaseManagerUserIDs(ctx context.Context, id int64) ([]int64, error)
+	ListContractLedgerKeys(ctx context.Context, contractIds []int64) ([]ListContractLedgerKeysRow, error)
+	ListContractStatusPeriods(ctx context.Context, contractID int64) ([]ContractStatusPeriod, error)
 	ListContractTypes(ctx context.Context) ([]ContractType, error)
+	// All versions of a contract from the first contract to the latest renewal
+	ListContractVersions(ctx context.Context, contractID int64) ([]ListContractVersionsRow, error)
+	ListContractWorkingHours(ctx context.Context, arg ListContractWorkingHoursParams) ([]ListContractWorkingHoursRow, error)
 	ListContracts(ctx context.Context, arg ListContractsParams) ([]ListContractsRow, error)
+	// Contracts that run and are approved at some point in the forecast window.
+	ListContractsForForecast(ctx context.Context, arg ListContractsForForecastParams) ([]ListContractsForForecastRow, error)
 	ListContractsTobeReminded(ctx context.Context) ([]ListContractsTobeRemindedRow, error)
+	// Running ambulante contracts that have an indication of hours and are approved on the
+	// status timeline, suspended contracts are skipped.
+	ListContractsWithHoursBudget(ctx context.Context) ([]ListContractsWithHoursBudgetRow, error)
+	// The schedules of a location in a range with the certifications of their employees
+	ListCoverageSchedules(ctx context.Context, arg ListCoverageSchedulesParams) ([]ListCoverageSchedulesRow, error)
 	ListEducations(ctx context.Context, employeeID int64) ([]EmployeeEducation, error)
 	ListEmergencyContacts(ctx context.Context, arg ListEmergencyContactsParams) ([]ListEmergencyContactsRow, error)
 	// Define the parameters for the query
@@ -249,46 +386,134 @@ type Querier interface {
 	// end_date: The end of the time range to search within.
 	// Order the combined results by start time
 	ListEmployeeAppointmentsInRange(ctx context.Context, arg ListEmployeeAppointmentsInRangeParams) ([]ListEmployeeAppointmentsInRangeRow, error)
+	ListEmployeeCalendarShifts(ctx context.Context, arg ListEmployeeCalendarShiftsParams) ([]ListEmployeeCalendarShiftsRow, error)
 	ListEmployeeCertifications(ctx context.Context, employeeID int64) ([]Certification, error)
 	ListEmployeeExperience(ctx context.Context, employeeID int64) ([]EmployeeExperience, error)
 	ListEmployeeProfile(ctx context.Context, arg ListEmployeeProfileParams) ([]ListEmployeeProfileRow, error)
+	ListEmployeeTimeEntriesInRange(ctx context.Context, arg ListEmployeeTimeEntriesInRangeParams) ([]ListEmployeeTimeEntriesInRangeRow, error)
+	// The unavailability of an employee that has not ended at the given time
+	ListEmployeeUnavailability(ctx context.Context, arg ListEmployeeUnavailabilityParams) ([]EmployeeUnavailability, error)
+	ListEmployeeUserIDs(ctx context.Context, employeeIds []int64) ([]int64, error)
+	// Pending and approved leave and the sick reports of the employees on the days from
+	// start_date up to and including end_date. end_date of an absence is its last day, for a
+	// sick employee who has not recovered the day before the expected return, or NULL.
+	ListEmployeesAbsences(ctx context.Context, arg ListEmployeesAbsencesParams) ([]ListEmployeesAbsencesRow, error)
+	ListEmployeesAppointmentsInRange(ctx context.Context, arg ListEmployeesAppointmentsInRangeParams) ([]ListEmployeesAppointmentsInRangeRow, error)
 	ListEmployeesByContractEndDate(ctx context.Context) ([]ListEmployeesByContractEndDateRow, error)
+	ListEmployeesSchedulesInRange(ctx context.Context, arg ListEmployeesSchedulesInRangeParams) ([]ListEmployeesSchedulesInRangeRow, error)
+	ListEmployeesShiftPreferences(ctx context.Context, employeeIds []int64) ([]EmployeeShiftPreference, error)
+	// The unavailability of the employees that overlaps the period
+	ListEmployeesUnavailability(ctx context.Context, arg ListEmployeesUnavailabilityParams) ([]EmployeeUnavailability, error)
+	ListEmployeesWeeklyAvailability(ctx context.Context, employeeIds []int64) ([]EmployeeWeeklyAvailability, error)
 	ListIncidents(ctx context.Context, arg ListIncidentsParams) ([]ListIncidentsRow, error)
 	ListIntakeForms(ctx context.Context, arg ListIntakeFormsParams) ([]ListIntakeFormsRow, error)
+	ListInvoiceCreditNotes(ctx context.Context, originalInvoiceID *int64) ([]ListInvoiceCreditNotesRow, error)
+	ListInvoiceDeliveries(ctx context.Context, invoiceID int64) ([]InvoiceDelivery, error)
+	ListInvoiceDeliveriesByBatch(ctx context.Context, batchID uuid.UUID) ([]ListInvoiceDeliveriesByBatchRow, error)
 	ListInvoices(ctx context.Context, arg ListInvoicesParams) ([]ListInvoicesRow, error)
 	ListLatestPayments(ctx context.Context) ([]ListLatestPaymentsRow, error)
+	ListLeaveBalanceAdjustments(ctx context.Context, arg ListLeaveBalanceAdjustmentsParams) ([]LeaveBalanceAdjustment, error)
+	// Leave requests that overlap the period, of the employees of the location when one is given
+	ListLeaveRequests(ctx context.Context, arg ListLeaveRequestsParams) ([]ListLeaveRequestsRow, error)
+	ListLeaveTypes(ctx context.Context) ([]LeaveType, error)
+	ListLedgerAccountMappings(ctx context.Context, organisationID int64) ([]LedgerAccountMapping, error)
+	// The users of the employees that work at a location or prefer to
+	ListLocationEmployeeUsers(ctx context.Context, locationID int64) ([]int64, error)
 	ListLocations(ctx context.Context, organisationID int64) ([]Location, error)
 	ListMaturityMatrix(ctx context.Context) ([]MaturityMatrix, error)
 	ListMedicationsByDiagnosisID(ctx context.Context, arg ListMedicationsByDiagnosisIDParams) ([]ListMedicationsByDiagnosisIDRow, error)
 	ListMedicationsByDiagnosisIDs(ctx context.Context, dollar_1 []int64) ([]ClientMedication, error)
+	ListMonthlyInvoicedReceived(ctx context.Context, arg ListMonthlyInvoicedReceivedParams) ([]ListMonthlyInvoicedReceivedRow, error)
 	ListNotifications(ctx context.Context, arg ListNotificationsParams) ([]Notification, error)
+	ListOpenInvoicesForMatching(ctx context.Context) ([]ListOpenInvoicesForMatchingRow, error)
+	// Open shifts that start after the given time, at the given locations or at any location
+	ListOpenShifts(ctx context.Context, arg ListOpenShiftsParams) ([]ListOpenShiftsRow, error)
 	ListOrganisations(ctx context.Context) ([]ListOrganisationsRow, error)
 	ListPayments(ctx context.Context, invoiceID int64) ([]ListPaymentsRow, error)
+	// Shifts of employees on the payroll that start within the period, with their time
+	// entries. ZZP employees and subcontractors are paid through self-billing.
+	ListPayrollShifts(ctx context.Context, arg ListPayrollShiftsParams) ([]ListPayrollShiftsRow, error)
+	// Returns the users that have a permission, with the location of their employee profile.
+	ListPermissionHolders(ctx context.Context, name string) ([]ListPermissionHoldersRow, error)
 	ListProgressReports(ctx context.Context, arg ListProgressReportsParams) ([]ListProgressReportsRow, error)
+	ListReceivablesAgingByClient(ctx context.Context, asOf pgtype.Date) ([]ListReceivablesAgingByClientRow, error)
+	// Open balances as of a date, bucketed by the days past the due date. Payments and
+	// issued credit notes up to the date reduce the balance.
+	ListReceivablesAgingBySender(ctx context.Context, asOf pgtype.Date) ([]ListReceivablesAgingBySenderRow, error)
 	ListRegistrationForms(ctx context.Context, arg ListRegistrationFormsParams) ([]RegistrationForm, error)
 	// Returns every role ordered by id with count of permissions.
 	ListRoles(ctx context.Context) ([]ListRolesRow, error)
+	// The employees a roster is generated for: the given employees, or else the employees of
+	// the location who are in service
+	ListRosterCandidates(ctx context.Context, arg ListRosterCandidatesParams) ([]ListRosterCandidatesRow, error)
+	ListRosterDraftAssignments(ctx context.Context, draftID int64) ([]ListRosterDraftAssignmentsRow, error)
+	ListRosterDrafts(ctx context.Context, locationID int64) ([]RosterDraft, error)
+	ListSelfBillingStatements(ctx context.Context, arg ListSelfBillingStatementsParams) ([]SelfBillingStatement, error)
+	// Approved statements of a month, exported statements are only included on request
+	ListSelfBillingStatementsForExport(ctx context.Context, arg ListSelfBillingStatementsForExportParams) ([]SelfBillingStatement, error)
 	ListSenders(ctx context.Context, arg ListSendersParams) ([]Sender, error)
+	// Materialized occurrences of a series that belong to the range by their place in the
+	// series or by their (moved) time
+	ListSeriesOccurrencesInRange(ctx context.Context, arg ListSeriesOccurrencesInRangeParams) ([]ScheduledAppointment, error)
+	// Swap requests of an employee, as requester or target, or of the shifts of a location
+	ListShiftSwapRequests(ctx context.Context, arg ListShiftSwapRequestsParams) ([]ListShiftSwapRequestsRow, error)
+	ListSickReportMilestones(ctx context.Context, sickReportIds []int64) ([]SickReportMilestone, error)
+	ListSickReports(ctx context.Context, arg ListSickReportsParams) ([]ListSickReportsRow, error)
+	ListStaffingNormLocations(ctx context.Context) ([]ListStaffingNormLocationsRow, error)
+	ListStaffingNorms(ctx context.Context, locationID int64) ([]ListStaffingNormsRow, error)
+	// Shifts of ZZP employees and subcontractors that start within the period, grouped by
+	// the organisation of the location they worked at. Shifts with an approved time entry
+	// count with the registered times and breaks, other shifts with the planned times.
+	ListSubcontractorShiftsForPeriod(ctx context.Context, arg ListSubcontractorShiftsForPeriodParams) ([]ListSubcontractorShiftsForPeriodRow, error)
+	// Time entries with the planned times of their schedules, by the start of the schedule
+	ListTimeEntries(ctx context.Context, arg ListTimeEntriesParams) ([]ListTimeEntriesRow, error)
+	ListTimeEntryBreaks(ctx context.Context, timeEntryID int64) ([]TimeEntryBreak, error)
+	// Issued invoices and credit notes of an organisation that are not part of an export
+	// batch yet. Canceled invoices are included when a credit note was issued against them,
+	// so the credit note is never booked without the invoice it reverses.
+	ListUnexportedInvoicesForExport(ctx context.Context, arg ListUnexportedInvoicesForExportParams) ([]ListUnexportedInvoicesForExportRow, error)
+	// Completed payments of an organisation that are not part of an export batch yet
+	ListUnexportedPaymentsForExport(ctx context.Context, arg ListUnexportedPaymentsForExportParams) ([]ListUnexportedPaymentsForExportRow, error)
 	ListUpcomingAppointments(ctx context.Context, creatorEmployeeID *int64) ([]ListUpcomingAppointmentsRow, error)
 	// ---------- 5. USER-PERMISSION MAPPING ----------
 	// Returns every permission granted to a user (direct or via roles).
 	ListUserPermissions(ctx context.Context, userID int64) ([]ListUserPermissionsRow, error)
+	ListWorkingTimeRules(ctx context.Context) ([]WorkingTimeRule, error)
+	MarkCalendarFeedTokenUsed(ctx context.Context, userID int64) error
 	MarkNotificationAsRead(ctx context.Context, id uuid.UUID) (Notification, error)
+	MarkSelfBillingStatementsExported(ctx context.Context, arg MarkSelfBillingStatementsExportedParams) error
+	MatchBankStatementLine(ctx context.Context, arg MatchBankStatementLineParams) (BankStatementLine, error)
 	MoveToWaitingList(ctx context.Context, id int64) (IntakeForm, error)
+	PublishRosterDraft(ctx context.Context, arg PublishRosterDraftParams) error
 	RecentIncidents(ctx context.Context) (int64, error)
+	RecordAccountingExportDownload(ctx context.Context, arg RecordAccountingExportDownloadParams) (AccountingExportBatch, error)
+	RecoverSickReport(ctx context.Context, arg RecoverSickReportParams) (SickReport, error)
+	RejectSelfBillingStatement(ctx context.Context, arg RejectSelfBillingStatementParams) (SelfBillingStatement, error)
 	// Removes *all* permissions from the given role.
 	RemovePermissionsFromRole(ctx context.Context, roleID int32) error
+	// The target accepts or declines a pending swap request
+	RespondShiftSwapRequest(ctx context.Context, arg RespondShiftSwapRequestParams) (ShiftSwapRequest, error)
+	ReviewLeaveRequest(ctx context.Context, arg ReviewLeaveRequestParams) (LeaveRequest, error)
+	// A planner approves or rejects a swap request the target accepted
+	ReviewShiftSwapRequest(ctx context.Context, arg ReviewShiftSwapRequestParams) (ShiftSwapRequest, error)
+	// Approves or rejects a deviation, an approval may correct the times that count
+	ReviewTimeEntry(ctx context.Context, arg ReviewTimeEntryParams) (TimeEntry, error)
 	SearchEmployeesByNameOrEmail(ctx context.Context, search *string) ([]SearchEmployeesByNameOrEmailRow, error)
+	SetAppointmentTemplateMaterializedUntil(ctx context.Context, arg SetAppointmentTemplateMaterializedUntilParams) error
 	SetAttachmentAsUsedorUnused(ctx context.Context, arg SetAttachmentAsUsedorUnusedParams) (AttachmentFile, error)
 	SetClientProfilePicture(ctx context.Context, arg SetClientProfilePictureParams) (ClientDetail, error)
 	SetEmployeeProfilePicture(ctx context.Context, arg SetEmployeeProfilePictureParams) (CustomUser, error)
+	SetRosterDraftAssignmentSchedule(ctx context.Context, arg SetRosterDraftAssignmentScheduleParams) error
+	StartTimeEntryBreak(ctx context.Context, arg StartTimeEntryBreakParams) (TimeEntryBreak, error)
 	StatusChangeCount(ctx context.Context) (int64, error)
 	TotalActiveClients(ctx context.Context) (int64, error)
 	TotalDischargeCount(ctx context.Context) (int64, error)
 	UpdateAppointment(ctx context.Context, arg UpdateAppointmentParams) (ScheduledAppointment, error)
 	UpdateAppointmentCard(ctx context.Context, arg UpdateAppointmentCardParams) (AppointmentCard, error)
 	UpdateAppointmentCardUrl(ctx context.Context, arg UpdateAppointmentCardUrlParams) (*string, error)
+	UpdateAppointmentTemplate(ctx context.Context, arg UpdateAppointmentTemplateParams) (AppointmentTemplate, error)
 	UpdateAssignedEmployee(ctx context.Context, arg UpdateAssignedEmployeeParams) (AssignedEmployee, error)
+	UpdateBankStatementImportCounts(ctx context.Context, arg UpdateBankStatementImportCountsParams) (BankStatementImport, error)
 	UpdateCarePlanAction(ctx context.Context, arg UpdateCarePlanActionParams) (CarePlanAction, error)
 	UpdateCarePlanIntervention(ctx context.Context, arg UpdateCarePlanInterventionParams) (CarePlanIntervention, error)
 	UpdateCarePlanObjective(ctx context.Context, arg UpdateCarePlanObjectiveParams) (CarePlanObjective, error)
@@ -313,7 +538,9 @@ type Querier interface {
 	UpdateIncident(ctx context.Context, arg UpdateIncidentParams) (Incident, error)
 	UpdateIncidentFileUrl(ctx context.Context, arg UpdateIncidentFileUrlParams) (*string, error)
 	UpdateInvoice(ctx context.Context, arg UpdateInvoiceParams) (Invoice, error)
+	UpdateInvoiceDeliveryStatus(ctx context.Context, arg UpdateInvoiceDeliveryStatusParams) (InvoiceDelivery, error)
 	UpdateInvoiceStatus(ctx context.Context, arg UpdateInvoiceStatusParams) (Invoice, error)
+	UpdateLeaveType(ctx context.Context, arg UpdateLeaveTypeParams) (LeaveType, error)
 	UpdateLocation(ctx context.Context, arg UpdateLocationParams) (Location, error)
 	UpdateOrganisation(ctx context.Context, arg UpdateOrganisationParams) (Organisation, error)
 	UpdatePassword(ctx context.Context, arg UpdatePasswordParams) error
@@ -321,9 +548,23 @@ type Querier interface {
 	UpdateProgressReport(ctx context.Context, arg UpdateProgressReportParams) (ProgressReport, error)
 	UpdateRegistrationForm(ctx context.Context, arg UpdateRegistrationFormParams) (RegistrationForm, error)
 	UpdateRegistrationFormStatus(ctx context.Context, arg UpdateRegistrationFormStatusParams) (RegistrationForm, error)
+	UpdateRosterDraftAssignmentEmployee(ctx context.Context, arg UpdateRosterDraftAssignmentEmployeeParams) error
 	UpdateSchedule(ctx context.Context, arg UpdateScheduleParams) (UpdateScheduleRow, error)
 	UpdateSender(ctx context.Context, arg UpdateSenderParams) (Sender, error)
 	UpdateShift(ctx context.Context, arg UpdateShiftParams) (LocationShift, error)
+	UpdateSickReport(ctx context.Context, arg UpdateSickReportParams) (SickReport, error)
+	UpdateStaffingNorm(ctx context.Context, arg UpdateStaffingNormParams) (StaffingNorm, error)
+	UpdateWorkingTimeRules(ctx context.Context, arg UpdateWorkingTimeRulesParams) (WorkingTimeRule, error)
+	UpsertCalendarFeedToken(ctx context.Context, arg UpsertCalendarFeedTokenParams) (CalendarFeedToken, error)
+	UpsertEmployeeShiftPreferences(ctx context.Context, arg UpsertEmployeeShiftPreferencesParams) (EmployeeShiftPreference, error)
+	UpsertInvoiceApprovalSettings(ctx context.Context, arg UpsertInvoiceApprovalSettingsParams) (InvoiceApprovalSetting, error)
+	UpsertInvoiceNumberSeries(ctx context.Context, arg UpsertInvoiceNumberSeriesParams) (InvoiceNumberSeries, error)
+	UpsertLocationClockIPRanges(ctx context.Context, arg UpsertLocationClockIPRangesParams) (LocationClockSetting, error)
+	UpsertLocationClockQrToken(ctx context.Context, arg UpsertLocationClockQrTokenParams) (LocationClockSetting, error)
+	// Creates the statement of a subcontractor for a month or replaces it while it is still
+	// a concept or rejected. Approved and exported statements are left alone and no row is
+	// returned for them.
+	UpsertSelfBillingStatement(ctx context.Context, arg UpsertSelfBillingStatementParams) (SelfBillingStatement, error)
 	UrgentCasesCount(ctx context.Context) (int64, error)
 }
 
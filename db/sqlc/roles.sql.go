@@ -234,6 +234,45 @@ func (q *Queries) ListAllRolePermissions(ctx context.Context, roleID int32) ([]L
 	return items, nil
 }
 
+const listPermissionHolders = `-- name: ListPermissionHolders :many
+SELECT up.user_id,
+       e.location_id
+FROM user_permissions up
+JOIN permissions p ON p.id = up.permission_id
+LEFT JOIN employee_profile e ON e.user_id = up.user_id
+WHERE p.name = $1
+ORDER BY up.user_id
+`
+
+type ListPermissionHoldersRow struct {
+	UserID     int64  `json:"user_id"`
+	LocationID *int64 `json:"location_id"`
+}
+
+// Returns the users that have a permission, with the location of their employee profile.
+func (q *Queries) ListPermissionHolders(ctx context.Context, name string) ([]ListPermissionHoldersRow, error) {
+	rows, err := q.db.Query(ctx, listPermissionHolders, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListPermissionHoldersRow{}
+	for rows.Next() {
+		var i ListPermissionHoldersRow
+		if err := rows.Scan(
+			&i.UserID,
+			&i.LocationID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listRoles = `-- name: ListRoles :many
 SELECT 
     r.id, r.name,
@@ -36,7 +36,7 @@ JOIN location l ON s.location_id = l.id
 `
 
 type CreateScheduleParams struct {
-	EmployeeID          int64            `json:"employee_id"`
+	EmployeeID          *int64           `json:"employee_id"`
 	LocationID          int64            `json:"location_id"`
 	LocationShiftID     *int64           `json:"location_shift_id"`
 	Color               *string          `json:"color"`
@@ -48,7 +48,7 @@ type CreateScheduleParams struct {
 
 type CreateScheduleRow struct {
 	ID                  uuid.UUID        `json:"id"`
-	EmployeeID          int64            `json:"employee_id"`
+	EmployeeID          *int64           `json:"employee_id"`
 	Color               *string          `json:"color"`
 	LocationID          int64            `json:"location_id"`
 	LocationShiftID     *int64           `json:"location_shift_id"`
@@ -107,7 +107,7 @@ WITH target_day AS (
 shift_days AS (
   SELECT 
     s.id AS shift_id,
-    s.employee_id,
+    e.id AS employee_id,
     s.location_id,
     s.color,
     s.start_datetime,
@@ -259,7 +259,7 @@ WITH target_month AS (
 )
 SELECT 
   s.id AS shift_id,
-  s.employee_id,
+  e.id AS employee_id,
   s.location_id,
   s.start_datetime,
   s.end_datetime,
@@ -344,7 +344,7 @@ SELECT s.id, s.employee_id, s.color, s.location_id, s.location_shift_id, s.is_cu
     ls.id AS location_shift_id
 FROM schedules s
 LEFT JOIN location_shift ls ON s.location_shift_id = ls.id
-JOIN employee_profile e ON s.employee_id = e.id
+LEFT JOIN employee_profile e ON s.employee_id = e.id
 JOIN location l ON s.location_id = l.id
 WHERE s.id = $1
 LIMIT 1
@@ -352,7 +352,7 @@ LIMIT 1
 
 type GetScheduleByIdRow struct {
 	ID                  uuid.UUID        `json:"id"`
-	EmployeeID          int64            `json:"employee_id"`
+	EmployeeID          *int64           `json:"employee_id"`
 	Color               *string          `json:"color"`
 	LocationID          int64            `json:"location_id"`
 	LocationShiftID     *int64           `json:"location_shift_id"`
@@ -362,8 +362,8 @@ type GetScheduleByIdRow struct {
 	CreatedByEmployeeID int64            `json:"created_by_employee_id"`
 	CreatedAt           pgtype.Timestamp `json:"created_at"`
 	UpdatedAt           pgtype.Timestamp `json:"updated_at"`
-	EmployeeFirstName   string           `json:"employee_first_name"`
-	EmployeeLastName    string           `json:"employee_last_name"`
+	EmployeeFirstName   *string          `json:"employee_first_name"`
+	EmployeeLastName    *string          `json:"employee_last_name"`
 	LocationName        string           `json:"location_name"`
 	LocationShiftName   *string          `json:"location_shift_name"`
 	LocationShiftID_2   *int64           `json:"location_shift_id_2"`
@@ -393,6 +393,136 @@ func (q *Queries) GetScheduleById(ctx context.Context, id uuid.UUID) (GetSchedul
 	return i, err
 }
 
+const listEmployeesAppointmentsInRange = `-- name: ListEmployeesAppointmentsInRange :many
+SELECT
+    a.id,
+    p.employee_id,
+    a.start_time,
+    a.end_time,
+    a.location,
+    a.status
+FROM scheduled_appointments a
+JOIN appointment_participants p ON p.appointment_id = a.id
+WHERE p.employee_id = ANY($1::bigint[])
+  AND a.status <> 'CANCELLED'
+  AND a.start_time < $2
+  AND a.end_time > $3
+ORDER BY p.employee_id, a.start_time
+`
+
+type ListEmployeesAppointmentsInRangeParams struct {
+	EmployeeIds []int64          `json:"employee_ids"`
+	EndDate     pgtype.Timestamp `json:"end_date"`
+	StartDate   pgtype.Timestamp `json:"start_date"`
+}
+
+type ListEmployeesAppointmentsInRangeRow struct {
+	ID         uuid.UUID        `json:"id"`
+	EmployeeID int64            `json:"employee_id"`
+	StartTime  pgtype.Timestamp `json:"start_time"`
+	EndTime    pgtype.Timestamp `json:"end_time"`
+	Location   *string          `json:"location"`
+	Status     string           `json:"status"`
+}
+
+func (q *Queries) ListEmployeesAppointmentsInRange(ctx context.Context, arg ListEmployeesAppointmentsInRangeParams) ([]ListEmployeesAppointmentsInRangeRow, error) {
+	rows, err := q.db.Query(ctx, listEmployeesAppointmentsInRange,
+		arg.EmployeeIds,
+		arg.EndDate,
+		arg.StartDate,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListEmployeesAppointmentsInRangeRow{}
+	for rows.Next() {
+		var i ListEmployeesAppointmentsInRangeRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.EmployeeID,
+			&i.StartTime,
+			&i.EndTime,
+			&i.Location,
+			&i.Status,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEmployeesSchedulesInRange = `-- name: ListEmployeesSchedulesInRange :many
+SELECT
+    s.id,
+    s.employee_id::bigint AS employee_id,
+    s.location_id,
+    s.start_datetime,
+    s.end_datetime,
+    l.name AS location_name,
+    ls.shift_name
+FROM schedules s
+JOIN location l ON s.location_id = l.id
+LEFT JOIN location_shift ls ON s.location_shift_id = ls.id
+WHERE s.employee_id = ANY($1::bigint[])
+  AND s.start_datetime < $2
+  AND s.end_datetime > $3
+ORDER BY s.employee_id, s.start_datetime
+`
+
+type ListEmployeesSchedulesInRangeParams struct {
+	EmployeeIds []int64          `json:"employee_ids"`
+	EndDate     pgtype.Timestamp `json:"end_date"`
+	StartDate   pgtype.Timestamp `json:"start_date"`
+}
+
+type ListEmployeesSchedulesInRangeRow struct {
+	ID            uuid.UUID        `json:"id"`
+	EmployeeID    int64            `json:"employee_id"`
+	LocationID    int64            `json:"location_id"`
+	StartDatetime pgtype.Timestamp `json:"start_datetime"`
+	EndDatetime   pgtype.Timestamp `json:"end_datetime"`
+	LocationName  string           `json:"location_name"`
+	ShiftName     *string          `json:"shift_name"`
+}
+
+// Open shifts have no employee and never match, employee_id is cast to keep it NOT NULL
+func (q *Queries) ListEmployeesSchedulesInRange(ctx context.Context, arg ListEmployeesSchedulesInRangeParams) ([]ListEmployeesSchedulesInRangeRow, error) {
+	rows, err := q.db.Query(ctx, listEmployeesSchedulesInRange,
+		arg.EmployeeIds,
+		arg.EndDate,
+		arg.StartDate,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListEmployeesSchedulesInRangeRow{}
+	for rows.Next() {
+		var i ListEmployeesSchedulesInRangeRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.EmployeeID,
+			&i.LocationID,
+			&i.StartDatetime,
+			&i.EndDatetime,
+			&i.LocationName,
+			&i.ShiftName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateSchedule = `-- name: UpdateSchedule :one
 WITH updated_schedule AS (
     UPDATE schedules
@@ -415,7 +545,7 @@ JOIN location l ON s.location_id = l.id
 
 type UpdateScheduleParams struct {
 	ID              uuid.UUID        `json:"id"`
-	EmployeeID      int64            `json:"employee_id"`
+	EmployeeID      *int64           `json:"employee_id"`
 	LocationID      int64            `json:"location_id"`
 	LocationShiftID *int64           `json:"location_shift_id"`
 	Color           *string          `json:"color"`
@@ -425,7 +555,7 @@ type UpdateScheduleParams struct {
 
 type UpdateScheduleRow struct {
 	ID                  uuid.UUID        `json:"id"`
-	EmployeeID          int64            `json:"employee_id"`
+	EmployeeID          *int64           `json:"employee_id"`
 	Color               *string          `json:"color"`
 	LocationID          int64            `json:"location_id"`
 	LocationShiftID     *int64           `json:"location_shift_id"`
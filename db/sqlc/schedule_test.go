@@ -13,7 +13,7 @@ func createRandomSchedule(t *testing.T, employeeID int64) CreateScheduleRow {
 	location := CreateRandomLocation(t)
 
 	arg := CreateScheduleParams{
-		EmployeeID:    employeeID,
+		EmployeeID:    &employeeID,
 		LocationID:    location.ID,
 		StartDatetime: pgtype.Timestamp{Time: time.Now(), Valid: true},
 		EndDatetime:   pgtype.Timestamp{Time: time.Now().Add(24 * time.Hour), Valid: true},
@@ -45,7 +45,7 @@ func TestGetMonthlySchedulesByLocation(t *testing.T) {
 	require.NotEmpty(t, schedules)
 	for _, s := range schedules {
 		require.Equal(t, schedule.LocationID, s.LocationID)
-		require.Equal(t, schedule.EmployeeID, s.EmployeeID)
+		require.Equal(t, *schedule.EmployeeID, s.EmployeeID)
 		require.NotEmpty(t, s.StartDatetime.Time)
 		require.NotEmpty(t, s.EndDatetime.Time)
 	}
@@ -67,7 +67,7 @@ func TestGetDailySchedulesByLocation(t *testing.T) {
 	require.NotEmpty(t, schedules)
 	for _, s := range schedules {
 		require.Equal(t, schedule.LocationID, s.LocationID)
-		require.Equal(t, schedule.EmployeeID, s.EmployeeID)
+		require.Equal(t, *schedule.EmployeeID, s.EmployeeID)
 		require.NotEmpty(t, s.StartDatetime.Time)
 		require.NotEmpty(t, s.EndDatetime.Time)
 	}
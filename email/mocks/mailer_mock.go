@@ -0,0 +1,113 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: maicare_go/email (interfaces: MailerInterface)
+//
+// Generated by this command:
+//
+//	mockgen -package emailmocks -destination=../email/mocks/mailer_mock.go maicare_go/email MailerInterface
+//
+
+// Package emailmocks is a generated GoMock package.
+package emailmocks
+
+import (
+	context "context"
+	email "maicare_go/email"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMailerInterface is a mock of MailerInterface interface.
+type MockMailerInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockMailerInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockMailerInterfaceMockRecorder is the mock recorder for MockMailerInterface.
+type MockMailerInterfaceMockRecorder struct {
+	mock *MockMailerInterface
+}
+
+// NewMockMailerInterface creates a new mock instance.
+func NewMockMailerInterface(ctrl *gomock.Controller) *MockMailerInterface {
+	mock := &MockMailerInterface{ctrl: ctrl}
+	mock.recorder = &MockMailerInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMailerInterface) EXPECT() *MockMailerInterfaceMockRecorder {
+	return m.recorder
+}
+
+// SendAcceptedRegistrationForm mocks base method.
+func (m *MockMailerInterface) SendAcceptedRegistrationForm(ctx context.Context, to []string, data email.AcceptedRegitrationForm) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendAcceptedRegistrationForm", ctx, to, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendAcceptedRegistrationForm indicates an expected call of SendAcceptedRegistrationForm.
+func (mr *MockMailerInterfaceMockRecorder) SendAcceptedRegistrationForm(ctx, to, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendAcceptedRegistrationForm", reflect.TypeOf((*MockMailerInterface)(nil).SendAcceptedRegistrationForm), ctx, to, data)
+}
+
+// SendClientContractReminder mocks base method.
+func (m *MockMailerInterface) SendClientContractReminder(ctx context.Context, to []string, data email.ClientContractReminder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendClientContractReminder", ctx, to, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendClientContractReminder indicates an expected call of SendClientContractReminder.
+func (mr *MockMailerInterfaceMockRecorder) SendClientContractReminder(ctx, to, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendClientContractReminder", reflect.TypeOf((*MockMailerInterface)(nil).SendClientContractReminder), ctx, to, data)
+}
+
+// SendCredentials mocks base method.
+func (m *MockMailerInterface) SendCredentials(ctx context.Context, to []string, data email.Credentials) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendCredentials", ctx, to, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendCredentials indicates an expected call of SendCredentials.
+func (mr *MockMailerInterfaceMockRecorder) SendCredentials(ctx, to, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendCredentials", reflect.TypeOf((*MockMailerInterface)(nil).SendCredentials), ctx, to, data)
+}
+
+// SendIncident mocks base method.
+func (m *MockMailerInterface) SendIncident(ctx context.Context, to []string, data email.Incident) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendIncident", ctx, to, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendIncident indicates an expected call of SendIncident.
+func (mr *MockMailerInterfaceMockRecorder) SendIncident(ctx, to, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendIncident", reflect.TypeOf((*MockMailerInterface)(nil).SendIncident), ctx, to, data)
+}
+
+// SendInvoice mocks base method.
+func (m *MockMailerInterface) SendInvoice(ctx context.Context, to []string, data email.InvoiceEmail, attachments []email.Attachment) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendInvoice", ctx, to, data, attachments)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendInvoice indicates an expected call of SendInvoice.
+func (mr *MockMailerInterfaceMockRecorder) SendInvoice(ctx, to, data, attachments any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendInvoice", reflect.TypeOf((*MockMailerInterface)(nil).SendInvoice), ctx, to, data, attachments)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"embed"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"html/template"
@@ -24,6 +25,15 @@ type SmtpConf struct {
 	SmtpPort      int
 }
 
+//go:generate mockgen -package emailmocks -destination=../email/mocks/mailer_mock.go maicare_go/email MailerInterface
+type MailerInterface interface {
+	SendCredentials(ctx context.Context, to []string, data Credentials) error
+	SendIncident(ctx context.Context, to []string, data Incident) error
+	SendAcceptedRegistrationForm(ctx context.Context, to []string, data AcceptedRegitrationForm) error
+	SendClientContractReminder(ctx context.Context, to []string, data ClientContractReminder) error
+	SendInvoice(ctx context.Context, to []string, data InvoiceEmail, attachments []Attachment) (string, error)
+}
+
 type BrevoConf struct {
 	SenderName  string
 	Senderemail string
@@ -81,6 +91,23 @@ type ClientContractReminder struct {
 	CurrentYear        int
 }
 
+type InvoiceEmail struct {
+	RecipientName string
+	InvoiceNumber string
+	InvoiceType   string
+	IssueDate     time.Time
+	DueDate       time.Time
+	TotalAmount   float64
+	IsReminder    bool
+	CurrentYear   int
+}
+
+// Attachment is a file sent along with an email, Content holds the raw bytes
+type Attachment struct {
+	Name    string
+	Content []byte
+}
+
 func NewSmtpConf(name, address, authentication, smtpHost string, smtpPort int) *SmtpConf {
 	return &SmtpConf{
 		Name:          name,
@@ -354,3 +381,78 @@ func (b *BrevoConf) SendClientContractReminder(ctx context.Context, to []string,
 
 	return nil
 }
+
+//go:embed templates/invoice.html
+var invoiceTemplateFS embed.FS
+
+// SendInvoice sends an invoice (or a reminder for it) with the PDF and UBL files attached.
+// It returns the message ID assigned by Brevo.
+func (b *BrevoConf) SendInvoice(ctx context.Context, to []string, data InvoiceEmail, attachments []Attachment) (string, error) {
+	if len(to) == 0 {
+		return "", errors.New("no recipient addresses provided")
+	}
+	if b.SenderName == "" || b.Senderemail == "" {
+		return "", errors.New("invalid sender configuration")
+	}
+	if b.ApiKey == "" {
+		return "", errors.New("invalid API key")
+	}
+
+	tmpl, err := template.ParseFS(invoiceTemplateFS, "templates/invoice.html")
+	if err != nil {
+		return "", fmt.Errorf("failed to parse HTML template: %w", err)
+	}
+
+	var body bytes.Buffer
+	if err := tmpl.Execute(&body, data); err != nil {
+		return "", fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	htmlContent := body.String()
+	sender := brevo.SendSmtpEmailSender{
+		Name:  b.SenderName,
+		Email: b.Senderemail,
+	}
+	recipients := make([]brevo.SendSmtpEmailTo, 0, len(to))
+	for _, recipient := range to {
+		recipients = append(recipients, brevo.SendSmtpEmailTo{
+			Email: recipient,
+			Name:  recipient,
+		})
+	}
+	emailAttachments := make([]brevo.SendSmtpEmailAttachment, 0, len(attachments))
+	for _, attachment := range attachments {
+		emailAttachments = append(emailAttachments, brevo.SendSmtpEmailAttachment{
+			Name:    attachment.Name,
+			Content: base64.StdEncoding.EncodeToString(attachment.Content),
+		})
+	}
+
+	subject := fmt.Sprintf("Factuur %s", data.InvoiceNumber)
+	if data.InvoiceType == "credit_note" {
+		subject = fmt.Sprintf("Creditnota %s", data.InvoiceNumber)
+	}
+	if data.IsReminder {
+		subject = fmt.Sprintf("Herinnering: factuur %s", data.InvoiceNumber)
+	}
+
+	emailContent := brevo.SendSmtpEmail{
+		Sender:      &sender,
+		To:          recipients,
+		Subject:     subject,
+		HtmlContent: htmlContent,
+		Attachment:  emailAttachments,
+	}
+	result, response, err := b.client.TransactionalEmailsApi.SendTransacEmail(ctx, emailContent)
+	if err != nil {
+		return "", fmt.Errorf("failed to send email: %w", err)
+	}
+
+	if response.StatusCode != 201 {
+		return "", fmt.Errorf("failed to send email, status code: %d", response.StatusCode)
+	}
+	log.Printf("Invoice email sent to %s", to)
+	log.Printf("Response Status Code: %d", response.StatusCode)
+
+	return result.MessageId, nil
+}
@@ -0,0 +1,145 @@
+// Package ical writes iCalendar (RFC 5545) calendars for subscription feeds. Events are
+// written in the Europe/Amsterdam time zone with its VTIMEZONE definition, so calendar
+// apps show them at the right local time on both sides of a daylight saving change.
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// TimeZone is the time zone the events are written in
+const TimeZone = "Europe/Amsterdam"
+
+// vtimezone describes Europe/Amsterdam with the EU daylight saving rules that apply since 1996
+const vtimezone = `BEGIN:VTIMEZONE
+TZID:Europe/Amsterdam
+X-LIC-LOCATION:Europe/Amsterdam
+BEGIN:DAYLIGHT
+TZOFFSETFROM:+0100
+TZOFFSETTO:+0200
+TZNAME:CEST
+DTSTART:19700329T020000
+RRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=-1SU
+END:DAYLIGHT
+BEGIN:STANDARD
+TZOFFSETFROM:+0200
+TZOFFSETTO:+0100
+TZNAME:CET
+DTSTART:19701025T030000
+RRULE:FREQ=YEARLY;BYMONTH=10;BYDAY=-1SU
+END:STANDARD
+END:VTIMEZONE`
+
+// Status values of an event
+const (
+	StatusConfirmed = "CONFIRMED"
+	StatusTentative = "TENTATIVE"
+	StatusCancelled = "CANCELLED"
+)
+
+// Event is a VEVENT. UID must stay the same for the lifetime of the event so that
+// subscribed calendars update it instead of adding a copy. Start and End are wall-clock
+// times in Europe/Amsterdam, the way schedules and appointments are stored, so their
+// location is not used.
+type Event struct {
+	UID          string
+	Summary      string
+	Location     string
+	Description  string
+	Start        time.Time
+	End          time.Time
+	Status       string
+	LastModified time.Time
+}
+
+// Calendar is a VCALENDAR with a display name for calendar apps
+type Calendar struct {
+	Name   string
+	Events []Event
+}
+
+// Encode returns the calendar as iCalendar data. now is written as the DTSTAMP of the
+// events.
+func (c Calendar) Encode(now time.Time) []byte {
+	w := &writer{}
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.line("PRODID:-//Maicare//Calendar feed//NL")
+	w.line("CALSCALE:GREGORIAN")
+	w.line("METHOD:PUBLISH")
+	if c.Name != "" {
+		w.line("X-WR-CALNAME:" + Escape(c.Name))
+	}
+	w.line("X-WR-TIMEZONE:" + TimeZone)
+	w.line("REFRESH-INTERVAL;VALUE=DURATION:PT1H")
+	w.line("X-PUBLISHED-TTL:PT1H")
+	for _, line := range strings.Split(vtimezone, "\n") {
+		w.line(line)
+	}
+
+	stamp := now.UTC().Format("20060102T150405Z")
+	for _, e := range c.Events {
+		w.line("BEGIN:VEVENT")
+		w.line("UID:" + e.UID)
+		w.line("DTSTAMP:" + stamp)
+		w.line("DTSTART;TZID=" + TimeZone + ":" + e.Start.Format("20060102T150405"))
+		w.line("DTEND;TZID=" + TimeZone + ":" + e.End.Format("20060102T150405"))
+		if !e.LastModified.IsZero() {
+			w.line("LAST-MODIFIED:" + e.LastModified.UTC().Format("20060102T150405Z"))
+		}
+		w.line("SUMMARY:" + Escape(e.Summary))
+		if e.Location != "" {
+			w.line("LOCATION:" + Escape(e.Location))
+		}
+		if e.Description != "" {
+			w.line("DESCRIPTION:" + Escape(e.Description))
+		}
+		if e.Status != "" {
+			w.line("STATUS:" + e.Status)
+		}
+		w.line("TRANSP:OPAQUE")
+		w.line("END:VEVENT")
+	}
+	w.line("END:VCALENDAR")
+	return w.buf.Bytes()
+}
+
+// Escape escapes a TEXT value
+func Escape(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(value)
+}
+
+// maxLineOctets is the longest content line RFC 5545 allows, without the line break
+const maxLineOctets = 75
+
+type writer struct {
+	buf bytes.Buffer
+}
+
+// line writes a content line folded after 75 octets, never inside a UTF-8 character
+func (w *writer) line(value string) {
+	limit := maxLineOctets
+	for len(value) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(value[cut]) {
+			cut--
+		}
+		w.buf.WriteString(value[:cut])
+		w.buf.WriteString("\r\n ")
+		value = value[cut:]
+		// the leading space of a continuation line counts
+		limit = maxLineOctets - 1
+	}
+	w.buf.WriteString(value)
+	w.buf.WriteString("\r\n")
+}
@@ -0,0 +1,68 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode(t *testing.T) {
+	calendar := Calendar{
+		Name: "Rooster",
+		Events: []Event{
+			{
+				UID:      "shift-1@maicare",
+				Summary:  "Avonddienst",
+				Location: "De Linde, Dorpsstraat 1",
+				// stored as Dutch wall-clock time, which pgx returns in UTC
+				Start:  time.Date(2025, time.July, 1, 18, 0, 0, 0, time.UTC),
+				End:    time.Date(2025, time.July, 2, 0, 0, 0, 0, time.UTC),
+				Status: StatusConfirmed,
+			},
+			{
+				UID:     "appointment-2@maicare",
+				Summary: "Appointment",
+				Start:   time.Date(2025, time.December, 1, 9, 0, 0, 0, time.UTC),
+				End:     time.Date(2025, time.December, 1, 10, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	data := string(calendar.Encode(time.Date(2025, time.June, 1, 12, 0, 0, 0, time.UTC)))
+	require.True(t, strings.HasPrefix(data, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	require.True(t, strings.HasSuffix(data, "END:VCALENDAR\r\n"))
+	require.Contains(t, data, "BEGIN:VTIMEZONE\r\nTZID:Europe/Amsterdam\r\n")
+	require.Contains(t, data, "X-WR-CALNAME:Rooster\r\n")
+	require.Contains(t, data, "UID:shift-1@maicare\r\nDTSTAMP:20250601T120000Z\r\n")
+	require.Contains(t, data, "DTSTART;TZID=Europe/Amsterdam:20250701T180000\r\n")
+	require.Contains(t, data, "DTEND;TZID=Europe/Amsterdam:20250702T000000\r\n")
+	require.Contains(t, data, "LOCATION:De Linde\\, Dorpsstraat 1\r\n")
+	require.Contains(t, data, "DTSTART;TZID=Europe/Amsterdam:20251201T090000\r\n")
+	require.Contains(t, data, "DTEND;TZID=Europe/Amsterdam:20251201T100000\r\n")
+	require.Equal(t, 2, strings.Count(data, "BEGIN:VEVENT"))
+	require.NotContains(t, strings.ReplaceAll(data, "\r\n", ""), "\n")
+}
+
+func TestEscape(t *testing.T) {
+	require.Equal(t, `a\\b\;c\,d\ne`, Escape("a\\b;c,d\ne"))
+}
+
+func TestFoldLongLines(t *testing.T) {
+	w := &writer{}
+	value := "DESCRIPTION:" + strings.Repeat("é", 100)
+	w.line(value)
+
+	lines := strings.Split(strings.TrimSuffix(w.buf.String(), "\r\n"), "\r\n")
+	require.Greater(t, len(lines), 1)
+	unfolded := lines[0]
+	for i, line := range lines {
+		require.LessOrEqual(t, len(line), maxLineOctets)
+		if i > 0 {
+			require.True(t, strings.HasPrefix(line, " "))
+			unfolded += line[1:]
+		}
+	}
+	require.Equal(t, value, unfolded)
+}
@@ -141,7 +141,7 @@ func main() {
 	}
 
 	// Init the buisness service
-	businessService := service.NewBusinessService(store, tokenMaker, logger, &config, b2Client)
+	businessService := service.NewBusinessService(store, tokenMaker, logger, &config, b2Client, asynqClient, hubInstance)
 
 	if !config.Remote {
 		redisClient := redis.NewClient(&redis.Options{
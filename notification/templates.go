@@ -11,10 +11,15 @@ const (
 	// Notification Types
 	TypeNewAppointment          = "new_appointment"
 	TypeAppointmentUpdate       = "appointment_update"
+	TypeAppointmentCancellation = "appointment_cancellation"
 	TypeNewClientAssignment     = "new_client_assigned"
 	TypeClientContractReminder  = "client_contract_reminder"
 	TypeNewIncidentReport       = "new_incident_report"
 	TypeNewScheduleNotification = "new_schedule_notification"
+	TypeContractHoursBudget     = "contract_hours_budget"
+	TypeStaffingGap             = "staffing_gap"
+	TypeShiftReplacement        = "shift_replacement"
+	TypeShiftMarketplace        = "shift_marketplace"
 )
 
 type NotificationPayload struct {
@@ -31,6 +36,11 @@ type NotificationData struct {
 	ClientContractReminder  *ClientContractReminderData  `json:"client_contract_reminder,omitempty"`
 	NewIncidentReport       *NewIncidentReportData       `json:"new_incident_report,omitempty"`
 	NewScheduleNotification *NewScheduleNotificationData `json:"new_schedule_notification,omitempty"`
+	ContractHoursBudget     *ContractHoursBudgetData     `json:"contract_hours_budget,omitempty"`
+	AppointmentSeriesChange *AppointmentSeriesChangeData `json:"appointment_series_change,omitempty"`
+	StaffingGap             *StaffingGapData             `json:"staffing_gap,omitempty"`
+	ShiftReplacement        *ShiftReplacementData        `json:"shift_replacement,omitempty"`
+	ShiftMarketplace        *ShiftMarketplaceData        `json:"shift_marketplace,omitempty"`
 }
 
 // Notifications Data Templates
@@ -51,6 +61,28 @@ func (a *NewAppointmentData) NewAppointmentMessage() string {
 	return message
 }
 
+// AppointmentSeriesChangeData is sent once for a change to a recurring appointment
+// instead of once per occurrence
+type AppointmentSeriesChangeData struct {
+	SeriesID    uuid.UUID `json:"series_id"`
+	Scope       string    `json:"scope"` // "following" or "all"
+	Cancelled   bool      `json:"cancelled"`
+	From        time.Time `json:"from"`       // first occurrence that changed
+	StartTime   time.Time `json:"start_time"` // new time of that occurrence, empty when cancelled
+	EndTime     time.Time `json:"end_time"`
+	Location    string    `json:"location"`
+	Occurrences int64     `json:"occurrences"` // upcoming occurrences that were replaced or removed
+}
+
+func (a *AppointmentSeriesChangeData) AppointmentSeriesChangeMessage() string {
+	if a.Cancelled {
+		return fmt.Sprintf("Recurring appointment cancelled from %s, %d upcoming appointments removed",
+			a.From.Format(time.RFC3339), a.Occurrences)
+	}
+	return fmt.Sprintf("Recurring appointment changed from %s: now from %s to %s at %s",
+		a.From.Format(time.RFC3339), a.StartTime.Format(time.RFC3339), a.EndTime.Format(time.RFC3339), a.Location)
+}
+
 type NewClientAssignmentData struct {
 	ClientID        int64   `json:"client_id"`
 	ClientFirstName string  `json:"client_first_name"`
@@ -75,6 +107,133 @@ type ClientContractReminderData struct {
 	ContractEnd        time.Time  `json:"contract_end"`
 	ReminderType       string     `json:"reminder_type"` // e.g., "initial
 	LastReminderSentAt *time.Time `json:"last_reminder_sent_at,omitempty"`
+	RenewalPath        string     `json:"renewal_path"`                  // action that renews the contract
+	RenewalContractID  *int64     `json:"renewal_contract_id,omitempty"` // draft renewal that already exists
+}
+
+func (c *ClientContractReminderData) ClientContractReminderMessage() string {
+	if c.RenewalContractID != nil {
+		return fmt.Sprintf("Contract %d of %s %s ends on %s, renewal %d is waiting for approval",
+			c.ContractID, c.ClientFirstName, c.ClientLastName, c.ContractEnd.Format("2006-01-02"), *c.RenewalContractID)
+	}
+	return fmt.Sprintf("Contract %d of %s %s ends on %s and can be renewed",
+		c.ContractID, c.ClientFirstName, c.ClientLastName, c.ContractEnd.Format("2006-01-02"))
+}
+
+type ContractHoursBudgetData struct {
+	ClientID          int64     `json:"client_id"`
+	ClientFirstName   string    `json:"client_first_name"`
+	ClientLastName    string    `json:"client_last_name"`
+	ContractID        int64     `json:"contract_id"`
+	Threshold         int32     `json:"threshold"` // 80 or 100 percent of the indication
+	PeriodStart       time.Time `json:"period_start"`
+	PeriodEnd         time.Time `json:"period_end"`
+	AuthorizedMinutes float64   `json:"authorized_minutes"`
+	ConsumedMinutes   float64   `json:"consumed_minutes"`
+}
+
+func (c *ContractHoursBudgetData) ContractHoursBudgetMessage() string {
+	return fmt.Sprintf("Contract %d of %s %s has used %d%% of its indicated hours (%.0f of %.0f minutes)",
+		c.ContractID, c.ClientFirstName, c.ClientLastName, c.Threshold, c.ConsumedMinutes, c.AuthorizedMinutes)
+}
+
+type StaffingGapData struct {
+	LocationID   int64             `json:"location_id"`
+	LocationName string            `json:"location_name"`
+	Gaps         []StaffingGapItem `json:"gaps"`
+}
+
+type StaffingGapItem struct {
+	Date                  string    `json:"date"`
+	LocationShiftID       int64     `json:"location_shift_id"`
+	ShiftName             string    `json:"shift_name"`
+	StartTime             time.Time `json:"start_time"`
+	MinStaff              int       `json:"min_staff"`
+	Scheduled             int       `json:"scheduled"`
+	MissingCertifications []string  `json:"missing_certifications,omitempty"`
+}
+
+func (s *StaffingGapData) StaffingGapMessage() string {
+	first := s.Gaps[0]
+	if len(s.Gaps) == 1 {
+		return fmt.Sprintf("%s has a staffing gap on the %s shift of %s", s.LocationName, first.ShiftName, first.Date)
+	}
+	return fmt.Sprintf("%s has %d staffing gaps in the next 14 days, the first on the %s shift of %s",
+		s.LocationName, len(s.Gaps), first.ShiftName, first.Date)
+}
+
+// ShiftReplacementData is sent to the planners of a location when an employee turns out
+// to be absent for shifts that are already scheduled
+type ShiftReplacementData struct {
+	EmployeeID        int64                  `json:"employee_id"`
+	EmployeeFirstName string                 `json:"employee_first_name"`
+	EmployeeLastName  string                 `json:"employee_last_name"`
+	Reason            string                 `json:"reason"` // "leave" or "sick"
+	LeaveRequestID    *int64                 `json:"leave_request_id,omitempty"`
+	SickReportID      *int64                 `json:"sick_report_id,omitempty"`
+	LocationID        int64                  `json:"location_id"`
+	LocationName      string                 `json:"location_name"`
+	Shifts            []ShiftReplacementItem `json:"shifts"`
+}
+
+type ShiftReplacementItem struct {
+	ScheduleID uuid.UUID `json:"schedule_id"`
+	ShiftName  *string   `json:"shift_name,omitempty"`
+	StartTime  time.Time `json:"start_time"`
+	EndTime    time.Time `json:"end_time"`
+}
+
+func (s *ShiftReplacementData) ShiftReplacementMessage() string {
+	reason := "is on leave"
+	if s.Reason == "sick" {
+		reason = "is sick"
+	}
+	first := s.Shifts[0]
+	if len(s.Shifts) == 1 {
+		return fmt.Sprintf("%s %s %s, the shift of %s at %s needs a replacement",
+			s.EmployeeFirstName, s.EmployeeLastName, reason, first.StartTime.Format("2006-01-02 15:04"), s.LocationName)
+	}
+	return fmt.Sprintf("%s %s %s, %d shifts at %s from %s need a replacement",
+		s.EmployeeFirstName, s.EmployeeLastName, reason, len(s.Shifts), s.LocationName, first.StartTime.Format("2006-01-02 15:04"))
+}
+
+// ShiftMarketplaceData is sent when an employee claims an open shift and as a swap request
+// is answered and reviewed. The requester is the employee that claims the shift or asks
+// the target to take it over.
+type ShiftMarketplaceData struct {
+	Event              string    `json:"event"` // "claimed", "swap_requested", "swap_accepted", "swap_declined", "swap_approved" or "swap_rejected"
+	ScheduleID         uuid.UUID `json:"schedule_id"`
+	SwapRequestID      *int64    `json:"swap_request_id,omitempty"`
+	RequesterFirstName string    `json:"requester_first_name"`
+	RequesterLastName  string    `json:"requester_last_name"`
+	TargetFirstName    string    `json:"target_first_name,omitempty"`
+	TargetLastName     string    `json:"target_last_name,omitempty"`
+	LocationID         int64     `json:"location_id"`
+	LocationName       string    `json:"location_name"`
+	StartTime          time.Time `json:"start_time"`
+	EndTime            time.Time `json:"end_time"`
+}
+
+func (m *ShiftMarketplaceData) ShiftMarketplaceMessage() string {
+	shift := fmt.Sprintf("the shift of %s at %s", m.StartTime.Format("2006-01-02 15:04"), m.LocationName)
+	switch m.Event {
+	case "claimed":
+		return fmt.Sprintf("%s %s took over the open shift of %s at %s",
+			m.RequesterFirstName, m.RequesterLastName, m.StartTime.Format("2006-01-02 15:04"), m.LocationName)
+	case "swap_requested":
+		return fmt.Sprintf("%s %s asks you to take over %s", m.RequesterFirstName, m.RequesterLastName, shift)
+	case "swap_accepted":
+		return fmt.Sprintf("%s %s takes over %s from %s %s, the swap waits for approval",
+			m.TargetFirstName, m.TargetLastName, shift, m.RequesterFirstName, m.RequesterLastName)
+	case "swap_declined":
+		return fmt.Sprintf("%s %s declined to take over %s", m.TargetFirstName, m.TargetLastName, shift)
+	case "swap_approved":
+		return fmt.Sprintf("The swap of %s from %s %s to %s %s is approved",
+			shift, m.RequesterFirstName, m.RequesterLastName, m.TargetFirstName, m.TargetLastName)
+	default:
+		return fmt.Sprintf("The swap of %s from %s %s to %s %s is rejected",
+			shift, m.RequesterFirstName, m.RequesterLastName, m.TargetFirstName, m.TargetLastName)
+	}
 }
 
 type NewIncidentReportData struct {
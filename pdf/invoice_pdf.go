@@ -8,6 +8,7 @@ import (
 	"html/template"
 	"maicare_go/bucket"
 	"mime/multipart"
+	"sort"
 	"time"
 
 	"github.com/SebastiaanKlippert/go-wkhtmltopdf"
@@ -25,6 +26,40 @@ type InvoicePDFData struct {
 	InvoiceDetails       []InvoiceDetail
 	TotalAmount          float64
 	ExtraItems           map[string]string
+	// ExtraItemOrder holds the labels of ExtraItems in the order of the sender's invoice template
+	ExtraItemOrder []string
+}
+
+type ExtraItem struct {
+	Label string
+	Value string
+}
+
+// OrderedExtraItems returns the extra items in the order of the sender's invoice template,
+// items that are not part of the template follow sorted by label
+func (d InvoicePDFData) OrderedExtraItems() []ExtraItem {
+	items := make([]ExtraItem, 0, len(d.ExtraItems))
+	seen := make(map[string]bool, len(d.ExtraItems))
+	for _, label := range d.ExtraItemOrder {
+		value, ok := d.ExtraItems[label]
+		if !ok || seen[label] {
+			continue
+		}
+		seen[label] = true
+		items = append(items, ExtraItem{Label: label, Value: value})
+	}
+
+	var rest []string
+	for label := range d.ExtraItems {
+		if !seen[label] {
+			rest = append(rest, label)
+		}
+	}
+	sort.Strings(rest)
+	for _, label := range rest {
+		items = append(items, ExtraItem{Label: label, Value: d.ExtraItems[label]})
+	}
+	return items
 }
 
 type InvoiceDetail struct {
@@ -62,3 +62,23 @@ func TestGenerateInvoicePDF(t *testing.T) {
 	require.NotEmpty(t, pdfBytes)
 
 }
+
+func TestOrderedExtraItems(t *testing.T) {
+	data := InvoicePDFData{
+		ExtraItems: map[string]string{
+			"Opmerkingen":       "Geen bijzonderheden.",
+			"BSN":               "123456789",
+			"Indication number": "IND-001",
+			"Care name":         "Begeleiding",
+		},
+		ExtraItemOrder: []string{"Indication number", "BSN", "Missing", "Care name"},
+	}
+
+	items := data.OrderedExtraItems()
+	require.Equal(t, []ExtraItem{
+		{Label: "Indication number", Value: "IND-001"},
+		{Label: "BSN", Value: "123456789"},
+		{Label: "Care name", Value: "Begeleiding"},
+		{Label: "Opmerkingen", Value: "Geen bijzonderheden."},
+	}, items)
+}
@@ -0,0 +1,80 @@
+package pdf
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"maicare_go/bucket"
+	"mime/multipart"
+	"time"
+
+	"github.com/SebastiaanKlippert/go-wkhtmltopdf"
+)
+
+// SelfBillingPDFData is a self-billing statement, the organisation issues the invoice
+// on behalf of the subcontractor
+type SelfBillingPDFData struct {
+	StatementNumber    string
+	StatementDate      time.Time
+	PeriodStart        time.Time
+	PeriodEnd          time.Time
+	Status             string
+	IssuerName         string
+	IssuerAddressLine1 string
+	IssuerPostalCity   string
+	IssuerKvkNumber    string
+	IssuerBtwNumber    string
+	SupplierName       string
+	SupplierEmail      string
+	Lines              []SelfBillingPDFLine
+	Hours              float64
+	Rate               float64
+	Subtotal           float64
+	VatRate            float64
+	VatAmount          float64
+	TotalAmount        float64
+}
+
+type SelfBillingPDFLine struct {
+	LocationName string
+	Start        time.Time
+	End          time.Time
+	Hours        float64
+	Amount       float64
+}
+
+//go:embed templates/self_billing.html
+var selfBillingTemplateFS embed.FS
+
+func GenerateSelfBillingPDF(data SelfBillingPDFData) (multipart.File, error) {
+	templ, err := template.New("self_billing.html").ParseFS(selfBillingTemplateFS, "templates/self_billing.html")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var body bytes.Buffer
+	if err := templ.Execute(&body, data); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	pdfg, err := wkhtmltopdf.NewPDFGenerator()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create PDF generator: %w", err)
+	}
+	pdfg.Dpi.Set(300)
+	pdfg.Orientation.Set(wkhtmltopdf.OrientationPortrait)
+	pdfg.Grayscale.Set(false)
+
+	page := wkhtmltopdf.NewPageReader(bytes.NewReader(body.Bytes()))
+	page.EnableLocalFileAccess.Set(true)
+	page.LoadErrorHandling.Set("ignore")
+	pdfg.AddPage(page)
+
+	if err := pdfg.Create(); err != nil {
+		return nil, fmt.Errorf("failed to create PDF: %w", err)
+	}
+	return &bucket.InMemoryFile{
+		Reader: bytes.NewReader(pdfg.Bytes()),
+	}, nil
+}
@@ -0,0 +1,288 @@
+package rrule
+
+import (
+	"slices"
+	"time"
+)
+
+// maxPeriods stops the expansion of rules that never match, such as BYMONTHDAY=30 with
+// BYMONTH=2
+const maxPeriods = 100000
+
+// Set is a recurrence set: DTSTART with an optional rule, extra occurrences (RDATE) and
+// excluded occurrences (EXDATE). An EXDATE at midnight excludes every occurrence on that
+// date, which is how skipped holidays are given.
+type Set struct {
+	DTStart time.Time
+	Rule    *Rule
+	RDates  []time.Time
+	ExDates []time.Time
+}
+
+// Between returns the occurrences of the set that start in [from, to), sorted
+func (s Set) Between(from, to time.Time) []time.Time {
+	var occurrences []time.Time
+	add := func(t time.Time) {
+		if !t.Before(from) && t.Before(to) {
+			occurrences = append(occurrences, t)
+		}
+	}
+
+	if s.Rule != nil {
+		s.Rule.iterate(s.DTStart, to, func(t time.Time) {
+			add(t)
+		})
+	} else {
+		add(s.DTStart)
+	}
+	for _, rdate := range s.RDates {
+		add(rdate)
+	}
+
+	slices.SortFunc(occurrences, func(a, b time.Time) int { return a.Compare(b) })
+	occurrences = slices.CompactFunc(occurrences, func(a, b time.Time) bool { return a.Equal(b) })
+	return slices.DeleteFunc(occurrences, s.IsExcluded)
+}
+
+// IsExcluded reports whether an occurrence is excluded by one of the EXDATEs
+func (s Set) IsExcluded(t time.Time) bool {
+	for _, ex := range s.ExDates {
+		if ex.Equal(t) {
+			return true
+		}
+		if isMidnight(ex) && sameDate(ex, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// Occurs reports whether t is an occurrence of the set
+func (s Set) Occurs(t time.Time) bool {
+	occurrences := s.Between(t, t.Add(time.Second))
+	return len(occurrences) > 0 && occurrences[0].Equal(t)
+}
+
+// iterate calls yield for every occurrence of the rule that starts before to, in order.
+// DTSTART is always the first occurrence and counts for COUNT.
+func (r Rule) iterate(dtstart, to time.Time, yield func(time.Time)) {
+	count := 0
+	until := r.until(dtstart.Location())
+	emit := func(t time.Time) bool {
+		if !until.IsZero() && t.After(until) {
+			return false
+		}
+		if !t.Before(to) {
+			return false
+		}
+		count++
+		yield(t)
+		return r.Count == 0 || count < r.Count
+	}
+
+	if !emit(dtstart) {
+		return
+	}
+
+	interval := max(r.Interval, 1)
+	for i := 0; i < maxPeriods; i++ {
+		periodStart := r.periodStart(dtstart, i*interval)
+		if !periodStart.Before(to) || (!until.IsZero() && periodStart.After(until)) {
+			return
+		}
+		for _, t := range r.candidates(dtstart, periodStart) {
+			if !t.After(dtstart) {
+				continue
+			}
+			if !emit(t) {
+				return
+			}
+		}
+	}
+}
+
+// periodStart returns the midnight that starts the n-th period after the one of dtstart
+func (r Rule) periodStart(dtstart time.Time, n int) time.Time {
+	day := date(dtstart.Year(), dtstart.Month(), dtstart.Day(), dtstart.Location())
+	switch r.Freq {
+	case Weekly:
+		offset := (int(day.Weekday()) - int(r.WeekStart) + 7) % 7
+		return day.AddDate(0, 0, n*7-offset)
+	case Monthly:
+		return date(day.Year(), day.Month()+time.Month(n), 1, day.Location())
+	case Yearly:
+		return date(day.Year()+n, time.January, 1, day.Location())
+	default:
+		return day.AddDate(0, 0, n)
+	}
+}
+
+// candidates returns the occurrences of the period that starts at periodStart, at the
+// clock time of dtstart
+func (r Rule) candidates(dtstart, periodStart time.Time) []time.Time {
+	var days []time.Time
+	switch r.Freq {
+	case Weekly:
+		if len(r.ByDay) == 0 {
+			days = append(days, periodStart.AddDate(0, 0, (int(dtstart.Weekday())-int(r.WeekStart)+7)%7))
+		}
+		for _, d := range r.ByDay {
+			days = append(days, periodStart.AddDate(0, 0, (int(d.Day)-int(r.WeekStart)+7)%7))
+		}
+	case Monthly:
+		days = r.monthDays(dtstart, periodStart.Year(), periodStart.Month())
+	case Yearly:
+		days = r.yearDays(dtstart, periodStart.Year())
+	default:
+		days = append(days, periodStart)
+	}
+
+	days = slices.DeleteFunc(days, func(day time.Time) bool { return !r.matches(day) })
+	slices.SortFunc(days, func(a, b time.Time) int { return a.Compare(b) })
+	days = slices.CompactFunc(days, func(a, b time.Time) bool { return a.Equal(b) })
+	days = applySetPos(days, r.BySetPos)
+
+	occurrences := make([]time.Time, 0, len(days))
+	for _, day := range days {
+		occurrences = append(occurrences, time.Date(day.Year(), day.Month(), day.Day(),
+			dtstart.Hour(), dtstart.Minute(), dtstart.Second(), dtstart.Nanosecond(), dtstart.Location()))
+	}
+	return occurrences
+}
+
+// matches applies the BYxxx parts that limit the days of a period
+func (r Rule) matches(day time.Time) bool {
+	if len(r.ByMonth) > 0 && !slices.Contains(r.ByMonth, int(day.Month())) {
+		return false
+	}
+	if r.Freq == Daily {
+		if len(r.ByMonthDay) > 0 && !matchesMonthDay(day, r.ByMonthDay) {
+			return false
+		}
+		if len(r.ByDay) > 0 && !slices.ContainsFunc(r.ByDay, func(d Weekday) bool { return d.Day == day.Weekday() }) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r Rule) monthDays(dtstart time.Time, year int, month time.Month) []time.Time {
+	loc := dtstart.Location()
+	daysInMonth := date(year, month+1, 0, loc).Day()
+	var days []time.Time
+	switch {
+	case len(r.ByMonthDay) > 0:
+		for _, md := range r.ByMonthDay {
+			if md < 0 {
+				md = daysInMonth + md + 1
+			}
+			if md < 1 || md > daysInMonth {
+				continue
+			}
+			day := date(year, month, md, loc)
+			// BYDAY limits BYMONTHDAY
+			if len(r.ByDay) > 0 && !slices.ContainsFunc(r.ByDay, func(d Weekday) bool {
+				return d.Day == day.Weekday() && (d.N == 0 || slices.ContainsFunc(nthWeekdays(date(year, month, 1, loc), daysInMonth, d), day.Equal))
+			}) {
+				continue
+			}
+			days = append(days, day)
+		}
+	case len(r.ByDay) > 0:
+		for _, d := range r.ByDay {
+			days = append(days, nthWeekdays(date(year, month, 1, loc), daysInMonth, d)...)
+		}
+	default:
+		// months without the day of DTSTART are skipped
+		if dtstart.Day() <= daysInMonth {
+			days = append(days, date(year, month, dtstart.Day(), loc))
+		}
+	}
+	return days
+}
+
+func (r Rule) yearDays(dtstart time.Time, year int) []time.Time {
+	loc := dtstart.Location()
+	if len(r.ByMonth) == 0 && len(r.ByMonthDay) == 0 && len(r.ByDay) > 0 {
+		first := date(year, time.January, 1, loc)
+		daysInYear := date(year+1, time.January, 1, loc).Sub(first).Hours() / 24
+		var days []time.Time
+		for _, d := range r.ByDay {
+			days = append(days, nthWeekdays(first, int(daysInYear+0.5), d)...)
+		}
+		return days
+	}
+
+	months := r.ByMonth
+	if len(months) == 0 {
+		if len(r.ByMonthDay) > 0 {
+			months = []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+		} else {
+			months = []int{int(dtstart.Month())}
+		}
+	}
+	var days []time.Time
+	for _, m := range months {
+		days = append(days, r.monthDays(dtstart, year, time.Month(m))...)
+	}
+	return days
+}
+
+// nthWeekdays returns the days of the span of length days starting at first that match
+// the weekday, every one of them when N is 0
+func nthWeekdays(first time.Time, length int, d Weekday) []time.Time {
+	offset := (int(d.Day) - int(first.Weekday()) + 7) % 7
+	var all []time.Time
+	for i := offset; i < length; i += 7 {
+		all = append(all, first.AddDate(0, 0, i))
+	}
+	switch {
+	case d.N == 0:
+		return all
+	case d.N > 0 && d.N <= len(all):
+		return []time.Time{all[d.N-1]}
+	case d.N < 0 && -d.N <= len(all):
+		return []time.Time{all[len(all)+d.N]}
+	}
+	return nil
+}
+
+func applySetPos(days []time.Time, positions []int) []time.Time {
+	if len(positions) == 0 {
+		return days
+	}
+	var selected []time.Time
+	for _, pos := range positions {
+		i := pos - 1
+		if pos < 0 {
+			i = len(days) + pos
+		}
+		if i >= 0 && i < len(days) {
+			selected = append(selected, days[i])
+		}
+	}
+	slices.SortFunc(selected, func(a, b time.Time) int { return a.Compare(b) })
+	return slices.CompactFunc(selected, func(a, b time.Time) bool { return a.Equal(b) })
+}
+
+func matchesMonthDay(day time.Time, monthDays []int) bool {
+	daysInMonth := date(day.Year(), day.Month()+1, 0, day.Location()).Day()
+	for _, md := range monthDays {
+		if md == day.Day() || (md < 0 && daysInMonth+md+1 == day.Day()) {
+			return true
+		}
+	}
+	return false
+}
+
+func date(year int, month time.Month, day int, loc *time.Location) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
+
+func isMidnight(t time.Time) bool {
+	return t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0
+}
+
+func sameDate(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.Month() == b.Month() && a.Day() == b.Day()
+}
@@ -0,0 +1,294 @@
+// Package rrule implements the recurrence rules of RFC 5545 (iCalendar) used by
+// appointment series: parsing and formatting RRULE values and expanding them together
+// with RDATE and EXDATE into occurrences.
+//
+// Times are floating: the rule is evaluated on the wall clock of DTSTART and the location
+// of DTSTART is kept for all occurrences. Recurrence below a day (BYHOUR, BYMINUTE,
+// BYSECOND, HOURLY and smaller frequencies) is not supported.
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+	Yearly  Frequency = "YEARLY"
+)
+
+var ErrInvalidRule = errors.New("invalid recurrence rule")
+
+// Weekday is a BYDAY entry, N is the ordinal within the month or year (1 is the first,
+// -1 the last) and 0 selects every such weekday.
+type Weekday struct {
+	Day time.Weekday
+	N   int
+}
+
+// Rule is a parsed RRULE. Until is the zero time when the rule has no UNTIL. Like DTSTART
+// it is floating, its wall clock is read in the location of DTSTART, unless UntilUTC is set
+// because the RRULE gave it in UTC.
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	Count      int
+	Until      time.Time
+	UntilUTC   bool
+	ByDay      []Weekday
+	ByMonthDay []int
+	ByMonth    []int
+	BySetPos   []int
+	WeekStart  time.Weekday
+}
+
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+func weekdayCode(d time.Weekday) string {
+	return strings.ToUpper(d.String()[:2])
+}
+
+// Parse parses an RRULE value such as "FREQ=WEEKLY;BYDAY=TU,TH;UNTIL=20251231". A leading
+// "RRULE:" is accepted.
+func Parse(value string) (Rule, error) {
+	rule := Rule{Interval: 1, WeekStart: time.Monday}
+	value = strings.TrimSpace(value)
+	value = strings.TrimPrefix(strings.TrimPrefix(value, "RRULE:"), "rrule:")
+	if value == "" {
+		return Rule{}, fmt.Errorf("%w: empty rule", ErrInvalidRule)
+	}
+
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(value, ";") {
+		if part == "" {
+			continue
+		}
+		name, val, ok := strings.Cut(part, "=")
+		if !ok || val == "" {
+			return Rule{}, fmt.Errorf("%w: malformed part %q", ErrInvalidRule, part)
+		}
+		name = strings.ToUpper(strings.TrimSpace(name))
+		val = strings.ToUpper(strings.TrimSpace(val))
+		if seen[name] {
+			return Rule{}, fmt.Errorf("%w: %s given more than once", ErrInvalidRule, name)
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			rule.Freq = Frequency(val)
+			if !slices.Contains([]Frequency{Daily, Weekly, Monthly, Yearly}, rule.Freq) {
+				err = fmt.Errorf("unsupported frequency %s", val)
+			}
+		case "INTERVAL":
+			rule.Interval, err = strconv.Atoi(val)
+			if err == nil && rule.Interval < 1 {
+				err = errors.New("INTERVAL must be positive")
+			}
+		case "COUNT":
+			rule.Count, err = strconv.Atoi(val)
+			if err == nil && rule.Count < 1 {
+				err = errors.New("COUNT must be positive")
+			}
+		case "UNTIL":
+			rule.UntilUTC = strings.HasSuffix(val, "Z")
+			rule.Until, err = parseUntil(val)
+		case "BYDAY":
+			rule.ByDay, err = parseByDay(val)
+		case "BYMONTHDAY":
+			rule.ByMonthDay, err = parseInts(val, -31, 31)
+		case "BYMONTH":
+			rule.ByMonth, err = parseInts(val, 1, 12)
+		case "BYSETPOS":
+			rule.BySetPos, err = parseInts(val, -366, 366)
+		case "WKST":
+			day, ok := weekdayCodes[val]
+			if !ok {
+				err = fmt.Errorf("unknown weekday %s", val)
+			}
+			rule.WeekStart = day
+		default:
+			err = fmt.Errorf("unsupported part %s", name)
+		}
+		if err != nil {
+			return Rule{}, fmt.Errorf("%w: %v", ErrInvalidRule, err)
+		}
+	}
+
+	if err := rule.Validate(); err != nil {
+		return Rule{}, err
+	}
+	return rule, nil
+}
+
+// Validate checks the combinations of parts that RFC 5545 does not allow
+func (r Rule) Validate() error {
+	if r.Freq == "" {
+		return fmt.Errorf("%w: FREQ is required", ErrInvalidRule)
+	}
+	if r.Count > 0 && !r.Until.IsZero() {
+		return fmt.Errorf("%w: COUNT and UNTIL cannot both be given", ErrInvalidRule)
+	}
+	if r.Freq == Weekly && len(r.ByMonthDay) > 0 {
+		return fmt.Errorf("%w: BYMONTHDAY cannot be used with FREQ=WEEKLY", ErrInvalidRule)
+	}
+	if r.Freq != Monthly && r.Freq != Yearly {
+		for _, d := range r.ByDay {
+			if d.N != 0 {
+				return fmt.Errorf("%w: ordinal BYDAY needs FREQ=MONTHLY or FREQ=YEARLY", ErrInvalidRule)
+			}
+		}
+	}
+	if len(r.BySetPos) > 0 && len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 && len(r.ByMonth) == 0 {
+		return fmt.Errorf("%w: BYSETPOS needs another BYxxx part", ErrInvalidRule)
+	}
+	if slices.Contains(r.ByMonthDay, 0) || slices.Contains(r.BySetPos, 0) {
+		return fmt.Errorf("%w: 0 is not a valid position", ErrInvalidRule)
+	}
+	return nil
+}
+
+// String formats the rule as an RRULE value without the "RRULE:" prefix
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.UntilUTC {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	} else if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.Format("20060102T150405"))
+	}
+	if len(r.ByMonth) > 0 {
+		parts = append(parts, "BYMONTH="+joinInts(r.ByMonth))
+	}
+	if len(r.ByMonthDay) > 0 {
+		parts = append(parts, "BYMONTHDAY="+joinInts(r.ByMonthDay))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, 0, len(r.ByDay))
+		for _, d := range r.ByDay {
+			code := weekdayCode(d.Day)
+			if d.N != 0 {
+				code = strconv.Itoa(d.N) + code
+			}
+			days = append(days, code)
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.BySetPos) > 0 {
+		parts = append(parts, "BYSETPOS="+joinInts(r.BySetPos))
+	}
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+weekdayCode(r.WeekStart))
+	}
+	return strings.Join(parts, ";")
+}
+
+// FromInterval builds the rule of the simple DAILY, WEEKLY and MONTHLY recurrence that
+// appointment templates supported before RRULE. until is inclusive, the zero time means
+// no end.
+func FromInterval(freq string, interval int, until time.Time) (Rule, error) {
+	if interval < 1 {
+		interval = 1
+	}
+	rule := Rule{
+		Freq:      Frequency(strings.ToUpper(freq)),
+		Interval:  interval,
+		WeekStart: time.Monday,
+	}
+	if !until.IsZero() {
+		rule.Until = time.Date(until.Year(), until.Month(), until.Day(), 23, 59, 59, 0, until.Location())
+	}
+	if rule.Freq != Daily && rule.Freq != Weekly && rule.Freq != Monthly {
+		return Rule{}, fmt.Errorf("%w: unsupported recurrence type %s", ErrInvalidRule, freq)
+	}
+	return rule, nil
+}
+
+// until returns UNTIL in the frame of a DTSTART in loc: a floating UNTIL gets the same wall
+// clock in loc, an UNTIL in UTC is an instant already
+func (r Rule) until(loc *time.Location) time.Time {
+	if r.Until.IsZero() || r.UntilUTC {
+		return r.Until
+	}
+	u := r.Until
+	return time.Date(u.Year(), u.Month(), u.Day(), u.Hour(), u.Minute(), u.Second(), u.Nanosecond(), loc)
+}
+
+func parseUntil(val string) (time.Time, error) {
+	val = strings.TrimSuffix(val, "Z")
+	if t, err := time.Parse("20060102T150405", val); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("20060102", val)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid UNTIL %s", val)
+	}
+	// a date includes the whole day
+	return t.Add(24*time.Hour - time.Second), nil
+}
+
+func parseByDay(val string) ([]Weekday, error) {
+	var days []Weekday
+	for _, item := range strings.Split(val, ",") {
+		item = strings.TrimSpace(item)
+		if len(item) < 2 {
+			return nil, fmt.Errorf("invalid BYDAY %s", item)
+		}
+		day, ok := weekdayCodes[item[len(item)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("unknown weekday %s", item)
+		}
+		var n int
+		if prefix := item[:len(item)-2]; prefix != "" {
+			var err error
+			n, err = strconv.Atoi(prefix)
+			if err != nil || n == 0 || n < -53 || n > 53 {
+				return nil, fmt.Errorf("invalid BYDAY ordinal %s", item)
+			}
+		}
+		days = append(days, Weekday{Day: day, N: n})
+	}
+	return days, nil
+}
+
+func parseInts(val string, min, max int) ([]int, error) {
+	var values []int
+	for _, item := range strings.Split(val, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(item))
+		if err != nil || n < min || n > max {
+			return nil, fmt.Errorf("invalid value %s", item)
+		}
+		values = append(values, n)
+	}
+	return values, nil
+}
+
+func joinInts(values []int) string {
+	items := make([]string, 0, len(values))
+	for _, v := range values {
+		items = append(items, strconv.Itoa(v))
+	}
+	return strings.Join(items, ",")
+}
@@ -0,0 +1,221 @@
+package rrule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func at(year int, month time.Month, day, hour, min int) time.Time {
+	return time.Date(year, month, day, hour, min, 0, 0, time.UTC)
+}
+
+func expand(t *testing.T, rule string, dtstart, from, to time.Time) []time.Time {
+	t.Helper()
+	r, err := Parse(rule)
+	require.NoError(t, err)
+	return Set{DTStart: dtstart, Rule: &r}.Between(from, to)
+}
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name  string
+		value string
+		check func(t *testing.T, rule Rule, err error)
+	}{
+		{
+			name:  "weekly by day",
+			value: "RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH;UNTIL=20251231",
+			check: func(t *testing.T, rule Rule, err error) {
+				require.NoError(t, err)
+				require.Equal(t, Weekly, rule.Freq)
+				require.Equal(t, 2, rule.Interval)
+				require.Equal(t, []Weekday{{Day: time.Tuesday}, {Day: time.Thursday}}, rule.ByDay)
+				require.Equal(t, at(2025, time.December, 31, 23, 59).Add(59*time.Second), rule.Until)
+			},
+		},
+		{
+			name:  "ordinal by day",
+			value: "FREQ=MONTHLY;BYDAY=1MO,-1FR",
+			check: func(t *testing.T, rule Rule, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []Weekday{{Day: time.Monday, N: 1}, {Day: time.Friday, N: -1}}, rule.ByDay)
+			},
+		},
+		{
+			name:  "missing freq",
+			value: "INTERVAL=2",
+			check: func(t *testing.T, rule Rule, err error) {
+				require.ErrorIs(t, err, ErrInvalidRule)
+			},
+		},
+		{
+			name:  "count and until",
+			value: "FREQ=DAILY;COUNT=3;UNTIL=20250101",
+			check: func(t *testing.T, rule Rule, err error) {
+				require.ErrorIs(t, err, ErrInvalidRule)
+			},
+		},
+		{
+			name:  "unsupported frequency",
+			value: "FREQ=HOURLY",
+			check: func(t *testing.T, rule Rule, err error) {
+				require.ErrorIs(t, err, ErrInvalidRule)
+			},
+		},
+		{
+			name:  "ordinal on weekly",
+			value: "FREQ=WEEKLY;BYDAY=1MO",
+			check: func(t *testing.T, rule Rule, err error) {
+				require.ErrorIs(t, err, ErrInvalidRule)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rule, err := Parse(tc.value)
+			tc.check(t, rule, err)
+		})
+	}
+}
+
+func TestRuleStringRoundTrip(t *testing.T) {
+	value := "FREQ=MONTHLY;INTERVAL=2;COUNT=10;BYMONTH=1,7;BYDAY=1MO,-1FR;BYSETPOS=1;WKST=SU"
+	rule, err := Parse(value)
+	require.NoError(t, err)
+	require.Equal(t, value, rule.String())
+
+	again, err := Parse(rule.String())
+	require.NoError(t, err)
+	require.Equal(t, rule, again)
+}
+
+func TestExpandTuesdayAndThursday(t *testing.T) {
+	dtstart := at(2025, time.March, 4, 9, 30) // a Tuesday
+	occurrences := expand(t, "FREQ=WEEKLY;BYDAY=TU,TH", dtstart, dtstart, at(2025, time.March, 15, 0, 0))
+	require.Equal(t, []time.Time{
+		at(2025, time.March, 4, 9, 30),
+		at(2025, time.March, 6, 9, 30),
+		at(2025, time.March, 11, 9, 30),
+		at(2025, time.March, 13, 9, 30),
+	}, occurrences)
+}
+
+func TestExpandFirstMondayOfMonth(t *testing.T) {
+	dtstart := at(2025, time.January, 6, 14, 0)
+	occurrences := expand(t, "FREQ=MONTHLY;BYDAY=1MO;COUNT=4", dtstart, dtstart, at(2026, time.January, 1, 0, 0))
+	require.Equal(t, []time.Time{
+		at(2025, time.January, 6, 14, 0),
+		at(2025, time.February, 3, 14, 0),
+		at(2025, time.March, 3, 14, 0),
+		at(2025, time.April, 7, 14, 0),
+	}, occurrences)
+}
+
+func TestExpandLastWorkdayOfMonth(t *testing.T) {
+	dtstart := at(2025, time.January, 31, 8, 0)
+	occurrences := expand(t, "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1", dtstart, dtstart, at(2025, time.April, 1, 0, 0))
+	require.Equal(t, []time.Time{
+		at(2025, time.January, 31, 8, 0),
+		at(2025, time.February, 28, 8, 0),
+		at(2025, time.March, 31, 8, 0),
+	}, occurrences)
+}
+
+func TestExpandSkipsMonthsWithoutDay(t *testing.T) {
+	dtstart := at(2025, time.January, 31, 10, 0)
+	occurrences := expand(t, "FREQ=MONTHLY", dtstart, dtstart, at(2025, time.June, 1, 0, 0))
+	require.Equal(t, []time.Time{
+		at(2025, time.January, 31, 10, 0),
+		at(2025, time.March, 31, 10, 0),
+		at(2025, time.May, 31, 10, 0),
+	}, occurrences)
+}
+
+func TestExpandYearly(t *testing.T) {
+	dtstart := at(2024, time.November, 28, 12, 0)
+	// fourth Thursday of November
+	occurrences := expand(t, "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH", dtstart, dtstart, at(2027, time.January, 1, 0, 0))
+	require.Equal(t, []time.Time{
+		at(2024, time.November, 28, 12, 0),
+		at(2025, time.November, 27, 12, 0),
+		at(2026, time.November, 26, 12, 0),
+	}, occurrences)
+}
+
+func TestExpandUntilAndRange(t *testing.T) {
+	dtstart := at(2025, time.May, 1, 9, 0)
+	occurrences := expand(t, "FREQ=DAILY;INTERVAL=3;UNTIL=20250513", dtstart, at(2025, time.May, 5, 0, 0), at(2025, time.June, 1, 0, 0))
+	require.Equal(t, []time.Time{
+		at(2025, time.May, 7, 9, 0),
+		at(2025, time.May, 10, 9, 0),
+		at(2025, time.May, 13, 9, 0),
+	}, occurrences)
+}
+
+func TestExpandUntilInDTStartLocation(t *testing.T) {
+	for _, offset := range []int{-5, 2} {
+		loc := time.FixedZone("local", offset*60*60)
+		dtstart := time.Date(2025, time.May, 11, 9, 0, 0, 0, loc)
+		to := time.Date(2025, time.June, 1, 0, 0, 0, 0, loc)
+
+		// a floating UNTIL is the wall clock of DTSTART, so the occurrence at 09:00 on the
+		// UNTIL day is the last one
+		occurrences := expand(t, "FREQ=DAILY;UNTIL=20250513T090000", dtstart, dtstart, to)
+		require.Len(t, occurrences, 3)
+		require.Equal(t, time.Date(2025, time.May, 13, 9, 0, 0, 0, loc), occurrences[2])
+
+		occurrences = expand(t, "FREQ=DAILY;UNTIL=20250513T083000", dtstart, dtstart, to)
+		require.Len(t, occurrences, 2)
+	}
+
+	// an UNTIL in UTC is an instant, 09:00 at UTC-5 is 14:00 UTC
+	dtstart := time.Date(2025, time.May, 11, 9, 0, 0, 0, time.FixedZone("local", -5*60*60))
+	occurrences := expand(t, "FREQ=DAILY;UNTIL=20250513T130000Z", dtstart, dtstart, dtstart.AddDate(0, 1, 0))
+	require.Len(t, occurrences, 2)
+
+	rule, err := Parse("FREQ=DAILY;UNTIL=20250513T130000Z")
+	require.NoError(t, err)
+	require.Equal(t, "FREQ=DAILY;UNTIL=20250513T130000Z", rule.String())
+}
+
+func TestExpandCountIncludesEarlierOccurrences(t *testing.T) {
+	dtstart := at(2025, time.May, 1, 9, 0)
+	occurrences := expand(t, "FREQ=DAILY;COUNT=5", dtstart, at(2025, time.May, 4, 0, 0), at(2025, time.June, 1, 0, 0))
+	require.Equal(t, []time.Time{
+		at(2025, time.May, 4, 9, 0),
+		at(2025, time.May, 5, 9, 0),
+	}, occurrences)
+}
+
+func TestSetWithExDatesAndRDates(t *testing.T) {
+	dtstart := at(2025, time.December, 22, 10, 0) // a Monday
+	rule, err := Parse("FREQ=WEEKLY;BYDAY=MO,TH")
+	require.NoError(t, err)
+	set := Set{
+		DTStart: dtstart,
+		Rule:    &rule,
+		// Christmas as a date and one exact occurrence
+		ExDates: []time.Time{at(2025, time.December, 25, 0, 0), at(2025, time.December, 29, 10, 0)},
+		RDates:  []time.Time{at(2025, time.December, 27, 11, 0)},
+	}
+
+	occurrences := set.Between(dtstart, at(2026, time.January, 3, 0, 0))
+	require.Equal(t, []time.Time{
+		at(2025, time.December, 22, 10, 0),
+		at(2025, time.December, 27, 11, 0),
+		at(2026, time.January, 1, 10, 0),
+	}, occurrences)
+	require.True(t, set.Occurs(at(2026, time.January, 1, 10, 0)))
+	require.False(t, set.Occurs(at(2025, time.December, 25, 10, 0)))
+}
+
+func TestFromInterval(t *testing.T) {
+	rule, err := FromInterval("weekly", 2, at(2025, time.June, 30, 0, 0))
+	require.NoError(t, err)
+	require.Equal(t, "FREQ=WEEKLY;INTERVAL=2;UNTIL=20250630T235959", rule.String())
+
+	_, err = FromInterval("NONE", 1, time.Time{})
+	require.ErrorIs(t, err, ErrInvalidRule)
+}
@@ -3,6 +3,7 @@ package appointment
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"maicare_go/async/aclient"
 	db "maicare_go/db/sqlc"
@@ -12,6 +13,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"go.uber.org/zap"
 )
@@ -35,7 +37,7 @@ func (s *appointmentService) CreateAppointment(req *CreateAppointmentRequest, us
 		}
 	}
 	req.ParticipantEmployeeIDs = filteredParticipants
-	if req.RecurrenceType == "NONE" {
+	if (req.RRule == nil || *req.RRule == "") && (req.RecurrenceType == "NONE" || req.RecurrenceType == "") {
 		return s.createNormalAppointment(req, employee.EmployeeID, employee.FirstName, employee.LastName, ctx)
 	} else {
 		return s.createRecurringAppointment(req, employee.EmployeeID, employee.FirstName, employee.LastName, ctx)
@@ -292,6 +294,12 @@ func (s *appointmentService) UpdateAppointment(
 		s.Logger.LogBusinessEvent(logger.LogLevelError, "UpdateAppointmentApi", "Start time is after end time")
 		return nil, fmt.Errorf("start time must be before end time")
 	}
+	if req.Scope == ScopeFollowing || req.Scope == ScopeAll {
+		return s.updateAppointmentSeries(ctx, appointmentID, req)
+	}
+	if req.RRule != nil && *req.RRule != "" {
+		return nil, fmt.Errorf("%w: the recurrence rule can only be changed for the following occurrences or the entire series", ErrInvalidRecurrence)
+	}
 
 	tx, err := s.Store.ConnPool.Begin(ctx)
 	if err != nil {
@@ -384,12 +392,52 @@ func (s *appointmentService) UpdateAppointment(
 
 func (s *appointmentService) DeleteAppointment(
 	ctx context.Context,
-	appointmentID uuid.UUID) error {
-	err := s.Store.DeleteAppointment(ctx, appointmentID)
+	appointmentID uuid.UUID,
+	req DeleteAppointmentRequest) error {
+	if req.Scope == ScopeFollowing || req.Scope == ScopeAll {
+		return s.deleteAppointmentSeries(ctx, appointmentID, req.Scope)
+	}
+
+	tx, err := s.Store.ConnPool.Begin(ctx)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "DeleteAppointmentApi", "Failed to begin transaction", zap.Error(err))
+		return fmt.Errorf("failed to delete appointment")
+	}
+	defer func() {
+		err := tx.Rollback(ctx)
+		if err != nil && err != sql.ErrTxDone {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "DeleteAppointmentApi", "Failed to rollback transaction", zap.Error(err))
+		}
+	}()
+	qtx := s.Store.WithTx(tx)
+
+	appointment, err := qtx.GetScheduledAppointment(ctx, appointmentID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "DeleteAppointmentApi", "Failed to get appointment", zap.Error(err))
+		return fmt.Errorf("failed to delete appointment")
+	}
+	// a deleted occurrence of a series is excluded so it is not stored again
+	if err == nil && appointment.AppointmentTemplatesID != nil && appointment.RecurrenceID.Valid {
+		err = qtx.AddAppointmentTemplateExdate(ctx, db.AddAppointmentTemplateExdateParams{
+			Exdate: appointment.RecurrenceID,
+			ID:     *appointment.AppointmentTemplatesID,
+		})
+		if err != nil {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "DeleteAppointmentApi", "Failed to exclude occurrence from series", zap.Error(err))
+			return fmt.Errorf("failed to delete appointment")
+		}
+	}
+
+	err = qtx.DeleteAppointment(ctx, appointmentID)
 	if err != nil {
 		s.Logger.LogBusinessEvent(logger.LogLevelError, "DeleteAppointmentApi", "Failed to delete appointment", zap.Error(err))
 		return fmt.Errorf("failed to delete appointment")
 	}
+
+	if err := tx.Commit(ctx); err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "DeleteAppointmentApi", "Failed to commit transaction", zap.Error(err))
+		return fmt.Errorf("failed to delete appointment")
+	}
 	s.Logger.LogBusinessEvent(logger.LogLevelInfo, "DeleteAppointmentApi", "Appointment deleted successfully", zap.String("appointment_id", appointmentID.String()))
 	return nil
 }
@@ -516,16 +564,31 @@ func (s *appointmentService) createRecurringAppointment(
 	employeeLastName string,
 	ctx context.Context) (*CreateAppointmentResponse, error) {
 
+	rule, err := RecurrenceRule(req)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CreateAppointmentApi", "Invalid recurrence", zap.Error(err))
+		return nil, err
+	}
+	recurrenceType := string(rule.Freq)
+	recurrenceInterval := int32(rule.Interval)
+	recurrenceEndDate := pgtype.Date{Time: rule.Until, Valid: !rule.Until.IsZero()}
+	ruleValue := rule.String()
+
 	appointmentTemp, err := s.Store.CreateAppointmentTemplate(ctx, db.CreateAppointmentTemplateParams{
-		CreatorEmployeeID:  employeeID,
-		StartTime:          pgtype.Timestamp{Time: req.StartTime, Valid: true},
-		EndTime:            pgtype.Timestamp{Time: req.EndTime, Valid: true},
-		Location:           req.Location,
-		Description:        req.Description,
-		Color:              req.Color,
-		RecurrenceType:     &req.RecurrenceType,
-		RecurrenceInterval: req.RecurrenceInterval,
-		RecurrenceEndDate:  pgtype.Date{Time: req.RecurrenceEndDate, Valid: true},
+		CreatorEmployeeID:      employeeID,
+		StartTime:              pgtype.Timestamp{Time: req.StartTime, Valid: true},
+		EndTime:                pgtype.Timestamp{Time: req.EndTime, Valid: true},
+		Location:               req.Location,
+		Description:            req.Description,
+		Color:                  req.Color,
+		RecurrenceType:         &recurrenceType,
+		RecurrenceInterval:     &recurrenceInterval,
+		RecurrenceEndDate:      recurrenceEndDate,
+		Rrule:                  &ruleValue,
+		Exdates:                toTimestamps(req.ExDates),
+		Rdates:                 toTimestamps(req.RDates),
+		ParticipantEmployeeIds: append([]int64{}, req.ParticipantEmployeeIDs...),
+		ClientIds:              append([]int64{}, req.ClientIDs...),
 	})
 	if err != nil {
 		s.Logger.LogBusinessEvent(logger.LogLevelError, "CreateAppointmentApi", "Failed to create appointment template", zap.Error(err))
@@ -533,9 +596,7 @@ func (s *appointmentService) createRecurringAppointment(
 	}
 
 	err = s.AsynqClient.EnqueueAppointmentTask(ctx, aclient.AppointmentPayload{
-		AppointmentTemplateID:  appointmentTemp.ID,
-		ParticipantEmployeeIDs: req.ParticipantEmployeeIDs,
-		ClientIDs:              req.ClientIDs,
+		AppointmentTemplateID: appointmentTemp.ID,
 	})
 	if err != nil {
 		s.Logger.LogBusinessEvent(logger.LogLevelError, "CreateAppointmentApi", "Failed to enqueue appointment creation task", zap.Error(err))
@@ -21,18 +21,22 @@ type ClientsDetails struct {
 	LastName  string `json:"last_name"`
 }
 
-// CreateAppointmentRequest represents the request payload for creating an appointment
+// CreateAppointmentRequest represents the request payload for creating an appointment. RRule is an
+// RFC 5545 recurrence rule, it replaces the recurrence type, interval and end date when given.
 type CreateAppointmentRequest struct {
-	StartTime              time.Time `json:"start_time" binding:"required" example:"2023-10-01T10:00:00Z"`
-	EndTime                time.Time `json:"end_time" binding:"required"`
-	Location               *string   `json:"location"`
-	Description            *string   `json:"description"`
-	Color                  *string   `json:"color" example:"#FF5733"`
-	RecurrenceType         string    `json:"recurrence_type" example:"NONE" enum:"NONE,DAILY,WEEKLY,MONTHLY"`
-	RecurrenceInterval     *int32    `json:"recurrence_interval"`
-	RecurrenceEndDate      time.Time `json:"recurrence_end_date" example:"2025-10-01T10:00:00Z"`
-	ParticipantEmployeeIDs []int64   `json:"participant_employee_ids"`
-	ClientIDs              []int64   `json:"client_ids"`
+	StartTime              time.Time   `json:"start_time" binding:"required" example:"2023-10-01T10:00:00Z"`
+	EndTime                time.Time   `json:"end_time" binding:"required"`
+	Location               *string     `json:"location"`
+	Description            *string     `json:"description"`
+	Color                  *string     `json:"color" example:"#FF5733"`
+	RecurrenceType         string      `json:"recurrence_type" example:"NONE" enum:"NONE,DAILY,WEEKLY,MONTHLY"`
+	RecurrenceInterval     *int32      `json:"recurrence_interval"`
+	RecurrenceEndDate      time.Time   `json:"recurrence_end_date" example:"2025-10-01T10:00:00Z"`
+	RRule                  *string     `json:"rrule" example:"FREQ=WEEKLY;BYDAY=TU,TH;UNTIL=20251231"`
+	ExDates                []time.Time `json:"exdates"`
+	RDates                 []time.Time `json:"rdates"`
+	ParticipantEmployeeIDs []int64     `json:"participant_employee_ids"`
+	ClientIDs              []int64     `json:"client_ids"`
 }
 
 // CreateAppointmentResponse represents the response payload for creating an appointment
@@ -129,7 +133,10 @@ type GetAppointmentResponse struct {
 }
 
 
-// UpdateAppointmentRequest represents the request payload for updating an appointment
+// UpdateAppointmentRequest represents the request payload for updating an appointment. For an
+// occurrence of a series, Scope selects the occurrences that change: only this one (the
+// default), this and the following ones or the entire series. RRule replaces the recurrence
+// rule for the following and all scopes.
 type UpdateAppointmentRequest struct {
 	StartTime              time.Time `json:"start_time" binding:"required" example:"2023-10-01T10:00:00Z"`
 	EndTime                time.Time `json:"end_time" binding:"required"`
@@ -138,6 +145,14 @@ type UpdateAppointmentRequest struct {
 	Description            *string   `json:"description"`
 	ClientIDs              *[]int64  `json:"client_ids"`
 	ParticipantEmployeeIDs *[]int64  `json:"participant_employee_ids"`
+	Scope                  string    `json:"scope" binding:"omitempty,oneof=this following all" example:"this"`
+	RRule                  *string   `json:"rrule" example:"FREQ=WEEKLY;BYDAY=TU"`
+}
+
+// DeleteAppointmentRequest represents the query of deleting an appointment, Scope works as for
+// UpdateAppointmentRequest
+type DeleteAppointmentRequest struct {
+	Scope string `form:"scope" binding:"omitempty,oneof=this following all"`
 }
 
 // UpdateAppointmentResponse represents the response payload for updating an appointment
@@ -158,3 +173,23 @@ type UpdateAppointmentResponse struct {
 	UpdatedAt              pgtype.Timestamp `json:"updated_at"`
 }
 
+// ListSeriesOccurrencesRequest represents the query for listing the occurrences of an appointment series in a date range
+type ListSeriesOccurrencesRequest struct {
+	StartDate time.Time `form:"start_date" binding:"required" example:"2025-04-27T00:00:00Z"`
+	EndDate   time.Time `form:"end_date" binding:"required" example:"2025-05-31T23:59:59Z"`
+}
+
+// SeriesOccurrenceResponse represents an occurrence of an appointment series. AppointmentID is
+// empty for occurrences beyond the stored window of the series.
+type SeriesOccurrenceResponse struct {
+	AppointmentID *uuid.UUID `json:"appointment_id"`
+	SeriesID      uuid.UUID  `json:"series_id"`
+	RecurrenceID  time.Time  `json:"recurrence_id"`
+	StartTime     time.Time  `json:"start_time"`
+	EndTime       time.Time  `json:"end_time"`
+	Location      *string    `json:"location"`
+	Description   *string    `json:"description"`
+	Color         *string    `json:"color"`
+	Status        string     `json:"status"`
+	IsException   bool       `json:"is_exception"`
+}
@@ -0,0 +1,342 @@
+package appointment
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	db "maicare_go/db/sqlc"
+	"maicare_go/logger"
+	"maicare_go/rrule"
+	"maicare_go/util"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+// SeriesWindow is how far ahead the occurrences of an appointment series are stored as
+// scheduled appointments. The window is moved forward daily, later occurrences are
+// expanded when they are queried.
+const SeriesWindow = 180 * 24 * time.Hour
+
+// maxOccurrenceRange limits the range that is expanded for a single query
+const maxOccurrenceRange = 366 * 24 * time.Hour
+
+var (
+	ErrInvalidRecurrence = errors.New("invalid recurrence")
+	ErrSeriesNotFound    = errors.New("appointment series not found")
+	ErrInvalidRange      = errors.New("invalid date range")
+)
+
+// RecurrenceRule returns the recurrence rule of a new series: the RRULE when given, else
+// the rule of the recurrence type, interval and end date
+func RecurrenceRule(req *CreateAppointmentRequest) (rrule.Rule, error) {
+	var rule rrule.Rule
+	var err error
+	if req.RRule != nil && *req.RRule != "" {
+		rule, err = rrule.Parse(*req.RRule)
+	} else {
+		interval := 1
+		if req.RecurrenceInterval != nil {
+			interval = int(*req.RecurrenceInterval)
+		}
+		rule, err = rrule.FromInterval(req.RecurrenceType, interval, req.RecurrenceEndDate)
+	}
+	if err != nil {
+		return rrule.Rule{}, fmt.Errorf("%w: %w", ErrInvalidRecurrence, err)
+	}
+	return rule, nil
+}
+
+// templateRecurrence returns the recurrence set of a series. Templates created before
+// RRULE support only have the legacy recurrence columns.
+func templateRecurrence(template db.AppointmentTemplate) (rrule.Set, error) {
+	var rule rrule.Rule
+	var err error
+	switch {
+	case template.Rrule != nil:
+		rule, err = rrule.Parse(*template.Rrule)
+	case template.RecurrenceType != nil:
+		interval := 1
+		if template.RecurrenceInterval != nil {
+			interval = int(*template.RecurrenceInterval)
+		}
+		var until time.Time
+		if template.RecurrenceEndDate.Valid {
+			until = template.RecurrenceEndDate.Time
+		}
+		rule, err = rrule.FromInterval(*template.RecurrenceType, interval, until)
+	default:
+		err = errors.New("missing recurrence rule")
+	}
+	if err != nil {
+		return rrule.Set{}, fmt.Errorf("%w: %w", ErrInvalidRecurrence, err)
+	}
+
+	return rrule.Set{
+		DTStart: template.StartTime.Time,
+		Rule:    &rule,
+		RDates:  fromTimestamps(template.Rdates),
+		ExDates: fromTimestamps(template.Exdates),
+	}, nil
+}
+
+// MaterializeAppointmentSeries stores the occurrences of a series that start before until
+// as scheduled appointments. Occurrences that were stored before, edited or deleted are
+// left alone. It returns the number of appointments created.
+func (s *appointmentService) MaterializeAppointmentSeries(ctx context.Context, templateID uuid.UUID, until time.Time) (int, error) {
+	tx, err := s.Store.ConnPool.Begin(ctx)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "MaterializeAppointmentSeries", "Failed to begin transaction", zap.Error(err))
+		return 0, fmt.Errorf("failed to materialize appointment series")
+	}
+	defer func() {
+		err := tx.Rollback(ctx)
+		if err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "MaterializeAppointmentSeries", "Failed to rollback transaction", zap.Error(err))
+		}
+	}()
+
+	created, err := s.materializeSeries(ctx, s.Store.WithTx(tx), templateID, until)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "MaterializeAppointmentSeries", "Failed to commit transaction", zap.Error(err))
+		return 0, fmt.Errorf("failed to materialize appointment series")
+	}
+
+	s.Logger.LogBusinessEvent(logger.LogLevelInfo, "MaterializeAppointmentSeries", "Appointment series materialized",
+		zap.String("appointment_template_id", templateID.String()), zap.Int("created", created), zap.Time("until", until))
+	return created, nil
+}
+
+// materializeSeries does the work of MaterializeAppointmentSeries in the transaction of qtx
+func (s *appointmentService) materializeSeries(ctx context.Context, qtx *db.Queries, templateID uuid.UUID, until time.Time) (int, error) {
+	template, err := qtx.GetAppointmentTemplateForUpdate(ctx, templateID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, ErrSeriesNotFound
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "MaterializeAppointmentSeries", "Failed to get appointment template", zap.Error(err), zap.String("appointment_template_id", templateID.String()))
+		return 0, fmt.Errorf("failed to materialize appointment series")
+	}
+
+	from := template.StartTime.Time
+	if template.MaterializedUntil.Valid && template.MaterializedUntil.Time.After(from) {
+		from = template.MaterializedUntil.Time
+	}
+	if !until.After(from) {
+		return 0, nil
+	}
+
+	recurrence, err := templateRecurrence(template)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "MaterializeAppointmentSeries", "Invalid recurrence of appointment template", zap.Error(err), zap.String("appointment_template_id", templateID.String()))
+		return 0, err
+	}
+	duration := template.EndTime.Time.Sub(template.StartTime.Time)
+
+	created := 0
+	for _, occurrence := range recurrence.Between(from, until) {
+		appointmentID, err := qtx.CreateSeriesOccurrence(ctx, db.CreateSeriesOccurrenceParams{
+			AppointmentTemplatesID: &template.ID,
+			CreatorEmployeeID:      &template.CreatorEmployeeID,
+			RecurrenceID:           pgtype.Timestamp{Time: occurrence, Valid: true},
+			StartTime:              pgtype.Timestamp{Time: occurrence, Valid: true},
+			EndTime:                pgtype.Timestamp{Time: occurrence.Add(duration), Valid: true},
+			Location:               template.Location,
+			Description:            template.Description,
+			Color:                  template.Color,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				// already stored
+				continue
+			}
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "MaterializeAppointmentSeries", "Failed to create occurrence", zap.Error(err), zap.String("appointment_template_id", templateID.String()))
+			return 0, fmt.Errorf("failed to materialize appointment series")
+		}
+
+		if len(template.ParticipantEmployeeIds) > 0 {
+			err = qtx.BulkAddAppointmentParticipants(ctx, db.BulkAddAppointmentParticipantsParams{
+				AppointmentID: appointmentID,
+				EmployeeIds:   template.ParticipantEmployeeIds,
+			})
+			if err != nil {
+				s.Logger.LogBusinessEvent(logger.LogLevelError, "MaterializeAppointmentSeries", "Failed to add appointment participants", zap.Error(err))
+				return 0, fmt.Errorf("failed to materialize appointment series")
+			}
+		}
+		if len(template.ClientIds) > 0 {
+			err = qtx.BulkAddAppointmentClients(ctx, db.BulkAddAppointmentClientsParams{
+				AppointmentID: appointmentID,
+				ClientIds:     template.ClientIds,
+			})
+			if err != nil {
+				s.Logger.LogBusinessEvent(logger.LogLevelError, "MaterializeAppointmentSeries", "Failed to add appointment clients", zap.Error(err))
+				return 0, fmt.Errorf("failed to materialize appointment series")
+			}
+		}
+		created++
+	}
+
+	err = qtx.SetAppointmentTemplateMaterializedUntil(ctx, db.SetAppointmentTemplateMaterializedUntilParams{
+		MaterializedUntil: pgtype.Timestamp{Time: until, Valid: true},
+		ID:                template.ID,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "MaterializeAppointmentSeries", "Failed to update materialized window", zap.Error(err))
+		return 0, fmt.Errorf("failed to materialize appointment series")
+	}
+
+	return created, nil
+}
+
+// ExtendAppointmentSeries moves the stored window of all series forward to SeriesWindow
+// from now. A series that fails is logged and skipped.
+func (s *appointmentService) ExtendAppointmentSeries(ctx context.Context) (int, error) {
+	horizon := util.NetherlandsNow().Add(SeriesWindow)
+	templates, err := s.Store.ListAppointmentTemplatesToMaterialize(ctx, pgtype.Timestamp{Time: horizon, Valid: true})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ExtendAppointmentSeries", "Failed to list appointment templates", zap.Error(err))
+		return 0, fmt.Errorf("failed to extend appointment series")
+	}
+
+	total := 0
+	for _, template := range templates {
+		created, err := s.MaterializeAppointmentSeries(ctx, template.ID, horizon)
+		if err != nil {
+			s.Logger.LogBusinessEvent(logger.LogLevelWarn, "ExtendAppointmentSeries", "Failed to extend appointment series",
+				zap.Error(err), zap.String("appointment_template_id", template.ID.String()))
+			continue
+		}
+		total += created
+	}
+	return total, nil
+}
+
+// ListAppointmentSeriesOccurrences expands a series for a date range. Stored occurrences,
+// including edited ones, are returned as they are stored; occurrences beyond the stored
+// window are expanded from the recurrence rule and have no appointment ID yet.
+func (s *appointmentService) ListAppointmentSeriesOccurrences(ctx context.Context, templateID uuid.UUID, req ListSeriesOccurrencesRequest) ([]SeriesOccurrenceResponse, error) {
+	if !req.EndDate.After(req.StartDate) || req.EndDate.Sub(req.StartDate) > maxOccurrenceRange {
+		return nil, fmt.Errorf("%w: the end date must be after the start date and at most a year later", ErrInvalidRange)
+	}
+
+	template, err := s.Store.GetAppointmentTemplate(ctx, templateID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) || errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrSeriesNotFound
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ListAppointmentSeriesOccurrences", "Failed to get appointment template", zap.Error(err))
+		return nil, fmt.Errorf("failed to list appointment series occurrences")
+	}
+	recurrence, err := templateRecurrence(template)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := s.Store.ListSeriesOccurrencesInRange(ctx, db.ListSeriesOccurrencesInRangeParams{
+		TemplateID: &template.ID,
+		StartDate:  pgtype.Timestamp{Time: req.StartDate, Valid: true},
+		EndDate:    pgtype.Timestamp{Time: req.EndDate, Valid: true},
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ListAppointmentSeriesOccurrences", "Failed to list stored occurrences", zap.Error(err))
+		return nil, fmt.Errorf("failed to list appointment series occurrences")
+	}
+
+	return mergeOccurrences(template, recurrence.Between(req.StartDate, req.EndDate), stored, req.StartDate, req.EndDate), nil
+}
+
+// mergeOccurrences combines the expanded occurrences of a series with the stored ones. A
+// stored occurrence replaces the expanded occurrence with the same recurrence ID, stored
+// occurrences that were moved into the range are added.
+func mergeOccurrences(template db.AppointmentTemplate, expanded []time.Time, stored []db.ScheduledAppointment, start, end time.Time) []SeriesOccurrenceResponse {
+	storedByRecurrence := make(map[int64]db.ScheduledAppointment, len(stored))
+	for _, appt := range stored {
+		if appt.RecurrenceID.Valid {
+			storedByRecurrence[appt.RecurrenceID.Time.Unix()] = appt
+		}
+	}
+
+	duration := template.EndTime.Time.Sub(template.StartTime.Time)
+	used := make(map[uuid.UUID]bool)
+	occurrences := make([]SeriesOccurrenceResponse, 0, len(expanded))
+	for _, t := range expanded {
+		if appt, ok := storedByRecurrence[t.Unix()]; ok {
+			used[appt.ID] = true
+			if appt.StartTime.Time.Before(end) && appt.EndTime.Time.After(start) {
+				occurrences = append(occurrences, storedOccurrence(template.ID, appt))
+			}
+			continue
+		}
+		if template.MaterializedUntil.Valid && t.Before(template.MaterializedUntil.Time) {
+			// stored before and deleted since
+			continue
+		}
+		occurrences = append(occurrences, SeriesOccurrenceResponse{
+			SeriesID:     template.ID,
+			RecurrenceID: t,
+			StartTime:    t,
+			EndTime:      t.Add(duration),
+			Location:     template.Location,
+			Description:  template.Description,
+			Color:        template.Color,
+			Status:       "PENDING",
+		})
+	}
+	for _, appt := range stored {
+		if !used[appt.ID] && appt.StartTime.Time.Before(end) && appt.EndTime.Time.After(start) {
+			occurrences = append(occurrences, storedOccurrence(template.ID, appt))
+		}
+	}
+
+	slices.SortFunc(occurrences, func(a, b SeriesOccurrenceResponse) int {
+		return a.StartTime.Compare(b.StartTime)
+	})
+	return occurrences
+}
+
+func storedOccurrence(seriesID uuid.UUID, appt db.ScheduledAppointment) SeriesOccurrenceResponse {
+	recurrenceID := appt.StartTime.Time
+	if appt.RecurrenceID.Valid {
+		recurrenceID = appt.RecurrenceID.Time
+	}
+	return SeriesOccurrenceResponse{
+		AppointmentID: &appt.ID,
+		SeriesID:      seriesID,
+		RecurrenceID:  recurrenceID,
+		StartTime:     appt.StartTime.Time,
+		EndTime:       appt.EndTime.Time,
+		Location:      appt.Location,
+		Description:   appt.Description,
+		Color:         appt.Color,
+		Status:        appt.Status,
+		IsException:   appt.IsException,
+	}
+}
+
+func toTimestamps(times []time.Time) []pgtype.Timestamp {
+	timestamps := make([]pgtype.Timestamp, 0, len(times))
+	for _, t := range times {
+		timestamps = append(timestamps, pgtype.Timestamp{Time: t, Valid: true})
+	}
+	return timestamps
+}
+
+func fromTimestamps(timestamps []pgtype.Timestamp) []time.Time {
+	times := make([]time.Time, 0, len(timestamps))
+	for _, ts := range timestamps {
+		if ts.Valid {
+			times = append(times, ts.Time)
+		}
+	}
+	return times
+}
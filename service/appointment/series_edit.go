@@ -0,0 +1,410 @@
+package appointment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	db "maicare_go/db/sqlc"
+	"maicare_go/logger"
+	"maicare_go/notification"
+	"maicare_go/rrule"
+	"maicare_go/util"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+// Scopes of an update or delete of an occurrence of a series
+const (
+	ScopeThis      = "this"
+	ScopeFollowing = "following"
+	ScopeAll       = "all"
+)
+
+var (
+	ErrAppointmentNotFound = errors.New("appointment not found")
+	ErrNotSeriesOccurrence = errors.New("appointment is not an occurrence of a series")
+)
+
+// updateAppointmentSeries applies an update to an occurrence and the ones after it, or to
+// the entire series. Occurrences that have started are kept as they are. For the following
+// scope the series is split: the template ends before the occurrence and a new template
+// continues from it. The upcoming occurrences are replaced, also when they were edited
+// one by one.
+func (s *appointmentService) updateAppointmentSeries(ctx context.Context, appointmentID uuid.UUID, req *UpdateAppointmentRequest) (*UpdateAppointmentResponse, error) {
+	tx, err := s.Store.ConnPool.Begin(ctx)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "UpdateAppointmentApi", "Failed to begin transaction", zap.Error(err))
+		return nil, fmt.Errorf("failed to update appointment")
+	}
+	defer func() {
+		err := tx.Rollback(ctx)
+		if err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "UpdateAppointmentApi", "Failed to rollback transaction", zap.Error(err))
+		}
+	}()
+	qtx := s.Store.WithTx(tx)
+
+	occurrence, template, err := s.lockSeriesOccurrence(ctx, qtx, "UpdateAppointmentApi", appointmentID)
+	if err != nil {
+		return nil, err
+	}
+	recurrence, err := templateRecurrence(template)
+	if err != nil {
+		return nil, err
+	}
+	rule := *recurrence.Rule
+	if req.RRule != nil && *req.RRule != "" {
+		rule, err = rrule.Parse(*req.RRule)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidRecurrence, err)
+		}
+	}
+
+	now := util.NetherlandsNow()
+	at := occurrence.RecurrenceID.Time
+	delta := req.StartTime.Sub(at)
+	duration := req.EndTime.Sub(req.StartTime)
+	participants := template.ParticipantEmployeeIds
+	if req.ParticipantEmployeeIDs != nil {
+		participants = append([]int64{}, *req.ParticipantEmployeeIDs...)
+	}
+	clients := template.ClientIds
+	if req.ClientIDs != nil {
+		clients = append([]int64{}, *req.ClientIDs...)
+	}
+
+	// the following occurrences of the first one are the entire series
+	split := req.Scope == ScopeFollowing && at.After(template.StartTime.Time)
+	var from pgtype.Timestamp
+	seriesID := template.ID
+	if split {
+		head, tail := splitRule(*recurrence.Rule, template.StartTime.Time, at)
+		if req.RRule == nil || *req.RRule == "" {
+			rule = tail
+		}
+		_, err = qtx.UpdateAppointmentTemplate(ctx, templateUpdate(template, head))
+		if err != nil {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "UpdateAppointmentApi", "Failed to end appointment series", zap.Error(err))
+			return nil, fmt.Errorf("failed to update appointment")
+		}
+
+		recurrenceType := string(rule.Freq)
+		recurrenceInterval := int32(rule.Interval)
+		ruleValue := rule.String()
+		next, err := qtx.CreateAppointmentTemplate(ctx, db.CreateAppointmentTemplateParams{
+			CreatorEmployeeID:      template.CreatorEmployeeID,
+			StartTime:              pgtype.Timestamp{Time: req.StartTime, Valid: true},
+			EndTime:                pgtype.Timestamp{Time: req.EndTime, Valid: true},
+			Location:               req.Location,
+			Description:            req.Description,
+			Color:                  req.Color,
+			RecurrenceType:         &recurrenceType,
+			RecurrenceInterval:     &recurrenceInterval,
+			RecurrenceEndDate:      pgtype.Date{Time: rule.Until, Valid: !rule.Until.IsZero()},
+			Rrule:                  &ruleValue,
+			Exdates:                toTimestamps(shiftTimes(fromTimestamps(template.Exdates), at, delta)),
+			Rdates:                 toTimestamps(shiftTimes(fromTimestamps(template.Rdates), at, delta)),
+			ParticipantEmployeeIds: participants,
+			ClientIds:              clients,
+		})
+		if err != nil {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "UpdateAppointmentApi", "Failed to create appointment template", zap.Error(err))
+			return nil, fmt.Errorf("failed to update appointment")
+		}
+		// occurrences that have started stay with the old series
+		err = qtx.SetAppointmentTemplateMaterializedUntil(ctx, db.SetAppointmentTemplateMaterializedUntilParams{
+			MaterializedUntil: pgtype.Timestamp{Time: now, Valid: true},
+			ID:                next.ID,
+		})
+		if err != nil {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "UpdateAppointmentApi", "Failed to update materialized window", zap.Error(err))
+			return nil, fmt.Errorf("failed to update appointment")
+		}
+		from = pgtype.Timestamp{Time: at, Valid: true}
+		seriesID = next.ID
+	} else {
+		start := template.StartTime.Time.Add(delta)
+		update := templateUpdate(template, rule)
+		update.StartTime = pgtype.Timestamp{Time: start, Valid: true}
+		update.EndTime = pgtype.Timestamp{Time: start.Add(duration), Valid: true}
+		update.Location = req.Location
+		update.Description = req.Description
+		update.Color = req.Color
+		update.Exdates = toTimestamps(shiftTimes(fromTimestamps(template.Exdates), time.Time{}, delta))
+		update.Rdates = toTimestamps(shiftTimes(fromTimestamps(template.Rdates), time.Time{}, delta))
+		update.ParticipantEmployeeIds = participants
+		update.ClientIds = clients
+		update.MaterializedUntil = pgtype.Timestamp{Time: now, Valid: true}
+		_, err = qtx.UpdateAppointmentTemplate(ctx, update)
+		if err != nil {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "UpdateAppointmentApi", "Failed to update appointment template", zap.Error(err))
+			return nil, fmt.Errorf("failed to update appointment")
+		}
+	}
+
+	replaced, err := qtx.DeleteSeriesOccurrencesFrom(ctx, db.DeleteSeriesOccurrencesFromParams{
+		TemplateID: &template.ID,
+		From:       from,
+		NotBefore:  pgtype.Timestamp{Time: now, Valid: true},
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "UpdateAppointmentApi", "Failed to delete upcoming occurrences", zap.Error(err))
+		return nil, fmt.Errorf("failed to update appointment")
+	}
+	if _, err := s.materializeSeries(ctx, qtx, seriesID, now.Add(SeriesWindow)); err != nil {
+		return nil, err
+	}
+
+	// the occurrence is not stored when it has started or is no longer part of the series
+	var resp *UpdateAppointmentResponse
+	appointment, err := qtx.GetSeriesOccurrence(ctx, db.GetSeriesOccurrenceParams{
+		AppointmentTemplatesID: &seriesID,
+		RecurrenceID:           pgtype.Timestamp{Time: req.StartTime, Valid: true},
+	})
+	switch {
+	case err == nil:
+		resp = updateResponse(appointment)
+	case errors.Is(err, pgx.ErrNoRows):
+		resp = &UpdateAppointmentResponse{
+			AppointmentTemplatesID: &seriesID,
+			CreatorEmployeeID:      &template.CreatorEmployeeID,
+			StartTime:              pgtype.Timestamp{Time: req.StartTime, Valid: true},
+			EndTime:                pgtype.Timestamp{Time: req.EndTime, Valid: true},
+			Location:               req.Location,
+			Description:            req.Description,
+			Color:                  req.Color,
+			Status:                 "PENDING",
+		}
+	default:
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "UpdateAppointmentApi", "Failed to get updated occurrence", zap.Error(err))
+		return nil, fmt.Errorf("failed to update appointment")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "UpdateAppointmentApi", "Failed to commit transaction", zap.Error(err))
+		return nil, fmt.Errorf("failed to update appointment")
+	}
+
+	s.notifySeriesChange(ctx, append(template.ParticipantEmployeeIds, participants...), notification.AppointmentSeriesChangeData{
+		SeriesID:    seriesID,
+		Scope:       req.Scope,
+		From:        at,
+		StartTime:   req.StartTime,
+		EndTime:     req.EndTime,
+		Location:    util.DerefString(req.Location),
+		Occurrences: replaced,
+	})
+
+	s.Logger.LogBusinessEvent(logger.LogLevelInfo, "UpdateAppointmentApi", "Appointment series updated successfully",
+		zap.String("appointment_template_id", template.ID.String()), zap.String("scope", req.Scope), zap.Int64("replaced", replaced))
+	return resp, nil
+}
+
+// deleteAppointmentSeries cancels an occurrence and the ones after it, or the upcoming
+// occurrences of the entire series. The template is ended instead of deleted so the
+// occurrences that have started are kept.
+func (s *appointmentService) deleteAppointmentSeries(ctx context.Context, appointmentID uuid.UUID, scope string) error {
+	tx, err := s.Store.ConnPool.Begin(ctx)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "DeleteAppointmentApi", "Failed to begin transaction", zap.Error(err))
+		return fmt.Errorf("failed to delete appointment")
+	}
+	defer func() {
+		err := tx.Rollback(ctx)
+		if err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "DeleteAppointmentApi", "Failed to rollback transaction", zap.Error(err))
+		}
+	}()
+	qtx := s.Store.WithTx(tx)
+
+	occurrence, template, err := s.lockSeriesOccurrence(ctx, qtx, "DeleteAppointmentApi", appointmentID)
+	if err != nil {
+		return err
+	}
+	recurrence, err := templateRecurrence(template)
+	if err != nil {
+		return err
+	}
+
+	now := util.NetherlandsNow()
+	end := occurrence.RecurrenceID.Time
+	from := pgtype.Timestamp{Time: end, Valid: true}
+	if scope == ScopeAll || !end.After(template.StartTime.Time) {
+		end = now
+		from = pgtype.Timestamp{}
+	}
+	head, _ := splitRule(*recurrence.Rule, template.StartTime.Time, end)
+	_, err = qtx.UpdateAppointmentTemplate(ctx, templateUpdate(template, head))
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "DeleteAppointmentApi", "Failed to end appointment series", zap.Error(err))
+		return fmt.Errorf("failed to delete appointment")
+	}
+
+	removed, err := qtx.DeleteSeriesOccurrencesFrom(ctx, db.DeleteSeriesOccurrencesFromParams{
+		TemplateID: &template.ID,
+		From:       from,
+		NotBefore:  pgtype.Timestamp{Time: now, Valid: true},
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "DeleteAppointmentApi", "Failed to delete upcoming occurrences", zap.Error(err))
+		return fmt.Errorf("failed to delete appointment")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "DeleteAppointmentApi", "Failed to commit transaction", zap.Error(err))
+		return fmt.Errorf("failed to delete appointment")
+	}
+
+	s.notifySeriesChange(ctx, template.ParticipantEmployeeIds, notification.AppointmentSeriesChangeData{
+		SeriesID:    template.ID,
+		Scope:       scope,
+		Cancelled:   true,
+		From:        end,
+		Occurrences: removed,
+	})
+
+	s.Logger.LogBusinessEvent(logger.LogLevelInfo, "DeleteAppointmentApi", "Appointment series cancelled successfully",
+		zap.String("appointment_template_id", template.ID.String()), zap.String("scope", scope), zap.Int64("removed", removed))
+	return nil
+}
+
+// lockSeriesOccurrence returns an occurrence of a series and its template, which is locked
+// until the transaction of qtx ends
+func (s *appointmentService) lockSeriesOccurrence(ctx context.Context, qtx *db.Queries, op string, appointmentID uuid.UUID) (db.ScheduledAppointment, db.AppointmentTemplate, error) {
+	occurrence, err := qtx.GetScheduledAppointment(ctx, appointmentID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.ScheduledAppointment{}, db.AppointmentTemplate{}, ErrAppointmentNotFound
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, op, "Failed to get appointment", zap.Error(err))
+		return db.ScheduledAppointment{}, db.AppointmentTemplate{}, fmt.Errorf("failed to get appointment")
+	}
+	if occurrence.AppointmentTemplatesID == nil || !occurrence.RecurrenceID.Valid {
+		return db.ScheduledAppointment{}, db.AppointmentTemplate{}, ErrNotSeriesOccurrence
+	}
+
+	template, err := qtx.GetAppointmentTemplateForUpdate(ctx, *occurrence.AppointmentTemplatesID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.ScheduledAppointment{}, db.AppointmentTemplate{}, ErrSeriesNotFound
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, op, "Failed to get appointment template", zap.Error(err))
+		return db.ScheduledAppointment{}, db.AppointmentTemplate{}, fmt.Errorf("failed to get appointment template")
+	}
+	return occurrence, template, nil
+}
+
+// notifySeriesChange sends a single notification about a change of a series to its
+// participants. Failures are logged, the change itself has been made.
+func (s *appointmentService) notifySeriesChange(ctx context.Context, employeeIDs []int64, data notification.AppointmentSeriesChangeData) {
+	employeeIDs = slices.Compact(slices.Sorted(slices.Values(employeeIDs)))
+	if len(employeeIDs) == 0 {
+		return
+	}
+	userIDs, err := s.Store.ListEmployeeUserIDs(ctx, employeeIDs)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "NotifySeriesChange", "Failed to get participant users", zap.Error(err))
+		return
+	}
+
+	notificationType := notification.TypeAppointmentUpdate
+	if data.Cancelled {
+		notificationType = notification.TypeAppointmentCancellation
+	}
+	err = s.AsynqClient.EnqueueNotificationTask(ctx, notification.NotificationPayload{
+		RecipientUserIDs: userIDs,
+		Type:             notificationType,
+		Data: notification.NotificationData{
+			AppointmentSeriesChange: &data,
+		},
+		Message:   data.AppointmentSeriesChangeMessage(),
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "NotifySeriesChange", "Failed to enqueue notification task", zap.Error(err))
+	}
+}
+
+// splitRule splits a rule at the occurrence at: head ends right before it and tail keeps
+// what is left of COUNT. A rule that has ended before at is returned unchanged as head.
+func splitRule(rule rrule.Rule, dtstart, at time.Time) (head, tail rrule.Rule) {
+	head, tail = rule, rule
+	if rule.Count > 0 {
+		before := len(rrule.Set{DTStart: dtstart, Rule: &rule}.Between(dtstart, at))
+		if before >= rule.Count {
+			return head, tail
+		}
+		tail.Count = rule.Count - before
+	}
+	end := at.Add(-time.Second)
+	if rule.Until.IsZero() || end.Before(rule.Until) {
+		head.Count = 0
+		head.Until = end
+		head.UntilUTC = false
+	}
+	return head, tail
+}
+
+// shiftTimes moves the times from at on by delta and drops the earlier ones. Dates, given
+// as midnight, are kept as they are.
+func shiftTimes(times []time.Time, at time.Time, delta time.Duration) []time.Time {
+	shifted := make([]time.Time, 0, len(times))
+	for _, t := range times {
+		if t.Before(at) {
+			continue
+		}
+		if hour, min, sec := t.Clock(); hour != 0 || min != 0 || sec != 0 || t.Nanosecond() != 0 {
+			t = t.Add(delta)
+		}
+		shifted = append(shifted, t)
+	}
+	return shifted
+}
+
+// templateUpdate returns the parameters that store template with rule
+func templateUpdate(template db.AppointmentTemplate, rule rrule.Rule) db.UpdateAppointmentTemplateParams {
+	recurrenceType := string(rule.Freq)
+	recurrenceInterval := int32(rule.Interval)
+	ruleValue := rule.String()
+	return db.UpdateAppointmentTemplateParams{
+		ID:                     template.ID,
+		StartTime:              template.StartTime,
+		EndTime:                template.EndTime,
+		Location:               template.Location,
+		Description:            template.Description,
+		Color:                  template.Color,
+		RecurrenceType:         &recurrenceType,
+		RecurrenceInterval:     &recurrenceInterval,
+		RecurrenceEndDate:      pgtype.Date{Time: rule.Until, Valid: !rule.Until.IsZero()},
+		Rrule:                  &ruleValue,
+		Exdates:                template.Exdates,
+		Rdates:                 template.Rdates,
+		ParticipantEmployeeIds: template.ParticipantEmployeeIds,
+		ClientIds:              template.ClientIds,
+		MaterializedUntil:      template.MaterializedUntil,
+	}
+}
+
+func updateResponse(appointment db.ScheduledAppointment) *UpdateAppointmentResponse {
+	return &UpdateAppointmentResponse{
+		ID:                     appointment.ID,
+		AppointmentTemplatesID: appointment.AppointmentTemplatesID,
+		CreatorEmployeeID:      appointment.CreatorEmployeeID,
+		StartTime:              appointment.StartTime,
+		EndTime:                appointment.EndTime,
+		Location:               appointment.Location,
+		Description:            appointment.Description,
+		Color:                  appointment.Color,
+		Status:                 appointment.Status,
+		IsConfirmed:            appointment.IsConfirmed,
+		ConfirmedByEmployeeID:  appointment.ConfirmedByEmployeeID,
+		ConfirmedAt:            appointment.ConfirmedAt,
+		CreatedAt:              appointment.CreatedAt,
+		UpdatedAt:              appointment.UpdatedAt,
+	}
+}
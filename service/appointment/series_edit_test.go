@@ -0,0 +1,59 @@
+package appointment
+
+import (
+	"testing"
+	"time"
+
+	"maicare_go/rrule"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitRule(t *testing.T) {
+	dtstart := time.Date(2025, time.March, 3, 9, 0, 0, 0, time.UTC)
+	at := time.Date(2025, time.March, 17, 9, 0, 0, 0, time.UTC)
+
+	rule, err := rrule.Parse("FREQ=WEEKLY;COUNT=5")
+	require.NoError(t, err)
+	head, tail := splitRule(rule, dtstart, at)
+	require.Equal(t, "FREQ=WEEKLY;UNTIL=20250317T085959", head.String())
+	require.Equal(t, "FREQ=WEEKLY;COUNT=3", tail.String())
+
+	headSet := rrule.Set{DTStart: dtstart, Rule: &head}
+	require.Len(t, headSet.Between(dtstart, dtstart.AddDate(1, 0, 0)), 2)
+
+	// a series that ended before the split point is left alone
+	rule, err = rrule.Parse("FREQ=WEEKLY;UNTIL=20250310")
+	require.NoError(t, err)
+	head, _ = splitRule(rule, dtstart, at)
+	require.Equal(t, rule, head)
+
+	rule, err = rrule.Parse("FREQ=WEEKLY;COUNT=2")
+	require.NoError(t, err)
+	head, _ = splitRule(rule, dtstart, at)
+	require.Equal(t, rule, head)
+
+	// splitting before the start ends the series without occurrences
+	rule, err = rrule.Parse("FREQ=DAILY")
+	require.NoError(t, err)
+	head, _ = splitRule(rule, dtstart, dtstart.AddDate(0, 0, -1))
+	require.Empty(t, rrule.Set{DTStart: dtstart, Rule: &head}.Between(dtstart, dtstart.AddDate(0, 1, 0)))
+}
+
+func TestShiftTimes(t *testing.T) {
+	at := time.Date(2025, time.March, 10, 9, 0, 0, 0, time.UTC)
+	times := []time.Time{
+		time.Date(2025, time.March, 3, 9, 0, 0, 0, time.UTC),
+		time.Date(2025, time.March, 17, 9, 0, 0, 0, time.UTC),
+		time.Date(2025, time.April, 21, 0, 0, 0, 0, time.UTC),
+	}
+
+	shifted := shiftTimes(times, at, 90*time.Minute)
+	require.Equal(t, []time.Time{
+		time.Date(2025, time.March, 17, 10, 30, 0, 0, time.UTC),
+		time.Date(2025, time.April, 21, 0, 0, 0, 0, time.UTC),
+	}, shifted)
+
+	require.Len(t, shiftTimes(times, time.Time{}, time.Hour), 3)
+	require.NotNil(t, shiftTimes(nil, at, time.Hour))
+}
@@ -0,0 +1,73 @@
+package appointment
+
+import (
+	"testing"
+	"time"
+
+	db "maicare_go/db/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func ts(t time.Time) pgtype.Timestamp {
+	return pgtype.Timestamp{Time: t, Valid: true}
+}
+
+func TestRecurrenceRule(t *testing.T) {
+	rule, err := RecurrenceRule(&CreateAppointmentRequest{
+		RRule: stringPtr("FREQ=MONTHLY;BYDAY=1MO"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "FREQ=MONTHLY;BYDAY=1MO", rule.String())
+
+	interval := int32(2)
+	rule, err = RecurrenceRule(&CreateAppointmentRequest{
+		RecurrenceType:     "WEEKLY",
+		RecurrenceInterval: &interval,
+		RecurrenceEndDate:  time.Date(2025, time.June, 30, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "FREQ=WEEKLY;INTERVAL=2;UNTIL=20250630T235959", rule.String())
+
+	_, err = RecurrenceRule(&CreateAppointmentRequest{RRule: stringPtr("FREQ=SECONDLY")})
+	require.ErrorIs(t, err, ErrInvalidRecurrence)
+}
+
+func TestMergeOccurrences(t *testing.T) {
+	day := func(d, hour int) time.Time { return time.Date(2025, time.March, d, hour, 0, 0, 0, time.UTC) }
+	template := db.AppointmentTemplate{
+		ID:                uuid.New(),
+		StartTime:         ts(day(3, 9)),
+		EndTime:           ts(day(3, 10)),
+		MaterializedUntil: ts(day(12, 0)),
+	}
+	movedID, storedID := uuid.New(), uuid.New()
+	stored := []db.ScheduledAppointment{
+		{ID: storedID, RecurrenceID: ts(day(3, 9)), StartTime: ts(day(3, 9)), EndTime: ts(day(3, 10)), Status: "CONFIRMED"},
+		// moved from the 10th to the 11th
+		{ID: movedID, RecurrenceID: ts(day(10, 9)), StartTime: ts(day(11, 14)), EndTime: ts(day(11, 15)), Status: "PENDING", IsException: true},
+	}
+	// the 5th was deleted, the 17th is beyond the stored window
+	expanded := []time.Time{day(3, 9), day(5, 9), day(10, 9), day(17, 9)}
+
+	occurrences := mergeOccurrences(template, expanded, stored, day(1, 0), day(20, 0))
+	require.Len(t, occurrences, 3)
+
+	require.Equal(t, &storedID, occurrences[0].AppointmentID)
+	require.Equal(t, "CONFIRMED", occurrences[0].Status)
+
+	require.Equal(t, &movedID, occurrences[1].AppointmentID)
+	require.Equal(t, day(10, 9), occurrences[1].RecurrenceID)
+	require.Equal(t, day(11, 14), occurrences[1].StartTime)
+	require.True(t, occurrences[1].IsException)
+
+	require.Nil(t, occurrences[2].AppointmentID)
+	require.Equal(t, day(17, 9), occurrences[2].StartTime)
+	require.Equal(t, day(17, 10), occurrences[2].EndTime)
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
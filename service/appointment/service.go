@@ -3,6 +3,7 @@ package appointment
 import (
 	"context"
 	"maicare_go/service/deps"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -15,8 +16,13 @@ type AppointmentService interface {
 	ListAppointmentsForClientInRange(ctx context.Context, clientID int64, req ListAppointmentsForClientRequest) ([]ListAppointmentsForClientResponse, error)
 	GetAppointment(ctx context.Context, appointmentID uuid.UUID) (*GetAppointmentResponse, error)
 	UpdateAppointment(ctx context.Context, appointmentID uuid.UUID, req *UpdateAppointmentRequest) (*UpdateAppointmentResponse, error)
-	DeleteAppointment(ctx context.Context, appointmentID uuid.UUID) error
+	DeleteAppointment(ctx context.Context, appointmentID uuid.UUID, req DeleteAppointmentRequest) error
 	ConfirmAppointment(ctx context.Context, appointmentID uuid.UUID, employeeID int64) error
+
+	// Series methods
+	MaterializeAppointmentSeries(ctx context.Context, templateID uuid.UUID, until time.Time) (int, error)
+	ExtendAppointmentSeries(ctx context.Context) (int, error)
+	ListAppointmentSeriesOccurrences(ctx context.Context, templateID uuid.UUID, req ListSeriesOccurrencesRequest) ([]SeriesOccurrenceResponse, error)
 }
 
 type appointmentService struct {
@@ -0,0 +1,256 @@
+package availability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	db "maicare_go/db/sqlc"
+	"maicare_go/logger"
+	"maicare_go/util"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrEmployeeNotFound       = errors.New("employee not found")
+	ErrUnavailabilityNotFound = errors.New("unavailability not found")
+	ErrInvalidAvailability    = errors.New("invalid availability")
+	ErrInvalidPreferences     = errors.New("invalid shift preferences")
+)
+
+// GetAvailability returns the weekly availability, the unavailability that has not ended
+// and the shift preferences of an employee
+func (s *availabilityService) GetAvailability(ctx context.Context, employeeID int64) (*AvailabilityResponse, error) {
+	if err := s.checkEmployee(ctx, "GetAvailability", employeeID); err != nil {
+		return nil, err
+	}
+
+	weekly, err := s.Store.ListEmployeesWeeklyAvailability(ctx, []int64{employeeID})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "GetAvailability", "Failed to list weekly availability", zap.Error(err), zap.Int64("employee_id", employeeID))
+		return nil, fmt.Errorf("failed to get availability")
+	}
+	now := util.ConvertTimeToNetherlandsTimezone(time.Now())
+	unavailable, err := s.Store.ListEmployeeUnavailability(ctx, db.ListEmployeeUnavailabilityParams{
+		EmployeeID:   employeeID,
+		FromDatetime: pgtype.Timestamp{Time: time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), now.Minute(), 0, 0, time.UTC), Valid: true},
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "GetAvailability", "Failed to list unavailability", zap.Error(err), zap.Int64("employee_id", employeeID))
+		return nil, fmt.Errorf("failed to get availability")
+	}
+	preferences, err := s.Store.GetEmployeeShiftPreferences(ctx, employeeID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "GetAvailability", "Failed to get shift preferences", zap.Error(err), zap.Int64("employee_id", employeeID))
+		return nil, fmt.Errorf("failed to get availability")
+	}
+
+	resp := &AvailabilityResponse{
+		EmployeeID:     employeeID,
+		Weekly:         make([]WeeklyAvailabilityResponse, len(weekly)),
+		Unavailability: make([]UnavailabilityResponse, len(unavailable)),
+		Preferences:    shiftPreferencesResponse(preferences),
+	}
+	for i, w := range weekly {
+		resp.Weekly[i] = WeeklyAvailabilityResponse{
+			ID:        w.ID,
+			Weekday:   w.Weekday,
+			StartTime: clockTime(w.StartTime),
+			EndTime:   clockTime(w.EndTime),
+		}
+	}
+	for i, u := range unavailable {
+		resp.Unavailability[i] = unavailabilityResponse(u)
+	}
+	return resp, nil
+}
+
+// SetWeeklyAvailability replaces the weekly availability of an employee
+func (s *availabilityService) SetWeeklyAvailability(ctx context.Context, employeeID int64, req *SetWeeklyAvailabilityRequest) (*AvailabilityResponse, error) {
+	arg := db.CreateEmployeeWeeklyAvailabilityParams{EmployeeID: employeeID}
+	for _, w := range req.Windows {
+		start, err := util.StringToPgTime(w.StartTime)
+		if err != nil {
+			return nil, fmt.Errorf("%w: start_time must be HH:MM", ErrInvalidAvailability)
+		}
+		end, err := util.StringToPgTime(w.EndTime)
+		if err != nil {
+			return nil, fmt.Errorf("%w: end_time must be HH:MM", ErrInvalidAvailability)
+		}
+		arg.Weekdays = append(arg.Weekdays, w.Weekday)
+		arg.StartTimes = append(arg.StartTimes, start)
+		arg.EndTimes = append(arg.EndTimes, end)
+	}
+	if err := s.checkEmployee(ctx, "SetWeeklyAvailability", employeeID); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.Store.ConnPool.Begin(ctx)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "SetWeeklyAvailability", "Failed to begin transaction", zap.Error(err))
+		return nil, fmt.Errorf("failed to set weekly availability")
+	}
+	defer func() {
+		if rbErr := tx.Rollback(ctx); rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "SetWeeklyAvailability", "Failed to rollback transaction", zap.Error(rbErr))
+		}
+	}()
+	qtx := s.Store.WithTx(tx)
+
+	if err := qtx.DeleteEmployeeWeeklyAvailability(ctx, employeeID); err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "SetWeeklyAvailability", "Failed to delete weekly availability", zap.Error(err), zap.Int64("employee_id", employeeID))
+		return nil, fmt.Errorf("failed to set weekly availability")
+	}
+	if len(arg.Weekdays) > 0 {
+		if err := qtx.CreateEmployeeWeeklyAvailability(ctx, arg); err != nil {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "SetWeeklyAvailability", "Failed to create weekly availability", zap.Error(err), zap.Int64("employee_id", employeeID))
+			return nil, fmt.Errorf("failed to set weekly availability")
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "SetWeeklyAvailability", "Failed to commit transaction", zap.Error(err))
+		return nil, fmt.Errorf("failed to set weekly availability")
+	}
+
+	s.Logger.LogBusinessEvent(logger.LogLevelInfo, "SetWeeklyAvailability", "Weekly availability set", zap.Int64("employee_id", employeeID), zap.Int("windows", len(arg.Weekdays)))
+	return s.GetAvailability(ctx, employeeID)
+}
+
+// CreateUnavailability records a one-off period in which an employee cannot work
+func (s *availabilityService) CreateUnavailability(ctx context.Context, employeeID int64, req *CreateUnavailabilityRequest) (*UnavailabilityResponse, error) {
+	if !req.EndDatetime.After(req.StartDatetime) {
+		return nil, fmt.Errorf("%w: the end must be after the start", ErrInvalidAvailability)
+	}
+	if err := s.checkEmployee(ctx, "CreateUnavailability", employeeID); err != nil {
+		return nil, err
+	}
+
+	unavailability, err := s.Store.CreateEmployeeUnavailability(ctx, db.CreateEmployeeUnavailabilityParams{
+		EmployeeID:    employeeID,
+		StartDatetime: pgtype.Timestamp{Time: req.StartDatetime, Valid: true},
+		EndDatetime:   pgtype.Timestamp{Time: req.EndDatetime, Valid: true},
+		Reason:        req.Reason,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CreateUnavailability", "Failed to create unavailability", zap.Error(err), zap.Int64("employee_id", employeeID))
+		return nil, fmt.Errorf("failed to create unavailability")
+	}
+
+	s.Logger.LogBusinessEvent(logger.LogLevelInfo, "CreateUnavailability", "Unavailability created", zap.Int64("employee_id", employeeID), zap.Int64("unavailability_id", unavailability.ID))
+	resp := unavailabilityResponse(unavailability)
+	return &resp, nil
+}
+
+// DeleteUnavailability removes unavailability of an employee
+func (s *availabilityService) DeleteUnavailability(ctx context.Context, employeeID, unavailabilityID int64) error {
+	_, err := s.Store.DeleteEmployeeUnavailability(ctx, db.DeleteEmployeeUnavailabilityParams{
+		ID:         unavailabilityID,
+		EmployeeID: employeeID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrUnavailabilityNotFound
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "DeleteUnavailability", "Failed to delete unavailability", zap.Error(err), zap.Int64("unavailability_id", unavailabilityID))
+		return fmt.Errorf("failed to delete unavailability")
+	}
+
+	s.Logger.LogBusinessEvent(logger.LogLevelInfo, "DeleteUnavailability", "Unavailability deleted", zap.Int64("employee_id", employeeID), zap.Int64("unavailability_id", unavailabilityID))
+	return nil
+}
+
+// UpdateShiftPreferences sets the shift preferences of an employee
+func (s *availabilityService) UpdateShiftPreferences(ctx context.Context, employeeID int64, req *ShiftPreferencesRequest) (*ShiftPreferencesResponse, error) {
+	locationIDs := []int64{}
+	for _, id := range req.PreferredLocationIDs {
+		if slices.Contains(locationIDs, id) {
+			continue
+		}
+		if _, err := s.Store.GetLocation(ctx, id); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, fmt.Errorf("%w: location %d does not exist", ErrInvalidPreferences, id)
+			}
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "UpdateShiftPreferences", "Failed to get location", zap.Error(err), zap.Int64("location_id", id))
+			return nil, fmt.Errorf("failed to update shift preferences")
+		}
+		locationIDs = append(locationIDs, id)
+	}
+	shiftNames := []string{}
+	for _, name := range req.PreferredShiftNames {
+		if name = strings.TrimSpace(name); name != "" && !slices.Contains(shiftNames, name) {
+			shiftNames = append(shiftNames, name)
+		}
+	}
+	if err := s.checkEmployee(ctx, "UpdateShiftPreferences", employeeID); err != nil {
+		return nil, err
+	}
+
+	preferences, err := s.Store.UpsertEmployeeShiftPreferences(ctx, db.UpsertEmployeeShiftPreferencesParams{
+		EmployeeID:             employeeID,
+		PreferredLocationIds:   locationIDs,
+		PreferredShiftNames:    shiftNames,
+		MaxNightShiftsPerMonth: req.MaxNightShiftsPerMonth,
+		Notes:                  req.Notes,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "UpdateShiftPreferences", "Failed to save shift preferences", zap.Error(err), zap.Int64("employee_id", employeeID))
+		return nil, fmt.Errorf("failed to update shift preferences")
+	}
+
+	s.Logger.LogBusinessEvent(logger.LogLevelInfo, "UpdateShiftPreferences", "Shift preferences updated", zap.Int64("employee_id", employeeID))
+	resp := shiftPreferencesResponse(preferences)
+	return &resp, nil
+}
+
+func (s *availabilityService) checkEmployee(ctx context.Context, operation string, employeeID int64) error {
+	if _, err := s.Store.GetEmployeeContractDetails(ctx, employeeID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrEmployeeNotFound
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, operation, "Failed to get employee", zap.Error(err), zap.Int64("employee_id", employeeID))
+		return fmt.Errorf("failed to get employee")
+	}
+	return nil
+}
+
+// clockTime formats a time of day as HH:MM
+func clockTime(t pgtype.Time) string {
+	return util.PgTimeToString(t)[:5]
+}
+
+func unavailabilityResponse(row db.EmployeeUnavailability) UnavailabilityResponse {
+	return UnavailabilityResponse{
+		ID:            row.ID,
+		EmployeeID:    row.EmployeeID,
+		StartDatetime: row.StartDatetime.Time,
+		EndDatetime:   row.EndDatetime.Time,
+		Reason:        row.Reason,
+		CreatedAt:     row.CreatedAt.Time,
+	}
+}
+
+// shiftPreferencesResponse returns the shift preferences, empty when the employee has not
+// set any
+func shiftPreferencesResponse(row db.EmployeeShiftPreference) ShiftPreferencesResponse {
+	resp := ShiftPreferencesResponse{
+		PreferredLocationIDs:   row.PreferredLocationIds,
+		PreferredShiftNames:    row.PreferredShiftNames,
+		MaxNightShiftsPerMonth: row.MaxNightShiftsPerMonth,
+		Notes:                  row.Notes,
+	}
+	if resp.PreferredLocationIDs == nil {
+		resp.PreferredLocationIDs = []int64{}
+	}
+	if resp.PreferredShiftNames == nil {
+		resp.PreferredShiftNames = []string{}
+	}
+	if row.UpdatedAt.Valid {
+		resp.UpdatedAt = &row.UpdatedAt.Time
+	}
+	return resp
+}
@@ -0,0 +1,68 @@
+package availability
+
+import "time"
+
+// WeeklyAvailabilityWindow is a time of the week in which an employee is available.
+// Weekday counts from Sunday, 0, to Saturday, 6. A window that ends at or before its start
+// runs into the next day, 00:00 to 00:00 is the whole day.
+type WeeklyAvailabilityWindow struct {
+	Weekday   int32  `json:"weekday" binding:"min=0,max=6" example:"1"`
+	StartTime string `json:"start_time" binding:"required" example:"07:00"`
+	EndTime   string `json:"end_time" binding:"required" example:"15:30"`
+}
+
+// SetWeeklyAvailabilityRequest replaces the weekly availability of an employee. Without
+// windows the employee is available at any time.
+type SetWeeklyAvailabilityRequest struct {
+	Windows []WeeklyAvailabilityWindow `json:"windows" binding:"dive"`
+}
+
+type WeeklyAvailabilityResponse struct {
+	ID        int64  `json:"id"`
+	Weekday   int32  `json:"weekday"`
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+}
+
+// CreateUnavailabilityRequest is a one-off period in which an employee cannot work
+type CreateUnavailabilityRequest struct {
+	StartDatetime time.Time `json:"start_datetime" binding:"required" example:"2025-03-12T08:00:00Z"`
+	EndDatetime   time.Time `json:"end_datetime" binding:"required" example:"2025-03-12T17:00:00Z"`
+	Reason        *string   `json:"reason" example:"Dentist"`
+}
+
+type UnavailabilityResponse struct {
+	ID            int64     `json:"id"`
+	EmployeeID    int64     `json:"employee_id"`
+	StartDatetime time.Time `json:"start_datetime"`
+	EndDatetime   time.Time `json:"end_datetime"`
+	Reason        *string   `json:"reason"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// ShiftPreferencesRequest sets what an employee prefers to work. The roster generator
+// favours the preferred locations and shifts, matching the shift names ignoring case, and
+// plans no more night shifts in a month than the maximum.
+type ShiftPreferencesRequest struct {
+	PreferredLocationIDs   []int64  `json:"preferred_location_ids" example:"1"`
+	PreferredShiftNames    []string `json:"preferred_shift_names" binding:"dive,required,max=100" example:"Ochtenddienst"`
+	MaxNightShiftsPerMonth *int32   `json:"max_night_shifts_per_month" binding:"omitempty,min=0,max=31" example:"4"`
+	Notes                  *string  `json:"notes" example:"No shifts on Wednesday afternoon"`
+}
+
+type ShiftPreferencesResponse struct {
+	PreferredLocationIDs   []int64    `json:"preferred_location_ids"`
+	PreferredShiftNames    []string   `json:"preferred_shift_names"`
+	MaxNightShiftsPerMonth *int32     `json:"max_night_shifts_per_month"`
+	Notes                  *string    `json:"notes"`
+	UpdatedAt              *time.Time `json:"updated_at"`
+}
+
+// AvailabilityResponse is when an employee can work: their weekly availability, the
+// unavailability that has not ended yet and their shift preferences
+type AvailabilityResponse struct {
+	EmployeeID     int64                        `json:"employee_id"`
+	Weekly         []WeeklyAvailabilityResponse `json:"weekly"`
+	Unavailability []UnavailabilityResponse     `json:"unavailability"`
+	Preferences    ShiftPreferencesResponse     `json:"preferences"`
+}
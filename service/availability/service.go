@@ -0,0 +1,24 @@
+package availability
+
+import (
+	"context"
+	"maicare_go/service/deps"
+)
+
+type AvailabilityService interface {
+	GetAvailability(ctx context.Context, employeeID int64) (*AvailabilityResponse, error)
+	SetWeeklyAvailability(ctx context.Context, employeeID int64, req *SetWeeklyAvailabilityRequest) (*AvailabilityResponse, error)
+	CreateUnavailability(ctx context.Context, employeeID int64, req *CreateUnavailabilityRequest) (*UnavailabilityResponse, error)
+	DeleteUnavailability(ctx context.Context, employeeID, unavailabilityID int64) error
+	UpdateShiftPreferences(ctx context.Context, employeeID int64, req *ShiftPreferencesRequest) (*ShiftPreferencesResponse, error)
+}
+
+type availabilityService struct {
+	*deps.ServiceDependencies
+}
+
+func NewAvailabilityService(deps *deps.ServiceDependencies) AvailabilityService {
+	return &availabilityService{
+		ServiceDependencies: deps,
+	}
+}
@@ -0,0 +1,12 @@
+package calendar
+
+import "time"
+
+// CalendarFeedResponse represents the calendar feed of a user. Token and URL are only
+// returned when the feed is created, afterwards only a hash of the token is known.
+type CalendarFeedResponse struct {
+	Token      string     `json:"token,omitempty"`
+	URL        string     `json:"url,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+}
@@ -0,0 +1,269 @@
+package calendar
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	db "maicare_go/db/sqlc"
+	"maicare_go/ical"
+	"maicare_go/logger"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+// The range of a feed around the moment it is fetched
+const (
+	FeedPast  = 30 * 24 * time.Hour
+	FeedAhead = 180 * 24 * time.Hour
+)
+
+var ErrFeedNotFound = errors.New("calendar feed not found")
+
+// CreateFeedToken creates the feed of a user, or replaces its token so the old feed URL
+// stops working
+func (s *calendarService) CreateFeedToken(ctx context.Context, userID int64) (*CalendarFeedResponse, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CreateFeedToken", "Failed to generate token", zap.Error(err))
+		return nil, fmt.Errorf("failed to create calendar feed")
+	}
+	token := base64.RawURLEncoding.EncodeToString(secret)
+
+	feed, err := s.Store.UpsertCalendarFeedToken(ctx, db.UpsertCalendarFeedTokenParams{
+		UserID:    userID,
+		TokenHash: hashToken(token),
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CreateFeedToken", "Failed to store token", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, fmt.Errorf("failed to create calendar feed")
+	}
+
+	s.Logger.LogBusinessEvent(logger.LogLevelInfo, "CreateFeedToken", "Calendar feed token created", zap.Int64("user_id", userID))
+	resp := feedResponse(feed)
+	resp.Token = token
+	return resp, nil
+}
+
+func (s *calendarService) GetFeedToken(ctx context.Context, userID int64) (*CalendarFeedResponse, error) {
+	feed, err := s.Store.GetCalendarFeedToken(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrFeedNotFound
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "GetFeedToken", "Failed to get token", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, fmt.Errorf("failed to get calendar feed")
+	}
+	return feedResponse(feed), nil
+}
+
+func (s *calendarService) RevokeFeedToken(ctx context.Context, userID int64) error {
+	deleted, err := s.Store.DeleteCalendarFeedToken(ctx, userID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "RevokeFeedToken", "Failed to delete token", zap.Error(err), zap.Int64("user_id", userID))
+		return fmt.Errorf("failed to revoke calendar feed")
+	}
+	if deleted == 0 {
+		return ErrFeedNotFound
+	}
+	s.Logger.LogBusinessEvent(logger.LogLevelInfo, "RevokeFeedToken", "Calendar feed token revoked", zap.Int64("user_id", userID))
+	return nil
+}
+
+// RenderFeed returns the iCalendar data of the feed with the token: the shifts and the
+// appointments of the employee of the user from FeedPast ago to FeedAhead from now
+func (s *calendarService) RenderFeed(ctx context.Context, token string) ([]byte, error) {
+	feed, err := s.Store.GetCalendarFeedTokenByHash(ctx, hashToken(token))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrFeedNotFound
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "RenderFeed", "Failed to get token", zap.Error(err))
+		return nil, fmt.Errorf("failed to render calendar feed")
+	}
+
+	employee, err := s.Store.GetEmployeeProfileByUserID(ctx, feed.UserID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrFeedNotFound
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "RenderFeed", "Failed to get employee profile", zap.Error(err), zap.Int64("user_id", feed.UserID))
+		return nil, fmt.Errorf("failed to render calendar feed")
+	}
+
+	now := time.Now()
+	start := pgtype.Timestamp{Time: now.Add(-FeedPast), Valid: true}
+	end := pgtype.Timestamp{Time: now.Add(FeedAhead), Valid: true}
+
+	shifts, err := s.Store.ListEmployeeCalendarShifts(ctx, db.ListEmployeeCalendarShiftsParams{
+		EmployeeID: employee.EmployeeID,
+		StartDate:  start,
+		EndDate:    end,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "RenderFeed", "Failed to list shifts", zap.Error(err))
+		return nil, fmt.Errorf("failed to render calendar feed")
+	}
+
+	appointments, err := s.Store.ListEmployeeAppointmentsInRange(ctx, db.ListEmployeeAppointmentsInRangeParams{
+		EmployeeID: &employee.EmployeeID,
+		StartDate:  start,
+		EndDate:    end,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "RenderFeed", "Failed to list appointments", zap.Error(err))
+		return nil, fmt.Errorf("failed to render calendar feed")
+	}
+
+	// an appointment is listed once for each way the employee is involved
+	var appointmentIDs []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	unique := appointments[:0]
+	for _, appt := range appointments {
+		if seen[appt.AppointmentID] {
+			continue
+		}
+		seen[appt.AppointmentID] = true
+		unique = append(unique, appt)
+		appointmentIDs = append(appointmentIDs, appt.AppointmentID)
+	}
+
+	participants := make(map[uuid.UUID][]string)
+	clients := make(map[uuid.UUID][]string)
+	if len(appointmentIDs) > 0 {
+		participantRows, err := s.Store.GetAppointmentParticipants(ctx, appointmentIDs)
+		if err != nil {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "RenderFeed", "Failed to get appointment participants", zap.Error(err))
+			return nil, fmt.Errorf("failed to render calendar feed")
+		}
+		for _, p := range participantRows {
+			participants[p.AppointmentID] = append(participants[p.AppointmentID], shortName(p.FirstName, p.LastName))
+		}
+
+		clientRows, err := s.Store.GetAppointmentClients(ctx, appointmentIDs)
+		if err != nil {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "RenderFeed", "Failed to get appointment clients", zap.Error(err))
+			return nil, fmt.Errorf("failed to render calendar feed")
+		}
+		for _, c := range clientRows {
+			clients[c.AppointmentID] = append(clients[c.AppointmentID], initials(c.FirstName, c.LastName))
+		}
+	}
+
+	calendar := ical.Calendar{Name: "Maicare"}
+	for _, shift := range shifts {
+		calendar.Events = append(calendar.Events, shiftEvent(shift))
+	}
+	for _, appt := range unique {
+		calendar.Events = append(calendar.Events, appointmentEvent(appt, participants[appt.AppointmentID], clients[appt.AppointmentID]))
+	}
+
+	data := calendar.Encode(now)
+
+	if err := s.Store.MarkCalendarFeedTokenUsed(ctx, feed.UserID); err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelWarn, "RenderFeed", "Failed to mark token as used", zap.Error(err))
+	}
+	return data, nil
+}
+
+// shiftEvent returns the event of a shift with the name of the shift and its location
+func shiftEvent(shift db.ListEmployeeCalendarShiftsRow) ical.Event {
+	summary := "Shift"
+	if shift.ShiftName != nil && *shift.ShiftName != "" {
+		summary = *shift.ShiftName
+	}
+	return ical.Event{
+		UID:          "shift-" + shift.ID.String() + "@maicare",
+		Summary:      summary,
+		Location:     strings.TrimSuffix(shift.LocationName+", "+shift.LocationAddress, ", "),
+		Start:        shift.StartDatetime.Time,
+		End:          shift.EndDatetime.Time,
+		Status:       ical.StatusConfirmed,
+		LastModified: shift.UpdatedAt.Time,
+	}
+}
+
+// appointmentEvent returns the event of an appointment. The description of an appointment
+// is left out and clients are only named by their initials, the feed is readable by
+// anyone who has its URL.
+func appointmentEvent(appt db.ListEmployeeAppointmentsInRangeRow, participants, clients []string) ical.Event {
+	summary := "Appointment"
+	if len(clients) > 0 {
+		summary += " with " + strings.Join(clients, ", ")
+	}
+	var description string
+	if len(participants) > 0 {
+		description = "Participants: " + strings.Join(participants, ", ")
+	}
+
+	status := ical.StatusTentative
+	switch {
+	case appt.Status == "CANCELLED":
+		status = ical.StatusCancelled
+	case appt.Status == "CONFIRMED" || appt.IsConfirmed:
+		status = ical.StatusConfirmed
+	}
+
+	event := ical.Event{
+		UID:         "appointment-" + appt.AppointmentID.String() + "@maicare",
+		Summary:     summary,
+		Description: description,
+		Start:       appt.StartTime.Time,
+		End:         appt.EndTime.Time,
+		Status:      status,
+	}
+	if appt.Location != nil {
+		event.Location = *appt.Location
+	}
+	return event
+}
+
+// shortName returns the first name and the initial of the last name, "Anna B."
+func shortName(firstName, lastName string) string {
+	if initial := initial(lastName); initial != "" {
+		return firstName + " " + initial + "."
+	}
+	return firstName
+}
+
+// initials returns the initials of a name, "A.B."
+func initials(firstName, lastName string) string {
+	var parts []string
+	for _, name := range []string{firstName, lastName} {
+		if initial := initial(name); initial != "" {
+			parts = append(parts, initial+".")
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+func initial(name string) string {
+	for _, r := range strings.TrimSpace(name) {
+		return strings.ToUpper(string(r))
+	}
+	return ""
+}
+
+// hashToken returns the hash a feed token is stored and looked up by
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func feedResponse(feed db.CalendarFeedToken) *CalendarFeedResponse {
+	resp := &CalendarFeedResponse{
+		CreatedAt: feed.CreatedAt.Time,
+	}
+	if feed.LastUsedAt.Valid {
+		resp.LastUsedAt = &feed.LastUsedAt.Time
+	}
+	return resp
+}
@@ -0,0 +1,65 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	db "maicare_go/db/sqlc"
+	"maicare_go/ical"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppointmentEvent(t *testing.T) {
+	id := uuid.New()
+	location := "Kantoor"
+	description := "Evaluatie zorgplan, diagnose besproken"
+	start := time.Date(2025, time.March, 3, 9, 0, 0, 0, time.UTC)
+	appt := db.ListEmployeeAppointmentsInRangeRow{
+		AppointmentID: id,
+		StartTime:     pgtype.Timestamp{Time: start, Valid: true},
+		EndTime:       pgtype.Timestamp{Time: start.Add(time.Hour), Valid: true},
+		Location:      &location,
+		Description:   &description,
+		Status:        "PENDING",
+	}
+
+	event := appointmentEvent(appt, []string{shortName("Anna", "de Boer")}, []string{initials("jan", "Jansen")})
+	require.Equal(t, "appointment-"+id.String()+"@maicare", event.UID)
+	require.Equal(t, "Appointment with J.J.", event.Summary)
+	require.Equal(t, "Participants: Anna D.", event.Description)
+	require.Equal(t, "Kantoor", event.Location)
+	require.Equal(t, ical.StatusTentative, event.Status)
+
+	appt.Status = "CANCELLED"
+	require.Equal(t, ical.StatusCancelled, appointmentEvent(appt, nil, nil).Status)
+	appt.Status = "CONFIRMED"
+	event = appointmentEvent(appt, nil, nil)
+	require.Equal(t, ical.StatusConfirmed, event.Status)
+	require.Equal(t, "Appointment", event.Summary)
+	require.Empty(t, event.Description)
+}
+
+func TestShiftEvent(t *testing.T) {
+	name := "Nachtdienst"
+	shift := db.ListEmployeeCalendarShiftsRow{
+		ID:              uuid.New(),
+		LocationName:    "De Linde",
+		LocationAddress: "Dorpsstraat 1",
+		ShiftName:       &name,
+	}
+	event := shiftEvent(shift)
+	require.Equal(t, "Nachtdienst", event.Summary)
+	require.Equal(t, "De Linde, Dorpsstraat 1", event.Location)
+
+	shift.ShiftName = nil
+	require.Equal(t, "Shift", shiftEvent(shift).Summary)
+}
+
+func TestHashToken(t *testing.T) {
+	require.Len(t, hashToken("secret"), 64)
+	require.Equal(t, hashToken("secret"), hashToken("secret"))
+	require.NotEqual(t, hashToken("secret"), hashToken("other"))
+}
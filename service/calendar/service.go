@@ -0,0 +1,23 @@
+package calendar
+
+import (
+	"context"
+	"maicare_go/service/deps"
+)
+
+type CalendarService interface {
+	CreateFeedToken(ctx context.Context, userID int64) (*CalendarFeedResponse, error)
+	GetFeedToken(ctx context.Context, userID int64) (*CalendarFeedResponse, error)
+	RevokeFeedToken(ctx context.Context, userID int64) error
+	RenderFeed(ctx context.Context, token string) ([]byte, error)
+}
+
+type calendarService struct {
+	*deps.ServiceDependencies
+}
+
+func NewCalendarService(deps *deps.ServiceDependencies) CalendarService {
+	return &calendarService{
+		ServiceDependencies: deps,
+	}
+}
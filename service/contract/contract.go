@@ -26,6 +26,17 @@ type ContractService interface {
 	GetClientContract(ctx context.Context, contractID int64) (*GetClientContractResponse, error)
 	ListContracts(ctx *gin.Context, req ListContractsRequest) (*pagination.Response[ListContractsResponse], error)
 	GetContractAuditLog(ctx context.Context, contractID int64) ([]GetContractAuditLogResponse, error)
+	GetContractHoursBudget(ctx context.Context, contractID int64) (*ContractHoursBudgetResponse, error)
+	CheckContractHoursBudgets(ctx context.Context) ([]ContractHoursBudgetAlert, error)
+	CreateContractWorkingHours(ctx context.Context, req CreateContractWorkingHoursRequest, contractID int64) (*ContractWorkingHoursResponse, error)
+	ListContractWorkingHours(ctx *gin.Context, req pagination.Request, contractID int64) (*pagination.Response[ContractWorkingHoursResponse], error)
+	DeleteContractWorkingHours(ctx context.Context, contractID int64, workingHoursID int64) error
+	ListContractStatusPeriods(ctx context.Context, contractID int64) ([]ContractStatusPeriodResponse, error)
+	CreateContractSuspension(ctx context.Context, req CreateContractSuspensionRequest, contractID int64, employeeID int64) ([]ContractStatusPeriodResponse, error)
+	DeleteContractSuspension(ctx context.Context, contractID int64, periodID int64, employeeID int64) ([]ContractStatusPeriodResponse, error)
+	RenewContract(ctx context.Context, req RenewContractRequest, contractID int64, employeeID int64) (*RenewContractResponse, error)
+	ListContractVersions(ctx context.Context, contractID int64) ([]ContractVersionResponse, error)
+	ExpireRenewedContracts(ctx context.Context) ([]int64, error)
 }
 
 type contractService struct {
@@ -86,23 +97,24 @@ func (s *contractService) DeleteContractType(ctx context.Context, contractTypeID
 
 func (s *contractService) CreateContract(ctx context.Context, req CreateContractRequest, clientID int64) (*CreateContractResponse, error) {
 	contract, err := s.Store.CreateContract(ctx, db.CreateContractParams{
-		TypeID:          req.TypeID,
-		StartDate:       pgtype.Timestamptz{Time: req.StartDate, Valid: true},
-		EndDate:         pgtype.Timestamptz{Time: req.EndDate, Valid: true},
-		ReminderPeriod:  req.ReminderPeriod,
-		Vat:             req.Vat,
-		Price:           req.Price,
-		PriceTimeUnit:   req.PriceTimeUnit,
-		Hours:           req.Hours,
-		HoursType:       req.HoursType,
-		CareName:        req.CareName,
-		CareType:        req.CareType,
-		ClientID:        clientID,
-		SenderID:        req.SenderID,
-		Status:          "draft",
-		AttachmentIds:   req.AttachmentIds,
-		FinancingAct:    req.FinancingAct,
-		FinancingOption: req.FinancingOption,
+		TypeID:           req.TypeID,
+		StartDate:        pgtype.Timestamptz{Time: req.StartDate, Valid: true},
+		EndDate:          pgtype.Timestamptz{Time: req.EndDate, Valid: true},
+		ReminderPeriod:   req.ReminderPeriod,
+		Vat:              req.Vat,
+		Price:            req.Price,
+		PriceTimeUnit:    req.PriceTimeUnit,
+		Hours:            req.Hours,
+		HoursType:        req.HoursType,
+		CareName:         req.CareName,
+		CareType:         req.CareType,
+		ClientID:         clientID,
+		SenderID:         req.SenderID,
+		Status:           "draft",
+		AttachmentIds:    req.AttachmentIds,
+		FinancingAct:     req.FinancingAct,
+		FinancingOption:  req.FinancingOption,
+		IndicationNumber: req.IndicationNumber,
 	})
 	if err != nil {
 		s.Logger.LogBusinessEvent(logger.LogLevelError, "CreateContract", "Failed to create contract", zap.Int64("client_id", clientID), zap.Error(err))
@@ -110,28 +122,29 @@ func (s *contractService) CreateContract(ctx context.Context, req CreateContract
 	}
 
 	response := &CreateContractResponse{
-		ID:              contract.ID,
-		TypeID:          contract.TypeID,
-		Status:          contract.Status,
-		StartDate:       contract.StartDate.Time,
-		EndDate:         contract.EndDate.Time,
-		ReminderPeriod:  contract.ReminderPeriod,
-		Vat:             contract.Vat,
-		Price:           contract.Price,
-		PriceTimeUnit:   contract.PriceTimeUnit,
-		Hours:           contract.Hours,
-		HoursType:       contract.HoursType,
-		CareName:        contract.CareName,
-		CareType:        contract.CareType,
-		ClientID:        contract.ClientID,
-		SenderID:        contract.SenderID,
-		AttachmentIds:   contract.AttachmentIds,
-		FinancingAct:    contract.FinancingAct,
-		FinancingOption: contract.FinancingOption,
-		DepartureReason: contract.DepartureReason,
-		DepartureReport: contract.DepartureReport,
-		UpdatedAt:       contract.UpdatedAt,
-		CreatedAt:       contract.CreatedAt,
+		ID:               contract.ID,
+		TypeID:           contract.TypeID,
+		Status:           contract.Status,
+		StartDate:        contract.StartDate.Time,
+		EndDate:          contract.EndDate.Time,
+		ReminderPeriod:   contract.ReminderPeriod,
+		Vat:              contract.Vat,
+		Price:            contract.Price,
+		PriceTimeUnit:    contract.PriceTimeUnit,
+		Hours:            contract.Hours,
+		HoursType:        contract.HoursType,
+		CareName:         contract.CareName,
+		CareType:         contract.CareType,
+		ClientID:         contract.ClientID,
+		SenderID:         contract.SenderID,
+		AttachmentIds:    contract.AttachmentIds,
+		FinancingAct:     contract.FinancingAct,
+		FinancingOption:  contract.FinancingOption,
+		IndicationNumber: contract.IndicationNumber,
+		DepartureReason:  contract.DepartureReason,
+		DepartureReport:  contract.DepartureReport,
+		UpdatedAt:        contract.UpdatedAt,
+		CreatedAt:        contract.CreatedAt,
 	}
 	return response, nil
 }
@@ -159,31 +172,32 @@ func (s *contractService) ListClientContracts(ctx *gin.Context, req ListClientCo
 	contractsRes := make([]ListClientContractsResponse, len(contracts))
 	for i, contract := range contracts {
 		contractsRes[i] = ListClientContractsResponse{
-			ID:              contract.ID,
-			TypeID:          contract.TypeID,
-			Status:          contract.Status,
-			StartDate:       contract.StartDate.Time,
-			EndDate:         contract.EndDate.Time,
-			ReminderPeriod:  contract.ReminderPeriod,
-			Vat:             contract.Vat,
-			Price:           contract.Price,
-			PriceTimeUnit:   contract.PriceTimeUnit,
-			Hours:           contract.Hours,
-			HoursType:       contract.HoursType,
-			CareName:        contract.CareName,
-			CareType:        contract.CareType,
-			ClientID:        contract.ClientID,
-			ClientFirstName: contract.ClientFirstName,
-			ClientLastName:  contract.ClientLastName,
-			SenderID:        contract.SenderID,
-			SenderName:      contract.SenderName,
-			AttachmentIds:   contract.AttachmentIds,
-			FinancingAct:    contract.FinancingAct,
-			FinancingOption: contract.FinancingOption,
-			DepartureReason: contract.DepartureReason,
-			DepartureReport: contract.DepartureReport,
-			UpdatedAt:       contract.UpdatedAt.Time,
-			CreatedAt:       contract.CreatedAt.Time,
+			ID:               contract.ID,
+			TypeID:           contract.TypeID,
+			Status:           contract.Status,
+			StartDate:        contract.StartDate.Time,
+			EndDate:          contract.EndDate.Time,
+			ReminderPeriod:   contract.ReminderPeriod,
+			Vat:              contract.Vat,
+			Price:            contract.Price,
+			PriceTimeUnit:    contract.PriceTimeUnit,
+			Hours:            contract.Hours,
+			HoursType:        contract.HoursType,
+			CareName:         contract.CareName,
+			CareType:         contract.CareType,
+			ClientID:         contract.ClientID,
+			ClientFirstName:  contract.ClientFirstName,
+			ClientLastName:   contract.ClientLastName,
+			SenderID:         contract.SenderID,
+			SenderName:       contract.SenderName,
+			AttachmentIds:    contract.AttachmentIds,
+			FinancingAct:     contract.FinancingAct,
+			FinancingOption:  contract.FinancingOption,
+			IndicationNumber: contract.IndicationNumber,
+			DepartureReason:  contract.DepartureReason,
+			DepartureReport:  contract.DepartureReport,
+			UpdatedAt:        contract.UpdatedAt.Time,
+			CreatedAt:        contract.CreatedAt.Time,
 		}
 	}
 
@@ -201,29 +215,30 @@ func (s *contractService) UpdateContract(ctx context.Context, req UpdateContract
 
 	qtx := s.Store.WithTx(tx)
 
-	_, err = tx.Exec(ctx, "SET LOCAL myapp.current_employee_id = $1", employeeID)
+	_, err = tx.Exec(ctx, fmt.Sprintf("SET LOCAL myapp.current_employee_id = %d", employeeID))
 	if err != nil {
 		s.Logger.LogBusinessEvent(logger.LogLevelError, "UpdateContract", "Failed to set current employee ID", zap.Int64("contract_id", contractID), zap.Int64("employee_id", employeeID), zap.Error(err))
 		return nil, err
 	}
 
 	contract, err := qtx.UpdateContract(ctx, db.UpdateContractParams{
-		ID:              contractID,
-		TypeID:          req.TypeID,
-		StartDate:       pgtype.Timestamptz{Time: req.StartDate, Valid: true},
-		EndDate:         pgtype.Timestamptz{Time: req.EndDate, Valid: true},
-		ReminderPeriod:  req.ReminderPeriod,
-		VAT:             req.Vat,
-		Price:           req.Price,
-		PriceTimeUnit:   req.PriceTimeUnit,
-		Hours:           req.Hours,
-		HoursType:       req.HoursType,
-		CareName:        req.CareName,
-		CareType:        req.CareType,
-		SenderID:        req.SenderID,
-		AttachmentIds:   req.AttachmentIds,
-		FinancingAct:    req.FinancingAct,
-		FinancingOption: req.FinancingOption,
+		ID:               contractID,
+		TypeID:           req.TypeID,
+		StartDate:        pgtype.Timestamptz{Time: req.StartDate, Valid: true},
+		EndDate:          pgtype.Timestamptz{Time: req.EndDate, Valid: true},
+		ReminderPeriod:   req.ReminderPeriod,
+		VAT:              req.Vat,
+		Price:            req.Price,
+		PriceTimeUnit:    req.PriceTimeUnit,
+		Hours:            req.Hours,
+		HoursType:        req.HoursType,
+		CareName:         req.CareName,
+		CareType:         req.CareType,
+		SenderID:         req.SenderID,
+		AttachmentIds:    req.AttachmentIds,
+		FinancingAct:     req.FinancingAct,
+		FinancingOption:  req.FinancingOption,
+		IndicationNumber: req.IndicationNumber,
 	})
 	if err != nil {
 		s.Logger.LogBusinessEvent(logger.LogLevelError, "UpdateContract", "Failed to update contract", zap.Int64("contract_id", contractID), zap.Error(err))
@@ -236,28 +251,29 @@ func (s *contractService) UpdateContract(ctx context.Context, req UpdateContract
 	}
 
 	response := &UpdateContractResponse{
-		ID:              contract.ID,
-		TypeID:          contract.TypeID,
-		Status:          contract.Status,
-		StartDate:       contract.StartDate.Time,
-		EndDate:         contract.EndDate.Time,
-		ReminderPeriod:  contract.ReminderPeriod,
-		Vat:             contract.Vat,
-		Price:           contract.Price,
-		PriceFrequency:  contract.PriceTimeUnit,
-		Hours:           contract.Hours,
-		HoursType:       contract.HoursType,
-		CareName:        contract.CareName,
-		CareType:        contract.CareType,
-		ClientID:        contract.ClientID,
-		SenderID:        contract.SenderID,
-		AttachmentIds:   contract.AttachmentIds,
-		FinancingAct:    contract.FinancingAct,
-		FinancingOption: contract.FinancingOption,
-		DepartureReason: contract.DepartureReason,
-		DepartureReport: contract.DepartureReport,
-		UpdatedAt:       contract.UpdatedAt.Time,
-		CreatedAt:       contract.CreatedAt.Time,
+		ID:               contract.ID,
+		TypeID:           contract.TypeID,
+		Status:           contract.Status,
+		StartDate:        contract.StartDate.Time,
+		EndDate:          contract.EndDate.Time,
+		ReminderPeriod:   contract.ReminderPeriod,
+		Vat:              contract.Vat,
+		Price:            contract.Price,
+		PriceFrequency:   contract.PriceTimeUnit,
+		Hours:            contract.Hours,
+		HoursType:        contract.HoursType,
+		CareName:         contract.CareName,
+		CareType:         contract.CareType,
+		ClientID:         contract.ClientID,
+		SenderID:         contract.SenderID,
+		AttachmentIds:    contract.AttachmentIds,
+		FinancingAct:     contract.FinancingAct,
+		FinancingOption:  contract.FinancingOption,
+		IndicationNumber: contract.IndicationNumber,
+		DepartureReason:  contract.DepartureReason,
+		DepartureReport:  contract.DepartureReport,
+		UpdatedAt:        contract.UpdatedAt.Time,
+		CreatedAt:        contract.CreatedAt.Time,
 	}
 	return response, nil
 }
@@ -272,7 +288,7 @@ func (s *contractService) UpdateContractStatus(ctx context.Context, req UpdateCo
 
 	qtx := s.Store.WithTx(tx)
 
-	_, err = tx.Exec(ctx, "SET LOCAL myapp.current_employee_id = $1", employeeID)
+	_, err = tx.Exec(ctx, fmt.Sprintf("SET LOCAL myapp.current_employee_id = %d", employeeID))
 	if err != nil {
 		s.Logger.LogBusinessEvent(logger.LogLevelError, "UpdateContractStatus", "Failed to set current employee ID", zap.Int64("contract_id", contractID), zap.Int64("employee_id", employeeID), zap.Error(err))
 		return nil, err
@@ -290,6 +306,31 @@ func (s *contractService) UpdateContractStatus(ctx context.Context, req UpdateCo
 		return nil, err
 	}
 
+	effectiveFrom := time.Now()
+	if req.EffectiveFrom != nil {
+		if req.EffectiveFrom.After(effectiveFrom) {
+			return nil, fmt.Errorf("%w: a status change cannot take effect in the future", ErrInvalidStatusPeriod)
+		}
+		if req.EffectiveFrom.Before(contract.StartDate.Time) {
+			return nil, fmt.Errorf("%w: a status change cannot take effect before the start of the contract", ErrInvalidStatusPeriod)
+		}
+		effectiveFrom = *req.EffectiveFrom
+	}
+
+	timeline, err := s.loadStatusTimeline(ctx, qtx, contractID)
+	if err != nil {
+		return nil, err
+	}
+	timeline = ApplyStatusPeriod(timeline, StatusPeriod{
+		Status:    req.Status,
+		From:      effectiveFrom,
+		Reason:    req.Reason,
+		CreatedBy: &employeeID,
+	})
+	if _, err := s.saveStatusTimeline(ctx, qtx, contractID, timeline); err != nil {
+		return nil, err
+	}
+
 	updatedContract, err := qtx.UpdateContractStatus(ctx, db.UpdateContractStatusParams{
 		ContractID: contractID,
 		Status:     req.Status,
@@ -299,6 +340,12 @@ func (s *contractService) UpdateContractStatus(ctx context.Context, req UpdateCo
 		return nil, err
 	}
 
+	if updatedContract.Status == ContractStatusApproved {
+		if err := s.closeRenewedContract(ctx, qtx, updatedContract, employeeID); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := tx.Commit(ctx); err != nil {
 		s.Logger.LogBusinessEvent(logger.LogLevelError, "UpdateContractStatus", "Failed to commit transaction", zap.Int64("contract_id", contractID), zap.Error(err))
 		return nil, err
@@ -319,32 +366,33 @@ func (s *contractService) GetClientContract(ctx context.Context, contractID int6
 	}
 
 	response := &GetClientContractResponse{
-		ID:              contract.ID,
-		TypeID:          contract.TypeID,
-		TypeName:        contract.ContractTypeName,
-		Status:          contract.Status,
-		StartDate:       contract.StartDate.Time,
-		EndDate:         contract.EndDate.Time,
-		ReminderPeriod:  contract.ReminderPeriod,
-		Vat:             contract.Vat,
-		Price:           contract.Price,
-		PriceTimeUnit:   contract.PriceTimeUnit,
-		Hours:           contract.Hours,
-		HoursType:       contract.HoursType,
-		CareName:        contract.CareName,
-		CareType:        contract.CareType,
-		ClientID:        contract.ClientID,
-		ClientFirstName: contract.ClientFirstName,
-		ClientLastName:  contract.ClientLastName,
-		SenderID:        contract.SenderID,
-		SenderName:      contract.SenderName,
-		AttachmentIds:   contract.AttachmentIds,
-		FinancingAct:    contract.FinancingAct,
-		FinancingOption: contract.FinancingOption,
-		DepartureReason: contract.DepartureReason,
-		DepartureReport: contract.DepartureReport,
-		UpdatedAt:       contract.UpdatedAt.Time,
-		CreatedAt:       contract.CreatedAt.Time,
+		ID:               contract.ID,
+		TypeID:           contract.TypeID,
+		TypeName:         contract.ContractTypeName,
+		Status:           contract.Status,
+		StartDate:        contract.StartDate.Time,
+		EndDate:          contract.EndDate.Time,
+		ReminderPeriod:   contract.ReminderPeriod,
+		Vat:              contract.Vat,
+		Price:            contract.Price,
+		PriceTimeUnit:    contract.PriceTimeUnit,
+		Hours:            contract.Hours,
+		HoursType:        contract.HoursType,
+		CareName:         contract.CareName,
+		CareType:         contract.CareType,
+		ClientID:         contract.ClientID,
+		ClientFirstName:  contract.ClientFirstName,
+		ClientLastName:   contract.ClientLastName,
+		SenderID:         contract.SenderID,
+		SenderName:       contract.SenderName,
+		AttachmentIds:    contract.AttachmentIds,
+		FinancingAct:     contract.FinancingAct,
+		FinancingOption:  contract.FinancingOption,
+		IndicationNumber: contract.IndicationNumber,
+		DepartureReason:  contract.DepartureReason,
+		DepartureReport:  contract.DepartureReport,
+		UpdatedAt:        contract.UpdatedAt.Time,
+		CreatedAt:        contract.CreatedAt.Time,
 	}
 	return response, nil
 }
@@ -0,0 +1,233 @@
+package contract
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	db "maicare_go/db/sqlc"
+	"maicare_go/logger"
+	"maicare_go/pagination"
+	"math"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+// budgetThresholds are the usage percentages the case manager is notified at
+var budgetThresholds = []int32{80, 100}
+
+var ErrNoHoursBudget = errors.New("contract has no indication of hours")
+
+// BudgetPeriod returns the period the indication of a contract applies to at a given
+// time and the minutes authorized for it. Weekly hours apply to the week (Monday to
+// Sunday) containing the time, all_period hours to the whole contract.
+func BudgetPeriod(hours float64, hoursType string, contractStart, contractEnd, at time.Time) (time.Time, time.Time, float64, error) {
+	if hours <= 0 {
+		return time.Time{}, time.Time{}, 0, ErrNoHoursBudget
+	}
+	switch hoursType {
+	case "weekly":
+		daysSinceMonday := (int(at.Weekday()) + 6) % 7
+		start := time.Date(at.Year(), at.Month(), at.Day()-daysSinceMonday, 0, 0, 0, 0, at.Location())
+		return start, start.AddDate(0, 0, 7), hours * 60, nil
+	case "all_period":
+		return contractStart, contractEnd, hours * 60, nil
+	}
+	return time.Time{}, time.Time{}, 0, fmt.Errorf("unsupported hours type: %s", hoursType)
+}
+
+// UsagePercent is the share of the authorized minutes that was consumed
+func UsagePercent(authorized, consumed float64) float64 {
+	if authorized <= 0 {
+		return 0
+	}
+	return math.Round(consumed/authorized*1000) / 10
+}
+
+// ReachedThresholds returns the alert thresholds the consumption has reached
+func ReachedThresholds(authorized, consumed float64) []int32 {
+	usage := UsagePercent(authorized, consumed)
+	var reached []int32
+	for _, threshold := range budgetThresholds {
+		if usage >= float64(threshold) {
+			reached = append(reached, threshold)
+		}
+	}
+	return reached
+}
+
+func (s *contractService) GetContractHoursBudget(ctx context.Context, contractID int64) (*ContractHoursBudgetResponse, error) {
+	contract, err := s.Store.GetClientContract(ctx, contractID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "GetContractHoursBudget", "Failed to get contract", zap.Int64("contract_id", contractID), zap.Error(err))
+		return nil, err
+	}
+	if contract.CareType != "ambulante" || contract.Hours == nil || contract.HoursType == nil {
+		return nil, ErrNoHoursBudget
+	}
+
+	return s.contractHoursBudget(ctx, contractID, *contract.Hours, *contract.HoursType, contract.StartDate.Time, contract.EndDate.Time, time.Now())
+}
+
+func (s *contractService) contractHoursBudget(ctx context.Context, contractID int64, hours float64, hoursType string, contractStart, contractEnd, at time.Time) (*ContractHoursBudgetResponse, error) {
+	periodStart, periodEnd, authorized, err := BudgetPeriod(hours, hoursType, contractStart, contractEnd, at)
+	if err != nil {
+		return nil, err
+	}
+
+	consumed, err := s.Store.GetContractConsumedMinutes(ctx, db.GetContractConsumedMinutesParams{
+		ContractID:  contractID,
+		PeriodStart: pgtype.Timestamp{Time: periodStart, Valid: true},
+		PeriodEnd:   pgtype.Timestamp{Time: periodEnd, Valid: true},
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "contractHoursBudget", "Failed to get consumed minutes", zap.Int64("contract_id", contractID), zap.Error(err))
+		return nil, err
+	}
+
+	total := consumed.AppointmentMinutes + consumed.RegisteredMinutes
+	return &ContractHoursBudgetResponse{
+		ContractID:         contractID,
+		HoursType:          hoursType,
+		PeriodStart:        periodStart,
+		PeriodEnd:          periodEnd,
+		AuthorizedMinutes:  authorized,
+		AppointmentMinutes: consumed.AppointmentMinutes,
+		RegisteredMinutes:  consumed.RegisteredMinutes,
+		ConsumedMinutes:    total,
+		RemainingMinutes:   authorized - total,
+		UsagePercent:       UsagePercent(authorized, total),
+	}, nil
+}
+
+// CheckContractHoursBudgets records the thresholds running contracts reached and returns
+// the ones to notify. When a contract passes several thresholds at once only the highest
+// one is returned.
+func (s *contractService) CheckContractHoursBudgets(ctx context.Context) ([]ContractHoursBudgetAlert, error) {
+	contracts, err := s.Store.ListContractsWithHoursBudget(ctx)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CheckContractHoursBudgets", "Failed to list contracts with an hours budget", zap.Error(err))
+		return nil, err
+	}
+
+	alerts := []ContractHoursBudgetAlert{}
+	now := time.Now()
+	for _, contract := range contracts {
+		budget, err := s.contractHoursBudget(ctx, contract.ID, *contract.Hours, *contract.HoursType, contract.StartDate.Time, contract.EndDate.Time, now)
+		if err != nil {
+			s.Logger.LogBusinessEvent(logger.LogLevelWarn, "CheckContractHoursBudgets", "Failed to calculate hours budget", zap.Int64("contract_id", contract.ID), zap.Error(err))
+			continue
+		}
+
+		var alert *ContractHoursBudgetAlert
+		for _, threshold := range ReachedThresholds(budget.AuthorizedMinutes, budget.ConsumedMinutes) {
+			_, err := s.Store.CreateContractBudgetAlert(ctx, db.CreateContractBudgetAlertParams{
+				ContractID:        contract.ID,
+				Threshold:         threshold,
+				PeriodStart:       pgtype.Date{Time: budget.PeriodStart, Valid: true},
+				AuthorizedMinutes: budget.AuthorizedMinutes,
+				ConsumedMinutes:   budget.ConsumedMinutes,
+			})
+			if errors.Is(err, pgx.ErrNoRows) {
+				continue
+			}
+			if err != nil {
+				s.Logger.LogBusinessEvent(logger.LogLevelError, "CheckContractHoursBudgets", "Failed to record budget alert", zap.Int64("contract_id", contract.ID), zap.Error(err))
+				return nil, err
+			}
+			alert = &ContractHoursBudgetAlert{
+				ContractID:        contract.ID,
+				ClientID:          contract.ClientID,
+				ClientFirstName:   contract.ClientFirstName,
+				ClientLastName:    contract.ClientLastName,
+				Threshold:         threshold,
+				PeriodStart:       budget.PeriodStart,
+				PeriodEnd:         budget.PeriodEnd,
+				AuthorizedMinutes: budget.AuthorizedMinutes,
+				ConsumedMinutes:   budget.ConsumedMinutes,
+			}
+		}
+		if alert == nil {
+			continue
+		}
+
+		alert.RecipientUserIDs, err = s.Store.ListContractCaseManagerUserIDs(ctx, contract.ID)
+		if err != nil {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "CheckContractHoursBudgets", "Failed to list case managers", zap.Int64("contract_id", contract.ID), zap.Error(err))
+			return nil, err
+		}
+		alerts = append(alerts, *alert)
+	}
+
+	return alerts, nil
+}
+
+func (s *contractService) CreateContractWorkingHours(ctx context.Context, req CreateContractWorkingHoursRequest, contractID int64) (*ContractWorkingHoursResponse, error) {
+	workingHours, err := s.Store.CreateContractWorkingHours(ctx, db.CreateContractWorkingHoursParams{
+		ContractID: contractID,
+		Minutes:    req.Minutes,
+		Datetime:   pgtype.Timestamptz{Time: req.Datetime, Valid: true},
+		Notes:      req.Notes,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CreateContractWorkingHours", "Failed to create working hours", zap.Int64("contract_id", contractID), zap.Error(err))
+		return nil, err
+	}
+
+	res := newContractWorkingHoursResponse(workingHours)
+	return &res, nil
+}
+
+func (s *contractService) ListContractWorkingHours(ctx *gin.Context, req pagination.Request, contractID int64) (*pagination.Response[ContractWorkingHoursResponse], error) {
+	params := req.GetParams()
+	workingHours, err := s.Store.ListContractWorkingHours(ctx, db.ListContractWorkingHoursParams{
+		ContractID: contractID,
+		Limit:      params.Limit,
+		Offset:     params.Offset,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ListContractWorkingHours", "Failed to list working hours", zap.Int64("contract_id", contractID), zap.Error(err))
+		return nil, err
+	}
+
+	var totalCount int64
+	res := make([]ContractWorkingHoursResponse, len(workingHours))
+	for i, item := range workingHours {
+		totalCount = item.TotalCount
+		res[i] = newContractWorkingHoursResponse(db.ContractWorkingHour{
+			ID:         item.ID,
+			ContractID: item.ContractID,
+			Minutes:    item.Minutes,
+			Datetime:   item.Datetime,
+			Notes:      item.Notes,
+		})
+	}
+
+	pag := pagination.NewResponse(ctx, req, res, totalCount)
+	return &pag, nil
+}
+
+func (s *contractService) DeleteContractWorkingHours(ctx context.Context, contractID int64, workingHoursID int64) error {
+	_, err := s.Store.DeleteContractWorkingHours(ctx, db.DeleteContractWorkingHoursParams{
+		ID:         workingHoursID,
+		ContractID: contractID,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "DeleteContractWorkingHours", "Failed to delete working hours", zap.Int64("contract_id", contractID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func newContractWorkingHoursResponse(workingHours db.ContractWorkingHour) ContractWorkingHoursResponse {
+	return ContractWorkingHoursResponse{
+		ID:         workingHours.ID,
+		ContractID: workingHours.ContractID,
+		Minutes:    workingHours.Minutes,
+		Datetime:   workingHours.Datetime.Time,
+		Notes:      workingHours.Notes,
+	}
+}
@@ -0,0 +1,50 @@
+package contract
+
+import (
+	"time"
+)
+
+// CreateContractWorkingHoursRequest registers care that was delivered outside the agenda
+type CreateContractWorkingHoursRequest struct {
+	Minutes  int32     `json:"minutes" binding:"required,gt=0"`
+	Datetime time.Time `json:"datetime" binding:"required"`
+	Notes    *string   `json:"notes"`
+}
+
+// ContractWorkingHoursResponse represents registered minutes on a contract
+type ContractWorkingHoursResponse struct {
+	ID         int64     `json:"id"`
+	ContractID int64     `json:"contract_id"`
+	Minutes    int32     `json:"minutes"`
+	Datetime   time.Time `json:"datetime"`
+	Notes      *string   `json:"notes"`
+}
+
+// ContractHoursBudgetResponse compares the indication of a contract with the care delivered
+// in the current budget period, a week for weekly hours or the whole contract otherwise
+type ContractHoursBudgetResponse struct {
+	ContractID         int64     `json:"contract_id"`
+	HoursType          string    `json:"hours_type"`
+	PeriodStart        time.Time `json:"period_start"`
+	PeriodEnd          time.Time `json:"period_end"`
+	AuthorizedMinutes  float64   `json:"authorized_minutes"`
+	AppointmentMinutes float64   `json:"appointment_minutes"`
+	RegisteredMinutes  float64   `json:"registered_minutes"`
+	ConsumedMinutes    float64   `json:"consumed_minutes"`
+	RemainingMinutes   float64   `json:"remaining_minutes"`
+	UsagePercent       float64   `json:"usage_percent"`
+}
+
+// ContractHoursBudgetAlert is a threshold a contract reached for the first time in its period
+type ContractHoursBudgetAlert struct {
+	ContractID        int64
+	ClientID          int64
+	ClientFirstName   string
+	ClientLastName    string
+	Threshold         int32
+	PeriodStart       time.Time
+	PeriodEnd         time.Time
+	AuthorizedMinutes float64
+	ConsumedMinutes   float64
+	RecipientUserIDs  []int64
+}
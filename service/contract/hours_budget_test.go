@@ -0,0 +1,47 @@
+package contract
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBudgetPeriod(t *testing.T) {
+	contractStart := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	contractEnd := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+	// a Thursday
+	at := time.Date(2025, 3, 13, 15, 30, 0, 0, time.UTC)
+
+	start, end, authorized, err := BudgetPeriod(4, "weekly", contractStart, contractEnd, at)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC), start)
+	require.Equal(t, time.Date(2025, 3, 17, 0, 0, 0, 0, time.UTC), end)
+	require.Equal(t, 240.0, authorized)
+
+	// Sunday belongs to the week that started on Monday
+	start, _, _, err = BudgetPeriod(4, "weekly", contractStart, contractEnd, time.Date(2025, 3, 16, 23, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC), start)
+
+	start, end, authorized, err = BudgetPeriod(100, "all_period", contractStart, contractEnd, at)
+	require.NoError(t, err)
+	require.Equal(t, contractStart, start)
+	require.Equal(t, contractEnd, end)
+	require.Equal(t, 6000.0, authorized)
+
+	_, _, _, err = BudgetPeriod(0, "weekly", contractStart, contractEnd, at)
+	require.ErrorIs(t, err, ErrNoHoursBudget)
+
+	_, _, _, err = BudgetPeriod(4, "monthly", contractStart, contractEnd, at)
+	require.Error(t, err)
+}
+
+func TestReachedThresholds(t *testing.T) {
+	require.Empty(t, ReachedThresholds(600, 479))
+	require.Equal(t, []int32{80}, ReachedThresholds(600, 480))
+	require.Equal(t, []int32{80, 100}, ReachedThresholds(600, 600))
+	require.Equal(t, []int32{80, 100}, ReachedThresholds(600, 900))
+	require.Empty(t, ReachedThresholds(0, 100))
+	require.Equal(t, 150.0, UsagePercent(600, 900))
+}
@@ -0,0 +1,288 @@
+package contract
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	db "maicare_go/db/sqlc"
+	"maicare_go/logger"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+const ContractStatusExpired = "expired"
+
+var (
+	ErrContractNotRenewable   = errors.New("only approved or expired contracts can be renewed")
+	ErrContractAlreadyRenewed = errors.New("contract has already been renewed")
+	ErrInvalidRenewalPeriod   = errors.New("invalid renewal period")
+)
+
+// RenewalTerms returns the period and price of the successor of a contract
+func RenewalTerms(predecessor db.Contract, req RenewContractRequest) (time.Time, time.Time, float64, error) {
+	start := predecessor.EndDate.Time
+	if req.StartDate != nil {
+		start = *req.StartDate
+	}
+	if start.Before(predecessor.StartDate.Time) {
+		return time.Time{}, time.Time{}, 0, fmt.Errorf("%w: the renewal cannot start before the contract it renews", ErrInvalidRenewalPeriod)
+	}
+	if !req.EndDate.After(start) {
+		return time.Time{}, time.Time{}, 0, fmt.Errorf("%w: end date must be after start date", ErrInvalidRenewalPeriod)
+	}
+
+	price := predecessor.Price
+	if req.Price != nil {
+		price = *req.Price
+	} else if req.PriceIndexPercentage != nil {
+		price = math.Round(price*(100+*req.PriceIndexPercentage)) / 100
+	}
+	return start, req.EndDate, price, nil
+}
+
+// RenewContract creates a draft copy of a contract for a new period and links it as the
+// successor. The contract is closed when the draft is approved.
+func (s *contractService) RenewContract(ctx context.Context, req RenewContractRequest, contractID int64, employeeID int64) (*RenewContractResponse, error) {
+	tx, err := s.Store.ConnPool.Begin(ctx)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "RenewContract", "Failed to begin transaction", zap.Int64("contract_id", contractID), zap.Error(err))
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.Store.WithTx(tx)
+
+	_, err = tx.Exec(ctx, fmt.Sprintf("SET LOCAL myapp.current_employee_id = %d", employeeID))
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "RenewContract", "Failed to set current employee ID", zap.Int64("contract_id", contractID), zap.Int64("employee_id", employeeID), zap.Error(err))
+		return nil, err
+	}
+
+	predecessor, err := qtx.GetContractForUpdate(ctx, contractID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "RenewContract", "Failed to get contract", zap.Int64("contract_id", contractID), zap.Error(err))
+		return nil, err
+	}
+	if predecessor.Status != ContractStatusApproved && predecessor.Status != ContractStatusExpired {
+		return nil, ErrContractNotRenewable
+	}
+	if existing, err := qtx.GetContractRenewalByPredecessor(ctx, contractID); err == nil {
+		return nil, fmt.Errorf("%w: draft contract %d", ErrContractAlreadyRenewed, existing.SuccessorID)
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "RenewContract", "Failed to get renewal", zap.Int64("contract_id", contractID), zap.Error(err))
+		return nil, err
+	}
+
+	start, end, price, err := RenewalTerms(predecessor, req)
+	if err != nil {
+		return nil, err
+	}
+
+	attachmentIDs := slices.Clone(predecessor.AttachmentIds)
+	for _, id := range req.AttachmentIds {
+		if slices.Contains(attachmentIDs, id) {
+			continue
+		}
+		attachmentIDs = append(attachmentIDs, id)
+		if _, err := qtx.SetAttachmentAsUsedorUnused(ctx, db.SetAttachmentAsUsedorUnusedParams{Uuid: id, IsUsed: true}); err != nil {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "RenewContract", "Failed to mark attachment as used", zap.String("attachment_id", id.String()), zap.Error(err))
+			return nil, err
+		}
+	}
+	if attachmentIDs == nil {
+		attachmentIDs = []uuid.UUID{}
+	}
+
+	successor, err := qtx.CreateContract(ctx, db.CreateContractParams{
+		TypeID:           predecessor.TypeID,
+		StartDate:        pgtype.Timestamptz{Time: start, Valid: true},
+		EndDate:          pgtype.Timestamptz{Time: end, Valid: true},
+		ReminderPeriod:   predecessor.ReminderPeriod,
+		Vat:              predecessor.Vat,
+		Price:            price,
+		PriceTimeUnit:    predecessor.PriceTimeUnit,
+		Hours:            predecessor.Hours,
+		HoursType:        predecessor.HoursType,
+		CareName:         predecessor.CareName,
+		CareType:         predecessor.CareType,
+		ClientID:         predecessor.ClientID,
+		SenderID:         predecessor.SenderID,
+		Status:           "draft",
+		AttachmentIds:    attachmentIDs,
+		FinancingAct:     predecessor.FinancingAct,
+		FinancingOption:  predecessor.FinancingOption,
+		IndicationNumber: predecessor.IndicationNumber,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "RenewContract", "Failed to create draft contract", zap.Int64("contract_id", contractID), zap.Error(err))
+		return nil, err
+	}
+
+	if _, err := qtx.CreateContractRenewal(ctx, db.CreateContractRenewalParams{
+		PredecessorID:        predecessor.ID,
+		SuccessorID:          successor.ID,
+		PriceIndexPercentage: req.PriceIndexPercentage,
+		CreatedBy:            &employeeID,
+	}); err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "RenewContract", "Failed to link renewal", zap.Int64("contract_id", contractID), zap.Int64("successor_id", successor.ID), zap.Error(err))
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "RenewContract", "Failed to commit transaction", zap.Int64("contract_id", contractID), zap.Error(err))
+		return nil, err
+	}
+
+	return &RenewContractResponse{
+		PredecessorID:        predecessor.ID,
+		PriceIndexPercentage: req.PriceIndexPercentage,
+		Contract:             newCreateContractResponse(successor),
+	}, nil
+}
+
+// ListContractVersions returns the renewal chain the contract is part of
+func (s *contractService) ListContractVersions(ctx context.Context, contractID int64) ([]ContractVersionResponse, error) {
+	rows, err := s.Store.ListContractVersions(ctx, contractID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ListContractVersions", "Failed to list contract versions", zap.Int64("contract_id", contractID), zap.Error(err))
+		return nil, err
+	}
+
+	versions := make([]ContractVersionResponse, len(rows))
+	for i, row := range rows {
+		versions[i] = ContractVersionResponse{
+			Version:              row.Version,
+			ContractID:           row.ID,
+			Status:               row.Status,
+			StartDate:            row.StartDate.Time,
+			EndDate:              row.EndDate.Time,
+			Price:                row.Price,
+			PriceTimeUnit:        row.PriceTimeUnit,
+			PriceIndexPercentage: row.PriceIndexPercentage,
+		}
+		if row.ClosedAt.Valid {
+			versions[i].ClosedAt = &row.ClosedAt.Time
+		}
+	}
+	return versions, nil
+}
+
+// ExpiresOnClose tells whether the predecessor of a renewal expires right away when the
+// renewal is approved. A predecessor that still runs until closeAt keeps its status and is
+// expired by ExpireRenewedContracts once closeAt has passed, a predecessor that the
+// renewal replaces before it started has no period left to run.
+func ExpiresOnClose(predecessor db.Contract, closeAt time.Time, now time.Time) bool {
+	return !closeAt.After(now) || !closeAt.After(predecessor.StartDate.Time)
+}
+
+// ExpireRenewedContracts expires the renewed contracts whose expired period has started
+func (s *contractService) ExpireRenewedContracts(ctx context.Context) ([]int64, error) {
+	ids, err := s.Store.ExpireRenewedContracts(ctx)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ExpireRenewedContracts", "Failed to expire renewed contracts", zap.Error(err))
+		return nil, err
+	}
+	return ids, nil
+}
+
+// closeRenewedContract closes the predecessor of an approved renewal. The predecessor
+// ends where the renewal starts and expires from then on, so the periods of both
+// contracts never overlap.
+func (s *contractService) closeRenewedContract(ctx context.Context, qtx *db.Queries, successor db.Contract, employeeID int64) error {
+	renewal, err := qtx.GetContractRenewalBySuccessor(ctx, successor.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "closeRenewedContract", "Failed to get renewal", zap.Int64("contract_id", successor.ID), zap.Error(err))
+		return err
+	}
+	if renewal.ClosedAt.Valid {
+		return nil
+	}
+
+	predecessor, err := qtx.GetContractForUpdate(ctx, renewal.PredecessorID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "closeRenewedContract", "Failed to get renewed contract", zap.Int64("contract_id", renewal.PredecessorID), zap.Error(err))
+		return err
+	}
+
+	closeAt := predecessor.EndDate.Time
+	if successor.StartDate.Time.Before(closeAt) {
+		closeAt = successor.StartDate.Time
+		if _, err := qtx.UpdateContract(ctx, db.UpdateContractParams{
+			ID:      predecessor.ID,
+			EndDate: pgtype.Timestamptz{Time: closeAt, Valid: true},
+		}); err != nil {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "closeRenewedContract", "Failed to end renewed contract", zap.Int64("contract_id", predecessor.ID), zap.Error(err))
+			return err
+		}
+	}
+
+	if closeAt.After(predecessor.StartDate.Time) {
+		timeline, err := s.loadStatusTimeline(ctx, qtx, predecessor.ID)
+		if err != nil {
+			return err
+		}
+		reason := fmt.Sprintf("renewed by contract %d", successor.ID)
+		timeline = ApplyStatusPeriod(timeline, StatusPeriod{
+			Status:    ContractStatusExpired,
+			From:      closeAt,
+			Reason:    &reason,
+			CreatedBy: &employeeID,
+		})
+		if _, err := s.saveStatusTimeline(ctx, qtx, predecessor.ID, timeline); err != nil {
+			return err
+		}
+	}
+
+	if ExpiresOnClose(predecessor, closeAt, time.Now()) {
+		if _, err := qtx.UpdateContractStatus(ctx, db.UpdateContractStatusParams{
+			ContractID: predecessor.ID,
+			Status:     ContractStatusExpired,
+		}); err != nil {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "closeRenewedContract", "Failed to expire renewed contract", zap.Int64("contract_id", predecessor.ID), zap.Error(err))
+			return err
+		}
+	}
+
+	if err := qtx.CloseContractRenewal(ctx, renewal.ID); err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "closeRenewedContract", "Failed to close renewal", zap.Int64("renewal_id", renewal.ID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func newCreateContractResponse(contract db.Contract) CreateContractResponse {
+	return CreateContractResponse{
+		ID:               contract.ID,
+		TypeID:           contract.TypeID,
+		Status:           contract.Status,
+		StartDate:        contract.StartDate.Time,
+		EndDate:          contract.EndDate.Time,
+		ReminderPeriod:   contract.ReminderPeriod,
+		Vat:              contract.Vat,
+		Price:            contract.Price,
+		PriceTimeUnit:    contract.PriceTimeUnit,
+		Hours:            contract.Hours,
+		HoursType:        contract.HoursType,
+		CareName:         contract.CareName,
+		CareType:         contract.CareType,
+		ClientID:         contract.ClientID,
+		SenderID:         contract.SenderID,
+		AttachmentIds:    contract.AttachmentIds,
+		FinancingAct:     contract.FinancingAct,
+		FinancingOption:  contract.FinancingOption,
+		IndicationNumber: contract.IndicationNumber,
+		DepartureReason:  contract.DepartureReason,
+		DepartureReport:  contract.DepartureReport,
+		UpdatedAt:        contract.UpdatedAt,
+		CreatedAt:        contract.CreatedAt,
+	}
+}
@@ -0,0 +1,40 @@
+package contract
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RenewContractRequest creates a draft successor of a contract. The new period starts
+// when the contract ends unless a start date is given. The price is indexed by the
+// percentage, an explicit price takes precedence. New indication documents are attached
+// next to the documents of the contract that is renewed.
+type RenewContractRequest struct {
+	StartDate            *time.Time  `json:"start_date" example:"2026-01-01T00:00:00Z"`
+	EndDate              time.Time   `json:"end_date" binding:"required" example:"2026-12-31T00:00:00Z"`
+	PriceIndexPercentage *float64    `json:"price_index_percentage" binding:"omitempty,min=-100,max=100" example:"3.5"`
+	Price                *float64    `json:"price" binding:"omitempty,min=0" example:"104.02"`
+	AttachmentIds        []uuid.UUID `json:"attachment_ids"`
+}
+
+// RenewContractResponse is the draft that renews a contract
+type RenewContractResponse struct {
+	PredecessorID        int64                  `json:"predecessor_id"`
+	PriceIndexPercentage *float64               `json:"price_index_percentage"`
+	Contract             CreateContractResponse `json:"contract"`
+}
+
+// ContractVersionResponse is a version in the renewal chain of a contract, the first
+// contract is version 1
+type ContractVersionResponse struct {
+	Version              int32      `json:"version"`
+	ContractID           int64      `json:"contract_id"`
+	Status               string     `json:"status"`
+	StartDate            time.Time  `json:"start_date"`
+	EndDate              time.Time  `json:"end_date"`
+	Price                float64    `json:"price"`
+	PriceTimeUnit        string     `json:"price_time_unit"`
+	PriceIndexPercentage *float64   `json:"price_index_percentage"`
+	ClosedAt             *time.Time `json:"closed_at"`
+}
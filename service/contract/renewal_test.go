@@ -0,0 +1,108 @@
+package contract
+
+import (
+	"testing"
+	"time"
+
+	db "maicare_go/db/sqlc"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenewalTerms(t *testing.T) {
+	predecessor := db.Contract{
+		StartDate: pgtype.Timestamptz{Time: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		EndDate:   pgtype.Timestamptz{Time: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		Price:     100.5,
+	}
+	end := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+	index := 3.5
+	price := 110.0
+	early := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	tooEarly := time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		req       RenewContractRequest
+		wantStart time.Time
+		wantPrice float64
+		wantErr   error
+	}{
+		{
+			name:      "starts when the contract ends",
+			req:       RenewContractRequest{EndDate: end},
+			wantStart: predecessor.EndDate.Time,
+			wantPrice: 100.5,
+		},
+		{
+			name:      "indexes the price",
+			req:       RenewContractRequest{EndDate: end, PriceIndexPercentage: &index},
+			wantStart: predecessor.EndDate.Time,
+			wantPrice: 104.02,
+		},
+		{
+			name:      "explicit price wins over the index",
+			req:       RenewContractRequest{EndDate: end, PriceIndexPercentage: &index, Price: &price},
+			wantStart: predecessor.EndDate.Time,
+			wantPrice: 110,
+		},
+		{
+			name:      "starts before the contract ends",
+			req:       RenewContractRequest{StartDate: &early, EndDate: end},
+			wantStart: early,
+			wantPrice: 100.5,
+		},
+		{
+			name:    "starts before the contract it renews",
+			req:     RenewContractRequest{StartDate: &tooEarly, EndDate: end},
+			wantErr: ErrInvalidRenewalPeriod,
+		},
+		{
+			name:    "ends before it starts",
+			req:     RenewContractRequest{EndDate: predecessor.EndDate.Time},
+			wantErr: ErrInvalidRenewalPeriod,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, gotEnd, gotPrice, err := RenewalTerms(predecessor, tt.req)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantStart, start)
+			require.Equal(t, end, gotEnd)
+			require.Equal(t, tt.wantPrice, gotPrice)
+		})
+	}
+}
+
+func TestExpiresOnClose(t *testing.T) {
+	predecessor := db.Contract{
+		StartDate: pgtype.Timestamptz{Time: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		EndDate:   pgtype.Timestamptz{Time: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+	}
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	beforeStart := time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		closeAt time.Time
+		now     time.Time
+		want    bool
+	}{
+		{name: "renewal started before it was approved", closeAt: time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC), now: now, want: true},
+		{name: "renewal starts now", closeAt: now, now: now, want: true},
+		{name: "renewal starts later", closeAt: predecessor.EndDate.Time, now: now, want: false},
+		{name: "renewal replaces a contract that has not started", closeAt: predecessor.StartDate.Time, now: beforeStart, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, ExpiresOnClose(predecessor, tt.closeAt, tt.now))
+		})
+	}
+}
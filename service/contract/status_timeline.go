@@ -0,0 +1,316 @@
+package contract
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	db "maicare_go/db/sqlc"
+	"maicare_go/logger"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+const (
+	ContractStatusApproved  = "approved"
+	ContractStatusSuspended = "suspended"
+)
+
+var (
+	ErrInvalidStatusPeriod = errors.New("invalid contract status period")
+	ErrSuspensionNotFound  = errors.New("suspension not found")
+)
+
+// StatusPeriod is a period of the status timeline of a contract, a period without an
+// end runs until the next change
+type StatusPeriod struct {
+	ID        int64
+	Status    string
+	From      time.Time
+	To        *time.Time
+	Reason    *string
+	CreatedBy *int64
+}
+
+// end returns the end of the period, open periods end far in the future
+func (p StatusPeriod) end() time.Time {
+	if p.To == nil {
+		return time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+	}
+	return *p.To
+}
+
+// ApplyStatusPeriod sets the status of the timeline from the start of the change until
+// its end. Without an end the status holds until the end of the timeline, otherwise the
+// earlier status continues after it. Suspensions after a new approval are kept, as they
+// still apply to the approved contract.
+func ApplyStatusPeriod(timeline []StatusPeriod, change StatusPeriod) []StatusPeriod {
+	changeEnd := change.end()
+
+	var out, suspensions []StatusPeriod
+	for _, p := range timeline {
+		if change.To == nil && change.Status == ContractStatusApproved && p.Status == ContractStatusSuspended && !p.From.Before(change.From) {
+			suspensions = append(suspensions, p)
+		}
+		if p.From.Before(change.From) {
+			before := p
+			if p.end().After(change.From) {
+				to := change.From
+				before.To = &to
+			}
+			out = append(out, before)
+		}
+		if p.end().After(changeEnd) {
+			after := p
+			if after.From.Before(changeEnd) {
+				after.From = changeEnd
+			}
+			out = append(out, after)
+		}
+	}
+	out = append(out, change)
+	sortStatusPeriods(out)
+
+	for _, suspension := range suspensions {
+		out = ApplyStatusPeriod(out, suspension)
+	}
+	return mergeStatusPeriods(out)
+}
+
+// RemoveSuspension takes a suspension out of the timeline, the status before it
+// continues over its period
+func RemoveSuspension(timeline []StatusPeriod, periodID int64) ([]StatusPeriod, error) {
+	index := slices.IndexFunc(timeline, func(p StatusPeriod) bool {
+		return p.ID == periodID && p.Status == ContractStatusSuspended
+	})
+	if index < 0 {
+		return nil, ErrSuspensionNotFound
+	}
+
+	out := slices.Clone(timeline)
+	if index == 0 || !adjoins(out[index-1], out[index]) {
+		out = slices.Delete(out, index, index+1)
+		return mergeStatusPeriods(out), nil
+	}
+	previous := out[index-1]
+	out[index].Status = previous.Status
+	out[index].Reason = previous.Reason
+	return mergeStatusPeriods(out), nil
+}
+
+// StatusAt returns the period of the timeline that covers a moment
+func StatusAt(timeline []StatusPeriod, at time.Time) (StatusPeriod, bool) {
+	for _, p := range timeline {
+		if !at.Before(p.From) && at.Before(p.end()) {
+			return p, true
+		}
+	}
+	return StatusPeriod{}, false
+}
+
+// ValidateSuspension checks that a suspension lies completely within approved periods
+// of the timeline, a contract can only be suspended while it is approved
+func ValidateSuspension(timeline []StatusPeriod, from time.Time, to *time.Time) error {
+	if to != nil && !to.After(from) {
+		return fmt.Errorf("%w: the end of a suspension has to be after its start", ErrInvalidStatusPeriod)
+	}
+	suspension := StatusPeriod{From: from, To: to}
+
+	covered := from
+	for _, p := range timeline {
+		if !p.end().After(from) || !p.From.Before(suspension.end()) {
+			continue
+		}
+		if p.Status != ContractStatusApproved || p.From.After(covered) {
+			return fmt.Errorf("%w: the contract is not approved during the whole suspension", ErrInvalidStatusPeriod)
+		}
+		covered = p.end()
+	}
+	if covered.Before(suspension.end()) {
+		return fmt.Errorf("%w: the contract is not approved during the whole suspension", ErrInvalidStatusPeriod)
+	}
+	return nil
+}
+
+func adjoins(a, b StatusPeriod) bool {
+	return a.To != nil && a.To.Equal(b.From)
+}
+
+func sortStatusPeriods(timeline []StatusPeriod) {
+	slices.SortStableFunc(timeline, func(a, b StatusPeriod) int {
+		return a.From.Compare(b.From)
+	})
+}
+
+// mergeStatusPeriods joins adjoining periods with the same status and reason
+func mergeStatusPeriods(timeline []StatusPeriod) []StatusPeriod {
+	var out []StatusPeriod
+	for _, p := range timeline {
+		if len(out) > 0 {
+			last := &out[len(out)-1]
+			if adjoins(*last, p) && last.Status == p.Status && equalReason(last.Reason, p.Reason) {
+				last.To = p.To
+				continue
+			}
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+func equalReason(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func (s *contractService) ListContractStatusPeriods(ctx context.Context, contractID int64) ([]ContractStatusPeriodResponse, error) {
+	periods, err := s.Store.ListContractStatusPeriods(ctx, contractID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ListContractStatusPeriods", "Failed to list contract status periods", zap.Int64("contract_id", contractID), zap.Error(err))
+		return nil, err
+	}
+	return newContractStatusPeriodResponses(periods), nil
+}
+
+func (s *contractService) CreateContractSuspension(ctx context.Context, req CreateContractSuspensionRequest, contractID int64, employeeID int64) ([]ContractStatusPeriodResponse, error) {
+	return s.changeStatusTimeline(ctx, "CreateContractSuspension", contractID, employeeID, func(timeline []StatusPeriod) ([]StatusPeriod, error) {
+		if err := ValidateSuspension(timeline, req.StartDate, req.EndDate); err != nil {
+			return nil, err
+		}
+		return ApplyStatusPeriod(timeline, StatusPeriod{
+			Status:    ContractStatusSuspended,
+			From:      req.StartDate,
+			To:        req.EndDate,
+			Reason:    &req.Reason,
+			CreatedBy: &employeeID,
+		}), nil
+	})
+}
+
+func (s *contractService) DeleteContractSuspension(ctx context.Context, contractID int64, periodID int64, employeeID int64) ([]ContractStatusPeriodResponse, error) {
+	return s.changeStatusTimeline(ctx, "DeleteContractSuspension", contractID, employeeID, func(timeline []StatusPeriod) ([]StatusPeriod, error) {
+		return RemoveSuspension(timeline, periodID)
+	})
+}
+
+// changeStatusTimeline loads the timeline of a contract, applies a change to it and
+// stores the result in a single transaction
+func (s *contractService) changeStatusTimeline(ctx context.Context, operation string, contractID int64, employeeID int64,
+	change func(timeline []StatusPeriod) ([]StatusPeriod, error)) ([]ContractStatusPeriodResponse, error) {
+	tx, err := s.Store.ConnPool.Begin(ctx)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, operation, "Failed to begin transaction", zap.Int64("contract_id", contractID), zap.Error(err))
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.Store.WithTx(tx)
+
+	_, err = tx.Exec(ctx, fmt.Sprintf("SET LOCAL myapp.current_employee_id = %d", employeeID))
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, operation, "Failed to set current employee ID", zap.Int64("contract_id", contractID), zap.Int64("employee_id", employeeID), zap.Error(err))
+		return nil, err
+	}
+
+	if _, err := qtx.GetClientContract(ctx, contractID); err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, operation, "Failed to get client contract", zap.Int64("contract_id", contractID), zap.Error(err))
+		return nil, err
+	}
+
+	timeline, err := s.loadStatusTimeline(ctx, qtx, contractID)
+	if err != nil {
+		return nil, err
+	}
+	timeline, err = change(timeline)
+	if err != nil {
+		return nil, err
+	}
+	periods, err := s.saveStatusTimeline(ctx, qtx, contractID, timeline)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, operation, "Failed to commit transaction", zap.Int64("contract_id", contractID), zap.Error(err))
+		return nil, err
+	}
+
+	return newContractStatusPeriodResponses(periods), nil
+}
+
+func (s *contractService) loadStatusTimeline(ctx context.Context, qtx *db.Queries, contractID int64) ([]StatusPeriod, error) {
+	periods, err := qtx.ListContractStatusPeriods(ctx, contractID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "loadStatusTimeline", "Failed to list contract status periods", zap.Int64("contract_id", contractID), zap.Error(err))
+		return nil, err
+	}
+
+	timeline := make([]StatusPeriod, len(periods))
+	for i, p := range periods {
+		timeline[i] = StatusPeriod{
+			ID:        p.ID,
+			Status:    p.Status,
+			From:      p.EffectiveFrom.Time,
+			Reason:    p.Reason,
+			CreatedBy: p.CreatedBy,
+		}
+		if p.EffectiveTo.Valid {
+			to := p.EffectiveTo.Time
+			timeline[i].To = &to
+		}
+	}
+	return timeline, nil
+}
+
+// saveStatusTimeline replaces the stored timeline of a contract
+func (s *contractService) saveStatusTimeline(ctx context.Context, qtx *db.Queries, contractID int64, timeline []StatusPeriod) ([]db.ContractStatusPeriod, error) {
+	if err := qtx.DeleteContractStatusPeriods(ctx, contractID); err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "saveStatusTimeline", "Failed to delete contract status periods", zap.Int64("contract_id", contractID), zap.Error(err))
+		return nil, err
+	}
+
+	periods := make([]db.ContractStatusPeriod, len(timeline))
+	for i, p := range timeline {
+		params := db.CreateContractStatusPeriodParams{
+			ContractID:    contractID,
+			Status:        p.Status,
+			EffectiveFrom: pgtype.Timestamptz{Time: p.From, Valid: true},
+			Reason:        p.Reason,
+			CreatedBy:     p.CreatedBy,
+		}
+		if p.To != nil {
+			params.EffectiveTo = pgtype.Timestamptz{Time: *p.To, Valid: true}
+		}
+		period, err := qtx.CreateContractStatusPeriod(ctx, params)
+		if err != nil {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "saveStatusTimeline", "Failed to create contract status period", zap.Int64("contract_id", contractID), zap.String("status", p.Status), zap.Error(err))
+			return nil, err
+		}
+		periods[i] = period
+	}
+	return periods, nil
+}
+
+func newContractStatusPeriodResponses(periods []db.ContractStatusPeriod) []ContractStatusPeriodResponse {
+	responses := make([]ContractStatusPeriodResponse, len(periods))
+	for i, p := range periods {
+		responses[i] = ContractStatusPeriodResponse{
+			ID:            p.ID,
+			ContractID:    p.ContractID,
+			Status:        p.Status,
+			EffectiveFrom: p.EffectiveFrom.Time,
+			Reason:        p.Reason,
+			CreatedBy:     p.CreatedBy,
+			CreatedAt:     p.CreatedAt.Time,
+		}
+		if p.EffectiveTo.Valid {
+			to := p.EffectiveTo.Time
+			responses[i].EffectiveTo = &to
+		}
+	}
+	return responses
+}
@@ -0,0 +1,23 @@
+package contract
+
+import "time"
+
+// CreateContractSuspensionRequest suspends an approved contract, for example during a
+// hospital stay. Without an end date the suspension lasts until it is removed.
+type CreateContractSuspensionRequest struct {
+	StartDate time.Time  `json:"start_date" binding:"required" example:"2025-03-01T00:00:00Z"`
+	EndDate   *time.Time `json:"end_date" example:"2025-03-15T00:00:00Z"`
+	Reason    string     `json:"reason" binding:"required,max=255" example:"hospital stay"`
+}
+
+// ContractStatusPeriodResponse is a period of the status timeline of a contract
+type ContractStatusPeriodResponse struct {
+	ID            int64      `json:"id"`
+	ContractID    int64      `json:"contract_id"`
+	Status        string     `json:"status"`
+	EffectiveFrom time.Time  `json:"effective_from"`
+	EffectiveTo   *time.Time `json:"effective_to"`
+	Reason        *string    `json:"reason"`
+	CreatedBy     *int64     `json:"created_by"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
@@ -0,0 +1,108 @@
+package contract
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func day(d int) time.Time {
+	return time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC)
+}
+
+func dayPtr(d int) *time.Time {
+	t := day(d)
+	return &t
+}
+
+type timelineStep struct {
+	status string
+	from   int
+	to     int // 0 means open
+}
+
+func requireTimeline(t *testing.T, expected []timelineStep, timeline []StatusPeriod) {
+	t.Helper()
+	require.Len(t, timeline, len(expected), "timeline: %+v", timeline)
+	for i, step := range expected {
+		require.Equal(t, step.status, timeline[i].Status, "period %d", i)
+		require.Equal(t, day(step.from), timeline[i].From, "period %d", i)
+		if step.to == 0 {
+			require.Nil(t, timeline[i].To, "period %d", i)
+		} else {
+			require.NotNil(t, timeline[i].To, "period %d", i)
+			require.Equal(t, day(step.to), *timeline[i].To, "period %d", i)
+		}
+	}
+}
+
+func TestApplyStatusPeriod(t *testing.T) {
+	// draft on the 1st, approved on the 10th
+	timeline := ApplyStatusPeriod(nil, StatusPeriod{Status: "draft", From: day(1)})
+	timeline = ApplyStatusPeriod(timeline, StatusPeriod{Status: ContractStatusApproved, From: day(10)})
+	requireTimeline(t, []timelineStep{{"draft", 1, 10}, {ContractStatusApproved, 10, 0}}, timeline)
+
+	// backdated approval takes over the draft period
+	backdated := ApplyStatusPeriod(timeline, StatusPeriod{Status: ContractStatusApproved, From: day(5)})
+	requireTimeline(t, []timelineStep{{"draft", 1, 5}, {ContractStatusApproved, 5, 0}}, backdated)
+
+	// a hospital stay splits the approved period
+	reason := "hospital stay"
+	suspended := ApplyStatusPeriod(timeline, StatusPeriod{Status: ContractStatusSuspended, From: day(15), To: dayPtr(20), Reason: &reason})
+	requireTimeline(t, []timelineStep{
+		{"draft", 1, 10}, {ContractStatusApproved, 10, 15}, {ContractStatusSuspended, 15, 20}, {ContractStatusApproved, 20, 0},
+	}, suspended)
+
+	// a later suspension survives a backdated approval
+	reapproved := ApplyStatusPeriod(suspended, StatusPeriod{Status: ContractStatusApproved, From: day(3)})
+	requireTimeline(t, []timelineStep{
+		{"draft", 1, 3}, {ContractStatusApproved, 3, 15}, {ContractStatusSuspended, 15, 20}, {ContractStatusApproved, 20, 0},
+	}, reapproved)
+
+	// termination ends everything after it
+	terminated := ApplyStatusPeriod(suspended, StatusPeriod{Status: "terminated", From: day(18)})
+	requireTimeline(t, []timelineStep{
+		{"draft", 1, 10}, {ContractStatusApproved, 10, 15}, {ContractStatusSuspended, 15, 18}, {"terminated", 18, 0},
+	}, terminated)
+}
+
+func TestRemoveSuspension(t *testing.T) {
+	reason := "hospital stay"
+	timeline := []StatusPeriod{
+		{ID: 1, Status: ContractStatusApproved, From: day(1), To: dayPtr(10)},
+		{ID: 2, Status: ContractStatusSuspended, From: day(10), To: dayPtr(20), Reason: &reason},
+		{ID: 3, Status: ContractStatusApproved, From: day(20)},
+	}
+
+	restored, err := RemoveSuspension(timeline, 2)
+	require.NoError(t, err)
+	requireTimeline(t, []timelineStep{{ContractStatusApproved, 1, 0}}, restored)
+	// the input is left untouched
+	require.Equal(t, ContractStatusSuspended, timeline[1].Status)
+
+	_, err = RemoveSuspension(timeline, 1)
+	require.ErrorIs(t, err, ErrSuspensionNotFound)
+}
+
+func TestValidateSuspension(t *testing.T) {
+	timeline := []StatusPeriod{
+		{Status: "draft", From: day(1), To: dayPtr(5)},
+		{Status: ContractStatusApproved, From: day(5), To: dayPtr(20)},
+		{Status: "terminated", From: day(20)},
+	}
+
+	require.NoError(t, ValidateSuspension(timeline, day(6), dayPtr(10)))
+	require.ErrorIs(t, ValidateSuspension(timeline, day(3), dayPtr(10)), ErrInvalidStatusPeriod)
+	require.ErrorIs(t, ValidateSuspension(timeline, day(15), dayPtr(25)), ErrInvalidStatusPeriod)
+	require.ErrorIs(t, ValidateSuspension(timeline, day(15), nil), ErrInvalidStatusPeriod)
+	require.ErrorIs(t, ValidateSuspension(timeline, day(10), dayPtr(10)), ErrInvalidStatusPeriod)
+
+	open := []StatusPeriod{{Status: ContractStatusApproved, From: day(1)}}
+	require.NoError(t, ValidateSuspension(open, day(6), nil))
+
+	_, ok := StatusAt(timeline, day(7))
+	require.True(t, ok)
+	period, _ := StatusAt(timeline, day(20))
+	require.Equal(t, "terminated", period.Status)
+}
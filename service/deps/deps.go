@@ -5,6 +5,8 @@ import (
 	"maicare_go/async/aclient"
 	"maicare_go/bucket"
 	db "maicare_go/db/sqlc"
+	"maicare_go/email"
+	"maicare_go/hub"
 	"maicare_go/logger"
 	"maicare_go/token"
 	"maicare_go/util"
@@ -18,15 +20,20 @@ type ServiceDependencies struct {
 	Config      *util.Config
 	B2Client    bucket.ObjectStorageInterface
 	AsynqClient aclient.AsynqClientInterface
+	Mailer      email.MailerInterface
+	Hub         *hub.Hub
 }
 
-func NewServiceDependencies(store *db.Store, tokenMaker token.Maker, logger logger.Logger, config *util.Config, b2Client bucket.ObjectStorageInterface) *ServiceDependencies {
+func NewServiceDependencies(store *db.Store, tokenMaker token.Maker, logger logger.Logger, config *util.Config, b2Client bucket.ObjectStorageInterface, asynqClient aclient.AsynqClientInterface, hubInstance *hub.Hub) *ServiceDependencies {
 	return &ServiceDependencies{
-		Store:      store,
-		TokenMaker: tokenMaker,
-		Logger:     logger,
-		Config:     config,
-		B2Client:   b2Client,
+		Store:       store,
+		TokenMaker:  tokenMaker,
+		Logger:      logger,
+		Config:      config,
+		B2Client:    b2Client,
+		AsynqClient: asynqClient,
+		Mailer:      email.NewBrevoConf(config.BrevoSenderName, config.BrevoSenderEmail, config.BrevoApiKey),
+		Hub:         hubInstance,
 	}
 }
 
@@ -0,0 +1,484 @@
+package employees
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	db "maicare_go/db/sqlc"
+	"maicare_go/logger"
+	"maicare_go/pdf"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrInvalidStatementMonth = errors.New("invalid month, expected YYYY-MM")
+	ErrStatementNotConcept   = errors.New("only concept statements can be approved or rejected")
+	ErrNoStatementsToExport  = errors.New("no approved statements to export")
+	ErrNoSubcontractorShifts = errors.New("no shifts of subcontractors in this month")
+)
+
+// SelfBillingDraft is a statement computed from the shifts of a subcontractor at one
+// organisation
+type SelfBillingDraft struct {
+	EmployeeID     int64
+	EmployeeName   string
+	OrganisationID int64
+	Rate           float64
+	Hours          float64
+	Subtotal       float64
+	VatRate        float64
+	VatAmount      float64
+	TotalAmount    float64
+	Lines          []SelfBillingLine
+}
+
+// ParseStatementMonth returns the first day of the month and the first day of the next
+// month
+func ParseStatementMonth(month string) (time.Time, time.Time, error) {
+	start, err := time.Parse("2006-01", month)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: %s", ErrInvalidStatementMonth, month)
+	}
+	return start, start.AddDate(0, 1, 0), nil
+}
+
+// BuildSelfBillingDrafts groups the shifts per subcontractor and organisation and prices
+// the worked hours, without breaks, at the contract rate. A shift counts in the month it
+// starts in.
+// Subcontractors without a contract rate are skipped.
+func BuildSelfBillingDrafts(shifts []db.ListSubcontractorShiftsForPeriodRow, vatRate float64) ([]SelfBillingDraft, []SkippedSelfBilling) {
+	var drafts []SelfBillingDraft
+	var skipped []SkippedSelfBilling
+	index := map[[2]int64]int{}
+	skippedKeys := map[[2]int64]bool{}
+
+	for _, shift := range shifts {
+		key := [2]int64{shift.EmployeeID, shift.OrganisationID}
+		name := strings.TrimSpace(shift.FirstName + " " + shift.LastName)
+		if shift.ContractRate == nil || *shift.ContractRate <= 0 {
+			if !skippedKeys[key] {
+				skippedKeys[key] = true
+				skipped = append(skipped, SkippedSelfBilling{
+					EmployeeID:     shift.EmployeeID,
+					EmployeeName:   name,
+					OrganisationID: shift.OrganisationID,
+					Reason:         "no contract rate",
+				})
+			}
+			continue
+		}
+
+		i, ok := index[key]
+		if !ok {
+			drafts = append(drafts, SelfBillingDraft{
+				EmployeeID:     shift.EmployeeID,
+				EmployeeName:   name,
+				OrganisationID: shift.OrganisationID,
+				Rate:           *shift.ContractRate,
+				VatRate:        vatRate,
+			})
+			i = len(drafts) - 1
+			index[key] = i
+		}
+
+		worked := shift.EndDatetime.Time.Sub(shift.StartDatetime.Time) - time.Duration(shift.BreakMinutes)*time.Minute
+		hours := round2(worked.Hours())
+		if hours <= 0 {
+			continue
+		}
+		amount := round2(hours * drafts[i].Rate)
+		drafts[i].Lines = append(drafts[i].Lines, SelfBillingLine{
+			ScheduleID:   shift.ID.String(),
+			LocationID:   shift.LocationID,
+			LocationName: shift.LocationName,
+			Start:        shift.StartDatetime.Time,
+			End:          shift.EndDatetime.Time,
+			BreakMinutes: shift.BreakMinutes,
+			Hours:        hours,
+			Amount:       amount,
+		})
+		drafts[i].Hours = round2(drafts[i].Hours + hours)
+		drafts[i].Subtotal = round2(drafts[i].Subtotal + amount)
+	}
+
+	for i := range drafts {
+		drafts[i].VatAmount = round2(drafts[i].Subtotal * drafts[i].VatRate / 100)
+		drafts[i].TotalAmount = round2(drafts[i].Subtotal + drafts[i].VatAmount)
+	}
+	return drafts, skipped
+}
+
+// SelfBillingStatementNumber numbers a statement by month, organisation and
+// subcontractor, so generating a month again keeps the number
+func SelfBillingStatementNumber(periodStart time.Time, organisationID, employeeID int64) string {
+	return fmt.Sprintf("SB-%s-%d-%d", periodStart.Format("200601"), organisationID, employeeID)
+}
+
+// GenerateSelfBillingStatements creates the statements of a month from the scheduled
+// shifts of subcontractors. Concept and rejected statements are replaced, approved and
+// exported statements are kept as they are.
+func (s *employeeService) GenerateSelfBillingStatements(req GenerateSelfBillingStatementsRequest, employeeID int64, ctx context.Context) (*GenerateSelfBillingStatementsResponse, error) {
+	periodStart, periodEnd, err := ParseStatementMonth(req.Month)
+	if err != nil {
+		return nil, err
+	}
+	var vatRate float64
+	if req.VatRate != nil {
+		vatRate = *req.VatRate
+	}
+
+	shifts, err := s.Store.ListSubcontractorShiftsForPeriod(ctx, db.ListSubcontractorShiftsForPeriodParams{
+		PeriodStart: pgtype.Timestamp{Time: periodStart, Valid: true},
+		PeriodEnd:   pgtype.Timestamp{Time: periodEnd, Valid: true},
+		EmployeeID:  req.EmployeeID,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "GenerateSelfBillingStatements", "Failed to list subcontractor shifts", zap.String("month", req.Month), zap.Error(err))
+		return nil, fmt.Errorf("failed to list subcontractor shifts: %w", err)
+	}
+	if len(shifts) == 0 {
+		return nil, ErrNoSubcontractorShifts
+	}
+
+	drafts, skipped := BuildSelfBillingDrafts(shifts, vatRate)
+
+	tx, err := s.Store.ConnPool.Begin(ctx)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "GenerateSelfBillingStatements", "Failed to begin transaction", zap.Error(err))
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.Store.WithTx(tx)
+
+	statements := []SelfBillingStatementResponse{}
+	for _, draft := range drafts {
+		lines, err := json.Marshal(draft.Lines)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal statement lines: %w", err)
+		}
+		statement, err := qtx.UpsertSelfBillingStatement(ctx, db.UpsertSelfBillingStatementParams{
+			StatementNumber: SelfBillingStatementNumber(periodStart, draft.OrganisationID, draft.EmployeeID),
+			EmployeeID:      draft.EmployeeID,
+			OrganisationID:  draft.OrganisationID,
+			EmployeeName:    draft.EmployeeName,
+			PeriodStart:     pgtype.Date{Time: periodStart, Valid: true},
+			PeriodEnd:       pgtype.Date{Time: periodEnd.AddDate(0, 0, -1), Valid: true},
+			Hours:           draft.Hours,
+			Rate:            draft.Rate,
+			Subtotal:        draft.Subtotal,
+			VatRate:         draft.VatRate,
+			VatAmount:       draft.VatAmount,
+			TotalAmount:     draft.TotalAmount,
+			Lines:           lines,
+			CreatedBy:       &employeeID,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			skipped = append(skipped, SkippedSelfBilling{
+				EmployeeID:     draft.EmployeeID,
+				EmployeeName:   draft.EmployeeName,
+				OrganisationID: draft.OrganisationID,
+				Reason:         "statement already approved",
+			})
+			continue
+		}
+		if err != nil {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "GenerateSelfBillingStatements", "Failed to save statement", zap.Int64("employee_id", draft.EmployeeID), zap.Error(err))
+			return nil, fmt.Errorf("failed to save statement: %w", err)
+		}
+		statements = append(statements, newSelfBillingStatementResponse(statement))
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "GenerateSelfBillingStatements", "Failed to commit transaction", zap.Error(err))
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if skipped == nil {
+		skipped = []SkippedSelfBilling{}
+	}
+	return &GenerateSelfBillingStatementsResponse{Statements: statements, Skipped: skipped}, nil
+}
+
+func (s *employeeService) ListSelfBillingStatements(req ListSelfBillingStatementsRequest, ctx context.Context) ([]SelfBillingStatementResponse, error) {
+	periodStart, _, err := ParseStatementMonth(req.Month)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.Store.ListSelfBillingStatements(ctx, db.ListSelfBillingStatementsParams{
+		PeriodStart: pgtype.Date{Time: periodStart, Valid: true},
+		Status:      req.Status,
+		EmployeeID:  req.EmployeeID,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ListSelfBillingStatements", "Failed to list statements", zap.String("month", req.Month), zap.Error(err))
+		return nil, fmt.Errorf("failed to list statements: %w", err)
+	}
+
+	statements := make([]SelfBillingStatementResponse, len(rows))
+	for i, row := range rows {
+		statements[i] = newSelfBillingStatementResponse(row)
+	}
+	return statements, nil
+}
+
+func (s *employeeService) GetSelfBillingStatement(statementID int64, ctx context.Context) (*SelfBillingStatementResponse, error) {
+	statement, err := s.Store.GetSelfBillingStatement(ctx, statementID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "GetSelfBillingStatement", "Failed to get statement", zap.Int64("statement_id", statementID), zap.Error(err))
+		return nil, fmt.Errorf("failed to get statement: %w", err)
+	}
+	res := newSelfBillingStatementResponse(statement)
+	return &res, nil
+}
+
+func (s *employeeService) ApproveSelfBillingStatement(statementID, employeeID int64, ctx context.Context) (*SelfBillingStatementResponse, error) {
+	statement, err := s.Store.ApproveSelfBillingStatement(ctx, db.ApproveSelfBillingStatementParams{
+		ID:         statementID,
+		ApprovedBy: &employeeID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, s.statementTransitionError(ctx, statementID)
+	}
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ApproveSelfBillingStatement", "Failed to approve statement", zap.Int64("statement_id", statementID), zap.Error(err))
+		return nil, fmt.Errorf("failed to approve statement: %w", err)
+	}
+	res := newSelfBillingStatementResponse(statement)
+	return &res, nil
+}
+
+func (s *employeeService) RejectSelfBillingStatement(req RejectSelfBillingStatementRequest, statementID int64, ctx context.Context) (*SelfBillingStatementResponse, error) {
+	statement, err := s.Store.RejectSelfBillingStatement(ctx, db.RejectSelfBillingStatementParams{
+		ID:              statementID,
+		RejectionReason: &req.Reason,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, s.statementTransitionError(ctx, statementID)
+	}
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "RejectSelfBillingStatement", "Failed to reject statement", zap.Int64("statement_id", statementID), zap.Error(err))
+		return nil, fmt.Errorf("failed to reject statement: %w", err)
+	}
+	res := newSelfBillingStatementResponse(statement)
+	return &res, nil
+}
+
+// statementTransitionError tells a missing statement apart from one that is no longer
+// a concept
+func (s *employeeService) statementTransitionError(ctx context.Context, statementID int64) error {
+	statement, err := s.Store.GetSelfBillingStatement(ctx, statementID)
+	if err != nil {
+		return fmt.Errorf("failed to get statement: %w", err)
+	}
+	return fmt.Errorf("%w: statement %s is %s", ErrStatementNotConcept, statement.StatementNumber, statement.Status)
+}
+
+// DownloadSelfBillingStatementPDF renders a statement as PDF with the organisation as
+// issuer on behalf of the subcontractor
+func (s *employeeService) DownloadSelfBillingStatementPDF(statementID int64, ctx context.Context) (*SelfBillingDocument, error) {
+	statement, err := s.Store.GetSelfBillingStatement(ctx, statementID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "DownloadSelfBillingStatementPDF", "Failed to get statement", zap.Int64("statement_id", statementID), zap.Error(err))
+		return nil, fmt.Errorf("failed to get statement: %w", err)
+	}
+	organisation, err := s.Store.GetOrganisation(ctx, statement.OrganisationID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "DownloadSelfBillingStatementPDF", "Failed to get organisation", zap.Int64("organisation_id", statement.OrganisationID), zap.Error(err))
+		return nil, fmt.Errorf("failed to get organisation: %w", err)
+	}
+	employee, err := s.Store.GetEmployeeProfileByID(ctx, statement.EmployeeID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "DownloadSelfBillingStatementPDF", "Failed to get employee", zap.Int64("employee_id", statement.EmployeeID), zap.Error(err))
+		return nil, fmt.Errorf("failed to get employee: %w", err)
+	}
+
+	res := newSelfBillingStatementResponse(statement)
+	data := pdf.SelfBillingPDFData{
+		StatementNumber:    res.StatementNumber,
+		StatementDate:      res.PeriodEnd,
+		PeriodStart:        res.PeriodStart,
+		PeriodEnd:          res.PeriodEnd,
+		Status:             res.Status,
+		IssuerName:         organisation.Name,
+		IssuerAddressLine1: organisation.Address,
+		IssuerPostalCity:   strings.TrimSpace(organisation.PostalCode + " " + organisation.City),
+		SupplierName:       res.EmployeeName,
+		SupplierEmail:      employee.Email,
+		Hours:              res.Hours,
+		Rate:               res.Rate,
+		Subtotal:           res.Subtotal,
+		VatRate:            res.VatRate,
+		VatAmount:          res.VatAmount,
+		TotalAmount:        res.TotalAmount,
+	}
+	if organisation.KvkNumber != nil {
+		data.IssuerKvkNumber = *organisation.KvkNumber
+	}
+	if organisation.BtwNumber != nil {
+		data.IssuerBtwNumber = *organisation.BtwNumber
+	}
+	for _, line := range res.Lines {
+		data.Lines = append(data.Lines, pdf.SelfBillingPDFLine{
+			LocationName: line.LocationName,
+			Start:        line.Start,
+			End:          line.End,
+			Hours:        line.Hours,
+			Amount:       line.Amount,
+		})
+	}
+
+	file, err := pdf.GenerateSelfBillingPDF(data)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "DownloadSelfBillingStatementPDF", "Failed to generate PDF", zap.Int64("statement_id", statementID), zap.Error(err))
+		return nil, fmt.Errorf("failed to generate PDF: %w", err)
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read PDF: %w", err)
+	}
+	return &SelfBillingDocument{
+		FileName:    res.StatementNumber + ".pdf",
+		ContentType: "application/pdf",
+		Content:     content,
+	}, nil
+}
+
+// ExportSelfBillingStatements writes the approved statements of a month as purchase
+// invoices for accounts payable and marks them exported
+func (s *employeeService) ExportSelfBillingStatements(req ExportSelfBillingStatementsRequest, employeeID int64, ctx context.Context) (*SelfBillingDocument, error) {
+	periodStart, _, err := ParseStatementMonth(req.Month)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.Store.ConnPool.Begin(ctx)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ExportSelfBillingStatements", "Failed to begin transaction", zap.Error(err))
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.Store.WithTx(tx)
+
+	statements, err := qtx.ListSelfBillingStatementsForExport(ctx, db.ListSelfBillingStatementsForExportParams{
+		PeriodStart:     pgtype.Date{Time: periodStart, Valid: true},
+		IncludeExported: req.IncludeExported,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ExportSelfBillingStatements", "Failed to list statements to export", zap.String("month", req.Month), zap.Error(err))
+		return nil, fmt.Errorf("failed to list statements to export: %w", err)
+	}
+	if len(statements) == 0 {
+		return nil, ErrNoStatementsToExport
+	}
+
+	content, err := RenderPayablesCSV(statements)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render export: %w", err)
+	}
+
+	ids := make([]int64, len(statements))
+	for i, statement := range statements {
+		ids[i] = statement.ID
+	}
+	if err := qtx.MarkSelfBillingStatementsExported(ctx, db.MarkSelfBillingStatementsExportedParams{
+		ExportedBy: &employeeID,
+		Ids:        ids,
+	}); err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ExportSelfBillingStatements", "Failed to mark statements exported", zap.String("month", req.Month), zap.Error(err))
+		return nil, fmt.Errorf("failed to mark statements exported: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ExportSelfBillingStatements", "Failed to commit transaction", zap.Error(err))
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return &SelfBillingDocument{
+		FileName:    fmt.Sprintf("self_billing_%s.csv", periodStart.Format("2006_01")),
+		ContentType: "text/csv; charset=utf-8",
+		Content:     content,
+	}, nil
+}
+
+// RenderPayablesCSV writes one purchase invoice per statement, the subcontractor is the
+// supplier
+func RenderPayablesCSV(statements []db.SelfBillingStatement) ([]byte, error) {
+	records := [][]string{{"invoice_number", "invoice_date", "supplier_code", "supplier_name", "organisation_id", "period_start", "period_end", "hours", "rate", "subtotal", "vat_rate", "vat_amount", "total_amount"}}
+	for _, st := range statements {
+		records = append(records, []string{
+			st.StatementNumber,
+			st.PeriodEnd.Time.Format("2006-01-02"),
+			strconv.FormatInt(st.EmployeeID, 10),
+			st.EmployeeName,
+			strconv.FormatInt(st.OrganisationID, 10),
+			st.PeriodStart.Time.Format("2006-01-02"),
+			st.PeriodEnd.Time.Format("2006-01-02"),
+			strconv.FormatFloat(st.Hours, 'f', 2, 64),
+			strconv.FormatFloat(st.Rate, 'f', 2, 64),
+			strconv.FormatFloat(st.Subtotal, 'f', 2, 64),
+			strconv.FormatFloat(st.VatRate, 'f', 2, 64),
+			strconv.FormatFloat(st.VatAmount, 'f', 2, 64),
+			strconv.FormatFloat(st.TotalAmount, 'f', 2, 64),
+		})
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.WriteAll(records); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func newSelfBillingStatementResponse(st db.SelfBillingStatement) SelfBillingStatementResponse {
+	res := SelfBillingStatementResponse{
+		ID:              st.ID,
+		StatementNumber: st.StatementNumber,
+		EmployeeID:      st.EmployeeID,
+		EmployeeName:    st.EmployeeName,
+		OrganisationID:  st.OrganisationID,
+		PeriodStart:     st.PeriodStart.Time,
+		PeriodEnd:       st.PeriodEnd.Time,
+		Hours:           st.Hours,
+		Rate:            st.Rate,
+		Subtotal:        st.Subtotal,
+		VatRate:         st.VatRate,
+		VatAmount:       st.VatAmount,
+		TotalAmount:     st.TotalAmount,
+		Lines:           []SelfBillingLine{},
+		Status:          st.Status,
+		RejectionReason: st.RejectionReason,
+		ApprovedBy:      st.ApprovedBy,
+		ExportedBy:      st.ExportedBy,
+		CreatedBy:       st.CreatedBy,
+		CreatedAt:       st.CreatedAt.Time,
+		UpdatedAt:       st.UpdatedAt.Time,
+	}
+	if st.ApprovedAt.Valid {
+		res.ApprovedAt = &st.ApprovedAt.Time
+	}
+	if st.ExportedAt.Valid {
+		res.ExportedAt = &st.ExportedAt.Time
+	}
+	_ = json.Unmarshal(st.Lines, &res.Lines)
+	return res
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
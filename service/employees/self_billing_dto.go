@@ -0,0 +1,101 @@
+package employees
+
+import "time"
+
+const (
+	SelfBillingStatusConcept  = "concept"
+	SelfBillingStatusApproved = "approved"
+	SelfBillingStatusRejected = "rejected"
+	SelfBillingStatusExported = "exported"
+)
+
+// GenerateSelfBillingStatementsRequest generates the statements of a month for all
+// subcontractors, or for one subcontractor when the employee is set
+type GenerateSelfBillingStatementsRequest struct {
+	Month      string   `json:"month" binding:"required" example:"2025-03"`
+	EmployeeID *int64   `json:"employee_id" example:"1"`
+	VatRate    *float64 `json:"vat_rate" binding:"omitempty,min=0,max=100" example:"0"`
+}
+
+// GenerateSelfBillingStatementsResponse lists the generated statements and the
+// subcontractors that were left out with the reason why
+type GenerateSelfBillingStatementsResponse struct {
+	Statements []SelfBillingStatementResponse `json:"statements"`
+	Skipped    []SkippedSelfBilling           `json:"skipped"`
+}
+
+// SkippedSelfBilling is a subcontractor without a statement for the month
+type SkippedSelfBilling struct {
+	EmployeeID     int64  `json:"employee_id"`
+	EmployeeName   string `json:"employee_name"`
+	OrganisationID int64  `json:"organisation_id"`
+	Reason         string `json:"reason"`
+}
+
+// ListSelfBillingStatementsRequest selects the statements of a month
+type ListSelfBillingStatementsRequest struct {
+	Month      string  `form:"month" binding:"required" example:"2025-03"`
+	Status     *string `form:"status" binding:"omitempty,oneof=concept approved rejected exported"`
+	EmployeeID *int64  `form:"employee_id"`
+}
+
+// RejectSelfBillingStatementRequest sends a concept statement back, it is replaced the
+// next time the month is generated
+type RejectSelfBillingStatementRequest struct {
+	Reason string `json:"reason" binding:"required,max=1000"`
+}
+
+// ExportSelfBillingStatementsRequest exports the approved statements of a month to
+// accounts payable. Statements that were exported before are only included when
+// IncludeExported is set.
+type ExportSelfBillingStatementsRequest struct {
+	Month           string `form:"month" binding:"required" example:"2025-03"`
+	IncludeExported bool   `form:"include_exported"`
+}
+
+// SelfBillingLine is a worked shift on a statement
+type SelfBillingLine struct {
+	ScheduleID   string    `json:"schedule_id"`
+	LocationID   int64     `json:"location_id"`
+	LocationName string    `json:"location_name"`
+	Start        time.Time `json:"start"`
+	End          time.Time `json:"end"`
+	BreakMinutes int32     `json:"break_minutes,omitempty"`
+	Hours        float64   `json:"hours"`
+	Amount       float64   `json:"amount"`
+}
+
+// SelfBillingStatementResponse is the self-billing statement of a subcontractor for a
+// month at one organisation
+type SelfBillingStatementResponse struct {
+	ID              int64             `json:"id"`
+	StatementNumber string            `json:"statement_number"`
+	EmployeeID      int64             `json:"employee_id"`
+	EmployeeName    string            `json:"employee_name"`
+	OrganisationID  int64             `json:"organisation_id"`
+	PeriodStart     time.Time         `json:"period_start"`
+	PeriodEnd       time.Time         `json:"period_end"`
+	Hours           float64           `json:"hours"`
+	Rate            float64           `json:"rate"`
+	Subtotal        float64           `json:"subtotal"`
+	VatRate         float64           `json:"vat_rate"`
+	VatAmount       float64           `json:"vat_amount"`
+	TotalAmount     float64           `json:"total_amount"`
+	Lines           []SelfBillingLine `json:"lines"`
+	Status          string            `json:"status"`
+	RejectionReason *string           `json:"rejection_reason"`
+	ApprovedBy      *int64            `json:"approved_by"`
+	ApprovedAt      *time.Time        `json:"approved_at"`
+	ExportedBy      *int64            `json:"exported_by"`
+	ExportedAt      *time.Time        `json:"exported_at"`
+	CreatedBy       *int64            `json:"created_by"`
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+}
+
+// SelfBillingDocument is a rendered statement or accounts payable export
+type SelfBillingDocument struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
@@ -0,0 +1,101 @@
+package employees
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	db "maicare_go/db/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func testShift(employeeID, organisationID int64, rate *float64, start time.Time, hours float64) db.ListSubcontractorShiftsForPeriodRow {
+	return db.ListSubcontractorShiftsForPeriodRow{
+		ID:             uuid.New(),
+		EmployeeID:     employeeID,
+		FirstName:      "Sam",
+		LastName:       "Jansen",
+		ContractRate:   rate,
+		OrganisationID: organisationID,
+		LocationID:     organisationID * 10,
+		LocationName:   "Location",
+		StartDatetime:  pgtype.Timestamp{Time: start, Valid: true},
+		EndDatetime:    pgtype.Timestamp{Time: start.Add(time.Duration(hours * float64(time.Hour))), Valid: true},
+	}
+}
+
+func TestBuildSelfBillingDrafts(t *testing.T) {
+	rate := 42.5
+	day := time.Date(2025, 3, 3, 22, 0, 0, 0, time.UTC)
+	shifts := []db.ListSubcontractorShiftsForPeriodRow{
+		testShift(1, 1, &rate, day, 8),
+		testShift(1, 1, &rate, day.AddDate(0, 0, 1), 7.75),
+		testShift(1, 2, &rate, day.AddDate(0, 0, 2), 4),
+		testShift(2, 1, nil, day, 8),
+		testShift(2, 1, nil, day.AddDate(0, 0, 1), 8),
+	}
+
+	drafts, skipped := BuildSelfBillingDrafts(shifts, 21)
+	require.Len(t, drafts, 2)
+
+	require.Equal(t, int64(1), drafts[0].OrganisationID)
+	require.Len(t, drafts[0].Lines, 2)
+	require.Equal(t, 15.75, drafts[0].Hours)
+	require.Equal(t, 669.38, drafts[0].Subtotal)
+	require.Equal(t, 140.57, drafts[0].VatAmount)
+	require.Equal(t, 809.95, drafts[0].TotalAmount)
+
+	require.Equal(t, int64(2), drafts[1].OrganisationID)
+	require.Equal(t, 170.0, drafts[1].Subtotal)
+
+	require.Len(t, skipped, 1)
+	require.Equal(t, int64(2), skipped[0].EmployeeID)
+	require.Equal(t, "no contract rate", skipped[0].Reason)
+}
+
+func TestBuildSelfBillingDraftsBreaks(t *testing.T) {
+	rate := 40.0
+	shift := testShift(1, 1, &rate, time.Date(2025, 3, 3, 7, 0, 0, 0, time.UTC), 8)
+	shift.BreakMinutes = 30
+
+	drafts, skipped := BuildSelfBillingDrafts([]db.ListSubcontractorShiftsForPeriodRow{shift}, 21)
+	require.Empty(t, skipped)
+	require.Len(t, drafts, 1)
+	require.Equal(t, int32(30), drafts[0].Lines[0].BreakMinutes)
+	require.Equal(t, 7.5, drafts[0].Hours)
+	require.Equal(t, 300.0, drafts[0].Subtotal)
+}
+
+func TestParseStatementMonth(t *testing.T) {
+	start, end, err := ParseStatementMonth("2025-12")
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC), start)
+	require.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), end)
+	require.Equal(t, "SB-202512-3-7", SelfBillingStatementNumber(start, 3, 7))
+
+	_, _, err = ParseStatementMonth("12-2025")
+	require.ErrorIs(t, err, ErrInvalidStatementMonth)
+}
+
+func TestRenderPayablesCSV(t *testing.T) {
+	data, err := RenderPayablesCSV([]db.SelfBillingStatement{{
+		StatementNumber: "SB-202503-1-1",
+		EmployeeID:      1,
+		EmployeeName:    "Sam Jansen",
+		OrganisationID:  1,
+		PeriodStart:     pgtype.Date{Time: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		PeriodEnd:       pgtype.Date{Time: time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC), Valid: true},
+		Hours:           15.75,
+		Rate:            42.5,
+		Subtotal:        669.38,
+		TotalAmount:     669.38,
+	}})
+	require.NoError(t, err)
+
+	rows := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, rows, 2)
+	require.Equal(t, "SB-202503-1-1,2025-03-31,1,Sam Jansen,1,2025-03-01,2025-03-31,15.75,42.50,669.38,0.00,0.00,669.38", rows[1])
+}
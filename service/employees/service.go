@@ -54,6 +54,15 @@ type EmployeeService interface {
 	ListEmployeeCertification(employeeID int64, ctx context.Context) ([]ListEmployeeCertificationResponse, error)
 	UpdateEmployeeCertification(req UpdateEmployeeCertificationRequest, certificationID int64, ctx context.Context) (*UpdateEmployeeCertificationResponse, error)
 	DeleteEmployeeCertification(certificationID int64, ctx context.Context) (*DeleteEmployeeCertificationResponse, error)
+
+	// Self-billing methods
+	GenerateSelfBillingStatements(req GenerateSelfBillingStatementsRequest, employeeID int64, ctx context.Context) (*GenerateSelfBillingStatementsResponse, error)
+	ListSelfBillingStatements(req ListSelfBillingStatementsRequest, ctx context.Context) ([]SelfBillingStatementResponse, error)
+	GetSelfBillingStatement(statementID int64, ctx context.Context) (*SelfBillingStatementResponse, error)
+	ApproveSelfBillingStatement(statementID, employeeID int64, ctx context.Context) (*SelfBillingStatementResponse, error)
+	RejectSelfBillingStatement(req RejectSelfBillingStatementRequest, statementID int64, ctx context.Context) (*SelfBillingStatementResponse, error)
+	DownloadSelfBillingStatementPDF(statementID int64, ctx context.Context) (*SelfBillingDocument, error)
+	ExportSelfBillingStatements(req ExportSelfBillingStatementsRequest, employeeID int64, ctx context.Context) (*SelfBillingDocument, error)
 }
 
 type employeeService struct {
@@ -0,0 +1,78 @@
+package finance
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+)
+
+const csvDateLayout = "2006-01-02"
+
+// CSV renders the aging report with a row per sender or client and a totals row
+func (r *ReceivablesAgingResponse) CSV() ([]byte, error) {
+	records := [][]string{{r.GroupBy + "_id", "name", "invoice_count", "days_0_30", "days_31_60", "days_61_90", "days_over_90", "total"}}
+	for _, row := range r.Rows {
+		id := ""
+		if row.ID != nil {
+			id = strconv.FormatInt(*row.ID, 10)
+		}
+		records = append(records, append([]string{id, row.Name, strconv.FormatInt(row.InvoiceCount, 10)}, row.AgingBuckets.csvFields()...))
+	}
+	records = append(records, append([]string{"", "Total", ""}, r.Totals.csvFields()...))
+	return writeCSV(records)
+}
+
+// CSV renders the summary as a single row
+func (r *FinanceSummaryResponse) CSV() ([]byte, error) {
+	return writeCSV([][]string{
+		{"start_date", "end_date", "invoiced_amount", "received_amount", "credit_note_count", "credited_amount", "open_balance", "dso"},
+		{
+			r.StartDate.Format(csvDateLayout),
+			r.EndDate.Format(csvDateLayout),
+			formatAmount(r.InvoicedAmount),
+			formatAmount(r.ReceivedAmount),
+			strconv.FormatInt(r.CreditNoteCount, 10),
+			formatAmount(r.CreditedAmount),
+			formatAmount(r.OpenBalance),
+			strconv.FormatFloat(r.DSO, 'f', 1, 64),
+		},
+	})
+}
+
+// MonthlyInvoicedReceivedCSV renders a row per month
+func MonthlyInvoicedReceivedCSV(months []MonthlyInvoicedReceivedResponse) ([]byte, error) {
+	records := [][]string{{"month", "invoiced_amount", "received_amount", "credit_note_count", "credited_amount"}}
+	for _, month := range months {
+		records = append(records, []string{
+			month.Month.Format("2006-01"),
+			formatAmount(month.InvoicedAmount),
+			formatAmount(month.ReceivedAmount),
+			strconv.FormatInt(month.CreditNoteCount, 10),
+			formatAmount(month.CreditedAmount),
+		})
+	}
+	return writeCSV(records)
+}
+
+func (b AgingBuckets) csvFields() []string {
+	return []string{
+		formatAmount(b.Days0To30),
+		formatAmount(b.Days31To60),
+		formatAmount(b.Days61To90),
+		formatAmount(b.DaysOver90),
+		formatAmount(b.Total),
+	}
+}
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
+
+func writeCSV(records [][]string) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.WriteAll(records); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,17 @@
+package finance
+
+import (
+	"context"
+)
+
+type FinanceService interface {
+	ReceivablesAging(ctx context.Context, req ReceivablesAgingRequest) (*ReceivablesAgingResponse, error)
+	FinanceSummary(ctx context.Context, req FinancePeriodRequest) (*FinanceSummaryResponse, error)
+	MonthlyInvoicedReceived(ctx context.Context, req FinancePeriodRequest) ([]MonthlyInvoicedReceivedResponse, error)
+	RevenueForecast(ctx context.Context, req RevenueForecastRequest) (*RevenueForecastResponse, error)
+	ListLedgerAccounts(ctx context.Context, organisationID int64) ([]LedgerAccountResponse, error)
+	UpdateLedgerAccounts(ctx context.Context, organisationID int64, req UpdateLedgerAccountsRequest, employeeID int64) ([]LedgerAccountResponse, error)
+	CreateAccountingExport(ctx context.Context, req CreateAccountingExportRequest, employeeID int64) (*AccountingExportBatchResponse, error)
+	ListAccountingExports(ctx context.Context, req ListAccountingExportsRequest) ([]AccountingExportBatchResponse, error)
+	DownloadAccountingExport(ctx context.Context, batchID int64, req DownloadAccountingExportRequest, employeeID int64) (*AccountingExportFile, error)
+}
@@ -0,0 +1,131 @@
+package finance
+
+import (
+	"time"
+)
+
+const (
+	AgingGroupBySender = "sender"
+	AgingGroupByClient = "client"
+
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+// ReceivablesAgingRequest defines the request for the receivables aging report.
+// AsOf defaults to today, GroupBy to sender.
+type ReceivablesAgingRequest struct {
+	AsOf    time.Time `form:"as_of" time_format:"2006-01-02"`
+	GroupBy string    `form:"group_by" binding:"omitempty,oneof=sender client"`
+	Format  string    `form:"format" binding:"omitempty,oneof=json csv"`
+}
+
+// FinancePeriodRequest defines a reporting period, both dates are inclusive.
+type FinancePeriodRequest struct {
+	StartDate time.Time `form:"start_date" binding:"required" time_format:"2006-01-02"`
+	EndDate   time.Time `form:"end_date" binding:"required" time_format:"2006-01-02"`
+	Format    string    `form:"format" binding:"omitempty,oneof=json csv"`
+}
+
+// AgingBuckets holds open balances by the number of days past the due date.
+type AgingBuckets struct {
+	Days0To30  float64 `json:"days_0_30"`
+	Days31To60 float64 `json:"days_31_60"`
+	Days61To90 float64 `json:"days_61_90"`
+	DaysOver90 float64 `json:"days_over_90"`
+	Total      float64 `json:"total"`
+}
+
+// ReceivablesAgingRow is the open balance of a single sender or client.
+type ReceivablesAgingRow struct {
+	ID           *int64 `json:"id"`
+	Name         string `json:"name"`
+	InvoiceCount int64  `json:"invoice_count"`
+	AgingBuckets
+}
+
+// ReceivablesAgingResponse defines the response for the receivables aging report.
+type ReceivablesAgingResponse struct {
+	AsOf    time.Time             `json:"as_of"`
+	GroupBy string                `json:"group_by"`
+	Rows    []ReceivablesAgingRow `json:"rows"`
+	Totals  AgingBuckets          `json:"totals"`
+}
+
+// FinanceSummaryResponse defines the response for the finance dashboard totals.
+type FinanceSummaryResponse struct {
+	StartDate       time.Time `json:"start_date"`
+	EndDate         time.Time `json:"end_date"`
+	InvoicedAmount  float64   `json:"invoiced_amount"`
+	ReceivedAmount  float64   `json:"received_amount"`
+	CreditNoteCount int64     `json:"credit_note_count"`
+	CreditedAmount  float64   `json:"credited_amount"`
+	OpenBalance     float64   `json:"open_balance"`
+	DSO             float64   `json:"dso"`
+}
+
+// MonthlyInvoicedReceivedResponse defines a month of the invoiced versus received overview.
+type MonthlyInvoicedReceivedResponse struct {
+	Month           time.Time `json:"month"`
+	InvoicedAmount  float64   `json:"invoiced_amount"`
+	ReceivedAmount  float64   `json:"received_amount"`
+	CreditNoteCount int64     `json:"credit_note_count"`
+	CreditedAmount  float64   `json:"credited_amount"`
+}
+
+// RevenueForecastRequest defines the request for the revenue forecast.
+// StartDate defaults to today, GroupBy to location.
+type RevenueForecastRequest struct {
+	StartDate time.Time `form:"start_date" time_format:"2006-01-02"`
+	GroupBy   string    `form:"group_by" binding:"omitempty,oneof=location financing_act sender"`
+}
+
+// RevenueForecastHorizon is the projected revenue excluding VAT over the first months of the forecast.
+// RevenueAtRisk is the revenue that is only earned when expiring contracts are renewed.
+type RevenueForecastHorizon struct {
+	Months              int     `json:"months"`
+	RevenueIfNotRenewed float64 `json:"revenue_if_not_renewed"`
+	RevenueIfRenewed    float64 `json:"revenue_if_renewed"`
+	RevenueAtRisk       float64 `json:"revenue_at_risk"`
+}
+
+// RevenueForecastGroup is the forecast of a single location, financing act or sender.
+type RevenueForecastGroup struct {
+	Key      string                   `json:"key"`
+	Name     string                   `json:"name"`
+	Horizons []RevenueForecastHorizon `json:"horizons"`
+}
+
+// RevenueForecastMonth is the projected revenue of a single month of the forecast.
+type RevenueForecastMonth struct {
+	Month               time.Time `json:"month"`
+	RevenueIfNotRenewed float64   `json:"revenue_if_not_renewed"`
+	RevenueIfRenewed    float64   `json:"revenue_if_renewed"`
+}
+
+// ExpiringContractForecast is a contract that ends within the forecast and has no approved
+// renewal yet. RenewalContractID is the drafted renewal, RenewalOverdue is set when the
+// reminder date has passed without one.
+type ExpiringContractForecast struct {
+	ContractID        int64     `json:"contract_id"`
+	ClientID          int64     `json:"client_id"`
+	ClientName        string    `json:"client_name"`
+	FinancingAct      string    `json:"financing_act"`
+	EndDate           time.Time `json:"end_date"`
+	ReminderDate      time.Time `json:"reminder_date"`
+	RenewalContractID *int64    `json:"renewal_contract_id"`
+	RenewalOverdue    bool      `json:"renewal_overdue"`
+	RevenueAtRisk     float64   `json:"revenue_at_risk"`
+}
+
+// RevenueForecastResponse defines the response for the revenue forecast.
+type RevenueForecastResponse struct {
+	StartDate         time.Time                  `json:"start_date"`
+	EndDate           time.Time                  `json:"end_date"`
+	GroupBy           string                     `json:"group_by"`
+	Horizons          []RevenueForecastHorizon   `json:"horizons"`
+	Groups            []RevenueForecastGroup     `json:"groups"`
+	Months            []RevenueForecastMonth     `json:"months"`
+	ExpiringContracts []ExpiringContractForecast `json:"expiring_contracts"`
+	Warnings          []string                   `json:"warnings"`
+}
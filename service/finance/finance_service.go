@@ -0,0 +1,167 @@
+package finance
+
+import (
+	"context"
+	"fmt"
+	db "maicare_go/db/sqlc"
+	"maicare_go/logger"
+	"maicare_go/service/deps"
+	"math"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+type financeService struct {
+	*deps.ServiceDependencies
+}
+
+func NewFinanceService(deps *deps.ServiceDependencies) FinanceService {
+	return &financeService{
+		ServiceDependencies: deps,
+	}
+}
+
+func (s *financeService) ReceivablesAging(ctx context.Context, req ReceivablesAgingRequest) (*ReceivablesAgingResponse, error) {
+	asOf := req.AsOf
+	if asOf.IsZero() {
+		asOf = time.Now()
+	}
+	groupBy := req.GroupBy
+	if groupBy == "" {
+		groupBy = AgingGroupBySender
+	}
+	asOfDate := pgtype.Date{Time: asOf, Valid: true}
+
+	response := &ReceivablesAgingResponse{
+		AsOf:    asOf,
+		GroupBy: groupBy,
+		Rows:    []ReceivablesAgingRow{},
+	}
+
+	switch groupBy {
+	case AgingGroupByClient:
+		rows, err := s.Store.ListReceivablesAgingByClient(ctx, asOfDate)
+		if err != nil {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "ReceivablesAging", "Failed to list receivables aging by client", zap.Error(err))
+			return nil, err
+		}
+		for _, row := range rows {
+			clientID := row.ClientID
+			response.Rows = append(response.Rows, ReceivablesAgingRow{
+				ID:           &clientID,
+				Name:         fmt.Sprintf("%s %s", row.FirstName, row.LastName),
+				InvoiceCount: row.InvoiceCount,
+				AgingBuckets: AgingBuckets{
+					Days0To30:  row.Days030,
+					Days31To60: row.Days3160,
+					Days61To90: row.Days6190,
+					DaysOver90: row.DaysOver90,
+					Total:      row.TotalBalance,
+				},
+			})
+		}
+	default:
+		rows, err := s.Store.ListReceivablesAgingBySender(ctx, asOfDate)
+		if err != nil {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "ReceivablesAging", "Failed to list receivables aging by sender", zap.Error(err))
+			return nil, err
+		}
+		for _, row := range rows {
+			name := "Unknown sender"
+			if row.SenderName != nil {
+				name = *row.SenderName
+			}
+			response.Rows = append(response.Rows, ReceivablesAgingRow{
+				ID:           row.SenderID,
+				Name:         name,
+				InvoiceCount: row.InvoiceCount,
+				AgingBuckets: AgingBuckets{
+					Days0To30:  row.Days030,
+					Days31To60: row.Days3160,
+					Days61To90: row.Days6190,
+					DaysOver90: row.DaysOver90,
+					Total:      row.TotalBalance,
+				},
+			})
+		}
+	}
+
+	for _, row := range response.Rows {
+		response.Totals.add(row.AgingBuckets)
+	}
+	return response, nil
+}
+
+func (s *financeService) FinanceSummary(ctx context.Context, req FinancePeriodRequest) (*FinanceSummaryResponse, error) {
+	summary, err := s.Store.GetFinanceSummary(ctx, db.GetFinanceSummaryParams{
+		StartDate: pgtype.Date{Time: req.StartDate, Valid: true},
+		EndDate:   pgtype.Date{Time: req.EndDate, Valid: true},
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "FinanceSummary", "Failed to get finance summary", zap.Error(err))
+		return nil, err
+	}
+
+	// the open balance is net of credit notes, so are the sales it is compared with
+	netInvoiced := summary.InvoicedAmount - summary.CreditedAmount
+	return &FinanceSummaryResponse{
+		StartDate:       req.StartDate,
+		EndDate:         req.EndDate,
+		InvoicedAmount:  summary.InvoicedAmount,
+		ReceivedAmount:  summary.ReceivedAmount,
+		CreditNoteCount: summary.CreditNoteCount,
+		CreditedAmount:  summary.CreditedAmount,
+		OpenBalance:     summary.OpenBalance,
+		DSO:             DaysSalesOutstanding(summary.OpenBalance, netInvoiced, periodDays(req.StartDate, req.EndDate)),
+	}, nil
+}
+
+func (s *financeService) MonthlyInvoicedReceived(ctx context.Context, req FinancePeriodRequest) ([]MonthlyInvoicedReceivedResponse, error) {
+	months, err := s.Store.ListMonthlyInvoicedReceived(ctx, db.ListMonthlyInvoicedReceivedParams{
+		StartDate: pgtype.Date{Time: req.StartDate, Valid: true},
+		EndDate:   pgtype.Date{Time: req.EndDate, Valid: true},
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "MonthlyInvoicedReceived", "Failed to list monthly invoiced and received amounts", zap.Error(err))
+		return nil, err
+	}
+
+	response := make([]MonthlyInvoicedReceivedResponse, len(months))
+	for i, month := range months {
+		response[i] = MonthlyInvoicedReceivedResponse{
+			Month:           month.Month.Time,
+			InvoicedAmount:  month.InvoicedAmount,
+			ReceivedAmount:  month.ReceivedAmount,
+			CreditNoteCount: month.CreditNoteCount,
+			CreditedAmount:  month.CreditedAmount,
+		}
+	}
+	return response, nil
+}
+
+// DaysSalesOutstanding is the open balance divided by the invoiced amount of the
+// period after credit notes, expressed in days of that period. It is 0 when nothing
+// was invoiced.
+func DaysSalesOutstanding(openBalance, invoiced float64, days int) float64 {
+	if invoiced <= 0 || days <= 0 {
+		return 0
+	}
+	return math.Round(openBalance/invoiced*float64(days)*10) / 10
+}
+
+// periodDays counts the days of a period including both dates
+func periodDays(start, end time.Time) int {
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	end = time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
+	return int(end.Sub(start).Hours()/24) + 1
+}
+
+func (b *AgingBuckets) add(other AgingBuckets) {
+	b.Days0To30 += other.Days0To30
+	b.Days31To60 += other.Days31To60
+	b.Days61To90 += other.Days61To90
+	b.DaysOver90 += other.DaysOver90
+	b.Total += other.Total
+}
@@ -0,0 +1,47 @@
+package finance
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDaysSalesOutstanding(t *testing.T) {
+	require.Equal(t, 45.0, DaysSalesOutstanding(15000, 30000, 90))
+	require.Equal(t, 10.3, DaysSalesOutstanding(1000, 2900, 30))
+	require.Zero(t, DaysSalesOutstanding(1000, 0, 30))
+	require.Zero(t, DaysSalesOutstanding(1000, 500, 0))
+}
+
+func TestPeriodDays(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, 1, periodDays(start, start))
+	require.Equal(t, 31, periodDays(start, time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)))
+	require.Equal(t, 90, periodDays(start, time.Date(2025, 3, 31, 23, 0, 0, 0, time.UTC)))
+}
+
+func TestReceivablesAgingCSV(t *testing.T) {
+	senderID := int64(4)
+	report := &ReceivablesAgingResponse{
+		GroupBy: AgingGroupBySender,
+		Rows: []ReceivablesAgingRow{
+			{ID: &senderID, Name: "Gemeente Utrecht, Jeugd", InvoiceCount: 2, AgingBuckets: AgingBuckets{Days0To30: 100, DaysOver90: 50.5, Total: 150.5}},
+			{Name: "Unknown sender", InvoiceCount: 1, AgingBuckets: AgingBuckets{Days31To60: 20, Total: 20}},
+		},
+	}
+	for _, row := range report.Rows {
+		report.Totals.add(row.AgingBuckets)
+	}
+
+	out, err := report.CSV()
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	require.Len(t, lines, 4)
+	require.Equal(t, "sender_id,name,invoice_count,days_0_30,days_31_60,days_61_90,days_over_90,total", lines[0])
+	require.Equal(t, `4,"Gemeente Utrecht, Jeugd",2,100.00,0.00,0.00,50.50,150.50`, lines[1])
+	require.Equal(t, ",Unknown sender,1,0.00,20.00,0.00,0.00,20.00", lines[2])
+	require.Equal(t, ",Total,,100.00,20.00,0.00,50.50,170.50", lines[3])
+}
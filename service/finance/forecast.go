@@ -0,0 +1,378 @@
+package finance
+
+import (
+	"context"
+	"fmt"
+	db "maicare_go/db/sqlc"
+	"maicare_go/logger"
+	invserv "maicare_go/service/invoice"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+const (
+	ForecastGroupByLocation     = "location"
+	ForecastGroupByFinancingAct = "financing_act"
+	ForecastGroupBySender       = "sender"
+)
+
+// forecastHorizons are the horizons in months management reports on, the last one
+// is the length of the forecast window
+var forecastHorizons = []int{3, 6, 12}
+
+// ForecastContract holds the contract fields a revenue projection needs
+type ForecastContract struct {
+	ID            int64
+	CareType      string
+	Price         float64
+	PriceTimeUnit string
+	Vat           float64
+	Hours         *float64
+	HoursType     *string
+	StartDate     time.Time
+	EndDate       time.Time
+	// ApprovedPeriods are the approved periods of the status timeline, suspensions are
+	// gaps between them. Nil means the contract is approved over its whole period.
+	ApprovedPeriods []ForecastPeriod
+	// Renewal are the terms of the drafted renewal. Without one the contract is renewed
+	// like RenewContract does by default: from its end date at the same price.
+	Renewal *ForecastRenewal
+}
+
+// ForecastRenewal is the period and price a contract continues on when it is renewed
+type ForecastRenewal struct {
+	StartDate time.Time
+	EndDate   time.Time
+	Price     float64
+}
+
+// ForecastPeriod is a period of a contract, a period without an end is open
+type ForecastPeriod struct {
+	Start time.Time
+	End   *time.Time
+}
+
+// ProjectContractRevenue projects the revenue excluding VAT of a contract between
+// from and to with the same calculations the invoices use. When renewed is set the
+// contract continues on its renewal terms after it ends, renewed again for the same
+// length whenever a renewal period ends.
+func ProjectContractRevenue(contract ForecastContract, from, to time.Time, renewed bool) (float64, error) {
+	renewal := contract.renewal()
+	contractEnd := contract.EndDate
+	if renewed && renewal.StartDate.Before(contractEnd) {
+		// a renewal that starts early replaces the rest of the contract
+		contractEnd = renewal.StartDate
+	}
+
+	total, err := projectApprovedRevenue(contract, maxTime(from, contract.StartDate), minTime(to, contractEnd))
+	if err != nil || !renewed {
+		return total, err
+	}
+
+	length := renewal.EndDate.Sub(renewal.StartDate)
+	if length <= 0 {
+		return total, nil
+	}
+	successor := contract
+	successor.Price = renewal.Price
+	successor.ApprovedPeriods = nil
+	successor.Renewal = nil
+	for periodStart := renewal.StartDate; periodStart.Before(to); periodStart = periodStart.Add(length) {
+		successor.StartDate = periodStart
+		successor.EndDate = periodStart.Add(length)
+		revenue, err := projectApprovedRevenue(successor, maxTime(from, successor.StartDate), minTime(to, successor.EndDate))
+		if err != nil {
+			return 0, err
+		}
+		total += revenue
+	}
+	return total, nil
+}
+
+// renewal returns the terms the contract is renewed on
+func (c ForecastContract) renewal() ForecastRenewal {
+	if c.Renewal != nil {
+		return *c.Renewal
+	}
+	return ForecastRenewal{
+		StartDate: c.EndDate,
+		EndDate:   c.EndDate.Add(c.EndDate.Sub(c.StartDate)),
+		Price:     c.Price,
+	}
+}
+
+// projectApprovedRevenue projects the revenue excluding VAT between start and end of the
+// periods in which the contract is approved
+func projectApprovedRevenue(contract ForecastContract, start, end time.Time) (float64, error) {
+	if !end.After(start) {
+		return 0, nil
+	}
+	if contract.ApprovedPeriods == nil {
+		return projectPeriodRevenue(contract, start, end)
+	}
+
+	var total float64
+	for _, period := range contract.ApprovedPeriods {
+		periodStart := maxTime(start, period.Start)
+		periodEnd := end
+		if period.End != nil {
+			periodEnd = minTime(end, *period.End)
+		}
+		if !periodEnd.After(periodStart) {
+			continue
+		}
+		revenue, err := projectPeriodRevenue(contract, periodStart, periodEnd)
+		if err != nil {
+			return 0, err
+		}
+		total += revenue
+	}
+	return total, nil
+}
+
+// projectPeriodRevenue projects the revenue excluding VAT of a period in which the
+// contract is billable
+func projectPeriodRevenue(contract ForecastContract, start, end time.Time) (float64, error) {
+	switch contract.CareType {
+	case "accommodation":
+		totals, err := invserv.CalculateAccomodationInvoiceTotal(invserv.AccommodationInvoiceParams{
+			Price:               contract.Price,
+			PriceTimeUnit:       contract.PriceTimeUnit,
+			VAT:                 contract.Vat,
+			BillablePeriodStart: start,
+			BillablePeriodEnd:   end,
+		})
+		if err != nil {
+			return 0, err
+		}
+		return totals.PreVatTotal, nil
+	case "ambulante":
+		minutes, err := expectedCareMinutes(contract, start, end)
+		if err != nil {
+			return 0, err
+		}
+		totals, err := invserv.CalculateAmbulanteInvoiceTotal(invserv.AmbulanteInvoiceParams{
+			Price:         contract.Price,
+			PriceTimeUnit: contract.PriceTimeUnit,
+			VAT:           contract.Vat,
+			TotalMinutes:  minutes,
+		})
+		if err != nil {
+			return 0, err
+		}
+		return totals.PreVatTotal, nil
+	}
+	return 0, fmt.Errorf("unsupported care type: %s", contract.CareType)
+}
+
+// expectedCareMinutes estimates the ambulante care in a period from the contracted
+// hours, as there are no appointments planned that far ahead
+func expectedCareMinutes(contract ForecastContract, start, end time.Time) (float64, error) {
+	if contract.Hours == nil || *contract.Hours <= 0 || contract.HoursType == nil {
+		return 0, fmt.Errorf("contract %d has no contracted hours", contract.ID)
+	}
+	days := end.Sub(start).Hours() / 24
+
+	switch *contract.HoursType {
+	case "weekly":
+		return *contract.Hours * 60 * days / 7, nil
+	case "all_period":
+		contractDays := contract.EndDate.Sub(contract.StartDate).Hours() / 24
+		if contractDays <= 0 {
+			return 0, fmt.Errorf("contract %d has an empty contract period", contract.ID)
+		}
+		return *contract.Hours * 60 * days / contractDays, nil
+	}
+	return 0, fmt.Errorf("unsupported hours type: %s", *contract.HoursType)
+}
+
+func (s *financeService) RevenueForecast(ctx context.Context, req RevenueForecastRequest) (*RevenueForecastResponse, error) {
+	start := req.StartDate
+	if start.IsZero() {
+		now := time.Now()
+		start = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	}
+	groupBy := req.GroupBy
+	if groupBy == "" {
+		groupBy = ForecastGroupByLocation
+	}
+	windowMonths := forecastHorizons[len(forecastHorizons)-1]
+	windowEnd := start.AddDate(0, windowMonths, 0)
+
+	contracts, err := s.Store.ListContractsForForecast(ctx, db.ListContractsForForecastParams{
+		WindowStart: pgtype.Timestamptz{Time: start, Valid: true},
+		WindowEnd:   pgtype.Timestamptz{Time: windowEnd, Valid: true},
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "RevenueForecast", "Failed to list contracts for forecast", zap.Error(err))
+		return nil, err
+	}
+
+	approvedPeriods, err := s.Store.ListApprovedContractPeriods(ctx, db.ListApprovedContractPeriodsParams{
+		WindowStart: pgtype.Timestamptz{Time: start, Valid: true},
+		WindowEnd:   pgtype.Timestamptz{Time: windowEnd, Valid: true},
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "RevenueForecast", "Failed to list approved contract periods", zap.Error(err))
+		return nil, err
+	}
+	periods := make(map[int64][]ForecastPeriod)
+	for _, period := range approvedPeriods {
+		forecastPeriod := ForecastPeriod{Start: period.EffectiveFrom.Time}
+		if period.EffectiveTo.Valid {
+			end := period.EffectiveTo.Time
+			forecastPeriod.End = &end
+		}
+		periods[period.ContractID] = append(periods[period.ContractID], forecastPeriod)
+	}
+
+	response := &RevenueForecastResponse{
+		StartDate:         start,
+		EndDate:           windowEnd,
+		GroupBy:           groupBy,
+		Horizons:          newForecastHorizons(),
+		Groups:            []RevenueForecastGroup{},
+		Months:            make([]RevenueForecastMonth, windowMonths),
+		ExpiringContracts: []ExpiringContractForecast{},
+		Warnings:          []string{},
+	}
+	for i := range response.Months {
+		response.Months[i].Month = start.AddDate(0, i, 0)
+	}
+	groups := make(map[string]int)
+
+	for _, row := range contracts {
+		contract := ForecastContract{
+			ID:            row.ID,
+			CareType:      row.CareType,
+			Price:         row.Price,
+			PriceTimeUnit: row.PriceTimeUnit,
+			Hours:         row.Hours,
+			HoursType:     row.HoursType,
+			StartDate:     row.StartDate.Time,
+			EndDate:       row.EndDate.Time,
+		}
+		if approved, ok := periods[row.ID]; ok {
+			contract.ApprovedPeriods = approved
+		} else {
+			contract.ApprovedPeriods = []ForecastPeriod{}
+		}
+		if row.Vat != nil && *row.Vat > 0 {
+			contract.Vat = float64(*row.Vat)
+		}
+		if row.RenewalContractID != nil && row.RenewalPrice != nil {
+			contract.Renewal = &ForecastRenewal{
+				StartDate: row.RenewalStartDate.Time,
+				EndDate:   row.RenewalEndDate.Time,
+				Price:     *row.RenewalPrice,
+			}
+		}
+		// an approved renewal is a contract of the forecast itself
+		renewable := !row.RenewalClosedAt.Valid
+
+		notRenewed := make([]float64, windowMonths)
+		renewed := make([]float64, windowMonths)
+		var projectionErr error
+		for i := range response.Months {
+			monthStart := start.AddDate(0, i, 0)
+			monthEnd := start.AddDate(0, i+1, 0)
+			if notRenewed[i], projectionErr = ProjectContractRevenue(contract, monthStart, monthEnd, false); projectionErr != nil {
+				break
+			}
+			if renewed[i], projectionErr = ProjectContractRevenue(contract, monthStart, monthEnd, renewable); projectionErr != nil {
+				break
+			}
+		}
+		if projectionErr != nil {
+			response.Warnings = append(response.Warnings, fmt.Sprintf("contract %d is left out of the forecast: %v", contract.ID, projectionErr))
+			continue
+		}
+
+		key, name := forecastGroup(groupBy, row)
+		index, ok := groups[key]
+		if !ok {
+			index = len(response.Groups)
+			groups[key] = index
+			response.Groups = append(response.Groups, RevenueForecastGroup{Key: key, Name: name, Horizons: newForecastHorizons()})
+		}
+
+		for i := range response.Months {
+			response.Months[i].RevenueIfNotRenewed += notRenewed[i]
+			response.Months[i].RevenueIfRenewed += renewed[i]
+			for h := range response.Horizons {
+				if i < response.Horizons[h].Months {
+					response.Horizons[h].add(notRenewed[i], renewed[i])
+					response.Groups[index].Horizons[h].add(notRenewed[i], renewed[i])
+				}
+			}
+		}
+
+		if contract.EndDate.Before(windowEnd) && renewable {
+			var atRisk float64
+			for i := range renewed {
+				atRisk += renewed[i] - notRenewed[i]
+			}
+			reminderDate := contract.EndDate.AddDate(0, 0, -int(row.ReminderPeriod))
+			response.ExpiringContracts = append(response.ExpiringContracts, ExpiringContractForecast{
+				ContractID:        contract.ID,
+				ClientID:          row.ClientID,
+				ClientName:        fmt.Sprintf("%s %s", row.ClientFirstName, row.ClientLastName),
+				FinancingAct:      row.FinancingAct,
+				EndDate:           contract.EndDate,
+				ReminderDate:      reminderDate,
+				RenewalContractID: row.RenewalContractID,
+				RenewalOverdue:    row.RenewalContractID == nil && !reminderDate.After(start),
+				RevenueAtRisk:     atRisk,
+			})
+		}
+	}
+
+	return response, nil
+}
+
+func forecastGroup(groupBy string, row db.ListContractsForForecastRow) (string, string) {
+	switch groupBy {
+	case ForecastGroupByFinancingAct:
+		return row.FinancingAct, row.FinancingAct
+	case ForecastGroupBySender:
+		if row.SenderID == nil || row.SenderName == nil {
+			return "none", "No sender"
+		}
+		return fmt.Sprintf("%d", *row.SenderID), *row.SenderName
+	default:
+		if row.LocationID == nil || row.LocationName == nil {
+			return "none", "No location"
+		}
+		return fmt.Sprintf("%d", *row.LocationID), *row.LocationName
+	}
+}
+
+func newForecastHorizons() []RevenueForecastHorizon {
+	horizons := make([]RevenueForecastHorizon, len(forecastHorizons))
+	for i, months := range forecastHorizons {
+		horizons[i] = RevenueForecastHorizon{Months: months}
+	}
+	return horizons
+}
+
+func (h *RevenueForecastHorizon) add(notRenewed, renewed float64) {
+	h.RevenueIfNotRenewed += notRenewed
+	h.RevenueIfRenewed += renewed
+	h.RevenueAtRisk += renewed - notRenewed
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,167 @@
+package finance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestProjectContractRevenue(t *testing.T) {
+	from := date(2025, 1, 1)
+	to := date(2025, 2, 1)
+
+	testCases := []struct {
+		name       string
+		contract   ForecastContract
+		notRenewed float64
+		renewed    float64
+	}{
+		{
+			name: "accommodation running the whole month",
+			contract: ForecastContract{
+				CareType: "accommodation", Price: 100, PriceTimeUnit: "daily",
+				StartDate: date(2024, 6, 1), EndDate: date(2025, 6, 1),
+			},
+			notRenewed: 3100,
+			renewed:    3100,
+		},
+		{
+			name: "accommodation ending halfway",
+			contract: ForecastContract{
+				CareType: "accommodation", Price: 700, PriceTimeUnit: "weekly", Vat: 21,
+				StartDate: date(2024, 6, 1), EndDate: date(2025, 1, 15),
+			},
+			notRenewed: 1400,
+			renewed:    3100,
+		},
+		{
+			name: "accommodation starting later",
+			contract: ForecastContract{
+				CareType: "accommodation", Price: 100, PriceTimeUnit: "daily",
+				StartDate: date(2025, 1, 22), EndDate: date(2025, 6, 1),
+			},
+			notRenewed: 1000,
+			renewed:    1000,
+		},
+		{
+			name: "ambulante with weekly hours",
+			contract: ForecastContract{
+				CareType: "ambulante", Price: 60, PriceTimeUnit: "hourly",
+				Hours: ptr(7.0), HoursType: ptr("weekly"),
+				StartDate: date(2024, 6, 1), EndDate: date(2025, 1, 15),
+			},
+			notRenewed: 840,
+			renewed:    1860,
+		},
+		{
+			name: "ambulante with hours for the whole period",
+			contract: ForecastContract{
+				CareType: "ambulante", Price: 1, PriceTimeUnit: "minute",
+				Hours: ptr(100.0), HoursType: ptr("all_period"),
+				StartDate: date(2024, 12, 22), EndDate: date(2025, 1, 11),
+			},
+			notRenewed: 3000,
+			renewed:    9300,
+		},
+		{
+			name: "accommodation suspended for a hospital stay",
+			contract: ForecastContract{
+				CareType: "accommodation", Price: 100, PriceTimeUnit: "daily",
+				StartDate: date(2024, 6, 1), EndDate: date(2025, 6, 1),
+				ApprovedPeriods: []ForecastPeriod{
+					{Start: date(2024, 6, 1), End: ptr(date(2025, 1, 10))},
+					{Start: date(2025, 1, 20)},
+				},
+			},
+			notRenewed: 2100,
+			renewed:    2100,
+		},
+		{
+			name: "contract approved halfway the period",
+			contract: ForecastContract{
+				CareType: "accommodation", Price: 100, PriceTimeUnit: "daily",
+				StartDate: date(2024, 6, 1), EndDate: date(2025, 6, 1),
+				ApprovedPeriods: []ForecastPeriod{{Start: date(2025, 1, 16)}},
+			},
+			notRenewed: 1600,
+			renewed:    1600,
+		},
+		{
+			name: "accommodation approved until its end",
+			contract: ForecastContract{
+				CareType: "accommodation", Price: 100, PriceTimeUnit: "daily",
+				StartDate: date(2024, 6, 1), EndDate: date(2025, 1, 15),
+				ApprovedPeriods: []ForecastPeriod{{Start: date(2024, 6, 1), End: ptr(date(2025, 1, 15))}},
+			},
+			notRenewed: 1400,
+			renewed:    3100,
+		},
+		{
+			name: "renewal drafted with an indexed price",
+			contract: ForecastContract{
+				CareType: "accommodation", Price: 100, PriceTimeUnit: "daily",
+				StartDate: date(2024, 6, 1), EndDate: date(2025, 1, 15),
+				Renewal: &ForecastRenewal{StartDate: date(2025, 1, 15), EndDate: date(2025, 7, 1), Price: 110},
+			},
+			notRenewed: 1400,
+			renewed:    3270,
+		},
+		{
+			name: "renewal drafted after a gap",
+			contract: ForecastContract{
+				CareType: "accommodation", Price: 100, PriceTimeUnit: "daily",
+				StartDate: date(2024, 6, 1), EndDate: date(2025, 1, 15),
+				Renewal: &ForecastRenewal{StartDate: date(2025, 1, 22), EndDate: date(2025, 7, 1), Price: 100},
+			},
+			notRenewed: 1400,
+			renewed:    2400,
+		},
+		{
+			name: "contract ended before the period",
+			contract: ForecastContract{
+				CareType: "accommodation", Price: 100, PriceTimeUnit: "daily",
+				StartDate: date(2024, 1, 1), EndDate: date(2024, 12, 1),
+			},
+			notRenewed: 0,
+			renewed:    3100,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			notRenewed, err := ProjectContractRevenue(tc.contract, from, to, false)
+			require.NoError(t, err)
+			require.InDelta(t, tc.notRenewed, notRenewed, 0.01)
+
+			renewed, err := ProjectContractRevenue(tc.contract, from, to, true)
+			require.NoError(t, err)
+			require.InDelta(t, tc.renewed, renewed, 0.01)
+		})
+	}
+}
+
+func TestProjectContractRevenueErrors(t *testing.T) {
+	from := date(2025, 1, 1)
+	to := date(2025, 2, 1)
+
+	_, err := ProjectContractRevenue(ForecastContract{
+		CareType: "ambulante", Price: 60, PriceTimeUnit: "hourly",
+		StartDate: date(2024, 6, 1), EndDate: date(2025, 6, 1),
+	}, from, to, false)
+	require.ErrorContains(t, err, "no contracted hours")
+
+	_, err = ProjectContractRevenue(ForecastContract{
+		CareType: "accommodation", Price: 3000, PriceTimeUnit: "monthly",
+		StartDate: date(2024, 6, 1), EndDate: date(2025, 6, 1),
+	}, from, to, false)
+	require.ErrorContains(t, err, "unsupported price time unit")
+}
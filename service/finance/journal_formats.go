@@ -0,0 +1,100 @@
+package finance
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strings"
+)
+
+const dutchDateLayout = "02-01-2006"
+
+// RenderJournal writes the journal lines in the import format of a bookkeeping package
+func RenderJournal(format string, lines []JournalLine) ([]byte, error) {
+	switch format {
+	case ExportFormatCSV:
+		return journalCSV(lines)
+	case ExportFormatExactOnline:
+		return journalExactOnline(lines)
+	case ExportFormatAFAS:
+		return journalAFAS(lines)
+	default:
+		return nil, fmt.Errorf("unknown export format %q", format)
+	}
+}
+
+// journalCSV is the generic layout with separate debit and credit columns
+func journalCSV(lines []JournalLine) ([]byte, error) {
+	records := [][]string{{"journal", "entry_number", "date", "account", "description", "debit", "credit", "vat_code", "relation_code", "relation_name", "care_type", "financing_act"}}
+	for _, line := range lines {
+		records = append(records, []string{
+			line.Journal,
+			line.EntryNumber,
+			line.Date.Format(csvDateLayout),
+			line.AccountCode,
+			line.Description,
+			formatAmount(line.Debit),
+			formatAmount(line.Credit),
+			line.VatCode,
+			line.RelationCode,
+			line.RelationName,
+			line.CareType,
+			line.FinancingAct,
+		})
+	}
+	return writeCSV(records)
+}
+
+// journalExactOnline follows the general journal import of Exact Online, a single
+// amount column that is positive for debit and negative for credit
+func journalExactOnline(lines []JournalLine) ([]byte, error) {
+	records := [][]string{{"Journal", "Date", "EntryNumber", "GLAccount", "Description", "Account", "AccountName", "AmountDC", "VATCode"}}
+	for _, line := range lines {
+		records = append(records, []string{
+			line.Journal,
+			line.Date.Format(dutchDateLayout),
+			line.EntryNumber,
+			line.AccountCode,
+			line.Description,
+			line.RelationCode,
+			line.RelationName,
+			formatAmount(line.Debit - line.Credit),
+			line.VatCode,
+		})
+	}
+	return writeSeparatedCSV(records, ';')
+}
+
+// journalAFAS follows the financial entries import of AFAS Profit, which expects
+// Dutch headers and decimal commas
+func journalAFAS(lines []JournalLine) ([]byte, error) {
+	records := [][]string{{"Dagboek", "Boekdatum", "Boekstuknummer", "Rekeningnummer", "Omschrijving", "Bedrag debet", "Bedrag credit", "Btw-code", "Relatie"}}
+	for _, line := range lines {
+		records = append(records, []string{
+			line.Journal,
+			line.Date.Format(dutchDateLayout),
+			line.EntryNumber,
+			line.AccountCode,
+			line.Description,
+			dutchAmount(line.Debit),
+			dutchAmount(line.Credit),
+			line.VatCode,
+			line.RelationCode,
+		})
+	}
+	return writeSeparatedCSV(records, ';')
+}
+
+func dutchAmount(amount float64) string {
+	return strings.Replace(formatAmount(amount), ".", ",", 1)
+}
+
+func writeSeparatedCSV(records [][]string, separator rune) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Comma = separator
+	if err := w.WriteAll(records); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
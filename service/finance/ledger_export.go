@@ -0,0 +1,357 @@
+package finance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	db "maicare_go/db/sqlc"
+	"maicare_go/logger"
+	invserv "maicare_go/service/invoice"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultSalesJournal = "VRK"
+	defaultBankJournal  = "BNK"
+)
+
+var (
+	ErrInvalidExportPeriod  = errors.New("end date cannot be before start date")
+	ErrNothingToExport      = errors.New("nothing to export")
+	ErrReexportNotConfirmed = errors.New("this export was downloaded before, confirm to download it again")
+)
+
+func (s *financeService) ListLedgerAccounts(ctx context.Context, organisationID int64) ([]LedgerAccountResponse, error) {
+	accounts, err := s.Store.ListLedgerAccountMappings(ctx, organisationID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ListLedgerAccounts", "Failed to list ledger account mappings", zap.Int64("organisation_id", organisationID), zap.Error(err))
+		return nil, err
+	}
+	return newLedgerAccountResponses(accounts), nil
+}
+
+func (s *financeService) UpdateLedgerAccounts(ctx context.Context, organisationID int64, req UpdateLedgerAccountsRequest, employeeID int64) ([]LedgerAccountResponse, error) {
+	if err := ValidateLedgerAccounts(req.Accounts); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.Store.ConnPool.Begin(ctx)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "UpdateLedgerAccounts", "Failed to begin transaction", zap.Int64("organisation_id", organisationID), zap.Error(err))
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.Store.WithTx(tx)
+
+	if err := qtx.DeleteLedgerAccountMappings(ctx, organisationID); err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "UpdateLedgerAccounts", "Failed to delete ledger account mappings", zap.Int64("organisation_id", organisationID), zap.Error(err))
+		return nil, err
+	}
+
+	accounts := make([]db.LedgerAccountMapping, len(req.Accounts))
+	for i, a := range req.Accounts {
+		accounts[i], err = qtx.CreateLedgerAccountMapping(ctx, db.CreateLedgerAccountMappingParams{
+			OrganisationID: organisationID,
+			AccountType:    a.AccountType,
+			CareType:       a.CareType,
+			FinancingAct:   a.FinancingAct,
+			VatRate:        a.VatRate,
+			AccountCode:    a.AccountCode,
+			VatCode:        a.VatCode,
+			Description:    a.Description,
+			UpdatedBy:      &employeeID,
+		})
+		if err != nil {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "UpdateLedgerAccounts", "Failed to create ledger account mapping", zap.Int64("organisation_id", organisationID), zap.Error(err))
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "UpdateLedgerAccounts", "Failed to commit transaction", zap.Int64("organisation_id", organisationID), zap.Error(err))
+		return nil, err
+	}
+	return newLedgerAccountResponses(accounts), nil
+}
+
+// CreateAccountingExport records a batch with everything of the period that was not
+// exported yet. The journal is built before the batch is stored, so a batch is never
+// recorded while ledger accounts are missing.
+func (s *financeService) CreateAccountingExport(ctx context.Context, req CreateAccountingExportRequest, employeeID int64) (*AccountingExportBatchResponse, error) {
+	if req.EndDate.Before(req.StartDate) {
+		return nil, ErrInvalidExportPeriod
+	}
+	if req.SalesJournal == "" {
+		req.SalesJournal = defaultSalesJournal
+	}
+	if req.BankJournal == "" {
+		req.BankJournal = defaultBankJournal
+	}
+
+	tx, err := s.Store.ConnPool.Begin(ctx)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CreateAccountingExport", "Failed to begin transaction", zap.Error(err))
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.Store.WithTx(tx)
+
+	period := db.ListUnexportedInvoicesForExportParams{
+		OrganisationID: req.OrganisationID,
+		StartDate:      pgtype.Date{Time: req.StartDate, Valid: true},
+		EndDate:        pgtype.Date{Time: req.EndDate, Valid: true},
+	}
+	invoiceRows, err := qtx.ListUnexportedInvoicesForExport(ctx, period)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CreateAccountingExport", "Failed to list invoices to export", zap.Int64("organisation_id", req.OrganisationID), zap.Error(err))
+		return nil, err
+	}
+	paymentRows, err := qtx.ListUnexportedPaymentsForExport(ctx, db.ListUnexportedPaymentsForExportParams(period))
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CreateAccountingExport", "Failed to list payments to export", zap.Int64("organisation_id", req.OrganisationID), zap.Error(err))
+		return nil, err
+	}
+	if len(invoiceRows) == 0 && len(paymentRows) == 0 {
+		return nil, ErrNothingToExport
+	}
+
+	if _, err := s.buildExportJournal(ctx, qtx, req.OrganisationID, invoiceRows, paymentRows, req.SalesJournal, req.BankJournal); err != nil {
+		return nil, err
+	}
+
+	batch, err := qtx.CreateAccountingExportBatch(ctx, db.CreateAccountingExportBatchParams{
+		OrganisationID: req.OrganisationID,
+		ExportFormat:   req.Format,
+		StartDate:      period.StartDate,
+		EndDate:        period.EndDate,
+		SalesJournal:   req.SalesJournal,
+		BankJournal:    req.BankJournal,
+		InvoiceCount:   int32(len(invoiceRows)),
+		PaymentCount:   int32(len(paymentRows)),
+		CreatedBy:      &employeeID,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CreateAccountingExport", "Failed to create export batch", zap.Int64("organisation_id", req.OrganisationID), zap.Error(err))
+		return nil, err
+	}
+
+	invoiceIDs := make([]int64, len(invoiceRows))
+	for i, row := range invoiceRows {
+		invoiceIDs[i] = row.ID
+	}
+	paymentIDs := make([]int64, len(paymentRows))
+	for i, row := range paymentRows {
+		paymentIDs[i] = row.ID
+	}
+	if err := qtx.AddAccountingExportInvoices(ctx, db.AddAccountingExportInvoicesParams{BatchID: batch.ID, InvoiceIds: invoiceIDs}); err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CreateAccountingExport", "Failed to add invoices to export batch", zap.Int64("batch_id", batch.ID), zap.Error(err))
+		return nil, err
+	}
+	if err := qtx.AddAccountingExportPayments(ctx, db.AddAccountingExportPaymentsParams{BatchID: batch.ID, PaymentIds: paymentIDs}); err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CreateAccountingExport", "Failed to add payments to export batch", zap.Int64("batch_id", batch.ID), zap.Error(err))
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CreateAccountingExport", "Failed to commit transaction", zap.Int64("batch_id", batch.ID), zap.Error(err))
+		return nil, err
+	}
+	return newAccountingExportBatchResponse(batch), nil
+}
+
+func (s *financeService) ListAccountingExports(ctx context.Context, req ListAccountingExportsRequest) ([]AccountingExportBatchResponse, error) {
+	batches, err := s.Store.ListAccountingExportBatches(ctx, req.OrganisationID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ListAccountingExports", "Failed to list export batches", zap.Int64("organisation_id", req.OrganisationID), zap.Error(err))
+		return nil, err
+	}
+
+	responses := make([]AccountingExportBatchResponse, len(batches))
+	for i, batch := range batches {
+		responses[i] = *newAccountingExportBatchResponse(batch)
+	}
+	return responses, nil
+}
+
+// DownloadAccountingExport renders the journal of a batch with the current ledger
+// accounts. Every download is recorded and downloading a batch again has to be
+// confirmed.
+func (s *financeService) DownloadAccountingExport(ctx context.Context, batchID int64, req DownloadAccountingExportRequest, employeeID int64) (*AccountingExportFile, error) {
+	batch, err := s.Store.GetAccountingExportBatch(ctx, batchID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "DownloadAccountingExport", "Failed to get export batch", zap.Int64("batch_id", batchID), zap.Error(err))
+		return nil, err
+	}
+	if batch.DownloadCount > 0 && !req.Confirm {
+		return nil, ErrReexportNotConfirmed
+	}
+
+	invoiceRows, err := s.Store.ListAccountingExportBatchInvoices(ctx, batchID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "DownloadAccountingExport", "Failed to list exported invoices", zap.Int64("batch_id", batchID), zap.Error(err))
+		return nil, err
+	}
+	paymentRows, err := s.Store.ListAccountingExportBatchPayments(ctx, batchID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "DownloadAccountingExport", "Failed to list exported payments", zap.Int64("batch_id", batchID), zap.Error(err))
+		return nil, err
+	}
+
+	invoices := make([]db.ListUnexportedInvoicesForExportRow, len(invoiceRows))
+	for i, row := range invoiceRows {
+		invoices[i] = db.ListUnexportedInvoicesForExportRow(row)
+	}
+	payments := make([]db.ListUnexportedPaymentsForExportRow, len(paymentRows))
+	for i, row := range paymentRows {
+		payments[i] = db.ListUnexportedPaymentsForExportRow(row)
+	}
+
+	lines, err := s.buildExportJournal(ctx, s.Store.Queries, batch.OrganisationID, invoices, payments, batch.SalesJournal, batch.BankJournal)
+	if err != nil {
+		return nil, err
+	}
+	data, err := RenderJournal(batch.ExportFormat, lines)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "DownloadAccountingExport", "Failed to render journal", zap.Int64("batch_id", batchID), zap.Error(err))
+		return nil, err
+	}
+
+	if _, err := s.Store.RecordAccountingExportDownload(ctx, db.RecordAccountingExportDownloadParams{
+		ID:               batchID,
+		LastDownloadedBy: &employeeID,
+	}); err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "DownloadAccountingExport", "Failed to record download", zap.Int64("batch_id", batchID), zap.Error(err))
+		return nil, err
+	}
+
+	return &AccountingExportFile{
+		BatchID: batchID,
+		Name:    fmt.Sprintf("journal_%s_%d", batch.ExportFormat, batchID),
+		Data:    data,
+	}, nil
+}
+
+// buildExportJournal loads the ledger accounts of the organisation and the care type and
+// financing act of the invoiced contracts and builds the journal
+func (s *financeService) buildExportJournal(ctx context.Context, q *db.Queries, organisationID int64,
+	invoiceRows []db.ListUnexportedInvoicesForExportRow, paymentRows []db.ListUnexportedPaymentsForExportRow,
+	salesJournal, bankJournal string) ([]JournalLine, error) {
+	accounts, err := q.ListLedgerAccountMappings(ctx, organisationID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "buildExportJournal", "Failed to list ledger account mappings", zap.Int64("organisation_id", organisationID), zap.Error(err))
+		return nil, err
+	}
+
+	details := make([][]invserv.InvoiceDetails, len(invoiceRows))
+	var contractIDs []int64
+	for i, row := range invoiceRows {
+		if len(row.InvoiceDetails) > 0 {
+			if err := json.Unmarshal(row.InvoiceDetails, &details[i]); err != nil {
+				s.Logger.LogBusinessEvent(logger.LogLevelError, "buildExportJournal", "Failed to parse invoice details", zap.Int64("invoice_id", row.ID), zap.Error(err))
+				return nil, fmt.Errorf("failed to parse the details of invoice %s: %w", row.InvoiceNumber, err)
+			}
+		}
+		for _, line := range details[i] {
+			contractIDs = append(contractIDs, line.ContractID)
+		}
+	}
+
+	contracts, err := q.ListContractLedgerKeys(ctx, contractIDs)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "buildExportJournal", "Failed to list invoiced contracts", zap.Error(err))
+		return nil, err
+	}
+	contractKeys := make(map[int64]db.ListContractLedgerKeysRow, len(contracts))
+	for _, c := range contracts {
+		contractKeys[c.ID] = c
+	}
+
+	invoices := make([]ExportInvoice, len(invoiceRows))
+	for i, row := range invoiceRows {
+		invoices[i] = ExportInvoice{
+			InvoiceNumber: row.InvoiceNumber,
+			InvoiceType:   row.InvoiceType,
+			IssueDate:     row.IssueDate.Time,
+			TotalAmount:   row.TotalAmount,
+			SenderID:      row.SenderID,
+			SenderName:    optionalString(row.SenderName),
+			ClientName:    row.ClientName,
+		}
+		for _, line := range details[i] {
+			exportLine := ExportInvoiceLine{
+				CareType:    line.ContractType,
+				PreVatTotal: line.PreVatTotal,
+				VatRate:     ledgerVatRate(line.Vat, line.PreVatTotal, line.Total),
+				VatAmount:   line.Total - line.PreVatTotal,
+			}
+			if c, ok := contractKeys[line.ContractID]; ok {
+				exportLine.CareType = c.CareType
+				exportLine.FinancingAct = c.FinancingAct
+			}
+			invoices[i].Lines = append(invoices[i].Lines, exportLine)
+		}
+	}
+
+	payments := make([]ExportPayment, len(paymentRows))
+	for i, row := range paymentRows {
+		payments[i] = ExportPayment{
+			ID:            row.ID,
+			InvoiceNumber: row.InvoiceNumber,
+			PaymentDate:   row.PaymentDate.Time,
+			Amount:        row.Amount,
+			Reference:     optionalString(row.PaymentReference),
+			SenderID:      row.SenderID,
+			SenderName:    optionalString(row.SenderName),
+		}
+	}
+
+	return BuildJournal(invoices, payments, accounts, salesJournal, bankJournal)
+}
+
+func newLedgerAccountResponses(accounts []db.LedgerAccountMapping) []LedgerAccountResponse {
+	responses := make([]LedgerAccountResponse, len(accounts))
+	for i, a := range accounts {
+		responses[i] = LedgerAccountResponse{
+			ID:             a.ID,
+			OrganisationID: a.OrganisationID,
+			AccountType:    a.AccountType,
+			CareType:       a.CareType,
+			FinancingAct:   a.FinancingAct,
+			VatRate:        a.VatRate,
+			AccountCode:    a.AccountCode,
+			VatCode:        a.VatCode,
+			Description:    a.Description,
+			UpdatedBy:      a.UpdatedBy,
+			UpdatedAt:      a.UpdatedAt.Time,
+		}
+	}
+	return responses
+}
+
+func newAccountingExportBatchResponse(batch db.AccountingExportBatch) *AccountingExportBatchResponse {
+	response := &AccountingExportBatchResponse{
+		ID:               batch.ID,
+		OrganisationID:   batch.OrganisationID,
+		Format:           batch.ExportFormat,
+		StartDate:        batch.StartDate.Time,
+		EndDate:          batch.EndDate.Time,
+		SalesJournal:     batch.SalesJournal,
+		BankJournal:      batch.BankJournal,
+		InvoiceCount:     batch.InvoiceCount,
+		PaymentCount:     batch.PaymentCount,
+		DownloadCount:    batch.DownloadCount,
+		LastDownloadedBy: batch.LastDownloadedBy,
+		CreatedBy:        batch.CreatedBy,
+		CreatedAt:        batch.CreatedAt.Time,
+	}
+	if batch.LastDownloadedAt.Valid {
+		downloadedAt := batch.LastDownloadedAt.Time
+		response.LastDownloadedAt = &downloadedAt
+	}
+	return response
+}
@@ -0,0 +1,93 @@
+package finance
+
+import "time"
+
+const (
+	LedgerAccountDebtor  = "debtor"
+	LedgerAccountRevenue = "revenue"
+	LedgerAccountVat     = "vat"
+	LedgerAccountBank    = "bank"
+
+	ExportFormatCSV         = "csv"
+	ExportFormatExactOnline = "exact_online"
+	ExportFormatAFAS        = "afas"
+)
+
+// LedgerAccountRequest maps journal lines to a ledger account. Revenue accounts can be
+// narrowed down to a care type and financing act, VAT accounts to a rate.
+type LedgerAccountRequest struct {
+	AccountType  string   `json:"account_type" binding:"required,oneof=debtor revenue vat bank"`
+	CareType     *string  `json:"care_type" binding:"omitempty,oneof=ambulante accommodation"`
+	FinancingAct *string  `json:"financing_act" binding:"omitempty,oneof=WMO ZVW WLZ JW WPG"`
+	VatRate      *float64 `json:"vat_rate" binding:"omitempty,min=0,max=100"`
+	AccountCode  string   `json:"account_code" binding:"required,max=20" example:"8000"`
+	VatCode      *string  `json:"vat_code" binding:"omitempty,max=10"`
+	Description  *string  `json:"description" binding:"omitempty,max=255"`
+}
+
+// UpdateLedgerAccountsRequest replaces all ledger account mappings of an organisation
+type UpdateLedgerAccountsRequest struct {
+	Accounts []LedgerAccountRequest `json:"accounts" binding:"required,dive"`
+}
+
+// LedgerAccountResponse is a ledger account mapping of an organisation
+type LedgerAccountResponse struct {
+	ID             int64     `json:"id"`
+	OrganisationID int64     `json:"organisation_id"`
+	AccountType    string    `json:"account_type"`
+	CareType       *string   `json:"care_type"`
+	FinancingAct   *string   `json:"financing_act"`
+	VatRate        *float64  `json:"vat_rate"`
+	AccountCode    string    `json:"account_code"`
+	VatCode        *string   `json:"vat_code"`
+	Description    *string   `json:"description"`
+	UpdatedBy      *int64    `json:"updated_by"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// CreateAccountingExportRequest exports the invoices, credit notes and payments of a
+// period that were not exported before. The journal codes default to VRK and BNK.
+type CreateAccountingExportRequest struct {
+	OrganisationID int64     `json:"organisation_id" binding:"required"`
+	StartDate      time.Time `json:"start_date" binding:"required"`
+	EndDate        time.Time `json:"end_date" binding:"required"`
+	Format         string    `json:"format" binding:"required,oneof=csv exact_online afas"`
+	SalesJournal   string    `json:"sales_journal" binding:"max=20" example:"VRK"`
+	BankJournal    string    `json:"bank_journal" binding:"max=20" example:"BNK"`
+}
+
+// ListAccountingExportsRequest selects the export batches of an organisation
+type ListAccountingExportsRequest struct {
+	OrganisationID int64 `form:"organisation_id" binding:"required"`
+}
+
+// DownloadAccountingExportRequest has to confirm downloads of a batch that was
+// downloaded before, so a batch isn't imported into the bookkeeping twice by accident
+type DownloadAccountingExportRequest struct {
+	Confirm bool `form:"confirm"`
+}
+
+// AccountingExportBatchResponse is a recorded export
+type AccountingExportBatchResponse struct {
+	ID               int64      `json:"id"`
+	OrganisationID   int64      `json:"organisation_id"`
+	Format           string     `json:"format"`
+	StartDate        time.Time  `json:"start_date"`
+	EndDate          time.Time  `json:"end_date"`
+	SalesJournal     string     `json:"sales_journal"`
+	BankJournal      string     `json:"bank_journal"`
+	InvoiceCount     int32      `json:"invoice_count"`
+	PaymentCount     int32      `json:"payment_count"`
+	DownloadCount    int32      `json:"download_count"`
+	LastDownloadedAt *time.Time `json:"last_downloaded_at"`
+	LastDownloadedBy *int64     `json:"last_downloaded_by"`
+	CreatedBy        *int64     `json:"created_by"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// AccountingExportFile is the journal file of an export batch
+type AccountingExportFile struct {
+	BatchID int64
+	Name    string
+	Data    []byte
+}
@@ -0,0 +1,309 @@
+package finance
+
+import (
+	"errors"
+	"fmt"
+	db "maicare_go/db/sqlc"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrMissingLedgerAccount = errors.New("missing ledger account mapping")
+	ErrInvalidLedgerAccount = errors.New("invalid ledger account mapping")
+)
+
+// JournalLine is a single debit or credit line of a journal entry. The lines of an
+// entry share the journal and entry number and always balance.
+type JournalLine struct {
+	Journal      string
+	EntryNumber  string
+	Date         time.Time
+	AccountCode  string
+	Description  string
+	Debit        float64
+	Credit       float64
+	VatCode      string
+	RelationCode string
+	RelationName string
+	CareType     string
+	FinancingAct string
+}
+
+// ExportInvoice is an invoice or credit note as it is booked, credit notes have
+// negative amounts
+type ExportInvoice struct {
+	InvoiceNumber string
+	InvoiceType   string
+	IssueDate     time.Time
+	TotalAmount   float64
+	Lines         []ExportInvoiceLine
+	SenderID      *int64
+	SenderName    string
+	ClientName    string
+}
+
+// ExportInvoiceLine is the revenue of an invoice line
+type ExportInvoiceLine struct {
+	CareType     string
+	FinancingAct string
+	PreVatTotal  float64
+	VatRate      float64
+	VatAmount    float64
+}
+
+// ExportPayment is a payment received on an invoice
+type ExportPayment struct {
+	ID            int64
+	InvoiceNumber string
+	PaymentDate   time.Time
+	Amount        float64
+	Reference     string
+	SenderID      *int64
+	SenderName    string
+}
+
+// BuildJournal books every invoice on the debtor account against revenue per care type
+// and financing act and VAT per rate, and every payment on the bank account against the
+// debtor account. Rounding differences between the lines and the invoice total are
+// booked on the first revenue line, so the debtor always matches the invoice. All
+// missing mappings are reported at once.
+func BuildJournal(invoices []ExportInvoice, payments []ExportPayment, accounts []db.LedgerAccountMapping, salesJournal, bankJournal string) ([]JournalLine, error) {
+	missing := map[string]bool{}
+	account := func(found *db.LedgerAccountMapping, name string) *db.LedgerAccountMapping {
+		if found == nil {
+			missing[name] = true
+			return &db.LedgerAccountMapping{}
+		}
+		return found
+	}
+
+	var lines []JournalLine
+	for _, inv := range invoices {
+		kind := "Invoice"
+		if inv.InvoiceType == "credit_note" {
+			kind = "Credit note"
+		}
+		entry := JournalLine{
+			Journal:      salesJournal,
+			EntryNumber:  inv.InvoiceNumber,
+			Date:         inv.IssueDate,
+			Description:  strings.TrimSpace(fmt.Sprintf("%s %s %s", kind, inv.InvoiceNumber, inv.ClientName)),
+			RelationCode: relationCode(inv.SenderID),
+			RelationName: inv.SenderName,
+		}
+
+		revenue, vat := groupInvoiceLines(inv)
+		var booked float64
+		for _, group := range revenue {
+			booked += group.PreVatTotal
+		}
+		for _, group := range vat {
+			booked += group.VatAmount
+		}
+		total := round2(inv.TotalAmount)
+		if len(revenue) == 0 {
+			revenue = append(revenue, ExportInvoiceLine{})
+		}
+		revenue[0].PreVatTotal = round2(revenue[0].PreVatTotal + total - round2(booked))
+
+		debtor := account(findLedgerAccount(accounts, LedgerAccountDebtor, "", "", nil), "debtor")
+		lines = appendJournalLine(lines, entry, debtor.AccountCode, "", total)
+
+		for _, group := range revenue {
+			mapping := account(findLedgerAccount(accounts, LedgerAccountRevenue, group.CareType, group.FinancingAct, nil), revenueAccountName(group))
+			vatCode := optionalString(mapping.VatCode)
+			if vatCode == "" {
+				if vatMapping := findLedgerAccount(accounts, LedgerAccountVat, "", "", &group.VatRate); vatMapping != nil {
+					vatCode = optionalString(vatMapping.VatCode)
+				}
+			}
+			line := entry
+			line.CareType = group.CareType
+			line.FinancingAct = group.FinancingAct
+			lines = appendJournalLine(lines, line, mapping.AccountCode, vatCode, -group.PreVatTotal)
+		}
+		for _, group := range vat {
+			mapping := account(findLedgerAccount(accounts, LedgerAccountVat, "", "", &group.VatRate), fmt.Sprintf("vat %s%%", strconv.FormatFloat(group.VatRate, 'f', -1, 64)))
+			lines = appendJournalLine(lines, entry, mapping.AccountCode, optionalString(mapping.VatCode), -group.VatAmount)
+		}
+	}
+
+	for _, payment := range payments {
+		entry := JournalLine{
+			Journal:      bankJournal,
+			EntryNumber:  fmt.Sprintf("PAY-%d", payment.ID),
+			Date:         payment.PaymentDate,
+			Description:  strings.TrimSpace(fmt.Sprintf("Payment %s %s", payment.InvoiceNumber, payment.Reference)),
+			RelationCode: relationCode(payment.SenderID),
+			RelationName: payment.SenderName,
+		}
+		amount := round2(payment.Amount)
+		bank := account(findLedgerAccount(accounts, LedgerAccountBank, "", "", nil), "bank")
+		debtor := account(findLedgerAccount(accounts, LedgerAccountDebtor, "", "", nil), "debtor")
+		lines = appendJournalLine(lines, entry, bank.AccountCode, "", amount)
+		lines = appendJournalLine(lines, entry, debtor.AccountCode, "", -amount)
+	}
+
+	if len(missing) > 0 {
+		names := make([]string, 0, len(missing))
+		for name := range missing {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		return nil, fmt.Errorf("%w: %s", ErrMissingLedgerAccount, strings.Join(names, ", "))
+	}
+	return lines, nil
+}
+
+// ValidateLedgerAccounts checks that mappings only use the keys of their account type
+// and that no two mappings have the same key
+func ValidateLedgerAccounts(accounts []LedgerAccountRequest) error {
+	seen := map[string]bool{}
+	for _, a := range accounts {
+		if a.AccountType != LedgerAccountRevenue && (a.CareType != nil || a.FinancingAct != nil) {
+			return fmt.Errorf("%w: only revenue accounts can have a care type or financing act", ErrInvalidLedgerAccount)
+		}
+		if a.AccountType != LedgerAccountVat && a.VatRate != nil {
+			return fmt.Errorf("%w: only VAT accounts can have a VAT rate", ErrInvalidLedgerAccount)
+		}
+		key := fmt.Sprintf("%s|%s|%s|%s", a.AccountType, optionalString(a.CareType), optionalString(a.FinancingAct), optionalRate(a.VatRate))
+		if seen[key] {
+			return fmt.Errorf("%w: duplicate %s account", ErrInvalidLedgerAccount, a.AccountType)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// groupInvoiceLines sums the revenue of an invoice per care type, financing act and VAT
+// rate and the VAT per rate, both in the order the lines appear
+func groupInvoiceLines(inv ExportInvoice) ([]ExportInvoiceLine, []ExportInvoiceLine) {
+	var revenue, vat []ExportInvoiceLine
+	for _, line := range inv.Lines {
+		i := slices.IndexFunc(revenue, func(g ExportInvoiceLine) bool {
+			return g.CareType == line.CareType && g.FinancingAct == line.FinancingAct && g.VatRate == line.VatRate
+		})
+		if i < 0 {
+			revenue = append(revenue, ExportInvoiceLine{CareType: line.CareType, FinancingAct: line.FinancingAct, VatRate: line.VatRate})
+			i = len(revenue) - 1
+		}
+		revenue[i].PreVatTotal += line.PreVatTotal
+
+		if line.VatAmount == 0 {
+			continue
+		}
+		j := slices.IndexFunc(vat, func(g ExportInvoiceLine) bool { return g.VatRate == line.VatRate })
+		if j < 0 {
+			vat = append(vat, ExportInvoiceLine{VatRate: line.VatRate})
+			j = len(vat) - 1
+		}
+		vat[j].VatAmount += line.VatAmount
+	}
+
+	for i := range revenue {
+		revenue[i].PreVatTotal = round2(revenue[i].PreVatTotal)
+	}
+	for i := range vat {
+		vat[i].VatAmount = round2(vat[i].VatAmount)
+	}
+	return revenue, vat
+}
+
+// findLedgerAccount returns the most specific mapping for the keys. A revenue mapping
+// for both the care type and financing act wins over one for only the care type, which
+// wins over one for only the financing act.
+func findLedgerAccount(accounts []db.LedgerAccountMapping, accountType, careType, financingAct string, vatRate *float64) *db.LedgerAccountMapping {
+	var best *db.LedgerAccountMapping
+	bestScore := -1
+	for i, a := range accounts {
+		if a.AccountType != accountType {
+			continue
+		}
+		score := 0
+		if a.CareType != nil {
+			if *a.CareType != careType {
+				continue
+			}
+			score += 2
+		}
+		if a.FinancingAct != nil {
+			if *a.FinancingAct != financingAct {
+				continue
+			}
+			score++
+		}
+		if a.VatRate != nil {
+			if vatRate == nil || *a.VatRate != *vatRate {
+				continue
+			}
+			score++
+		}
+		if score > bestScore {
+			best, bestScore = &accounts[i], score
+		}
+	}
+	return best
+}
+
+// appendJournalLine adds a line with a positive amount as debit and a negative amount
+// as credit, lines without an amount are left out
+func appendJournalLine(lines []JournalLine, line JournalLine, accountCode, vatCode string, amount float64) []JournalLine {
+	amount = round2(amount)
+	if amount == 0 {
+		return lines
+	}
+	line.AccountCode = accountCode
+	line.VatCode = vatCode
+	if amount > 0 {
+		line.Debit = amount
+	} else {
+		line.Credit = -amount
+	}
+	return append(lines, line)
+}
+
+func revenueAccountName(group ExportInvoiceLine) string {
+	name := strings.TrimSpace(strings.Join([]string{"revenue", group.CareType, group.FinancingAct}, " "))
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// ledgerVatRate returns the VAT rate of an invoice line, credit notes don't carry the
+// rate so it is derived from the totals
+func ledgerVatRate(vat, preVatTotal, total float64) float64 {
+	if vat > 0 {
+		return vat
+	}
+	if preVatTotal == 0 || total == preVatTotal {
+		return 0
+	}
+	return math.Round((total/preVatTotal - 1) * 100)
+}
+
+func relationCode(senderID *int64) string {
+	if senderID == nil {
+		return ""
+	}
+	return strconv.FormatInt(*senderID, 10)
+}
+
+func optionalString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func optionalRate(rate *float64) string {
+	if rate == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*rate, 'f', 2, 64)
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,160 @@
+package finance
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	db "maicare_go/db/sqlc"
+
+	"github.com/stretchr/testify/require"
+)
+
+func ledgerTestAccounts() []db.LedgerAccountMapping {
+	accommodation := "accommodation"
+	wlz := "WLZ"
+	high := 21.0
+	vatCode := "VH"
+	return []db.LedgerAccountMapping{
+		{AccountType: LedgerAccountDebtor, AccountCode: "1300"},
+		{AccountType: LedgerAccountBank, AccountCode: "1100"},
+		{AccountType: LedgerAccountRevenue, AccountCode: "8000"},
+		{AccountType: LedgerAccountRevenue, CareType: &accommodation, AccountCode: "8100"},
+		{AccountType: LedgerAccountRevenue, CareType: &accommodation, FinancingAct: &wlz, AccountCode: "8110"},
+		{AccountType: LedgerAccountVat, VatRate: &high, AccountCode: "1500", VatCode: &vatCode},
+	}
+}
+
+func requireBalanced(t *testing.T, lines []JournalLine) {
+	t.Helper()
+	totals := map[string]float64{}
+	for _, line := range lines {
+		totals[line.EntryNumber] = round2(totals[line.EntryNumber] + line.Debit - line.Credit)
+	}
+	for entry, total := range totals {
+		require.Zero(t, total, "entry %s doesn't balance", entry)
+	}
+}
+
+func TestBuildJournal(t *testing.T) {
+	senderID := int64(7)
+	invoices := []ExportInvoice{
+		{
+			InvoiceNumber: "INV1-2026-00001",
+			InvoiceType:   "standard",
+			IssueDate:     time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC),
+			TotalAmount:   3805.01,
+			SenderID:      &senderID,
+			SenderName:    "Zorgkantoor",
+			Lines: []ExportInvoiceLine{
+				{CareType: "accommodation", FinancingAct: "WLZ", PreVatTotal: 3000},
+				{CareType: "accommodation", FinancingAct: "JW", PreVatTotal: 200},
+				{CareType: "ambulante", FinancingAct: "WMO", PreVatTotal: 500, VatRate: 21, VatAmount: 105},
+			},
+		},
+		{
+			InvoiceNumber: "INV1-2026-00002",
+			InvoiceType:   "credit_note",
+			IssueDate:     time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC),
+			TotalAmount:   -605,
+			Lines: []ExportInvoiceLine{
+				{CareType: "ambulante", FinancingAct: "WMO", PreVatTotal: -500, VatRate: 21, VatAmount: -105},
+			},
+		},
+	}
+	payments := []ExportPayment{
+		{ID: 12, InvoiceNumber: "INV1-2026-00001", PaymentDate: time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC), Amount: 3000},
+	}
+
+	lines, err := BuildJournal(invoices, payments, ledgerTestAccounts(), "VRK", "BNK")
+	require.NoError(t, err)
+	requireBalanced(t, lines)
+	require.Len(t, lines, 10)
+
+	// the invoice: debtor against the most specific revenue accounts and VAT, the
+	// rounding difference lands on the first revenue line
+	require.Equal(t, JournalLine{
+		Journal: "VRK", EntryNumber: "INV1-2026-00001", Date: invoices[0].IssueDate, AccountCode: "1300",
+		Description: "Invoice INV1-2026-00001", Debit: 3805.01, RelationCode: "7", RelationName: "Zorgkantoor",
+	}, lines[0])
+	require.Equal(t, "8110", lines[1].AccountCode)
+	require.Equal(t, 3000.01, lines[1].Credit)
+	require.Equal(t, "8100", lines[2].AccountCode)
+	require.Equal(t, "8000", lines[3].AccountCode)
+	require.Equal(t, "VH", lines[3].VatCode)
+	require.Equal(t, "1500", lines[4].AccountCode)
+	require.Equal(t, 105.0, lines[4].Credit)
+
+	// the credit note reverses the sides
+	require.Equal(t, "1300", lines[5].AccountCode)
+	require.Equal(t, 605.0, lines[5].Credit)
+	require.Equal(t, 500.0, lines[6].Debit)
+	require.Equal(t, 105.0, lines[7].Debit)
+
+	// the payment goes through the bank journal
+	require.Equal(t, "BNK", lines[8].Journal)
+	require.Equal(t, "PAY-12", lines[8].EntryNumber)
+	require.Equal(t, "1100", lines[8].AccountCode)
+	require.Equal(t, 3000.0, lines[8].Debit)
+	require.Equal(t, "1300", lines[9].AccountCode)
+	require.Equal(t, 3000.0, lines[9].Credit)
+}
+
+func TestBuildJournalMissingAccounts(t *testing.T) {
+	invoices := []ExportInvoice{{
+		InvoiceNumber: "INV1-2026-00001",
+		TotalAmount:   109,
+		Lines:         []ExportInvoiceLine{{CareType: "ambulante", FinancingAct: "WMO", PreVatTotal: 100, VatRate: 9, VatAmount: 9}},
+	}}
+	accounts := ledgerTestAccounts()[:2]
+
+	_, err := BuildJournal(invoices, nil, accounts, "VRK", "BNK")
+	require.ErrorIs(t, err, ErrMissingLedgerAccount)
+	require.Contains(t, err.Error(), "revenue ambulante WMO, vat 9%")
+}
+
+func TestValidateLedgerAccounts(t *testing.T) {
+	care := "ambulante"
+	rate := 21.0
+	require.NoError(t, ValidateLedgerAccounts([]LedgerAccountRequest{
+		{AccountType: LedgerAccountRevenue, AccountCode: "8000"},
+		{AccountType: LedgerAccountRevenue, CareType: &care, AccountCode: "8200"},
+		{AccountType: LedgerAccountVat, VatRate: &rate, AccountCode: "1500"},
+	}))
+	require.ErrorIs(t, ValidateLedgerAccounts([]LedgerAccountRequest{
+		{AccountType: LedgerAccountDebtor, CareType: &care, AccountCode: "1300"},
+	}), ErrInvalidLedgerAccount)
+	require.ErrorIs(t, ValidateLedgerAccounts([]LedgerAccountRequest{
+		{AccountType: LedgerAccountRevenue, VatRate: &rate, AccountCode: "8000"},
+	}), ErrInvalidLedgerAccount)
+	require.ErrorIs(t, ValidateLedgerAccounts([]LedgerAccountRequest{
+		{AccountType: LedgerAccountRevenue, CareType: &care, AccountCode: "8000"},
+		{AccountType: LedgerAccountRevenue, CareType: &care, AccountCode: "8200"},
+	}), ErrInvalidLedgerAccount)
+}
+
+func TestRenderJournal(t *testing.T) {
+	lines := []JournalLine{
+		{Journal: "VRK", EntryNumber: "INV1-2026-00001", Date: time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), AccountCode: "1300", Description: "Invoice INV1-2026-00001", Debit: 1210.5, RelationCode: "7"},
+		{Journal: "VRK", EntryNumber: "INV1-2026-00001", Date: time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), AccountCode: "8000", Description: "Invoice INV1-2026-00001", Credit: 1210.5, VatCode: "VH", RelationCode: "7"},
+	}
+
+	data, err := RenderJournal(ExportFormatCSV, lines)
+	require.NoError(t, err)
+	rows := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, rows, 3)
+	require.Equal(t, "VRK,INV1-2026-00001,2026-01-31,1300,Invoice INV1-2026-00001,1210.50,0.00,,7,,,", rows[1])
+
+	data, err = RenderJournal(ExportFormatExactOnline, lines)
+	require.NoError(t, err)
+	rows = strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Equal(t, "VRK;31-01-2026;INV1-2026-00001;8000;Invoice INV1-2026-00001;7;;-1210.50;VH", rows[2])
+
+	data, err = RenderJournal(ExportFormatAFAS, lines)
+	require.NoError(t, err)
+	rows = strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Equal(t, "VRK;31-01-2026;INV1-2026-00001;1300;Invoice INV1-2026-00001;1210,50;0,00;;7", rows[1])
+
+	_, err = RenderJournal("xml", lines)
+	require.Error(t, err)
+}
@@ -0,0 +1,198 @@
+package invoice
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	db "maicare_go/db/sqlc"
+	"maicare_go/logger"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	approvalEventSubmit  = "SUBMIT"
+	approvalEventApprove = "APPROVE"
+	approvalEventReject  = "REJECT"
+)
+
+func (s *invoiceService) SubmitInvoiceForApproval(ctx context.Context, invoiceID int64, employeeID int64) (*InvoiceApprovalResponse, error) {
+	return s.changeApprovalStatus(ctx, invoiceID, employeeID, InvoiceStatusPendingApproval, approvalEventSubmit, "", nil)
+}
+
+func (s *invoiceService) ApproveInvoice(ctx context.Context, invoiceID int64, employeeID int64) (*InvoiceApprovalResponse, error) {
+	return s.changeApprovalStatus(ctx, invoiceID, employeeID, InvoiceStatusApproved, approvalEventApprove, "", s.checkInvoiceApprover)
+}
+
+func (s *invoiceService) RejectInvoice(ctx context.Context, invoiceID int64, req RejectInvoiceRequest, employeeID int64) (*InvoiceApprovalResponse, error) {
+	return s.changeApprovalStatus(ctx, invoiceID, employeeID, InvoiceStatusConcept, approvalEventReject, req.Reason, nil)
+}
+
+// changeApprovalStatus moves an invoice through the approval steps and records the
+// step as its own event in invoice_audit next to the row change of the audit trigger
+func (s *invoiceService) changeApprovalStatus(ctx context.Context, invoiceID int64, employeeID int64, to InvoiceStatus, event string, reason string,
+	check func(ctx context.Context, qtx *db.Queries, inv db.GetInvoiceRow, employeeID int64) error) (*InvoiceApprovalResponse, error) {
+	tx, err := s.Store.ConnPool.Begin(ctx)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "changeApprovalStatus", "Failed to begin transaction", zap.Int64("invoice_id", invoiceID), zap.Error(err))
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.Store.WithTx(tx)
+
+	_, err = tx.Exec(ctx, fmt.Sprintf("SET LOCAL myapp.current_employee_id = %d", employeeID))
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "changeApprovalStatus", "Failed to set current employee ID", zap.Int64("employee_id", employeeID), zap.Error(err))
+		return nil, err
+	}
+
+	inv, err := qtx.GetInvoice(ctx, invoiceID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "changeApprovalStatus", "Failed to get invoice", zap.Int64("invoice_id", invoiceID), zap.Error(err))
+		return nil, err
+	}
+
+	if inv.Status == string(to) {
+		return nil, fmt.Errorf("%w: invoice is already %s", ErrInvalidStatusTransition, inv.Status)
+	}
+	if err := ValidateStatusTransition(inv.Status, string(to)); err != nil {
+		return nil, err
+	}
+
+	if check != nil {
+		if err := check(ctx, qtx, inv, employeeID); err != nil {
+			return nil, err
+		}
+	}
+
+	updated, err := qtx.UpdateInvoiceStatus(ctx, db.UpdateInvoiceStatusParams{
+		ID:     invoiceID,
+		Status: string(to),
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "changeApprovalStatus", "Failed to update invoice status", zap.Int64("invoice_id", invoiceID), zap.String("status", string(to)), zap.Error(err))
+		return nil, err
+	}
+
+	oldValues, err := json.Marshal(map[string]string{"status": inv.Status})
+	if err != nil {
+		return nil, err
+	}
+	newValues := map[string]string{"status": updated.Status}
+	if reason != "" {
+		newValues["reason"] = reason
+	}
+	newValuesBytes, err := json.Marshal(newValues)
+	if err != nil {
+		return nil, err
+	}
+
+	audit, err := qtx.CreateInvoiceApprovalEvent(ctx, db.CreateInvoiceApprovalEventParams{
+		InvoiceID:     invoiceID,
+		Operation:     event,
+		ChangedBy:     &employeeID,
+		OldValues:     oldValues,
+		NewValues:     newValuesBytes,
+		ChangedFields: []string{"status"},
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "changeApprovalStatus", "Failed to record approval event", zap.Int64("invoice_id", invoiceID), zap.String("event", event), zap.Error(err))
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "changeApprovalStatus", "Failed to commit transaction", zap.Int64("invoice_id", invoiceID), zap.Error(err))
+		return nil, err
+	}
+
+	s.Logger.LogBusinessEvent(logger.LogLevelInfo, "changeApprovalStatus", "Invoice approval status changed",
+		zap.Int64("invoice_id", invoiceID), zap.String("event", event), zap.String("status", updated.Status), zap.Int64("employee_id", employeeID))
+
+	return &InvoiceApprovalResponse{
+		ID:            updated.ID,
+		InvoiceNumber: updated.InvoiceNumber,
+		Status:        updated.Status,
+		ChangedBy:     employeeID,
+		ChangedAt:     audit.ChangedAt.Time,
+	}, nil
+}
+
+// checkInvoiceApprover applies the four-eyes rule with the threshold of the
+// organisation that issues the invoice
+func (s *invoiceService) checkInvoiceApprover(ctx context.Context, qtx *db.Queries, inv db.GetInvoiceRow, employeeID int64) error {
+	actors, err := qtx.GetInvoiceApprovalActors(ctx, inv.ID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "checkInvoiceApprover", "Failed to get invoice approval actors", zap.Int64("invoice_id", inv.ID), zap.Error(err))
+		return err
+	}
+
+	threshold, err := s.invoiceApprovalThreshold(ctx, qtx, inv.ID)
+	if err != nil {
+		return err
+	}
+
+	return CheckFourEyes(employeeID, actors.CreatedBy, actors.SubmittedBy, inv.TotalAmount, threshold)
+}
+
+// invoiceApprovalThreshold returns zero, so every invoice needs a second employee,
+// when the organisation has no threshold configured
+func (s *invoiceService) invoiceApprovalThreshold(ctx context.Context, qtx *db.Queries, invoiceID int64) (float64, error) {
+	issuer, err := qtx.GetInvoiceIssuer(ctx, invoiceID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "invoiceApprovalThreshold", "Failed to get invoice issuer", zap.Int64("invoice_id", invoiceID), zap.Error(err))
+		return 0, err
+	}
+
+	settings, err := qtx.GetInvoiceApprovalSettings(ctx, issuer.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "invoiceApprovalThreshold", "Failed to get approval settings", zap.Int64("organisation_id", issuer.ID), zap.Error(err))
+		return 0, err
+	}
+	return settings.ApprovalThreshold, nil
+}
+
+func (s *invoiceService) GetInvoiceApprovalSettings(ctx context.Context, organisationID int64) (*InvoiceApprovalSettingsResponse, error) {
+	settings, err := s.Store.GetInvoiceApprovalSettings(ctx, organisationID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &InvoiceApprovalSettingsResponse{OrganisationID: organisationID}, nil
+	}
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "GetInvoiceApprovalSettings", "Failed to get approval settings", zap.Int64("organisation_id", organisationID), zap.Error(err))
+		return nil, err
+	}
+
+	return &InvoiceApprovalSettingsResponse{
+		OrganisationID:    settings.OrganisationID,
+		ApprovalThreshold: settings.ApprovalThreshold,
+		UpdatedBy:         settings.UpdatedBy,
+		UpdatedAt:         &settings.UpdatedAt.Time,
+	}, nil
+}
+
+func (s *invoiceService) UpdateInvoiceApprovalSettings(ctx context.Context, organisationID int64, req UpdateInvoiceApprovalSettingsRequest, employeeID int64) (*InvoiceApprovalSettingsResponse, error) {
+	settings, err := s.Store.UpsertInvoiceApprovalSettings(ctx, db.UpsertInvoiceApprovalSettingsParams{
+		OrganisationID:    organisationID,
+		ApprovalThreshold: req.ApprovalThreshold,
+		UpdatedBy:         &employeeID,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "UpdateInvoiceApprovalSettings", "Failed to update approval settings", zap.Int64("organisation_id", organisationID), zap.Error(err))
+		return nil, err
+	}
+
+	return &InvoiceApprovalSettingsResponse{
+		OrganisationID:    settings.OrganisationID,
+		ApprovalThreshold: settings.ApprovalThreshold,
+		UpdatedBy:         settings.UpdatedBy,
+		UpdatedAt:         &settings.UpdatedAt.Time,
+	}, nil
+}
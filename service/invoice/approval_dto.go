@@ -0,0 +1,30 @@
+package invoice
+
+import "time"
+
+// RejectInvoiceRequest sends an invoice back to concept
+type RejectInvoiceRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// InvoiceApprovalResponse is returned after an approval step
+type InvoiceApprovalResponse struct {
+	ID            int64     `json:"id"`
+	InvoiceNumber string    `json:"invoice_number"`
+	Status        string    `json:"status"`
+	ChangedBy     int64     `json:"changed_by"`
+	ChangedAt     time.Time `json:"changed_at"`
+}
+
+// UpdateInvoiceApprovalSettingsRequest sets the four-eyes threshold of an organisation
+type UpdateInvoiceApprovalSettingsRequest struct {
+	ApprovalThreshold float64 `json:"approval_threshold" binding:"min=0" example:"1000"`
+}
+
+// InvoiceApprovalSettingsResponse holds the four-eyes threshold of an organisation
+type InvoiceApprovalSettingsResponse struct {
+	OrganisationID    int64      `json:"organisation_id"`
+	ApprovalThreshold float64    `json:"approval_threshold"`
+	UpdatedBy         *int64     `json:"updated_by"`
+	UpdatedAt         *time.Time `json:"updated_at"`
+}
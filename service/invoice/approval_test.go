@@ -0,0 +1,54 @@
+package invoice
+
+import (
+	"context"
+	"testing"
+
+	db "maicare_go/db/sqlc"
+	"maicare_go/util"
+
+	"github.com/stretchr/testify/require"
+)
+
+// createTestEmployee creates an employee to act on invoices as
+func createTestEmployee(t *testing.T) db.EmployeeProfile {
+	ctx := context.Background()
+	user, err := testStore.CreateUser(ctx, db.CreateUserParams{
+		Password: util.RandomString(12),
+		Email:    util.RandomEmail(),
+		IsActive: true,
+	})
+	require.NoError(t, err)
+	employee, err := testStore.CreateEmployeeProfile(ctx, db.CreateEmployeeProfileParams{
+		UserID:    user.ID,
+		FirstName: util.RandomString(5),
+		LastName:  util.RandomString(5),
+		Email:     user.Email,
+	})
+	require.NoError(t, err)
+	return employee
+}
+
+func TestInvoiceApproval(t *testing.T) {
+	service, _, _ := newTestDeliveryService(t)
+	submitter := createTestEmployee(t)
+	approver := createTestEmployee(t)
+	inv := createDeliveryInvoice(t, InvoiceStatusConcept, nil)
+
+	submitted, err := service.SubmitInvoiceForApproval(context.Background(), inv.ID, submitter.ID)
+	require.NoError(t, err)
+	require.Equal(t, string(InvoiceStatusPendingApproval), submitted.Status)
+
+	// the employee who submitted the invoice cannot approve it as well
+	_, err = service.ApproveInvoice(context.Background(), inv.ID, submitter.ID)
+	require.ErrorIs(t, err, ErrFourEyesViolation)
+
+	approved, err := service.ApproveInvoice(context.Background(), inv.ID, approver.ID)
+	require.NoError(t, err)
+	require.Equal(t, string(InvoiceStatusApproved), approved.Status)
+	require.Equal(t, approver.ID, approved.ChangedBy)
+
+	actors, err := testStore.GetInvoiceApprovalActors(context.Background(), inv.ID)
+	require.NoError(t, err)
+	require.Equal(t, &submitter.ID, actors.SubmittedBy)
+}
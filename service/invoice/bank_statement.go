@@ -0,0 +1,405 @@
+package invoice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maicare_go/bankstatement"
+	db "maicare_go/db/sqlc"
+	"maicare_go/logger"
+	"maicare_go/pagination"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+const (
+	StatementLineMatched   = "matched"
+	StatementLineUnmatched = "unmatched"
+	StatementLineIgnored   = "ignored"
+
+	// invoice_payment_history.payment_reference is a VARCHAR(100)
+	maxPaymentReferenceLength = 100
+)
+
+var (
+	ErrStatementLineNotOpen = errors.New("bank statement line is already matched or ignored")
+	ErrInvoiceNotPayable    = errors.New("invoice is not open for payments")
+)
+
+func (s *invoiceService) ImportBankStatement(ctx context.Context, req ImportBankStatementRequest, employeeID int64) (*ImportBankStatementResponse, error) {
+	format := req.Format
+	if format == "" {
+		detected, err := bankstatement.DetectFormat(req.Content)
+		if err != nil {
+			return nil, err
+		}
+		format = detected
+	}
+
+	statement, err := bankstatement.Parse(req.Content, format)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ImportBankStatement", "Failed to parse bank statement", zap.String("file_name", req.FileName), zap.String("format", format), zap.Error(err))
+		return nil, err
+	}
+
+	openInvoices, err := s.Store.ListOpenInvoicesForMatching(ctx)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ImportBankStatement", "Failed to list open invoices", zap.Error(err))
+		return nil, err
+	}
+
+	candidates := make([]MatchCandidate, len(openInvoices))
+	for i, inv := range openInvoices {
+		candidates[i] = MatchCandidate{
+			InvoiceID:     inv.ID,
+			InvoiceNumber: inv.InvoiceNumber,
+			TotalAmount:   inv.TotalAmount - inv.CreditedAmount,
+			PaidAmount:    inv.PaidAmount,
+		}
+		if inv.SenderName != nil {
+			candidates[i].SenderName = *inv.SenderName
+		}
+	}
+
+	tx, err := s.Store.ConnPool.Begin(ctx)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ImportBankStatement", "Failed to begin transaction", zap.Error(err))
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.Store.WithTx(tx)
+
+	_, err = tx.Exec(ctx, fmt.Sprintf("SET LOCAL myapp.current_employee_id = %d", employeeID))
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ImportBankStatement", "Failed to set current employee ID", zap.Int64("employee_id", employeeID), zap.Error(err))
+		return nil, err
+	}
+
+	statementImport, err := qtx.CreateBankStatementImport(ctx, db.CreateBankStatementImportParams{
+		FileName:           req.FileName,
+		Format:             statement.Format,
+		AccountIban:        optionalString(statement.AccountIBAN),
+		StatementReference: optionalString(statement.Reference),
+		ImportedBy:         &employeeID,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ImportBankStatement", "Failed to create bank statement import", zap.String("file_name", req.FileName), zap.Error(err))
+		return nil, err
+	}
+
+	response := &ImportBankStatementResponse{
+		ImportID:           statementImport.ID,
+		Format:             statement.Format,
+		AccountIban:        statement.AccountIBAN,
+		StatementReference: statement.Reference,
+		Matches:            []BankStatementMatch{},
+	}
+
+	for _, entry := range statement.Entries {
+		if !entry.IsCredit() {
+			response.SkippedDebitLines++
+			continue
+		}
+
+		match := MatchStatementEntry(entry, candidates)
+		var suggestedInvoiceID *int64
+		if match != nil {
+			suggestedInvoiceID = &match.InvoiceID
+		}
+
+		line, err := qtx.CreateBankStatementLine(ctx, db.CreateBankStatementLineParams{
+			ImportID:           statementImport.ID,
+			LineHash:           entry.Hash(statement.AccountIBAN),
+			EntryReference:     optionalString(entry.Reference),
+			BookingDate:        pgtype.Date{Time: entry.BookingDate, Valid: true},
+			Amount:             entry.Amount,
+			Currency:           entry.Currency,
+			CounterpartyName:   optionalString(entry.CounterpartyName),
+			CounterpartyIban:   optionalString(entry.CounterpartyIBAN),
+			RemittanceInfo:     optionalString(entry.RemittanceInfo),
+			SuggestedInvoiceID: suggestedInvoiceID,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			// the line was part of an earlier import
+			response.DuplicateLines++
+			continue
+		}
+		if err != nil {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "ImportBankStatement", "Failed to create bank statement line", zap.Int64("import_id", statementImport.ID), zap.Error(err))
+			return nil, err
+		}
+		response.TotalLines++
+
+		if match == nil || !match.Confident {
+			response.UnmatchedLines++
+			continue
+		}
+
+		matched, err := s.applyStatementPayment(ctx, qtx, line, match.InvoiceID, match.Method, employeeID)
+		if err != nil {
+			return nil, err
+		}
+		response.MatchedLines++
+		response.Matches = append(response.Matches, *matched)
+
+		// the same invoice can't be matched on its full amount twice in one statement
+		for i := range candidates {
+			if candidates[i].InvoiceID == match.InvoiceID {
+				candidates[i].PaidAmount += line.Amount
+			}
+		}
+	}
+
+	_, err = qtx.UpdateBankStatementImportCounts(ctx, db.UpdateBankStatementImportCountsParams{
+		ID:           statementImport.ID,
+		TotalLines:   response.TotalLines,
+		MatchedLines: response.MatchedLines,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ImportBankStatement", "Failed to update import counts", zap.Int64("import_id", statementImport.ID), zap.Error(err))
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ImportBankStatement", "Failed to commit transaction", zap.Int64("import_id", statementImport.ID), zap.Error(err))
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// applyStatementPayment records the statement line as a completed bank transfer and
+// moves the invoice to the status that belongs to the new paid total
+func (s *invoiceService) applyStatementPayment(ctx context.Context, qtx *db.Queries, line db.BankStatementLine, invoiceID int64, method string, employeeID int64) (*BankStatementMatch, error) {
+	inv, err := qtx.GetInvoice(ctx, invoiceID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "applyStatementPayment", "Failed to get invoice", zap.Int64("invoice_id", invoiceID), zap.Error(err))
+		return nil, err
+	}
+
+	reference := line.EntryReference
+	if reference != nil && len(*reference) > maxPaymentReferenceLength {
+		truncated := (*reference)[:maxPaymentReferenceLength]
+		reference = &truncated
+	}
+	paymentMethod := string(PaymentMethodBankTransfer)
+	notes := fmt.Sprintf("Imported from bank statement (line %d)", line.ID)
+
+	payment, err := qtx.CreatePayment(ctx, db.CreatePaymentParams{
+		InvoiceID:        invoiceID,
+		PaymentMethod:    &paymentMethod,
+		PaymentStatus:    string(PaymentStatusCompleted),
+		Amount:           line.Amount,
+		PaymentDate:      line.BookingDate,
+		PaymentReference: reference,
+		Notes:            &notes,
+		RecordedBy:       &employeeID,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "applyStatementPayment", "Failed to create payment", zap.Int64("invoice_id", invoiceID), zap.Int64("line_id", line.ID), zap.Error(err))
+		return nil, err
+	}
+
+	totalPaid, err := qtx.GetTotalPaidAmountByInvoice(ctx, invoiceID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "applyStatementPayment", "Failed to get total paid amount", zap.Int64("invoice_id", invoiceID), zap.Error(err))
+		return nil, err
+	}
+
+	creditedAmount, err := qtx.GetInvoiceCreditedAmount(ctx, &inv.ID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "applyStatementPayment", "Failed to get credited amount", zap.Int64("invoice_id", invoiceID), zap.Error(err))
+		return nil, err
+	}
+
+	status, err := DetermineInvoiceStatus(inv.TotalAmount-creditedAmount, totalPaid)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "applyStatementPayment", "Failed to determine invoice status", zap.Int64("invoice_id", invoiceID), zap.Error(err))
+		return nil, err
+	}
+
+	if string(status) != inv.Status {
+		_, err = qtx.UpdateInvoiceStatus(ctx, db.UpdateInvoiceStatusParams{
+			ID:     invoiceID,
+			Status: string(status),
+		})
+		if err != nil {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "applyStatementPayment", "Failed to update invoice status", zap.Int64("invoice_id", invoiceID), zap.Error(err))
+			return nil, err
+		}
+	}
+
+	_, err = qtx.MatchBankStatementLine(ctx, db.MatchBankStatementLineParams{
+		ID:          line.ID,
+		MatchMethod: &method,
+		InvoiceID:   &invoiceID,
+		PaymentID:   &payment.ID,
+		ReviewedBy:  &employeeID,
+		ReviewedAt:  pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "applyStatementPayment", "Failed to match bank statement line", zap.Int64("line_id", line.ID), zap.Error(err))
+		return nil, err
+	}
+
+	return &BankStatementMatch{
+		LineID:        line.ID,
+		InvoiceID:     invoiceID,
+		InvoiceNumber: inv.InvoiceNumber,
+		PaymentID:     payment.ID,
+		Amount:        line.Amount,
+		MatchMethod:   method,
+		InvoiceStatus: string(status),
+	}, nil
+}
+
+func (s *invoiceService) ListBankStatementLines(ctx *gin.Context, req ListBankStatementLinesRequest) (*pagination.Response[BankStatementLineResponse], error) {
+	params := req.GetParams()
+
+	lines, err := s.Store.ListBankStatementLines(ctx, db.ListBankStatementLinesParams{
+		Status:   req.Status,
+		ImportID: req.ImportID,
+		Limit:    params.Limit,
+		Offset:   params.Offset,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ListBankStatementLines", "Failed to list bank statement lines", zap.Error(err))
+		return nil, err
+	}
+
+	if len(lines) == 0 {
+		pag := pagination.NewResponse(ctx, req.Request, []BankStatementLineResponse{}, 0)
+		return &pag, nil
+	}
+
+	totalCount := lines[0].TotalCount
+
+	linesRes := make([]BankStatementLineResponse, len(lines))
+	for i, line := range lines {
+		linesRes[i] = BankStatementLineResponse{
+			ID:                     line.ID,
+			ImportID:               line.ImportID,
+			EntryReference:         line.EntryReference,
+			BookingDate:            line.BookingDate.Time,
+			Amount:                 line.Amount,
+			Currency:               line.Currency,
+			CounterpartyName:       line.CounterpartyName,
+			CounterpartyIban:       line.CounterpartyIban,
+			RemittanceInfo:         line.RemittanceInfo,
+			Status:                 line.Status,
+			MatchMethod:            line.MatchMethod,
+			SuggestedInvoiceID:     line.SuggestedInvoiceID,
+			SuggestedInvoiceNumber: line.SuggestedInvoiceNumber,
+			InvoiceID:              line.InvoiceID,
+			InvoiceNumber:          line.InvoiceNumber,
+			PaymentID:              line.PaymentID,
+			ReviewNote:             line.ReviewNote,
+			ReviewedBy:             line.ReviewedBy,
+			CreatedAt:              line.CreatedAt.Time,
+		}
+		if line.ReviewedAt.Valid {
+			linesRes[i].ReviewedAt = &line.ReviewedAt.Time
+		}
+	}
+
+	pag := pagination.NewResponse(ctx, req.Request, linesRes, totalCount)
+	return &pag, nil
+}
+
+func (s *invoiceService) MatchBankStatementLine(ctx context.Context, lineID int64, req MatchBankStatementLineRequest, employeeID int64) (*BankStatementMatch, error) {
+	tx, err := s.Store.ConnPool.Begin(ctx)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "MatchBankStatementLine", "Failed to begin transaction", zap.Int64("line_id", lineID), zap.Error(err))
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.Store.WithTx(tx)
+
+	_, err = tx.Exec(ctx, fmt.Sprintf("SET LOCAL myapp.current_employee_id = %d", employeeID))
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "MatchBankStatementLine", "Failed to set current employee ID", zap.Int64("employee_id", employeeID), zap.Error(err))
+		return nil, err
+	}
+
+	line, err := qtx.GetBankStatementLine(ctx, lineID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "MatchBankStatementLine", "Failed to get bank statement line", zap.Int64("line_id", lineID), zap.Error(err))
+		return nil, err
+	}
+	if line.Status != StatementLineUnmatched {
+		return nil, ErrStatementLineNotOpen
+	}
+
+	inv, err := qtx.GetInvoice(ctx, req.InvoiceID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "MatchBankStatementLine", "Failed to get invoice", zap.Int64("invoice_id", req.InvoiceID), zap.Error(err))
+		return nil, err
+	}
+	if inv.InvoiceType != "standard" || !IsApproved(inv.Status) {
+		return nil, ErrInvoiceNotPayable
+	}
+
+	matched, err := s.applyStatementPayment(ctx, qtx, line, req.InvoiceID, MatchMethodManual, employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "MatchBankStatementLine", "Failed to commit transaction", zap.Int64("line_id", lineID), zap.Error(err))
+		return nil, err
+	}
+
+	return matched, nil
+}
+
+func (s *invoiceService) IgnoreBankStatementLine(ctx context.Context, lineID int64, req IgnoreBankStatementLineRequest, employeeID int64) (*BankStatementLineResponse, error) {
+	line, err := s.Store.IgnoreBankStatementLine(ctx, db.IgnoreBankStatementLineParams{
+		ID:         lineID,
+		ReviewNote: req.Note,
+		ReviewedBy: &employeeID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrStatementLineNotOpen
+	}
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "IgnoreBankStatementLine", "Failed to ignore bank statement line", zap.Int64("line_id", lineID), zap.Error(err))
+		return nil, err
+	}
+
+	response := &BankStatementLineResponse{
+		ID:                 line.ID,
+		ImportID:           line.ImportID,
+		EntryReference:     line.EntryReference,
+		BookingDate:        line.BookingDate.Time,
+		Amount:             line.Amount,
+		Currency:           line.Currency,
+		CounterpartyName:   line.CounterpartyName,
+		CounterpartyIban:   line.CounterpartyIban,
+		RemittanceInfo:     line.RemittanceInfo,
+		Status:             line.Status,
+		MatchMethod:        line.MatchMethod,
+		SuggestedInvoiceID: line.SuggestedInvoiceID,
+		InvoiceID:          line.InvoiceID,
+		PaymentID:          line.PaymentID,
+		ReviewNote:         line.ReviewNote,
+		ReviewedBy:         line.ReviewedBy,
+		CreatedAt:          line.CreatedAt.Time,
+	}
+	if line.ReviewedAt.Valid {
+		response.ReviewedAt = &line.ReviewedAt.Time
+	}
+	return response, nil
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,79 @@
+package invoice
+
+import (
+	"maicare_go/pagination"
+	"time"
+)
+
+// ImportBankStatementRequest holds an uploaded CAMT.053 or MT940 statement
+type ImportBankStatementRequest struct {
+	FileName string
+	Format   string
+	Content  []byte
+}
+
+// BankStatementMatch describes a statement line that was booked on an invoice
+type BankStatementMatch struct {
+	LineID        int64   `json:"line_id"`
+	InvoiceID     int64   `json:"invoice_id"`
+	InvoiceNumber string  `json:"invoice_number"`
+	PaymentID     int64   `json:"payment_id"`
+	Amount        float64 `json:"amount"`
+	MatchMethod   string  `json:"match_method"`
+	InvoiceStatus string  `json:"invoice_status"`
+}
+
+// ImportBankStatementResponse summarises the result of a statement import
+type ImportBankStatementResponse struct {
+	ImportID           int64                `json:"import_id"`
+	Format             string               `json:"format"`
+	AccountIban        string               `json:"account_iban"`
+	StatementReference string               `json:"statement_reference"`
+	TotalLines         int32                `json:"total_lines"`
+	MatchedLines       int32                `json:"matched_lines"`
+	UnmatchedLines     int32                `json:"unmatched_lines"`
+	DuplicateLines     int32                `json:"duplicate_lines"`
+	SkippedDebitLines  int32                `json:"skipped_debit_lines"`
+	Matches            []BankStatementMatch `json:"matches"`
+}
+
+// ListBankStatementLinesRequest defines the filters of the review queue
+type ListBankStatementLinesRequest struct {
+	pagination.Request
+	Status   *string `form:"status" binding:"omitempty,oneof=matched unmatched ignored"`
+	ImportID *int64  `form:"import_id"`
+}
+
+// BankStatementLineResponse represents a single imported statement line
+type BankStatementLineResponse struct {
+	ID                     int64      `json:"id"`
+	ImportID               int64      `json:"import_id"`
+	EntryReference         *string    `json:"entry_reference"`
+	BookingDate            time.Time  `json:"booking_date"`
+	Amount                 float64    `json:"amount"`
+	Currency               string     `json:"currency"`
+	CounterpartyName       *string    `json:"counterparty_name"`
+	CounterpartyIban       *string    `json:"counterparty_iban"`
+	RemittanceInfo         *string    `json:"remittance_info"`
+	Status                 string     `json:"status"`
+	MatchMethod            *string    `json:"match_method"`
+	SuggestedInvoiceID     *int64     `json:"suggested_invoice_id"`
+	SuggestedInvoiceNumber *string    `json:"suggested_invoice_number"`
+	InvoiceID              *int64     `json:"invoice_id"`
+	InvoiceNumber          *string    `json:"invoice_number"`
+	PaymentID              *int64     `json:"payment_id"`
+	ReviewNote             *string    `json:"review_note"`
+	ReviewedBy             *int64     `json:"reviewed_by"`
+	ReviewedAt             *time.Time `json:"reviewed_at"`
+	CreatedAt              time.Time  `json:"created_at"`
+}
+
+// MatchBankStatementLineRequest books an unmatched line on an invoice by hand
+type MatchBankStatementLineRequest struct {
+	InvoiceID int64 `json:"invoice_id" binding:"required"`
+}
+
+// IgnoreBankStatementLineRequest removes a line from the review queue
+type IgnoreBankStatementLineRequest struct {
+	Note *string `json:"note"`
+}
@@ -125,6 +125,34 @@ func CalculateAmbulanteInvoiceTotal(params AmbulanteInvoiceParams) (*AmbulanteIn
 	return nil, fmt.Errorf("unsupported price time unit: %s", params.PriceTimeUnit)
 }
 
+// IndicationMinutes returns the minutes of care the indication of a contract allows in
+// a billable period. Weekly hours are prorated over the days of the period, all_period
+// hours cover the whole contract. The second value is false when there is no indication.
+// Compare the result with IndicationUsage.
+func IndicationMinutes(hours *float64, hoursType *string, periodStart, periodEnd time.Time) (float64, bool) {
+	if hours == nil || *hours <= 0 || hoursType == nil {
+		return 0, false
+	}
+	switch *hoursType {
+	case "weekly":
+		days := math.Ceil(periodEnd.Sub(periodStart).Hours() / 24)
+		return *hours * 60 * days / 7, true
+	case "all_period":
+		return *hours * 60, true
+	}
+	return 0, false
+}
+
+// IndicationUsage returns the minutes to compare with the indication of a contract when a
+// period is billed. An all_period indication covers the whole contract, so everything
+// billed on it before counts as well.
+func IndicationUsage(hoursType *string, billedBefore, periodMinutes float64) float64 {
+	if hoursType != nil && *hoursType == "all_period" {
+		return billedBefore + periodMinutes
+	}
+	return periodMinutes
+}
+
 func VerifyTotalAmount(invoiceDetails []InvoiceDetails, totalAmount float64) (bool, error) {
 	var calculatedTotal float64
 
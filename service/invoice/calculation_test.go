@@ -0,0 +1,46 @@
+package invoice
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndicationMinutes(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	hours := 3.0
+	weekly := "weekly"
+	allPeriod := "all_period"
+
+	minutes, ok := IndicationMinutes(&hours, &weekly, start, end)
+	require.True(t, ok)
+	require.Equal(t, 360.0, minutes)
+
+	minutes, ok = IndicationMinutes(&hours, &allPeriod, start, end)
+	require.True(t, ok)
+	require.Equal(t, 180.0, minutes)
+
+	_, ok = IndicationMinutes(nil, &weekly, start, end)
+	require.False(t, ok)
+
+	zero := 0.0
+	_, ok = IndicationMinutes(&zero, &weekly, start, end)
+	require.False(t, ok)
+
+	_, ok = IndicationMinutes(&hours, nil, start, end)
+	require.False(t, ok)
+}
+
+func TestIndicationUsage(t *testing.T) {
+	weekly := "weekly"
+	allPeriod := "all_period"
+
+	// weekly indications only look at the period
+	require.Equal(t, 120.0, IndicationUsage(&weekly, 600, 120))
+	require.Equal(t, 120.0, IndicationUsage(nil, 600, 120))
+
+	// all_period indications add what was billed on the contract before
+	require.Equal(t, 720.0, IndicationUsage(&allPeriod, 600, 120))
+}
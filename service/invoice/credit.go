@@ -0,0 +1,371 @@
+package invoice
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	db "maicare_go/db/sqlc"
+	"maicare_go/logger"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrInvalidCreditRequest = errors.New("invalid credit note request")
+	ErrCreditExceedsInvoice = errors.New("credited amount exceeds the remaining amount of the invoice")
+	ErrNothingToCredit      = errors.New("invoice has already been fully credited")
+)
+
+// creditTolerance absorbs rounding differences when comparing credited amounts
+const creditTolerance = 0.005
+
+// BuildCreditNoteLines works out the lines of a credit note for the original invoice
+// lines, given the lines of the credit notes that were already created for it. Credit
+// note lines carry negative totals, a line can never be credited for more than what
+// is left of it.
+func BuildCreditNoteLines(original []InvoiceDetails, credited []InvoiceDetails, req CreditInvoiceRequest) ([]InvoiceDetails, error) {
+	if len(req.Lines) > 0 && req.Amount != nil {
+		return nil, fmt.Errorf("%w: credit either lines or an amount, not both", ErrInvalidCreditRequest)
+	}
+
+	remaining := make(map[int64]float64, len(original))
+	for _, line := range original {
+		remaining[line.ContractID] += line.Total
+	}
+	for _, line := range credited {
+		remaining[line.ContractID] += line.Total
+	}
+
+	var lines []InvoiceDetails
+	switch {
+	case len(req.Lines) > 0:
+		for _, lineReq := range req.Lines {
+			line, ok := findInvoiceLine(original, lineReq.ContractID)
+			if !ok {
+				return nil, fmt.Errorf("%w: contract %d is not part of the invoice", ErrInvalidCreditRequest, lineReq.ContractID)
+			}
+			creditLine, err := creditInvoiceLine(line, lineReq, remaining[line.ContractID])
+			if err != nil {
+				return nil, err
+			}
+			if -creditLine.Total > remaining[line.ContractID]+creditTolerance {
+				return nil, fmt.Errorf("%w: contract %d has %.2f left to credit", ErrCreditExceedsInvoice, line.ContractID, remaining[line.ContractID])
+			}
+			remaining[line.ContractID] += creditLine.Total
+			lines = append(lines, creditLine)
+		}
+	case req.Amount != nil:
+		left := round2(*req.Amount)
+		for _, line := range original {
+			if left <= 0 {
+				break
+			}
+			open := round2(remaining[line.ContractID])
+			if open <= 0 {
+				continue
+			}
+			amount := min(left, open)
+			lines = append(lines, creditLineAmount(line, amount))
+			remaining[line.ContractID] -= amount
+			left = round2(left - amount)
+		}
+		if left > 0 {
+			return nil, fmt.Errorf("%w: %.2f cannot be credited", ErrCreditExceedsInvoice, left)
+		}
+	default:
+		for _, line := range original {
+			open := round2(remaining[line.ContractID])
+			if open <= 0 {
+				continue
+			}
+			if open >= round2(line.Total) {
+				lines = append(lines, mirrorInvoiceLine(line))
+			} else {
+				lines = append(lines, creditLineAmount(line, open))
+			}
+			remaining[line.ContractID] = 0
+		}
+	}
+
+	if len(lines) == 0 {
+		return nil, ErrNothingToCredit
+	}
+	return lines, nil
+}
+
+// creditInvoiceLine credits a single line by amount, by periods or in full
+func creditInvoiceLine(line InvoiceDetails, req CreditLineRequest, remaining float64) (InvoiceDetails, error) {
+	if req.Amount != nil && len(req.Periods) > 0 {
+		return InvoiceDetails{}, fmt.Errorf("%w: credit either periods or an amount of contract %d, not both", ErrInvalidCreditRequest, line.ContractID)
+	}
+	if req.Amount != nil {
+		return creditLineAmount(line, round2(*req.Amount)), nil
+	}
+	if len(req.Periods) == 0 {
+		open := round2(remaining)
+		if open <= 0 {
+			return InvoiceDetails{}, fmt.Errorf("%w: contract %d has already been credited", ErrCreditExceedsInvoice, line.ContractID)
+		}
+		if open >= round2(line.Total) {
+			return mirrorInvoiceLine(line), nil
+		}
+		return creditLineAmount(line, open), nil
+	}
+
+	if line.ContractType != "accommodation" {
+		return InvoiceDetails{}, fmt.Errorf("%w: only accommodation lines can be credited by period", ErrInvalidCreditRequest)
+	}
+
+	creditLine := InvoiceDetails{
+		ContractID:    line.ContractID,
+		ContractType:  line.ContractType,
+		Vat:           line.Vat,
+		Price:         -line.Price,
+		PriceTimeUnit: line.PriceTimeUnit,
+	}
+	for _, period := range req.Periods {
+		if !periodWithinInvoiceLine(line, period) {
+			return InvoiceDetails{}, fmt.Errorf("%w: period %s - %s is not invoiced on contract %d", ErrInvalidCreditRequest,
+				period.StartDate.Format(time.DateOnly), period.EndDate.Format(time.DateOnly), line.ContractID)
+		}
+		totals, err := CalculateAccomodationInvoiceTotal(AccommodationInvoiceParams{
+			Price:               line.Price,
+			PriceTimeUnit:       line.PriceTimeUnit,
+			VAT:                 line.Vat,
+			BillablePeriodStart: period.StartDate,
+			BillablePeriodEnd:   period.EndDate,
+		})
+		if err != nil {
+			return InvoiceDetails{}, fmt.Errorf("%w: %v", ErrInvalidCreditRequest, err)
+		}
+		timeFrame := totals.TimeFrame
+		creditLine.Periods = append(creditLine.Periods, InvoicePeriod{
+			StartDate:             period.StartDate,
+			EndDate:               period.EndDate,
+			AcommodationTimeFrame: &timeFrame,
+		})
+		creditLine.PreVatTotal -= totals.PreVatTotal
+		creditLine.Total -= totals.Total
+	}
+	creditLine.PreVatTotal = round2(creditLine.PreVatTotal)
+	creditLine.Total = round2(creditLine.Total)
+	return creditLine, nil
+}
+
+// mirrorInvoiceLine negates a line that is credited completely
+func mirrorInvoiceLine(line InvoiceDetails) InvoiceDetails {
+	return InvoiceDetails{
+		ContractID:    line.ContractID,
+		ContractType:  line.ContractType,
+		Periods:       line.Periods,
+		PreVatTotal:   -line.PreVatTotal,
+		Total:         -line.Total,
+		Vat:           line.Vat,
+		Price:         -line.Price,
+		PriceTimeUnit: line.PriceTimeUnit,
+	}
+}
+
+// creditLineAmount credits a fixed amount including VAT of a line
+func creditLineAmount(line InvoiceDetails, amount float64) InvoiceDetails {
+	return InvoiceDetails{
+		ContractID:    line.ContractID,
+		ContractType:  line.ContractType,
+		PreVatTotal:   -round2(amount / (1 + line.Vat/100)),
+		Total:         -amount,
+		Vat:           line.Vat,
+		Price:         -line.Price,
+		PriceTimeUnit: line.PriceTimeUnit,
+	}
+}
+
+func findInvoiceLine(lines []InvoiceDetails, contractID int64) (InvoiceDetails, bool) {
+	for _, line := range lines {
+		if line.ContractID == contractID {
+			return line, true
+		}
+	}
+	return InvoiceDetails{}, false
+}
+
+func periodWithinInvoiceLine(line InvoiceDetails, period CreditPeriod) bool {
+	if period.EndDate.Before(period.StartDate) {
+		return false
+	}
+	for _, invoiced := range line.Periods {
+		if !period.StartDate.Before(invoiced.StartDate) && !period.EndDate.After(invoiced.EndDate) {
+			return true
+		}
+	}
+	return false
+}
+
+// CreditedInvoiceStatus returns the status of an invoice once credit notes lowered its
+// open balance. A fully credited invoice without payments is canceled, otherwise the
+// payments are compared to what is left of the invoice. Invoices without payments keep
+// their approved or expired status.
+func CreditedInvoiceStatus(current string, total, credited, paid float64) (InvoiceStatus, error) {
+	open := round2(total - credited)
+	if open <= 0 && paid <= PAYMENT_TOLERANCE {
+		return InvoiceStatusCanceled, nil
+	}
+
+	status, err := DetermineInvoiceStatus(open, paid)
+	if err != nil {
+		return "", err
+	}
+	if status == InvoiceStatusOutstanding {
+		switch InvoiceStatus(current) {
+		case InvoiceStatusApproved, InvoiceStatusExpired:
+			return InvoiceStatus(current), nil
+		}
+	}
+	return status, nil
+}
+
+func (s *invoiceService) CreditInvoice(ctx context.Context, invoiceID int64, req CreditInvoiceRequest, employeeID int64) (*CreditInvoiceResponse, error) {
+	tx, err := s.Store.ConnPool.Begin(ctx)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CreditInvoice", "Failed to begin transaction", zap.Int64("invoice_id", invoiceID), zap.Error(err))
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.Store.WithTx(tx)
+
+	_, err = tx.Exec(ctx, fmt.Sprintf("SET LOCAL myapp.current_employee_id = %d", employeeID))
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CreditInvoice", "Failed to set current employee ID", zap.Int64("employee_id", employeeID), zap.Error(err))
+		return nil, err
+	}
+
+	inv, err := qtx.GetInvoice(ctx, invoiceID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CreditInvoice", "Failed to get invoice", zap.Int64("invoice_id", invoiceID), zap.Error(err))
+		return nil, err
+	}
+	if inv.InvoiceType == "credit_note" {
+		return nil, fmt.Errorf("%w: invoice %s is a credit note", ErrInvalidCreditRequest, inv.InvoiceNumber)
+	}
+	if !IsApproved(inv.Status) {
+		return nil, ErrInvoiceNotApproved
+	}
+
+	var original []InvoiceDetails
+	if err := json.Unmarshal(inv.InvoiceDetails, &original); err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CreditInvoice", "Failed to unmarshal invoice details", zap.Int64("invoice_id", invoiceID), zap.Error(err))
+		return nil, err
+	}
+
+	creditNotes, err := qtx.ListInvoiceCreditNotes(ctx, &inv.ID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CreditInvoice", "Failed to list credit notes", zap.Int64("invoice_id", invoiceID), zap.Error(err))
+		return nil, err
+	}
+	var credited []InvoiceDetails
+	var creditedAmount float64
+	for _, note := range creditNotes {
+		var noteLines []InvoiceDetails
+		if err := json.Unmarshal(note.InvoiceDetails, &noteLines); err != nil {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "CreditInvoice", "Failed to unmarshal credit note details", zap.Int64("credit_note_id", note.ID), zap.Error(err))
+			return nil, err
+		}
+		credited = append(credited, noteLines...)
+		creditedAmount -= note.TotalAmount
+	}
+
+	lines, err := BuildCreditNoteLines(original, credited, req)
+	if err != nil {
+		return nil, err
+	}
+	var creditTotal float64
+	for _, line := range lines {
+		creditTotal += line.Total
+	}
+	creditTotal = round2(creditTotal)
+	if -creditTotal > round2(inv.TotalAmount-creditedAmount)+creditTolerance {
+		return nil, fmt.Errorf("%w: %.2f left to credit", ErrCreditExceedsInvoice, inv.TotalAmount-creditedAmount)
+	}
+	creditedAmount -= creditTotal
+
+	detailsBytes, err := json.Marshal(lines)
+	if err != nil {
+		return nil, err
+	}
+	extraContent := []byte("{}")
+	if req.Reason != "" {
+		extraContent, err = json.Marshal(map[string]string{"reason": req.Reason})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	invoiceNumber, invoiceSequence, err := AllocateInvoiceNumber(ctx, qtx, inv.ClientID, now)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CreditInvoice", "Failed to generate invoice number", zap.Error(err))
+		return nil, err
+	}
+
+	creditNote, err := qtx.CreateCreditNote(ctx, db.CreateCreditNoteParams{
+		InvoiceNumber:     invoiceNumber,
+		InvoiceSequence:   invoiceSequence,
+		DueDate:           pgtype.Date{Time: now.AddDate(0, 0, 30), Valid: true},
+		IssueDate:         pgtype.Date{Time: now, Valid: true},
+		InvoiceDetails:    detailsBytes,
+		TotalAmount:       creditTotal,
+		ExtraContent:      extraContent,
+		ClientID:          inv.ClientID,
+		SenderID:          inv.SenderID,
+		OriginalInvoiceID: &inv.ID,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CreditInvoice", "Failed to create credit note", zap.Int64("invoice_id", invoiceID), zap.Error(err))
+		return nil, err
+	}
+
+	totalPaid, err := qtx.GetTotalPaidAmountByInvoice(ctx, inv.ID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CreditInvoice", "Failed to get total paid amount", zap.Int64("invoice_id", invoiceID), zap.Error(err))
+		return nil, err
+	}
+	status, err := CreditedInvoiceStatus(inv.Status, inv.TotalAmount, creditedAmount, totalPaid)
+	if err != nil {
+		return nil, err
+	}
+	if string(status) != inv.Status {
+		if err := ValidateStatusTransition(inv.Status, string(status)); err != nil {
+			return nil, err
+		}
+		_, err = qtx.UpdateInvoiceStatus(ctx, db.UpdateInvoiceStatusParams{
+			ID:     inv.ID,
+			Status: string(status),
+		})
+		if err != nil {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "CreditInvoice", "Failed to update invoice status", zap.Int64("invoice_id", invoiceID), zap.String("status", string(status)), zap.Error(err))
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CreditInvoice", "Failed to commit transaction", zap.Int64("invoice_id", invoiceID), zap.Error(err))
+		return nil, err
+	}
+
+	s.Logger.LogBusinessEvent(logger.LogLevelInfo, "CreditInvoice", "Credit note created",
+		zap.Int64("invoice_id", invoiceID), zap.Int64("credit_note_id", creditNote.ID), zap.Float64("total_amount", creditTotal), zap.Int64("employee_id", employeeID))
+
+	return &CreditInvoiceResponse{
+		ID:                creditNote.ID,
+		InvoiceNumber:     creditNote.InvoiceNumber,
+		TotalAmount:       creditNote.TotalAmount,
+		InvoiceDetails:    lines,
+		OriginalInvoiceID: inv.ID,
+		OriginalStatus:    string(status),
+		CreditedAmount:    round2(creditedAmount),
+		OpenBalance:       round2(inv.TotalAmount - creditedAmount - totalPaid),
+	}, nil
+}
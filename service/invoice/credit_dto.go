@@ -0,0 +1,37 @@
+package invoice
+
+import "time"
+
+// CreditPeriod selects days of an accommodation period to credit
+type CreditPeriod struct {
+	StartDate time.Time `json:"start_date" binding:"required"`
+	EndDate   time.Time `json:"end_date" binding:"required"`
+}
+
+// CreditLineRequest credits a single contract line of the invoice. Without periods or
+// an amount the remaining amount of the line is credited.
+type CreditLineRequest struct {
+	ContractID int64          `json:"contract_id" binding:"required"`
+	Periods    []CreditPeriod `json:"periods"`
+	Amount     *float64       `json:"amount" binding:"omitempty,gt=0"`
+}
+
+// CreditInvoiceRequest selects what to credit. An empty request credits everything that
+// was not credited yet, an amount without lines is spread over the lines in order.
+type CreditInvoiceRequest struct {
+	Lines  []CreditLineRequest `json:"lines" binding:"omitempty,dive"`
+	Amount *float64            `json:"amount" binding:"omitempty,gt=0"`
+	Reason string              `json:"reason"`
+}
+
+// CreditInvoiceResponse is returned after a credit note was created
+type CreditInvoiceResponse struct {
+	ID                int64            `json:"id"`
+	InvoiceNumber     string           `json:"invoice_number"`
+	TotalAmount       float64          `json:"total_amount"`
+	InvoiceDetails    []InvoiceDetails `json:"invoice_details"`
+	OriginalInvoiceID int64            `json:"original_invoice_id"`
+	OriginalStatus    string           `json:"original_status"`
+	CreditedAmount    float64          `json:"credited_amount"`
+	OpenBalance       float64          `json:"open_balance"`
+}
@@ -0,0 +1,166 @@
+package invoice
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func creditTestLines() []InvoiceDetails {
+	return []InvoiceDetails{
+		{
+			ContractID:    1,
+			ContractType:  "accommodation",
+			Price:         100,
+			PriceTimeUnit: "daily",
+			PreVatTotal:   3000,
+			Total:         3000,
+			Periods: []InvoicePeriod{
+				{StartDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+		{
+			ContractID:    2,
+			ContractType:  "ambulante",
+			Price:         60,
+			PriceTimeUnit: "hourly",
+			PreVatTotal:   500,
+			Total:         605,
+			Vat:           21,
+		},
+	}
+}
+
+func creditTotal(lines []InvoiceDetails) float64 {
+	var total float64
+	for _, line := range lines {
+		total += line.Total
+	}
+	return round2(total)
+}
+
+func TestBuildCreditNoteLinesFull(t *testing.T) {
+	lines, err := BuildCreditNoteLines(creditTestLines(), nil, CreditInvoiceRequest{})
+	require.NoError(t, err)
+	require.Len(t, lines, 2)
+	require.Equal(t, -3605.0, creditTotal(lines))
+	require.Len(t, lines[0].Periods, 1)
+
+	// a second full credit only credits what is left
+	amount := 605.0
+	credited, err := BuildCreditNoteLines(creditTestLines(), nil, CreditInvoiceRequest{Amount: &amount})
+	require.NoError(t, err)
+	lines, err = BuildCreditNoteLines(creditTestLines(), credited, CreditInvoiceRequest{})
+	require.NoError(t, err)
+	require.Equal(t, -3000.0, creditTotal(lines))
+
+	_, err = BuildCreditNoteLines(creditTestLines(), append(credited, lines...), CreditInvoiceRequest{})
+	require.ErrorIs(t, err, ErrNothingToCredit)
+}
+
+func TestBuildCreditNoteLinesByLine(t *testing.T) {
+	lines, err := BuildCreditNoteLines(creditTestLines(), nil, CreditInvoiceRequest{
+		Lines: []CreditLineRequest{{ContractID: 2}},
+	})
+	require.NoError(t, err)
+	require.Len(t, lines, 1)
+	require.Equal(t, int64(2), lines[0].ContractID)
+	require.Equal(t, -605.0, lines[0].Total)
+	require.Equal(t, -500.0, lines[0].PreVatTotal)
+
+	_, err = BuildCreditNoteLines(creditTestLines(), lines, CreditInvoiceRequest{
+		Lines: []CreditLineRequest{{ContractID: 2}},
+	})
+	require.ErrorIs(t, err, ErrCreditExceedsInvoice)
+
+	_, err = BuildCreditNoteLines(creditTestLines(), nil, CreditInvoiceRequest{
+		Lines: []CreditLineRequest{{ContractID: 3}},
+	})
+	require.ErrorIs(t, err, ErrInvalidCreditRequest)
+}
+
+func TestBuildCreditNoteLinesByPeriod(t *testing.T) {
+	lines, err := BuildCreditNoteLines(creditTestLines(), nil, CreditInvoiceRequest{
+		Lines: []CreditLineRequest{{
+			ContractID: 1,
+			Periods: []CreditPeriod{
+				{StartDate: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)},
+			},
+		}},
+	})
+	require.NoError(t, err)
+	require.Len(t, lines, 1)
+	require.Equal(t, -300.0, lines[0].Total)
+	require.Len(t, lines[0].Periods, 1)
+	require.Equal(t, "3 days", *lines[0].Periods[0].AcommodationTimeFrame)
+
+	testCases := []struct {
+		name string
+		line CreditLineRequest
+	}{
+		{
+			name: "period outside the invoice",
+			line: CreditLineRequest{ContractID: 1, Periods: []CreditPeriod{
+				{StartDate: time.Date(2025, 1, 25, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 2, 5, 0, 0, 0, 0, time.UTC)},
+			}},
+		},
+		{
+			name: "period on ambulante line",
+			line: CreditLineRequest{ContractID: 2, Periods: []CreditPeriod{
+				{StartDate: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)},
+			}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := BuildCreditNoteLines(creditTestLines(), nil, CreditInvoiceRequest{Lines: []CreditLineRequest{tc.line}})
+			require.ErrorIs(t, err, ErrInvalidCreditRequest)
+		})
+	}
+}
+
+func TestBuildCreditNoteLinesByAmount(t *testing.T) {
+	amount := 3100.0
+	lines, err := BuildCreditNoteLines(creditTestLines(), nil, CreditInvoiceRequest{Amount: &amount})
+	require.NoError(t, err)
+	require.Len(t, lines, 2)
+	require.Equal(t, -3000.0, lines[0].Total)
+	require.Equal(t, -100.0, lines[1].Total)
+	require.InDelta(t, -82.64, lines[1].PreVatTotal, 0.001)
+
+	amount = 4000
+	_, err = BuildCreditNoteLines(creditTestLines(), nil, CreditInvoiceRequest{Amount: &amount})
+	require.ErrorIs(t, err, ErrCreditExceedsInvoice)
+
+	lineAmount := 700.0
+	_, err = BuildCreditNoteLines(creditTestLines(), nil, CreditInvoiceRequest{
+		Lines: []CreditLineRequest{{ContractID: 2, Amount: &lineAmount}},
+	})
+	require.ErrorIs(t, err, ErrCreditExceedsInvoice)
+}
+
+func TestCreditedInvoiceStatus(t *testing.T) {
+	testCases := []struct {
+		name     string
+		current  InvoiceStatus
+		total    float64
+		credited float64
+		paid     float64
+		expected InvoiceStatus
+	}{
+		{"fully credited", InvoiceStatusOutstanding, 1000, 1000, 0, InvoiceStatusCanceled},
+		{"partially credited without payments", InvoiceStatusApproved, 1000, 400, 0, InvoiceStatusApproved},
+		{"credited down to the paid amount", InvoiceStatusPartiallyPaid, 1000, 400, 600, InvoiceStatusPaid},
+		{"credited below the paid amount", InvoiceStatusPaid, 1000, 400, 1000, InvoiceStatusOverpaid},
+		{"fully credited after payment", InvoiceStatusPaid, 1000, 1000, 1000, InvoiceStatusOverpaid},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, err := CreditedInvoiceStatus(string(tc.current), tc.total, tc.credited, tc.paid)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, status)
+		})
+	}
+}
@@ -23,11 +23,14 @@ const (
 type InvoiceStatus string
 
 const (
-	InvoiceStatusOutstanding   InvoiceStatus = "outstanding"
-	InvoiceStatusPartiallyPaid InvoiceStatus = "partially_paid"
-	InvoiceStatusPaid          InvoiceStatus = "paid"
-	InvoiceStatusExpired       InvoiceStatus = "expired"
-	InvoiceStatusOverpaid      InvoiceStatus = "overpaid"
-	InvoiceStatusImported      InvoiceStatus = "imported"
-	InvoiceStatusConcept       InvoiceStatus = "concept"
+	InvoiceStatusOutstanding     InvoiceStatus = "outstanding"
+	InvoiceStatusPartiallyPaid   InvoiceStatus = "partially_paid"
+	InvoiceStatusPaid            InvoiceStatus = "paid"
+	InvoiceStatusExpired         InvoiceStatus = "expired"
+	InvoiceStatusOverpaid        InvoiceStatus = "overpaid"
+	InvoiceStatusImported        InvoiceStatus = "imported"
+	InvoiceStatusConcept         InvoiceStatus = "concept"
+	InvoiceStatusPendingApproval InvoiceStatus = "pending_approval"
+	InvoiceStatusApproved        InvoiceStatus = "approved"
+	InvoiceStatusCanceled        InvoiceStatus = "canceled"
 )
@@ -0,0 +1,272 @@
+package invoice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maicare_go/async/aclient"
+	db "maicare_go/db/sqlc"
+	"maicare_go/logger"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+const (
+	DeliveryStatusQueued = "queued"
+	DeliveryStatusSent   = "sent"
+	DeliveryStatusFailed = "failed"
+)
+
+var ErrNoInvoiceRecipients = errors.New("sender of the invoice has no email address")
+
+// SendInvoice emails an approved invoice to its sender right away
+func (s *invoiceService) SendInvoice(ctx context.Context, invoiceID int64, employeeID int64) (*InvoiceDeliveryResponse, error) {
+	return s.sendInvoiceNow(ctx, invoiceID, false, &employeeID)
+}
+
+func (s *invoiceService) sendInvoiceNow(ctx context.Context, invoiceID int64, isReminder bool, sentBy *int64) (*InvoiceDeliveryResponse, error) {
+	delivery, err := s.queueInvoiceDelivery(ctx, invoiceID, nil, isReminder, sentBy)
+	if err != nil {
+		return nil, err
+	}
+	return s.processDelivery(ctx, delivery)
+}
+
+// SendInvoiceBatch queues the invoices of a run, the deliveries are sent by the worker
+func (s *invoiceService) SendInvoiceBatch(ctx context.Context, req SendInvoiceBatchRequest, employeeID int64) (*SendInvoiceBatchResponse, error) {
+	if s.AsynqClient == nil {
+		return nil, errors.New("task queue is not configured")
+	}
+
+	invoiceIDs := req.InvoiceIDs
+	if len(invoiceIDs) == 0 {
+		ids, err := s.Store.ListApprovedInvoicesForDelivery(ctx, db.ListApprovedInvoicesForDeliveryParams{
+			StartDate: pgtype.Date{Time: req.StartDate, Valid: true},
+			EndDate:   pgtype.Date{Time: req.EndDate, Valid: true},
+		})
+		if err != nil {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "SendInvoiceBatch", "Failed to list approved invoices", zap.Error(err))
+			return nil, err
+		}
+		invoiceIDs = ids
+	}
+
+	response := &SendInvoiceBatchResponse{
+		BatchID: uuid.New(),
+		Skipped: []SkippedInvoiceDelivery{},
+	}
+
+	for _, invoiceID := range invoiceIDs {
+		delivery, err := s.queueInvoiceDelivery(ctx, invoiceID, &response.BatchID, false, &employeeID)
+		if err != nil {
+			response.Skipped = append(response.Skipped, SkippedInvoiceDelivery{InvoiceID: invoiceID, Reason: err.Error()})
+			continue
+		}
+
+		err = s.AsynqClient.EnqueueInvoiceDelivery(ctx, aclient.InvoiceDeliveryPayload{DeliveryID: delivery.ID})
+		if err != nil {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "SendInvoiceBatch", "Failed to enqueue invoice delivery", zap.Int64("delivery_id", delivery.ID), zap.Error(err))
+			s.failDelivery(ctx, delivery.ID, err)
+			response.Skipped = append(response.Skipped, SkippedInvoiceDelivery{InvoiceID: invoiceID, Reason: err.Error()})
+			continue
+		}
+		response.Queued++
+	}
+
+	s.Logger.LogBusinessEvent(logger.LogLevelInfo, "SendInvoiceBatch", "Queued invoice batch",
+		zap.String("batch_id", response.BatchID.String()), zap.Int("queued", response.Queued), zap.Int("skipped", len(response.Skipped)))
+	return response, nil
+}
+
+// ProcessInvoiceDelivery sends a queued delivery, it is called by the worker
+func (s *invoiceService) ProcessInvoiceDelivery(ctx context.Context, deliveryID int64) (*InvoiceDeliveryResponse, error) {
+	delivery, err := s.Store.GetInvoiceDelivery(ctx, deliveryID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ProcessInvoiceDelivery", "Failed to get invoice delivery", zap.Int64("delivery_id", deliveryID), zap.Error(err))
+		return nil, err
+	}
+	if delivery.Status != DeliveryStatusQueued {
+		return nil, fmt.Errorf("invoice delivery %d is already %s", deliveryID, delivery.Status)
+	}
+	return s.processDelivery(ctx, delivery)
+}
+
+func (s *invoiceService) ListInvoiceDeliveries(ctx context.Context, invoiceID int64) ([]InvoiceDeliveryResponse, error) {
+	deliveries, err := s.Store.ListInvoiceDeliveries(ctx, invoiceID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ListInvoiceDeliveries", "Failed to list invoice deliveries", zap.Int64("invoice_id", invoiceID), zap.Error(err))
+		return nil, err
+	}
+
+	response := make([]InvoiceDeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		response[i] = newInvoiceDeliveryResponse(delivery)
+	}
+	return response, nil
+}
+
+func (s *invoiceService) GetInvoiceDeliveryBatch(ctx context.Context, batchID uuid.UUID) (*InvoiceDeliveryBatchResponse, error) {
+	deliveries, err := s.Store.ListInvoiceDeliveriesByBatch(ctx, batchID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "GetInvoiceDeliveryBatch", "Failed to list batch deliveries", zap.String("batch_id", batchID.String()), zap.Error(err))
+		return nil, err
+	}
+
+	response := &InvoiceDeliveryBatchResponse{
+		BatchID:    batchID,
+		Total:      len(deliveries),
+		Deliveries: make([]InvoiceDeliveryResponse, len(deliveries)),
+	}
+	for i, delivery := range deliveries {
+		response.Deliveries[i] = newInvoiceDeliveryResponse(db.InvoiceDelivery{
+			ID:           delivery.ID,
+			InvoiceID:    delivery.InvoiceID,
+			BatchID:      delivery.BatchID,
+			Recipients:   delivery.Recipients,
+			IsReminder:   delivery.IsReminder,
+			Status:       delivery.Status,
+			MessageID:    delivery.MessageID,
+			ErrorMessage: delivery.ErrorMessage,
+			SentBy:       delivery.SentBy,
+			SentAt:       delivery.SentAt,
+			CreatedAt:    delivery.CreatedAt,
+		})
+		response.Deliveries[i].InvoiceNumber = delivery.InvoiceNumber
+
+		switch delivery.Status {
+		case DeliveryStatusQueued:
+			response.Queued++
+		case DeliveryStatusSent:
+			response.Sent++
+		case DeliveryStatusFailed:
+			response.Failed++
+		}
+	}
+	return response, nil
+}
+
+// queueInvoiceDelivery records a send attempt for an approved invoice
+func (s *invoiceService) queueInvoiceDelivery(ctx context.Context, invoiceID int64, batchID *uuid.UUID, isReminder bool, sentBy *int64) (db.InvoiceDelivery, error) {
+	inv, err := s.Store.GetInvoice(ctx, invoiceID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "queueInvoiceDelivery", "Failed to get invoice", zap.Int64("invoice_id", invoiceID), zap.Error(err))
+		return db.InvoiceDelivery{}, fmt.Errorf("failed to get invoice: %w", err)
+	}
+	if !IsApproved(inv.Status) {
+		return db.InvoiceDelivery{}, ErrInvoiceNotApproved
+	}
+
+	delivery, err := s.Store.CreateInvoiceDelivery(ctx, db.CreateInvoiceDeliveryParams{
+		InvoiceID:  invoiceID,
+		BatchID:    batchID,
+		Recipients: invoiceRecipients(inv),
+		IsReminder: isReminder,
+		SentBy:     sentBy,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "queueInvoiceDelivery", "Failed to create invoice delivery", zap.Int64("invoice_id", invoiceID), zap.Error(err))
+		return db.InvoiceDelivery{}, err
+	}
+	return delivery, nil
+}
+
+// processDelivery emails the invoice and records the outcome. The first successful
+// delivery of an approved invoice makes it outstanding.
+func (s *invoiceService) processDelivery(ctx context.Context, delivery db.InvoiceDelivery) (*InvoiceDeliveryResponse, error) {
+	inv, err := s.Store.GetInvoice(ctx, delivery.InvoiceID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "processDelivery", "Failed to get invoice", zap.Int64("invoice_id", delivery.InvoiceID), zap.Error(err))
+		s.failDelivery(ctx, delivery.ID, err)
+		return nil, err
+	}
+	if !IsApproved(inv.Status) {
+		s.failDelivery(ctx, delivery.ID, ErrInvoiceNotApproved)
+		return nil, ErrInvoiceNotApproved
+	}
+
+	messageID, err := s.emailInvoice(ctx, inv, delivery.Recipients, delivery.IsReminder)
+	if err != nil {
+		s.failDelivery(ctx, delivery.ID, err)
+		return nil, err
+	}
+
+	tx, err := s.Store.ConnPool.Begin(ctx)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "processDelivery", "Failed to begin transaction", zap.Int64("delivery_id", delivery.ID), zap.Error(err))
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.Store.WithTx(tx)
+
+	if delivery.SentBy != nil {
+		_, err = tx.Exec(ctx, fmt.Sprintf("SET LOCAL myapp.current_employee_id = %d", *delivery.SentBy))
+		if err != nil {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "processDelivery", "Failed to set current employee ID", zap.Int64("employee_id", *delivery.SentBy), zap.Error(err))
+			return nil, err
+		}
+	}
+
+	sent, err := qtx.UpdateInvoiceDeliveryStatus(ctx, db.UpdateInvoiceDeliveryStatusParams{
+		ID:        delivery.ID,
+		Status:    DeliveryStatusSent,
+		MessageID: &messageID,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "processDelivery", "Failed to update invoice delivery", zap.Int64("delivery_id", delivery.ID), zap.Error(err))
+		return nil, err
+	}
+
+	if !delivery.IsReminder && inv.Status == string(InvoiceStatusApproved) {
+		_, err = qtx.UpdateInvoiceStatus(ctx, db.UpdateInvoiceStatusParams{
+			ID:     inv.ID,
+			Status: string(InvoiceStatusOutstanding),
+		})
+		if err != nil {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "processDelivery", "Failed to update invoice status", zap.Int64("invoice_id", inv.ID), zap.Error(err))
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "processDelivery", "Failed to commit transaction", zap.Int64("delivery_id", delivery.ID), zap.Error(err))
+		return nil, err
+	}
+
+	response := newInvoiceDeliveryResponse(sent)
+	response.InvoiceNumber = inv.InvoiceNumber
+	return &response, nil
+}
+
+func (s *invoiceService) failDelivery(ctx context.Context, deliveryID int64, cause error) {
+	message := cause.Error()
+	_, err := s.Store.UpdateInvoiceDeliveryStatus(ctx, db.UpdateInvoiceDeliveryStatusParams{
+		ID:           deliveryID,
+		Status:       DeliveryStatusFailed,
+		ErrorMessage: &message,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "failDelivery", "Failed to record failed invoice delivery", zap.Int64("delivery_id", deliveryID), zap.Error(err))
+	}
+}
+
+func newInvoiceDeliveryResponse(delivery db.InvoiceDelivery) InvoiceDeliveryResponse {
+	response := InvoiceDeliveryResponse{
+		ID:           delivery.ID,
+		InvoiceID:    delivery.InvoiceID,
+		BatchID:      delivery.BatchID,
+		Recipients:   delivery.Recipients,
+		IsReminder:   delivery.IsReminder,
+		Status:       delivery.Status,
+		MessageID:    delivery.MessageID,
+		ErrorMessage: delivery.ErrorMessage,
+		SentBy:       delivery.SentBy,
+		CreatedAt:    delivery.CreatedAt.Time,
+	}
+	if delivery.SentAt.Valid {
+		response.SentAt = &delivery.SentAt.Time
+	}
+	return response
+}
@@ -0,0 +1,54 @@
+package invoice
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SendInvoiceBatchRequest sends the invoices of a run, either the given invoices or
+// all approved invoices issued in the period that were not sent yet
+type SendInvoiceBatchRequest struct {
+	InvoiceIDs []int64   `json:"invoice_ids"`
+	StartDate  time.Time `json:"start_date" binding:"required_without=InvoiceIDs"`
+	EndDate    time.Time `json:"end_date" binding:"required_without=InvoiceIDs"`
+}
+
+// InvoiceDeliveryResponse represents a single attempt to email an invoice
+type InvoiceDeliveryResponse struct {
+	ID            int64      `json:"id"`
+	InvoiceID     int64      `json:"invoice_id"`
+	InvoiceNumber string     `json:"invoice_number,omitempty"`
+	BatchID       *uuid.UUID `json:"batch_id"`
+	Recipients    []string   `json:"recipients"`
+	IsReminder    bool       `json:"is_reminder"`
+	Status        string     `json:"status"`
+	MessageID     *string    `json:"message_id"`
+	ErrorMessage  *string    `json:"error_message"`
+	SentBy        *int64     `json:"sent_by"`
+	SentAt        *time.Time `json:"sent_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// SkippedInvoiceDelivery is an invoice of a batch that could not be queued
+type SkippedInvoiceDelivery struct {
+	InvoiceID int64  `json:"invoice_id"`
+	Reason    string `json:"reason"`
+}
+
+// SendInvoiceBatchResponse is returned when the invoices of a run are queued
+type SendInvoiceBatchResponse struct {
+	BatchID uuid.UUID                `json:"batch_id"`
+	Queued  int                      `json:"queued"`
+	Skipped []SkippedInvoiceDelivery `json:"skipped"`
+}
+
+// InvoiceDeliveryBatchResponse reports the progress of a batch
+type InvoiceDeliveryBatchResponse struct {
+	BatchID    uuid.UUID                 `json:"batch_id"`
+	Total      int                       `json:"total"`
+	Queued     int                       `json:"queued"`
+	Sent       int                       `json:"sent"`
+	Failed     int                       `json:"failed"`
+	Deliveries []InvoiceDeliveryResponse `json:"deliveries"`
+}
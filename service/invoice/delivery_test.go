@@ -0,0 +1,175 @@
+package invoice
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	asyncmocks "maicare_go/async/aclient/mocks"
+	db "maicare_go/db/sqlc"
+	"maicare_go/email"
+	emailmocks "maicare_go/email/mocks"
+	"maicare_go/service/deps"
+	"maicare_go/util"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestDeliveryService(t *testing.T) (*invoiceService, *emailmocks.MockMailerInterface, *asyncmocks.MockAsynqClientInterface) {
+	ctrl := gomock.NewController(t)
+	mailer := emailmocks.NewMockMailerInterface(ctrl)
+	asynqClient := asyncmocks.NewMockAsynqClientInterface(ctrl)
+	return &invoiceService{ServiceDependencies: &deps.ServiceDependencies{
+		Store:       testStore,
+		Logger:      testLogger,
+		Config:      &testConfig,
+		AsynqClient: asynqClient,
+		Mailer:      mailer,
+	}}, mailer, asynqClient
+}
+
+// createDeliveryInvoice creates an invoice for a sender that receives its invoices at senderEmail
+func createDeliveryInvoice(t *testing.T, status InvoiceStatus, senderEmail *string) db.Invoice {
+	ctx := context.Background()
+	organisation, err := testStore.CreateOrganisation(ctx, db.CreateOrganisationParams{
+		Name:       util.RandomString(5),
+		Address:    "Oudegracht 1",
+		PostalCode: "3511AA",
+		City:       "Utrecht",
+	})
+	require.NoError(t, err)
+	location, err := testStore.CreateLocation(ctx, db.CreateLocationParams{
+		OrganisationID: organisation.ID,
+		Name:           util.RandomString(5),
+		Address:        util.RandomString(8),
+	})
+	require.NoError(t, err)
+	sender, err := testStore.CreateSender(ctx, db.CreateSenderParams{
+		Types:        "main_provider",
+		Name:         util.RandomString(5),
+		EmailAddress: senderEmail,
+		Contacts:     []byte(`[]`),
+	})
+	require.NoError(t, err)
+	client, err := testStore.CreateClientDetails(ctx, db.CreateClientDetailsParams{
+		FirstName:   util.RandomString(5),
+		LastName:    util.RandomString(5),
+		Email:       util.RandomEmail(),
+		DateOfBirth: pgtype.Date{Time: time.Now().AddDate(-20, 0, 0), Valid: true},
+		Gender:      "male",
+		Filenumber:  util.RandomString(6),
+		SenderID:    &sender.ID,
+		LocationID:  &location.ID,
+		Addresses:   []byte("[]"),
+	})
+	require.NoError(t, err)
+
+	inv, err := testStore.CreateInvoice(ctx, db.CreateInvoiceParams{
+		InvoiceNumber:  "TEST-" + util.RandomString(10),
+		IssueDate:      pgtype.Date{Time: time.Now(), Valid: true},
+		DueDate:        pgtype.Date{Time: time.Now().AddDate(0, 0, 30), Valid: true},
+		InvoiceDetails: []byte(`[]`),
+		TotalAmount:    1210,
+		ClientID:       client.ID,
+		SenderID:       &sender.ID,
+		InvoiceType:    "standard",
+	})
+	require.NoError(t, err)
+	inv, err = testStore.UpdateInvoiceStatus(ctx, db.UpdateInvoiceStatusParams{ID: inv.ID, Status: string(status)})
+	require.NoError(t, err)
+	return inv
+}
+
+func TestSendInvoice(t *testing.T) {
+	service, mailer, _ := newTestDeliveryService(t)
+	inv := createDeliveryInvoice(t, InvoiceStatusApproved, util.StringPtr("facturen@zorgkantoor.example"))
+
+	mailer.EXPECT().
+		SendInvoice(gomock.Any(), []string{"facturen@zorgkantoor.example"}, gomock.Any(), gomock.Len(2)).
+		DoAndReturn(func(_ context.Context, _ []string, data email.InvoiceEmail, _ []email.Attachment) (string, error) {
+			require.Equal(t, inv.InvoiceNumber, data.InvoiceNumber)
+			require.False(t, data.IsReminder)
+			return "<message-1@brevo>", nil
+		})
+
+	delivery, err := service.sendInvoiceNow(context.Background(), inv.ID, false, nil)
+	require.NoError(t, err)
+	require.Equal(t, DeliveryStatusSent, delivery.Status)
+	require.Equal(t, "<message-1@brevo>", *delivery.MessageID)
+	require.NotNil(t, delivery.SentAt)
+
+	// the first delivery makes the invoice outstanding
+	sent, err := testStore.GetInvoice(context.Background(), inv.ID)
+	require.NoError(t, err)
+	require.Equal(t, string(InvoiceStatusOutstanding), sent.Status)
+}
+
+func TestSendInvoiceFailures(t *testing.T) {
+	service, mailer, _ := newTestDeliveryService(t)
+
+	// invoices are only sent once they are approved
+	concept := createDeliveryInvoice(t, InvoiceStatusConcept, util.StringPtr("facturen@zorgkantoor.example"))
+	_, err := service.sendInvoiceNow(context.Background(), concept.ID, false, nil)
+	require.ErrorIs(t, err, ErrInvoiceNotApproved)
+
+	// a sender without an address fails the delivery without calling the mailer
+	inv := createDeliveryInvoice(t, InvoiceStatusApproved, nil)
+	_, err = service.sendInvoiceNow(context.Background(), inv.ID, false, nil)
+	require.ErrorIs(t, err, ErrNoInvoiceRecipients)
+
+	deliveries, err := service.ListInvoiceDeliveries(context.Background(), inv.ID)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	require.Equal(t, DeliveryStatusFailed, deliveries[0].Status)
+
+	// a rejected email is recorded and the invoice stays approved
+	inv = createDeliveryInvoice(t, InvoiceStatusApproved, util.StringPtr("facturen@zorgkantoor.example"))
+	mailer.EXPECT().SendInvoice(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return("", errors.New("mailbox unavailable"))
+	_, err = service.sendInvoiceNow(context.Background(), inv.ID, false, nil)
+	require.Error(t, err)
+
+	deliveries, err = service.ListInvoiceDeliveries(context.Background(), inv.ID)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	require.Equal(t, DeliveryStatusFailed, deliveries[0].Status)
+	require.Equal(t, "mailbox unavailable", *deliveries[0].ErrorMessage)
+
+	unchanged, err := testStore.GetInvoice(context.Background(), inv.ID)
+	require.NoError(t, err)
+	require.Equal(t, string(InvoiceStatusApproved), unchanged.Status)
+}
+
+func TestSendInvoiceBatch(t *testing.T) {
+	service, mailer, asynqClient := newTestDeliveryService(t)
+	approved := createDeliveryInvoice(t, InvoiceStatusApproved, util.StringPtr("facturen@gemeente.example"))
+	concept := createDeliveryInvoice(t, InvoiceStatusConcept, util.StringPtr("facturen@gemeente.example"))
+
+	asynqClient.EXPECT().EnqueueInvoiceDelivery(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+	batch, err := service.SendInvoiceBatch(context.Background(), SendInvoiceBatchRequest{InvoiceIDs: []int64{approved.ID, concept.ID}}, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, batch.Queued)
+	require.Len(t, batch.Skipped, 1)
+	require.Equal(t, concept.ID, batch.Skipped[0].InvoiceID)
+
+	progress, err := service.GetInvoiceDeliveryBatch(context.Background(), batch.BatchID)
+	require.NoError(t, err)
+	require.Equal(t, 1, progress.Total)
+	require.Equal(t, 1, progress.Queued)
+
+	// the worker sends the queued delivery once
+	mailer.EXPECT().SendInvoice(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return("<message-2@brevo>", nil)
+	delivery, err := service.ProcessInvoiceDelivery(context.Background(), progress.Deliveries[0].ID)
+	require.NoError(t, err)
+	require.Equal(t, DeliveryStatusSent, delivery.Status)
+
+	_, err = service.ProcessInvoiceDelivery(context.Background(), progress.Deliveries[0].ID)
+	require.Error(t, err)
+
+	progress, err = service.GetInvoiceDeliveryBatch(context.Background(), batch.BatchID)
+	require.NoError(t, err)
+	require.Equal(t, 1, progress.Sent)
+	require.Zero(t, progress.Queued)
+}
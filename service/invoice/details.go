@@ -23,7 +23,13 @@ func (s *invoiceService) GetInvoiceByID(ctx context.Context, invoiceID int64) (*
 		return nil, fmt.Errorf("failed to unmarshal invoice details")
 	}
 
-	paymentCompletionPrc := s.calculatePaymentCompletionPercentage(ctx, inv.TotalAmount, invoiceID)
+	creditedAmount, err := s.Store.GetInvoiceCreditedAmount(ctx, &inv.ID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "GetInvoiceByID", "Failed to get credited amount", zap.Error(err), zap.Int64("invoice_id", invoiceID))
+		return nil, err
+	}
+
+	paymentCompletionPrc := s.calculatePaymentCompletionPercentage(ctx, inv.TotalAmount-creditedAmount, invoiceID)
 
 	return &GetInvoiceByIDResponse{
 		ID:                   inv.ID,
@@ -47,5 +53,6 @@ func (s *invoiceService) GetInvoiceByID(ctx context.Context, invoiceID int64) (*
 		ClientFirstName:      inv.ClientFirstName,
 		ClientLastName:       inv.ClientLastName,
 		PaymentCompletionPrc: paymentCompletionPrc,
+		CreditedAmount:       creditedAmount,
 	}, nil
 }
@@ -30,4 +30,5 @@ type GetInvoiceByIDResponse struct {
 	ClientFirstName      string           `json:"client_first_name"`
 	ClientLastName       string           `json:"client_last_name"`
 	PaymentCompletionPrc float64          `json:"payment_completion_prc"`
+	CreditedAmount       float64          `json:"credited_amount"`
 }
@@ -0,0 +1,248 @@
+package invoice
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	db "maicare_go/db/sqlc"
+	"maicare_go/logger"
+	"maicare_go/pdf"
+	"maicare_go/util"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// ExportInvoice renders an invoice in the requested format (pdf or ubl)
+func (s *invoiceService) ExportInvoice(ctx context.Context, invoiceID int64, format string) (*InvoiceDocument, error) {
+	if format != InvoiceFormatPDF && format != InvoiceFormatUBL {
+		return nil, fmt.Errorf("unsupported invoice format: %s", format)
+	}
+
+	inv, err := s.Store.GetInvoice(ctx, invoiceID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ExportInvoice", "Failed to get invoice", zap.Error(err), zap.Int64("invoice_id", invoiceID))
+		return nil, fmt.Errorf("failed to get invoice: %w", err)
+	}
+	if !IsApproved(inv.Status) {
+		return nil, ErrInvoiceNotApproved
+	}
+
+	pdfBytes, err := s.renderInvoicePDF(ctx, inv)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ExportInvoice", "Failed to render invoice PDF", zap.Error(err), zap.Int64("invoice_id", invoiceID))
+		return nil, err
+	}
+	if format == InvoiceFormatPDF {
+		return &InvoiceDocument{
+			FileName:    invoiceFileName(inv.InvoiceNumber, "pdf"),
+			ContentType: "application/pdf",
+			Content:     pdfBytes,
+		}, nil
+	}
+
+	ublBytes, err := s.renderInvoiceUBL(ctx, inv, pdfBytes)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ExportInvoice", "Failed to render invoice UBL", zap.Error(err), zap.Int64("invoice_id", invoiceID))
+		return nil, err
+	}
+	return &InvoiceDocument{
+		FileName:    invoiceFileName(inv.InvoiceNumber, "xml"),
+		ContentType: "application/xml",
+		Content:     ublBytes,
+	}, nil
+}
+
+func invoiceFileName(invoiceNumber, extension string) string {
+	return fmt.Sprintf("Invoice_%s.%s", invoiceNumber, extension)
+}
+
+func parseSenderContacts(raw []byte) []senderContact {
+	var contacts []senderContact
+	if len(raw) == 0 {
+		return contacts
+	}
+	if err := json.Unmarshal(raw, &contacts); err != nil {
+		return nil
+	}
+	return contacts
+}
+
+// invoiceRecipients collects the email addresses of the sender the invoice is addressed to
+func invoiceRecipients(inv db.GetInvoiceRow) []string {
+	seen := make(map[string]bool)
+	var recipients []string
+	add := func(address *string) {
+		if address == nil {
+			return
+		}
+		value := strings.TrimSpace(*address)
+		if value == "" || seen[strings.ToLower(value)] {
+			return
+		}
+		seen[strings.ToLower(value)] = true
+		recipients = append(recipients, value)
+	}
+
+	add(inv.SenderEmailAddress)
+	for _, contact := range parseSenderContacts(inv.SenderContacts) {
+		add(contact.Email)
+	}
+	return recipients
+}
+
+func (s *invoiceService) renderInvoicePDF(ctx context.Context, inv db.GetInvoiceRow) ([]byte, error) {
+	var invoiceDetails []InvoiceDetails
+	if err := json.Unmarshal(inv.InvoiceDetails, &invoiceDetails); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal invoice details: %w", err)
+	}
+
+	pdfInvoiceDetails := make([]pdf.InvoiceDetail, 0, len(invoiceDetails))
+	for _, detail := range invoiceDetails {
+		periods := make([]pdf.InvoicePeriod, 0, len(detail.Periods))
+		for _, period := range detail.Periods {
+			periods = append(periods, pdf.InvoicePeriod{
+				StartDate:             period.StartDate,
+				EndDate:               period.EndDate,
+				AcommodationTimeFrame: util.DerefString(period.AcommodationTimeFrame),
+				AmbulanteTotalMinutes: util.DerefFloat64(period.AmbulanteTotalMinutes),
+			})
+		}
+		pdfInvoiceDetails = append(pdfInvoiceDetails, pdf.InvoiceDetail{
+			CareType:      detail.ContractType,
+			Price:         detail.Price,
+			PriceTimeUnit: detail.PriceTimeUnit,
+			PreVatTotal:   detail.PreVatTotal,
+			Total:         detail.Total,
+			Periods:       periods,
+		})
+	}
+
+	var extraItems map[string]string
+	if len(inv.ExtraContent) > 0 {
+		if err := json.Unmarshal(inv.ExtraContent, &extraItems); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal extra content: %w", err)
+		}
+	}
+
+	var extraItemOrder []string
+	if inv.SenderID != nil && len(extraItems) > 0 {
+		labels, err := s.Store.GetSenderTemplateLabels(ctx, *inv.SenderID)
+		if err != nil {
+			// the items are still printed, only in label order
+			s.Logger.LogBusinessEvent(logger.LogLevelWarn, "RenderInvoicePDF", "Failed to get sender invoice template",
+				zap.Error(err), zap.Int64("invoice_id", inv.ID))
+		}
+		extraItemOrder = labels
+	}
+
+	var contactPerson string
+	if contacts := parseSenderContacts(inv.SenderContacts); len(contacts) > 0 {
+		contactPerson = util.DerefString(contacts[0].Name)
+	}
+
+	file, err := pdf.GenerateInvoicePDF(pdf.InvoicePDFData{
+		ID:                   inv.ID,
+		SenderName:           util.DerefString(inv.SenderName),
+		SenderContactPerson:  contactPerson,
+		SenderAddressLine1:   util.DerefString(inv.SenderAddress),
+		SenderPostalCodeCity: strings.TrimSpace(util.DerefString(inv.SenderPostalCode) + " " + util.DerefString(inv.SenderPlace)),
+		InvoiceNumber:        inv.InvoiceNumber,
+		InvoiceDate:          inv.IssueDate.Time,
+		DueDate:              inv.DueDate.Time,
+		InvoiceDetails:       pdfInvoiceDetails,
+		TotalAmount:          inv.TotalAmount,
+		ExtraItems:           extraItems,
+		ExtraItemOrder:       extraItemOrder,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate invoice PDF: %w", err)
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read invoice PDF: %w", err)
+	}
+	return content, nil
+}
+
+func (s *invoiceService) renderInvoiceUBL(ctx context.Context, inv db.GetInvoiceRow, pdfBytes []byte) ([]byte, error) {
+	var invoiceDetails []InvoiceDetails
+	if err := json.Unmarshal(inv.InvoiceDetails, &invoiceDetails); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal invoice details: %w", err)
+	}
+
+	issuer, err := s.Store.GetInvoiceIssuer(ctx, inv.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invoice issuer: %w", err)
+	}
+
+	var originalInvoiceNumber string
+	if inv.OriginalInvoiceID != nil {
+		original, err := s.Store.GetInvoice(ctx, *inv.OriginalInvoiceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get original invoice: %w", err)
+		}
+		originalInvoiceNumber = original.InvoiceNumber
+	}
+
+	var customerEmail, customerPhone string
+	if recipients := invoiceRecipients(inv); len(recipients) > 0 {
+		customerEmail = recipients[0]
+	}
+	if contacts := parseSenderContacts(inv.SenderContacts); len(contacts) > 0 {
+		customerPhone = util.DerefString(contacts[0].PhoneNumber)
+	}
+
+	return GenerateUBL(UBLInput{
+		InvoiceNumber:         inv.InvoiceNumber,
+		InvoiceType:           inv.InvoiceType,
+		IssueDate:             inv.IssueDate.Time,
+		DueDate:               inv.DueDate.Time,
+		OriginalInvoiceNumber: originalInvoiceNumber,
+		Supplier: UBLParty{
+			Name:       issuer.Name,
+			Street:     issuer.Address,
+			City:       issuer.City,
+			PostalCode: issuer.PostalCode,
+			KvkNumber:  util.DerefString(issuer.KvkNumber),
+			BtwNumber:  util.DerefString(issuer.BtwNumber),
+			Email:      util.DerefString(issuer.Email),
+			Phone:      util.DerefString(issuer.PhoneNumber),
+		},
+		SupplierIBAN: util.DerefString(issuer.Iban),
+		Customer: UBLParty{
+			Name:        util.DerefString(inv.SenderName),
+			Street:      util.DerefString(inv.SenderAddress),
+			City:        util.DerefString(inv.SenderPlace),
+			PostalCode:  util.DerefString(inv.SenderPostalCode),
+			CountryCode: ublCountryCode(inv.SenderLand),
+			KvkNumber:   util.DerefString(inv.SenderKvknumber),
+			BtwNumber:   util.DerefString(inv.SenderBtwnumber),
+			Email:       customerEmail,
+			Phone:       customerPhone,
+		},
+		Lines:       invoiceDetails,
+		PDF:         pdfBytes,
+		PDFFileName: invoiceFileName(inv.InvoiceNumber, "pdf"),
+	})
+}
+
+// ublCountryCode maps the free text land of a sender onto an ISO 3166 code
+func ublCountryCode(land *string) string {
+	value := strings.ToUpper(strings.TrimSpace(util.DerefString(land)))
+	switch value {
+	case "", "NL", "NEDERLAND", "NETHERLANDS", "THE NETHERLANDS":
+		return ublCountryNL
+	case "BELGIE", "BELGIË", "BELGIUM":
+		return "BE"
+	case "DUITSLAND", "GERMANY", "DEUTSCHLAND":
+		return "DE"
+	}
+	if len(value) == 2 {
+		return value
+	}
+	return ublCountryNL
+}
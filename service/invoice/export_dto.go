@@ -0,0 +1,20 @@
+package invoice
+
+// Supported download formats for an invoice
+const (
+	InvoiceFormatPDF = "pdf"
+	InvoiceFormatUBL = "ubl"
+)
+
+// InvoiceDocument is a rendered invoice file ready to be downloaded or attached to an email
+type InvoiceDocument struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+type senderContact struct {
+	Name        *string `json:"name"`
+	Email       *string `json:"email"`
+	PhoneNumber *string `json:"phone_number"`
+}
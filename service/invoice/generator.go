@@ -53,22 +53,6 @@ type InvoicePeriod struct {
 	AmbulanteTotalMinutes *float64  `json:"ambulante_total_minutes,omitempty"`
 }
 
-func (s *invoiceService) GenerateInvoiceNumber(ctx context.Context) (string, int64, error) {
-	now := time.Now()
-	datePart := now.Format("20060102") // YYYYMMDD
-
-	// Get the maximum sequence number for today
-	maxSeq, err := s.Store.GetMaxInvoiceSequenceForDate(ctx, now)
-	if err != nil {
-		return "", 0, fmt.Errorf("failed to get max sequence: %w", err)
-	}
-
-	nextSeq := maxSeq + 1
-	invoiceNumber := fmt.Sprintf("INV-%s-%04d", datePart, nextSeq)
-
-	return invoiceNumber, nextSeq, nil
-}
-
 type GenerateInvoiceRequest struct {
 	ClientID  int64
 	StartDate time.Time
@@ -175,6 +159,17 @@ func (s *invoiceService) GenerateInvoice(req GenerateInvoiceRequest, ctx context
 			warningCount++
 		}
 
+		// all_period indications are checked against everything billed on the contract
+		var billedMinutes float64
+		if contract.CareType == "ambulante" && contract.HoursType != nil && *contract.HoursType == "all_period" {
+			billedMinutes, err = s.Store.GetContractBilledMinutes(ctx, contract.ID)
+			if err != nil {
+				invoice[i].Warnings = append(invoice[i].Warnings,
+					fmt.Sprintf("failed to get the minutes billed before on contract %d: %v", contract.ID, err))
+				warningCount++
+			}
+		}
+
 		for _, period := range billablePeriods {
 			var periodItem InvoicePeriod
 			periodItem.StartDate = period.BillableStart.Time
@@ -232,6 +227,14 @@ func (s *invoiceService) GenerateInvoice(req GenerateInvoiceRequest, ctx context
 					totalMinutes += duration.Minutes()
 				}
 
+				usage := IndicationUsage(contract.HoursType, billedMinutes, totalMinutes)
+				billedMinutes += totalMinutes
+				if indication, ok := IndicationMinutes(contract.Hours, contract.HoursType, period.BillableStart.Time, period.BillableEnd.Time); ok && usage > indication {
+					invoice[i].Warnings = append(invoice[i].Warnings,
+						fmt.Sprintf("billed %.0f minutes for contract %d exceed the indication of %.0f minutes", usage, contract.ID, indication))
+					warningCount++
+				}
+
 				totals, err := CalculateAmbulanteInvoiceTotal(AmbulanteInvoiceParams{
 					Price:         contract.Price,
 					PriceTimeUnit: contract.PriceTimeUnit,
@@ -263,18 +266,9 @@ func (s *invoiceService) GenerateInvoice(req GenerateInvoiceRequest, ctx context
 	}
 
 	invoiceDate := time.Now()
-	invoiceNumber, invoiceSequence, err := s.GenerateInvoiceNumber(ctx)
-	if err != nil {
-		s.Logger.LogBusinessEvent(logger.LogLevelError, "GenerateInvoice", "Failed to generate invoice number",
-			zap.Int64("client_id", req.ClientID), zap.String("error", err.Error()))
-		return nil, 0, fmt.Errorf("failed to generate invoice number: %w", err)
-	}
-
 	finalInvoice := InvoiceData{
 		ClientID: req.ClientID,
 		// SenderID:          *contracts[0].SenderID,
-		InvoiceNumber:   invoiceNumber,
-		InvoiceSequence: invoiceSequence,
 		// InvoiceDate:       invoiceDate,
 		InvoiceDetails: invoice,
 		TotalAmount:    totalAmount,
@@ -308,7 +302,25 @@ func (s *invoiceService) GenerateInvoice(req GenerateInvoiceRequest, ctx context
 		return nil, 0, fmt.Errorf("failed to marshal extra content: %v", err)
 	}
 
-	createdInv, err := s.Store.CreateInvoice(ctx, db.CreateInvoiceParams{
+	// the number series stays locked until the invoice is committed
+	tx, err := s.Store.ConnPool.Begin(ctx)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "GenerateInvoice", "Failed to begin transaction",
+			zap.Int64("client_id", req.ClientID), zap.String("error", err.Error()))
+		return nil, 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.Store.WithTx(tx)
+
+	finalInvoice.InvoiceNumber, finalInvoice.InvoiceSequence, err = AllocateInvoiceNumber(ctx, qtx, req.ClientID, invoiceDate)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "GenerateInvoice", "Failed to generate invoice number",
+			zap.Int64("client_id", req.ClientID), zap.String("error", err.Error()))
+		return nil, 0, fmt.Errorf("failed to generate invoice number: %w", err)
+	}
+
+	createdInv, err := qtx.CreateInvoice(ctx, db.CreateInvoiceParams{
 		ClientID:        finalInvoice.ClientID,
 		SenderID:        &clientSender.ID,
 		DueDate:         pgtype.Date{Time: time.Now().Add(30 * 24 * time.Hour), Valid: true},
@@ -327,6 +339,12 @@ func (s *invoiceService) GenerateInvoice(req GenerateInvoiceRequest, ctx context
 		return nil, 0, fmt.Errorf("failed to create invoice in database: %v", err)
 	}
 
+	if err := tx.Commit(ctx); err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "GenerateInvoice", "Failed to commit transaction",
+			zap.Int64("client_id", req.ClientID), zap.String("error", err.Error()))
+		return nil, 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	result := &GenerateInvoiceResult{
 		ID:              createdInv.ID,
 		InvoiceNumber:   createdInv.InvoiceNumber,
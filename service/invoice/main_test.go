@@ -0,0 +1,40 @@
+package invoice
+
+import (
+	"context"
+	"log"
+	db "maicare_go/db/sqlc"
+	"maicare_go/logger"
+	"maicare_go/util"
+	"os"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var testStore *db.Store
+var testConfig util.Config
+var testLogger logger.Logger
+
+func TestMain(m *testing.M) {
+	config, err := util.LoadConfig("../../")
+	if err != nil {
+		log.Fatalf("Could not load conf %v", err)
+	}
+	testConfig = config
+
+	conn, err := pgxpool.New(context.Background(), config.DbSource)
+	if err != nil {
+		log.Fatalf("unable to connect to database: %v", err)
+	}
+	defer conn.Close()
+
+	testStore = db.NewStore(conn)
+
+	testLogger, err = logger.SetupLogger(config.Environment)
+	if err != nil {
+		log.Fatalf("cannot setup logger: %v", err)
+	}
+
+	os.Exit(m.Run())
+}
@@ -0,0 +1,143 @@
+package invoice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	db "maicare_go/db/sqlc"
+	"maicare_go/logger"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	NumberResetYearly     = "yearly"
+	NumberResetContinuous = "continuous"
+
+	defaultNumberFormat    = "{prefix}-{year}-{seq}"
+	defaultSequencePadding = 5
+)
+
+var ErrInvalidNumberFormat = errors.New("invalid invoice number format")
+
+var numberFormatToken = regexp.MustCompile(`\{[^{}]*\}`)
+
+// ValidateNumberFormat checks that a number format only uses known tokens and always
+// contains the prefix and the sequence. A series that resets every year needs the year
+// in the number, otherwise the numbers of different years would collide.
+func ValidateNumberFormat(format string, resetPeriod string) error {
+	if resetPeriod != NumberResetYearly && resetPeriod != NumberResetContinuous {
+		return fmt.Errorf("%w: unknown reset period %q", ErrInvalidNumberFormat, resetPeriod)
+	}
+	for _, token := range numberFormatToken.FindAllString(format, -1) {
+		switch token {
+		case "{prefix}", "{year}", "{seq}":
+		default:
+			return fmt.Errorf("%w: unknown token %s", ErrInvalidNumberFormat, token)
+		}
+	}
+	if strings.ContainsAny(numberFormatToken.ReplaceAllString(format, ""), "{}") {
+		return fmt.Errorf("%w: unbalanced braces", ErrInvalidNumberFormat)
+	}
+	if !strings.Contains(format, "{prefix}") || !strings.Contains(format, "{seq}") {
+		return fmt.Errorf("%w: the format needs {prefix} and {seq}", ErrInvalidNumberFormat)
+	}
+	if resetPeriod == NumberResetYearly && !strings.Contains(format, "{year}") {
+		return fmt.Errorf("%w: a yearly series needs {year} in the format", ErrInvalidNumberFormat)
+	}
+	return nil
+}
+
+// FormatInvoiceNumber renders the last allocated number of a series
+func FormatInvoiceNumber(series db.InvoiceNumberSeries) string {
+	year := ""
+	if series.SequenceYear != nil {
+		year = strconv.Itoa(int(*series.SequenceYear))
+	}
+	return strings.NewReplacer(
+		"{prefix}", series.Prefix,
+		"{year}", year,
+		"{seq}", fmt.Sprintf("%0*d", series.SequencePadding, series.LastSequence),
+	).Replace(series.NumberFormat)
+}
+
+// AllocateInvoiceNumber takes the next number from the series of the organisation that
+// issues the invoices of the client. It has to run in the transaction that creates the
+// invoice: the series stays locked until it commits, and a rollback returns the number.
+func AllocateInvoiceNumber(ctx context.Context, qtx *db.Queries, clientID int64, issueDate time.Time) (string, int64, error) {
+	organisationID, err := qtx.GetClientInvoiceOrganisationID(ctx, clientID)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to get the issuing organisation of client %d: %w", clientID, err)
+	}
+
+	series, err := qtx.AllocateInvoiceNumber(ctx, db.AllocateInvoiceNumberParams{
+		OrganisationID: organisationID,
+		SequenceYear:   int32(issueDate.Year()),
+	})
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to allocate invoice number: %w", err)
+	}
+	return FormatInvoiceNumber(series), series.LastSequence, nil
+}
+
+func (s *invoiceService) GetInvoiceNumberSeries(ctx context.Context, organisationID int64) (*InvoiceNumberSeriesResponse, error) {
+	series, err := s.Store.GetInvoiceNumberSeries(ctx, organisationID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &InvoiceNumberSeriesResponse{
+			OrganisationID:  organisationID,
+			Prefix:          "INV" + strconv.FormatInt(organisationID, 10),
+			NumberFormat:    defaultNumberFormat,
+			SequencePadding: defaultSequencePadding,
+			ResetPeriod:     NumberResetYearly,
+		}, nil
+	}
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "GetInvoiceNumberSeries", "Failed to get invoice number series", zap.Int64("organisation_id", organisationID), zap.Error(err))
+		return nil, err
+	}
+	return newInvoiceNumberSeriesResponse(series), nil
+}
+
+func (s *invoiceService) UpdateInvoiceNumberSeries(ctx context.Context, organisationID int64, req UpdateInvoiceNumberSeriesRequest, employeeID int64) (*InvoiceNumberSeriesResponse, error) {
+	if err := ValidateNumberFormat(req.NumberFormat, req.ResetPeriod); err != nil {
+		return nil, err
+	}
+
+	series, err := s.Store.UpsertInvoiceNumberSeries(ctx, db.UpsertInvoiceNumberSeriesParams{
+		OrganisationID:  organisationID,
+		Prefix:          req.Prefix,
+		NumberFormat:    req.NumberFormat,
+		SequencePadding: req.SequencePadding,
+		ResetPeriod:     req.ResetPeriod,
+		UpdatedBy:       &employeeID,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "UpdateInvoiceNumberSeries", "Failed to update invoice number series", zap.Int64("organisation_id", organisationID), zap.Error(err))
+		return nil, err
+	}
+	return newInvoiceNumberSeriesResponse(series), nil
+}
+
+func newInvoiceNumberSeriesResponse(series db.InvoiceNumberSeries) *InvoiceNumberSeriesResponse {
+	response := &InvoiceNumberSeriesResponse{
+		OrganisationID:  series.OrganisationID,
+		Prefix:          series.Prefix,
+		NumberFormat:    series.NumberFormat,
+		SequencePadding: series.SequencePadding,
+		ResetPeriod:     series.ResetPeriod,
+		SequenceYear:    series.SequenceYear,
+		LastSequence:    series.LastSequence,
+		UpdatedBy:       series.UpdatedBy,
+		UpdatedAt:       &series.UpdatedAt.Time,
+	}
+	if series.LastSequence > 0 {
+		last := FormatInvoiceNumber(series)
+		response.LastInvoiceNumber = &last
+	}
+	return response
+}
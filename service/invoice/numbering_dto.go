@@ -0,0 +1,26 @@
+package invoice
+
+import "time"
+
+// UpdateInvoiceNumberSeriesRequest configures the invoice numbers of an organisation. The
+// format supports the tokens {prefix}, {year} and {seq}.
+type UpdateInvoiceNumberSeriesRequest struct {
+	Prefix          string `json:"prefix" binding:"required,max=20" example:"MC"`
+	NumberFormat    string `json:"number_format" binding:"required,max=50" example:"{prefix}-{year}-{seq}"`
+	SequencePadding int32  `json:"sequence_padding" binding:"min=1,max=10" example:"5"`
+	ResetPeriod     string `json:"reset_period" binding:"required,oneof=yearly continuous" example:"yearly"`
+}
+
+// InvoiceNumberSeriesResponse holds the invoice number series of an organisation
+type InvoiceNumberSeriesResponse struct {
+	OrganisationID    int64      `json:"organisation_id"`
+	Prefix            string     `json:"prefix"`
+	NumberFormat      string     `json:"number_format"`
+	SequencePadding   int32      `json:"sequence_padding"`
+	ResetPeriod       string     `json:"reset_period"`
+	SequenceYear      *int32     `json:"sequence_year"`
+	LastSequence      int64      `json:"last_sequence"`
+	LastInvoiceNumber *string    `json:"last_invoice_number"`
+	UpdatedBy         *int64     `json:"updated_by"`
+	UpdatedAt         *time.Time `json:"updated_at"`
+}
@@ -0,0 +1,55 @@
+package invoice
+
+import (
+	"testing"
+
+	db "maicare_go/db/sqlc"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateNumberFormat(t *testing.T) {
+	testCases := []struct {
+		name        string
+		format      string
+		resetPeriod string
+		valid       bool
+	}{
+		{"default format", "{prefix}-{year}-{seq}", NumberResetYearly, true},
+		{"continuous without year", "{prefix}{seq}", NumberResetContinuous, true},
+		{"yearly without year", "{prefix}-{seq}", NumberResetYearly, false},
+		{"missing prefix", "F{year}-{seq}", NumberResetYearly, false},
+		{"missing sequence", "{prefix}-{year}", NumberResetYearly, false},
+		{"unknown token", "{prefix}-{month}-{seq}", NumberResetContinuous, false},
+		{"unbalanced braces", "{prefix}-{seq", NumberResetContinuous, false},
+		{"unknown reset period", "{prefix}-{year}-{seq}", "monthly", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateNumberFormat(tc.format, tc.resetPeriod)
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, ErrInvalidNumberFormat)
+			}
+		})
+	}
+}
+
+func TestFormatInvoiceNumber(t *testing.T) {
+	year := int32(2026)
+	series := db.InvoiceNumberSeries{
+		Prefix:          "MC",
+		NumberFormat:    defaultNumberFormat,
+		SequencePadding: 5,
+		SequenceYear:    &year,
+		LastSequence:    42,
+	}
+	require.Equal(t, "MC-2026-00042", FormatInvoiceNumber(series))
+
+	series.NumberFormat = "{prefix}{seq}"
+	series.SequencePadding = 2
+	series.LastSequence = 1234
+	require.Equal(t, "MC1234", FormatInvoiceNumber(series))
+}
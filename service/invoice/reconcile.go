@@ -0,0 +1,156 @@
+package invoice
+
+import (
+	"maicare_go/bankstatement"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+const (
+	MatchMethodInvoiceNumber = "invoice_number"
+	MatchMethodAmountSender  = "amount_sender"
+	MatchMethodManual        = "manual"
+
+	// amounts on a statement are exact, anything within a cent is the same amount
+	matchAmountTolerance = 0.01
+)
+
+// MatchCandidate is an open invoice a statement line can be matched against
+type MatchCandidate struct {
+	InvoiceID     int64
+	InvoiceNumber string
+	TotalAmount   float64
+	PaidAmount    float64
+	SenderName    string
+}
+
+// Outstanding returns the amount that still has to be paid
+func (c MatchCandidate) Outstanding() float64 {
+	return math.Round((c.TotalAmount-c.PaidAmount)*100) / 100
+}
+
+// MatchResult is the outcome of matching a statement line. Only confident matches
+// are booked automatically, the others are kept as suggestion for the review queue.
+type MatchResult struct {
+	InvoiceID int64
+	Method    string
+	Confident bool
+}
+
+// normalizeReference keeps only letters and digits so "INV-2025 0101-0001" and
+// "inv202501010001" compare equal
+func normalizeReference(value string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(value) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// containsReference reports whether the invoice number appears in the text as a whole,
+// with or without separators, so "INV-2025-1" is not found in "INV-2025-10"
+func containsReference(text, number string) bool {
+	number = normalizeReference(number)
+	if len(number) < 4 {
+		return false
+	}
+	parts := make([]string, 0, len(number))
+	for _, r := range number {
+		parts = append(parts, regexp.QuoteMeta(string(r)))
+	}
+	pattern := `(?:^|[^\pL\pN])` + strings.Join(parts, `[^\pL\pN]*`) + `(?:[^\pL\pN]|$)`
+	return regexp.MustCompile(pattern).MatchString(strings.ToUpper(text))
+}
+
+var legalForms = map[string]bool{"BV": true, "NV": true, "VOF": true, "STICHTING": true, "HOLDING": true}
+
+func normalizeName(value string) string {
+	var words []string
+	for _, word := range strings.FieldsFunc(strings.ToUpper(value), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '.'
+	}) {
+		word = strings.ReplaceAll(word, ".", "")
+		if word == "" || legalForms[word] {
+			continue
+		}
+		words = append(words, word)
+	}
+	return strings.Join(words, " ")
+}
+
+// namesMatch compares the account holder name on the statement with the sender name.
+// One name may add words to the other, "Gemeente Utrecht inz. Jeugd" is still
+// "Gemeente Utrecht", but words are never matched partially.
+func namesMatch(counterparty, sender string) bool {
+	a, b := normalizeName(counterparty), normalizeName(sender)
+	if len(a) < 3 || len(b) < 3 {
+		return false
+	}
+	a, b = " "+a+" ", " "+b+" "
+	return strings.Contains(a, b) || strings.Contains(b, a)
+}
+
+func sameAmount(a, b float64) bool {
+	return math.Abs(a-b) < matchAmountTolerance
+}
+
+// MatchStatementEntry matches an incoming payment to one of the open invoices.
+// An invoice number in the remittance information wins, it is only confident when the
+// amount is the outstanding amount. Otherwise the amount and the sender name have to
+// agree on exactly one invoice.
+func MatchStatementEntry(entry bankstatement.Entry, candidates []MatchCandidate) *MatchResult {
+	if !entry.IsCredit() || len(candidates) == 0 {
+		return nil
+	}
+
+	remittance := entry.RemittanceInfo + " " + entry.Reference
+	var numberHits []MatchCandidate
+	if normalizeReference(remittance) != "" {
+		for _, candidate := range candidates {
+			if containsReference(remittance, candidate.InvoiceNumber) {
+				numberHits = append(numberHits, candidate)
+			}
+		}
+	}
+
+	if len(numberHits) == 1 {
+		// a payment of another amount is a partial payment or a typo in the reference, both
+		// are left to the review queue
+		confident := sameAmount(numberHits[0].Outstanding(), entry.Amount)
+		return &MatchResult{InvoiceID: numberHits[0].InvoiceID, Method: MatchMethodInvoiceNumber, Confident: confident}
+	}
+	if len(numberHits) > 1 {
+		// one transfer for several invoices has to be split by hand, suggest the one with the exact amount
+		for _, hit := range numberHits {
+			if sameAmount(hit.Outstanding(), entry.Amount) {
+				return &MatchResult{InvoiceID: hit.InvoiceID, Method: MatchMethodInvoiceNumber, Confident: false}
+			}
+		}
+		return &MatchResult{InvoiceID: numberHits[0].InvoiceID, Method: MatchMethodInvoiceNumber, Confident: false}
+	}
+
+	var amountHits, senderHits []MatchCandidate
+	for _, candidate := range candidates {
+		if !sameAmount(candidate.Outstanding(), entry.Amount) {
+			continue
+		}
+		amountHits = append(amountHits, candidate)
+		if namesMatch(entry.CounterpartyName, candidate.SenderName) {
+			senderHits = append(senderHits, candidate)
+		}
+	}
+
+	switch {
+	case len(senderHits) == 1:
+		return &MatchResult{InvoiceID: senderHits[0].InvoiceID, Method: MatchMethodAmountSender, Confident: true}
+	case len(senderHits) > 1:
+		return &MatchResult{InvoiceID: senderHits[0].InvoiceID, Method: MatchMethodAmountSender, Confident: false}
+	case len(amountHits) == 1:
+		return &MatchResult{InvoiceID: amountHits[0].InvoiceID, Method: MatchMethodAmountSender, Confident: false}
+	}
+	return nil
+}
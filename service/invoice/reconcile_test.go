@@ -0,0 +1,140 @@
+package invoice
+
+import (
+	"maicare_go/bankstatement"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testCandidates() []MatchCandidate {
+	return []MatchCandidate{
+		{InvoiceID: 1, InvoiceNumber: "INV-20250101-0001", TotalAmount: 1210, SenderName: "Gemeente Utrecht"},
+		{InvoiceID: 2, InvoiceNumber: "INV-20250101-0002", TotalAmount: 500, PaidAmount: 200, SenderName: "Zorgkantoor Midden B.V."},
+		{InvoiceID: 3, InvoiceNumber: "INV-20250102-0001", TotalAmount: 300, SenderName: "Gemeente Utrecht"},
+		{InvoiceID: 4, InvoiceNumber: "INV-20250102-0002", TotalAmount: 300, SenderName: "Stichting Jeugdzorg"},
+	}
+}
+
+func TestMatchStatementEntry(t *testing.T) {
+	testCases := []struct {
+		name      string
+		entry     bankstatement.Entry
+		invoiceID int64
+		method    string
+		confident bool
+		noMatch   bool
+	}{
+		{
+			name:      "invoice number in remittance",
+			entry:     bankstatement.Entry{CreditDebit: bankstatement.Credit, Amount: 1210, RemittanceInfo: "Betaling factuur INV-20250101-0001"},
+			invoiceID: 1,
+			method:    MatchMethodInvoiceNumber,
+			confident: true,
+		},
+		{
+			name:      "invoice number with another amount needs review",
+			entry:     bankstatement.Entry{CreditDebit: bankstatement.Credit, Amount: 1000, RemittanceInfo: "Betaling factuur INV-20250101-0001"},
+			invoiceID: 1,
+			method:    MatchMethodInvoiceNumber,
+			confident: false,
+		},
+		{
+			name:      "invoice number without separators",
+			entry:     bankstatement.Entry{CreditDebit: bankstatement.Credit, Amount: 300, RemittanceInfo: "inv 20250102 0002"},
+			invoiceID: 4,
+			method:    MatchMethodInvoiceNumber,
+			confident: true,
+		},
+		{
+			name:      "several invoice numbers need review",
+			entry:     bankstatement.Entry{CreditDebit: bankstatement.Credit, Amount: 300, RemittanceInfo: "INV-20250101-0002 INV-20250102-0001"},
+			invoiceID: 2,
+			method:    MatchMethodInvoiceNumber,
+			confident: false,
+		},
+		{
+			name:      "amount and sender",
+			entry:     bankstatement.Entry{CreditDebit: bankstatement.Credit, Amount: 300, CounterpartyName: "STICHTING JEUGDZORG", RemittanceInfo: "december"},
+			invoiceID: 4,
+			method:    MatchMethodAmountSender,
+			confident: true,
+		},
+		{
+			name:      "outstanding amount of partially paid invoice",
+			entry:     bankstatement.Entry{CreditDebit: bankstatement.Credit, Amount: 300, CounterpartyName: "Zorgkantoor Midden"},
+			invoiceID: 2,
+			method:    MatchMethodAmountSender,
+			confident: true,
+		},
+		{
+			name:      "amount only is a suggestion",
+			entry:     bankstatement.Entry{CreditDebit: bankstatement.Credit, Amount: 1210, CounterpartyName: "J. Jansen"},
+			invoiceID: 1,
+			method:    MatchMethodAmountSender,
+			confident: false,
+		},
+		{
+			name:    "invoice number is not found inside a longer number",
+			entry:   bankstatement.Entry{CreditDebit: bankstatement.Credit, Amount: 1000, RemittanceInfo: "INV-20250101-00010"},
+			noMatch: true,
+		},
+		{
+			name:    "amount matches several invoices of unknown sender",
+			entry:   bankstatement.Entry{CreditDebit: bankstatement.Credit, Amount: 300, CounterpartyName: "Onbekend"},
+			noMatch: true,
+		},
+		{
+			name:    "debit lines are never matched",
+			entry:   bankstatement.Entry{CreditDebit: bankstatement.Debit, Amount: 1210, RemittanceInfo: "INV-20250101-0001"},
+			noMatch: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := MatchStatementEntry(tc.entry, testCandidates())
+			if tc.noMatch {
+				require.Nil(t, result)
+				return
+			}
+			require.NotNil(t, result)
+			require.Equal(t, tc.invoiceID, result.InvoiceID)
+			require.Equal(t, tc.method, result.Method)
+			require.Equal(t, tc.confident, result.Confident)
+		})
+	}
+}
+
+func TestNamesMatch(t *testing.T) {
+	require.True(t, namesMatch("ZORGKANTOOR MIDDEN BV", "Zorgkantoor Midden B.V."))
+	require.True(t, namesMatch("Gemeente Utrecht inz. Jeugd", "Gemeente Utrecht"))
+	require.False(t, namesMatch("Gemeente Zeist", "Gemeente Utrecht"))
+	require.False(t, namesMatch("", "Gemeente Utrecht"))
+	require.False(t, namesMatch("Utrechtse Zorg", "Utrecht"))
+}
+
+func TestContainsReference(t *testing.T) {
+	require.True(t, containsReference("factuur INV-2025-1, dank", "INV-2025-1"))
+	require.True(t, containsReference("inv20251", "INV-2025-1"))
+	require.False(t, containsReference("factuur INV-2025-10", "INV-2025-1"))
+	require.False(t, containsReference("XINV-2025-1", "INV-2025-1"))
+	require.False(t, containsReference("INV-2025-1", "1"))
+}
+
+func TestSeveralInvoiceNumbersAreNotConfident(t *testing.T) {
+	candidates := []MatchCandidate{
+		{InvoiceID: 1, InvoiceNumber: "2025-1", TotalAmount: 100},
+		{InvoiceID: 10, InvoiceNumber: "2025-10", TotalAmount: 100},
+	}
+	entry := bankstatement.Entry{CreditDebit: bankstatement.Credit, Amount: 100, RemittanceInfo: "facturen 2025-1 en 2025-10"}
+	result := MatchStatementEntry(entry, candidates)
+	require.NotNil(t, result)
+	require.False(t, result.Confident)
+
+	entry.RemittanceInfo = "factuur 2025-10"
+	result = MatchStatementEntry(entry, candidates)
+	require.NotNil(t, result)
+	require.Equal(t, int64(10), result.InvoiceID)
+	require.True(t, result.Confident)
+}
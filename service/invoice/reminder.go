@@ -2,7 +2,12 @@ package invoice
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	db "maicare_go/db/sqlc"
+	"maicare_go/email"
 	"maicare_go/logger"
+	"time"
 
 	"go.uber.org/zap"
 )
@@ -14,10 +19,61 @@ func (s *invoiceService) SendInvoiceReminder(ctx context.Context, invoiceID int6
 			zap.Error(err), zap.Int64("invoiceID", invoiceID))
 		return err
 	}
-	if senderID != nil {
-		s.Logger.LogBusinessEvent(logger.LogLevelInfo, "SendInvoiceReminder", "Sending reminder email",
-			zap.Int64("invoiceID", invoiceID), zap.Int64("senderID", *senderID))
-		// Logic to send reminder email using senderID
+	if senderID == nil {
+		return fmt.Errorf("invoice %d has no sender to remind", invoiceID)
 	}
-	return nil
+
+	s.Logger.LogBusinessEvent(logger.LogLevelInfo, "SendInvoiceReminder", "Sending reminder email",
+		zap.Int64("invoiceID", invoiceID), zap.Int64("senderID", *senderID))
+	_, err = s.sendInvoiceNow(ctx, invoiceID, true, nil)
+	return err
+}
+
+// emailInvoice renders the PDF and UBL versions of an invoice and mails both to the
+// given recipients. It returns the message ID of the sent email.
+func (s *invoiceService) emailInvoice(ctx context.Context, inv db.GetInvoiceRow, recipients []string, isReminder bool) (string, error) {
+	if s.Mailer == nil {
+		return "", errors.New("mailer is not configured")
+	}
+	if len(recipients) == 0 {
+		return "", ErrNoInvoiceRecipients
+	}
+
+	pdfBytes, err := s.renderInvoicePDF(ctx, inv)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "EmailInvoice", "Failed to render invoice PDF",
+			zap.Error(err), zap.Int64("invoiceID", inv.ID))
+		return "", err
+	}
+	ublBytes, err := s.renderInvoiceUBL(ctx, inv, pdfBytes)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "EmailInvoice", "Failed to render invoice UBL",
+			zap.Error(err), zap.Int64("invoiceID", inv.ID))
+		return "", err
+	}
+
+	var recipientName string
+	if contacts := parseSenderContacts(inv.SenderContacts); len(contacts) > 0 && contacts[0].Name != nil {
+		recipientName = *contacts[0].Name
+	}
+
+	messageID, err := s.Mailer.SendInvoice(ctx, recipients, email.InvoiceEmail{
+		RecipientName: recipientName,
+		InvoiceNumber: inv.InvoiceNumber,
+		InvoiceType:   inv.InvoiceType,
+		IssueDate:     inv.IssueDate.Time,
+		DueDate:       inv.DueDate.Time,
+		TotalAmount:   inv.TotalAmount,
+		IsReminder:    isReminder,
+		CurrentYear:   time.Now().Year(),
+	}, []email.Attachment{
+		{Name: invoiceFileName(inv.InvoiceNumber, "pdf"), Content: pdfBytes},
+		{Name: invoiceFileName(inv.InvoiceNumber, "xml"), Content: ublBytes},
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "EmailInvoice", "Failed to send invoice email",
+			zap.Error(err), zap.Int64("invoiceID", inv.ID))
+		return "", fmt.Errorf("failed to send invoice email: %w", err)
+	}
+	return messageID, nil
 }
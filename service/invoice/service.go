@@ -2,7 +2,11 @@ package invoice
 
 import (
 	"context"
+	"maicare_go/pagination"
 	"maicare_go/service/deps"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 // InvoiceService Interface and implementation
@@ -12,6 +16,24 @@ type InvoiceService interface {
 	GenerateInvoice(req GenerateInvoiceRequest, ctx context.Context) (*GenerateInvoiceResult, int64, error)
 	GetInvoiceByID(ctx context.Context, invoiceID int64) (*GetInvoiceByIDResponse, error)
 	SendInvoiceReminder(ctx context.Context, invoiceID int64) error
+	ExportInvoice(ctx context.Context, invoiceID int64, format string) (*InvoiceDocument, error)
+	ImportBankStatement(ctx context.Context, req ImportBankStatementRequest, employeeID int64) (*ImportBankStatementResponse, error)
+	ListBankStatementLines(ctx *gin.Context, req ListBankStatementLinesRequest) (*pagination.Response[BankStatementLineResponse], error)
+	MatchBankStatementLine(ctx context.Context, lineID int64, req MatchBankStatementLineRequest, employeeID int64) (*BankStatementMatch, error)
+	IgnoreBankStatementLine(ctx context.Context, lineID int64, req IgnoreBankStatementLineRequest, employeeID int64) (*BankStatementLineResponse, error)
+	SubmitInvoiceForApproval(ctx context.Context, invoiceID int64, employeeID int64) (*InvoiceApprovalResponse, error)
+	ApproveInvoice(ctx context.Context, invoiceID int64, employeeID int64) (*InvoiceApprovalResponse, error)
+	RejectInvoice(ctx context.Context, invoiceID int64, req RejectInvoiceRequest, employeeID int64) (*InvoiceApprovalResponse, error)
+	GetInvoiceApprovalSettings(ctx context.Context, organisationID int64) (*InvoiceApprovalSettingsResponse, error)
+	UpdateInvoiceApprovalSettings(ctx context.Context, organisationID int64, req UpdateInvoiceApprovalSettingsRequest, employeeID int64) (*InvoiceApprovalSettingsResponse, error)
+	GetInvoiceNumberSeries(ctx context.Context, organisationID int64) (*InvoiceNumberSeriesResponse, error)
+	UpdateInvoiceNumberSeries(ctx context.Context, organisationID int64, req UpdateInvoiceNumberSeriesRequest, employeeID int64) (*InvoiceNumberSeriesResponse, error)
+	SendInvoice(ctx context.Context, invoiceID int64, employeeID int64) (*InvoiceDeliveryResponse, error)
+	SendInvoiceBatch(ctx context.Context, req SendInvoiceBatchRequest, employeeID int64) (*SendInvoiceBatchResponse, error)
+	ProcessInvoiceDelivery(ctx context.Context, deliveryID int64) (*InvoiceDeliveryResponse, error)
+	ListInvoiceDeliveries(ctx context.Context, invoiceID int64) ([]InvoiceDeliveryResponse, error)
+	GetInvoiceDeliveryBatch(ctx context.Context, batchID uuid.UUID) (*InvoiceDeliveryBatchResponse, error)
+	CreditInvoice(ctx context.Context, invoiceID int64, req CreditInvoiceRequest, employeeID int64) (*CreditInvoiceResponse, error)
 }
 
 type invoiceService struct {
@@ -0,0 +1,76 @@
+package invoice
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	ErrInvalidStatusTransition = errors.New("invalid invoice status transition")
+	ErrInvoiceNotApproved      = errors.New("invoice has not been approved yet")
+	ErrFourEyesViolation       = errors.New("invoice has to be approved by another employee than the one who created or submitted it")
+)
+
+// invoiceTransitions lists the statuses an invoice can move to from each status.
+// Invoices are drafted as concept, reviewed through pending_approval and only
+// reach the payment statuses once approved. Payment statuses follow the paid
+// amount, so they can move back and forth when payments are corrected.
+var invoiceTransitions = map[InvoiceStatus][]InvoiceStatus{
+	InvoiceStatusConcept:         {InvoiceStatusPendingApproval, InvoiceStatusCanceled},
+	InvoiceStatusPendingApproval: {InvoiceStatusApproved, InvoiceStatusConcept, InvoiceStatusCanceled},
+	InvoiceStatusApproved:        {InvoiceStatusOutstanding, InvoiceStatusPartiallyPaid, InvoiceStatusPaid, InvoiceStatusOverpaid, InvoiceStatusCanceled},
+	InvoiceStatusOutstanding:     {InvoiceStatusPartiallyPaid, InvoiceStatusPaid, InvoiceStatusOverpaid, InvoiceStatusExpired, InvoiceStatusCanceled},
+	InvoiceStatusPartiallyPaid:   {InvoiceStatusOutstanding, InvoiceStatusPaid, InvoiceStatusOverpaid, InvoiceStatusExpired, InvoiceStatusCanceled},
+	InvoiceStatusExpired:         {InvoiceStatusOutstanding, InvoiceStatusPartiallyPaid, InvoiceStatusPaid, InvoiceStatusOverpaid, InvoiceStatusCanceled},
+	InvoiceStatusPaid:            {InvoiceStatusOutstanding, InvoiceStatusPartiallyPaid, InvoiceStatusOverpaid, InvoiceStatusCanceled},
+	InvoiceStatusOverpaid:        {InvoiceStatusOutstanding, InvoiceStatusPartiallyPaid, InvoiceStatusPaid, InvoiceStatusCanceled},
+	InvoiceStatusImported:        {InvoiceStatusOutstanding, InvoiceStatusPartiallyPaid, InvoiceStatusPaid, InvoiceStatusOverpaid, InvoiceStatusExpired, InvoiceStatusCanceled},
+	InvoiceStatusCanceled:        {},
+}
+
+// CanTransition reports whether an invoice may move from one status to another
+func CanTransition(from, to InvoiceStatus) bool {
+	for _, next := range invoiceTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateStatusTransition returns ErrInvalidStatusTransition when the change is not
+// allowed. Setting the current status again is a no-op and always allowed.
+func ValidateStatusTransition(from, to string) error {
+	if from == to {
+		return nil
+	}
+	if !CanTransition(InvoiceStatus(from), InvoiceStatus(to)) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidStatusTransition, from, to)
+	}
+	return nil
+}
+
+// IsApproved reports whether an invoice passed the approval step, only those can be
+// sent, reminded or exported
+func IsApproved(status string) bool {
+	switch InvoiceStatus(status) {
+	case InvoiceStatusConcept, InvoiceStatusPendingApproval, InvoiceStatusCanceled:
+		return false
+	}
+	_, known := invoiceTransitions[InvoiceStatus(status)]
+	return known
+}
+
+// CheckFourEyes enforces that invoices at or above the approval threshold are approved
+// by someone else than the employee who created or submitted them. A threshold of zero
+// means every invoice needs a second employee.
+func CheckFourEyes(approverID int64, createdBy, submittedBy *int64, totalAmount, threshold float64) error {
+	if threshold > 0 && math.Abs(totalAmount) < threshold {
+		return nil
+	}
+	if (createdBy != nil && *createdBy == approverID) || (submittedBy != nil && *submittedBy == approverID) {
+		return ErrFourEyesViolation
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package invoice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateStatusTransition(t *testing.T) {
+	testCases := []struct {
+		from  InvoiceStatus
+		to    InvoiceStatus
+		valid bool
+	}{
+		{InvoiceStatusConcept, InvoiceStatusPendingApproval, true},
+		{InvoiceStatusConcept, InvoiceStatusOutstanding, false},
+		{InvoiceStatusConcept, InvoiceStatusApproved, false},
+		{InvoiceStatusPendingApproval, InvoiceStatusApproved, true},
+		{InvoiceStatusPendingApproval, InvoiceStatusConcept, true},
+		{InvoiceStatusPendingApproval, InvoiceStatusPaid, false},
+		{InvoiceStatusApproved, InvoiceStatusOutstanding, true},
+		{InvoiceStatusApproved, InvoiceStatusConcept, false},
+		{InvoiceStatusOutstanding, InvoiceStatusPaid, true},
+		{InvoiceStatusOutstanding, InvoiceStatusPendingApproval, false},
+		{InvoiceStatusPaid, InvoiceStatusPartiallyPaid, true},
+		{InvoiceStatusPaid, InvoiceStatusCanceled, true},
+		{InvoiceStatusCanceled, InvoiceStatusOutstanding, false},
+		{InvoiceStatusCanceled, InvoiceStatusCanceled, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.from)+"->"+string(tc.to), func(t *testing.T) {
+			err := ValidateStatusTransition(string(tc.from), string(tc.to))
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, ErrInvalidStatusTransition)
+			}
+		})
+	}
+}
+
+func TestIsApproved(t *testing.T) {
+	require.False(t, IsApproved("concept"))
+	require.False(t, IsApproved("pending_approval"))
+	require.False(t, IsApproved("canceled"))
+	require.False(t, IsApproved("unknown"))
+	require.True(t, IsApproved("approved"))
+	require.True(t, IsApproved("outstanding"))
+	require.True(t, IsApproved("imported"))
+}
+
+func TestCheckFourEyes(t *testing.T) {
+	creator := int64(1)
+	submitter := int64(2)
+
+	require.ErrorIs(t, CheckFourEyes(1, &creator, &submitter, 100, 0), ErrFourEyesViolation)
+	require.ErrorIs(t, CheckFourEyes(2, &creator, &submitter, 100, 0), ErrFourEyesViolation)
+	require.NoError(t, CheckFourEyes(3, &creator, &submitter, 100, 0))
+	// below the threshold the submitter may approve the invoice
+	require.NoError(t, CheckFourEyes(2, &creator, &submitter, 499.99, 500))
+	require.ErrorIs(t, CheckFourEyes(2, &creator, &submitter, 500, 500), ErrFourEyesViolation)
+	// credit notes are compared on their absolute amount
+	require.ErrorIs(t, CheckFourEyes(2, nil, &submitter, -750, 500), ErrFourEyesViolation)
+	require.NoError(t, CheckFourEyes(2, nil, nil, 750, 500))
+}
@@ -0,0 +1,519 @@
+package invoice
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// UBL 2.1 identifiers for the Dutch NLCIUS profile (SI-UBL 2.0)
+const (
+	UBLCustomizationID = "urn:cen.eu:en16931:2017#compliant#urn:fdc:nen.nl:nlcius:v1.0"
+	UBLProfileID       = "urn:fdc:peppol.eu:2017:poacc:billing:01:1.0"
+
+	ublInvoiceNamespace    = "urn:oasis:names:specification:ubl:schema:xsd:Invoice-2"
+	ublCreditNoteNamespace = "urn:oasis:names:specification:ubl:schema:xsd:CreditNote-2"
+	ublCACNamespace        = "urn:oasis:names:specification:ubl:schema:xsd:CommonAggregateComponents-2"
+	ublCBCNamespace        = "urn:oasis:names:specification:ubl:schema:xsd:CommonBasicComponents-2"
+
+	ublInvoiceTypeCode    = "380"
+	ublCreditNoteTypeCode = "381"
+	ublCurrency           = "EUR"
+	ublUnitCode           = "C62" // one (unit)
+	ublCountryNL          = "NL"
+
+	// Electronic address / legal entity schemes accepted by NLCIUS
+	ublSchemeKVK = "0106"
+	ublSchemeOIN = "0190"
+
+	// Payment means code (UNCL4461) of a credit transfer to the account of the supplier
+	ublPaymentMeansCreditTransfer = "30"
+
+	ublTaxCategoryStandard = "S"
+	ublTaxCategoryExempt   = "E"
+
+	// Youth and healthcare services are VAT exempt (art. 11 lid 1 sub g Wet OB)
+	ublExemptionReason = "Vrijgesteld van btw (zorg, art. 11 lid 1 Wet OB)"
+)
+
+// UBLParty holds the party details used for the supplier and customer blocks
+type UBLParty struct {
+	Name        string
+	Street      string
+	City        string
+	PostalCode  string
+	CountryCode string
+	KvkNumber   string
+	BtwNumber   string
+	Email       string
+	Phone       string
+}
+
+// UBLInput is everything required to render an invoice or credit note as UBL
+type UBLInput struct {
+	InvoiceNumber         string
+	InvoiceType           string // standard | credit_note
+	IssueDate             time.Time
+	DueDate               time.Time
+	BuyerReference        string
+	OriginalInvoiceNumber string
+	Supplier              UBLParty
+	SupplierIBAN          string // the account the invoice is paid into, no payment means without it
+	Customer              UBLParty
+	Lines                 []InvoiceDetails
+	PDF                   []byte
+	PDFFileName           string
+}
+
+type ublAmount struct {
+	CurrencyID string `xml:"currencyID,attr"`
+	Value      string `xml:",chardata"`
+}
+
+type ublQuantity struct {
+	UnitCode string `xml:"unitCode,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type ublIdentifier struct {
+	SchemeID string `xml:"schemeID,attr,omitempty"`
+	Value    string `xml:",chardata"`
+}
+
+type ublBinaryObject struct {
+	MimeCode string `xml:"mimeCode,attr"`
+	Filename string `xml:"filename,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type ublAttachment struct {
+	EmbeddedDocumentBinaryObject ublBinaryObject `xml:"cbc:EmbeddedDocumentBinaryObject"`
+}
+
+type ublDocumentReference struct {
+	ID         string         `xml:"cbc:ID"`
+	Attachment *ublAttachment `xml:"cac:Attachment,omitempty"`
+}
+
+type ublBillingReference struct {
+	InvoiceDocumentReference ublDocumentReference `xml:"cac:InvoiceDocumentReference"`
+}
+
+type ublFinancialAccount struct {
+	ID string `xml:"cbc:ID"`
+}
+
+type ublPaymentMeans struct {
+	PaymentMeansCode      string               `xml:"cbc:PaymentMeansCode"`
+	PaymentID             string               `xml:"cbc:PaymentID,omitempty"`
+	PayeeFinancialAccount *ublFinancialAccount `xml:"cac:PayeeFinancialAccount,omitempty"`
+}
+
+type ublCountry struct {
+	IdentificationCode string `xml:"cbc:IdentificationCode"`
+}
+
+type ublPostalAddress struct {
+	StreetName string     `xml:"cbc:StreetName,omitempty"`
+	CityName   string     `xml:"cbc:CityName,omitempty"`
+	PostalZone string     `xml:"cbc:PostalZone,omitempty"`
+	Country    ublCountry `xml:"cac:Country"`
+}
+
+type ublTaxScheme struct {
+	ID string `xml:"cbc:ID"`
+}
+
+type ublPartyTaxScheme struct {
+	CompanyID string       `xml:"cbc:CompanyID"`
+	TaxScheme ublTaxScheme `xml:"cac:TaxScheme"`
+}
+
+type ublPartyLegalEntity struct {
+	RegistrationName string         `xml:"cbc:RegistrationName"`
+	CompanyID        *ublIdentifier `xml:"cbc:CompanyID,omitempty"`
+}
+
+type ublPartyName struct {
+	Name string `xml:"cbc:Name"`
+}
+
+type ublContact struct {
+	Telephone      string `xml:"cbc:Telephone,omitempty"`
+	ElectronicMail string `xml:"cbc:ElectronicMail,omitempty"`
+}
+
+type ublParty struct {
+	EndpointID       *ublIdentifier      `xml:"cbc:EndpointID,omitempty"`
+	PartyName        ublPartyName        `xml:"cac:PartyName"`
+	PostalAddress    ublPostalAddress    `xml:"cac:PostalAddress"`
+	PartyTaxScheme   *ublPartyTaxScheme  `xml:"cac:PartyTaxScheme,omitempty"`
+	PartyLegalEntity ublPartyLegalEntity `xml:"cac:PartyLegalEntity"`
+	Contact          *ublContact         `xml:"cac:Contact,omitempty"`
+}
+
+type ublPartyWrapper struct {
+	Party ublParty `xml:"cac:Party"`
+}
+
+type ublTaxCategory struct {
+	ID                     string       `xml:"cbc:ID"`
+	Percent                string       `xml:"cbc:Percent"`
+	TaxExemptionReasonCode string       `xml:"cbc:TaxExemptionReasonCode,omitempty"`
+	TaxExemptionReason     string       `xml:"cbc:TaxExemptionReason,omitempty"`
+	TaxScheme              ublTaxScheme `xml:"cac:TaxScheme"`
+}
+
+type ublTaxSubtotal struct {
+	TaxableAmount ublAmount      `xml:"cbc:TaxableAmount"`
+	TaxAmount     ublAmount      `xml:"cbc:TaxAmount"`
+	TaxCategory   ublTaxCategory `xml:"cac:TaxCategory"`
+}
+
+type ublTaxTotal struct {
+	TaxAmount    ublAmount        `xml:"cbc:TaxAmount"`
+	TaxSubtotals []ublTaxSubtotal `xml:"cac:TaxSubtotal"`
+}
+
+type ublMonetaryTotal struct {
+	LineExtensionAmount ublAmount `xml:"cbc:LineExtensionAmount"`
+	TaxExclusiveAmount  ublAmount `xml:"cbc:TaxExclusiveAmount"`
+	TaxInclusiveAmount  ublAmount `xml:"cbc:TaxInclusiveAmount"`
+	PayableAmount       ublAmount `xml:"cbc:PayableAmount"`
+}
+
+type ublClassifiedTaxCategory struct {
+	ID        string       `xml:"cbc:ID"`
+	Percent   string       `xml:"cbc:Percent"`
+	TaxScheme ublTaxScheme `xml:"cac:TaxScheme"`
+}
+
+type ublItem struct {
+	Name                  string                   `xml:"cbc:Name"`
+	ClassifiedTaxCategory ublClassifiedTaxCategory `xml:"cac:ClassifiedTaxCategory"`
+}
+
+type ublPrice struct {
+	PriceAmount ublAmount `xml:"cbc:PriceAmount"`
+}
+
+type ublPeriod struct {
+	StartDate string `xml:"cbc:StartDate"`
+	EndDate   string `xml:"cbc:EndDate"`
+}
+
+// ublLine is shared by InvoiceLine and CreditNoteLine, only the quantity element differs
+type ublLine struct {
+	ID                  string       `xml:"cbc:ID"`
+	InvoicedQuantity    *ublQuantity `xml:"cbc:InvoicedQuantity,omitempty"`
+	CreditedQuantity    *ublQuantity `xml:"cbc:CreditedQuantity,omitempty"`
+	LineExtensionAmount ublAmount    `xml:"cbc:LineExtensionAmount"`
+	InvoicePeriod       *ublPeriod   `xml:"cac:InvoicePeriod,omitempty"`
+	Item                ublItem      `xml:"cac:Item"`
+	Price               ublPrice     `xml:"cac:Price"`
+}
+
+// UBLDocument is the in-memory representation of a UBL 2.1 Invoice or CreditNote
+type UBLDocument struct {
+	XMLName                     xml.Name
+	XMLNS                       string                 `xml:"xmlns,attr"`
+	XMLNSCAC                    string                 `xml:"xmlns:cac,attr"`
+	XMLNSCBC                    string                 `xml:"xmlns:cbc,attr"`
+	CustomizationID             string                 `xml:"cbc:CustomizationID"`
+	ProfileID                   string                 `xml:"cbc:ProfileID"`
+	ID                          string                 `xml:"cbc:ID"`
+	IssueDate                   string                 `xml:"cbc:IssueDate"`
+	DueDate                     string                 `xml:"cbc:DueDate,omitempty"`
+	InvoiceTypeCode             string                 `xml:"cbc:InvoiceTypeCode,omitempty"`
+	CreditNoteTypeCode          string                 `xml:"cbc:CreditNoteTypeCode,omitempty"`
+	DocumentCurrencyCode        string                 `xml:"cbc:DocumentCurrencyCode"`
+	BuyerReference              string                 `xml:"cbc:BuyerReference,omitempty"`
+	BillingReference            *ublBillingReference   `xml:"cac:BillingReference,omitempty"`
+	AdditionalDocumentReference []ublDocumentReference `xml:"cac:AdditionalDocumentReference"`
+	AccountingSupplierParty     ublPartyWrapper        `xml:"cac:AccountingSupplierParty"`
+	AccountingCustomerParty     ublPartyWrapper        `xml:"cac:AccountingCustomerParty"`
+	PaymentMeans                []ublPaymentMeans      `xml:"cac:PaymentMeans"`
+	TaxTotal                    ublTaxTotal            `xml:"cac:TaxTotal"`
+	LegalMonetaryTotal          ublMonetaryTotal       `xml:"cac:LegalMonetaryTotal"`
+	InvoiceLines                []ublLine              `xml:"cac:InvoiceLine"`
+	CreditNoteLines             []ublLine              `xml:"cac:CreditNoteLine"`
+}
+
+// UBLViolation is a single failed business rule, identified by its schematron rule ID
+type UBLViolation struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// UBLValidationError is returned when a document does not satisfy the NLCIUS rules
+type UBLValidationError struct {
+	Violations []UBLViolation
+}
+
+func (e *UBLValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		msgs = append(msgs, fmt.Sprintf("[%s] %s", v.Rule, v.Message))
+	}
+	return "invalid UBL document: " + strings.Join(msgs, "; ")
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+func formatAmount(v float64) string {
+	return fmt.Sprintf("%.2f", round2(v))
+}
+
+func eur(v float64) ublAmount {
+	return ublAmount{CurrencyID: ublCurrency, Value: formatAmount(v)}
+}
+
+// vatPercent returns the VAT rate of an invoice line. Credit notes created from an
+// invoice don't carry the rate, so it is derived from the totals in that case.
+func vatPercent(line InvoiceDetails) float64 {
+	if line.Vat > 0 {
+		return line.Vat
+	}
+	if line.PreVatTotal == 0 || line.Total == line.PreVatTotal {
+		return 0
+	}
+	return math.Round((line.Total/line.PreVatTotal - 1) * 100)
+}
+
+func taxCategoryFor(percent float64) string {
+	if percent <= 0 {
+		return ublTaxCategoryExempt
+	}
+	return ublTaxCategoryStandard
+}
+
+func buildUBLParty(p UBLParty) ublParty {
+	country := p.CountryCode
+	if country == "" {
+		country = ublCountryNL
+	}
+	party := ublParty{
+		PartyName: ublPartyName{Name: p.Name},
+		PostalAddress: ublPostalAddress{
+			StreetName: p.Street,
+			CityName:   p.City,
+			PostalZone: p.PostalCode,
+			Country:    ublCountry{IdentificationCode: country},
+		},
+		PartyLegalEntity: ublPartyLegalEntity{RegistrationName: p.Name},
+	}
+	if p.KvkNumber != "" {
+		party.EndpointID = &ublIdentifier{SchemeID: ublSchemeKVK, Value: p.KvkNumber}
+		party.PartyLegalEntity.CompanyID = &ublIdentifier{SchemeID: ublSchemeKVK, Value: p.KvkNumber}
+	}
+	if p.BtwNumber != "" {
+		party.PartyTaxScheme = &ublPartyTaxScheme{
+			CompanyID: p.BtwNumber,
+			TaxScheme: ublTaxScheme{ID: "VAT"},
+		}
+	}
+	if p.Email != "" || p.Phone != "" {
+		party.Contact = &ublContact{Telephone: p.Phone, ElectronicMail: p.Email}
+	}
+	return party
+}
+
+// NewUBLDocument maps the invoice data onto a UBL Invoice (or CreditNote) document.
+// Credit notes are stored with negative amounts, UBL expects them positive.
+func NewUBLDocument(input UBLInput) *UBLDocument {
+	isCreditNote := input.InvoiceType == "credit_note"
+	sign := 1.0
+	if isCreditNote {
+		sign = -1.0
+	}
+
+	doc := &UBLDocument{
+		XMLNSCAC:                ublCACNamespace,
+		XMLNSCBC:                ublCBCNamespace,
+		CustomizationID:         UBLCustomizationID,
+		ProfileID:               UBLProfileID,
+		ID:                      input.InvoiceNumber,
+		IssueDate:               input.IssueDate.Format("2006-01-02"),
+		DocumentCurrencyCode:    ublCurrency,
+		BuyerReference:          input.BuyerReference,
+		AccountingSupplierParty: ublPartyWrapper{Party: buildUBLParty(input.Supplier)},
+		AccountingCustomerParty: ublPartyWrapper{Party: buildUBLParty(input.Customer)},
+	}
+	if doc.BuyerReference == "" {
+		doc.BuyerReference = input.InvoiceNumber
+	}
+
+	if isCreditNote {
+		doc.XMLName = xml.Name{Local: "CreditNote"}
+		doc.XMLNS = ublCreditNoteNamespace
+		doc.CreditNoteTypeCode = ublCreditNoteTypeCode
+		if input.OriginalInvoiceNumber != "" {
+			doc.BillingReference = &ublBillingReference{
+				InvoiceDocumentReference: ublDocumentReference{ID: input.OriginalInvoiceNumber},
+			}
+		}
+	} else {
+		doc.XMLName = xml.Name{Local: "Invoice"}
+		doc.XMLNS = ublInvoiceNamespace
+		doc.InvoiceTypeCode = ublInvoiceTypeCode
+		if !input.DueDate.IsZero() {
+			doc.DueDate = input.DueDate.Format("2006-01-02")
+		}
+		// the invoice number is the payment reference, the bank statement matching looks for it
+		if iban := strings.ReplaceAll(input.SupplierIBAN, " ", ""); iban != "" {
+			doc.PaymentMeans = append(doc.PaymentMeans, ublPaymentMeans{
+				PaymentMeansCode:      ublPaymentMeansCreditTransfer,
+				PaymentID:             input.InvoiceNumber,
+				PayeeFinancialAccount: &ublFinancialAccount{ID: strings.ToUpper(iban)},
+			})
+		}
+	}
+
+	if len(input.PDF) > 0 {
+		fileName := input.PDFFileName
+		if fileName == "" {
+			fileName = "Invoice_" + input.InvoiceNumber + ".pdf"
+		}
+		doc.AdditionalDocumentReference = append(doc.AdditionalDocumentReference, ublDocumentReference{
+			ID: input.InvoiceNumber,
+			Attachment: &ublAttachment{
+				EmbeddedDocumentBinaryObject: ublBinaryObject{
+					MimeCode: "application/pdf",
+					Filename: fileName,
+					Value:    base64.StdEncoding.EncodeToString(input.PDF),
+				},
+			},
+		})
+	}
+
+	// group the line totals per tax category and rate
+	type taxKey struct {
+		category string
+		percent  float64
+	}
+	taxable := make(map[taxKey]float64)
+	var taxOrder []taxKey
+	var lineTotal float64
+
+	lines := make([]ublLine, 0, len(input.Lines))
+	for i, detail := range input.Lines {
+		percent := vatPercent(detail)
+		category := taxCategoryFor(percent)
+		if category == ublTaxCategoryExempt {
+			percent = 0
+		}
+		net := round2(sign * detail.PreVatTotal)
+		lineTotal += net
+
+		key := taxKey{category: category, percent: percent}
+		if _, ok := taxable[key]; !ok {
+			taxOrder = append(taxOrder, key)
+		}
+		taxable[key] += net
+
+		name := detail.ContractType
+		if name == "" {
+			name = "Zorg"
+		}
+		line := ublLine{
+			ID:                  fmt.Sprintf("%d", i+1),
+			LineExtensionAmount: eur(net),
+			Item: ublItem{
+				Name: fmt.Sprintf("%s (contract %d)", name, detail.ContractID),
+				ClassifiedTaxCategory: ublClassifiedTaxCategory{
+					ID:        category,
+					Percent:   formatAmount(percent),
+					TaxScheme: ublTaxScheme{ID: "VAT"},
+				},
+			},
+			Price: ublPrice{PriceAmount: eur(net)},
+		}
+		quantity := &ublQuantity{UnitCode: ublUnitCode, Value: "1"}
+		if isCreditNote {
+			line.CreditedQuantity = quantity
+		} else {
+			line.InvoicedQuantity = quantity
+		}
+		if len(detail.Periods) > 0 {
+			start, end := detail.Periods[0].StartDate, detail.Periods[0].EndDate
+			for _, p := range detail.Periods[1:] {
+				if p.StartDate.Before(start) {
+					start = p.StartDate
+				}
+				if p.EndDate.After(end) {
+					end = p.EndDate
+				}
+			}
+			line.InvoicePeriod = &ublPeriod{
+				StartDate: start.Format("2006-01-02"),
+				EndDate:   end.Format("2006-01-02"),
+			}
+		}
+		lines = append(lines, line)
+	}
+	if isCreditNote {
+		doc.CreditNoteLines = lines
+	} else {
+		doc.InvoiceLines = lines
+	}
+
+	var taxTotal float64
+	for _, key := range taxOrder {
+		amount := round2(taxable[key] * key.percent / 100)
+		taxTotal += amount
+		category := ublTaxCategory{
+			ID:        key.category,
+			Percent:   formatAmount(key.percent),
+			TaxScheme: ublTaxScheme{ID: "VAT"},
+		}
+		if key.category == ublTaxCategoryExempt {
+			category.TaxExemptionReasonCode = "VATEX-EU-132-1C"
+			category.TaxExemptionReason = ublExemptionReason
+		}
+		doc.TaxTotal.TaxSubtotals = append(doc.TaxTotal.TaxSubtotals, ublTaxSubtotal{
+			TaxableAmount: eur(taxable[key]),
+			TaxAmount:     eur(amount),
+			TaxCategory:   category,
+		})
+	}
+	doc.TaxTotal.TaxAmount = eur(taxTotal)
+
+	doc.LegalMonetaryTotal = ublMonetaryTotal{
+		LineExtensionAmount: eur(lineTotal),
+		TaxExclusiveAmount:  eur(lineTotal),
+		TaxInclusiveAmount:  eur(lineTotal + taxTotal),
+		PayableAmount:       eur(lineTotal + taxTotal),
+	}
+	return doc
+}
+
+// Lines returns the invoice or credit note lines of the document
+func (d *UBLDocument) Lines() []ublLine {
+	if d.XMLName.Local == "CreditNote" {
+		return d.CreditNoteLines
+	}
+	return d.InvoiceLines
+}
+
+// Marshal renders the document as XML including the declaration header
+func (d *UBLDocument) Marshal() ([]byte, error) {
+	out, err := xml.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal UBL document: %w", err)
+	}
+	return append([]byte(xml.Header), out...), nil
+}
+
+// GenerateUBL builds, validates and renders the UBL document for an invoice
+func GenerateUBL(input UBLInput) ([]byte, error) {
+	doc := NewUBLDocument(input)
+	if violations := ValidateUBL(doc); len(violations) > 0 {
+		return nil, &UBLValidationError{Violations: violations}
+	}
+	return doc.Marshal()
+}
@@ -0,0 +1,210 @@
+package invoice
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func validUBLInput() UBLInput {
+	return UBLInput{
+		InvoiceNumber: "INV-20250101-0001",
+		InvoiceType:   "standard",
+		IssueDate:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		DueDate:       time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+		Supplier: UBLParty{
+			Name:       "Maicare Zorg B.V.",
+			Street:     "Voorbeeldstraat 123",
+			City:       "Amsterdam",
+			PostalCode: "1011AB",
+			KvkNumber:  "12345678",
+			BtwNumber:  "NL123456789B01",
+		},
+		SupplierIBAN: "NL91 ABNA 0417 1643 00",
+		Customer: UBLParty{
+			Name:       "Gemeente Amsterdam",
+			Street:     "Amstel 1",
+			City:       "Amsterdam",
+			PostalCode: "1011PN",
+			KvkNumber:  "34366966",
+		},
+		Lines: []InvoiceDetails{
+			{
+				ContractID:   1,
+				ContractType: "accommodation",
+				PreVatTotal:  1000,
+				Total:        1000,
+				Periods: []InvoicePeriod{
+					{StartDate: time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)},
+				},
+			},
+			{
+				ContractID:   2,
+				ContractType: "ambulante",
+				PreVatTotal:  200,
+				Total:        242,
+				Vat:          21,
+			},
+		},
+		PDF: []byte("%PDF-1.4 test"),
+	}
+}
+
+func requireRule(t *testing.T, violations []UBLViolation, rule string) {
+	t.Helper()
+	for _, v := range violations {
+		if v.Rule == rule {
+			return
+		}
+	}
+	t.Fatalf("expected violation %s, got %v", rule, violations)
+}
+
+func TestGenerateUBLInvoice(t *testing.T) {
+	out, err := GenerateUBL(validUBLInput())
+	require.NoError(t, err)
+
+	xmlDoc := string(out)
+	require.True(t, strings.HasPrefix(xmlDoc, "<?xml"))
+	require.Contains(t, xmlDoc, `<Invoice xmlns="urn:oasis:names:specification:ubl:schema:xsd:Invoice-2"`)
+	require.Contains(t, xmlDoc, "<cbc:CustomizationID>"+UBLCustomizationID+"</cbc:CustomizationID>")
+	require.Contains(t, xmlDoc, "<cbc:InvoiceTypeCode>380</cbc:InvoiceTypeCode>")
+	require.Contains(t, xmlDoc, `<cbc:CompanyID schemeID="0106">12345678</cbc:CompanyID>`)
+	require.Contains(t, xmlDoc, `<cbc:EmbeddedDocumentBinaryObject mimeCode="application/pdf" filename="Invoice_INV-20250101-0001.pdf">`)
+	require.Contains(t, xmlDoc, `<cbc:LineExtensionAmount currencyID="EUR">1200.00</cbc:LineExtensionAmount>`)
+	require.Contains(t, xmlDoc, `<cbc:PayableAmount currencyID="EUR">1242.00</cbc:PayableAmount>`)
+	require.Contains(t, xmlDoc, "<cbc:TaxExemptionReasonCode>VATEX-EU-132-1C</cbc:TaxExemptionReasonCode>")
+	require.Contains(t, xmlDoc, "<cbc:PaymentMeansCode>30</cbc:PaymentMeansCode>")
+	require.Contains(t, xmlDoc, "<cbc:PaymentID>INV-20250101-0001</cbc:PaymentID>")
+	require.Contains(t, xmlDoc, "<cbc:ID>NL91ABNA0417164300</cbc:ID>")
+	// the payment means come after the parties and before the totals
+	require.Less(t, strings.Index(xmlDoc, "<cac:AccountingCustomerParty>"), strings.Index(xmlDoc, "<cac:PaymentMeans>"))
+	require.Less(t, strings.Index(xmlDoc, "<cac:PaymentMeans>"), strings.Index(xmlDoc, "<cac:TaxTotal>"))
+}
+
+func TestGenerateUBLCreditNote(t *testing.T) {
+	input := validUBLInput()
+	input.InvoiceType = "credit_note"
+	input.OriginalInvoiceNumber = "INV-20241201-0003"
+	for i := range input.Lines {
+		input.Lines[i].PreVatTotal = -input.Lines[i].PreVatTotal
+		input.Lines[i].Total = -input.Lines[i].Total
+		input.Lines[i].Vat = 0
+	}
+
+	doc := NewUBLDocument(input)
+	require.Empty(t, ValidateUBL(doc))
+	require.Len(t, doc.CreditNoteLines, 2)
+	require.Empty(t, doc.InvoiceLines)
+	require.Equal(t, "1242.00", doc.LegalMonetaryTotal.PayableAmount.Value)
+	// the rate is derived from the totals when the credit note doesn't carry it
+	require.Equal(t, "21.00", doc.CreditNoteLines[1].Item.ClassifiedTaxCategory.Percent)
+
+	out, err := doc.Marshal()
+	require.NoError(t, err)
+	require.Contains(t, string(out), "<CreditNote xmlns=")
+	require.Contains(t, string(out), "<cbc:CreditNoteTypeCode>381</cbc:CreditNoteTypeCode>")
+	require.Contains(t, string(out), "<cac:BillingReference>")
+	require.NotContains(t, string(out), "<cac:PaymentMeans>")
+}
+
+func TestValidateUBLRules(t *testing.T) {
+	testCases := []struct {
+		name        string
+		rule        string
+		mutateInput func(input *UBLInput)
+		mutateDoc   func(doc *UBLDocument)
+	}{
+		{
+			name:      "missing invoice number",
+			rule:      "BR-02",
+			mutateDoc: func(doc *UBLDocument) { doc.ID = "" },
+		},
+		{
+			name:        "no lines",
+			rule:        "BR-16",
+			mutateInput: func(input *UBLInput) { input.Lines = nil },
+		},
+		{
+			name:      "line sum mismatch",
+			rule:      "BR-CO-10",
+			mutateDoc: func(doc *UBLDocument) { doc.LegalMonetaryTotal.LineExtensionAmount = eur(1) },
+		},
+		{
+			name:      "tax inclusive mismatch",
+			rule:      "BR-CO-15",
+			mutateDoc: func(doc *UBLDocument) { doc.LegalMonetaryTotal.TaxInclusiveAmount = eur(1) },
+		},
+		{
+			name: "missing exemption reason",
+			rule: "BR-E-10",
+			mutateDoc: func(doc *UBLDocument) {
+				doc.TaxTotal.TaxSubtotals[0].TaxCategory.TaxExemptionReason = ""
+				doc.TaxTotal.TaxSubtotals[0].TaxCategory.TaxExemptionReasonCode = ""
+			},
+		},
+		{
+			name:        "supplier without VAT identifier",
+			rule:        "BR-S-02",
+			mutateInput: func(input *UBLInput) { input.Supplier.BtwNumber = "" },
+		},
+		{
+			name:        "supplier without KVK",
+			rule:        "BR-NL-1",
+			mutateInput: func(input *UBLInput) { input.Supplier.KvkNumber = "" },
+		},
+		{
+			name: "credit note without invoice reference",
+			rule: "BR-NL-2",
+			mutateInput: func(input *UBLInput) {
+				input.InvoiceType = "credit_note"
+				input.OriginalInvoiceNumber = ""
+			},
+		},
+		{
+			name:        "supplier address incomplete",
+			rule:        "BR-NL-3",
+			mutateInput: func(input *UBLInput) { input.Supplier.PostalCode = "" },
+		},
+		{
+			name:        "customer address incomplete",
+			rule:        "BR-NL-4",
+			mutateInput: func(input *UBLInput) { input.Customer.City = "" },
+		},
+		{
+			name: "credit transfer without account",
+			rule: "BR-61",
+			mutateDoc: func(doc *UBLDocument) {
+				doc.PaymentMeans[0].PayeeFinancialAccount = nil
+			},
+		},
+		{
+			name: "customer legal id with foreign scheme",
+			rule: "BR-NL-5",
+			mutateDoc: func(doc *UBLDocument) {
+				doc.AccountingCustomerParty.Party.PartyLegalEntity.CompanyID.SchemeID = "0088"
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := validUBLInput()
+			if tc.mutateInput != nil {
+				tc.mutateInput(&input)
+
+				_, err := GenerateUBL(input)
+				var validationErr *UBLValidationError
+				require.ErrorAs(t, err, &validationErr)
+			}
+
+			doc := NewUBLDocument(input)
+			if tc.mutateDoc != nil {
+				tc.mutateDoc(doc)
+			}
+			requireRule(t, ValidateUBL(doc), tc.rule)
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package invoice
+
+import (
+	"strconv"
+)
+
+// ValidateUBL checks a document against the EN 16931 business rules and the
+// NLCIUS (BR-NL) additions that apply to the documents we produce. It is not the
+// official schematron and only covers the rules our documents can break, the
+// rule IDs are the schematron ones so a rejection can be traced back.
+func ValidateUBL(doc *UBLDocument) []UBLViolation {
+	var violations []UBLViolation
+	fail := func(rule, message string) {
+		violations = append(violations, UBLViolation{Rule: rule, Message: message})
+	}
+
+	isCreditNote := doc.XMLName.Local == "CreditNote"
+	supplier := doc.AccountingSupplierParty.Party
+	customer := doc.AccountingCustomerParty.Party
+
+	// EN 16931 core rules
+	if doc.CustomizationID == "" {
+		fail("BR-01", "an invoice shall have a specification identifier")
+	}
+	if doc.ID == "" {
+		fail("BR-02", "an invoice shall have an invoice number")
+	}
+	if doc.IssueDate == "" {
+		fail("BR-03", "an invoice shall have an invoice issue date")
+	}
+	if (isCreditNote && doc.CreditNoteTypeCode == "") || (!isCreditNote && doc.InvoiceTypeCode == "") {
+		fail("BR-04", "an invoice shall have an invoice type code")
+	}
+	if doc.DocumentCurrencyCode == "" {
+		fail("BR-05", "an invoice shall have an invoice currency code")
+	}
+	if supplier.PartyLegalEntity.RegistrationName == "" {
+		fail("BR-06", "an invoice shall contain the seller name")
+	}
+	if customer.PartyLegalEntity.RegistrationName == "" {
+		fail("BR-07", "an invoice shall contain the buyer name")
+	}
+	if supplier.PostalAddress.Country.IdentificationCode == "" {
+		fail("BR-09", "the seller postal address shall contain a country code")
+	}
+	if customer.PostalAddress.Country.IdentificationCode == "" {
+		fail("BR-11", "the buyer postal address shall contain a country code")
+	}
+
+	lines := doc.Lines()
+	if len(lines) == 0 {
+		fail("BR-16", "an invoice shall have at least one invoice line")
+	}
+
+	var lineSum float64
+	for _, line := range lines {
+		if line.ID == "" {
+			fail("BR-21", "each invoice line shall have an invoice line identifier")
+		}
+		if line.LineExtensionAmount.Value == "" {
+			fail("BR-24", "each invoice line shall have an invoice line net amount")
+		}
+		if line.Item.Name == "" {
+			fail("BR-25", "each invoice line shall contain the item name")
+		}
+		lineSum += parseAmount(line.LineExtensionAmount.Value)
+	}
+
+	totals := doc.LegalMonetaryTotal
+	lineExtension := parseAmount(totals.LineExtensionAmount.Value)
+	taxExclusive := parseAmount(totals.TaxExclusiveAmount.Value)
+	taxInclusive := parseAmount(totals.TaxInclusiveAmount.Value)
+	payable := parseAmount(totals.PayableAmount.Value)
+	taxAmount := parseAmount(doc.TaxTotal.TaxAmount.Value)
+
+	if round2(lineSum) != round2(lineExtension) {
+		fail("BR-CO-10", "sum of invoice line net amounts must equal the line extension amount")
+	}
+	if round2(taxExclusive) != round2(lineExtension) {
+		fail("BR-CO-13", "invoice total amount without VAT must equal the sum of line net amounts")
+	}
+	if round2(taxInclusive) != round2(taxExclusive+taxAmount) {
+		fail("BR-CO-15", "invoice total amount with VAT must equal the amount without VAT plus the VAT total")
+	}
+	if round2(payable) != round2(taxInclusive) {
+		fail("BR-CO-16", "amount due for payment must equal the total amount with VAT")
+	}
+
+	for _, means := range doc.PaymentMeans {
+		if means.PaymentMeansCode == "" {
+			fail("BR-49", "a payment instruction shall specify the payment means type code")
+		}
+		if means.PaymentMeansCode == ublPaymentMeansCreditTransfer && (means.PayeeFinancialAccount == nil || means.PayeeFinancialAccount.ID == "") {
+			fail("BR-61", "a credit transfer shall contain the payment account identifier")
+		}
+	}
+
+	var subtotalTax float64
+	hasVatID := supplier.PartyTaxScheme != nil && supplier.PartyTaxScheme.CompanyID != ""
+	for _, sub := range doc.TaxTotal.TaxSubtotals {
+		taxable := parseAmount(sub.TaxableAmount.Value)
+		amount := parseAmount(sub.TaxAmount.Value)
+		percent := parseAmount(sub.TaxCategory.Percent)
+		subtotalTax += amount
+
+		if round2(amount) != round2(taxable*percent/100) {
+			fail("BR-CO-17", "VAT category tax amount must equal the taxable amount multiplied by the rate")
+		}
+		switch sub.TaxCategory.ID {
+		case ublTaxCategoryStandard:
+			if !hasVatID {
+				fail("BR-S-02", "an invoice with standard rated VAT shall contain the seller VAT identifier")
+			}
+			if percent <= 0 {
+				fail("BR-S-05", "standard rated VAT lines shall have a rate greater than zero")
+			}
+		case ublTaxCategoryExempt:
+			if !hasVatID {
+				fail("BR-E-02", "an invoice with VAT exempt lines shall contain the seller VAT identifier")
+			}
+			if amount != 0 {
+				fail("BR-E-09", "VAT amount for the exempt category shall be zero")
+			}
+			if sub.TaxCategory.TaxExemptionReason == "" && sub.TaxCategory.TaxExemptionReasonCode == "" {
+				fail("BR-E-10", "an exempt VAT breakdown shall have an exemption reason")
+			}
+		}
+	}
+	if round2(subtotalTax) != round2(taxAmount) {
+		fail("BR-CO-14", "invoice VAT total must equal the sum of the VAT category tax amounts")
+	}
+
+	// NLCIUS rules, both parties of our invoices are Dutch
+	if supplier.PostalAddress.Country.IdentificationCode == ublCountryNL {
+		if !hasDutchLegalID(supplier.PartyLegalEntity.CompanyID) {
+			fail("BR-NL-1", "a Dutch supplier shall provide a KVK (0106) or OIN (0190) legal entity identifier")
+		}
+		if isCreditNote && (doc.BillingReference == nil || doc.BillingReference.InvoiceDocumentReference.ID == "") {
+			fail("BR-NL-2", "a credit note of a Dutch supplier shall reference the corrected invoice")
+		}
+		if supplier.PostalAddress.StreetName == "" || supplier.PostalAddress.CityName == "" || supplier.PostalAddress.PostalZone == "" {
+			fail("BR-NL-3", "a Dutch supplier address shall contain street, city and postal code")
+		}
+	}
+	if customer.PostalAddress.Country.IdentificationCode == ublCountryNL {
+		if customer.PostalAddress.StreetName == "" || customer.PostalAddress.CityName == "" || customer.PostalAddress.PostalZone == "" {
+			fail("BR-NL-4", "a Dutch customer address shall contain street, city and postal code")
+		}
+		if customer.PartyLegalEntity.CompanyID != nil && !hasDutchLegalID(customer.PartyLegalEntity.CompanyID) {
+			fail("BR-NL-5", "a Dutch customer legal entity identifier shall use the KVK (0106) or OIN (0190) scheme")
+		}
+	}
+
+	return violations
+}
+
+func hasDutchLegalID(id *ublIdentifier) bool {
+	if id == nil || id.Value == "" {
+		return false
+	}
+	return id.SchemeID == ublSchemeKVK || id.SchemeID == ublSchemeOIN
+}
+
+func parseAmount(v string) float64 {
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0
+	}
+	return f
+}
@@ -0,0 +1,552 @@
+package leave
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	db "maicare_go/db/sqlc"
+	"maicare_go/logger"
+	"maicare_go/util"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+// Statuses of a leave request
+const (
+	StatusPending   = "pending"
+	StatusApproved  = "approved"
+	StatusRejected  = "rejected"
+	StatusCancelled = "cancelled"
+)
+
+// StatutoryLeaveWeeks is the leave an employee builds up in a year, in weeks of their
+// contract hours
+const StatutoryLeaveWeeks = 4
+
+var (
+	ErrLeaveTypeNotFound        = errors.New("leave type not found")
+	ErrLeaveTypeExists          = errors.New("a leave type with this code already exists")
+	ErrLeaveRequestNotFound     = errors.New("leave request not found")
+	ErrEmployeeNotFound         = errors.New("employee not found")
+	ErrInvalidLeaveRequest      = errors.New("invalid leave request")
+	ErrLeaveOverlap             = errors.New("the employee already has leave in this period")
+	ErrInsufficientLeaveBalance = errors.New("not enough leave hours left")
+	ErrLeaveRequestClosed       = errors.New("the leave request can no longer be changed")
+	ErrNotOwnLeaveRequest       = errors.New("the leave request belongs to another employee")
+	ErrOwnLeaveRequest          = errors.New("employees cannot review their own leave requests")
+)
+
+// ListLeaveTypes lists all leave types, also the ones that can no longer be requested
+func (s *leaveService) ListLeaveTypes(ctx context.Context) ([]LeaveTypeResponse, error) {
+	rows, err := s.Store.ListLeaveTypes(ctx)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ListLeaveTypes", "Failed to list leave types", zap.Error(err))
+		return nil, fmt.Errorf("failed to list leave types")
+	}
+	types := make([]LeaveTypeResponse, len(rows))
+	for i, row := range rows {
+		types[i] = leaveTypeResponse(row)
+	}
+	return types, nil
+}
+
+func (s *leaveService) CreateLeaveType(ctx context.Context, req *CreateLeaveTypeRequest) (*LeaveTypeResponse, error) {
+	code := strings.ToLower(strings.TrimSpace(req.Code))
+	existing, err := s.Store.ListLeaveTypes(ctx)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CreateLeaveType", "Failed to list leave types", zap.Error(err))
+		return nil, fmt.Errorf("failed to create leave type")
+	}
+	for _, t := range existing {
+		if t.Code == code {
+			return nil, ErrLeaveTypeExists
+		}
+	}
+
+	row, err := s.Store.CreateLeaveType(ctx, db.CreateLeaveTypeParams{
+		Code:           code,
+		Name:           req.Name,
+		Paid:           req.Paid,
+		DeductsBalance: req.DeductsBalance,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CreateLeaveType", "Failed to create leave type", zap.Error(err))
+		return nil, fmt.Errorf("failed to create leave type")
+	}
+	resp := leaveTypeResponse(row)
+	return &resp, nil
+}
+
+// UpdateLeaveType changes a leave type. Inactive types can no longer be requested, the
+// leave that was taken with them is kept.
+func (s *leaveService) UpdateLeaveType(ctx context.Context, leaveTypeID int64, req *UpdateLeaveTypeRequest) (*LeaveTypeResponse, error) {
+	row, err := s.Store.UpdateLeaveType(ctx, db.UpdateLeaveTypeParams{
+		ID:             leaveTypeID,
+		Name:           req.Name,
+		Paid:           req.Paid,
+		DeductsBalance: req.DeductsBalance,
+		IsActive:       req.IsActive,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrLeaveTypeNotFound
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "UpdateLeaveType", "Failed to update leave type", zap.Error(err), zap.Int64("leave_type_id", leaveTypeID))
+		return nil, fmt.Errorf("failed to update leave type")
+	}
+	resp := leaveTypeResponse(row)
+	return &resp, nil
+}
+
+// CreateLeaveRequest requests leave for the employee. Leave that deducts from the balance
+// cannot take more hours than the employee has available in the year.
+func (s *leaveService) CreateLeaveRequest(ctx context.Context, employeeID int64, req *CreateLeaveRequestRequest) (*LeaveRequestResponse, error) {
+	start, end, err := parseLeavePeriod(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	leaveType, err := s.Store.GetLeaveType(ctx, req.LeaveTypeID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: unknown leave type", ErrInvalidLeaveRequest)
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CreateLeaveRequest", "Failed to get leave type", zap.Error(err), zap.Int64("leave_type_id", req.LeaveTypeID))
+		return nil, fmt.Errorf("failed to create leave request")
+	}
+	if !leaveType.IsActive {
+		return nil, fmt.Errorf("%w: %s can no longer be requested", ErrInvalidLeaveRequest, leaveType.Name)
+	}
+
+	contract, err := s.Store.GetEmployeeContractDetails(ctx, employeeID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrEmployeeNotFound
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CreateLeaveRequest", "Failed to get contract details", zap.Error(err), zap.Int64("employee_id", employeeID))
+		return nil, fmt.Errorf("failed to create leave request")
+	}
+	hours := leaveHours(contractHours(contract.ContractHours), start, end)
+	if req.Hours != nil {
+		// the hours of a longer period always follow from the contract, so they cannot be
+		// lowered to get around the balance
+		if !start.Equal(end) || *req.Hours <= 0 {
+			return nil, fmt.Errorf("%w: hours can only be given for part of a single day", ErrInvalidLeaveRequest)
+		}
+		hours = *req.Hours
+	}
+
+	absences, err := s.Store.ListEmployeesAbsences(ctx, db.ListEmployeesAbsencesParams{
+		EmployeeIds: []int64{employeeID},
+		StartDate:   pgtype.Date{Time: start, Valid: true},
+		EndDate:     pgtype.Date{Time: end, Valid: true},
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CreateLeaveRequest", "Failed to list absences", zap.Error(err), zap.Int64("employee_id", employeeID))
+		return nil, fmt.Errorf("failed to create leave request")
+	}
+	for _, a := range absences {
+		if a.Kind == "leave" {
+			return nil, ErrLeaveOverlap
+		}
+	}
+
+	if leaveType.DeductsBalance {
+		balance, err := s.GetLeaveBalance(ctx, employeeID, int32(start.Year()))
+		if err != nil {
+			return nil, err
+		}
+		if hours > balance.AvailableHours {
+			return nil, fmt.Errorf("%w: %.2f hours requested, %.2f available in %d", ErrInsufficientLeaveBalance, hours, balance.AvailableHours, start.Year())
+		}
+	}
+
+	created, err := s.Store.CreateLeaveRequest(ctx, db.CreateLeaveRequestParams{
+		EmployeeID:  employeeID,
+		LeaveTypeID: leaveType.ID,
+		StartDate:   pgtype.Date{Time: start, Valid: true},
+		EndDate:     pgtype.Date{Time: end, Valid: true},
+		Hours:       hours,
+		Reason:      req.Reason,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CreateLeaveRequest", "Failed to create leave request", zap.Error(err), zap.Int64("employee_id", employeeID))
+		return nil, fmt.Errorf("failed to create leave request")
+	}
+	s.Logger.LogBusinessEvent(logger.LogLevelInfo, "CreateLeaveRequest", "Leave requested",
+		zap.Int64("leave_request_id", created.ID), zap.Int64("employee_id", employeeID), zap.Float64("hours", hours))
+	return s.getLeaveRequest(ctx, "CreateLeaveRequest", created.ID)
+}
+
+// ListLeaveRequests lists the leave requests that overlap a period
+func (s *leaveService) ListLeaveRequests(ctx context.Context, req *ListLeaveRequestsRequest) ([]LeaveRequestResponse, error) {
+	startDate, err := parseOptionalDate(req.StartDate, "start_date", ErrInvalidLeaveRequest)
+	if err != nil {
+		return nil, err
+	}
+	endDate, err := parseOptionalDate(req.EndDate, "end_date", ErrInvalidLeaveRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.Store.ListLeaveRequests(ctx, db.ListLeaveRequestsParams{
+		EmployeeID: req.EmployeeID,
+		LocationID: req.LocationID,
+		Status:     req.Status,
+		StartDate:  startDate,
+		EndDate:    endDate,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ListLeaveRequests", "Failed to list leave requests", zap.Error(err))
+		return nil, fmt.Errorf("failed to list leave requests")
+	}
+	requests := make([]LeaveRequestResponse, len(rows))
+	for i, row := range rows {
+		requests[i] = leaveRequestResponse(db.GetLeaveRequestRow(row))
+	}
+	return requests, nil
+}
+
+// CancelLeaveRequest withdraws a leave request of the employee, approved leave only until
+// it starts
+func (s *leaveService) CancelLeaveRequest(ctx context.Context, leaveRequestID, employeeID int64) (*LeaveRequestResponse, error) {
+	request, err := s.getLeaveRequest(ctx, "CancelLeaveRequest", leaveRequestID)
+	if err != nil {
+		return nil, err
+	}
+	if request.EmployeeID != employeeID {
+		return nil, ErrNotOwnLeaveRequest
+	}
+	if request.Status == StatusApproved && !request.StartDate.After(today()) {
+		return nil, fmt.Errorf("%w: the leave has already started", ErrLeaveRequestClosed)
+	}
+
+	if _, err := s.Store.CancelLeaveRequest(ctx, leaveRequestID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrLeaveRequestClosed
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CancelLeaveRequest", "Failed to cancel leave request", zap.Error(err), zap.Int64("leave_request_id", leaveRequestID))
+		return nil, fmt.Errorf("failed to cancel leave request")
+	}
+	return s.getLeaveRequest(ctx, "CancelLeaveRequest", leaveRequestID)
+}
+
+// ApproveLeaveRequest approves a pending leave request of another employee. The shifts
+// the employee is scheduled for during the leave are returned, and the planners of their
+// locations are notified that they need a replacement.
+func (s *leaveService) ApproveLeaveRequest(ctx context.Context, leaveRequestID, employeeID int64, req *ReviewLeaveRequestRequest) (*LeaveRequestResponse, error) {
+	request, err := s.reviewableLeaveRequest(ctx, "ApproveLeaveRequest", leaveRequestID, employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.DeductsBalance {
+		balance, err := s.GetLeaveBalance(ctx, request.EmployeeID, int32(request.StartDate.Year()))
+		if err != nil {
+			return nil, err
+		}
+		// the remaining hours only leave out the approved leave, so this and the other
+		// pending requests are not counted against it
+		if request.Hours > balance.RemainingHours {
+			return nil, fmt.Errorf("%w: %.2f hours requested, %.2f left in %d", ErrInsufficientLeaveBalance, request.Hours, balance.RemainingHours, request.StartDate.Year())
+		}
+	}
+
+	if err := s.reviewLeaveRequest(ctx, "ApproveLeaveRequest", leaveRequestID, employeeID, StatusApproved, req.Note); err != nil {
+		return nil, err
+	}
+	resp, err := s.getLeaveRequest(ctx, "ApproveLeaveRequest", leaveRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.AffectedShifts, err = s.affectedShifts(ctx, "ApproveLeaveRequest", resp.EmployeeID, resp.StartDate, resp.EndDate.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	id := resp.ID
+	s.notifyReplacement(ctx, "ApproveLeaveRequest", absentEmployee{
+		EmployeeID:     resp.EmployeeID,
+		FirstName:      resp.EmployeeFirstName,
+		LastName:       resp.EmployeeLastName,
+		Reason:         "leave",
+		LeaveRequestID: &id,
+	}, resp.AffectedShifts)
+	return resp, nil
+}
+
+// RejectLeaveRequest rejects a pending leave request of another employee
+func (s *leaveService) RejectLeaveRequest(ctx context.Context, leaveRequestID, employeeID int64, req *ReviewLeaveRequestRequest) (*LeaveRequestResponse, error) {
+	if _, err := s.reviewableLeaveRequest(ctx, "RejectLeaveRequest", leaveRequestID, employeeID); err != nil {
+		return nil, err
+	}
+	if err := s.reviewLeaveRequest(ctx, "RejectLeaveRequest", leaveRequestID, employeeID, StatusRejected, req.Note); err != nil {
+		return nil, err
+	}
+	return s.getLeaveRequest(ctx, "RejectLeaveRequest", leaveRequestID)
+}
+
+// GetLeaveBalance returns the leave of an employee in a year
+func (s *leaveService) GetLeaveBalance(ctx context.Context, employeeID int64, year int32) (*LeaveBalanceResponse, error) {
+	contract, err := s.Store.GetEmployeeContractDetails(ctx, employeeID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrEmployeeNotFound
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "GetLeaveBalance", "Failed to get contract details", zap.Error(err), zap.Int64("employee_id", employeeID))
+		return nil, fmt.Errorf("failed to get leave balance")
+	}
+	taken, err := s.Store.GetLeaveHoursTaken(ctx, db.GetLeaveHoursTakenParams{EmployeeID: employeeID, Year: year})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "GetLeaveBalance", "Failed to get leave taken", zap.Error(err), zap.Int64("employee_id", employeeID))
+		return nil, fmt.Errorf("failed to get leave balance")
+	}
+	adjustments, err := s.Store.ListLeaveBalanceAdjustments(ctx, db.ListLeaveBalanceAdjustmentsParams{EmployeeID: employeeID, Year: year})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "GetLeaveBalance", "Failed to list adjustments", zap.Error(err), zap.Int64("employee_id", employeeID))
+		return nil, fmt.Errorf("failed to get leave balance")
+	}
+
+	var contractStart, contractEnd *time.Time
+	if contract.ContractStartDate.Valid {
+		contractStart = &contract.ContractStartDate.Time
+	}
+	if contract.ContractEndDate.Valid {
+		contractEnd = &contract.ContractEndDate.Time
+	}
+	hours := contractHours(contract.ContractHours)
+	entitlement, accrued := leaveEntitlement(hours, contractStart, contractEnd, int(year), today())
+
+	balance := &LeaveBalanceResponse{
+		EmployeeID:       employeeID,
+		Year:             year,
+		ContractHours:    hours,
+		EntitlementHours: entitlement,
+		AccruedHours:     accrued,
+		ApprovedHours:    taken.ApprovedHours,
+		PendingHours:     taken.PendingHours,
+		Adjustments:      make([]LeaveBalanceAdjustmentResponse, len(adjustments)),
+	}
+	for i, a := range adjustments {
+		balance.AdjustmentHours += a.Hours
+		balance.Adjustments[i] = LeaveBalanceAdjustmentResponse{
+			ID:                  a.ID,
+			Hours:               a.Hours,
+			Reason:              a.Reason,
+			CreatedByEmployeeID: a.CreatedByEmployeeID,
+			CreatedAt:           a.CreatedAt.Time,
+		}
+	}
+	balance.AdjustmentHours = roundHours(balance.AdjustmentHours)
+	balance.RemainingHours = roundHours(entitlement + balance.AdjustmentHours - balance.ApprovedHours)
+	balance.AvailableHours = roundHours(balance.RemainingHours - balance.PendingHours)
+	return balance, nil
+}
+
+// AdjustLeaveBalance corrects the leave balance of an employee in a year
+func (s *leaveService) AdjustLeaveBalance(ctx context.Context, employeeID, createdBy int64, req *LeaveBalanceAdjustmentRequest) (*LeaveBalanceResponse, error) {
+	if _, err := s.Store.GetEmployeeContractDetails(ctx, employeeID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrEmployeeNotFound
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "AdjustLeaveBalance", "Failed to get employee", zap.Error(err), zap.Int64("employee_id", employeeID))
+		return nil, fmt.Errorf("failed to adjust leave balance")
+	}
+	_, err := s.Store.CreateLeaveBalanceAdjustment(ctx, db.CreateLeaveBalanceAdjustmentParams{
+		EmployeeID:          employeeID,
+		Year:                req.Year,
+		Hours:               req.Hours,
+		Reason:              req.Reason,
+		CreatedByEmployeeID: &createdBy,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "AdjustLeaveBalance", "Failed to create adjustment", zap.Error(err), zap.Int64("employee_id", employeeID))
+		return nil, fmt.Errorf("failed to adjust leave balance")
+	}
+	s.Logger.LogBusinessEvent(logger.LogLevelInfo, "AdjustLeaveBalance", "Leave balance adjusted",
+		zap.Int64("employee_id", employeeID), zap.Int32("year", req.Year), zap.Float64("hours", req.Hours), zap.Int64("created_by", createdBy))
+	return s.GetLeaveBalance(ctx, employeeID, req.Year)
+}
+
+// reviewableLeaveRequest returns a pending leave request that the employee may review
+func (s *leaveService) reviewableLeaveRequest(ctx context.Context, operation string, leaveRequestID, employeeID int64) (*LeaveRequestResponse, error) {
+	request, err := s.getLeaveRequest(ctx, operation, leaveRequestID)
+	if err != nil {
+		return nil, err
+	}
+	if request.EmployeeID == employeeID {
+		return nil, ErrOwnLeaveRequest
+	}
+	if request.Status != StatusPending {
+		return nil, ErrLeaveRequestClosed
+	}
+	return request, nil
+}
+
+func (s *leaveService) reviewLeaveRequest(ctx context.Context, operation string, leaveRequestID, employeeID int64, status string, note *string) error {
+	_, err := s.Store.ReviewLeaveRequest(ctx, db.ReviewLeaveRequestParams{
+		Status:               status,
+		ReviewNote:           note,
+		ReviewedByEmployeeID: &employeeID,
+		ID:                   leaveRequestID,
+	})
+	if err != nil {
+		// reviewed by someone else in the meantime
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrLeaveRequestClosed
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, operation, "Failed to review leave request", zap.Error(err), zap.Int64("leave_request_id", leaveRequestID))
+		return fmt.Errorf("failed to review leave request")
+	}
+	s.Logger.LogBusinessEvent(logger.LogLevelInfo, operation, "Leave request reviewed",
+		zap.Int64("leave_request_id", leaveRequestID), zap.String("status", status), zap.Int64("reviewed_by", employeeID))
+	return nil
+}
+
+func (s *leaveService) getLeaveRequest(ctx context.Context, operation string, leaveRequestID int64) (*LeaveRequestResponse, error) {
+	row, err := s.Store.GetLeaveRequest(ctx, leaveRequestID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrLeaveRequestNotFound
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, operation, "Failed to get leave request", zap.Error(err), zap.Int64("leave_request_id", leaveRequestID))
+		return nil, fmt.Errorf("failed to get leave request")
+	}
+	resp := leaveRequestResponse(row)
+	return &resp, nil
+}
+
+// parseLeavePeriod parses the first and last day of leave, which have to be in the same
+// year as the balance is per year
+func parseLeavePeriod(startDate, endDate string) (time.Time, time.Time, error) {
+	start, err := time.Parse("2006-01-02", startDate)
+	if err != nil {
+		return start, start, fmt.Errorf("%w: start_date must be YYYY-MM-DD", ErrInvalidLeaveRequest)
+	}
+	end, err := time.Parse("2006-01-02", endDate)
+	if err != nil {
+		return start, end, fmt.Errorf("%w: end_date must be YYYY-MM-DD", ErrInvalidLeaveRequest)
+	}
+	if end.Before(start) {
+		return start, end, fmt.Errorf("%w: the end date is before the start date", ErrInvalidLeaveRequest)
+	}
+	if end.Year() != start.Year() {
+		return start, end, fmt.Errorf("%w: leave cannot span two years, request it per year", ErrInvalidLeaveRequest)
+	}
+	return start, end, nil
+}
+
+// parseOptionalDate parses a YYYY-MM-DD date that may be left out
+func parseOptionalDate(value *string, name string, invalid error) (pgtype.Date, error) {
+	if value == nil {
+		return pgtype.Date{}, nil
+	}
+	t, err := time.Parse("2006-01-02", *value)
+	if err != nil {
+		return pgtype.Date{}, fmt.Errorf("%w: %s must be YYYY-MM-DD", invalid, name)
+	}
+	return pgtype.Date{Time: t, Valid: true}, nil
+}
+
+// leaveHours returns the hours leave from start up to and including end takes when the
+// weekly contract hours are spread over Monday to Friday
+func leaveHours(contractHours float64, start, end time.Time) float64 {
+	days := 0
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		if day.Weekday() != time.Saturday && day.Weekday() != time.Sunday {
+			days++
+		}
+	}
+	return roundHours(float64(days) * contractHours / 5)
+}
+
+// leaveEntitlement returns the leave hours of a year and the part of them accrued by the
+// given day. The statutory leave is four times the weekly contract hours, in proportion
+// to the days of the year the contract runs.
+func leaveEntitlement(contractHours float64, contractStart, contractEnd *time.Time, year int, day time.Time) (float64, float64) {
+	yearStart := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	yearEnd := yearStart.AddDate(1, 0, 0)
+	from, to := yearStart, yearEnd
+	if contractStart != nil && contractStart.After(from) {
+		from = *contractStart
+	}
+	if contractEnd != nil && contractEnd.AddDate(0, 0, 1).Before(to) {
+		to = contractEnd.AddDate(0, 0, 1)
+	}
+	if !from.Before(to) {
+		return 0, 0
+	}
+
+	perDay := StatutoryLeaveWeeks * contractHours / days(yearStart, yearEnd)
+	until := day.AddDate(0, 0, 1)
+	if until.After(to) {
+		until = to
+	}
+	accrued := 0.0
+	if until.After(from) {
+		accrued = perDay * days(from, until)
+	}
+	return roundHours(perDay * days(from, to)), roundHours(accrued)
+}
+
+func days(from, to time.Time) float64 {
+	return to.Sub(from).Hours() / 24
+}
+
+func roundHours(hours float64) float64 {
+	return math.Round(hours*100) / 100
+}
+
+func contractHours(hours *float64) float64 {
+	if hours == nil {
+		return 0
+	}
+	return *hours
+}
+
+func today() time.Time {
+	now := util.NetherlandsNow()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func leaveTypeResponse(row db.LeaveType) LeaveTypeResponse {
+	return LeaveTypeResponse{
+		ID:             row.ID,
+		Code:           row.Code,
+		Name:           row.Name,
+		Paid:           row.Paid,
+		DeductsBalance: row.DeductsBalance,
+		IsActive:       row.IsActive,
+		CreatedAt:      row.CreatedAt.Time,
+	}
+}
+
+func leaveRequestResponse(row db.GetLeaveRequestRow) LeaveRequestResponse {
+	resp := LeaveRequestResponse{
+		ID:                   row.ID,
+		EmployeeID:           row.EmployeeID,
+		EmployeeFirstName:    row.EmployeeFirstName,
+		EmployeeLastName:     row.EmployeeLastName,
+		LeaveTypeID:          row.LeaveTypeID,
+		LeaveTypeCode:        row.LeaveTypeCode,
+		LeaveTypeName:        row.LeaveTypeName,
+		DeductsBalance:       row.DeductsBalance,
+		StartDate:            row.StartDate.Time,
+		EndDate:              row.EndDate.Time,
+		Hours:                row.Hours,
+		Reason:               row.Reason,
+		Status:               row.Status,
+		ReviewNote:           row.ReviewNote,
+		ReviewedByEmployeeID: row.ReviewedByEmployeeID,
+		CreatedAt:            row.CreatedAt.Time,
+	}
+	if row.ReviewedAt.Valid {
+		resp.ReviewedAt = &row.ReviewedAt.Time
+	}
+	return resp
+}
@@ -0,0 +1,196 @@
+package leave
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LeaveTypeResponse is a kind of leave. Leave of a type that deducts from the balance is
+// taken from the yearly leave hours of the employee.
+type LeaveTypeResponse struct {
+	ID             int64     `json:"id"`
+	Code           string    `json:"code"`
+	Name           string    `json:"name"`
+	Paid           bool      `json:"paid"`
+	DeductsBalance bool      `json:"deducts_balance"`
+	IsActive       bool      `json:"is_active"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type CreateLeaveTypeRequest struct {
+	Code           string `json:"code" binding:"required,max=50" example:"study"`
+	Name           string `json:"name" binding:"required,max=100" example:"Studieverlof"`
+	Paid           bool   `json:"paid" example:"true"`
+	DeductsBalance bool   `json:"deducts_balance" example:"false"`
+}
+
+type UpdateLeaveTypeRequest struct {
+	Name           string `json:"name" binding:"required,max=100" example:"Studieverlof"`
+	Paid           bool   `json:"paid" example:"true"`
+	DeductsBalance bool   `json:"deducts_balance" example:"false"`
+	IsActive       bool   `json:"is_active" example:"true"`
+}
+
+// CreateLeaveRequestRequest requests leave from StartDate up to and including EndDate,
+// within one year. The leave takes the contract hours of the employee spread over the
+// working days, Monday to Friday. Hours is only given for part of a single day.
+type CreateLeaveRequestRequest struct {
+	LeaveTypeID int64    `json:"leave_type_id" binding:"required" example:"1"`
+	StartDate   string   `json:"start_date" binding:"required" example:"2025-07-14"`
+	EndDate     string   `json:"end_date" binding:"required" example:"2025-07-25"`
+	Hours       *float64 `json:"hours" binding:"omitempty,gt=0,max=24" example:"4"`
+	Reason      *string  `json:"reason" example:"Summer holiday"`
+}
+
+// ListLeaveRequestsRequest represents the query for the leave requests that overlap a
+// period, all of them when no dates are given
+type ListLeaveRequestsRequest struct {
+	EmployeeID *int64  `form:"employee_id"`
+	LocationID *int64  `form:"location_id"`
+	Status     *string `form:"status" binding:"omitempty,oneof=pending approved rejected cancelled"`
+	StartDate  *string `form:"start_date" example:"2025-07-01"`
+	EndDate    *string `form:"end_date" example:"2025-07-31"`
+}
+
+type ReviewLeaveRequestRequest struct {
+	Note *string `json:"note" example:"Enjoy your holiday"`
+}
+
+// AffectedShift is a shift of an absent employee that needs a replacement
+type AffectedShift struct {
+	ScheduleID   uuid.UUID `json:"schedule_id"`
+	LocationID   int64     `json:"location_id"`
+	LocationName string    `json:"location_name"`
+	ShiftName    *string   `json:"shift_name,omitempty"`
+	StartTime    time.Time `json:"start_time"`
+	EndTime      time.Time `json:"end_time"`
+}
+
+// LeaveRequestResponse is a leave request. AffectedShifts are returned when leave is
+// approved: the shifts of the employee during the leave, the planners are notified that
+// they need a replacement.
+type LeaveRequestResponse struct {
+	ID                   int64           `json:"id"`
+	EmployeeID           int64           `json:"employee_id"`
+	EmployeeFirstName    string          `json:"employee_first_name"`
+	EmployeeLastName     string          `json:"employee_last_name"`
+	LeaveTypeID          int64           `json:"leave_type_id"`
+	LeaveTypeCode        string          `json:"leave_type_code"`
+	LeaveTypeName        string          `json:"leave_type_name"`
+	DeductsBalance       bool            `json:"deducts_balance"`
+	StartDate            time.Time       `json:"start_date"`
+	EndDate              time.Time       `json:"end_date"`
+	Hours                float64         `json:"hours"`
+	Reason               *string         `json:"reason"`
+	Status               string          `json:"status" enums:"pending,approved,rejected,cancelled"`
+	ReviewNote           *string         `json:"review_note"`
+	ReviewedByEmployeeID *int64          `json:"reviewed_by_employee_id"`
+	ReviewedAt           *time.Time      `json:"reviewed_at"`
+	CreatedAt            time.Time       `json:"created_at"`
+	AffectedShifts       []AffectedShift `json:"affected_shifts,omitempty"`
+}
+
+// LeaveBalanceRequest represents the query for a leave balance, of the current year when
+// no year is given
+type LeaveBalanceRequest struct {
+	Year *int32 `form:"year" binding:"omitempty,min=2000,max=2100" example:"2025"`
+}
+
+// LeaveBalanceResponse is the leave of an employee in a year, in hours. The entitlement is
+// the statutory leave of four times the weekly contract hours, in proportion to the part
+// of the year the contract runs, of which Accrued has been built up so far. Remaining is
+// what is left after the approved leave, Available also leaves out the pending requests.
+type LeaveBalanceResponse struct {
+	EmployeeID       int64                            `json:"employee_id"`
+	Year             int32                            `json:"year"`
+	ContractHours    float64                          `json:"contract_hours"`
+	EntitlementHours float64                          `json:"entitlement_hours"`
+	AccruedHours     float64                          `json:"accrued_hours"`
+	AdjustmentHours  float64                          `json:"adjustment_hours"`
+	ApprovedHours    float64                          `json:"approved_hours"`
+	PendingHours     float64                          `json:"pending_hours"`
+	RemainingHours   float64                          `json:"remaining_hours"`
+	AvailableHours   float64                          `json:"available_hours"`
+	Adjustments      []LeaveBalanceAdjustmentResponse `json:"adjustments"`
+}
+
+type LeaveBalanceAdjustmentResponse struct {
+	ID                  int64     `json:"id"`
+	Hours               float64   `json:"hours"`
+	Reason              string    `json:"reason"`
+	CreatedByEmployeeID *int64    `json:"created_by_employee_id"`
+	CreatedAt           time.Time `json:"created_at"`
+}
+
+// LeaveBalanceAdjustmentRequest corrects the balance of a year, such as hours carried
+// over from the year before. Negative hours lower the balance.
+type LeaveBalanceAdjustmentRequest struct {
+	Year   int32   `json:"year" binding:"required,min=2000,max=2100" example:"2025"`
+	Hours  float64 `json:"hours" binding:"required" example:"16"`
+	Reason string  `json:"reason" binding:"required" example:"Carried over from 2024"`
+}
+
+// CreateSickReportRequest reports an employee sick from StartDate, today when not given
+type CreateSickReportRequest struct {
+	StartDate          *string `json:"start_date" example:"2025-03-03"`
+	ExpectedReturnDate *string `json:"expected_return_date" example:"2025-03-10"`
+	Notes              *string `json:"notes" example:"Flu"`
+}
+
+type UpdateSickReportRequest struct {
+	ExpectedReturnDate *string `json:"expected_return_date" example:"2025-03-17"`
+	Notes              *string `json:"notes"`
+}
+
+// RecoverSickReportRequest ends a sick report, RecoveredOn is the first day the employee
+// works again, today when not given
+type RecoverSickReportRequest struct {
+	RecoveredOn *string `json:"recovered_on" example:"2025-03-12"`
+}
+
+type ListSickReportsRequest struct {
+	EmployeeID *int64 `form:"employee_id"`
+	Open       *bool  `form:"open"`
+}
+
+// CompleteMilestoneRequest records that a step of the Wet verbetering poortwachter has
+// been taken, on CompletedOn or today when not given
+type CompleteMilestoneRequest struct {
+	CompletedOn *string `json:"completed_on" example:"2025-04-11"`
+	Notes       *string `json:"notes" example:"Problem analysis by the company doctor"`
+}
+
+// MilestoneResponse is a step of the Wet verbetering poortwachter for a sick report, due
+// a number of weeks after the first day of sickness. Steps that fall due after the
+// employee recovered are not applicable.
+type MilestoneResponse struct {
+	Milestone             string     `json:"milestone"`
+	Name                  string     `json:"name"`
+	Week                  int        `json:"week"`
+	DueDate               time.Time  `json:"due_date"`
+	Status                string     `json:"status" enums:"completed,overdue,due_soon,upcoming,not_applicable"`
+	CompletedOn           *time.Time `json:"completed_on,omitempty"`
+	CompletedByEmployeeID *int64     `json:"completed_by_employee_id,omitempty"`
+	Notes                 *string    `json:"notes,omitempty"`
+}
+
+// SickReportResponse is a sick report with its poortwachter milestones. AffectedShifts are
+// returned when an employee is reported sick or their expected return is later: the
+// shifts that need a replacement, the planners are notified about them.
+type SickReportResponse struct {
+	ID                   int64               `json:"id"`
+	EmployeeID           int64               `json:"employee_id"`
+	EmployeeFirstName    string              `json:"employee_first_name"`
+	EmployeeLastName     string              `json:"employee_last_name"`
+	StartDate            time.Time           `json:"start_date"`
+	ExpectedReturnDate   *time.Time          `json:"expected_return_date"`
+	RecoveredOn          *time.Time          `json:"recovered_on"`
+	Open                 bool                `json:"open"`
+	SickDays             int                 `json:"sick_days"`
+	Notes                *string             `json:"notes"`
+	ReportedByEmployeeID *int64              `json:"reported_by_employee_id"`
+	CreatedAt            time.Time           `json:"created_at"`
+	Milestones           []MilestoneResponse `json:"milestones"`
+	AffectedShifts       []AffectedShift     `json:"affected_shifts,omitempty"`
+}
@@ -0,0 +1,96 @@
+package leave
+
+import (
+	"testing"
+	"time"
+
+	db "maicare_go/db/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestLeaveHours(t *testing.T) {
+	// Monday 14 July up to and including Friday 25 July 2025 are 10 working days
+	require.Equal(t, 72.0, leaveHours(36, day(2025, time.July, 14), day(2025, time.July, 25)))
+	// a weekend takes no leave
+	require.Zero(t, leaveHours(36, day(2025, time.July, 19), day(2025, time.July, 20)))
+	require.Equal(t, 6.4, leaveHours(32, day(2025, time.July, 18), day(2025, time.July, 18)))
+}
+
+func TestLeaveEntitlement(t *testing.T) {
+	// a full year of 36 hours a week gives four weeks, 182 of the 365 days are accrued by
+	// the end of 1 July
+	entitlement, accrued := leaveEntitlement(36, nil, nil, 2025, day(2025, time.July, 1))
+	require.Equal(t, 144.0, entitlement)
+	require.Equal(t, 71.8, accrued)
+
+	// the contract starts on 2 July and runs 183 days of the year
+	start := day(2025, time.July, 2)
+	entitlement, accrued = leaveEntitlement(36, &start, nil, 2025, day(2025, time.July, 1))
+	require.Equal(t, 72.2, entitlement)
+	require.Zero(t, accrued)
+
+	// the contract ended the year before
+	end := day(2024, time.December, 31)
+	entitlement, accrued = leaveEntitlement(36, nil, &end, 2025, day(2025, time.July, 1))
+	require.Zero(t, entitlement)
+	require.Zero(t, accrued)
+
+	// at the end of the year all of it is accrued
+	entitlement, accrued = leaveEntitlement(24, nil, nil, 2024, day(2025, time.March, 1))
+	require.Equal(t, 96.0, entitlement)
+	require.Equal(t, entitlement, accrued)
+}
+
+func TestMilestones(t *testing.T) {
+	start := day(2025, time.March, 3)
+	completed := []db.SickReportMilestone{
+		{SickReportID: 1, Milestone: "occupational_health_report", CompletedOn: pgtype.Date{Time: day(2025, time.March, 4), Valid: true}},
+	}
+
+	result := milestones(start, nil, completed, day(2025, time.April, 7))
+	require.Len(t, result, len(PoortwachterMilestones))
+	require.Equal(t, MilestoneCompleted, result[0].Status)
+	require.Equal(t, day(2025, time.March, 10), result[0].DueDate)
+	// the problem analysis is due on 14 April, within two weeks
+	require.Equal(t, MilestoneDueSoon, result[1].Status)
+	require.Equal(t, MilestoneUpcoming, result[2].Status)
+
+	result = milestones(start, nil, nil, day(2025, time.April, 15))
+	require.Equal(t, MilestoneOverdue, result[0].Status)
+	require.Equal(t, MilestoneOverdue, result[1].Status)
+
+	// recovered before the problem analysis was due
+	recovered := day(2025, time.April, 1)
+	result = milestones(start, &recovered, nil, day(2025, time.April, 15))
+	require.Equal(t, MilestoneOverdue, result[0].Status)
+	require.Equal(t, MilestoneNotApplicable, result[1].Status)
+}
+
+func TestReplacementNotifications(t *testing.T) {
+	leaveRequestID := int64(7)
+	employee := absentEmployee{EmployeeID: 1, FirstName: "Anna", LastName: "de Vries", Reason: "leave", LeaveRequestID: &leaveRequestID}
+	monday := day(2025, time.July, 14).Add(7 * time.Hour)
+	shifts := []AffectedShift{
+		{ScheduleID: uuid.New(), LocationID: 10, LocationName: "De Linde", StartTime: monday, EndTime: monday.Add(8 * time.Hour)},
+		{ScheduleID: uuid.New(), LocationID: 20, LocationName: "De Eik", StartTime: monday.AddDate(0, 0, 1), EndTime: monday.AddDate(0, 0, 1).Add(8 * time.Hour)},
+		{ScheduleID: uuid.New(), LocationID: 10, LocationName: "De Linde", StartTime: monday.AddDate(0, 0, 2), EndTime: monday.AddDate(0, 0, 2).Add(8 * time.Hour)},
+	}
+
+	notifications := replacementNotifications(employee, shifts)
+	require.Len(t, notifications, 2)
+	require.Equal(t, int64(10), notifications[0].LocationID)
+	require.Len(t, notifications[0].Shifts, 2)
+	require.Equal(t, shifts[2].ScheduleID, notifications[0].Shifts[1].ScheduleID)
+	require.Equal(t, int64(20), notifications[1].LocationID)
+	require.Equal(t, &leaveRequestID, notifications[1].LeaveRequestID)
+	require.Nil(t, notifications[1].SickReportID)
+
+	require.Empty(t, replacementNotifications(employee, nil))
+}
@@ -0,0 +1,121 @@
+package leave
+
+import (
+	"context"
+	"fmt"
+	db "maicare_go/db/sqlc"
+	"maicare_go/logger"
+	"maicare_go/notification"
+	"maicare_go/service/schedule"
+	"maicare_go/util"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+// absentEmployee is who is absent and why, for the replacement notifications
+type absentEmployee struct {
+	EmployeeID     int64
+	FirstName      string
+	LastName       string
+	Reason         string // "leave" or "sick"
+	LeaveRequestID *int64
+	SickReportID   *int64
+}
+
+// affectedShifts returns the shifts of the employee between start and end that have not
+// ended yet
+func (s *leaveService) affectedShifts(ctx context.Context, operation string, employeeID int64, start, end time.Time) ([]AffectedShift, error) {
+	now := util.NetherlandsNow()
+	if start.Before(now) {
+		start = now
+	}
+	shifts := []AffectedShift{}
+	if !start.Before(end) {
+		return shifts, nil
+	}
+	rows, err := s.Store.ListEmployeesSchedulesInRange(ctx, db.ListEmployeesSchedulesInRangeParams{
+		EmployeeIds: []int64{employeeID},
+		StartDate:   pgtype.Timestamp{Time: start, Valid: true},
+		EndDate:     pgtype.Timestamp{Time: end, Valid: true},
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, operation, "Failed to list shifts", zap.Error(err), zap.Int64("employee_id", employeeID))
+		return nil, fmt.Errorf("failed to list the shifts of the employee")
+	}
+	for _, row := range rows {
+		shifts = append(shifts, AffectedShift{
+			ScheduleID:   row.ID,
+			LocationID:   row.LocationID,
+			LocationName: row.LocationName,
+			ShiftName:    row.ShiftName,
+			StartTime:    row.StartDatetime.Time,
+			EndTime:      row.EndDatetime.Time,
+		})
+	}
+	return shifts, nil
+}
+
+// notifyReplacement tells the planners of each location that the shifts of the absent
+// employee there need a replacement. Failing notifications are logged, the absence has
+// been saved already.
+func (s *leaveService) notifyReplacement(ctx context.Context, operation string, employee absentEmployee, shifts []AffectedShift) {
+	if len(shifts) == 0 {
+		return
+	}
+	planners, err := s.Store.ListPermissionHolders(ctx, schedule.PlannerPermission)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, operation, "Failed to list planners", zap.Error(err))
+		return
+	}
+
+	for _, data := range replacementNotifications(employee, shifts) {
+		recipients := schedule.LocationPlanners(planners, data.LocationID)
+		if len(recipients) == 0 {
+			s.Logger.LogBusinessEvent(logger.LogLevelWarn, operation, "No planners to notify", zap.Int64("location_id", data.LocationID))
+			continue
+		}
+		err := s.AsynqClient.EnqueueNotificationTask(ctx, notification.NotificationPayload{
+			RecipientUserIDs: recipients,
+			Type:             notification.TypeShiftReplacement,
+			Data:             notification.NotificationData{ShiftReplacement: data},
+			CreatedAt:        time.Now(),
+			Message:          data.ShiftReplacementMessage(),
+		})
+		if err != nil {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, operation, "Failed to enqueue notification task", zap.Error(err), zap.Int64("location_id", data.LocationID))
+		}
+	}
+}
+
+// replacementNotifications groups the shifts by location, the planners of each location
+// get one notification
+func replacementNotifications(employee absentEmployee, shifts []AffectedShift) []*notification.ShiftReplacementData {
+	var notifications []*notification.ShiftReplacementData
+	byLocation := make(map[int64]*notification.ShiftReplacementData)
+	for _, shift := range shifts {
+		data, ok := byLocation[shift.LocationID]
+		if !ok {
+			data = &notification.ShiftReplacementData{
+				EmployeeID:        employee.EmployeeID,
+				EmployeeFirstName: employee.FirstName,
+				EmployeeLastName:  employee.LastName,
+				Reason:            employee.Reason,
+				LeaveRequestID:    employee.LeaveRequestID,
+				SickReportID:      employee.SickReportID,
+				LocationID:        shift.LocationID,
+				LocationName:      shift.LocationName,
+			}
+			byLocation[shift.LocationID] = data
+			notifications = append(notifications, data)
+		}
+		data.Shifts = append(data.Shifts, notification.ShiftReplacementItem{
+			ScheduleID: shift.ScheduleID,
+			ShiftName:  shift.ShiftName,
+			StartTime:  shift.StartTime,
+			EndTime:    shift.EndTime,
+		})
+	}
+	return notifications
+}
@@ -0,0 +1,35 @@
+package leave
+
+import (
+	"context"
+	"maicare_go/service/deps"
+)
+
+type LeaveService interface {
+	ListLeaveTypes(ctx context.Context) ([]LeaveTypeResponse, error)
+	CreateLeaveType(ctx context.Context, req *CreateLeaveTypeRequest) (*LeaveTypeResponse, error)
+	UpdateLeaveType(ctx context.Context, leaveTypeID int64, req *UpdateLeaveTypeRequest) (*LeaveTypeResponse, error)
+	CreateLeaveRequest(ctx context.Context, employeeID int64, req *CreateLeaveRequestRequest) (*LeaveRequestResponse, error)
+	ListLeaveRequests(ctx context.Context, req *ListLeaveRequestsRequest) ([]LeaveRequestResponse, error)
+	CancelLeaveRequest(ctx context.Context, leaveRequestID, employeeID int64) (*LeaveRequestResponse, error)
+	ApproveLeaveRequest(ctx context.Context, leaveRequestID, employeeID int64, req *ReviewLeaveRequestRequest) (*LeaveRequestResponse, error)
+	RejectLeaveRequest(ctx context.Context, leaveRequestID, employeeID int64, req *ReviewLeaveRequestRequest) (*LeaveRequestResponse, error)
+	GetLeaveBalance(ctx context.Context, employeeID int64, year int32) (*LeaveBalanceResponse, error)
+	AdjustLeaveBalance(ctx context.Context, employeeID, createdBy int64, req *LeaveBalanceAdjustmentRequest) (*LeaveBalanceResponse, error)
+	CreateSickReport(ctx context.Context, employeeID, reportedBy int64, req *CreateSickReportRequest) (*SickReportResponse, error)
+	ListSickReports(ctx context.Context, req *ListSickReportsRequest) ([]SickReportResponse, error)
+	GetSickReport(ctx context.Context, sickReportID int64) (*SickReportResponse, error)
+	UpdateSickReport(ctx context.Context, sickReportID int64, req *UpdateSickReportRequest) (*SickReportResponse, error)
+	RecoverSickReport(ctx context.Context, sickReportID int64, req *RecoverSickReportRequest) (*SickReportResponse, error)
+	CompleteSickReportMilestone(ctx context.Context, sickReportID int64, milestone string, employeeID int64, req *CompleteMilestoneRequest) (*SickReportResponse, error)
+}
+
+type leaveService struct {
+	*deps.ServiceDependencies
+}
+
+func NewLeaveService(deps *deps.ServiceDependencies) LeaveService {
+	return &leaveService{
+		ServiceDependencies: deps,
+	}
+}
@@ -0,0 +1,366 @@
+package leave
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	db "maicare_go/db/sqlc"
+	"maicare_go/logger"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+// Milestone is a step of the Wet verbetering poortwachter, due Week weeks after the
+// first day of sickness
+type Milestone struct {
+	Code string
+	Name string
+	Week int
+}
+
+// PoortwachterMilestones are the steps an employer takes while an employee is sick
+var PoortwachterMilestones = []Milestone{
+	{Code: "occupational_health_report", Name: "Ziekmelding bij de arbodienst", Week: 1},
+	{Code: "problem_analysis", Name: "Probleemanalyse", Week: 6},
+	{Code: "action_plan", Name: "Plan van aanpak", Week: 8},
+	{Code: "uwv_notification", Name: "Ziekmelding bij het UWV", Week: 42},
+	{Code: "first_year_evaluation", Name: "Eerstejaarsevaluatie", Week: 52},
+	{Code: "wia_application", Name: "WIA-aanvraag", Week: 91},
+	{Code: "end_of_wage_continuation", Name: "Einde loondoorbetaling", Week: 104},
+}
+
+// Statuses of a milestone
+const (
+	MilestoneCompleted     = "completed"
+	MilestoneOverdue       = "overdue"
+	MilestoneDueSoon       = "due_soon"
+	MilestoneUpcoming      = "upcoming"
+	MilestoneNotApplicable = "not_applicable"
+)
+
+// MilestoneDueSoonDays is how long before its due date a milestone is due soon
+const MilestoneDueSoonDays = 14
+
+// SickReplacementHorizon is how far ahead the shifts of a sick employee without an
+// expected return need a replacement
+const SickReplacementHorizon = 7 * 24 * time.Hour
+
+var (
+	ErrSickReportNotFound = errors.New("sick report not found")
+	ErrInvalidSickReport  = errors.New("invalid sick report")
+	ErrSickReportOpen     = errors.New("the employee is already reported sick")
+	ErrSickReportClosed   = errors.New("the employee has already recovered")
+	ErrUnknownMilestone   = errors.New("unknown poortwachter milestone")
+)
+
+// CreateSickReport reports an employee sick. The shifts of the employee until the
+// expected return, or in the coming week, are returned and the planners of their
+// locations are notified that they need a replacement.
+func (s *leaveService) CreateSickReport(ctx context.Context, employeeID, reportedBy int64, req *CreateSickReportRequest) (*SickReportResponse, error) {
+	startDate := pgtype.Date{Time: today(), Valid: true}
+	if req.StartDate != nil {
+		var err error
+		if startDate, err = parseOptionalDate(req.StartDate, "start_date", ErrInvalidSickReport); err != nil {
+			return nil, err
+		}
+	}
+	expectedReturn, err := parseOptionalDate(req.ExpectedReturnDate, "expected_return_date", ErrInvalidSickReport)
+	if err != nil {
+		return nil, err
+	}
+	if expectedReturn.Valid && !expectedReturn.Time.After(startDate.Time) {
+		return nil, fmt.Errorf("%w: the expected return must be after the first day of sickness", ErrInvalidSickReport)
+	}
+
+	if _, err := s.Store.GetEmployeeContractDetails(ctx, employeeID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrEmployeeNotFound
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CreateSickReport", "Failed to get employee", zap.Error(err), zap.Int64("employee_id", employeeID))
+		return nil, fmt.Errorf("failed to create sick report")
+	}
+	open := true
+	running, err := s.Store.ListSickReports(ctx, db.ListSickReportsParams{EmployeeID: &employeeID, Open: &open})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CreateSickReport", "Failed to list sick reports", zap.Error(err), zap.Int64("employee_id", employeeID))
+		return nil, fmt.Errorf("failed to create sick report")
+	}
+	if len(running) > 0 {
+		return nil, ErrSickReportOpen
+	}
+
+	created, err := s.Store.CreateSickReport(ctx, db.CreateSickReportParams{
+		EmployeeID:           employeeID,
+		StartDate:            startDate,
+		ExpectedReturnDate:   expectedReturn,
+		Notes:                req.Notes,
+		ReportedByEmployeeID: &reportedBy,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CreateSickReport", "Failed to create sick report", zap.Error(err), zap.Int64("employee_id", employeeID))
+		return nil, fmt.Errorf("failed to create sick report")
+	}
+	s.Logger.LogBusinessEvent(logger.LogLevelInfo, "CreateSickReport", "Employee reported sick",
+		zap.Int64("sick_report_id", created.ID), zap.Int64("employee_id", employeeID), zap.Int64("reported_by", reportedBy))
+
+	resp, err := s.GetSickReport(ctx, created.ID)
+	if err != nil {
+		return nil, err
+	}
+	resp.AffectedShifts, err = s.affectedShifts(ctx, "CreateSickReport", employeeID, resp.StartDate, replacementEnd(expectedReturn))
+	if err != nil {
+		return nil, err
+	}
+	s.notifySick(ctx, "CreateSickReport", resp)
+	return resp, nil
+}
+
+// ListSickReports lists the sick reports, the latest first
+func (s *leaveService) ListSickReports(ctx context.Context, req *ListSickReportsRequest) ([]SickReportResponse, error) {
+	rows, err := s.Store.ListSickReports(ctx, db.ListSickReportsParams{
+		EmployeeID: req.EmployeeID,
+		Open:       req.Open,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ListSickReports", "Failed to list sick reports", zap.Error(err))
+		return nil, fmt.Errorf("failed to list sick reports")
+	}
+	ids := make([]int64, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+	completed, err := s.Store.ListSickReportMilestones(ctx, ids)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ListSickReports", "Failed to list milestones", zap.Error(err))
+		return nil, fmt.Errorf("failed to list sick reports")
+	}
+
+	reports := make([]SickReportResponse, len(rows))
+	for i, row := range rows {
+		reports[i] = sickReportResponse(db.GetSickReportRow(row), completed, today())
+	}
+	return reports, nil
+}
+
+func (s *leaveService) GetSickReport(ctx context.Context, sickReportID int64) (*SickReportResponse, error) {
+	row, err := s.Store.GetSickReport(ctx, sickReportID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSickReportNotFound
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "GetSickReport", "Failed to get sick report", zap.Error(err), zap.Int64("sick_report_id", sickReportID))
+		return nil, fmt.Errorf("failed to get sick report")
+	}
+	completed, err := s.Store.ListSickReportMilestones(ctx, []int64{sickReportID})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "GetSickReport", "Failed to list milestones", zap.Error(err), zap.Int64("sick_report_id", sickReportID))
+		return nil, fmt.Errorf("failed to get sick report")
+	}
+	resp := sickReportResponse(row, completed, today())
+	return &resp, nil
+}
+
+// UpdateSickReport changes the expected return and notes of a running sick report. When
+// the employee is expected back later, the planners are notified about the shifts that
+// need a replacement now.
+func (s *leaveService) UpdateSickReport(ctx context.Context, sickReportID int64, req *UpdateSickReportRequest) (*SickReportResponse, error) {
+	report, err := s.GetSickReport(ctx, sickReportID)
+	if err != nil {
+		return nil, err
+	}
+	if !report.Open {
+		return nil, ErrSickReportClosed
+	}
+	expectedReturn, err := parseOptionalDate(req.ExpectedReturnDate, "expected_return_date", ErrInvalidSickReport)
+	if err != nil {
+		return nil, err
+	}
+	if expectedReturn.Valid && !expectedReturn.Time.After(report.StartDate) {
+		return nil, fmt.Errorf("%w: the expected return must be after the first day of sickness", ErrInvalidSickReport)
+	}
+
+	_, err = s.Store.UpdateSickReport(ctx, db.UpdateSickReportParams{
+		ID:                 sickReportID,
+		ExpectedReturnDate: expectedReturn,
+		Notes:              req.Notes,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "UpdateSickReport", "Failed to update sick report", zap.Error(err), zap.Int64("sick_report_id", sickReportID))
+		return nil, fmt.Errorf("failed to update sick report")
+	}
+
+	previous := pgtype.Date{}
+	if report.ExpectedReturnDate != nil {
+		previous = pgtype.Date{Time: *report.ExpectedReturnDate, Valid: true}
+	}
+	resp, err := s.GetSickReport(ctx, sickReportID)
+	if err != nil {
+		return nil, err
+	}
+	from, to := replacementEnd(previous), replacementEnd(expectedReturn)
+	if to.After(from) {
+		resp.AffectedShifts, err = s.affectedShifts(ctx, "UpdateSickReport", resp.EmployeeID, from, to)
+		if err != nil {
+			return nil, err
+		}
+		s.notifySick(ctx, "UpdateSickReport", resp)
+	}
+	return resp, nil
+}
+
+// RecoverSickReport ends a running sick report
+func (s *leaveService) RecoverSickReport(ctx context.Context, sickReportID int64, req *RecoverSickReportRequest) (*SickReportResponse, error) {
+	report, err := s.GetSickReport(ctx, sickReportID)
+	if err != nil {
+		return nil, err
+	}
+	recoveredOn := pgtype.Date{Time: today(), Valid: true}
+	if req.RecoveredOn != nil {
+		if recoveredOn, err = parseOptionalDate(req.RecoveredOn, "recovered_on", ErrInvalidSickReport); err != nil {
+			return nil, err
+		}
+	}
+	if !recoveredOn.Time.After(report.StartDate) {
+		return nil, fmt.Errorf("%w: the employee recovers after the first day of sickness", ErrInvalidSickReport)
+	}
+
+	if _, err := s.Store.RecoverSickReport(ctx, db.RecoverSickReportParams{ID: sickReportID, RecoveredOn: recoveredOn}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSickReportClosed
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "RecoverSickReport", "Failed to recover sick report", zap.Error(err), zap.Int64("sick_report_id", sickReportID))
+		return nil, fmt.Errorf("failed to recover sick report")
+	}
+	s.Logger.LogBusinessEvent(logger.LogLevelInfo, "RecoverSickReport", "Employee recovered",
+		zap.Int64("sick_report_id", sickReportID), zap.Int64("employee_id", report.EmployeeID))
+	return s.GetSickReport(ctx, sickReportID)
+}
+
+// CompleteSickReportMilestone records that a poortwachter step has been taken
+func (s *leaveService) CompleteSickReportMilestone(ctx context.Context, sickReportID int64, milestone string, employeeID int64, req *CompleteMilestoneRequest) (*SickReportResponse, error) {
+	known := false
+	for _, m := range PoortwachterMilestones {
+		known = known || m.Code == milestone
+	}
+	if !known {
+		return nil, ErrUnknownMilestone
+	}
+	if _, err := s.GetSickReport(ctx, sickReportID); err != nil {
+		return nil, err
+	}
+	completedOn := pgtype.Date{Time: today(), Valid: true}
+	if req.CompletedOn != nil {
+		var err error
+		if completedOn, err = parseOptionalDate(req.CompletedOn, "completed_on", ErrInvalidSickReport); err != nil {
+			return nil, err
+		}
+	}
+
+	_, err := s.Store.CompleteSickReportMilestone(ctx, db.CompleteSickReportMilestoneParams{
+		SickReportID:          sickReportID,
+		Milestone:             milestone,
+		CompletedOn:           completedOn,
+		Notes:                 req.Notes,
+		CompletedByEmployeeID: &employeeID,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CompleteSickReportMilestone", "Failed to complete milestone", zap.Error(err),
+			zap.Int64("sick_report_id", sickReportID), zap.String("milestone", milestone))
+		return nil, fmt.Errorf("failed to complete milestone")
+	}
+	return s.GetSickReport(ctx, sickReportID)
+}
+
+func (s *leaveService) notifySick(ctx context.Context, operation string, report *SickReportResponse) {
+	id := report.ID
+	s.notifyReplacement(ctx, operation, absentEmployee{
+		EmployeeID:   report.EmployeeID,
+		FirstName:    report.EmployeeFirstName,
+		LastName:     report.EmployeeLastName,
+		Reason:       "sick",
+		SickReportID: &id,
+	}, report.AffectedShifts)
+}
+
+// replacementEnd returns until when the shifts of a sick employee need a replacement: the
+// expected return, or the end of the replacement horizon
+func replacementEnd(expectedReturn pgtype.Date) time.Time {
+	if expectedReturn.Valid {
+		return expectedReturn.Time
+	}
+	return today().AddDate(0, 0, 1).Add(SickReplacementHorizon)
+}
+
+func sickReportResponse(row db.GetSickReportRow, completed []db.SickReportMilestone, day time.Time) SickReportResponse {
+	resp := SickReportResponse{
+		ID:                   row.ID,
+		EmployeeID:           row.EmployeeID,
+		EmployeeFirstName:    row.EmployeeFirstName,
+		EmployeeLastName:     row.EmployeeLastName,
+		StartDate:            row.StartDate.Time,
+		Open:                 !row.RecoveredOn.Valid,
+		Notes:                row.Notes,
+		ReportedByEmployeeID: row.ReportedByEmployeeID,
+		CreatedAt:            row.CreatedAt.Time,
+	}
+	if row.ExpectedReturnDate.Valid {
+		resp.ExpectedReturnDate = &row.ExpectedReturnDate.Time
+	}
+	last := day.AddDate(0, 0, 1)
+	if row.RecoveredOn.Valid {
+		resp.RecoveredOn = &row.RecoveredOn.Time
+		last = row.RecoveredOn.Time
+	}
+	if last.After(resp.StartDate) {
+		resp.SickDays = int(days(resp.StartDate, last))
+	}
+
+	var own []db.SickReportMilestone
+	for _, m := range completed {
+		if m.SickReportID == row.ID {
+			own = append(own, m)
+		}
+	}
+	resp.Milestones = milestones(resp.StartDate, resp.RecoveredOn, own, day)
+	return resp
+}
+
+// milestones returns the poortwachter steps of a sick report on a day. Steps that fall
+// due after the employee recovered are not applicable, unless they were taken.
+func milestones(start time.Time, recoveredOn *time.Time, completed []db.SickReportMilestone, day time.Time) []MilestoneResponse {
+	result := make([]MilestoneResponse, len(PoortwachterMilestones))
+	for i, m := range PoortwachterMilestones {
+		r := MilestoneResponse{
+			Milestone: m.Code,
+			Name:      m.Name,
+			Week:      m.Week,
+			DueDate:   start.AddDate(0, 0, 7*m.Week),
+		}
+		done := false
+		for _, c := range completed {
+			if c.Milestone == m.Code {
+				done = true
+				r.CompletedOn = &c.CompletedOn.Time
+				r.CompletedByEmployeeID = c.CompletedByEmployeeID
+				r.Notes = c.Notes
+			}
+		}
+		switch {
+		case done:
+			r.Status = MilestoneCompleted
+		case recoveredOn != nil && !recoveredOn.After(r.DueDate):
+			r.Status = MilestoneNotApplicable
+		case r.DueDate.Before(day):
+			r.Status = MilestoneOverdue
+		case !r.DueDate.After(day.AddDate(0, 0, MilestoneDueSoonDays)):
+			r.Status = MilestoneDueSoon
+		default:
+			r.Status = MilestoneUpcoming
+		}
+		result[i] = r
+	}
+	return result
+}
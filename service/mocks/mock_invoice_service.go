@@ -11,9 +11,12 @@ package mocks
 
 import (
 	context "context"
+	pagination "maicare_go/pagination"
 	invoice "maicare_go/service/invoice"
 	reflect "reflect"
 
+	gin "github.com/gin-gonic/gin"
+	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -41,6 +44,51 @@ func (m *MockInvoiceService) EXPECT() *MockInvoiceServiceMockRecorder {
 	return m.recorder
 }
 
+// ApproveInvoice mocks base method.
+func (m *MockInvoiceService) ApproveInvoice(ctx context.Context, invoiceID, employeeID int64) (*invoice.InvoiceApprovalResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveInvoice", ctx, invoiceID, employeeID)
+	ret0, _ := ret[0].(*invoice.InvoiceApprovalResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveInvoice indicates an expected call of ApproveInvoice.
+func (mr *MockInvoiceServiceMockRecorder) ApproveInvoice(ctx, invoiceID, employeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveInvoice", reflect.TypeOf((*MockInvoiceService)(nil).ApproveInvoice), ctx, invoiceID, employeeID)
+}
+
+// CreditInvoice mocks base method.
+func (m *MockInvoiceService) CreditInvoice(ctx context.Context, invoiceID int64, req invoice.CreditInvoiceRequest, employeeID int64) (*invoice.CreditInvoiceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreditInvoice", ctx, invoiceID, req, employeeID)
+	ret0, _ := ret[0].(*invoice.CreditInvoiceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreditInvoice indicates an expected call of CreditInvoice.
+func (mr *MockInvoiceServiceMockRecorder) CreditInvoice(ctx, invoiceID, req, employeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreditInvoice", reflect.TypeOf((*MockInvoiceService)(nil).CreditInvoice), ctx, invoiceID, req, employeeID)
+}
+
+// ExportInvoice mocks base method.
+func (m *MockInvoiceService) ExportInvoice(ctx context.Context, invoiceID int64, format string) (*invoice.InvoiceDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportInvoice", ctx, invoiceID, format)
+	ret0, _ := ret[0].(*invoice.InvoiceDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportInvoice indicates an expected call of ExportInvoice.
+func (mr *MockInvoiceServiceMockRecorder) ExportInvoice(ctx, invoiceID, format any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportInvoice", reflect.TypeOf((*MockInvoiceService)(nil).ExportInvoice), ctx, invoiceID, format)
+}
+
 // GenerateInvoice mocks base method.
 func (m *MockInvoiceService) GenerateInvoice(req invoice.GenerateInvoiceRequest, ctx context.Context) (*invoice.GenerateInvoiceResult, int64, error) {
 	m.ctrl.T.Helper()
@@ -57,6 +105,21 @@ func (mr *MockInvoiceServiceMockRecorder) GenerateInvoice(req, ctx any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateInvoice", reflect.TypeOf((*MockInvoiceService)(nil).GenerateInvoice), req, ctx)
 }
 
+// GetInvoiceApprovalSettings mocks base method.
+func (m *MockInvoiceService) GetInvoiceApprovalSettings(ctx context.Context, organisationID int64) (*invoice.InvoiceApprovalSettingsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoiceApprovalSettings", ctx, organisationID)
+	ret0, _ := ret[0].(*invoice.InvoiceApprovalSettingsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoiceApprovalSettings indicates an expected call of GetInvoiceApprovalSettings.
+func (mr *MockInvoiceServiceMockRecorder) GetInvoiceApprovalSettings(ctx, organisationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoiceApprovalSettings", reflect.TypeOf((*MockInvoiceService)(nil).GetInvoiceApprovalSettings), ctx, organisationID)
+}
+
 // GetInvoiceByID mocks base method.
 func (m *MockInvoiceService) GetInvoiceByID(ctx context.Context, invoiceID int64) (*invoice.GetInvoiceByIDResponse, error) {
 	m.ctrl.T.Helper()
@@ -72,6 +135,171 @@ func (mr *MockInvoiceServiceMockRecorder) GetInvoiceByID(ctx, invoiceID any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoiceByID", reflect.TypeOf((*MockInvoiceService)(nil).GetInvoiceByID), ctx, invoiceID)
 }
 
+// GetInvoiceDeliveryBatch mocks base method.
+func (m *MockInvoiceService) GetInvoiceDeliveryBatch(ctx context.Context, batchID uuid.UUID) (*invoice.InvoiceDeliveryBatchResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoiceDeliveryBatch", ctx, batchID)
+	ret0, _ := ret[0].(*invoice.InvoiceDeliveryBatchResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoiceDeliveryBatch indicates an expected call of GetInvoiceDeliveryBatch.
+func (mr *MockInvoiceServiceMockRecorder) GetInvoiceDeliveryBatch(ctx, batchID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoiceDeliveryBatch", reflect.TypeOf((*MockInvoiceService)(nil).GetInvoiceDeliveryBatch), ctx, batchID)
+}
+
+// GetInvoiceNumberSeries mocks base method.
+func (m *MockInvoiceService) GetInvoiceNumberSeries(ctx context.Context, organisationID int64) (*invoice.InvoiceNumberSeriesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoiceNumberSeries", ctx, organisationID)
+	ret0, _ := ret[0].(*invoice.InvoiceNumberSeriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoiceNumberSeries indicates an expected call of GetInvoiceNumberSeries.
+func (mr *MockInvoiceServiceMockRecorder) GetInvoiceNumberSeries(ctx, organisationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoiceNumberSeries", reflect.TypeOf((*MockInvoiceService)(nil).GetInvoiceNumberSeries), ctx, organisationID)
+}
+
+// IgnoreBankStatementLine mocks base method.
+func (m *MockInvoiceService) IgnoreBankStatementLine(ctx context.Context, lineID int64, req invoice.IgnoreBankStatementLineRequest, employeeID int64) (*invoice.BankStatementLineResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IgnoreBankStatementLine", ctx, lineID, req, employeeID)
+	ret0, _ := ret[0].(*invoice.BankStatementLineResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IgnoreBankStatementLine indicates an expected call of IgnoreBankStatementLine.
+func (mr *MockInvoiceServiceMockRecorder) IgnoreBankStatementLine(ctx, lineID, req, employeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IgnoreBankStatementLine", reflect.TypeOf((*MockInvoiceService)(nil).IgnoreBankStatementLine), ctx, lineID, req, employeeID)
+}
+
+// ImportBankStatement mocks base method.
+func (m *MockInvoiceService) ImportBankStatement(ctx context.Context, req invoice.ImportBankStatementRequest, employeeID int64) (*invoice.ImportBankStatementResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportBankStatement", ctx, req, employeeID)
+	ret0, _ := ret[0].(*invoice.ImportBankStatementResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportBankStatement indicates an expected call of ImportBankStatement.
+func (mr *MockInvoiceServiceMockRecorder) ImportBankStatement(ctx, req, employeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportBankStatement", reflect.TypeOf((*MockInvoiceService)(nil).ImportBankStatement), ctx, req, employeeID)
+}
+
+// ListBankStatementLines mocks base method.
+func (m *MockInvoiceService) ListBankStatementLines(ctx *gin.Context, req invoice.ListBankStatementLinesRequest) (*pagination.Response[invoice.BankStatementLineResponse], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBankStatementLines", ctx, req)
+	ret0, _ := ret[0].(*pagination.Response[invoice.BankStatementLineResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBankStatementLines indicates an expected call of ListBankStatementLines.
+func (mr *MockInvoiceServiceMockRecorder) ListBankStatementLines(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBankStatementLines", reflect.TypeOf((*MockInvoiceService)(nil).ListBankStatementLines), ctx, req)
+}
+
+// ListInvoiceDeliveries mocks base method.
+func (m *MockInvoiceService) ListInvoiceDeliveries(ctx context.Context, invoiceID int64) ([]invoice.InvoiceDeliveryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInvoiceDeliveries", ctx, invoiceID)
+	ret0, _ := ret[0].([]invoice.InvoiceDeliveryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInvoiceDeliveries indicates an expected call of ListInvoiceDeliveries.
+func (mr *MockInvoiceServiceMockRecorder) ListInvoiceDeliveries(ctx, invoiceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInvoiceDeliveries", reflect.TypeOf((*MockInvoiceService)(nil).ListInvoiceDeliveries), ctx, invoiceID)
+}
+
+// MatchBankStatementLine mocks base method.
+func (m *MockInvoiceService) MatchBankStatementLine(ctx context.Context, lineID int64, req invoice.MatchBankStatementLineRequest, employeeID int64) (*invoice.BankStatementMatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MatchBankStatementLine", ctx, lineID, req, employeeID)
+	ret0, _ := ret[0].(*invoice.BankStatementMatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MatchBankStatementLine indicates an expected call of MatchBankStatementLine.
+func (mr *MockInvoiceServiceMockRecorder) MatchBankStatementLine(ctx, lineID, req, employeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchBankStatementLine", reflect.TypeOf((*MockInvoiceService)(nil).MatchBankStatementLine), ctx, lineID, req, employeeID)
+}
+
+// ProcessInvoiceDelivery mocks base method.
+func (m *MockInvoiceService) ProcessInvoiceDelivery(ctx context.Context, deliveryID int64) (*invoice.InvoiceDeliveryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessInvoiceDelivery", ctx, deliveryID)
+	ret0, _ := ret[0].(*invoice.InvoiceDeliveryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProcessInvoiceDelivery indicates an expected call of ProcessInvoiceDelivery.
+func (mr *MockInvoiceServiceMockRecorder) ProcessInvoiceDelivery(ctx, deliveryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessInvoiceDelivery", reflect.TypeOf((*MockInvoiceService)(nil).ProcessInvoiceDelivery), ctx, deliveryID)
+}
+
+// RejectInvoice mocks base method.
+func (m *MockInvoiceService) RejectInvoice(ctx context.Context, invoiceID int64, req invoice.RejectInvoiceRequest, employeeID int64) (*invoice.InvoiceApprovalResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectInvoice", ctx, invoiceID, req, employeeID)
+	ret0, _ := ret[0].(*invoice.InvoiceApprovalResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RejectInvoice indicates an expected call of RejectInvoice.
+func (mr *MockInvoiceServiceMockRecorder) RejectInvoice(ctx, invoiceID, req, employeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectInvoice", reflect.TypeOf((*MockInvoiceService)(nil).RejectInvoice), ctx, invoiceID, req, employeeID)
+}
+
+// SendInvoice mocks base method.
+func (m *MockInvoiceService) SendInvoice(ctx context.Context, invoiceID, employeeID int64) (*invoice.InvoiceDeliveryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendInvoice", ctx, invoiceID, employeeID)
+	ret0, _ := ret[0].(*invoice.InvoiceDeliveryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendInvoice indicates an expected call of SendInvoice.
+func (mr *MockInvoiceServiceMockRecorder) SendInvoice(ctx, invoiceID, employeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendInvoice", reflect.TypeOf((*MockInvoiceService)(nil).SendInvoice), ctx, invoiceID, employeeID)
+}
+
+// SendInvoiceBatch mocks base method.
+func (m *MockInvoiceService) SendInvoiceBatch(ctx context.Context, req invoice.SendInvoiceBatchRequest, employeeID int64) (*invoice.SendInvoiceBatchResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendInvoiceBatch", ctx, req, employeeID)
+	ret0, _ := ret[0].(*invoice.SendInvoiceBatchResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendInvoiceBatch indicates an expected call of SendInvoiceBatch.
+func (mr *MockInvoiceServiceMockRecorder) SendInvoiceBatch(ctx, req, employeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendInvoiceBatch", reflect.TypeOf((*MockInvoiceService)(nil).SendInvoiceBatch), ctx, req, employeeID)
+}
+
 // SendInvoiceReminder mocks base method.
 func (m *MockInvoiceService) SendInvoiceReminder(ctx context.Context, invoiceID int64) error {
 	m.ctrl.T.Helper()
@@ -85,3 +313,48 @@ func (mr *MockInvoiceServiceMockRecorder) SendInvoiceReminder(ctx, invoiceID any
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendInvoiceReminder", reflect.TypeOf((*MockInvoiceService)(nil).SendInvoiceReminder), ctx, invoiceID)
 }
+
+// SubmitInvoiceForApproval mocks base method.
+func (m *MockInvoiceService) SubmitInvoiceForApproval(ctx context.Context, invoiceID, employeeID int64) (*invoice.InvoiceApprovalResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitInvoiceForApproval", ctx, invoiceID, employeeID)
+	ret0, _ := ret[0].(*invoice.InvoiceApprovalResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitInvoiceForApproval indicates an expected call of SubmitInvoiceForApproval.
+func (mr *MockInvoiceServiceMockRecorder) SubmitInvoiceForApproval(ctx, invoiceID, employeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitInvoiceForApproval", reflect.TypeOf((*MockInvoiceService)(nil).SubmitInvoiceForApproval), ctx, invoiceID, employeeID)
+}
+
+// UpdateInvoiceApprovalSettings mocks base method.
+func (m *MockInvoiceService) UpdateInvoiceApprovalSettings(ctx context.Context, organisationID int64, req invoice.UpdateInvoiceApprovalSettingsRequest, employeeID int64) (*invoice.InvoiceApprovalSettingsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInvoiceApprovalSettings", ctx, organisationID, req, employeeID)
+	ret0, _ := ret[0].(*invoice.InvoiceApprovalSettingsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateInvoiceApprovalSettings indicates an expected call of UpdateInvoiceApprovalSettings.
+func (mr *MockInvoiceServiceMockRecorder) UpdateInvoiceApprovalSettings(ctx, organisationID, req, employeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInvoiceApprovalSettings", reflect.TypeOf((*MockInvoiceService)(nil).UpdateInvoiceApprovalSettings), ctx, organisationID, req, employeeID)
+}
+
+// UpdateInvoiceNumberSeries mocks base method.
+func (m *MockInvoiceService) UpdateInvoiceNumberSeries(ctx context.Context, organisationID int64, req invoice.UpdateInvoiceNumberSeriesRequest, employeeID int64) (*invoice.InvoiceNumberSeriesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInvoiceNumberSeries", ctx, organisationID, req, employeeID)
+	ret0, _ := ret[0].(*invoice.InvoiceNumberSeriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateInvoiceNumberSeries indicates an expected call of UpdateInvoiceNumberSeries.
+func (mr *MockInvoiceServiceMockRecorder) UpdateInvoiceNumberSeries(ctx, organisationID, req, employeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInvoiceNumberSeries", reflect.TypeOf((*MockInvoiceService)(nil).UpdateInvoiceNumberSeries), ctx, organisationID, req, employeeID)
+}
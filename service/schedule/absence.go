@@ -0,0 +1,105 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	db "maicare_go/db/sqlc"
+	"maicare_go/logger"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+// Kinds and statuses of the absences returned by ListEmployeesAbsences
+const (
+	absenceSick   = "sick"
+	leavePending  = "pending"
+	sickRecovered = "recovered"
+)
+
+// absences returns the leave and sickness of the employees on the days from start up to
+// end
+func (s *scheduleService) absences(ctx context.Context, operation string, employeeIDs []int64, start, end time.Time) ([]db.ListEmployeesAbsencesRow, error) {
+	rows, err := s.Store.ListEmployeesAbsences(ctx, db.ListEmployeesAbsencesParams{
+		EmployeeIds: employeeIDs,
+		StartDate:   pgtype.Date{Time: startOfDay(start), Valid: true},
+		EndDate:     pgtype.Date{Time: startOfDay(end), Valid: true},
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, operation, "Failed to list absences", zap.Error(err))
+		return nil, fmt.Errorf("failed to check absences")
+	}
+	return rows, nil
+}
+
+// absenceConflicts returns the leave and sickness of the employee of the slot during it.
+// Approved leave and sickness are errors, the employee is not there. Leave that has not
+// been approved yet is a warning.
+func absenceConflicts(slot Slot, absences []db.ListEmployeesAbsencesRow) []Conflict {
+	conflicts := []Conflict{}
+	for _, a := range absences {
+		if a.EmployeeID != slot.EmployeeID {
+			continue
+		}
+		start, end := absencePeriod(a, slot.End)
+		if !overlaps(slot.Start, slot.End, start, end) {
+			continue
+		}
+		id := a.ID
+		c := Conflict{
+			Kind:       ConflictAbsence,
+			Severity:   SeverityError,
+			EmployeeID: a.EmployeeID,
+			StartTime:  start,
+			EndTime:    end,
+		}
+		switch {
+		case a.Kind == absenceSick:
+			c.SickReportID = &id
+			switch {
+			case a.Status == sickRecovered:
+				c.Message = fmt.Sprintf("Sick from %s to %s", a.StartDate.Time.Format("2006-01-02"), a.EndDate.Time.Format("2006-01-02"))
+			case a.EndDate.Valid:
+				c.Message = fmt.Sprintf("Sick since %s, expected back on %s", a.StartDate.Time.Format("2006-01-02"), end.Format("2006-01-02"))
+			default:
+				c.Message = "Sick since " + a.StartDate.Time.Format("2006-01-02")
+			}
+		case a.Status == leavePending:
+			c.LeaveRequestID = &id
+			c.Severity = SeverityWarning
+			c.Message = fmt.Sprintf("Requested %s from %s to %s, not approved yet", a.Description, a.StartDate.Time.Format("2006-01-02"), a.EndDate.Time.Format("2006-01-02"))
+		default:
+			c.LeaveRequestID = &id
+			c.Message = fmt.Sprintf("On %s from %s to %s", a.Description, a.StartDate.Time.Format("2006-01-02"), a.EndDate.Time.Format("2006-01-02"))
+		}
+		conflicts = append(conflicts, c)
+	}
+	return conflicts
+}
+
+// absencePeriod returns when an absence starts and ends. Absences are whole days, an
+// employee who is sick without an expected return is absent until at least the given
+// time.
+func absencePeriod(a db.ListEmployeesAbsencesRow, until time.Time) (time.Time, time.Time) {
+	start := startOfDay(a.StartDate.Time)
+	if !a.EndDate.Valid {
+		return start, maxTime(until, start.AddDate(0, 0, 1))
+	}
+	return start, startOfDay(a.EndDate.Time).AddDate(0, 0, 1)
+}
+
+// absentPeriods returns the absences of each employee as periods, ending no later than
+// until
+func absentPeriods(absences []db.ListEmployeesAbsencesRow, until time.Time) map[int64][]rosterPeriod {
+	periods := make(map[int64][]rosterPeriod)
+	for _, a := range absences {
+		start, end := absencePeriod(a, until)
+		periods[a.EmployeeID] = append(periods[a.EmployeeID], rosterPeriod{Start: start, End: end})
+	}
+	return periods
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,78 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	db "maicare_go/db/sqlc"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func date(t time.Time) pgtype.Date {
+	return pgtype.Date{Time: t, Valid: true}
+}
+
+func TestAbsenceConflicts(t *testing.T) {
+	slot := Slot{
+		EmployeeID: 1,
+		LocationID: 10,
+		Start:      monday.AddDate(0, 0, 2).Add(7 * time.Hour),
+		End:        monday.AddDate(0, 0, 2).Add(15 * time.Hour),
+	}
+	absences := []db.ListEmployeesAbsencesRow{
+		{ID: 1, EmployeeID: 1, Kind: "leave", Status: "approved", Description: "Vakantieverlof", StartDate: date(monday), EndDate: date(monday.AddDate(0, 0, 4))},
+		{ID: 2, EmployeeID: 1, Kind: "leave", Status: "pending", Description: "Bijzonder verlof", StartDate: date(monday.AddDate(0, 0, 2)), EndDate: date(monday.AddDate(0, 0, 2))},
+		// sick without an expected return
+		{ID: 3, EmployeeID: 1, Kind: "sick", Status: "open", StartDate: date(monday.AddDate(0, 0, 1))},
+		// ended the day before the slot
+		{ID: 4, EmployeeID: 1, Kind: "leave", Status: "approved", Description: "Vakantieverlof", StartDate: date(monday), EndDate: date(monday.AddDate(0, 0, 1))},
+		// another employee
+		{ID: 5, EmployeeID: 2, Kind: "sick", Status: "open", StartDate: date(monday)},
+	}
+
+	conflicts := absenceConflicts(slot, absences)
+	require.Len(t, conflicts, 3)
+	require.Equal(t, ConflictAbsence, conflicts[0].Kind)
+	require.Equal(t, SeverityError, conflicts[0].Severity)
+	require.Equal(t, int64(1), *conflicts[0].LeaveRequestID)
+	require.Equal(t, "On Vakantieverlof from 2025-03-03 to 2025-03-07", conflicts[0].Message)
+	require.Equal(t, SeverityWarning, conflicts[1].Severity)
+	require.Equal(t, "Requested Bijzonder verlof from 2025-03-05 to 2025-03-05, not approved yet", conflicts[1].Message)
+	require.Equal(t, SeverityError, conflicts[2].Severity)
+	require.Nil(t, conflicts[2].LeaveRequestID)
+	require.Equal(t, int64(3), *conflicts[2].SickReportID)
+	require.Equal(t, "Sick since 2025-03-04", conflicts[2].Message)
+	require.Equal(t, slot.End, conflicts[2].EndTime)
+	require.False(t, HasErrors(conflicts[1:2]))
+
+	// the sick employee is expected back on the day of the slot, the absence ends the
+	// day before
+	absences[2].EndDate = date(monday.AddDate(0, 0, 1))
+	conflicts = absenceConflicts(slot, absences[2:3])
+	require.Empty(t, conflicts)
+	absences[2].EndDate = date(monday.AddDate(0, 0, 2))
+	conflicts = absenceConflicts(slot, absences[2:3])
+	require.Len(t, conflicts, 1)
+	require.Equal(t, "Sick since 2025-03-04, expected back on 2025-03-06", conflicts[0].Message)
+}
+
+func TestGenerateRosterAbsences(t *testing.T) {
+	slots := week(7, 8, 1)
+	input := weekInput(slots,
+		rosterCandidate{EmployeeID: 1, ContractHours: 40},
+		rosterCandidate{EmployeeID: 2, ContractHours: 16},
+	)
+	// employee 1 is on leave from Monday to Wednesday
+	input.Absences = absentPeriods([]db.ListEmployeesAbsencesRow{
+		{ID: 1, EmployeeID: 1, Kind: "leave", Status: "approved", StartDate: date(monday), EndDate: date(monday.AddDate(0, 0, 2))},
+	}, input.MonthEnd)
+
+	positions := generateRoster(input)
+	for _, p := range positions[:3] {
+		require.NotEqual(t, int64(1), p.EmployeeID)
+	}
+	require.Equal(t, 4, assigned(positions)[1])
+	require.Equal(t, 2, assigned(positions)[2])
+}
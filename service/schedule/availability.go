@@ -0,0 +1,238 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	db "maicare_go/db/sqlc"
+	"maicare_go/logger"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+// LocationAvailability is the availability over a period of the employees of a location,
+// and of the other employees with shifts there
+type LocationAvailability struct {
+	employees   []db.ListRosterCandidatesRow
+	weekly      []db.EmployeeWeeklyAvailability
+	unavailable []db.EmployeeUnavailability
+}
+
+// GetLocationAvailability returns the availability between start and end of the employees
+// of the location and of the given employees
+func (s *scheduleService) GetLocationAvailability(ctx context.Context, locationID int64, employeeIDs []int64, start, end time.Time) (*LocationAvailability, error) {
+	employees, err := s.Store.ListRosterCandidates(ctx, db.ListRosterCandidatesParams{LocationID: &locationID})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "GetLocationAvailability", "Failed to list employees", zap.Error(err), zap.Int64("location_id", locationID))
+		return nil, fmt.Errorf("failed to get availability")
+	}
+	var others []int64
+	for _, id := range employeeIDs {
+		if !slices.ContainsFunc(employees, func(e db.ListRosterCandidatesRow) bool { return e.ID == id }) && !slices.Contains(others, id) {
+			others = append(others, id)
+		}
+	}
+	if len(others) > 0 {
+		rows, err := s.Store.ListRosterCandidates(ctx, db.ListRosterCandidatesParams{EmployeeIds: others})
+		if err != nil {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "GetLocationAvailability", "Failed to list employees", zap.Error(err), zap.Int64("location_id", locationID))
+			return nil, fmt.Errorf("failed to get availability")
+		}
+		employees = append(employees, rows...)
+	}
+
+	ids := make([]int64, len(employees))
+	for i, e := range employees {
+		ids[i] = e.ID
+	}
+	weekly, unavailable, err := s.availability(ctx, "GetLocationAvailability", ids, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return &LocationAvailability{employees: employees, weekly: weekly, unavailable: unavailable}, nil
+}
+
+// Day returns when each of the employees can work on a day
+func (a *LocationAvailability) Day(day time.Time) []EmployeeAvailability {
+	start := startOfDay(day)
+	end := start.AddDate(0, 0, 1)
+	result := make([]EmployeeAvailability, 0, len(a.employees))
+	for _, e := range a.employees {
+		weekly := employeeWeekly(a.weekly, e.ID)
+		availability := EmployeeAvailability{
+			EmployeeID:         e.ID,
+			EmployeeFirstName:  e.FirstName,
+			EmployeeLastName:   e.LastName,
+			WeeklyAvailability: len(weekly) > 0,
+			Available:          []AvailabilityPeriod{},
+			Unavailable:        []AvailabilityPeriod{},
+		}
+		if len(weekly) == 0 {
+			availability.Available = append(availability.Available, AvailabilityPeriod{StartTime: start, EndTime: end})
+		}
+		for _, w := range availableWindows(weekly, start, end) {
+			availability.Available = append(availability.Available, AvailabilityPeriod{StartTime: w.Start, EndTime: w.End})
+		}
+		for _, u := range a.unavailable {
+			if u.EmployeeID != e.ID || !overlaps(start, end, u.StartDatetime.Time, u.EndDatetime.Time) {
+				continue
+			}
+			id := u.ID
+			availability.Unavailable = append(availability.Unavailable, AvailabilityPeriod{
+				UnavailabilityID: &id,
+				StartTime:        u.StartDatetime.Time,
+				EndTime:          u.EndDatetime.Time,
+				Reason:           u.Reason,
+			})
+		}
+		result = append(result, availability)
+	}
+	return result
+}
+
+// Conflicts returns the availability conflicts of a shift
+func (a *LocationAvailability) Conflicts(slot Slot) []Conflict {
+	return availabilityConflicts(slot, a.weekly, a.unavailable)
+}
+
+// availability returns the weekly availability of the employees and their unavailability
+// between start and end
+func (s *scheduleService) availability(ctx context.Context, operation string, employeeIDs []int64, start, end time.Time) ([]db.EmployeeWeeklyAvailability, []db.EmployeeUnavailability, error) {
+	weekly, err := s.Store.ListEmployeesWeeklyAvailability(ctx, employeeIDs)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, operation, "Failed to list weekly availability", zap.Error(err))
+		return nil, nil, fmt.Errorf("failed to check availability")
+	}
+	unavailable, err := s.Store.ListEmployeesUnavailability(ctx, db.ListEmployeesUnavailabilityParams{
+		EmployeeIds: employeeIDs,
+		StartDate:   pgtype.Timestamp{Time: start, Valid: true},
+		EndDate:     pgtype.Timestamp{Time: end, Valid: true},
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, operation, "Failed to list unavailability", zap.Error(err))
+		return nil, nil, fmt.Errorf("failed to check availability")
+	}
+	return weekly, unavailable, nil
+}
+
+// availabilityConflicts returns the unavailability of the employee of the slot during it,
+// and whether the slot falls outside their weekly availability. Availability is what the
+// employee asked for, so these are warnings.
+func availabilityConflicts(slot Slot, weekly []db.EmployeeWeeklyAvailability, unavailable []db.EmployeeUnavailability) []Conflict {
+	conflicts := []Conflict{}
+	own := employeeWeekly(weekly, slot.EmployeeID)
+	if len(own) > 0 && !covered(slot.Start, slot.End, availableWindows(own, slot.Start, slot.End)) {
+		conflicts = append(conflicts, Conflict{
+			Kind:       ConflictAvailability,
+			Severity:   SeverityWarning,
+			EmployeeID: slot.EmployeeID,
+			StartTime:  slot.Start,
+			EndTime:    slot.End,
+			Message:    "Outside the weekly availability of the employee",
+		})
+	}
+	for _, u := range unavailable {
+		if u.EmployeeID != slot.EmployeeID || !overlaps(slot.Start, slot.End, u.StartDatetime.Time, u.EndDatetime.Time) {
+			continue
+		}
+		id := u.ID
+		message := fmt.Sprintf("Unavailable from %s to %s", u.StartDatetime.Time.Format("2006-01-02 15:04"), u.EndDatetime.Time.Format("2006-01-02 15:04"))
+		if u.Reason != nil && *u.Reason != "" {
+			message += ": " + *u.Reason
+		}
+		conflicts = append(conflicts, Conflict{
+			Kind:             ConflictAvailability,
+			Severity:         SeverityWarning,
+			EmployeeID:       u.EmployeeID,
+			UnavailabilityID: &id,
+			StartTime:        u.StartDatetime.Time,
+			EndTime:          u.EndDatetime.Time,
+			Message:          message,
+		})
+	}
+	return conflicts
+}
+
+func employeeWeekly(weekly []db.EmployeeWeeklyAvailability, employeeID int64) []db.EmployeeWeeklyAvailability {
+	var own []db.EmployeeWeeklyAvailability
+	for _, w := range weekly {
+		if w.EmployeeID == employeeID {
+			own = append(own, w)
+		}
+	}
+	return own
+}
+
+// availableWindows returns the windows of the weekly availability that overlap the period,
+// sorted by start. A window that ends at or before its start runs into the next day.
+func availableWindows(weekly []db.EmployeeWeeklyAvailability, from, to time.Time) []rosterPeriod {
+	var windows []rosterPeriod
+	for day := startOfDay(from).AddDate(0, 0, -1); day.Before(to); day = day.AddDate(0, 0, 1) {
+		for _, w := range weekly {
+			if time.Weekday(w.Weekday) != day.Weekday() {
+				continue
+			}
+			start := day.Add(time.Duration(w.StartTime.Microseconds) * time.Microsecond)
+			end := day.Add(time.Duration(w.EndTime.Microseconds) * time.Microsecond)
+			if !end.After(start) {
+				end = end.AddDate(0, 0, 1)
+			}
+			if overlaps(start, end, from, to) {
+				windows = append(windows, rosterPeriod{Start: start, End: end})
+			}
+		}
+	}
+	slices.SortFunc(windows, func(a, b rosterPeriod) int { return a.Start.Compare(b.Start) })
+	return windows
+}
+
+// weeklyWindows returns the windows of the weekly availability between from and to of
+// each employee that has weekly availability
+func weeklyWindows(weekly []db.EmployeeWeeklyAvailability, from, to time.Time) map[int64][]rosterPeriod {
+	byEmployee := make(map[int64][]db.EmployeeWeeklyAvailability)
+	for _, w := range weekly {
+		byEmployee[w.EmployeeID] = append(byEmployee[w.EmployeeID], w)
+	}
+	windows := make(map[int64][]rosterPeriod, len(byEmployee))
+	for employeeID, own := range byEmployee {
+		windows[employeeID] = availableWindows(own, from, to)
+	}
+	return windows
+}
+
+// covered reports whether the windows, sorted by start, cover the period without a gap
+func covered(start, end time.Time, windows []rosterPeriod) bool {
+	for _, w := range windows {
+		if !start.Before(end) {
+			break
+		}
+		if w.Start.After(start) {
+			return false
+		}
+		if w.End.After(start) {
+			start = w.End
+		}
+	}
+	return !start.Before(end)
+}
+
+// applyPreferences sets the shift preferences of an employee on a roster candidate for a
+// location
+func applyPreferences(candidate *rosterCandidate, preferences db.EmployeeShiftPreference, locationID int64, shifts map[int64]db.LocationShift) {
+	candidate.OtherLocation = len(preferences.PreferredLocationIds) > 0 && !slices.Contains(preferences.PreferredLocationIds, locationID)
+	if len(preferences.PreferredShiftNames) > 0 {
+		candidate.PreferredShifts = make(map[int64]bool)
+		for id, shift := range shifts {
+			candidate.PreferredShifts[id] = slices.ContainsFunc(preferences.PreferredShiftNames, func(name string) bool {
+				return strings.EqualFold(strings.TrimSpace(name), shift.ShiftName)
+			})
+		}
+	}
+	if preferences.MaxNightShiftsPerMonth != nil {
+		maxNights := int(*preferences.MaxNightShiftsPerMonth)
+		candidate.MaxNights = &maxNights
+	}
+}
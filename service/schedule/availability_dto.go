@@ -0,0 +1,24 @@
+package schedule
+
+import "time"
+
+// AvailabilityPeriod is a period on a day in which an employee is available, or one-off
+// unavailability with its ID and reason
+type AvailabilityPeriod struct {
+	UnavailabilityID *int64    `json:"unavailability_id,omitempty"`
+	StartTime        time.Time `json:"start_time"`
+	EndTime          time.Time `json:"end_time"`
+	Reason           *string   `json:"reason,omitempty"`
+}
+
+// EmployeeAvailability is when an employee can work on a day. Available are the windows of
+// their weekly availability, or the whole day when they have none. Unavailable is the
+// one-off unavailability that overlaps the day.
+type EmployeeAvailability struct {
+	EmployeeID         int64                `json:"employee_id"`
+	EmployeeFirstName  string               `json:"employee_first_name"`
+	EmployeeLastName   string               `json:"employee_last_name"`
+	WeeklyAvailability bool                 `json:"weekly_availability"`
+	Available          []AvailabilityPeriod `json:"available"`
+	Unavailable        []AvailabilityPeriod `json:"unavailable"`
+}
@@ -0,0 +1,127 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	db "maicare_go/db/sqlc"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAvailableWindows(t *testing.T) {
+	weekly := []db.EmployeeWeeklyAvailability{
+		{EmployeeID: 1, Weekday: int32(time.Monday), StartTime: clock(7, 0), EndTime: clock(12, 0)},
+		{EmployeeID: 1, Weekday: int32(time.Monday), StartTime: clock(12, 0), EndTime: clock(18, 0)},
+		// runs into Saturday
+		{EmployeeID: 1, Weekday: int32(time.Friday), StartTime: clock(22, 0), EndTime: clock(6, 0)},
+	}
+	friday := monday.AddDate(0, 0, 4)
+
+	windows := availableWindows(weekly, monday, monday.AddDate(0, 0, 7))
+	require.Equal(t, []rosterPeriod{
+		{Start: monday.Add(7 * time.Hour), End: monday.Add(12 * time.Hour)},
+		{Start: monday.Add(12 * time.Hour), End: monday.Add(18 * time.Hour)},
+		{Start: friday.Add(22 * time.Hour), End: friday.Add(30 * time.Hour)},
+	}, windows)
+
+	// the window of Friday night is found from Saturday
+	saturday := friday.AddDate(0, 0, 1)
+	require.Len(t, availableWindows(weekly, saturday, saturday.AddDate(0, 0, 1)), 1)
+
+	require.True(t, covered(monday.Add(8*time.Hour), monday.Add(17*time.Hour), windows))
+	require.False(t, covered(monday.Add(6*time.Hour), monday.Add(14*time.Hour), windows))
+	require.False(t, covered(monday.Add(15*time.Hour), monday.Add(23*time.Hour), windows))
+	require.True(t, covered(friday.Add(23*time.Hour), friday.Add(29*time.Hour), windows))
+	require.False(t, covered(monday.Add(8*time.Hour), monday.Add(9*time.Hour), nil))
+}
+
+func TestAvailabilityConflicts(t *testing.T) {
+	slot := Slot{
+		EmployeeID: 1,
+		LocationID: 10,
+		Start:      monday.Add(7 * time.Hour),
+		End:        monday.Add(15 * time.Hour),
+	}
+	reason := "Tandarts"
+	weekly := []db.EmployeeWeeklyAvailability{
+		{EmployeeID: 1, Weekday: int32(time.Monday), StartTime: clock(8, 0), EndTime: clock(16, 0)},
+	}
+	unavailable := []db.EmployeeUnavailability{
+		{ID: 4, EmployeeID: 1, StartDatetime: pgtype.Timestamp{Time: monday.Add(13 * time.Hour), Valid: true}, EndDatetime: pgtype.Timestamp{Time: monday.Add(14 * time.Hour), Valid: true}, Reason: &reason},
+		// after the slot
+		{ID: 5, EmployeeID: 1, StartDatetime: pgtype.Timestamp{Time: monday.Add(15 * time.Hour), Valid: true}, EndDatetime: pgtype.Timestamp{Time: monday.Add(18 * time.Hour), Valid: true}},
+		// another employee
+		{ID: 6, EmployeeID: 2, StartDatetime: pgtype.Timestamp{Time: monday, Valid: true}, EndDatetime: pgtype.Timestamp{Time: monday.AddDate(0, 0, 1), Valid: true}},
+	}
+
+	conflicts := availabilityConflicts(slot, weekly, unavailable)
+	require.Len(t, conflicts, 2)
+	require.Equal(t, ConflictAvailability, conflicts[0].Kind)
+	require.Equal(t, "Outside the weekly availability of the employee", conflicts[0].Message)
+	require.Equal(t, int64(4), *conflicts[1].UnavailabilityID)
+	require.Equal(t, "Unavailable from 2025-03-03 13:00 to 2025-03-03 14:00: Tandarts", conflicts[1].Message)
+	require.False(t, HasErrors(conflicts))
+
+	// without weekly availability the employee can work at any time
+	slot.EmployeeID = 2
+	slot.Start = monday.AddDate(0, 0, 1).Add(7 * time.Hour)
+	slot.End = monday.AddDate(0, 0, 1).Add(15 * time.Hour)
+	require.Empty(t, availabilityConflicts(slot, weekly, unavailable))
+}
+
+func TestGenerateRosterAvailability(t *testing.T) {
+	input := weekInput(week(7, 8, 1),
+		rosterCandidate{EmployeeID: 1, ContractHours: 40},
+		rosterCandidate{EmployeeID: 2, ContractHours: 40},
+	)
+	// employee 1 only works on Monday and Tuesday mornings
+	input.Availability = weeklyWindows([]db.EmployeeWeeklyAvailability{
+		{EmployeeID: 1, Weekday: int32(time.Monday), StartTime: clock(7, 0), EndTime: clock(15, 0)},
+		{EmployeeID: 1, Weekday: int32(time.Tuesday), StartTime: clock(6, 0), EndTime: clock(16, 0)},
+	}, input.MonthStart, input.MonthEnd)
+
+	positions := generateRoster(input)
+	for _, p := range positions[2:] {
+		require.NotEqual(t, int64(1), p.EmployeeID)
+	}
+	require.Positive(t, assigned(positions)[1])
+}
+
+func TestGenerateRosterMaxNights(t *testing.T) {
+	maxNights := 1
+	positions := generateRoster(weekInput(week(23, 8, 1),
+		rosterCandidate{EmployeeID: 1, MaxNights: &maxNights},
+		rosterCandidate{EmployeeID: 2},
+		rosterCandidate{EmployeeID: 3},
+	))
+	require.Equal(t, 1, assigned(positions)[1])
+	require.Equal(t, 7, assigned(positions)[1]+assigned(positions)[2]+assigned(positions)[3])
+}
+
+func TestGenerateRosterPreferences(t *testing.T) {
+	slots := week(7, 8, 1)
+	for _, slot := range week(15, 8, 1) {
+		slot.LocationShiftID = 2
+		slots = append(slots, slot)
+	}
+	positions := generateRoster(weekInput(slots,
+		rosterCandidate{EmployeeID: 1, PreferredShifts: map[int64]bool{2: true}},
+		rosterCandidate{EmployeeID: 2, PreferredShifts: map[int64]bool{1: true}},
+	))
+	for _, p := range positions {
+		require.Equal(t, slots[p.Slot].LocationShiftID, map[int64]int64{1: 2, 2: 1}[p.EmployeeID])
+	}
+
+	// an employee that prefers other locations is planned last, the weekend shifts are
+	// still shared
+	positions = generateRoster(weekInput(week(7, 8, 1),
+		rosterCandidate{EmployeeID: 1, OtherLocation: true},
+		rosterCandidate{EmployeeID: 2},
+	))
+	for _, p := range positions[:5] {
+		require.Equal(t, int64(2), p.EmployeeID)
+	}
+	require.Equal(t, map[int64]int{1: 1, 2: 6}, assigned(positions))
+}
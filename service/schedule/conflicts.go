@@ -0,0 +1,240 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	db "maicare_go/db/sqlc"
+	"maicare_go/logger"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrScheduleConflict = errors.New("the schedule conflicts with other shifts or an absence of the employee or the working time rules")
+	ErrInvalidMonth     = errors.New("invalid year or month")
+)
+
+// HasErrors reports whether any of the conflicts blocks scheduling
+func HasErrors(conflicts []Conflict) bool {
+	for _, c := range conflicts {
+		if c.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckScheduleConflicts returns the shifts, appointments, absences and unavailability of
+// the employee that overlap the slot, and the working time rules the slot breaks
+func (s *scheduleService) CheckScheduleConflicts(ctx context.Context, slot Slot) ([]Conflict, error) {
+	slot.Start = asStored(slot.Start)
+	slot.End = asStored(slot.End)
+	arg := db.ListEmployeesSchedulesInRangeParams{
+		EmployeeIds: []int64{slot.EmployeeID},
+		StartDate:   pgtype.Timestamp{Time: slot.Start, Valid: true},
+		EndDate:     pgtype.Timestamp{Time: slot.End, Valid: true},
+	}
+
+	shifts, err := s.Store.ListEmployeesSchedulesInRange(ctx, arg)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CheckScheduleConflicts", "Failed to list shifts", zap.Error(err), zap.Int64("employee_id", slot.EmployeeID))
+		return nil, fmt.Errorf("failed to check schedule conflicts")
+	}
+
+	appointments, err := s.Store.ListEmployeesAppointmentsInRange(ctx, db.ListEmployeesAppointmentsInRangeParams(arg))
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CheckScheduleConflicts", "Failed to list appointments", zap.Error(err), zap.Int64("employee_id", slot.EmployeeID))
+		return nil, fmt.Errorf("failed to check schedule conflicts")
+	}
+
+	absences, err := s.absences(ctx, "CheckScheduleConflicts", []int64{slot.EmployeeID}, slot.Start, slot.End)
+	if err != nil {
+		return nil, err
+	}
+	weekly, unavailable, err := s.availability(ctx, "CheckScheduleConflicts", []int64{slot.EmployeeID}, slot.Start, slot.End)
+	if err != nil {
+		return nil, err
+	}
+
+	workingTime, err := s.workingTimeConflicts(ctx, slot)
+	if err != nil {
+		return nil, err
+	}
+	conflicts := append(detectConflicts(slot, shifts, appointments), absenceConflicts(slot, absences)...)
+	conflicts = append(conflicts, availabilityConflicts(slot, weekly, unavailable)...)
+	return append(conflicts, workingTime...), nil
+}
+
+// CheckRosterConflicts checks every shift of a location in a month against the other
+// shifts and the appointments of its employee, at any location, their absences and their
+// availability
+func (s *scheduleService) CheckRosterConflicts(ctx context.Context, locationID int64, year, month int32) (*RosterConflictsResponse, error) {
+	if year < 1 || month < 1 || month > 12 {
+		return nil, ErrInvalidMonth
+	}
+
+	roster, err := s.Store.GetMonthlySchedulesByLocation(ctx, db.GetMonthlySchedulesByLocationParams{
+		Year:       year,
+		Month:      month,
+		LocationID: locationID,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CheckRosterConflicts", "Failed to get roster", zap.Error(err), zap.Int64("location_id", locationID))
+		return nil, fmt.Errorf("failed to check roster conflicts")
+	}
+
+	resp := &RosterConflictsResponse{
+		LocationID:    locationID,
+		Year:          year,
+		Month:         month,
+		ShiftsChecked: len(roster),
+		Shifts:        []RosterShiftConflicts{},
+	}
+	if len(roster) == 0 {
+		return resp, nil
+	}
+
+	var employeeIDs []int64
+	seen := make(map[int64]bool)
+	start, end := roster[0].StartDatetime.Time, roster[0].EndDatetime.Time
+	for _, shift := range roster {
+		if !seen[shift.EmployeeID] {
+			seen[shift.EmployeeID] = true
+			employeeIDs = append(employeeIDs, shift.EmployeeID)
+		}
+		if shift.StartDatetime.Time.Before(start) {
+			start = shift.StartDatetime.Time
+		}
+		if shift.EndDatetime.Time.After(end) {
+			end = shift.EndDatetime.Time
+		}
+	}
+
+	arg := db.ListEmployeesSchedulesInRangeParams{
+		EmployeeIds: employeeIDs,
+		StartDate:   pgtype.Timestamp{Time: start, Valid: true},
+		EndDate:     pgtype.Timestamp{Time: end, Valid: true},
+	}
+	shifts, err := s.Store.ListEmployeesSchedulesInRange(ctx, arg)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CheckRosterConflicts", "Failed to list shifts", zap.Error(err), zap.Int64("location_id", locationID))
+		return nil, fmt.Errorf("failed to check roster conflicts")
+	}
+	appointments, err := s.Store.ListEmployeesAppointmentsInRange(ctx, db.ListEmployeesAppointmentsInRangeParams(arg))
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CheckRosterConflicts", "Failed to list appointments", zap.Error(err), zap.Int64("location_id", locationID))
+		return nil, fmt.Errorf("failed to check roster conflicts")
+	}
+	absences, err := s.absences(ctx, "CheckRosterConflicts", employeeIDs, start, end)
+	if err != nil {
+		return nil, err
+	}
+	weekly, unavailable, err := s.availability(ctx, "CheckRosterConflicts", employeeIDs, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, shift := range roster {
+		scheduleID := shift.ShiftID
+		slot := Slot{
+			ScheduleID: &scheduleID,
+			EmployeeID: shift.EmployeeID,
+			LocationID: shift.LocationID,
+			Start:      shift.StartDatetime.Time,
+			End:        shift.EndDatetime.Time,
+		}
+		conflicts := append(detectConflicts(slot, shifts, appointments), absenceConflicts(slot, absences)...)
+		conflicts = append(conflicts, availabilityConflicts(slot, weekly, unavailable)...)
+		if len(conflicts) == 0 {
+			continue
+		}
+		for _, c := range conflicts {
+			if c.Severity == SeverityError {
+				resp.Errors++
+			} else {
+				resp.Warnings++
+			}
+		}
+		resp.Shifts = append(resp.Shifts, RosterShiftConflicts{
+			ScheduleID:        shift.ShiftID,
+			EmployeeID:        shift.EmployeeID,
+			EmployeeFirstName: shift.EmployeeFirstName,
+			EmployeeLastName:  shift.EmployeeLastName,
+			ShiftName:         shift.ShiftName,
+			StartTime:         shift.StartDatetime.Time,
+			EndTime:           shift.EndDatetime.Time,
+			Conflicts:         conflicts,
+		})
+	}
+	return resp, nil
+}
+
+// detectConflicts returns the shifts and appointments of the employee of the slot that
+// overlap it. An employee cannot work two shifts at the same time, so an overlapping shift
+// is an error. An appointment during a shift is a warning, it may well take place at the
+// location of the shift.
+func detectConflicts(slot Slot, shifts []db.ListEmployeesSchedulesInRangeRow, appointments []db.ListEmployeesAppointmentsInRangeRow) []Conflict {
+	conflicts := []Conflict{}
+	for _, shift := range shifts {
+		if shift.EmployeeID != slot.EmployeeID || slot.skips(shift.ID) {
+			continue
+		}
+		if !overlaps(slot.Start, slot.End, shift.StartDatetime.Time, shift.EndDatetime.Time) {
+			continue
+		}
+		scheduleID := shift.ID
+		locationID := shift.LocationID
+		location := shift.LocationName
+		message := "Overlaps with another shift at " + shift.LocationName
+		if shift.LocationID == slot.LocationID {
+			message = "Overlaps with another shift at the same location"
+		}
+		conflicts = append(conflicts, Conflict{
+			Kind:       ConflictShift,
+			Severity:   SeverityError,
+			EmployeeID: shift.EmployeeID,
+			ScheduleID: &scheduleID,
+			LocationID: &locationID,
+			Location:   &location,
+			StartTime:  shift.StartDatetime.Time,
+			EndTime:    shift.EndDatetime.Time,
+			Message:    message,
+		})
+	}
+
+	for _, appt := range appointments {
+		if appt.EmployeeID != slot.EmployeeID {
+			continue
+		}
+		if !overlaps(slot.Start, slot.End, appt.StartTime.Time, appt.EndTime.Time) {
+			continue
+		}
+		appointmentID := appt.ID
+		conflicts = append(conflicts, Conflict{
+			Kind:          ConflictAppointment,
+			Severity:      SeverityWarning,
+			EmployeeID:    appt.EmployeeID,
+			AppointmentID: &appointmentID,
+			Location:      appt.Location,
+			StartTime:     appt.StartTime.Time,
+			EndTime:       appt.EndTime.Time,
+			Message:       "Overlaps with an appointment",
+		})
+	}
+	return conflicts
+}
+
+// overlaps reports whether two periods share time. Periods that only touch, a shift that
+// ends when the next starts, do not overlap.
+func overlaps(start, end, otherStart, otherEnd time.Time) bool {
+	return start.Before(otherEnd) && otherStart.Before(end)
+}
+
+// asStored returns a time as it is read back from a TIMESTAMP column: the wall clock time
+// in UTC
+func asStored(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
@@ -0,0 +1,65 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	db "maicare_go/db/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func ts(t time.Time) pgtype.Timestamp {
+	return pgtype.Timestamp{Time: t, Valid: true}
+}
+
+func TestDetectConflicts(t *testing.T) {
+	day := time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC)
+	self := uuid.New()
+	slot := Slot{
+		ScheduleID: &self,
+		EmployeeID: 1,
+		LocationID: 10,
+		Start:      day.Add(7 * time.Hour),
+		End:        day.Add(15 * time.Hour),
+	}
+
+	shifts := []db.ListEmployeesSchedulesInRangeRow{
+		// the slot itself
+		{ID: self, EmployeeID: 1, LocationID: 10, StartDatetime: ts(slot.Start), EndDatetime: ts(slot.End)},
+		// overlapping at another location
+		{ID: uuid.New(), EmployeeID: 1, LocationID: 20, LocationName: "De Linde", StartDatetime: ts(day.Add(14 * time.Hour)), EndDatetime: ts(day.Add(22 * time.Hour))},
+		// the next shift starts when the slot ends
+		{ID: uuid.New(), EmployeeID: 1, LocationID: 10, StartDatetime: ts(day.Add(15 * time.Hour)), EndDatetime: ts(day.Add(23 * time.Hour))},
+		// another employee
+		{ID: uuid.New(), EmployeeID: 2, LocationID: 10, StartDatetime: ts(slot.Start), EndDatetime: ts(slot.End)},
+	}
+	appointments := []db.ListEmployeesAppointmentsInRangeRow{
+		{ID: uuid.New(), EmployeeID: 1, StartTime: ts(day.Add(9 * time.Hour)), EndTime: ts(day.Add(10 * time.Hour))},
+		{ID: uuid.New(), EmployeeID: 1, StartTime: ts(day.Add(16 * time.Hour)), EndTime: ts(day.Add(17 * time.Hour))},
+	}
+
+	conflicts := detectConflicts(slot, shifts, appointments)
+	require.Len(t, conflicts, 2)
+	require.Equal(t, ConflictShift, conflicts[0].Kind)
+	require.Equal(t, SeverityError, conflicts[0].Severity)
+	require.Equal(t, shifts[1].ID, *conflicts[0].ScheduleID)
+	require.Equal(t, "Overlaps with another shift at De Linde", conflicts[0].Message)
+	require.Equal(t, ConflictAppointment, conflicts[1].Kind)
+	require.Equal(t, SeverityWarning, conflicts[1].Severity)
+	require.True(t, HasErrors(conflicts))
+	require.False(t, HasErrors(conflicts[1:]))
+
+	// a new schedule has no id to exclude, so the identical shift conflicts
+	slot.ScheduleID = nil
+	require.Len(t, detectConflicts(slot, shifts[:1], nil), 1)
+}
+
+func TestAsStored(t *testing.T) {
+	amsterdam, err := time.LoadLocation("Europe/Amsterdam")
+	require.NoError(t, err)
+	local := time.Date(2025, time.July, 1, 9, 30, 0, 0, amsterdam)
+	require.Equal(t, time.Date(2025, time.July, 1, 9, 30, 0, 0, time.UTC), asStored(local))
+}
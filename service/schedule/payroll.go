@@ -0,0 +1,143 @@
+package schedule
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	db "maicare_go/db/sqlc"
+	"maicare_go/logger"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+var ErrInvalidPayrollMonth = errors.New("invalid month, expected YYYY-MM")
+
+// GetPayroll returns the planned and worked hours of the employees on the payroll in a
+// month
+func (s *scheduleService) GetPayroll(ctx context.Context, req *PayrollRequest) (*PayrollResponse, error) {
+	start, err := time.Parse("2006-01", req.Month)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPayrollMonth, req.Month)
+	}
+	rows, err := s.Store.ListPayrollShifts(ctx, db.ListPayrollShiftsParams{
+		PeriodStart: pgtype.Timestamp{Time: start, Valid: true},
+		PeriodEnd:   pgtype.Timestamp{Time: start.AddDate(0, 1, 0), Valid: true},
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "GetPayroll", "Failed to list shifts", zap.Error(err), zap.String("month", req.Month))
+		return nil, fmt.Errorf("failed to get payroll")
+	}
+	return &PayrollResponse{Month: req.Month, Employees: buildPayroll(rows)}, nil
+}
+
+// ExportPayroll returns the payroll report of a month as CSV, one line per employee
+func (s *scheduleService) ExportPayroll(ctx context.Context, req *PayrollRequest) (*PayrollExport, error) {
+	payroll, err := s.GetPayroll(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	content, err := renderPayrollCSV(payroll.Employees)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ExportPayroll", "Failed to render payroll", zap.Error(err), zap.String("month", req.Month))
+		return nil, fmt.Errorf("failed to export payroll")
+	}
+	return &PayrollExport{
+		FileName:    fmt.Sprintf("payroll_%s.csv", req.Month),
+		ContentType: "text/csv; charset=utf-8",
+		Content:     content,
+	}, nil
+}
+
+// buildPayroll adds up the shifts per employee. A shift counts with its approved time
+// entry, without breaks, and otherwise with its planned times.
+func buildPayroll(rows []db.ListPayrollShiftsRow) []PayrollEmployee {
+	employees := []PayrollEmployee{}
+	index := make(map[int64]int)
+	for _, row := range rows {
+		i, ok := index[row.EmployeeID]
+		if !ok {
+			employees = append(employees, PayrollEmployee{
+				EmployeeID:    row.EmployeeID,
+				FirstName:     row.FirstName,
+				LastName:      row.LastName,
+				ContractType:  row.ContractType,
+				ContractHours: row.ContractHours,
+			})
+			i = len(employees) - 1
+			index[row.EmployeeID] = i
+		}
+		e := &employees[i]
+
+		planned := row.EndDatetime.Time.Sub(row.StartDatetime.Time).Hours()
+		worked := planned
+		status := ""
+		if row.TimeEntryStatus != nil {
+			status = *row.TimeEntryStatus
+		}
+		switch status {
+		case TimeEntryStatusApproved:
+			e.ApprovedShifts++
+			if row.ActualStart.Valid && row.ActualEnd.Valid {
+				worked = row.ActualEnd.Time.Sub(row.ActualStart.Time).Hours()
+				if row.BreakMinutes != nil {
+					worked -= float64(*row.BreakMinutes) / 60
+				}
+			}
+		case TimeEntryStatusOpen, TimeEntryStatusPending:
+			e.UnapprovedShifts++
+		case TimeEntryStatusRejected:
+			e.RejectedShifts++
+		default:
+			e.UnregisteredShifts++
+		}
+
+		e.Shifts++
+		e.PlannedHours = roundHours(e.PlannedHours + planned)
+		e.WorkedHours = roundHours(e.WorkedHours + worked)
+	}
+	return employees
+}
+
+func renderPayrollCSV(employees []PayrollEmployee) ([]byte, error) {
+	records := [][]string{{"employee_id", "first_name", "last_name", "contract_type", "contract_hours", "shifts", "planned_hours", "worked_hours", "approved_shifts", "unapproved_shifts", "rejected_shifts", "unregistered_shifts"}}
+	for _, e := range employees {
+		contractType, contractHours := "", ""
+		if e.ContractType != nil {
+			contractType = *e.ContractType
+		}
+		if e.ContractHours != nil {
+			contractHours = strconv.FormatFloat(*e.ContractHours, 'f', 2, 64)
+		}
+		records = append(records, []string{
+			strconv.FormatInt(e.EmployeeID, 10),
+			e.FirstName,
+			e.LastName,
+			contractType,
+			contractHours,
+			strconv.Itoa(e.Shifts),
+			strconv.FormatFloat(e.PlannedHours, 'f', 2, 64),
+			strconv.FormatFloat(e.WorkedHours, 'f', 2, 64),
+			strconv.Itoa(e.ApprovedShifts),
+			strconv.Itoa(e.UnapprovedShifts),
+			strconv.Itoa(e.RejectedShifts),
+			strconv.Itoa(e.UnregisteredShifts),
+		})
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.WriteAll(records); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func roundHours(hours float64) float64 {
+	return math.Round(hours*100) / 100
+}
@@ -0,0 +1,361 @@
+package schedule
+
+import (
+	"cmp"
+	"fmt"
+	db "maicare_go/db/sqlc"
+	"maicare_go/util"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DefaultMinRest is the rest between two shifts of an employee when the planner does not
+// set one, the daily rest of the Arbeidstijdenwet
+const DefaultMinRest = 11 * time.Hour
+
+// rosterSlot is a shift of the location on one day of the month
+type rosterSlot struct {
+	LocationShiftID int64
+	Start           time.Time
+	End             time.Time
+	Staff           int
+	Certifications  []CertificationRequirement
+	// employees that are already scheduled on the shift
+	Scheduled []int64
+}
+
+// rosterCandidate is an employee the roster can be filled with. ContractHours are per
+// week, 0 when the employee has no contract hours. Rules are the working time rules of
+// the contract type of the employee, nil when it is exempt. OtherLocation is set when the
+// employee prefers to work at other locations, PreferredShifts when they prefer some of
+// the shifts of the location and MaxNights when they want no more night shifts a month.
+type rosterCandidate struct {
+	EmployeeID      int64
+	ContractHours   float64
+	Certifications  map[string]bool
+	Rules           *WorkingTimeRules
+	OtherLocation   bool
+	PreferredShifts map[int64]bool
+	MaxNights       *int
+}
+
+type rosterPeriod struct {
+	Start time.Time
+	End   time.Time
+}
+
+type rosterInput struct {
+	Slots      []rosterSlot
+	Candidates []rosterCandidate
+	// shifts the candidates already have, at any location
+	Shifts map[int64][]rosterPeriod
+	// leave, sickness and unavailability of the candidates
+	Absences map[int64][]rosterPeriod
+	// the windows of the weekly availability of the candidates that have it
+	Availability map[int64][]rosterPeriod
+	MinRest      time.Duration
+	MonthStart   time.Time
+	MonthEnd     time.Time
+}
+
+// rosterPosition is a position on a slot, EmployeeID is 0 when it could not be filled
+type rosterPosition struct {
+	Slot           int
+	EmployeeID     int64
+	UnfilledReason string
+}
+
+// rosterState is what has been planned for an employee so far
+type rosterState struct {
+	candidate rosterCandidate
+	target    time.Duration // hours in the month, 0 without contract hours
+	worked    time.Duration
+	nights    int
+	weekends  int
+	periods   []rosterPeriod
+	absences  []rosterPeriod
+	// restricted is set when the employee is only available within the windows
+	restricted bool
+	windows    []rosterPeriod
+}
+
+// generateRoster fills the open positions of the slots, earliest slot first. Each
+// position gets the eligible employee that has had the fewest night or weekend shifts
+// when the slot is one, then the one whose preferences the slot goes against least, and
+// then the lowest share of their contract hours. Employees
+// without contract hours are only used when nobody with contract hours is eligible.
+// Positions that need a certification are filled first.
+func generateRoster(input rosterInput) []rosterPosition {
+	states := make(map[int64]*rosterState, len(input.Candidates))
+	var order []int64
+	monthDays := input.MonthEnd.Sub(input.MonthStart).Hours() / 24
+	for _, c := range input.Candidates {
+		state := &rosterState{
+			candidate: c,
+			target:    time.Duration(c.ContractHours * monthDays / 7 * float64(time.Hour)),
+			absences:  input.Absences[c.EmployeeID],
+		}
+		state.windows, state.restricted = input.Availability[c.EmployeeID]
+		for _, p := range input.Shifts[c.EmployeeID] {
+			state.periods = append(state.periods, p)
+			if p.Start.Before(input.MonthStart) || !p.Start.Before(input.MonthEnd) {
+				continue
+			}
+			state.worked += p.End.Sub(p.Start)
+			if isNightShift(p.Start, p.End) {
+				state.nights++
+			}
+			if isWeekendShift(p.Start) {
+				state.weekends++
+			}
+		}
+		states[c.EmployeeID] = state
+		order = append(order, c.EmployeeID)
+	}
+
+	slotOrder := make([]int, len(input.Slots))
+	for i := range slotOrder {
+		slotOrder[i] = i
+	}
+	slices.SortStableFunc(slotOrder, func(a, b int) int {
+		return input.Slots[a].Start.Compare(input.Slots[b].Start)
+	})
+
+	var positions []rosterPosition
+	for _, i := range slotOrder {
+		slot := input.Slots[i]
+		night := isNightShift(slot.Start, slot.End)
+		weekend := isWeekendShift(slot.Start)
+		onSlot := slices.Clone(slot.Scheduled)
+		open := slot.Staff - len(onSlot)
+
+		pick := func(certification string) *rosterState {
+			var best *rosterState
+			for _, id := range order {
+				state := states[id]
+				if slices.Contains(onSlot, id) || !state.eligible(slot, input.MinRest) {
+					continue
+				}
+				if certification != "" && !state.candidate.Certifications[certification] {
+					continue
+				}
+				if best == nil || state.rank(slot, night, weekend, best) < 0 {
+					best = state
+				}
+			}
+			return best
+		}
+		assign := func(state *rosterState) {
+			onSlot = append(onSlot, state.candidate.EmployeeID)
+			state.periods = append(state.periods, rosterPeriod{Start: slot.Start, End: slot.End})
+			state.worked += slot.End.Sub(slot.Start)
+			if night {
+				state.nights++
+			}
+			if weekend {
+				state.weekends++
+			}
+			positions = append(positions, rosterPosition{Slot: i, EmployeeID: state.candidate.EmployeeID})
+			open--
+		}
+
+		for _, req := range slot.Certifications {
+			name := strings.ToLower(req.Name)
+			have := 0
+			for _, id := range onSlot {
+				if state, ok := states[id]; ok && state.candidate.Certifications[name] {
+					have++
+				}
+			}
+			for ; have < req.Count && open > 0; have++ {
+				if state := pick(name); state != nil {
+					assign(state)
+					continue
+				}
+				positions = append(positions, rosterPosition{
+					Slot:           i,
+					UnfilledReason: fmt.Sprintf("no available employee with certification %s", req.Name),
+				})
+				open--
+			}
+		}
+		for open > 0 {
+			if state := pick(""); state != nil {
+				assign(state)
+				continue
+			}
+			positions = append(positions, rosterPosition{Slot: i, UnfilledReason: "no available employee"})
+			open--
+		}
+	}
+	return positions
+}
+
+// eligible reports whether the employee can work the slot: available and not absent,
+// enough rest before and after their other shifts, room left in their contract hours and
+// night shifts, and no working time rule broken
+func (s *rosterState) eligible(slot rosterSlot, minRest time.Duration) bool {
+	for _, a := range s.absences {
+		if overlaps(slot.Start, slot.End, a.Start, a.End) {
+			return false
+		}
+	}
+	if s.restricted && !covered(slot.Start, slot.End, s.windows) {
+		return false
+	}
+	if s.candidate.MaxNights != nil && s.nights >= *s.candidate.MaxNights && isNightShift(slot.Start, slot.End) {
+		return false
+	}
+	for _, p := range s.periods {
+		if overlaps(slot.Start, slot.End.Add(minRest), p.Start, p.End.Add(minRest)) {
+			return false
+		}
+	}
+	if s.target != 0 && s.worked+slot.End.Sub(slot.Start) > s.target {
+		return false
+	}
+	return s.candidate.Rules == nil || !s.breaksWorkingTime(slot)
+}
+
+// breaksWorkingTime reports whether working the slot breaks a working time rule of the
+// employee
+func (s *rosterState) breaksWorkingTime(slot rosterSlot) bool {
+	others := make([]workPeriod, len(s.periods))
+	for i, p := range s.periods {
+		others[i] = workPeriod{Start: p.Start, End: p.End}
+	}
+	return len(newWorkingTimeViolations(*s.candidate.Rules, others, workPeriod{Start: slot.Start, End: slot.End})) > 0
+}
+
+// rank compares two employees for a slot, the lower one gets the slot
+func (s *rosterState) rank(slot rosterSlot, night, weekend bool, other *rosterState) int {
+	return cmp.Or(
+		compareBool(s.target == 0, other.target == 0),
+		cmp.Compare(s.burden(night, weekend), other.burden(night, weekend)),
+		cmp.Compare(s.aversion(slot), other.aversion(slot)),
+		cmp.Compare(s.share(), other.share()),
+		cmp.Compare(s.candidate.EmployeeID, other.candidate.EmployeeID),
+	)
+}
+
+// burden is the number of unpopular shifts of the kind of the slot the employee already has
+func (s *rosterState) burden(night, weekend bool) int {
+	burden := 0
+	if night {
+		burden += s.nights
+	}
+	if weekend {
+		burden += s.weekends
+	}
+	return burden
+}
+
+// aversion is the number of preferences of the employee the slot goes against
+func (s *rosterState) aversion(slot rosterSlot) int {
+	aversion := 0
+	if s.candidate.OtherLocation {
+		aversion++
+	}
+	if s.candidate.PreferredShifts != nil && !s.candidate.PreferredShifts[slot.LocationShiftID] {
+		aversion++
+	}
+	return aversion
+}
+
+// share is the part of the contract hours that has been planned
+func (s *rosterState) share() float64 {
+	if s.target == 0 {
+		return 0
+	}
+	return float64(s.worked) / float64(s.target)
+}
+
+func compareBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	default:
+		return -1
+	}
+}
+
+// isNightShift reports whether a shift is a night shift as the Arbeidstijdenwet defines it:
+// more than an hour of work between midnight and 6:00
+func isNightShift(start, end time.Time) bool {
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+	for ; day.Before(end); day = day.AddDate(0, 0, 1) {
+		from, to := maxTime(start, day), minTime(end, day.Add(6*time.Hour))
+		if to.Sub(from) > time.Hour {
+			return true
+		}
+	}
+	return false
+}
+
+// isWeekendShift reports whether a shift starts on a Saturday or Sunday
+func isWeekendShift(start time.Time) bool {
+	return start.Weekday() == time.Saturday || start.Weekday() == time.Sunday
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+// buildRosterSlots returns a slot for each shift required on each day of the month. The
+// employees already scheduled on a shift take up positions of its slot.
+func buildRosterSlots(monthStart time.Time, shifts map[int64]db.LocationShift, requirements func(time.Weekday) []ShiftRequirement, existing []db.GetMonthlySchedulesByLocationRow) []rosterSlot {
+	scheduled := make(map[string][]int64)
+	for _, row := range existing {
+		if row.LocationShiftID == nil {
+			continue
+		}
+		key := slotKey(*row.LocationShiftID, row.StartDatetime.Time)
+		scheduled[key] = append(scheduled[key], row.EmployeeID)
+	}
+
+	var slots []rosterSlot
+	for day := monthStart; day.Month() == monthStart.Month(); day = day.AddDate(0, 0, 1) {
+		for _, req := range requirements(day.Weekday()) {
+			start, end := shiftTimes(day, shifts[req.LocationShiftID])
+			slots = append(slots, rosterSlot{
+				LocationShiftID: req.LocationShiftID,
+				Start:           start,
+				End:             end,
+				Staff:           req.Staff,
+				Certifications:  req.Certifications,
+				Scheduled:       scheduled[slotKey(req.LocationShiftID, start)],
+			})
+		}
+	}
+	return slots
+}
+
+// shiftTimes returns when a location shift starts and ends on a day, the same way preset
+// shift schedules are created
+func shiftTimes(day time.Time, shift db.LocationShift) (time.Time, time.Time) {
+	startHour, startMin, startSec, startNano := util.MicrosecondsToTimeComponents(shift.StartTime.Microseconds)
+	endHour, endMin, endSec, endNano := util.MicrosecondsToTimeComponents(shift.EndTime.Microseconds)
+	start := time.Date(day.Year(), day.Month(), day.Day(), startHour, startMin, startSec, startNano, time.UTC)
+	end := time.Date(day.Year(), day.Month(), day.Day(), endHour, endMin, endSec, endNano, time.UTC)
+	if shift.EndTime.Microseconds < shift.StartTime.Microseconds {
+		end = end.AddDate(0, 0, 1)
+	}
+	return start, end
+}
+
+func slotKey(locationShiftID int64, start time.Time) string {
+	return fmt.Sprintf("%d/%s", locationShiftID, start.Format("2006-01-02"))
+}
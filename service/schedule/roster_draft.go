@@ -0,0 +1,609 @@
+package schedule
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	db "maicare_go/db/sqlc"
+	"maicare_go/logger"
+	"maicare_go/notification"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+const (
+	RosterStatusDraft     = "draft"
+	RosterStatusPublished = "published"
+)
+
+var (
+	ErrInvalidRosterRequirements = errors.New("invalid staffing requirements")
+	ErrRosterDraftNotFound       = errors.New("roster draft not found")
+	ErrRosterDraftPublished      = errors.New("roster draft is already published")
+	ErrRosterAssignmentNotFound  = errors.New("roster assignment not found")
+	ErrRosterAssignmentOverlap   = errors.New("the employee already has an overlapping position in the draft")
+	ErrRosterConflict            = errors.New("positions of the draft conflict with other shifts of their employees")
+)
+
+// GenerateRosterDraft proposes a roster for the shifts of a location in a month and saves
+// it as a draft. Shifts that are already scheduled count towards the required staff.
+// Employees are only planned when they are available, favouring their shift preferences.
+func (s *scheduleService) GenerateRosterDraft(ctx context.Context, locationID, employeeID int64, req *GenerateRosterRequest) (*RosterDraftResponse, error) {
+	if req.Year < 1 || req.Month < 1 || req.Month > 12 {
+		return nil, ErrInvalidMonth
+	}
+
+	locationShifts, err := s.Store.GetShiftsByLocationID(ctx, locationID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "GenerateRosterDraft", "Failed to get location shifts", zap.Error(err), zap.Int64("location_id", locationID))
+		return nil, fmt.Errorf("failed to generate roster")
+	}
+	shifts := make(map[int64]db.LocationShift, len(locationShifts))
+	for _, shift := range locationShifts {
+		shifts[shift.ID] = shift
+	}
+	seen := make(map[int64]bool)
+	for _, r := range req.Requirements {
+		if _, ok := shifts[r.LocationShiftID]; !ok {
+			return nil, fmt.Errorf("%w: shift %d does not belong to the location", ErrInvalidRosterRequirements, r.LocationShiftID)
+		}
+		if seen[r.LocationShiftID] {
+			return nil, fmt.Errorf("%w: shift %d is listed more than once", ErrInvalidRosterRequirements, r.LocationShiftID)
+		}
+		seen[r.LocationShiftID] = true
+		for _, c := range r.Certifications {
+			if c.Count > r.Staff {
+				return nil, fmt.Errorf("%w: more staff with %s than staff on shift %d", ErrInvalidRosterRequirements, c.Name, r.LocationShiftID)
+			}
+		}
+	}
+
+	// without requirements the roster follows the staffing norms of the location
+	requirements := func(time.Weekday) []ShiftRequirement { return req.Requirements }
+	if len(req.Requirements) == 0 {
+		normRows, err := s.Store.ListStaffingNorms(ctx, locationID)
+		if err != nil {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "GenerateRosterDraft", "Failed to list staffing norms", zap.Error(err), zap.Int64("location_id", locationID))
+			return nil, fmt.Errorf("failed to generate roster")
+		}
+		if len(normRows) == 0 {
+			return nil, fmt.Errorf("%w: the location has no staffing norms", ErrInvalidRosterRequirements)
+		}
+		norms := make([]db.StaffingNorm, len(normRows))
+		for i, row := range normRows {
+			norms[i] = staffingNorm(row)
+		}
+		requirements = func(weekday time.Weekday) []ShiftRequirement { return normRequirements(norms, weekday) }
+	}
+
+	minRest := DefaultMinRest
+	if req.MinRestHours != nil {
+		minRest = time.Duration(*req.MinRestHours * float64(time.Hour))
+	}
+	monthStart := time.Date(int(req.Year), time.Month(req.Month), 1, 0, 0, 0, 0, time.UTC)
+	monthEnd := monthStart.AddDate(0, 1, 0)
+
+	existing, err := s.Store.GetMonthlySchedulesByLocation(ctx, db.GetMonthlySchedulesByLocationParams{
+		Year:       req.Year,
+		Month:      req.Month,
+		LocationID: locationID,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "GenerateRosterDraft", "Failed to get schedules", zap.Error(err), zap.Int64("location_id", locationID))
+		return nil, fmt.Errorf("failed to generate roster")
+	}
+
+	candidateRows, err := s.Store.ListRosterCandidates(ctx, db.ListRosterCandidatesParams{
+		EmployeeIds: req.EmployeeIDs,
+		LocationID:  &locationID,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "GenerateRosterDraft", "Failed to list employees", zap.Error(err), zap.Int64("location_id", locationID))
+		return nil, fmt.Errorf("failed to generate roster")
+	}
+	rules, err := s.workingTimeRules(ctx, "GenerateRosterDraft")
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([]rosterCandidate, 0, len(candidateRows))
+	employeeIDs := make([]int64, 0, len(candidateRows))
+	for _, row := range candidateRows {
+		candidate := rosterCandidate{
+			EmployeeID:     row.ID,
+			Certifications: make(map[string]bool, len(row.Certifications)),
+		}
+		if row.ContractHours != nil {
+			candidate.ContractHours = *row.ContractHours
+		}
+		for _, name := range row.Certifications {
+			candidate.Certifications[name] = true
+		}
+		if r, ok := rules[contractType(row.ContractType)]; ok && !r.Exempt {
+			candidate.Rules = &r
+		}
+		candidates = append(candidates, candidate)
+		employeeIDs = append(employeeIDs, row.ID)
+	}
+	preferences, err := s.Store.ListEmployeesShiftPreferences(ctx, employeeIDs)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "GenerateRosterDraft", "Failed to list shift preferences", zap.Error(err), zap.Int64("location_id", locationID))
+		return nil, fmt.Errorf("failed to generate roster")
+	}
+	for _, p := range preferences {
+		i := slices.Index(employeeIDs, p.EmployeeID)
+		applyPreferences(&candidates[i], p, locationID, shifts)
+	}
+
+	// shifts just outside the month matter for the rest between shifts, and the weeks
+	// before it for the averages of the working time rules
+	margin := minRest + 24*time.Hour
+	busyRows, err := s.Store.ListEmployeesSchedulesInRange(ctx, db.ListEmployeesSchedulesInRangeParams{
+		EmployeeIds: employeeIDs,
+		StartDate:   pgtype.Timestamp{Time: monthStart.Add(-margin - (referenceWeeks+1)*oneWeek), Valid: true},
+		EndDate:     pgtype.Timestamp{Time: monthEnd.Add(margin + 2*oneWeek), Valid: true},
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "GenerateRosterDraft", "Failed to list shifts", zap.Error(err), zap.Int64("location_id", locationID))
+		return nil, fmt.Errorf("failed to generate roster")
+	}
+	busy := make(map[int64][]rosterPeriod)
+	for _, row := range busyRows {
+		busy[row.EmployeeID] = append(busy[row.EmployeeID], rosterPeriod{Start: row.StartDatetime.Time, End: row.EndDatetime.Time})
+	}
+	// leave that is still pending counts as well, the planner decides on it before the
+	// roster is published
+	absences, err := s.absences(ctx, "GenerateRosterDraft", employeeIDs, monthStart, monthEnd.Add(margin))
+	if err != nil {
+		return nil, err
+	}
+	blocked := absentPeriods(absences, monthEnd.Add(margin))
+	weekly, unavailable, err := s.availability(ctx, "GenerateRosterDraft", employeeIDs, monthStart, monthEnd.Add(margin))
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range unavailable {
+		blocked[u.EmployeeID] = append(blocked[u.EmployeeID], rosterPeriod{Start: u.StartDatetime.Time, End: u.EndDatetime.Time})
+	}
+
+	slots := buildRosterSlots(monthStart, shifts, requirements, existing)
+	positions := generateRoster(rosterInput{
+		Slots:        slots,
+		Candidates:   candidates,
+		Shifts:       busy,
+		Absences:     blocked,
+		Availability: weeklyWindows(weekly, monthStart, monthEnd.Add(margin)),
+		MinRest:      minRest,
+		MonthStart:   monthStart,
+		MonthEnd:     monthEnd,
+	})
+
+	settings, err := json.Marshal(RosterSettings{
+		Requirements:  req.Requirements,
+		StaffingNorms: len(req.Requirements) == 0,
+		MinRestHours:  minRest.Hours(),
+		EmployeeIDs:   req.EmployeeIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate roster")
+	}
+
+	arg := db.CreateRosterDraftAssignmentsParams{}
+	for _, p := range positions {
+		slot := slots[p.Slot]
+		arg.LocationShiftIds = append(arg.LocationShiftIds, slot.LocationShiftID)
+		arg.EmployeeIds = append(arg.EmployeeIds, p.EmployeeID)
+		arg.StartDatetimes = append(arg.StartDatetimes, pgtype.Timestamp{Time: slot.Start, Valid: true})
+		arg.EndDatetimes = append(arg.EndDatetimes, pgtype.Timestamp{Time: slot.End, Valid: true})
+		arg.UnfilledReasons = append(arg.UnfilledReasons, p.UnfilledReason)
+	}
+
+	tx, err := s.Store.ConnPool.Begin(ctx)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "GenerateRosterDraft", "Failed to begin transaction", zap.Error(err))
+		return nil, fmt.Errorf("failed to generate roster")
+	}
+	defer func() {
+		if rbErr := tx.Rollback(ctx); rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "GenerateRosterDraft", "Failed to rollback transaction", zap.Error(rbErr))
+		}
+	}()
+	qtx := s.Store.WithTx(tx)
+
+	draft, err := qtx.CreateRosterDraft(ctx, db.CreateRosterDraftParams{
+		LocationID:          locationID,
+		Year:                req.Year,
+		Month:               req.Month,
+		Requirements:        settings,
+		CreatedByEmployeeID: &employeeID,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "GenerateRosterDraft", "Failed to create draft", zap.Error(err), zap.Int64("location_id", locationID))
+		return nil, fmt.Errorf("failed to generate roster")
+	}
+	arg.DraftID = draft.ID
+	if len(positions) > 0 {
+		if err := qtx.CreateRosterDraftAssignments(ctx, arg); err != nil {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "GenerateRosterDraft", "Failed to create assignments", zap.Error(err), zap.Int64("draft_id", draft.ID))
+			return nil, fmt.Errorf("failed to generate roster")
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "GenerateRosterDraft", "Failed to commit transaction", zap.Error(err))
+		return nil, fmt.Errorf("failed to generate roster")
+	}
+
+	s.Logger.LogBusinessEvent(logger.LogLevelInfo, "GenerateRosterDraft", "Roster draft generated",
+		zap.Int64("draft_id", draft.ID), zap.Int64("location_id", locationID), zap.Int("positions", len(positions)))
+	return s.GetRosterDraft(ctx, draft.ID)
+}
+
+func (s *scheduleService) GetRosterDraft(ctx context.Context, draftID int64) (*RosterDraftResponse, error) {
+	draft, err := s.Store.GetRosterDraft(ctx, draftID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrRosterDraftNotFound
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "GetRosterDraft", "Failed to get draft", zap.Error(err), zap.Int64("draft_id", draftID))
+		return nil, fmt.Errorf("failed to get roster draft")
+	}
+	assignments, err := s.Store.ListRosterDraftAssignments(ctx, draftID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "GetRosterDraft", "Failed to list assignments", zap.Error(err), zap.Int64("draft_id", draftID))
+		return nil, fmt.Errorf("failed to get roster draft")
+	}
+	return rosterDraftResponse(draft, assignments), nil
+}
+
+func (s *scheduleService) ListRosterDrafts(ctx context.Context, locationID int64) ([]RosterDraftListItem, error) {
+	drafts, err := s.Store.ListRosterDrafts(ctx, locationID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ListRosterDrafts", "Failed to list drafts", zap.Error(err), zap.Int64("location_id", locationID))
+		return nil, fmt.Errorf("failed to list roster drafts")
+	}
+	items := make([]RosterDraftListItem, len(drafts))
+	for i, draft := range drafts {
+		items[i] = rosterDraftListItem(draft)
+	}
+	return items, nil
+}
+
+// UpdateRosterAssignment gives a position of a draft to another employee, or leaves it
+// open. An employee can only have one position at a time in a draft.
+func (s *scheduleService) UpdateRosterAssignment(ctx context.Context, draftID, assignmentID int64, req *UpdateRosterAssignmentRequest) (*RosterDraftResponse, error) {
+	draft, err := s.Store.GetRosterDraft(ctx, draftID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrRosterDraftNotFound
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "UpdateRosterAssignment", "Failed to get draft", zap.Error(err), zap.Int64("draft_id", draftID))
+		return nil, fmt.Errorf("failed to update roster assignment")
+	}
+	if draft.Status != RosterStatusDraft {
+		return nil, ErrRosterDraftPublished
+	}
+
+	assignment, err := s.Store.GetRosterDraftAssignment(ctx, db.GetRosterDraftAssignmentParams{
+		ID:      assignmentID,
+		DraftID: draftID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrRosterAssignmentNotFound
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "UpdateRosterAssignment", "Failed to get assignment", zap.Error(err), zap.Int64("assignment_id", assignmentID))
+		return nil, fmt.Errorf("failed to update roster assignment")
+	}
+
+	if req.EmployeeID != nil {
+		overlapping, err := s.Store.CountOverlappingRosterDraftAssignments(ctx, db.CountOverlappingRosterDraftAssignmentsParams{
+			DraftID:       draftID,
+			EmployeeID:    req.EmployeeID,
+			ID:            assignmentID,
+			StartDatetime: assignment.StartDatetime,
+			EndDatetime:   assignment.EndDatetime,
+		})
+		if err != nil {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "UpdateRosterAssignment", "Failed to check overlapping assignments", zap.Error(err), zap.Int64("assignment_id", assignmentID))
+			return nil, fmt.Errorf("failed to update roster assignment")
+		}
+		if overlapping > 0 {
+			return nil, ErrRosterAssignmentOverlap
+		}
+	}
+
+	err = s.Store.UpdateRosterDraftAssignmentEmployee(ctx, db.UpdateRosterDraftAssignmentEmployeeParams{
+		ID:         assignmentID,
+		EmployeeID: req.EmployeeID,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "UpdateRosterAssignment", "Failed to update assignment", zap.Error(err), zap.Int64("assignment_id", assignmentID))
+		return nil, fmt.Errorf("failed to update roster assignment")
+	}
+	return s.GetRosterDraft(ctx, draftID)
+}
+
+// DeleteRosterDraft discards a draft. Published drafts are kept as the record of what
+// was published.
+func (s *scheduleService) DeleteRosterDraft(ctx context.Context, draftID int64) error {
+	deleted, err := s.Store.DeleteRosterDraft(ctx, draftID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "DeleteRosterDraft", "Failed to delete draft", zap.Error(err), zap.Int64("draft_id", draftID))
+		return fmt.Errorf("failed to delete roster draft")
+	}
+	if deleted > 0 {
+		return nil
+	}
+	if _, err := s.Store.GetRosterDraft(ctx, draftID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrRosterDraftNotFound
+		}
+		return fmt.Errorf("failed to delete roster draft")
+	}
+	return ErrRosterDraftPublished
+}
+
+// PublishRosterDraft creates a schedule for every filled position of a draft and notifies
+// the employees. The positions are checked again for conflicts, the employees may have
+// been scheduled elsewhere since the draft was generated; those conflicts are returned with
+// ErrRosterConflict unless override is set.
+func (s *scheduleService) PublishRosterDraft(ctx context.Context, draftID, employeeID int64, override bool) (*RosterDraftResponse, []RosterAssignmentConflicts, error) {
+	draft, err := s.GetRosterDraft(ctx, draftID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if draft.Status != RosterStatusDraft {
+		return nil, nil, ErrRosterDraftPublished
+	}
+
+	var filled []RosterAssignmentResponse
+	var employeeIDs []int64
+	for _, a := range draft.Assignments {
+		if a.EmployeeID == nil {
+			continue
+		}
+		filled = append(filled, a)
+		if !slices.Contains(employeeIDs, *a.EmployeeID) {
+			employeeIDs = append(employeeIDs, *a.EmployeeID)
+		}
+	}
+
+	if len(filled) > 0 {
+		arg := db.ListEmployeesSchedulesInRangeParams{
+			EmployeeIds: employeeIDs,
+			StartDate:   pgtype.Timestamp{Time: filled[0].StartTime, Valid: true},
+			EndDate:     pgtype.Timestamp{Time: filled[len(filled)-1].EndTime.Add(24 * time.Hour), Valid: true},
+		}
+		shifts, err := s.Store.ListEmployeesSchedulesInRange(ctx, arg)
+		if err != nil {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "PublishRosterDraft", "Failed to list shifts", zap.Error(err), zap.Int64("draft_id", draftID))
+			return nil, nil, fmt.Errorf("failed to publish roster draft")
+		}
+		appointments, err := s.Store.ListEmployeesAppointmentsInRange(ctx, db.ListEmployeesAppointmentsInRangeParams(arg))
+		if err != nil {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "PublishRosterDraft", "Failed to list appointments", zap.Error(err), zap.Int64("draft_id", draftID))
+			return nil, nil, fmt.Errorf("failed to publish roster draft")
+		}
+		absences, err := s.absences(ctx, "PublishRosterDraft", employeeIDs, arg.StartDate.Time, arg.EndDate.Time)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var blocking []RosterAssignmentConflicts
+		for _, a := range filled {
+			slot := Slot{
+				EmployeeID: *a.EmployeeID,
+				LocationID: draft.LocationID,
+				Start:      a.StartTime,
+				End:        a.EndTime,
+			}
+			conflicts := append(detectConflicts(slot, shifts, appointments), absenceConflicts(slot, absences)...)
+			if HasErrors(conflicts) {
+				blocking = append(blocking, RosterAssignmentConflicts{
+					AssignmentID: a.ID,
+					EmployeeID:   *a.EmployeeID,
+					StartTime:    a.StartTime,
+					EndTime:      a.EndTime,
+					Conflicts:    conflicts,
+				})
+			}
+		}
+		if len(blocking) > 0 {
+			if !override {
+				return nil, blocking, ErrRosterConflict
+			}
+			s.Logger.LogBusinessEvent(logger.LogLevelInfo, "PublishRosterDraft", "Roster conflicts overridden",
+				zap.Int64("draft_id", draftID), zap.Int("positions", len(blocking)))
+		}
+	}
+
+	tx, err := s.Store.ConnPool.Begin(ctx)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "PublishRosterDraft", "Failed to begin transaction", zap.Error(err))
+		return nil, nil, fmt.Errorf("failed to publish roster draft")
+	}
+	defer func() {
+		if rbErr := tx.Rollback(ctx); rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "PublishRosterDraft", "Failed to rollback transaction", zap.Error(rbErr))
+		}
+	}()
+	qtx := s.Store.WithTx(tx)
+
+	// a draft is published once, also when two planners publish it at the same time
+	locked, err := qtx.GetRosterDraftForUpdate(ctx, draftID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "PublishRosterDraft", "Failed to lock draft", zap.Error(err), zap.Int64("draft_id", draftID))
+		return nil, nil, fmt.Errorf("failed to publish roster draft")
+	}
+	if locked.Status != RosterStatusDraft {
+		return nil, nil, ErrRosterDraftPublished
+	}
+
+	created := make([]db.CreateScheduleRow, 0, len(filled))
+	for _, a := range filled {
+		locationShiftID := a.LocationShiftID
+		schedule, err := qtx.CreateSchedule(ctx, db.CreateScheduleParams{
+			EmployeeID:          a.EmployeeID,
+			LocationID:          draft.LocationID,
+			LocationShiftID:     &locationShiftID,
+			IsCustom:            false,
+			CreatedByEmployeeID: employeeID,
+			StartDatetime:       pgtype.Timestamp{Time: a.StartTime, Valid: true},
+			EndDatetime:         pgtype.Timestamp{Time: a.EndTime, Valid: true},
+		})
+		if err != nil {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "PublishRosterDraft", "Failed to create schedule", zap.Error(err), zap.Int64("assignment_id", a.ID))
+			return nil, nil, fmt.Errorf("failed to publish roster draft")
+		}
+		if err := qtx.SetRosterDraftAssignmentSchedule(ctx, db.SetRosterDraftAssignmentScheduleParams{
+			ID:         a.ID,
+			ScheduleID: &schedule.ID,
+		}); err != nil {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "PublishRosterDraft", "Failed to link schedule", zap.Error(err), zap.Int64("assignment_id", a.ID))
+			return nil, nil, fmt.Errorf("failed to publish roster draft")
+		}
+		created = append(created, schedule)
+	}
+
+	if err := qtx.PublishRosterDraft(ctx, db.PublishRosterDraftParams{
+		ID:                    draftID,
+		PublishedByEmployeeID: &employeeID,
+	}); err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "PublishRosterDraft", "Failed to publish draft", zap.Error(err), zap.Int64("draft_id", draftID))
+		return nil, nil, fmt.Errorf("failed to publish roster draft")
+	}
+	if err := tx.Commit(ctx); err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "PublishRosterDraft", "Failed to commit transaction", zap.Error(err))
+		return nil, nil, fmt.Errorf("failed to publish roster draft")
+	}
+
+	s.Logger.LogBusinessEvent(logger.LogLevelInfo, "PublishRosterDraft", "Roster draft published",
+		zap.Int64("draft_id", draftID), zap.Int("schedules", len(created)))
+	s.notifyNewSchedules(ctx, created, employeeID)
+
+	published, err := s.GetRosterDraft(ctx, draftID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return published, nil, nil
+}
+
+// notifyNewSchedules sends the new schedule notification for each schedule to its employee
+func (s *scheduleService) notifyNewSchedules(ctx context.Context, schedules []db.CreateScheduleRow, createdBy int64) {
+	userIDs := make(map[int64]int64)
+	for _, schedule := range schedules {
+		if schedule.EmployeeID == nil {
+			continue
+		}
+		employeeID := *schedule.EmployeeID
+		userID, ok := userIDs[employeeID]
+		if !ok {
+			var err error
+			userID, err = s.Store.GetUserIDByEmployeeID(ctx, employeeID)
+			if err != nil {
+				s.Logger.LogBusinessEvent(logger.LogLevelError, "NotifyNewSchedules", "Failed to get user of employee", zap.Error(err), zap.Int64("employee_id", employeeID))
+				continue
+			}
+			userIDs[employeeID] = userID
+		}
+
+		data := &notification.NewScheduleNotificationData{
+			ScheduleID: schedule.ID,
+			CreatedBy:  createdBy,
+			StartTime:  schedule.StartDatetime.Time,
+			EndTime:    schedule.EndDatetime.Time,
+			Location:   schedule.LocationName,
+		}
+		err := s.AsynqClient.EnqueueNotificationTask(ctx, notification.NotificationPayload{
+			RecipientUserIDs: []int64{userID},
+			Type:             notification.TypeNewScheduleNotification,
+			Data:             notification.NotificationData{NewScheduleNotification: data},
+			CreatedAt:        time.Now(),
+			Message:          data.NewScheduleMessage(),
+		})
+		if err != nil {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "NotifyNewSchedules", "Failed to enqueue notification task", zap.Error(err), zap.String("schedule_id", schedule.ID.String()))
+		}
+	}
+}
+
+func rosterDraftListItem(draft db.RosterDraft) RosterDraftListItem {
+	item := RosterDraftListItem{
+		ID:         draft.ID,
+		LocationID: draft.LocationID,
+		Year:       draft.Year,
+		Month:      draft.Month,
+		Status:     draft.Status,
+		CreatedAt:  draft.CreatedAt.Time,
+	}
+	if draft.PublishedAt.Valid {
+		item.PublishedAt = &draft.PublishedAt.Time
+	}
+	return item
+}
+
+func rosterDraftResponse(draft db.RosterDraft, rows []db.ListRosterDraftAssignmentsRow) *RosterDraftResponse {
+	resp := &RosterDraftResponse{
+		RosterDraftListItem:   rosterDraftListItem(draft),
+		CreatedByEmployeeID:   draft.CreatedByEmployeeID,
+		PublishedByEmployeeID: draft.PublishedByEmployeeID,
+		Positions:             len(rows),
+		Assignments:           make([]RosterAssignmentResponse, len(rows)),
+		Employees:             []RosterEmployeeSummary{},
+	}
+	// the settings are written by GenerateRosterDraft, a draft is still useful without them
+	_ = json.Unmarshal(draft.Requirements, &resp.Settings)
+
+	summaries := make(map[int64]*RosterEmployeeSummary)
+	for i, row := range rows {
+		start, end := row.StartDatetime.Time, row.EndDatetime.Time
+		a := RosterAssignmentResponse{
+			ID:                row.ID,
+			LocationShiftID:   row.LocationShiftID,
+			ShiftName:         row.ShiftName,
+			EmployeeID:        row.EmployeeID,
+			EmployeeFirstName: row.EmployeeFirstName,
+			EmployeeLastName:  row.EmployeeLastName,
+			StartTime:         start,
+			EndTime:           end,
+			Night:             isNightShift(start, end),
+			Weekend:           isWeekendShift(start),
+			UnfilledReason:    row.UnfilledReason,
+			ScheduleID:        row.ScheduleID,
+		}
+		resp.Assignments[i] = a
+
+		if row.EmployeeID == nil {
+			resp.Unfilled++
+			continue
+		}
+		summary, ok := summaries[*row.EmployeeID]
+		if !ok {
+			summary = &RosterEmployeeSummary{EmployeeID: *row.EmployeeID}
+			if row.EmployeeFirstName != nil && row.EmployeeLastName != nil {
+				summary.FirstName, summary.LastName = *row.EmployeeFirstName, *row.EmployeeLastName
+			}
+			summaries[*row.EmployeeID] = summary
+		}
+		summary.Shifts++
+		summary.Hours += end.Sub(start).Hours()
+		if a.Night {
+			summary.NightShifts++
+		}
+		if a.Weekend {
+			summary.WeekendShifts++
+		}
+	}
+	for _, summary := range summaries {
+		resp.Employees = append(resp.Employees, *summary)
+	}
+	slices.SortFunc(resp.Employees, func(a, b RosterEmployeeSummary) int {
+		return strings.Compare(a.LastName+" "+a.FirstName, b.LastName+" "+b.FirstName)
+	})
+	return resp
+}
@@ -0,0 +1,174 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	db "maicare_go/db/sqlc"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+// week returns a slot for each day of the week starting on Monday 3 March 2025
+func week(startHour, hours, staff int, certifications ...CertificationRequirement) []rosterSlot {
+	monday := time.Date(2025, time.March, 3, startHour, 0, 0, 0, time.UTC)
+	var slots []rosterSlot
+	for i := 0; i < 7; i++ {
+		start := monday.AddDate(0, 0, i)
+		slots = append(slots, rosterSlot{
+			LocationShiftID: 1,
+			Start:           start,
+			End:             start.Add(time.Duration(hours) * time.Hour),
+			Staff:           staff,
+			Certifications:  certifications,
+		})
+	}
+	return slots
+}
+
+func weekInput(slots []rosterSlot, candidates ...rosterCandidate) rosterInput {
+	start := time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC)
+	return rosterInput{
+		Slots:      slots,
+		Candidates: candidates,
+		MinRest:    DefaultMinRest,
+		MonthStart: start,
+		MonthEnd:   start.AddDate(0, 0, 7),
+	}
+}
+
+func assigned(positions []rosterPosition) map[int64]int {
+	counts := make(map[int64]int)
+	for _, p := range positions {
+		counts[p.EmployeeID]++
+	}
+	return counts
+}
+
+func TestGenerateRosterDistributesNights(t *testing.T) {
+	positions := generateRoster(weekInput(week(23, 8, 1),
+		rosterCandidate{EmployeeID: 1, ContractHours: 40},
+		rosterCandidate{EmployeeID: 2, ContractHours: 40},
+		rosterCandidate{EmployeeID: 3, ContractHours: 40},
+	))
+	require.Len(t, positions, 7)
+	require.Equal(t, map[int64]int{1: 3, 2: 2, 3: 2}, assigned(positions))
+}
+
+func TestGenerateRosterRestAndContractHours(t *testing.T) {
+	slots := append(week(7, 8, 1), week(15, 8, 1)...)
+	positions := generateRoster(weekInput(slots,
+		rosterCandidate{EmployeeID: 1, ContractHours: 24},
+		rosterCandidate{EmployeeID: 2, ContractHours: 24},
+	))
+
+	// 3 shifts each, and the late shift never leaves 11 hours before the next early shift
+	counts := assigned(positions)
+	require.Equal(t, 3, counts[1])
+	require.Equal(t, 3, counts[2])
+	require.Equal(t, 8, counts[0])
+
+	periods := make(map[int64][]rosterPeriod)
+	for _, p := range positions {
+		if p.EmployeeID == 0 {
+			require.Equal(t, "no available employee", p.UnfilledReason)
+			continue
+		}
+		slot := slots[p.Slot]
+		for _, other := range periods[p.EmployeeID] {
+			require.False(t, overlaps(slot.Start, slot.End.Add(DefaultMinRest), other.Start, other.End.Add(DefaultMinRest)))
+		}
+		periods[p.EmployeeID] = append(periods[p.EmployeeID], rosterPeriod{Start: slot.Start, End: slot.End})
+	}
+}
+
+func TestGenerateRosterCertifications(t *testing.T) {
+	bhv := map[string]bool{"bhv": true}
+	positions := generateRoster(weekInput(week(7, 8, 2, CertificationRequirement{Name: "BHV", Count: 1}),
+		rosterCandidate{EmployeeID: 1, ContractHours: 40},
+		rosterCandidate{EmployeeID: 2, ContractHours: 40},
+		rosterCandidate{EmployeeID: 3, ContractHours: 24, Certifications: bhv},
+	))
+
+	// the only BHV certified employee can work 3 days, the other days lack one
+	var unfilled []string
+	for _, p := range positions {
+		if p.EmployeeID == 0 {
+			unfilled = append(unfilled, p.UnfilledReason)
+		}
+	}
+	require.Len(t, positions, 14)
+	require.Equal(t, 3, assigned(positions)[3])
+	require.Len(t, unfilled, 4)
+	require.Equal(t, "no available employee with certification BHV", unfilled[0])
+}
+
+func TestGenerateRosterExistingShifts(t *testing.T) {
+	slots := week(7, 8, 1)
+	// employee 1 is already on the Monday shift, employee 2 has a shift elsewhere on Tuesday
+	slots[0].Scheduled = []int64{1}
+	input := weekInput(slots,
+		rosterCandidate{EmployeeID: 1, ContractHours: 40},
+		rosterCandidate{EmployeeID: 2, ContractHours: 40},
+		rosterCandidate{EmployeeID: 3},
+	)
+	input.Shifts = map[int64][]rosterPeriod{
+		1: {{Start: slots[0].Start, End: slots[0].End}},
+		2: {{Start: slots[1].Start, End: slots[1].End}},
+	}
+
+	positions := generateRoster(input)
+	require.Len(t, positions, 6)
+	require.Equal(t, 1, positions[0].Slot)
+	require.Equal(t, int64(1), positions[0].EmployeeID)
+	// the employee without contract hours is not needed
+	require.Zero(t, assigned(positions)[3])
+}
+
+func TestIsNightShift(t *testing.T) {
+	day := time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC)
+	require.True(t, isNightShift(day.Add(23*time.Hour), day.Add(31*time.Hour+30*time.Minute)))
+	require.True(t, isNightShift(day.Add(3*time.Hour), day.Add(11*time.Hour)))
+	require.False(t, isNightShift(day.Add(15*time.Hour), day.Add(23*time.Hour)))
+	// an hour between midnight and 6:00 is not enough
+	require.False(t, isNightShift(day.Add(17*time.Hour), day.Add(25*time.Hour)))
+	require.False(t, isNightShift(day.Add(5*time.Hour), day.Add(13*time.Hour)))
+}
+
+func TestBuildRosterSlots(t *testing.T) {
+	night := db.LocationShift{
+		ID:        7,
+		StartTime: pgtype.Time{Microseconds: int64(23 * time.Hour / time.Microsecond), Valid: true},
+		EndTime:   pgtype.Time{Microseconds: int64(7*time.Hour+30*time.Minute) / int64(time.Microsecond), Valid: true},
+	}
+	monthStart := time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC)
+	shiftID := int64(7)
+	slots := buildRosterSlots(monthStart, map[int64]db.LocationShift{7: night},
+		func(time.Weekday) []ShiftRequirement { return []ShiftRequirement{{LocationShiftID: 7, Staff: 2}} },
+		[]db.GetMonthlySchedulesByLocationRow{{
+			EmployeeID:      4,
+			LocationShiftID: &shiftID,
+			StartDatetime:   pgtype.Timestamp{Time: time.Date(2025, time.February, 10, 23, 0, 0, 0, time.UTC), Valid: true},
+		}})
+
+	require.Len(t, slots, 28)
+	require.Equal(t, time.Date(2025, time.February, 28, 23, 0, 0, 0, time.UTC), slots[27].Start)
+	require.Equal(t, time.Date(2025, time.March, 1, 7, 30, 0, 0, time.UTC), slots[27].End)
+	require.Equal(t, []int64{4}, slots[9].Scheduled)
+	require.Empty(t, slots[10].Scheduled)
+}
+
+func TestGenerateRosterWorkingTimeRules(t *testing.T) {
+	slots := week(8, 9, 1)
+
+	// without contract hours nothing but the rest limits the shifts
+	positions := generateRoster(weekInput(slots, rosterCandidate{EmployeeID: 1}))
+	require.Equal(t, 7, assigned(positions)[1])
+
+	// the seventh shift would make 63 hours in the week without weekly rest
+	rules := atw
+	positions = generateRoster(weekInput(slots, rosterCandidate{EmployeeID: 1, Rules: &rules}))
+	require.Equal(t, 6, assigned(positions)[1])
+	require.Equal(t, "no available employee", positions[6].UnfilledReason)
+}
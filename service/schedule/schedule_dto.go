@@ -0,0 +1,278 @@
+package schedule
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Slot is a shift that is about to be scheduled. ScheduleID is set when an existing
+// schedule is moved, so that it does not conflict with itself. Replaces is the shift the
+// employee gives up in a swap, which does not count either.
+type Slot struct {
+	ScheduleID *uuid.UUID
+	EmployeeID int64
+	LocationID int64
+	Start      time.Time
+	End        time.Time
+	Replaces   *uuid.UUID
+}
+
+// skips reports whether a shift of the employee is left out when checking the slot
+func (s Slot) skips(scheduleID uuid.UUID) bool {
+	return (s.ScheduleID != nil && scheduleID == *s.ScheduleID) || (s.Replaces != nil && scheduleID == *s.Replaces)
+}
+
+// ConflictKind is what a shift overlaps with, or the working time rules it breaks
+type ConflictKind string
+
+const (
+	ConflictShift        ConflictKind = "shift"
+	ConflictAppointment  ConflictKind = "appointment"
+	ConflictWorkingTime  ConflictKind = "working_time"
+	ConflictAbsence      ConflictKind = "absence"
+	ConflictAvailability ConflictKind = "availability"
+)
+
+// ConflictSeverity tells whether a conflict blocks scheduling. Errors can only be saved
+// with the override flag, warnings are returned with the saved schedule.
+type ConflictSeverity string
+
+const (
+	SeverityError   ConflictSeverity = "error"
+	SeverityWarning ConflictSeverity = "warning"
+)
+
+// Conflict is a shift or appointment of the employee that overlaps the checked shift, a
+// working time rule the shift breaks, leave or sickness of the employee or a time they are
+// not available. For a rule, the times are the shift, week or rest period it is about.
+type Conflict struct {
+	Kind             ConflictKind     `json:"kind" enums:"shift,appointment,working_time,absence,availability"`
+	Severity         ConflictSeverity `json:"severity" enums:"error,warning"`
+	EmployeeID       int64            `json:"employee_id"`
+	ScheduleID       *uuid.UUID       `json:"schedule_id,omitempty"`
+	AppointmentID    *uuid.UUID       `json:"appointment_id,omitempty"`
+	LocationID       *int64           `json:"location_id,omitempty"`
+	Location         *string          `json:"location,omitempty"`
+	StartTime        time.Time        `json:"start_time"`
+	EndTime          time.Time        `json:"end_time"`
+	Message          string           `json:"message"`
+	Rule             string           `json:"rule,omitempty"`
+	LeaveRequestID   *int64           `json:"leave_request_id,omitempty"`
+	SickReportID     *int64           `json:"sick_report_id,omitempty"`
+	UnavailabilityID *int64           `json:"unavailability_id,omitempty"`
+}
+
+// RosterShiftConflicts are the conflicts of one shift of a roster
+type RosterShiftConflicts struct {
+	ScheduleID        uuid.UUID  `json:"schedule_id"`
+	EmployeeID        int64      `json:"employee_id"`
+	EmployeeFirstName string     `json:"employee_first_name"`
+	EmployeeLastName  string     `json:"employee_last_name"`
+	ShiftName         *string    `json:"shift_name,omitempty"`
+	StartTime         time.Time  `json:"start_time"`
+	EndTime           time.Time  `json:"end_time"`
+	Conflicts         []Conflict `json:"conflicts"`
+}
+
+// RosterConflictsResponse lists the shifts of a location in a month that have conflicts
+type RosterConflictsResponse struct {
+	LocationID    int64                  `json:"location_id"`
+	Year          int32                  `json:"year"`
+	Month         int32                  `json:"month"`
+	ShiftsChecked int                    `json:"shifts_checked"`
+	Errors        int                    `json:"errors"`
+	Warnings      int                    `json:"warnings"`
+	Shifts        []RosterShiftConflicts `json:"shifts"`
+}
+
+// GenerateRosterRequest represents the request for generating a roster draft. The roster
+// is generated for the employees of the location unless EmployeeIDs are given, and follows
+// the staffing norms of the location unless Requirements are given.
+type GenerateRosterRequest struct {
+	Year         int32              `json:"year" binding:"required" example:"2025"`
+	Month        int32              `json:"month" binding:"required,min=1,max=12" example:"3"`
+	Requirements []ShiftRequirement `json:"requirements" binding:"omitempty,dive"`
+	MinRestHours *float64           `json:"min_rest_hours" binding:"omitempty,min=0" example:"11"`
+	EmployeeIDs  []int64            `json:"employee_ids"`
+}
+
+// ShiftRequirement is how many staff a shift of the location needs on every day
+type ShiftRequirement struct {
+	LocationShiftID int64                      `json:"location_shift_id" binding:"required" example:"1"`
+	Staff           int                        `json:"staff" binding:"required,min=1" example:"2"`
+	Certifications  []CertificationRequirement `json:"certifications" binding:"omitempty,dive"`
+}
+
+// CertificationRequirement is how many of the staff of a shift need a certification. The
+// name is matched against the certifications of employees, ignoring case.
+type CertificationRequirement struct {
+	Name  string `json:"name" binding:"required" example:"BHV"`
+	Count int    `json:"count" binding:"required,min=1" example:"1"`
+}
+
+// RosterSettings are stored with a draft: what it was generated with
+type RosterSettings struct {
+	Requirements  []ShiftRequirement `json:"requirements"`
+	StaffingNorms bool               `json:"staffing_norms"`
+	MinRestHours  float64            `json:"min_rest_hours"`
+	EmployeeIDs   []int64            `json:"employee_ids,omitempty"`
+}
+
+// RosterAssignmentResponse is a position on a shift of a roster draft
+type RosterAssignmentResponse struct {
+	ID                int64      `json:"id"`
+	LocationShiftID   int64      `json:"location_shift_id"`
+	ShiftName         string     `json:"shift_name"`
+	EmployeeID        *int64     `json:"employee_id"`
+	EmployeeFirstName *string    `json:"employee_first_name"`
+	EmployeeLastName  *string    `json:"employee_last_name"`
+	StartTime         time.Time  `json:"start_time"`
+	EndTime           time.Time  `json:"end_time"`
+	Night             bool       `json:"night"`
+	Weekend           bool       `json:"weekend"`
+	UnfilledReason    *string    `json:"unfilled_reason"`
+	ScheduleID        *uuid.UUID `json:"schedule_id"`
+}
+
+// RosterEmployeeSummary is what a roster draft assigns to an employee
+type RosterEmployeeSummary struct {
+	EmployeeID    int64   `json:"employee_id"`
+	FirstName     string  `json:"first_name"`
+	LastName      string  `json:"last_name"`
+	Shifts        int     `json:"shifts"`
+	Hours         float64 `json:"hours"`
+	NightShifts   int     `json:"night_shifts"`
+	WeekendShifts int     `json:"weekend_shifts"`
+}
+
+// RosterDraftListItem represents a roster draft in a list
+type RosterDraftListItem struct {
+	ID          int64      `json:"id"`
+	LocationID  int64      `json:"location_id"`
+	Year        int32      `json:"year"`
+	Month       int32      `json:"month"`
+	Status      string     `json:"status" enums:"draft,published"`
+	CreatedAt   time.Time  `json:"created_at"`
+	PublishedAt *time.Time `json:"published_at"`
+}
+
+// RosterDraftResponse represents a roster draft with its positions
+type RosterDraftResponse struct {
+	RosterDraftListItem
+	Settings              RosterSettings             `json:"settings"`
+	CreatedByEmployeeID   *int64                     `json:"created_by_employee_id"`
+	PublishedByEmployeeID *int64                     `json:"published_by_employee_id"`
+	Positions             int                        `json:"positions"`
+	Unfilled              int                        `json:"unfilled"`
+	Assignments           []RosterAssignmentResponse `json:"assignments"`
+	Employees             []RosterEmployeeSummary    `json:"employees"`
+}
+
+// UpdateRosterAssignmentRequest assigns a position of a draft to another employee, or
+// leaves it open when EmployeeID is null
+type UpdateRosterAssignmentRequest struct {
+	EmployeeID *int64 `json:"employee_id" example:"1"`
+}
+
+// PublishRosterDraftRequest represents the request for publishing a roster draft
+type PublishRosterDraftRequest struct {
+	// Publish even if positions overlap shifts the employees got since the draft was generated
+	Override bool `json:"override" example:"false"`
+}
+
+// RosterAssignmentConflicts are the conflicts of a position of a draft that is published
+type RosterAssignmentConflicts struct {
+	AssignmentID int64      `json:"assignment_id"`
+	EmployeeID   int64      `json:"employee_id"`
+	StartTime    time.Time  `json:"start_time"`
+	EndTime      time.Time  `json:"end_time"`
+	Conflicts    []Conflict `json:"conflicts"`
+}
+
+// StaffingNormRequest sets the minimum staffing of a shift of a location, on one weekday
+// or on every day that has no norm of its own
+type StaffingNormRequest struct {
+	LocationShiftID int64                      `json:"location_shift_id" binding:"required" example:"3"`
+	Weekday         *int16                     `json:"weekday" binding:"omitempty,min=0,max=6" example:"6"` // 0 is Sunday, empty for every day
+	MinStaff        int32                      `json:"min_staff" binding:"required,min=1" example:"2"`
+	Certifications  []CertificationRequirement `json:"certifications" binding:"omitempty,dive"`
+}
+
+// UpdateStaffingNormRequest changes a staffing norm, the shift of a norm cannot change
+type UpdateStaffingNormRequest struct {
+	Weekday        *int16                     `json:"weekday" binding:"omitempty,min=0,max=6" example:"6"`
+	MinStaff       int32                      `json:"min_staff" binding:"required,min=1" example:"2"`
+	Certifications []CertificationRequirement `json:"certifications" binding:"omitempty,dive"`
+}
+
+// StaffingNormResponse represents a staffing norm
+type StaffingNormResponse struct {
+	ID              int64                      `json:"id"`
+	LocationID      int64                      `json:"location_id"`
+	LocationShiftID int64                      `json:"location_shift_id"`
+	ShiftName       string                     `json:"shift_name,omitempty"`
+	Weekday         *int16                     `json:"weekday"`
+	MinStaff        int32                      `json:"min_staff"`
+	Certifications  []CertificationRequirement `json:"certifications"`
+	CreatedAt       time.Time                  `json:"created_at"`
+	UpdatedAt       time.Time                  `json:"updated_at"`
+}
+
+// StaffingCoverageRequest represents the query of a coverage report, dates are YYYY-MM-DD
+// and the end date is included
+type StaffingCoverageRequest struct {
+	StartDate string `form:"start_date" binding:"required" example:"2025-03-01"`
+	EndDate   string `form:"end_date" binding:"required" example:"2025-03-31"`
+	OnlyGaps  bool   `form:"only_gaps"`
+}
+
+// CertificationShortage is a certification a shift has too few staff with
+type CertificationShortage struct {
+	Name      string `json:"name"`
+	Required  int    `json:"required"`
+	Scheduled int    `json:"scheduled"`
+}
+
+// CoverageEmployee is an employee scheduled on a shift
+type CoverageEmployee struct {
+	EmployeeID int64  `json:"employee_id"`
+	FirstName  string `json:"first_name"`
+	LastName   string `json:"last_name"`
+}
+
+// ShiftCoverage compares the staff scheduled on a shift on one day with its norm
+type ShiftCoverage struct {
+	Date                  string                  `json:"date"`
+	LocationShiftID       int64                   `json:"location_shift_id"`
+	ShiftName             string                  `json:"shift_name"`
+	StartTime             time.Time               `json:"start_time"`
+	EndTime               time.Time               `json:"end_time"`
+	MinStaff              int                     `json:"min_staff"`
+	Scheduled             int                     `json:"scheduled"`
+	Employees             []CoverageEmployee      `json:"employees"`
+	Understaffed          bool                    `json:"understaffed"`
+	MissingCertifications []CertificationShortage `json:"missing_certifications"`
+}
+
+// HasGap reports whether the shift is understaffed or lacks certified staff
+func (c ShiftCoverage) HasGap() bool {
+	return c.Understaffed || len(c.MissingCertifications) > 0
+}
+
+// StaffingCoverageResponse is the coverage of the shifts of a location that have a norm
+type StaffingCoverageResponse struct {
+	LocationID int64           `json:"location_id"`
+	StartDate  string          `json:"start_date"`
+	EndDate    string          `json:"end_date"`
+	Gaps       int             `json:"gaps"`
+	Shifts     []ShiftCoverage `json:"shifts"`
+}
+
+// StaffingGapAlert lists the gaps of a location for its planners
+type StaffingGapAlert struct {
+	LocationID       int64
+	LocationName     string
+	RecipientUserIDs []int64
+	Gaps             []ShiftCoverage
+}
@@ -0,0 +1,66 @@
+package schedule
+
+import (
+	"context"
+	"maicare_go/service/deps"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ScheduleService interface {
+	CheckScheduleConflicts(ctx context.Context, slot Slot) ([]Conflict, error)
+	CheckRosterConflicts(ctx context.Context, locationID int64, year, month int32) (*RosterConflictsResponse, error)
+	GenerateRosterDraft(ctx context.Context, locationID, employeeID int64, req *GenerateRosterRequest) (*RosterDraftResponse, error)
+	GetRosterDraft(ctx context.Context, draftID int64) (*RosterDraftResponse, error)
+	ListRosterDrafts(ctx context.Context, locationID int64) ([]RosterDraftListItem, error)
+	UpdateRosterAssignment(ctx context.Context, draftID, assignmentID int64, req *UpdateRosterAssignmentRequest) (*RosterDraftResponse, error)
+	DeleteRosterDraft(ctx context.Context, draftID int64) error
+	PublishRosterDraft(ctx context.Context, draftID, employeeID int64, override bool) (*RosterDraftResponse, []RosterAssignmentConflicts, error)
+	CreateStaffingNorm(ctx context.Context, locationID int64, req *StaffingNormRequest) (*StaffingNormResponse, error)
+	ListStaffingNorms(ctx context.Context, locationID int64) ([]StaffingNormResponse, error)
+	UpdateStaffingNorm(ctx context.Context, locationID, normID int64, req *UpdateStaffingNormRequest) (*StaffingNormResponse, error)
+	DeleteStaffingNorm(ctx context.Context, locationID, normID int64) error
+	GetStaffingCoverage(ctx context.Context, locationID int64, req *StaffingCoverageRequest) (*StaffingCoverageResponse, error)
+	CheckStaffingGaps(ctx context.Context) ([]StaffingGapAlert, error)
+	ClockIn(ctx context.Context, scheduleID uuid.UUID, employeeID int64, req *ClockRequest, clientIP string) (*TimeEntryResponse, error)
+	ClockOut(ctx context.Context, scheduleID uuid.UUID, employeeID int64, req *ClockRequest, clientIP string) (*TimeEntryResponse, error)
+	StartBreak(ctx context.Context, scheduleID uuid.UUID, employeeID int64) (*TimeEntryResponse, error)
+	EndBreak(ctx context.Context, scheduleID uuid.UUID, employeeID int64) (*TimeEntryResponse, error)
+	GetScheduleTimeEntry(ctx context.Context, scheduleID uuid.UUID) (*TimeEntryResponse, error)
+	ListTimeEntries(ctx context.Context, req *ListTimeEntriesRequest) ([]TimeEntryResponse, error)
+	ApproveTimeEntry(ctx context.Context, timeEntryID, employeeID int64, req *ApproveTimeEntryRequest) (*TimeEntryResponse, error)
+	RejectTimeEntry(ctx context.Context, timeEntryID, employeeID int64, req *RejectTimeEntryRequest) (*TimeEntryResponse, error)
+	GetLocationClockSettings(ctx context.Context, locationID int64) (*LocationClockSettingsResponse, error)
+	UpdateLocationClockSettings(ctx context.Context, locationID int64, req *UpdateLocationClockSettingsRequest) (*LocationClockSettingsResponse, error)
+	RotateLocationClockQRToken(ctx context.Context, locationID int64) (*LocationClockSettingsResponse, error)
+	RemoveLocationClockQRToken(ctx context.Context, locationID int64) (*LocationClockSettingsResponse, error)
+	GetPayroll(ctx context.Context, req *PayrollRequest) (*PayrollResponse, error)
+	ExportPayroll(ctx context.Context, req *PayrollRequest) (*PayrollExport, error)
+	ListWorkingTimeRules(ctx context.Context) ([]WorkingTimeRulesResponse, error)
+	UpdateWorkingTimeRules(ctx context.Context, contractType string, req *UpdateWorkingTimeRulesRequest) (*WorkingTimeRulesResponse, error)
+	GetWorkingTimeCompliance(ctx context.Context, req *WorkingTimeComplianceRequest) (*WorkingTimeComplianceResponse, error)
+	GetLocationAvailability(ctx context.Context, locationID int64, employeeIDs []int64, start, end time.Time) (*LocationAvailability, error)
+	CreateOpenShift(ctx context.Context, locationID, employeeID int64, req *CreateOpenShiftRequest) (*OpenShiftResponse, error)
+	ListOpenShifts(ctx context.Context, locationID int64) ([]OpenShiftResponse, error)
+	ListEmployeeOpenShifts(ctx context.Context, employeeID int64) ([]OpenShiftResponse, error)
+	ReleaseShift(ctx context.Context, scheduleID uuid.UUID) (*OpenShiftResponse, error)
+	ClaimOpenShift(ctx context.Context, scheduleID uuid.UUID, employeeID int64) (*OpenShiftResponse, error)
+	CreateShiftSwapRequest(ctx context.Context, employeeID int64, req *CreateShiftSwapRequestRequest) (*ShiftSwapRequestResponse, error)
+	ListShiftSwapRequests(ctx context.Context, req *ListShiftSwapRequestsRequest) ([]ShiftSwapRequestResponse, error)
+	AcceptShiftSwapRequest(ctx context.Context, swapRequestID, employeeID int64) (*ShiftSwapRequestResponse, error)
+	DeclineShiftSwapRequest(ctx context.Context, swapRequestID, employeeID int64) (*ShiftSwapRequestResponse, error)
+	CancelShiftSwapRequest(ctx context.Context, swapRequestID, employeeID int64) (*ShiftSwapRequestResponse, error)
+	ApproveShiftSwapRequest(ctx context.Context, swapRequestID, employeeID int64, req *ReviewShiftSwapRequestRequest) (*ShiftSwapRequestResponse, error)
+	RejectShiftSwapRequest(ctx context.Context, swapRequestID, employeeID int64, req *ReviewShiftSwapRequestRequest) (*ShiftSwapRequestResponse, error)
+}
+
+type scheduleService struct {
+	*deps.ServiceDependencies
+}
+
+func NewScheduleService(deps *deps.ServiceDependencies) ScheduleService {
+	return &scheduleService{
+		ServiceDependencies: deps,
+	}
+}
@@ -0,0 +1,957 @@
+package schedule
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	db "maicare_go/db/sqlc"
+	"maicare_go/logger"
+	"maicare_go/notification"
+	"maicare_go/util"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+// Statuses of a shift swap request. The target accepts or declines a pending request, a
+// planner approves or rejects an accepted one.
+const (
+	SwapStatusPending   = "pending"
+	SwapStatusAccepted  = "accepted"
+	SwapStatusDeclined  = "declined"
+	SwapStatusApproved  = "approved"
+	SwapStatusRejected  = "rejected"
+	SwapStatusCancelled = "cancelled"
+)
+
+// MarketplaceUpdate is the type of the websocket messages about open shifts and swaps
+const MarketplaceUpdate = "shift_marketplace_update"
+
+var (
+	ErrEmployeeNotFound    = errors.New("employee not found")
+	ErrInvalidOpenShift    = errors.New("invalid open shift")
+	ErrShiftTaken          = errors.New("the shift has been taken by another employee")
+	ErrShiftAlreadyOpen    = errors.New("the shift is already open")
+	ErrShiftStarted        = errors.New("the shift has already started")
+	ErrNotEligible         = errors.New("the employee cannot take over the shift")
+	ErrSwapRequestNotFound = errors.New("swap request not found")
+	ErrInvalidSwapRequest  = errors.New("invalid swap request")
+	ErrSwapRequestClosed   = errors.New("the swap request can no longer be changed")
+	ErrNotOwnSwapRequest   = errors.New("the swap request is meant for another employee")
+	ErrOwnSwapRequest      = errors.New("swap requests cannot be reviewed by the employees that swap")
+)
+
+// marketplaceShift is a shift that is open or changes hands
+type marketplaceShift struct {
+	ID              uuid.UUID
+	EmployeeID      *int64
+	LocationID      int64
+	LocationName    string
+	LocationShiftID *int64
+	ShiftName       *string
+	Color           *string
+	Start           time.Time
+	End             time.Time
+}
+
+// CreateOpenShift adds a shift without an employee to a location, for eligible employees
+// to claim
+func (s *scheduleService) CreateOpenShift(ctx context.Context, locationID, employeeID int64, req *CreateOpenShiftRequest) (*OpenShiftResponse, error) {
+	start, end, err := s.openShiftTimes(ctx, locationID, req)
+	if err != nil {
+		return nil, err
+	}
+	if !start.After(util.NetherlandsNow()) {
+		return nil, ErrShiftStarted
+	}
+
+	row, err := s.Store.CreateSchedule(ctx, db.CreateScheduleParams{
+		LocationID:          locationID,
+		LocationShiftID:     req.LocationShiftID,
+		Color:               req.Color,
+		IsCustom:            req.LocationShiftID == nil,
+		CreatedByEmployeeID: employeeID,
+		StartDatetime:       pgtype.Timestamp{Time: start, Valid: true},
+		EndDatetime:         pgtype.Timestamp{Time: end, Valid: true},
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CreateOpenShift", "Failed to create open shift", zap.Error(err), zap.Int64("location_id", locationID))
+		return nil, fmt.Errorf("failed to create open shift")
+	}
+	s.Logger.LogBusinessEvent(logger.LogLevelInfo, "CreateOpenShift", "Open shift created",
+		zap.String("schedule_id", row.ID.String()), zap.Int64("location_id", locationID), zap.Int64("created_by", employeeID))
+
+	shift, err := s.marketplaceSchedule(ctx, "CreateOpenShift", row.ID)
+	if err != nil {
+		return nil, err
+	}
+	s.publishMarketplaceEvent("CreateOpenShift", MarketplaceEvent{Event: "open_shift_created", ScheduleID: shift.ID, LocationID: locationID},
+		s.locationAudience(ctx, "CreateOpenShift", locationID))
+	return openShiftResponse(shift), nil
+}
+
+// ListOpenShifts returns the open shifts of a location that have not started yet
+func (s *scheduleService) ListOpenShifts(ctx context.Context, locationID int64) ([]OpenShiftResponse, error) {
+	rows, err := s.Store.ListOpenShifts(ctx, db.ListOpenShiftsParams{
+		FromDatetime: pgtype.Timestamp{Time: util.NetherlandsNow(), Valid: true},
+		LocationIds:  []int64{locationID},
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ListOpenShifts", "Failed to list open shifts", zap.Error(err), zap.Int64("location_id", locationID))
+		return nil, fmt.Errorf("failed to list open shifts")
+	}
+	shifts := make([]OpenShiftResponse, len(rows))
+	for i, row := range rows {
+		shifts[i] = *openShiftResponse(openShift(row))
+	}
+	return shifts, nil
+}
+
+// ListEmployeeOpenShifts returns the open shifts at the locations the employee works at or
+// prefers, with whether they can claim each of them
+func (s *scheduleService) ListEmployeeOpenShifts(ctx context.Context, employeeID int64) ([]OpenShiftResponse, error) {
+	employee, err := s.marketplaceEmployee(ctx, "ListEmployeeOpenShifts", employeeID)
+	if err != nil {
+		return nil, err
+	}
+	shifts := []OpenShiftResponse{}
+	locationIDs := employeeLocations(employee)
+	if len(locationIDs) == 0 {
+		return shifts, nil
+	}
+
+	rows, err := s.Store.ListOpenShifts(ctx, db.ListOpenShiftsParams{
+		FromDatetime: pgtype.Timestamp{Time: util.NetherlandsNow(), Valid: true},
+		LocationIds:  locationIDs,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ListEmployeeOpenShifts", "Failed to list open shifts", zap.Error(err), zap.Int64("employee_id", employeeID))
+		return nil, fmt.Errorf("failed to list open shifts")
+	}
+	for _, row := range rows {
+		shift := openShift(row)
+		eligibility, err := s.shiftEligibility(ctx, "ListEmployeeOpenShifts", employee, shift, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp := openShiftResponse(shift)
+		resp.Eligibility = eligibility
+		shifts = append(shifts, *resp)
+	}
+	return shifts, nil
+}
+
+// ReleaseShift takes a shift off its employee, for example when they called in sick, and
+// offers it as an open shift. Open swap requests of the shift are cancelled.
+func (s *scheduleService) ReleaseShift(ctx context.Context, scheduleID uuid.UUID) (*OpenShiftResponse, error) {
+	shift, err := s.marketplaceSchedule(ctx, "ReleaseShift", scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if shift.EmployeeID == nil {
+		return nil, ErrShiftAlreadyOpen
+	}
+	if !shift.Start.After(util.NetherlandsNow()) {
+		return nil, ErrShiftStarted
+	}
+
+	if _, err := s.assignSchedule(ctx, s.Store.Queries, "ReleaseShift", scheduleID, shift.EmployeeID, nil); err != nil {
+		return nil, err
+	}
+	s.Logger.LogBusinessEvent(logger.LogLevelInfo, "ReleaseShift", "Shift released",
+		zap.String("schedule_id", scheduleID.String()), zap.Int64("employee_id", *shift.EmployeeID))
+
+	cancelled, err := s.Store.CancelShiftSwapRequestsForSchedules(ctx, []uuid.UUID{scheduleID})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ReleaseShift", "Failed to cancel swap requests", zap.Error(err), zap.String("schedule_id", scheduleID.String()))
+	}
+	s.publishCancelledSwaps("ReleaseShift", shift.LocationID, cancelled)
+
+	shift.EmployeeID = nil
+	s.publishMarketplaceEvent("ReleaseShift", MarketplaceEvent{Event: "shift_released", ScheduleID: scheduleID, LocationID: shift.LocationID},
+		s.locationAudience(ctx, "ReleaseShift", shift.LocationID))
+	return openShiftResponse(shift), nil
+}
+
+// ClaimOpenShift assigns an open shift to the employee when they are eligible. Of two
+// employees that claim the same shift at once, the second gets ErrShiftTaken.
+func (s *scheduleService) ClaimOpenShift(ctx context.Context, scheduleID uuid.UUID, employeeID int64) (*OpenShiftResponse, error) {
+	shift, err := s.marketplaceSchedule(ctx, "ClaimOpenShift", scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if shift.EmployeeID != nil {
+		return nil, ErrShiftTaken
+	}
+	if !shift.Start.After(util.NetherlandsNow()) {
+		return nil, ErrShiftStarted
+	}
+	employee, err := s.marketplaceEmployee(ctx, "ClaimOpenShift", employeeID)
+	if err != nil {
+		return nil, err
+	}
+	eligibility, err := s.shiftEligibility(ctx, "ClaimOpenShift", employee, shift, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !eligibility.Eligible {
+		return nil, notEligible(employee, eligibility)
+	}
+
+	if _, err := s.assignSchedule(ctx, s.Store.Queries, "ClaimOpenShift", scheduleID, nil, &employeeID); err != nil {
+		return nil, err
+	}
+	s.Logger.LogBusinessEvent(logger.LogLevelInfo, "ClaimOpenShift", "Open shift claimed",
+		zap.String("schedule_id", scheduleID.String()), zap.Int64("employee_id", employeeID))
+
+	shift.EmployeeID = &employeeID
+	data := &notification.ShiftMarketplaceData{
+		Event:              "claimed",
+		ScheduleID:         scheduleID,
+		RequesterFirstName: employee.FirstName,
+		RequesterLastName:  employee.LastName,
+		LocationID:         shift.LocationID,
+		LocationName:       shift.LocationName,
+		StartTime:          shift.Start,
+		EndTime:            shift.End,
+	}
+	s.notifyMarketplace(ctx, "ClaimOpenShift", data, append(s.planners(ctx, "ClaimOpenShift", shift.LocationID), employee.UserID))
+	s.publishMarketplaceEvent("ClaimOpenShift", MarketplaceEvent{Event: "shift_claimed", ScheduleID: scheduleID, LocationID: shift.LocationID, EmployeeID: &employeeID},
+		s.locationAudience(ctx, "ClaimOpenShift", shift.LocationID))
+
+	resp := openShiftResponse(shift)
+	resp.Eligibility = eligibility
+	return resp, nil
+}
+
+// CreateShiftSwapRequest asks a colleague to take over a shift of the employee, in
+// exchange for one of their shifts when a target schedule is given. Both need to be able
+// to take over the shift they get.
+func (s *scheduleService) CreateShiftSwapRequest(ctx context.Context, employeeID int64, req *CreateShiftSwapRequestRequest) (*ShiftSwapRequestResponse, error) {
+	if req.TargetEmployeeID == employeeID {
+		return nil, fmt.Errorf("%w: ask a colleague to take over the shift", ErrInvalidSwapRequest)
+	}
+	if req.TargetScheduleID != nil && *req.TargetScheduleID == req.ScheduleID {
+		return nil, fmt.Errorf("%w: the shifts of a swap differ", ErrInvalidSwapRequest)
+	}
+
+	shift, err := s.marketplaceSchedule(ctx, "CreateShiftSwapRequest", req.ScheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if shift.EmployeeID == nil || *shift.EmployeeID != employeeID {
+		return nil, ErrNotScheduledEmployee
+	}
+	var targetShift *marketplaceShift
+	if req.TargetScheduleID != nil {
+		ts, err := s.marketplaceSchedule(ctx, "CreateShiftSwapRequest", *req.TargetScheduleID)
+		if err != nil {
+			return nil, err
+		}
+		if ts.EmployeeID == nil || *ts.EmployeeID != req.TargetEmployeeID {
+			return nil, fmt.Errorf("%w: the shift in exchange belongs to another employee", ErrInvalidSwapRequest)
+		}
+		targetShift = &ts
+	}
+
+	open, err := s.Store.ListShiftSwapRequests(ctx, db.ListShiftSwapRequestsParams{EmployeeID: &employeeID})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CreateShiftSwapRequest", "Failed to list swap requests", zap.Error(err), zap.Int64("employee_id", employeeID))
+		return nil, fmt.Errorf("failed to create swap request")
+	}
+	for _, r := range open {
+		if r.ScheduleID == req.ScheduleID && r.TargetEmployeeID == req.TargetEmployeeID && (r.Status == SwapStatusPending || r.Status == SwapStatusAccepted) {
+			return nil, fmt.Errorf("%w: the colleague has already been asked to take over the shift", ErrInvalidSwapRequest)
+		}
+	}
+
+	if err := s.checkSwap(ctx, "CreateShiftSwapRequest", employeeID, req.TargetEmployeeID, shift, targetShift); err != nil {
+		return nil, err
+	}
+
+	created, err := s.Store.CreateShiftSwapRequest(ctx, db.CreateShiftSwapRequestParams{
+		ScheduleID:          req.ScheduleID,
+		RequesterEmployeeID: employeeID,
+		TargetEmployeeID:    req.TargetEmployeeID,
+		TargetScheduleID:    req.TargetScheduleID,
+		Message:             req.Message,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CreateShiftSwapRequest", "Failed to create swap request", zap.Error(err), zap.Int64("employee_id", employeeID))
+		return nil, fmt.Errorf("failed to create swap request")
+	}
+	s.Logger.LogBusinessEvent(logger.LogLevelInfo, "CreateShiftSwapRequest", "Swap request created",
+		zap.Int64("swap_request_id", created.ID), zap.Int64("requester", employeeID), zap.Int64("target", req.TargetEmployeeID))
+
+	swap, err := s.getShiftSwapRequest(ctx, "CreateShiftSwapRequest", created.ID)
+	if err != nil {
+		return nil, err
+	}
+	s.notifyMarketplace(ctx, "CreateShiftSwapRequest", swapNotification("swap_requested", swap), []int64{swap.TargetUserID})
+	s.publishSwapEvent("CreateShiftSwapRequest", "swap_requested", swap, swap.RequesterUserID, swap.TargetUserID)
+	return swapRequestResponse(swap), nil
+}
+
+// ListShiftSwapRequests returns the swap requests of an employee, as requester or target,
+// or of the shifts of a location
+func (s *scheduleService) ListShiftSwapRequests(ctx context.Context, req *ListShiftSwapRequestsRequest) ([]ShiftSwapRequestResponse, error) {
+	rows, err := s.Store.ListShiftSwapRequests(ctx, db.ListShiftSwapRequestsParams{
+		EmployeeID: req.EmployeeID,
+		LocationID: req.LocationID,
+		Status:     req.Status,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ListShiftSwapRequests", "Failed to list swap requests", zap.Error(err))
+		return nil, fmt.Errorf("failed to list swap requests")
+	}
+	requests := make([]ShiftSwapRequestResponse, len(rows))
+	for i, row := range rows {
+		requests[i] = *swapRequestResponse(db.GetShiftSwapRequestRow(row))
+	}
+	return requests, nil
+}
+
+// AcceptShiftSwapRequest is the target agreeing to the swap, which then waits for a
+// planner. Eligibility is checked again, the roster may have changed since the request.
+func (s *scheduleService) AcceptShiftSwapRequest(ctx context.Context, swapRequestID, employeeID int64) (*ShiftSwapRequestResponse, error) {
+	swap, err := s.targetSwapRequest(ctx, "AcceptShiftSwapRequest", swapRequestID, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err := s.checkSwapRequest(ctx, "AcceptShiftSwapRequest", swap); err != nil {
+		return nil, err
+	}
+	swap, err = s.respondShiftSwapRequest(ctx, "AcceptShiftSwapRequest", swap, SwapStatusAccepted)
+	if err != nil {
+		return nil, err
+	}
+
+	planners := s.planners(ctx, "AcceptShiftSwapRequest", swapLocations(swap)...)
+	s.notifyMarketplace(ctx, "AcceptShiftSwapRequest", swapNotification("swap_accepted", swap), planners)
+	s.publishSwapEvent("AcceptShiftSwapRequest", "swap_accepted", swap, append(planners, swap.RequesterUserID, swap.TargetUserID)...)
+	return swapRequestResponse(swap), nil
+}
+
+// DeclineShiftSwapRequest is the target turning the swap down
+func (s *scheduleService) DeclineShiftSwapRequest(ctx context.Context, swapRequestID, employeeID int64) (*ShiftSwapRequestResponse, error) {
+	swap, err := s.targetSwapRequest(ctx, "DeclineShiftSwapRequest", swapRequestID, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	swap, err = s.respondShiftSwapRequest(ctx, "DeclineShiftSwapRequest", swap, SwapStatusDeclined)
+	if err != nil {
+		return nil, err
+	}
+
+	s.notifyMarketplace(ctx, "DeclineShiftSwapRequest", swapNotification("swap_declined", swap), []int64{swap.RequesterUserID})
+	s.publishSwapEvent("DeclineShiftSwapRequest", "swap_declined", swap, swap.RequesterUserID, swap.TargetUserID)
+	return swapRequestResponse(swap), nil
+}
+
+// CancelShiftSwapRequest withdraws a swap request of the employee that has not been
+// reviewed yet
+func (s *scheduleService) CancelShiftSwapRequest(ctx context.Context, swapRequestID, employeeID int64) (*ShiftSwapRequestResponse, error) {
+	swap, err := s.getShiftSwapRequest(ctx, "CancelShiftSwapRequest", swapRequestID)
+	if err != nil {
+		return nil, err
+	}
+	if swap.RequesterEmployeeID != employeeID {
+		return nil, ErrNotOwnSwapRequest
+	}
+	if _, err := s.Store.CancelShiftSwapRequest(ctx, swapRequestID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSwapRequestClosed
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CancelShiftSwapRequest", "Failed to cancel swap request", zap.Error(err), zap.Int64("swap_request_id", swapRequestID))
+		return nil, fmt.Errorf("failed to cancel swap request")
+	}
+	s.Logger.LogBusinessEvent(logger.LogLevelInfo, "CancelShiftSwapRequest", "Swap request cancelled", zap.Int64("swap_request_id", swapRequestID))
+
+	recipients := []int64{swap.RequesterUserID, swap.TargetUserID}
+	if swap.Status == SwapStatusAccepted {
+		recipients = append(recipients, s.planners(ctx, "CancelShiftSwapRequest", swapLocations(swap)...)...)
+	}
+	swap.Status = SwapStatusCancelled
+	s.publishSwapEvent("CancelShiftSwapRequest", "swap_cancelled", swap, recipients...)
+	return swapRequestResponse(swap), nil
+}
+
+// ApproveShiftSwapRequest moves the shifts of an accepted swap to their new employees.
+// Other open swap requests of the shifts are cancelled, and the employees and planners
+// are notified.
+func (s *scheduleService) ApproveShiftSwapRequest(ctx context.Context, swapRequestID, employeeID int64, req *ReviewShiftSwapRequestRequest) (*ShiftSwapRequestResponse, error) {
+	swap, err := s.reviewableSwapRequest(ctx, "ApproveShiftSwapRequest", swapRequestID, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	shift, targetShift, err := s.checkSwapRequest(ctx, "ApproveShiftSwapRequest", swap)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.Store.ConnPool.Begin(ctx)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ApproveShiftSwapRequest", "Failed to begin transaction", zap.Error(err))
+		return nil, fmt.Errorf("failed to approve swap request")
+	}
+	defer func() {
+		if rbErr := tx.Rollback(ctx); rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "ApproveShiftSwapRequest", "Failed to rollback transaction", zap.Error(rbErr))
+		}
+	}()
+	qtx := s.Store.WithTx(tx)
+
+	if err := s.reviewShiftSwapRequest(ctx, qtx, "ApproveShiftSwapRequest", swapRequestID, employeeID, SwapStatusApproved, req.Note); err != nil {
+		return nil, err
+	}
+	scheduleIDs := []uuid.UUID{shift.ID}
+	if _, err := s.assignSchedule(ctx, qtx, "ApproveShiftSwapRequest", shift.ID, &swap.RequesterEmployeeID, &swap.TargetEmployeeID); err != nil {
+		return nil, err
+	}
+	if targetShift != nil {
+		scheduleIDs = append(scheduleIDs, targetShift.ID)
+		if _, err := s.assignSchedule(ctx, qtx, "ApproveShiftSwapRequest", targetShift.ID, &swap.TargetEmployeeID, &swap.RequesterEmployeeID); err != nil {
+			return nil, err
+		}
+	}
+	cancelled, err := qtx.CancelShiftSwapRequestsForSchedules(ctx, scheduleIDs)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ApproveShiftSwapRequest", "Failed to cancel swap requests", zap.Error(err), zap.Int64("swap_request_id", swapRequestID))
+		return nil, fmt.Errorf("failed to approve swap request")
+	}
+	if err := tx.Commit(ctx); err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ApproveShiftSwapRequest", "Failed to commit transaction", zap.Error(err))
+		return nil, fmt.Errorf("failed to approve swap request")
+	}
+	s.Logger.LogBusinessEvent(logger.LogLevelInfo, "ApproveShiftSwapRequest", "Swap request approved",
+		zap.Int64("swap_request_id", swapRequestID), zap.Int64("reviewed_by", employeeID))
+
+	swap, err = s.getShiftSwapRequest(ctx, "ApproveShiftSwapRequest", swapRequestID)
+	if err != nil {
+		return nil, err
+	}
+	recipients := append(s.planners(ctx, "ApproveShiftSwapRequest", swapLocations(swap)...), swap.RequesterUserID, swap.TargetUserID)
+	s.notifyMarketplace(ctx, "ApproveShiftSwapRequest", swapNotification("swap_approved", swap), recipients)
+	s.publishSwapEvent("ApproveShiftSwapRequest", "swap_approved", swap, recipients...)
+	s.publishCancelledSwaps("ApproveShiftSwapRequest", swap.LocationID, cancelled)
+	return swapRequestResponse(swap), nil
+}
+
+// RejectShiftSwapRequest turns an accepted swap down, the shifts stay with their employees
+func (s *scheduleService) RejectShiftSwapRequest(ctx context.Context, swapRequestID, employeeID int64, req *ReviewShiftSwapRequestRequest) (*ShiftSwapRequestResponse, error) {
+	if _, err := s.reviewableSwapRequest(ctx, "RejectShiftSwapRequest", swapRequestID, employeeID); err != nil {
+		return nil, err
+	}
+	if err := s.reviewShiftSwapRequest(ctx, s.Store.Queries, "RejectShiftSwapRequest", swapRequestID, employeeID, SwapStatusRejected, req.Note); err != nil {
+		return nil, err
+	}
+	s.Logger.LogBusinessEvent(logger.LogLevelInfo, "RejectShiftSwapRequest", "Swap request rejected",
+		zap.Int64("swap_request_id", swapRequestID), zap.Int64("reviewed_by", employeeID))
+
+	swap, err := s.getShiftSwapRequest(ctx, "RejectShiftSwapRequest", swapRequestID)
+	if err != nil {
+		return nil, err
+	}
+	recipients := []int64{swap.RequesterUserID, swap.TargetUserID}
+	s.notifyMarketplace(ctx, "RejectShiftSwapRequest", swapNotification("swap_rejected", swap), recipients)
+	s.publishSwapEvent("RejectShiftSwapRequest", "swap_rejected", swap,
+		append(s.planners(ctx, "RejectShiftSwapRequest", swapLocations(swap)...), recipients...)...)
+	return swapRequestResponse(swap), nil
+}
+
+// shiftEligibility checks whether an employee can take over a shift: they work at its
+// location or prefer to, hold the certifications the staffing norm of the shift would
+// lack without them, and the shift does not conflict with their other shifts, absences or
+// the working time rules. Replaces is the shift the employee gives up in a swap.
+func (s *scheduleService) shiftEligibility(ctx context.Context, operation string, employee db.GetShiftMarketplaceEmployeeRow, shift marketplaceShift, replaces *uuid.UUID) (*ShiftEligibility, error) {
+	coverage, err := s.staffingCoverage(ctx, shift.LocationID, startOfDay(shift.Start), shift.End, shift.ID)
+	if err != nil {
+		return nil, err
+	}
+	conflicts, err := s.CheckScheduleConflicts(ctx, Slot{
+		ScheduleID: &shift.ID,
+		EmployeeID: employee.ID,
+		LocationID: shift.LocationID,
+		Start:      shift.Start,
+		End:        shift.End,
+		Replaces:   replaces,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return eligibility(employee, shift.LocationID, shiftCertifications(coverage, shift), conflicts), nil
+}
+
+// eligibility decides whether an employee can take over a shift of a location that needs
+// the certifications
+func eligibility(employee db.GetShiftMarketplaceEmployeeRow, locationID int64, certifications []string, conflicts []Conflict) *ShiftEligibility {
+	e := &ShiftEligibility{Reasons: []string{}, Conflicts: conflicts}
+	if !slices.Contains(employeeLocations(employee), locationID) {
+		e.Reasons = append(e.Reasons, "Does not work at the location of the shift")
+	}
+	for _, name := range certifications {
+		if !slices.Contains(employee.Certifications, strings.ToLower(name)) {
+			e.Reasons = append(e.Reasons, "Lacks the certification "+name+" the shift needs")
+		}
+	}
+	if HasErrors(conflicts) {
+		e.Reasons = append(e.Reasons, "Conflicts with other shifts, an absence or the working time rules")
+	}
+	e.Eligible = len(e.Reasons) == 0
+	return e
+}
+
+// shiftCertifications returns the certifications the staffing norms require on the shifts
+// the schedule counts for, that the other staff of those shifts lack
+func shiftCertifications(coverage []ShiftCoverage, shift marketplaceShift) []string {
+	var names []string
+	for _, c := range coverage {
+		if !countsFor(shift.LocationShiftID, shift.Start, shift.End, c.LocationShiftID, c.StartTime, c.EndTime) {
+			continue
+		}
+		for _, missing := range c.MissingCertifications {
+			if !slices.ContainsFunc(names, func(name string) bool { return strings.EqualFold(name, missing.Name) }) {
+				names = append(names, missing.Name)
+			}
+		}
+	}
+	return names
+}
+
+// employeeLocations returns the location of the employee and the locations they prefer
+func employeeLocations(employee db.GetShiftMarketplaceEmployeeRow) []int64 {
+	var locationIDs []int64
+	if employee.LocationID != nil {
+		locationIDs = append(locationIDs, *employee.LocationID)
+	}
+	for _, id := range employee.PreferredLocationIds {
+		if !slices.Contains(locationIDs, id) {
+			locationIDs = append(locationIDs, id)
+		}
+	}
+	return locationIDs
+}
+
+func notEligible(employee db.GetShiftMarketplaceEmployeeRow, e *ShiftEligibility) error {
+	return fmt.Errorf("%w: %s %s: %s", ErrNotEligible, employee.FirstName, employee.LastName, strings.Join(e.Reasons, ", "))
+}
+
+// checkSwap checks that the target can take over the shift and the requester the shift
+// of the target they get in exchange
+func (s *scheduleService) checkSwap(ctx context.Context, operation string, requesterID, targetID int64, shift marketplaceShift, targetShift *marketplaceShift) error {
+	if !shift.Start.After(util.NetherlandsNow()) || (targetShift != nil && !targetShift.Start.After(util.NetherlandsNow())) {
+		return ErrShiftStarted
+	}
+	target, err := s.marketplaceEmployee(ctx, operation, targetID)
+	if err != nil {
+		return err
+	}
+	var replaces *uuid.UUID
+	if targetShift != nil {
+		replaces = &targetShift.ID
+	}
+	e, err := s.shiftEligibility(ctx, operation, target, shift, replaces)
+	if err != nil {
+		return err
+	}
+	if !e.Eligible {
+		return notEligible(target, e)
+	}
+	if targetShift == nil {
+		return nil
+	}
+
+	requester, err := s.marketplaceEmployee(ctx, operation, requesterID)
+	if err != nil {
+		return err
+	}
+	e, err = s.shiftEligibility(ctx, operation, requester, *targetShift, &shift.ID)
+	if err != nil {
+		return err
+	}
+	if !e.Eligible {
+		return notEligible(requester, e)
+	}
+	return nil
+}
+
+// checkSwapRequest checks that the shifts of a swap request are still with the employees
+// that swap them and that both can still take over the shift they get
+func (s *scheduleService) checkSwapRequest(ctx context.Context, operation string, swap db.GetShiftSwapRequestRow) (marketplaceShift, *marketplaceShift, error) {
+	shift, err := s.marketplaceSchedule(ctx, operation, swap.ScheduleID)
+	if err != nil {
+		return shift, nil, err
+	}
+	if shift.EmployeeID == nil || *shift.EmployeeID != swap.RequesterEmployeeID {
+		return shift, nil, ErrShiftTaken
+	}
+	var targetShift *marketplaceShift
+	if swap.TargetScheduleID != nil {
+		ts, err := s.marketplaceSchedule(ctx, operation, *swap.TargetScheduleID)
+		if err != nil {
+			return shift, nil, err
+		}
+		if ts.EmployeeID == nil || *ts.EmployeeID != swap.TargetEmployeeID {
+			return shift, nil, ErrShiftTaken
+		}
+		targetShift = &ts
+	}
+	return shift, targetShift, s.checkSwap(ctx, operation, swap.RequesterEmployeeID, swap.TargetEmployeeID, shift, targetShift)
+}
+
+// assignSchedule moves a schedule from one employee to another, nil for an open shift
+func (s *scheduleService) assignSchedule(ctx context.Context, q *db.Queries, operation string, scheduleID uuid.UUID, from, to *int64) (db.Schedule, error) {
+	schedule, err := q.AssignSchedule(ctx, db.AssignScheduleParams{
+		EmployeeID:        to,
+		ID:                scheduleID,
+		CurrentEmployeeID: from,
+	})
+	if err != nil {
+		// claimed or moved by someone else in the meantime
+		if errors.Is(err, pgx.ErrNoRows) {
+			return schedule, ErrShiftTaken
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, operation, "Failed to assign schedule", zap.Error(err), zap.String("schedule_id", scheduleID.String()))
+		return schedule, fmt.Errorf("failed to assign schedule")
+	}
+	return schedule, nil
+}
+
+// targetSwapRequest returns a pending swap request the employee is asked to answer
+func (s *scheduleService) targetSwapRequest(ctx context.Context, operation string, swapRequestID, employeeID int64) (db.GetShiftSwapRequestRow, error) {
+	swap, err := s.getShiftSwapRequest(ctx, operation, swapRequestID)
+	if err != nil {
+		return swap, err
+	}
+	if swap.TargetEmployeeID != employeeID {
+		return swap, ErrNotOwnSwapRequest
+	}
+	if swap.Status != SwapStatusPending {
+		return swap, ErrSwapRequestClosed
+	}
+	return swap, nil
+}
+
+// reviewableSwapRequest returns an accepted swap request a planner can approve or reject
+func (s *scheduleService) reviewableSwapRequest(ctx context.Context, operation string, swapRequestID, employeeID int64) (db.GetShiftSwapRequestRow, error) {
+	swap, err := s.getShiftSwapRequest(ctx, operation, swapRequestID)
+	if err != nil {
+		return swap, err
+	}
+	if swap.RequesterEmployeeID == employeeID || swap.TargetEmployeeID == employeeID {
+		return swap, ErrOwnSwapRequest
+	}
+	if swap.Status != SwapStatusAccepted {
+		return swap, ErrSwapRequestClosed
+	}
+	return swap, nil
+}
+
+func (s *scheduleService) respondShiftSwapRequest(ctx context.Context, operation string, swap db.GetShiftSwapRequestRow, status string) (db.GetShiftSwapRequestRow, error) {
+	row, err := s.Store.RespondShiftSwapRequest(ctx, db.RespondShiftSwapRequestParams{Status: status, ID: swap.ID})
+	if err != nil {
+		// cancelled by the requester in the meantime
+		if errors.Is(err, pgx.ErrNoRows) {
+			return swap, ErrSwapRequestClosed
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, operation, "Failed to answer swap request", zap.Error(err), zap.Int64("swap_request_id", swap.ID))
+		return swap, fmt.Errorf("failed to answer swap request")
+	}
+	s.Logger.LogBusinessEvent(logger.LogLevelInfo, operation, "Swap request answered", zap.Int64("swap_request_id", swap.ID), zap.String("status", status))
+	swap.Status = row.Status
+	swap.RespondedAt = row.RespondedAt
+	return swap, nil
+}
+
+func (s *scheduleService) reviewShiftSwapRequest(ctx context.Context, q *db.Queries, operation string, swapRequestID, employeeID int64, status string, note *string) error {
+	_, err := q.ReviewShiftSwapRequest(ctx, db.ReviewShiftSwapRequestParams{
+		Status:               status,
+		ReviewNote:           note,
+		ReviewedByEmployeeID: &employeeID,
+		ID:                   swapRequestID,
+	})
+	if err != nil {
+		// reviewed or cancelled in the meantime
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrSwapRequestClosed
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, operation, "Failed to review swap request", zap.Error(err), zap.Int64("swap_request_id", swapRequestID))
+		return fmt.Errorf("failed to review swap request")
+	}
+	return nil
+}
+
+func (s *scheduleService) getShiftSwapRequest(ctx context.Context, operation string, swapRequestID int64) (db.GetShiftSwapRequestRow, error) {
+	swap, err := s.Store.GetShiftSwapRequest(ctx, swapRequestID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return swap, ErrSwapRequestNotFound
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, operation, "Failed to get swap request", zap.Error(err), zap.Int64("swap_request_id", swapRequestID))
+		return swap, fmt.Errorf("failed to get swap request")
+	}
+	return swap, nil
+}
+
+func (s *scheduleService) marketplaceSchedule(ctx context.Context, operation string, scheduleID uuid.UUID) (marketplaceShift, error) {
+	row, err := s.Store.GetScheduleById(ctx, scheduleID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return marketplaceShift{}, ErrScheduleNotFound
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, operation, "Failed to get schedule", zap.Error(err), zap.String("schedule_id", scheduleID.String()))
+		return marketplaceShift{}, fmt.Errorf("failed to get schedule")
+	}
+	return marketplaceShift{
+		ID:              row.ID,
+		EmployeeID:      row.EmployeeID,
+		LocationID:      row.LocationID,
+		LocationName:    row.LocationName,
+		LocationShiftID: row.LocationShiftID,
+		ShiftName:       row.LocationShiftName,
+		Color:           row.Color,
+		Start:           row.StartDatetime.Time,
+		End:             row.EndDatetime.Time,
+	}, nil
+}
+
+func (s *scheduleService) marketplaceEmployee(ctx context.Context, operation string, employeeID int64) (db.GetShiftMarketplaceEmployeeRow, error) {
+	employee, err := s.Store.GetShiftMarketplaceEmployee(ctx, employeeID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return employee, ErrEmployeeNotFound
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, operation, "Failed to get employee", zap.Error(err), zap.Int64("employee_id", employeeID))
+		return employee, fmt.Errorf("failed to get employee")
+	}
+	return employee, nil
+}
+
+// openShiftTimes returns the start and end of a new open shift
+func (s *scheduleService) openShiftTimes(ctx context.Context, locationID int64, req *CreateOpenShiftRequest) (time.Time, time.Time, error) {
+	if req.LocationShiftID == nil {
+		if req.StartDatetime == nil || req.EndDatetime == nil || req.ShiftDate != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: give a location shift and shift date, or a start and end", ErrInvalidOpenShift)
+		}
+		start, end := asStored(*req.StartDatetime), asStored(*req.EndDatetime)
+		if !start.Before(end) {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: the start must be before the end", ErrInvalidOpenShift)
+		}
+		return start, end, nil
+	}
+
+	if req.ShiftDate == nil || req.StartDatetime != nil || req.EndDatetime != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: give a location shift and shift date, or a start and end", ErrInvalidOpenShift)
+	}
+	day, err := time.Parse("2006-01-02", *req.ShiftDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: invalid shift_date, expected YYYY-MM-DD", ErrInvalidOpenShift)
+	}
+	shift, err := s.Store.GetShiftByID(ctx, *req.LocationShiftID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: location shift not found", ErrInvalidOpenShift)
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CreateOpenShift", "Failed to get location shift", zap.Error(err), zap.Int64("location_shift_id", *req.LocationShiftID))
+		return time.Time{}, time.Time{}, fmt.Errorf("failed to create open shift")
+	}
+	if shift.LocationID != locationID {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: the shift belongs to another location", ErrInvalidOpenShift)
+	}
+	start, end := shiftTimes(day, shift)
+	return start, end, nil
+}
+
+// planners returns the user IDs of the planners of the locations. Failing to list them is
+// logged, the change has been saved already.
+func (s *scheduleService) planners(ctx context.Context, operation string, locationIDs ...int64) []int64 {
+	holders, err := s.Store.ListPermissionHolders(ctx, PlannerPermission)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, operation, "Failed to list planners", zap.Error(err))
+		return nil
+	}
+	var userIDs []int64
+	for _, locationID := range locationIDs {
+		userIDs = append(userIDs, LocationPlanners(holders, locationID)...)
+	}
+	return userIDs
+}
+
+// locationAudience returns the users that see the open shifts of a location: the
+// employees that work there or prefer to, and its planners
+func (s *scheduleService) locationAudience(ctx context.Context, operation string, locationID int64) []int64 {
+	userIDs, err := s.Store.ListLocationEmployeeUsers(ctx, locationID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, operation, "Failed to list employees of location", zap.Error(err), zap.Int64("location_id", locationID))
+	}
+	return append(userIDs, s.planners(ctx, operation, locationID)...)
+}
+
+// notifyMarketplace stores and sends a notification about a claimed shift or a swap
+// request. Failing notifications are logged, the change has been saved already.
+func (s *scheduleService) notifyMarketplace(ctx context.Context, operation string, data *notification.ShiftMarketplaceData, recipients []int64) {
+	recipients = uniqueIDs(recipients)
+	if len(recipients) == 0 {
+		return
+	}
+	err := s.AsynqClient.EnqueueNotificationTask(ctx, notification.NotificationPayload{
+		RecipientUserIDs: recipients,
+		Type:             notification.TypeShiftMarketplace,
+		Data:             notification.NotificationData{ShiftMarketplace: data},
+		CreatedAt:        time.Now(),
+		Message:          data.ShiftMarketplaceMessage(),
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, operation, "Failed to enqueue notification task", zap.Error(err), zap.String("schedule_id", data.ScheduleID.String()))
+	}
+}
+
+// publishMarketplaceEvent pushes a change to the connected users over the websocket hub
+func (s *scheduleService) publishMarketplaceEvent(operation string, event MarketplaceEvent, userIDs []int64) {
+	if s.Hub == nil {
+		return
+	}
+	event.Type = MarketplaceUpdate
+	message, err := json.Marshal(event)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, operation, "Failed to marshal marketplace event", zap.Error(err))
+		return
+	}
+	for _, userID := range uniqueIDs(userIDs) {
+		s.Hub.SendToUser(userID, message)
+	}
+}
+
+func (s *scheduleService) publishSwapEvent(operation, event string, swap db.GetShiftSwapRequestRow, userIDs ...int64) {
+	s.publishMarketplaceEvent(operation, MarketplaceEvent{
+		Event:         event,
+		ScheduleID:    swap.ScheduleID,
+		SwapRequestID: &swap.ID,
+		LocationID:    swap.LocationID,
+	}, userIDs)
+}
+
+// publishCancelledSwaps tells the employees of swap requests that were cancelled because
+// their shift changed hands
+func (s *scheduleService) publishCancelledSwaps(operation string, locationID int64, cancelled []db.CancelShiftSwapRequestsForSchedulesRow) {
+	for _, c := range cancelled {
+		swapRequestID := c.ID
+		s.publishMarketplaceEvent(operation, MarketplaceEvent{
+			Event:         "swap_cancelled",
+			ScheduleID:    c.ScheduleID,
+			SwapRequestID: &swapRequestID,
+			LocationID:    locationID,
+		}, []int64{c.RequesterUserID, c.TargetUserID})
+	}
+}
+
+// swapLocations returns the locations of the shifts of a swap
+func swapLocations(swap db.GetShiftSwapRequestRow) []int64 {
+	locationIDs := []int64{swap.LocationID}
+	if swap.TargetLocationID != nil && *swap.TargetLocationID != swap.LocationID {
+		locationIDs = append(locationIDs, *swap.TargetLocationID)
+	}
+	return locationIDs
+}
+
+func uniqueIDs(ids []int64) []int64 {
+	var unique []int64
+	for _, id := range ids {
+		if !slices.Contains(unique, id) {
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+func openShift(row db.ListOpenShiftsRow) marketplaceShift {
+	return marketplaceShift{
+		ID:              row.ID,
+		LocationID:      row.LocationID,
+		LocationName:    row.LocationName,
+		LocationShiftID: row.LocationShiftID,
+		ShiftName:       row.ShiftName,
+		Color:           row.Color,
+		Start:           row.StartDatetime.Time,
+		End:             row.EndDatetime.Time,
+	}
+}
+
+func openShiftResponse(shift marketplaceShift) *OpenShiftResponse {
+	return &OpenShiftResponse{
+		ScheduleID:      shift.ID,
+		LocationID:      shift.LocationID,
+		LocationName:    shift.LocationName,
+		LocationShiftID: shift.LocationShiftID,
+		ShiftName:       shift.ShiftName,
+		Color:           shift.Color,
+		StartTime:       shift.Start,
+		EndTime:         shift.End,
+		EmployeeID:      shift.EmployeeID,
+	}
+}
+
+func swapNotification(event string, swap db.GetShiftSwapRequestRow) *notification.ShiftMarketplaceData {
+	swapRequestID := swap.ID
+	return &notification.ShiftMarketplaceData{
+		Event:              event,
+		ScheduleID:         swap.ScheduleID,
+		SwapRequestID:      &swapRequestID,
+		RequesterFirstName: swap.RequesterFirstName,
+		RequesterLastName:  swap.RequesterLastName,
+		TargetFirstName:    swap.TargetFirstName,
+		TargetLastName:     swap.TargetLastName,
+		LocationID:         swap.LocationID,
+		LocationName:       swap.LocationName,
+		StartTime:          swap.StartDatetime.Time,
+		EndTime:            swap.EndDatetime.Time,
+	}
+}
+
+func swapRequestResponse(row db.GetShiftSwapRequestRow) *ShiftSwapRequestResponse {
+	resp := &ShiftSwapRequestResponse{
+		ID:                  row.ID,
+		Status:              row.Status,
+		RequesterEmployeeID: row.RequesterEmployeeID,
+		RequesterFirstName:  row.RequesterFirstName,
+		RequesterLastName:   row.RequesterLastName,
+		TargetEmployeeID:    row.TargetEmployeeID,
+		TargetFirstName:     row.TargetFirstName,
+		TargetLastName:      row.TargetLastName,
+		Shift: SwapShift{
+			ScheduleID:   row.ScheduleID,
+			LocationID:   row.LocationID,
+			LocationName: row.LocationName,
+			ShiftName:    row.ShiftName,
+			StartTime:    row.StartDatetime.Time,
+			EndTime:      row.EndDatetime.Time,
+		},
+		Message:              row.Message,
+		ReviewNote:           row.ReviewNote,
+		ReviewedByEmployeeID: row.ReviewedByEmployeeID,
+		CreatedAt:            row.CreatedAt.Time,
+	}
+	if row.TargetScheduleID != nil && row.TargetLocationID != nil {
+		resp.TargetShift = &SwapShift{
+			ScheduleID:   *row.TargetScheduleID,
+			LocationID:   *row.TargetLocationID,
+			LocationName: *row.TargetLocationName,
+			ShiftName:    row.TargetShiftName,
+			StartTime:    row.TargetStartDatetime.Time,
+			EndTime:      row.TargetEndDatetime.Time,
+		}
+	}
+	if row.RespondedAt.Valid {
+		resp.RespondedAt = &row.RespondedAt.Time
+	}
+	if row.ReviewedAt.Valid {
+		resp.ReviewedAt = &row.ReviewedAt.Time
+	}
+	return resp
+}
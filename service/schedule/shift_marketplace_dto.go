@@ -0,0 +1,102 @@
+package schedule
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateOpenShiftRequest represents the request for an open shift of a location: the
+// location shift and the date for a preset shift, or the start and end of a custom shift
+type CreateOpenShiftRequest struct {
+	LocationShiftID *int64     `json:"location_shift_id" example:"1"`
+	ShiftDate       *string    `json:"shift_date" example:"2025-03-01"`
+	StartDatetime   *time.Time `json:"start_datetime" example:"2025-03-01T07:00:00Z"`
+	EndDatetime     *time.Time `json:"end_datetime" example:"2025-03-01T15:00:00Z"`
+	Color           *string    `json:"color" example:"#0000FF"`
+}
+
+// ShiftEligibility tells whether an employee can take over a shift. Reasons explain why
+// not, conflicts that are only warnings do not make an employee ineligible.
+type ShiftEligibility struct {
+	Eligible  bool       `json:"eligible"`
+	Reasons   []string   `json:"reasons"`
+	Conflicts []Conflict `json:"conflicts"`
+}
+
+// OpenShiftResponse represents an open shift, or the shift once it is claimed. Eligibility
+// is set in the open shifts listed for an employee.
+type OpenShiftResponse struct {
+	ScheduleID      uuid.UUID         `json:"schedule_id"`
+	LocationID      int64             `json:"location_id"`
+	LocationName    string            `json:"location_name"`
+	LocationShiftID *int64            `json:"location_shift_id"`
+	ShiftName       *string           `json:"shift_name"`
+	Color           *string           `json:"color"`
+	StartTime       time.Time         `json:"start_time"`
+	EndTime         time.Time         `json:"end_time"`
+	EmployeeID      *int64            `json:"employee_id"`
+	Eligibility     *ShiftEligibility `json:"eligibility,omitempty"`
+}
+
+// CreateShiftSwapRequestRequest asks a colleague to take over a shift, in exchange for a
+// shift of the colleague when TargetScheduleID is set
+type CreateShiftSwapRequestRequest struct {
+	ScheduleID       uuid.UUID  `json:"schedule_id" binding:"required"`
+	TargetEmployeeID int64      `json:"target_employee_id" binding:"required" example:"2"`
+	TargetScheduleID *uuid.UUID `json:"target_schedule_id"`
+	Message          *string    `json:"message" example:"Can you take my Saturday?"`
+}
+
+type ListShiftSwapRequestsRequest struct {
+	EmployeeID *int64  `form:"employee_id"`
+	LocationID *int64  `form:"location_id"`
+	Status     *string `form:"status" binding:"omitempty,oneof=pending accepted declined approved rejected cancelled"`
+}
+
+type ReviewShiftSwapRequestRequest struct {
+	Note *string `json:"note" example:"Approved, both of you are qualified"`
+}
+
+// SwapShift is one of the shifts of a swap request
+type SwapShift struct {
+	ScheduleID   uuid.UUID `json:"schedule_id"`
+	LocationID   int64     `json:"location_id"`
+	LocationName string    `json:"location_name"`
+	ShiftName    *string   `json:"shift_name"`
+	StartTime    time.Time `json:"start_time"`
+	EndTime      time.Time `json:"end_time"`
+}
+
+// ShiftSwapRequestResponse represents a swap request. The target takes over Shift, the
+// requester takes over TargetShift in exchange when it is set.
+type ShiftSwapRequestResponse struct {
+	ID                   int64      `json:"id"`
+	Status               string     `json:"status" enums:"pending,accepted,declined,approved,rejected,cancelled"`
+	RequesterEmployeeID  int64      `json:"requester_employee_id"`
+	RequesterFirstName   string     `json:"requester_first_name"`
+	RequesterLastName    string     `json:"requester_last_name"`
+	TargetEmployeeID     int64      `json:"target_employee_id"`
+	TargetFirstName      string     `json:"target_first_name"`
+	TargetLastName       string     `json:"target_last_name"`
+	Shift                SwapShift  `json:"shift"`
+	TargetShift          *SwapShift `json:"target_shift"`
+	Message              *string    `json:"message"`
+	RespondedAt          *time.Time `json:"responded_at"`
+	ReviewNote           *string    `json:"review_note"`
+	ReviewedByEmployeeID *int64     `json:"reviewed_by_employee_id"`
+	ReviewedAt           *time.Time `json:"reviewed_at"`
+	CreatedAt            time.Time  `json:"created_at"`
+}
+
+// MarketplaceEvent is pushed over the websocket hub to the employees and planners a
+// change of the open shifts or of a swap request concerns, so that their views update
+// without a reload
+type MarketplaceEvent struct {
+	Type          string    `json:"type"` // always "shift_marketplace_update"
+	Event         string    `json:"event" enums:"open_shift_created,shift_released,shift_claimed,swap_requested,swap_accepted,swap_declined,swap_cancelled,swap_approved,swap_rejected"`
+	ScheduleID    uuid.UUID `json:"schedule_id"`
+	SwapRequestID *int64    `json:"swap_request_id,omitempty"`
+	LocationID    int64     `json:"location_id"`
+	EmployeeID    *int64    `json:"employee_id"` // who has the shift now, empty for an open shift
+}
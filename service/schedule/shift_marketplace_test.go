@@ -0,0 +1,90 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	db "maicare_go/db/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShiftCertifications(t *testing.T) {
+	day := monday.Add(7 * time.Hour)
+	coverage := []ShiftCoverage{
+		{LocationShiftID: 1, StartTime: day, EndTime: day.Add(8 * time.Hour), MissingCertifications: []CertificationShortage{
+			{Name: "BHV", Required: 1}, {Name: "Medicatie", Required: 2, Scheduled: 1},
+		}},
+		{LocationShiftID: 2, StartTime: day.Add(8 * time.Hour), EndTime: day.Add(16 * time.Hour), MissingCertifications: []CertificationShortage{
+			{Name: "bhv", Required: 1}, {Name: "EHBO", Required: 1},
+		}},
+	}
+	shiftID := int64(1)
+
+	// a preset shift only counts for its own shift
+	shift := marketplaceShift{LocationShiftID: &shiftID, Start: day, End: day.Add(8 * time.Hour)}
+	require.Equal(t, []string{"BHV", "Medicatie"}, shiftCertifications(coverage, shift))
+
+	// a custom shift counts for the shifts it covers completely
+	shift = marketplaceShift{Start: day, End: day.Add(16 * time.Hour)}
+	require.Equal(t, []string{"BHV", "Medicatie", "EHBO"}, shiftCertifications(coverage, shift))
+	shift.End = day.Add(12 * time.Hour)
+	require.Equal(t, []string{"BHV", "Medicatie"}, shiftCertifications(coverage, shift))
+	require.Empty(t, shiftCertifications(nil, shift))
+}
+
+func TestEligibility(t *testing.T) {
+	locationID := int64(10)
+	employee := db.GetShiftMarketplaceEmployeeRow{
+		ID:                   1,
+		FirstName:            "Sanne",
+		LastName:             "de Vries",
+		LocationID:           &locationID,
+		PreferredLocationIds: []int64{20, 10},
+		Certifications:       []string{"bhv"},
+	}
+	require.Equal(t, []int64{10, 20}, employeeLocations(employee))
+
+	e := eligibility(employee, 20, []string{"BHV"}, []Conflict{{Kind: ConflictAppointment, Severity: SeverityWarning}})
+	require.True(t, e.Eligible)
+	require.Empty(t, e.Reasons)
+	require.Len(t, e.Conflicts, 1)
+
+	e = eligibility(employee, 30, []string{"BHV", "EHBO"}, []Conflict{{Kind: ConflictShift, Severity: SeverityError}})
+	require.False(t, e.Eligible)
+	require.Equal(t, []string{
+		"Does not work at the location of the shift",
+		"Lacks the certification EHBO the shift needs",
+		"Conflicts with other shifts, an absence or the working time rules",
+	}, e.Reasons)
+	require.ErrorIs(t, notEligible(employee, e), ErrNotEligible)
+
+	// without a location or preferences an employee works nowhere
+	require.Empty(t, employeeLocations(db.GetShiftMarketplaceEmployeeRow{}))
+}
+
+func TestSwapSlot(t *testing.T) {
+	given, taken, other := uuid.New(), uuid.New(), uuid.New()
+	shifts := []db.ListEmployeesSchedulesInRangeRow{
+		{ID: given, EmployeeID: 1, LocationID: 10, StartDatetime: pgtype.Timestamp{Time: monday.Add(7 * time.Hour), Valid: true}, EndDatetime: pgtype.Timestamp{Time: monday.Add(15 * time.Hour), Valid: true}},
+		{ID: other, EmployeeID: 1, LocationID: 10, StartDatetime: pgtype.Timestamp{Time: monday.Add(14 * time.Hour), Valid: true}, EndDatetime: pgtype.Timestamp{Time: monday.Add(22 * time.Hour), Valid: true}},
+	}
+	slot := Slot{
+		ScheduleID: &taken,
+		EmployeeID: 1,
+		LocationID: 10,
+		Start:      monday.Add(7 * time.Hour),
+		End:        monday.Add(13 * time.Hour),
+	}
+	require.Len(t, detectConflicts(slot, shifts, nil), 1)
+
+	// the shift the employee gives up in the swap does not conflict
+	slot.Replaces = &given
+	require.Empty(t, detectConflicts(slot, shifts, nil))
+	slot.End = monday.Add(15 * time.Hour)
+	conflicts := detectConflicts(slot, shifts, nil)
+	require.Len(t, conflicts, 1)
+	require.Equal(t, other, *conflicts[0].ScheduleID)
+}
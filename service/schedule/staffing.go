@@ -0,0 +1,463 @@
+package schedule
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	db "maicare_go/db/sqlc"
+	"maicare_go/logger"
+	"maicare_go/util"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+// GapLookahead is how far ahead the daily check warns planners about staffing gaps
+const GapLookahead = 14 * 24 * time.Hour
+
+// PlannerPermission is the permission of the users that are notified about staffing gaps
+// and about shifts that need a replacement
+const PlannerPermission = "SCHEDULE.CREATE"
+
+// maxRangeDays limits the date range of reports
+const maxRangeDays = 93
+
+var (
+	ErrStaffingNormNotFound = errors.New("staffing norm not found")
+	ErrStaffingNormExists   = errors.New("the shift already has a staffing norm for this weekday")
+	ErrInvalidStaffingNorm  = errors.New("invalid staffing norm")
+	ErrInvalidDateRange     = errors.New("invalid date range")
+)
+
+func (s *scheduleService) CreateStaffingNorm(ctx context.Context, locationID int64, req *StaffingNormRequest) (*StaffingNormResponse, error) {
+	shift, err := s.Store.GetShiftByID(ctx, req.LocationShiftID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: unknown shift %d", ErrInvalidStaffingNorm, req.LocationShiftID)
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CreateStaffingNorm", "Failed to get shift", zap.Error(err), zap.Int64("location_shift_id", req.LocationShiftID))
+		return nil, fmt.Errorf("failed to create staffing norm")
+	}
+	if shift.LocationID != locationID {
+		return nil, fmt.Errorf("%w: shift %d does not belong to the location", ErrInvalidStaffingNorm, req.LocationShiftID)
+	}
+	if err := s.checkStaffingNorm(ctx, "CreateStaffingNorm", locationID, 0, req.LocationShiftID, req.Weekday, req.MinStaff, req.Certifications); err != nil {
+		return nil, err
+	}
+
+	certifications, err := json.Marshal(normalizeCertifications(req.Certifications))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create staffing norm")
+	}
+	norm, err := s.Store.CreateStaffingNorm(ctx, db.CreateStaffingNormParams{
+		LocationID:      locationID,
+		LocationShiftID: req.LocationShiftID,
+		Weekday:         req.Weekday,
+		MinStaff:        req.MinStaff,
+		Certifications:  certifications,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CreateStaffingNorm", "Failed to create staffing norm", zap.Error(err), zap.Int64("location_id", locationID))
+		return nil, fmt.Errorf("failed to create staffing norm")
+	}
+	return staffingNormResponse(norm, shift.ShiftName), nil
+}
+
+func (s *scheduleService) ListStaffingNorms(ctx context.Context, locationID int64) ([]StaffingNormResponse, error) {
+	rows, err := s.Store.ListStaffingNorms(ctx, locationID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ListStaffingNorms", "Failed to list staffing norms", zap.Error(err), zap.Int64("location_id", locationID))
+		return nil, fmt.Errorf("failed to list staffing norms")
+	}
+	norms := make([]StaffingNormResponse, len(rows))
+	for i, row := range rows {
+		norms[i] = *staffingNormResponse(staffingNorm(row), row.ShiftName)
+	}
+	return norms, nil
+}
+
+func (s *scheduleService) UpdateStaffingNorm(ctx context.Context, locationID, normID int64, req *UpdateStaffingNormRequest) (*StaffingNormResponse, error) {
+	norm, err := s.Store.GetStaffingNorm(ctx, db.GetStaffingNormParams{ID: normID, LocationID: locationID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrStaffingNormNotFound
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "UpdateStaffingNorm", "Failed to get staffing norm", zap.Error(err), zap.Int64("norm_id", normID))
+		return nil, fmt.Errorf("failed to update staffing norm")
+	}
+	if err := s.checkStaffingNorm(ctx, "UpdateStaffingNorm", locationID, normID, norm.LocationShiftID, req.Weekday, req.MinStaff, req.Certifications); err != nil {
+		return nil, err
+	}
+
+	certifications, err := json.Marshal(normalizeCertifications(req.Certifications))
+	if err != nil {
+		return nil, fmt.Errorf("failed to update staffing norm")
+	}
+	norm, err = s.Store.UpdateStaffingNorm(ctx, db.UpdateStaffingNormParams{
+		ID:             normID,
+		LocationID:     locationID,
+		Weekday:        req.Weekday,
+		MinStaff:       req.MinStaff,
+		Certifications: certifications,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "UpdateStaffingNorm", "Failed to update staffing norm", zap.Error(err), zap.Int64("norm_id", normID))
+		return nil, fmt.Errorf("failed to update staffing norm")
+	}
+	return staffingNormResponse(norm, ""), nil
+}
+
+func (s *scheduleService) DeleteStaffingNorm(ctx context.Context, locationID, normID int64) error {
+	deleted, err := s.Store.DeleteStaffingNorm(ctx, db.DeleteStaffingNormParams{ID: normID, LocationID: locationID})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "DeleteStaffingNorm", "Failed to delete staffing norm", zap.Error(err), zap.Int64("norm_id", normID))
+		return fmt.Errorf("failed to delete staffing norm")
+	}
+	if deleted == 0 {
+		return ErrStaffingNormNotFound
+	}
+	return nil
+}
+
+// checkStaffingNorm validates a norm and checks that its shift has no other norm for the
+// weekday
+func (s *scheduleService) checkStaffingNorm(ctx context.Context, operation string, locationID, normID, locationShiftID int64, weekday *int16, minStaff int32, certifications []CertificationRequirement) error {
+	for _, c := range certifications {
+		if int32(c.Count) > minStaff {
+			return fmt.Errorf("%w: more staff with %s than the minimum staff", ErrInvalidStaffingNorm, c.Name)
+		}
+	}
+
+	norms, err := s.Store.ListStaffingNorms(ctx, locationID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, operation, "Failed to list staffing norms", zap.Error(err), zap.Int64("location_id", locationID))
+		return fmt.Errorf("failed to save staffing norm")
+	}
+	for _, norm := range norms {
+		if norm.ID != normID && norm.LocationShiftID == locationShiftID && equalWeekday(norm.Weekday, weekday) {
+			return ErrStaffingNormExists
+		}
+	}
+	return nil
+}
+
+// GetStaffingCoverage compares the schedules of a location with its staffing norms for
+// every day from the start to the end date
+func (s *scheduleService) GetStaffingCoverage(ctx context.Context, locationID int64, req *StaffingCoverageRequest) (*StaffingCoverageResponse, error) {
+	start, end, err := parseDateRange(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	shifts, err := s.staffingCoverage(ctx, locationID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &StaffingCoverageResponse{
+		LocationID: locationID,
+		StartDate:  req.StartDate,
+		EndDate:    req.EndDate,
+		Shifts:     []ShiftCoverage{},
+	}
+	for _, shift := range shifts {
+		if shift.HasGap() {
+			resp.Gaps++
+		} else if req.OnlyGaps {
+			continue
+		}
+		resp.Shifts = append(resp.Shifts, shift)
+	}
+	return resp, nil
+}
+
+// CheckStaffingGaps returns the staffing gaps of the coming GapLookahead for every
+// location with staffing norms, with the planners to notify. Planners of the location are
+// notified, or all planners when the location has none.
+func (s *scheduleService) CheckStaffingGaps(ctx context.Context) ([]StaffingGapAlert, error) {
+	locations, err := s.Store.ListStaffingNormLocations(ctx)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CheckStaffingGaps", "Failed to list locations", zap.Error(err))
+		return nil, err
+	}
+	if len(locations) == 0 {
+		return nil, nil
+	}
+
+	planners, err := s.Store.ListPermissionHolders(ctx, PlannerPermission)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CheckStaffingGaps", "Failed to list planners", zap.Error(err))
+		return nil, err
+	}
+
+	now := util.ConvertTimeToNetherlandsTimezone(time.Now())
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	alerts := []StaffingGapAlert{}
+	for _, location := range locations {
+		shifts, err := s.staffingCoverage(ctx, location.ID, today, today.Add(GapLookahead))
+		if err != nil {
+			s.Logger.LogBusinessEvent(logger.LogLevelWarn, "CheckStaffingGaps", "Failed to check coverage", zap.Error(err), zap.Int64("location_id", location.ID))
+			continue
+		}
+		var gaps []ShiftCoverage
+		for _, shift := range shifts {
+			if shift.HasGap() {
+				gaps = append(gaps, shift)
+			}
+		}
+		if len(gaps) == 0 {
+			continue
+		}
+		alerts = append(alerts, StaffingGapAlert{
+			LocationID:       location.ID,
+			LocationName:     location.Name,
+			RecipientUserIDs: LocationPlanners(planners, location.ID),
+			Gaps:             gaps,
+		})
+	}
+	return alerts, nil
+}
+
+// staffingCoverage returns the coverage of the shifts with a norm on the days from start
+// up to end. The excluded schedules do not count, to see a shift without the employee
+// that leaves it.
+func (s *scheduleService) staffingCoverage(ctx context.Context, locationID int64, start, end time.Time, excluded ...uuid.UUID) ([]ShiftCoverage, error) {
+	normRows, err := s.Store.ListStaffingNorms(ctx, locationID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "StaffingCoverage", "Failed to list staffing norms", zap.Error(err), zap.Int64("location_id", locationID))
+		return nil, fmt.Errorf("failed to get staffing coverage")
+	}
+	if len(normRows) == 0 {
+		return []ShiftCoverage{}, nil
+	}
+	locationShifts, err := s.Store.GetShiftsByLocationID(ctx, locationID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "StaffingCoverage", "Failed to get location shifts", zap.Error(err), zap.Int64("location_id", locationID))
+		return nil, fmt.Errorf("failed to get staffing coverage")
+	}
+	// night shifts of the last day end the day after
+	schedules, err := s.Store.ListCoverageSchedules(ctx, db.ListCoverageSchedulesParams{
+		LocationID: locationID,
+		StartDate:  pgtype.Timestamp{Time: start, Valid: true},
+		EndDate:    pgtype.Timestamp{Time: end.AddDate(0, 0, 1), Valid: true},
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "StaffingCoverage", "Failed to list schedules", zap.Error(err), zap.Int64("location_id", locationID))
+		return nil, fmt.Errorf("failed to get staffing coverage")
+	}
+	schedules = slices.DeleteFunc(schedules, func(row db.ListCoverageSchedulesRow) bool { return slices.Contains(excluded, row.ID) })
+
+	norms := make([]db.StaffingNorm, len(normRows))
+	for i, row := range normRows {
+		norms[i] = staffingNorm(row)
+	}
+	return computeCoverage(start, end, locationShifts, norms, schedules), nil
+}
+
+// computeCoverage compares the staff on each shift with a norm on the days from start up
+// to end. Preset shift schedules count for their shift, custom schedules for the shifts
+// they cover completely.
+func computeCoverage(start, end time.Time, shifts []db.LocationShift, norms []db.StaffingNorm, schedules []db.ListCoverageSchedulesRow) []ShiftCoverage {
+	coverage := []ShiftCoverage{}
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		for _, shift := range shifts {
+			norm := resolveNorm(norms, shift.ID, day.Weekday())
+			if norm == nil {
+				continue
+			}
+			slotStart, slotEnd := shiftTimes(day, shift)
+			c := ShiftCoverage{
+				Date:            day.Format("2006-01-02"),
+				LocationShiftID: shift.ID,
+				ShiftName:       shift.ShiftName,
+				StartTime:       slotStart,
+				EndTime:         slotEnd,
+				MinStaff:        int(norm.MinStaff),
+				Employees:       []CoverageEmployee{},
+			}
+
+			certified := make(map[string]int)
+			for _, schedule := range schedules {
+				if !countsFor(schedule.LocationShiftID, schedule.StartDatetime.Time, schedule.EndDatetime.Time, shift.ID, slotStart, slotEnd) {
+					continue
+				}
+				if slices.ContainsFunc(c.Employees, func(e CoverageEmployee) bool { return e.EmployeeID == schedule.EmployeeID }) {
+					continue
+				}
+				c.Employees = append(c.Employees, CoverageEmployee{
+					EmployeeID: schedule.EmployeeID,
+					FirstName:  schedule.EmployeeFirstName,
+					LastName:   schedule.EmployeeLastName,
+				})
+				for _, name := range schedule.Certifications {
+					certified[name]++
+				}
+			}
+
+			c.Scheduled = len(c.Employees)
+			c.Understaffed = c.Scheduled < c.MinStaff
+			c.MissingCertifications = []CertificationShortage{}
+			for _, req := range normCertifications(*norm) {
+				if have := certified[strings.ToLower(req.Name)]; have < req.Count {
+					c.MissingCertifications = append(c.MissingCertifications, CertificationShortage{
+						Name:      req.Name,
+						Required:  req.Count,
+						Scheduled: have,
+					})
+				}
+			}
+			coverage = append(coverage, c)
+		}
+	}
+	return coverage
+}
+
+// countsFor reports whether a schedule counts for a shift of the location that runs from
+// slotStart to slotEnd: a preset shift schedule for its shift on the same day, a custom
+// schedule when it covers the shift completely
+func countsFor(scheduleShiftID *int64, start, end time.Time, locationShiftID int64, slotStart, slotEnd time.Time) bool {
+	if scheduleShiftID != nil {
+		return *scheduleShiftID == locationShiftID && sameDay(start, slotStart)
+	}
+	return !start.After(slotStart) && !end.Before(slotEnd)
+}
+
+// resolveNorm returns the norm of a shift on a weekday: the norm for that weekday, or else
+// the norm for every day
+func resolveNorm(norms []db.StaffingNorm, locationShiftID int64, weekday time.Weekday) *db.StaffingNorm {
+	var everyDay *db.StaffingNorm
+	for i := range norms {
+		norm := &norms[i]
+		if norm.LocationShiftID != locationShiftID {
+			continue
+		}
+		if norm.Weekday == nil {
+			everyDay = norm
+		} else if time.Weekday(*norm.Weekday) == weekday {
+			return norm
+		}
+	}
+	return everyDay
+}
+
+// normRequirements returns the staffing requirements of the shifts on a weekday
+func normRequirements(norms []db.StaffingNorm, weekday time.Weekday) []ShiftRequirement {
+	var requirements []ShiftRequirement
+	seen := make(map[int64]bool)
+	for _, n := range norms {
+		if seen[n.LocationShiftID] {
+			continue
+		}
+		seen[n.LocationShiftID] = true
+		if norm := resolveNorm(norms, n.LocationShiftID, weekday); norm != nil {
+			requirements = append(requirements, ShiftRequirement{
+				LocationShiftID: norm.LocationShiftID,
+				Staff:           int(norm.MinStaff),
+				Certifications:  normCertifications(*norm),
+			})
+		}
+	}
+	return requirements
+}
+
+// LocationPlanners returns the user IDs of the planners of a location, or of all planners
+// when it has none
+func LocationPlanners(planners []db.ListPermissionHoldersRow, locationID int64) []int64 {
+	var all, local []int64
+	for _, p := range planners {
+		if slices.Contains(all, p.UserID) {
+			continue
+		}
+		all = append(all, p.UserID)
+		if p.LocationID != nil && *p.LocationID == locationID {
+			local = append(local, p.UserID)
+		}
+	}
+	if len(local) > 0 {
+		return local
+	}
+	return all
+}
+
+func normCertifications(norm db.StaffingNorm) []CertificationRequirement {
+	var certifications []CertificationRequirement
+	// the column is only written by this package, a broken value means no requirements
+	_ = json.Unmarshal(norm.Certifications, &certifications)
+	return certifications
+}
+
+// normalizeCertifications trims the names of certifications and merges duplicates
+func normalizeCertifications(certifications []CertificationRequirement) []CertificationRequirement {
+	normalized := []CertificationRequirement{}
+	for _, c := range certifications {
+		c.Name = strings.TrimSpace(c.Name)
+		i := slices.IndexFunc(normalized, func(n CertificationRequirement) bool { return strings.EqualFold(n.Name, c.Name) })
+		if i < 0 {
+			normalized = append(normalized, c)
+		} else if c.Count > normalized[i].Count {
+			normalized[i].Count = c.Count
+		}
+	}
+	return normalized
+}
+
+// parseDateRange parses the YYYY-MM-DD dates of a report and returns the start of the
+// first day and the end of the last day
+func parseDateRange(startDate, endDate string) (time.Time, time.Time, error) {
+	start, err := time.Parse("2006-01-02", startDate)
+	if err != nil {
+		return start, start, fmt.Errorf("%w: start_date must be YYYY-MM-DD", ErrInvalidDateRange)
+	}
+	end, err := time.Parse("2006-01-02", endDate)
+	if err != nil {
+		return start, end, fmt.Errorf("%w: end_date must be YYYY-MM-DD", ErrInvalidDateRange)
+	}
+	if end.Before(start) || end.Sub(start) >= maxRangeDays*24*time.Hour {
+		return start, end, fmt.Errorf("%w: the end date must be after the start date and at most %d days later", ErrInvalidDateRange, maxRangeDays-1)
+	}
+	return start, end.AddDate(0, 0, 1), nil
+}
+
+func equalWeekday(a, b *int16) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func sameDay(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
+}
+
+func staffingNorm(row db.ListStaffingNormsRow) db.StaffingNorm {
+	return db.StaffingNorm{
+		ID:              row.ID,
+		LocationID:      row.LocationID,
+		LocationShiftID: row.LocationShiftID,
+		Weekday:         row.Weekday,
+		MinStaff:        row.MinStaff,
+		Certifications:  row.Certifications,
+		CreatedAt:       row.CreatedAt,
+		UpdatedAt:       row.UpdatedAt,
+	}
+}
+
+func staffingNormResponse(norm db.StaffingNorm, shiftName string) *StaffingNormResponse {
+	return &StaffingNormResponse{
+		ID:              norm.ID,
+		LocationID:      norm.LocationID,
+		LocationShiftID: norm.LocationShiftID,
+		ShiftName:       shiftName,
+		Weekday:         norm.Weekday,
+		MinStaff:        norm.MinStaff,
+		Certifications:  append([]CertificationRequirement{}, normCertifications(norm)...),
+		CreatedAt:       norm.CreatedAt.Time,
+		UpdatedAt:       norm.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,91 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	db "maicare_go/db/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func clock(hour, minute int) pgtype.Time {
+	return pgtype.Time{Microseconds: int64(time.Duration(hour)*time.Hour+time.Duration(minute)*time.Minute) / int64(time.Microsecond), Valid: true}
+}
+
+func TestComputeCoverage(t *testing.T) {
+	day := time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC) // a Monday
+	night := db.LocationShift{ID: 1, ShiftName: "Night", StartTime: clock(23, 0), EndTime: clock(7, 0)}
+	early := db.LocationShift{ID: 2, ShiftName: "Early", StartTime: clock(7, 0), EndTime: clock(15, 0)}
+	weekday := int16(time.Tuesday)
+	norms := []db.StaffingNorm{
+		{ID: 1, LocationShiftID: 1, MinStaff: 2, Certifications: []byte(`[{"name":"BHV","count":1}]`)},
+		// on Tuesdays a single employee is enough
+		{ID: 2, LocationShiftID: 1, Weekday: &weekday, MinStaff: 1, Certifications: []byte(`[]`)},
+	}
+	nightShift := int64(1)
+	schedules := []db.ListCoverageSchedulesRow{
+		{ID: uuid.New(), EmployeeID: 1, LocationShiftID: &nightShift, StartDatetime: ts(day.Add(23 * time.Hour)), EndDatetime: ts(day.Add(31 * time.Hour))},
+		// a custom schedule that covers the whole night
+		{ID: uuid.New(), EmployeeID: 2, StartDatetime: ts(day.Add(22 * time.Hour)), EndDatetime: ts(day.Add(32 * time.Hour)), Certifications: []string{"bhv"}},
+		// a custom schedule that covers part of the night does not count
+		{ID: uuid.New(), EmployeeID: 3, StartDatetime: ts(day.Add(47 * time.Hour)), EndDatetime: ts(day.Add(51 * time.Hour))},
+	}
+
+	coverage := computeCoverage(day, day.AddDate(0, 0, 2), []db.LocationShift{night, early}, norms, schedules)
+	require.Len(t, coverage, 2)
+
+	monday := coverage[0]
+	require.Equal(t, "2025-03-03", monday.Date)
+	require.Equal(t, 2, monday.MinStaff)
+	require.Equal(t, 2, monday.Scheduled)
+	require.False(t, monday.HasGap())
+
+	tuesday := coverage[1]
+	require.Equal(t, "2025-03-04", tuesday.Date)
+	require.Equal(t, day.AddDate(0, 0, 2).Add(7*time.Hour), tuesday.EndTime)
+	require.Equal(t, 1, tuesday.MinStaff)
+	require.Zero(t, tuesday.Scheduled)
+	require.True(t, tuesday.Understaffed)
+	require.Empty(t, tuesday.MissingCertifications)
+
+	// without the certified employee the Monday night lacks BHV
+	coverage = computeCoverage(day, day.AddDate(0, 0, 1), []db.LocationShift{night}, norms, schedules[:1])
+	require.True(t, coverage[0].Understaffed)
+	require.Equal(t, []CertificationShortage{{Name: "BHV", Required: 1}}, coverage[0].MissingCertifications)
+}
+
+func TestNormRequirements(t *testing.T) {
+	saturday := int16(time.Saturday)
+	norms := []db.StaffingNorm{
+		{LocationShiftID: 1, MinStaff: 2},
+		{LocationShiftID: 1, Weekday: &saturday, MinStaff: 3},
+		{LocationShiftID: 2, Weekday: &saturday, MinStaff: 1},
+	}
+
+	require.Equal(t, []ShiftRequirement{{LocationShiftID: 1, Staff: 2}}, normRequirements(norms, time.Monday))
+	require.Equal(t, []ShiftRequirement{
+		{LocationShiftID: 1, Staff: 3},
+		{LocationShiftID: 2, Staff: 1},
+	}, normRequirements(norms, time.Saturday))
+}
+
+func TestLocationPlanners(t *testing.T) {
+	here, elsewhere := int64(1), int64(2)
+	planners := []db.ListPermissionHoldersRow{
+		{UserID: 10, LocationID: &here},
+		{UserID: 11, LocationID: &elsewhere},
+		{UserID: 12},
+	}
+	require.Equal(t, []int64{10}, LocationPlanners(planners, here))
+	require.Equal(t, []int64{10, 11, 12}, LocationPlanners(planners, 3))
+}
+
+func TestNormalizeCertifications(t *testing.T) {
+	require.Equal(t, []CertificationRequirement{{Name: "BHV", Count: 2}}, normalizeCertifications([]CertificationRequirement{
+		{Name: " BHV", Count: 1},
+		{Name: "bhv", Count: 2},
+	}))
+}
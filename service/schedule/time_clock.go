@@ -0,0 +1,658 @@
+package schedule
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	db "maicare_go/db/sqlc"
+	"maicare_go/logger"
+	"maicare_go/util"
+	"net"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+const (
+	TimeEntryStatusOpen     = "open"
+	TimeEntryStatusPending  = "pending"
+	TimeEntryStatusApproved = "approved"
+	TimeEntryStatusRejected = "rejected"
+)
+
+// How an employee proved to be at the location when clocking in or out
+const (
+	ClockMethodNone = "none"
+	ClockMethodIP   = "ip"
+	ClockMethodQR   = "qr"
+)
+
+// DeviationTolerance is how far the registered times may differ from the planned times
+// before a manager has to approve them. Within the tolerance the planned times count.
+const DeviationTolerance = 15 * time.Minute
+
+// ClockInWindow is how long before the planned start employees can clock in
+const ClockInWindow = time.Hour
+
+var (
+	ErrScheduleNotFound     = errors.New("schedule not found")
+	ErrNotScheduledEmployee = errors.New("the schedule belongs to another employee")
+	ErrOutsideClockWindow   = errors.New("clocking in is possible from an hour before the start until the end of the shift")
+	ErrAlreadyClockedIn     = errors.New("already clocked in for this schedule")
+	ErrAlreadyClockedOut    = errors.New("already clocked out for this schedule")
+	ErrNotClockedIn         = errors.New("not clocked in for this schedule")
+	ErrLocationNotVerified  = errors.New("clock in and out from the network of the location or with its QR code")
+	ErrBreakRunning         = errors.New("a break is already running")
+	ErrNoBreakRunning       = errors.New("no break is running")
+	ErrTimeEntryNotFound    = errors.New("time entry not found")
+	ErrTimeEntryNotPending  = errors.New("only time entries waiting for approval can be approved or rejected")
+	ErrOwnTimeEntry         = errors.New("time entries cannot be approved or rejected by their own employee")
+	ErrInvalidTimeEntry     = errors.New("invalid time entry")
+	ErrInvalidIPRange       = errors.New("invalid IP range")
+)
+
+// ClockIn registers the start of the working time of a schedule of the employee
+func (s *scheduleService) ClockIn(ctx context.Context, scheduleID uuid.UUID, employeeID int64, req *ClockRequest, clientIP string) (*TimeEntryResponse, error) {
+	schedule, err := s.clockSchedule(ctx, "ClockIn", scheduleID, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	now := util.NetherlandsNow()
+	if now.Before(schedule.StartDatetime.Time.Add(-ClockInWindow)) || !now.Before(schedule.EndDatetime.Time) {
+		return nil, ErrOutsideClockWindow
+	}
+
+	_, err = s.Store.GetTimeEntryBySchedule(ctx, scheduleID)
+	if err == nil {
+		return nil, ErrAlreadyClockedIn
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ClockIn", "Failed to get time entry", zap.Error(err), zap.String("schedule_id", scheduleID.String()))
+		return nil, fmt.Errorf("failed to clock in")
+	}
+
+	method, err := s.verifyClockLocation(ctx, "ClockIn", schedule.LocationID, clientIP, req.QRToken)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := s.Store.CreateTimeEntry(ctx, db.CreateTimeEntryParams{
+		ScheduleID:    scheduleID,
+		EmployeeID:    employeeID,
+		ClockInAt:     pgtype.Timestamp{Time: now, Valid: true},
+		ClockInMethod: method,
+		ClockInIp:     optionalIP(clientIP),
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ClockIn", "Failed to create time entry", zap.Error(err), zap.String("schedule_id", scheduleID.String()))
+		return nil, fmt.Errorf("failed to clock in")
+	}
+
+	s.Logger.LogBusinessEvent(logger.LogLevelInfo, "ClockIn", "Employee clocked in", zap.Int64("employee_id", employeeID), zap.String("schedule_id", scheduleID.String()), zap.String("method", method))
+	return scheduleTimeEntryResponse(entry, schedule, nil), nil
+}
+
+// ClockOut registers the end of the working time of a schedule. A running break ends with
+// it. Times within the DeviationTolerance of the planned times are approved right away,
+// other times wait for the approval of a manager.
+func (s *scheduleService) ClockOut(ctx context.Context, scheduleID uuid.UUID, employeeID int64, req *ClockRequest, clientIP string) (*TimeEntryResponse, error) {
+	schedule, err := s.clockSchedule(ctx, "ClockOut", scheduleID, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := s.openTimeEntry(ctx, "ClockOut", scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	method, err := s.verifyClockLocation(ctx, "ClockOut", schedule.LocationID, clientIP, req.QRToken)
+	if err != nil {
+		return nil, err
+	}
+	now := util.NetherlandsNow()
+
+	tx, err := s.Store.ConnPool.Begin(ctx)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ClockOut", "Failed to begin transaction", zap.Error(err))
+		return nil, fmt.Errorf("failed to clock out")
+	}
+	defer func() {
+		if rbErr := tx.Rollback(ctx); rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+			s.Logger.LogBusinessEvent(logger.LogLevelError, "ClockOut", "Failed to rollback transaction", zap.Error(rbErr))
+		}
+	}()
+	qtx := s.Store.WithTx(tx)
+
+	_, err = qtx.EndTimeEntryBreak(ctx, db.EndTimeEntryBreakParams{
+		TimeEntryID: entry.ID,
+		EndAt:       pgtype.Timestamp{Time: now, Valid: true},
+	})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ClockOut", "Failed to end break", zap.Error(err), zap.Int64("time_entry_id", entry.ID))
+		return nil, fmt.Errorf("failed to clock out")
+	}
+	breaks, err := qtx.ListTimeEntryBreaks(ctx, entry.ID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ClockOut", "Failed to list breaks", zap.Error(err), zap.Int64("time_entry_id", entry.ID))
+		return nil, fmt.Errorf("failed to clock out")
+	}
+
+	actualStart, actualEnd, status := settleTimeEntry(schedule.StartDatetime.Time, schedule.EndDatetime.Time, entry.ClockInAt.Time, now)
+	entry, err = qtx.ClockOutTimeEntry(ctx, db.ClockOutTimeEntryParams{
+		ID:             entry.ID,
+		ClockOutAt:     pgtype.Timestamp{Time: now, Valid: true},
+		ClockOutMethod: &method,
+		ClockOutIp:     optionalIP(clientIP),
+		ActualStart:    pgtype.Timestamp{Time: actualStart, Valid: true},
+		ActualEnd:      pgtype.Timestamp{Time: actualEnd, Valid: true},
+		BreakMinutes:   breakMinutes(breaks, now),
+		Status:         status,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAlreadyClockedOut
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ClockOut", "Failed to clock out", zap.Error(err), zap.Int64("time_entry_id", entry.ID))
+		return nil, fmt.Errorf("failed to clock out")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ClockOut", "Failed to commit transaction", zap.Error(err))
+		return nil, fmt.Errorf("failed to clock out")
+	}
+
+	s.Logger.LogBusinessEvent(logger.LogLevelInfo, "ClockOut", "Employee clocked out", zap.Int64("employee_id", employeeID), zap.String("schedule_id", scheduleID.String()), zap.String("status", status))
+	return scheduleTimeEntryResponse(entry, schedule, breaks), nil
+}
+
+// StartBreak starts a break during a shift the employee is clocked in for
+func (s *scheduleService) StartBreak(ctx context.Context, scheduleID uuid.UUID, employeeID int64) (*TimeEntryResponse, error) {
+	schedule, err := s.clockSchedule(ctx, "StartBreak", scheduleID, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := s.openTimeEntry(ctx, "StartBreak", scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	breaks, err := s.Store.ListTimeEntryBreaks(ctx, entry.ID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "StartBreak", "Failed to list breaks", zap.Error(err), zap.Int64("time_entry_id", entry.ID))
+		return nil, fmt.Errorf("failed to start break")
+	}
+	for _, b := range breaks {
+		if !b.EndAt.Valid {
+			return nil, ErrBreakRunning
+		}
+	}
+
+	started, err := s.Store.StartTimeEntryBreak(ctx, db.StartTimeEntryBreakParams{
+		TimeEntryID: entry.ID,
+		StartAt:     pgtype.Timestamp{Time: util.NetherlandsNow(), Valid: true},
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "StartBreak", "Failed to start break", zap.Error(err), zap.Int64("time_entry_id", entry.ID))
+		return nil, fmt.Errorf("failed to start break")
+	}
+	return scheduleTimeEntryResponse(entry, schedule, append(breaks, started)), nil
+}
+
+// EndBreak ends the running break of a shift the employee is clocked in for
+func (s *scheduleService) EndBreak(ctx context.Context, scheduleID uuid.UUID, employeeID int64) (*TimeEntryResponse, error) {
+	schedule, err := s.clockSchedule(ctx, "EndBreak", scheduleID, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := s.openTimeEntry(ctx, "EndBreak", scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.Store.EndTimeEntryBreak(ctx, db.EndTimeEntryBreakParams{
+		TimeEntryID: entry.ID,
+		EndAt:       pgtype.Timestamp{Time: util.NetherlandsNow(), Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNoBreakRunning
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "EndBreak", "Failed to end break", zap.Error(err), zap.Int64("time_entry_id", entry.ID))
+		return nil, fmt.Errorf("failed to end break")
+	}
+	breaks, err := s.Store.ListTimeEntryBreaks(ctx, entry.ID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "EndBreak", "Failed to list breaks", zap.Error(err), zap.Int64("time_entry_id", entry.ID))
+		return nil, fmt.Errorf("failed to end break")
+	}
+	return scheduleTimeEntryResponse(entry, schedule, breaks), nil
+}
+
+// GetScheduleTimeEntry returns the time entry of a schedule with its breaks
+func (s *scheduleService) GetScheduleTimeEntry(ctx context.Context, scheduleID uuid.UUID) (*TimeEntryResponse, error) {
+	schedule, err := s.Store.GetScheduleById(ctx, scheduleID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrScheduleNotFound
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "GetScheduleTimeEntry", "Failed to get schedule", zap.Error(err), zap.String("schedule_id", scheduleID.String()))
+		return nil, fmt.Errorf("failed to get time entry")
+	}
+	entry, err := s.Store.GetTimeEntryBySchedule(ctx, scheduleID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTimeEntryNotFound
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "GetScheduleTimeEntry", "Failed to get time entry", zap.Error(err), zap.String("schedule_id", scheduleID.String()))
+		return nil, fmt.Errorf("failed to get time entry")
+	}
+	breaks, err := s.Store.ListTimeEntryBreaks(ctx, entry.ID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "GetScheduleTimeEntry", "Failed to list breaks", zap.Error(err), zap.Int64("time_entry_id", entry.ID))
+		return nil, fmt.Errorf("failed to get time entry")
+	}
+	return scheduleTimeEntryResponse(entry, schedule, breaks), nil
+}
+
+// ListTimeEntries lists the time entries of the schedules that start in a date range,
+// with their planned times
+func (s *scheduleService) ListTimeEntries(ctx context.Context, req *ListTimeEntriesRequest) ([]TimeEntryResponse, error) {
+	start, end, err := parseDateRange(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.Store.ListTimeEntries(ctx, db.ListTimeEntriesParams{
+		StartDate:  pgtype.Timestamp{Time: start, Valid: true},
+		EndDate:    pgtype.Timestamp{Time: end, Valid: true},
+		LocationID: req.LocationID,
+		EmployeeID: req.EmployeeID,
+		Status:     req.Status,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ListTimeEntries", "Failed to list time entries", zap.Error(err))
+		return nil, fmt.Errorf("failed to list time entries")
+	}
+
+	entries := make([]TimeEntryResponse, len(rows))
+	for i, row := range rows {
+		entry := newTimeEntryResponse(db.TimeEntry{
+			ID:                   row.ID,
+			ScheduleID:           row.ScheduleID,
+			EmployeeID:           row.EmployeeID,
+			ClockInAt:            row.ClockInAt,
+			ClockInMethod:        row.ClockInMethod,
+			ClockOutAt:           row.ClockOutAt,
+			ClockOutMethod:       row.ClockOutMethod,
+			ActualStart:          row.ActualStart,
+			ActualEnd:            row.ActualEnd,
+			BreakMinutes:         row.BreakMinutes,
+			Status:               row.Status,
+			ReviewNote:           row.ReviewNote,
+			ReviewedByEmployeeID: row.ReviewedByEmployeeID,
+			ReviewedAt:           row.ReviewedAt,
+		}, row.PlannedStart.Time, row.PlannedEnd.Time)
+		entry.EmployeeFirstName = row.EmployeeFirstName
+		entry.EmployeeLastName = row.EmployeeLastName
+		entry.LocationID = row.LocationID
+		entry.LocationName = row.LocationName
+		entries[i] = *entry
+	}
+	return entries, nil
+}
+
+// ApproveTimeEntry approves the deviation of a time entry, with corrected times if given
+func (s *scheduleService) ApproveTimeEntry(ctx context.Context, timeEntryID, employeeID int64, req *ApproveTimeEntryRequest) (*TimeEntryResponse, error) {
+	entry, schedule, err := s.pendingTimeEntry(ctx, "ApproveTimeEntry", timeEntryID, employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end, minutes := entry.ActualStart.Time, entry.ActualEnd.Time, entry.BreakMinutes
+	if req.ActualStart != nil {
+		start = asStored(*req.ActualStart)
+	}
+	if req.ActualEnd != nil {
+		end = asStored(*req.ActualEnd)
+	}
+	if req.BreakMinutes != nil {
+		minutes = *req.BreakMinutes
+	}
+	if !end.After(start) || time.Duration(minutes)*time.Minute >= end.Sub(start) {
+		return nil, fmt.Errorf("%w: the end must be after the start and the break shorter than the time worked", ErrInvalidTimeEntry)
+	}
+
+	return s.reviewTimeEntry(ctx, "ApproveTimeEntry", schedule, db.ReviewTimeEntryParams{
+		ID:                   entry.ID,
+		Status:               TimeEntryStatusApproved,
+		ActualStart:          pgtype.Timestamp{Time: start, Valid: true},
+		ActualEnd:            pgtype.Timestamp{Time: end, Valid: true},
+		BreakMinutes:         minutes,
+		ReviewNote:           req.Note,
+		ReviewedByEmployeeID: &employeeID,
+	})
+}
+
+// RejectTimeEntry rejects the deviation of a time entry, the planned times count instead
+func (s *scheduleService) RejectTimeEntry(ctx context.Context, timeEntryID, employeeID int64, req *RejectTimeEntryRequest) (*TimeEntryResponse, error) {
+	entry, schedule, err := s.pendingTimeEntry(ctx, "RejectTimeEntry", timeEntryID, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	return s.reviewTimeEntry(ctx, "RejectTimeEntry", schedule, db.ReviewTimeEntryParams{
+		ID:                   entry.ID,
+		Status:               TimeEntryStatusRejected,
+		ActualStart:          entry.ActualStart,
+		ActualEnd:            entry.ActualEnd,
+		BreakMinutes:         entry.BreakMinutes,
+		ReviewNote:           &req.Reason,
+		ReviewedByEmployeeID: &employeeID,
+	})
+}
+
+func (s *scheduleService) GetLocationClockSettings(ctx context.Context, locationID int64) (*LocationClockSettingsResponse, error) {
+	settings, err := s.Store.GetLocationClockSettings(ctx, locationID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &LocationClockSettingsResponse{LocationID: locationID, IPRanges: []string{}}, nil
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "GetLocationClockSettings", "Failed to get clock settings", zap.Error(err), zap.Int64("location_id", locationID))
+		return nil, fmt.Errorf("failed to get clock settings")
+	}
+	return clockSettingsResponse(settings), nil
+}
+
+// UpdateLocationClockSettings sets the networks employees of the location clock in from
+func (s *scheduleService) UpdateLocationClockSettings(ctx context.Context, locationID int64, req *UpdateLocationClockSettingsRequest) (*LocationClockSettingsResponse, error) {
+	ranges := make([]string, 0, len(req.IPRanges))
+	for _, r := range req.IPRanges {
+		network, err := parseIPRange(r)
+		if err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, network.String())
+	}
+
+	settings, err := s.Store.UpsertLocationClockIPRanges(ctx, db.UpsertLocationClockIPRangesParams{
+		LocationID: locationID,
+		IpRanges:   ranges,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "UpdateLocationClockSettings", "Failed to save clock settings", zap.Error(err), zap.Int64("location_id", locationID))
+		return nil, fmt.Errorf("failed to update clock settings")
+	}
+	return clockSettingsResponse(settings), nil
+}
+
+// RotateLocationClockQRToken gives the location a new QR code, the old code stops working
+func (s *scheduleService) RotateLocationClockQRToken(ctx context.Context, locationID int64) (*LocationClockSettingsResponse, error) {
+	random := make([]byte, 24)
+	if _, err := rand.Read(random); err != nil {
+		return nil, fmt.Errorf("failed to create QR code")
+	}
+	token := hex.EncodeToString(random)
+	return s.setLocationClockQRToken(ctx, "RotateLocationClockQRToken", locationID, &token)
+}
+
+// RemoveLocationClockQRToken stops clocking in with the QR code of the location
+func (s *scheduleService) RemoveLocationClockQRToken(ctx context.Context, locationID int64) (*LocationClockSettingsResponse, error) {
+	return s.setLocationClockQRToken(ctx, "RemoveLocationClockQRToken", locationID, nil)
+}
+
+func (s *scheduleService) setLocationClockQRToken(ctx context.Context, operation string, locationID int64, token *string) (*LocationClockSettingsResponse, error) {
+	settings, err := s.Store.UpsertLocationClockQrToken(ctx, db.UpsertLocationClockQrTokenParams{
+		LocationID: locationID,
+		QrToken:    token,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, operation, "Failed to save QR code", zap.Error(err), zap.Int64("location_id", locationID))
+		return nil, fmt.Errorf("failed to update clock settings")
+	}
+	return clockSettingsResponse(settings), nil
+}
+
+// clockSchedule returns a schedule an employee clocks for
+func (s *scheduleService) clockSchedule(ctx context.Context, operation string, scheduleID uuid.UUID, employeeID int64) (db.GetScheduleByIdRow, error) {
+	schedule, err := s.Store.GetScheduleById(ctx, scheduleID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return schedule, ErrScheduleNotFound
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, operation, "Failed to get schedule", zap.Error(err), zap.String("schedule_id", scheduleID.String()))
+		return schedule, fmt.Errorf("failed to get schedule")
+	}
+	if schedule.EmployeeID == nil || *schedule.EmployeeID != employeeID {
+		return schedule, ErrNotScheduledEmployee
+	}
+	return schedule, nil
+}
+
+// openTimeEntry returns the time entry of a schedule the employee is clocked in for
+func (s *scheduleService) openTimeEntry(ctx context.Context, operation string, scheduleID uuid.UUID) (db.TimeEntry, error) {
+	entry, err := s.Store.GetTimeEntryBySchedule(ctx, scheduleID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entry, ErrNotClockedIn
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, operation, "Failed to get time entry", zap.Error(err), zap.String("schedule_id", scheduleID.String()))
+		return entry, fmt.Errorf("failed to get time entry")
+	}
+	if entry.Status != TimeEntryStatusOpen {
+		return entry, ErrAlreadyClockedOut
+	}
+	return entry, nil
+}
+
+// pendingTimeEntry returns a time entry a manager can approve or reject
+func (s *scheduleService) pendingTimeEntry(ctx context.Context, operation string, timeEntryID, employeeID int64) (db.TimeEntry, db.GetScheduleByIdRow, error) {
+	var schedule db.GetScheduleByIdRow
+	entry, err := s.Store.GetTimeEntry(ctx, timeEntryID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entry, schedule, ErrTimeEntryNotFound
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, operation, "Failed to get time entry", zap.Error(err), zap.Int64("time_entry_id", timeEntryID))
+		return entry, schedule, fmt.Errorf("failed to get time entry")
+	}
+	if entry.Status != TimeEntryStatusPending {
+		return entry, schedule, ErrTimeEntryNotPending
+	}
+	if entry.EmployeeID == employeeID {
+		return entry, schedule, ErrOwnTimeEntry
+	}
+	schedule, err = s.Store.GetScheduleById(ctx, entry.ScheduleID)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, operation, "Failed to get schedule", zap.Error(err), zap.Int64("time_entry_id", timeEntryID))
+		return entry, schedule, fmt.Errorf("failed to get time entry")
+	}
+	return entry, schedule, nil
+}
+
+func (s *scheduleService) reviewTimeEntry(ctx context.Context, operation string, schedule db.GetScheduleByIdRow, arg db.ReviewTimeEntryParams) (*TimeEntryResponse, error) {
+	entry, err := s.Store.ReviewTimeEntry(ctx, arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTimeEntryNotPending
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, operation, "Failed to review time entry", zap.Error(err), zap.Int64("time_entry_id", arg.ID))
+		return nil, fmt.Errorf("failed to review time entry")
+	}
+	s.Logger.LogBusinessEvent(logger.LogLevelInfo, operation, "Time entry reviewed", zap.Int64("time_entry_id", entry.ID), zap.String("status", entry.Status))
+	return scheduleTimeEntryResponse(entry, schedule, nil), nil
+}
+
+// verifyClockLocation checks that a clock event comes from the location and returns how
+func (s *scheduleService) verifyClockLocation(ctx context.Context, operation string, locationID int64, clientIP string, qrToken *string) (string, error) {
+	settings, err := s.Store.GetLocationClockSettings(ctx, locationID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ClockMethodNone, nil
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, operation, "Failed to get clock settings", zap.Error(err), zap.Int64("location_id", locationID))
+		return "", fmt.Errorf("failed to verify location")
+	}
+	return verifyClockLocation(settings, clientIP, qrToken)
+}
+
+// verifyClockLocation accepts a clock event with the QR token of the location or from one
+// of its networks. Locations without either accept any clock event.
+func verifyClockLocation(settings db.LocationClockSetting, clientIP string, qrToken *string) (string, error) {
+	if len(settings.IpRanges) == 0 && settings.QrToken == nil {
+		return ClockMethodNone, nil
+	}
+	if settings.QrToken != nil && qrToken != nil && subtle.ConstantTimeCompare([]byte(*settings.QrToken), []byte(*qrToken)) == 1 {
+		return ClockMethodQR, nil
+	}
+	if ip := net.ParseIP(clientIP); ip != nil {
+		for _, r := range settings.IpRanges {
+			if network, err := parseIPRange(r); err == nil && network.Contains(ip) {
+				return ClockMethodIP, nil
+			}
+		}
+	}
+	return "", ErrLocationNotVerified
+}
+
+// parseIPRange parses a CIDR range or a single IP address
+func parseIPRange(value string) (*net.IPNet, error) {
+	if _, network, err := net.ParseCIDR(value); err == nil {
+		return network, nil
+	}
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidIPRange, value)
+	}
+	bits := 128
+	if ip.To4() != nil {
+		ip, bits = ip.To4(), 32
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+}
+
+// settleTimeEntry returns the times that count for the registered times of a shift and
+// whether they need approval. A start or end within the DeviationTolerance of the planned
+// time counts as planned.
+func settleTimeEntry(plannedStart, plannedEnd, clockIn, clockOut time.Time) (time.Time, time.Time, string) {
+	start, end := clockIn, clockOut
+	status := TimeEntryStatusApproved
+	if within(clockIn, plannedStart) {
+		start = plannedStart
+	} else {
+		status = TimeEntryStatusPending
+	}
+	if within(clockOut, plannedEnd) {
+		end = plannedEnd
+	} else {
+		status = TimeEntryStatusPending
+	}
+	if end.Before(start) {
+		return clockIn, clockOut, TimeEntryStatusPending
+	}
+	return start, end, status
+}
+
+func within(t, planned time.Time) bool {
+	d := t.Sub(planned)
+	return d >= -DeviationTolerance && d <= DeviationTolerance
+}
+
+// breakMinutes is the time of the breaks in whole minutes, a running break lasts until now
+func breakMinutes(breaks []db.TimeEntryBreak, now time.Time) int32 {
+	var total time.Duration
+	for _, b := range breaks {
+		end := now
+		if b.EndAt.Valid {
+			end = b.EndAt.Time
+		}
+		total += end.Sub(b.StartAt.Time)
+	}
+	return int32(total / time.Minute)
+}
+
+func optionalIP(ip string) *string {
+	if ip == "" {
+		return nil
+	}
+	return &ip
+}
+
+func scheduleTimeEntryResponse(entry db.TimeEntry, schedule db.GetScheduleByIdRow, breaks []db.TimeEntryBreak) *TimeEntryResponse {
+	resp := newTimeEntryResponse(entry, schedule.StartDatetime.Time, schedule.EndDatetime.Time)
+	resp.EmployeeFirstName = util.DerefString(schedule.EmployeeFirstName)
+	resp.EmployeeLastName = util.DerefString(schedule.EmployeeLastName)
+	resp.LocationID = schedule.LocationID
+	resp.LocationName = schedule.LocationName
+	if breaks != nil {
+		resp.Breaks = make([]TimeEntryBreakResponse, len(breaks))
+		now := util.NetherlandsNow()
+		for i, b := range breaks {
+			resp.Breaks[i] = TimeEntryBreakResponse{
+				ID:      b.ID,
+				StartAt: b.StartAt.Time,
+				Minutes: breakMinutes(breaks[i:i+1], now),
+			}
+			if b.EndAt.Valid {
+				resp.Breaks[i].EndAt = &b.EndAt.Time
+			}
+		}
+	}
+	return resp
+}
+
+func newTimeEntryResponse(entry db.TimeEntry, plannedStart, plannedEnd time.Time) *TimeEntryResponse {
+	resp := &TimeEntryResponse{
+		ID:                   entry.ID,
+		ScheduleID:           entry.ScheduleID,
+		EmployeeID:           entry.EmployeeID,
+		PlannedStart:         plannedStart,
+		PlannedEnd:           plannedEnd,
+		PlannedMinutes:       minutes(plannedEnd.Sub(plannedStart)),
+		ClockInAt:            entry.ClockInAt.Time,
+		ClockInMethod:        entry.ClockInMethod,
+		ClockOutMethod:       entry.ClockOutMethod,
+		BreakMinutes:         entry.BreakMinutes,
+		Status:               entry.Status,
+		ReviewNote:           entry.ReviewNote,
+		ReviewedByEmployeeID: entry.ReviewedByEmployeeID,
+	}
+	if entry.ClockOutAt.Valid {
+		resp.ClockOutAt = &entry.ClockOutAt.Time
+		resp.Deviation = &TimeDeviation{
+			StartMinutes:  minutes(entry.ClockInAt.Time.Sub(plannedStart)),
+			EndMinutes:    minutes(entry.ClockOutAt.Time.Sub(plannedEnd)),
+			WorkedMinutes: minutes(entry.ClockOutAt.Time.Sub(entry.ClockInAt.Time)) - entry.BreakMinutes - resp.PlannedMinutes,
+		}
+	}
+	if entry.ActualStart.Valid && entry.ActualEnd.Valid {
+		resp.ActualStart = &entry.ActualStart.Time
+		resp.ActualEnd = &entry.ActualEnd.Time
+		worked := minutes(entry.ActualEnd.Time.Sub(entry.ActualStart.Time)) - entry.BreakMinutes
+		resp.WorkedMinutes = &worked
+	}
+	if entry.ReviewedAt.Valid {
+		resp.ReviewedAt = &entry.ReviewedAt.Time
+	}
+	return resp
+}
+
+func clockSettingsResponse(settings db.LocationClockSetting) *LocationClockSettingsResponse {
+	resp := &LocationClockSettingsResponse{
+		LocationID: settings.LocationID,
+		IPRanges:   settings.IpRanges,
+		QRToken:    settings.QrToken,
+	}
+	if resp.IPRanges == nil {
+		resp.IPRanges = []string{}
+	}
+	if settings.UpdatedAt.Valid {
+		resp.UpdatedAt = &settings.UpdatedAt.Time
+	}
+	return resp
+}
+
+func minutes(d time.Duration) int32 {
+	return int32(d / time.Minute)
+}
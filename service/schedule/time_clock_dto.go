@@ -0,0 +1,132 @@
+package schedule
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ClockRequest represents a clock or break event of an employee. The QR token is the
+// content of the QR code shown at the location, when the employee scanned it.
+type ClockRequest struct {
+	QRToken *string `json:"qr_token"`
+}
+
+// TimeEntryBreakResponse is a break registered during a shift, EndAt is nil while it runs
+type TimeEntryBreakResponse struct {
+	ID      int64      `json:"id"`
+	StartAt time.Time  `json:"start_at"`
+	EndAt   *time.Time `json:"end_at"`
+	Minutes int32      `json:"minutes"`
+}
+
+// TimeDeviation compares the registered times with the planned times in minutes. A
+// positive StartMinutes is a late start, a positive EndMinutes a late end and a positive
+// WorkedMinutes more time worked than planned.
+type TimeDeviation struct {
+	StartMinutes  int32 `json:"start_minutes"`
+	EndMinutes    int32 `json:"end_minutes"`
+	WorkedMinutes int32 `json:"worked_minutes"`
+}
+
+// TimeEntryResponse is the registered working time of a schedule next to the planned time
+type TimeEntryResponse struct {
+	ID                   int64                    `json:"id"`
+	ScheduleID           uuid.UUID                `json:"schedule_id"`
+	EmployeeID           int64                    `json:"employee_id"`
+	EmployeeFirstName    string                   `json:"employee_first_name,omitempty"`
+	EmployeeLastName     string                   `json:"employee_last_name,omitempty"`
+	LocationID           int64                    `json:"location_id"`
+	LocationName         string                   `json:"location_name,omitempty"`
+	PlannedStart         time.Time                `json:"planned_start"`
+	PlannedEnd           time.Time                `json:"planned_end"`
+	PlannedMinutes       int32                    `json:"planned_minutes"`
+	ClockInAt            time.Time                `json:"clock_in_at"`
+	ClockInMethod        string                   `json:"clock_in_method"`
+	ClockOutAt           *time.Time               `json:"clock_out_at"`
+	ClockOutMethod       *string                  `json:"clock_out_method"`
+	ActualStart          *time.Time               `json:"actual_start"`
+	ActualEnd            *time.Time               `json:"actual_end"`
+	BreakMinutes         int32                    `json:"break_minutes"`
+	WorkedMinutes        *int32                   `json:"worked_minutes"`
+	Deviation            *TimeDeviation           `json:"deviation"`
+	Status               string                   `json:"status"`
+	ReviewNote           *string                  `json:"review_note"`
+	ReviewedByEmployeeID *int64                   `json:"reviewed_by_employee_id"`
+	ReviewedAt           *time.Time               `json:"reviewed_at"`
+	Breaks               []TimeEntryBreakResponse `json:"breaks,omitempty"`
+}
+
+// ListTimeEntriesRequest represents the query for time entries of schedules that start in
+// a date range, dates are YYYY-MM-DD and the end date is included
+type ListTimeEntriesRequest struct {
+	StartDate  string  `form:"start_date" binding:"required" example:"2025-03-01"`
+	EndDate    string  `form:"end_date" binding:"required" example:"2025-03-31"`
+	LocationID *int64  `form:"location_id"`
+	EmployeeID *int64  `form:"employee_id"`
+	Status     *string `form:"status" binding:"omitempty,oneof=open pending approved rejected"`
+}
+
+// ApproveTimeEntryRequest represents the approval of a deviation. The times and break
+// can be corrected, the registered values are kept when they are left out.
+type ApproveTimeEntryRequest struct {
+	ActualStart  *time.Time `json:"actual_start"`
+	ActualEnd    *time.Time `json:"actual_end"`
+	BreakMinutes *int32     `json:"break_minutes" binding:"omitempty,min=0"`
+	Note         *string    `json:"note"`
+}
+
+// RejectTimeEntryRequest represents the rejection of a deviation, the planned times count
+// instead
+type RejectTimeEntryRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// LocationClockSettingsResponse represents how employees prove they are at a location
+type LocationClockSettingsResponse struct {
+	LocationID int64      `json:"location_id"`
+	IPRanges   []string   `json:"ip_ranges"`
+	QRToken    *string    `json:"qr_token"`
+	UpdatedAt  *time.Time `json:"updated_at"`
+}
+
+// UpdateLocationClockSettingsRequest sets the networks of a location, as CIDR ranges or
+// single IP addresses. An empty list removes the IP check.
+type UpdateLocationClockSettingsRequest struct {
+	IPRanges []string `json:"ip_ranges" binding:"omitempty,dive,required" example:"192.168.1.0/24"`
+}
+
+// PayrollRequest represents the month of a payroll report, YYYY-MM
+type PayrollRequest struct {
+	Month string `form:"month" binding:"required" example:"2025-03"`
+}
+
+// PayrollEmployee are the hours of an employee in a month. Worked hours are the approved
+// registered hours, or the planned hours of shifts without an approved time entry.
+type PayrollEmployee struct {
+	EmployeeID         int64    `json:"employee_id"`
+	FirstName          string   `json:"first_name"`
+	LastName           string   `json:"last_name"`
+	ContractType       *string  `json:"contract_type"`
+	ContractHours      *float64 `json:"contract_hours"`
+	Shifts             int      `json:"shifts"`
+	PlannedHours       float64  `json:"planned_hours"`
+	WorkedHours        float64  `json:"worked_hours"`
+	ApprovedShifts     int      `json:"approved_shifts"`
+	UnapprovedShifts   int      `json:"unapproved_shifts"`
+	RejectedShifts     int      `json:"rejected_shifts"`
+	UnregisteredShifts int      `json:"unregistered_shifts"`
+}
+
+// PayrollResponse are the hours of the employees on the payroll in a month
+type PayrollResponse struct {
+	Month     string            `json:"month"`
+	Employees []PayrollEmployee `json:"employees"`
+}
+
+// PayrollExport is the payroll report of a month as a CSV file
+type PayrollExport struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
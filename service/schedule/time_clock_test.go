@@ -0,0 +1,113 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	db "maicare_go/db/sqlc"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettleTimeEntry(t *testing.T) {
+	start := time.Date(2025, 3, 3, 7, 0, 0, 0, time.UTC)
+	end := start.Add(8 * time.Hour)
+
+	// clocking within the tolerance settles on the planned times
+	actualStart, actualEnd, status := settleTimeEntry(start, end, start.Add(-10*time.Minute), end.Add(5*time.Minute))
+	require.Equal(t, TimeEntryStatusApproved, status)
+	require.Equal(t, start, actualStart)
+	require.Equal(t, end, actualEnd)
+
+	// leaving an hour early needs the approval of a manager
+	actualStart, actualEnd, status = settleTimeEntry(start, end, start.Add(2*time.Minute), end.Add(-time.Hour))
+	require.Equal(t, TimeEntryStatusPending, status)
+	require.Equal(t, start, actualStart)
+	require.Equal(t, end.Add(-time.Hour), actualEnd)
+
+	// snapping may not put the end before the start
+	clockIn, clockOut := end.Add(5*time.Minute), end.Add(10*time.Minute)
+	actualStart, actualEnd, status = settleTimeEntry(start, end, clockIn, clockOut)
+	require.Equal(t, TimeEntryStatusPending, status)
+	require.Equal(t, clockIn, actualStart)
+	require.Equal(t, clockOut, actualEnd)
+}
+
+func TestVerifyClockLocation(t *testing.T) {
+	method, err := verifyClockLocation(db.LocationClockSetting{}, "203.0.113.7", nil)
+	require.NoError(t, err)
+	require.Equal(t, ClockMethodNone, method)
+
+	token := "secret"
+	wrong := "guess"
+	settings := db.LocationClockSetting{IpRanges: []string{"203.0.113.0/24", "2001:db8::1"}, QrToken: &token}
+
+	method, err = verifyClockLocation(settings, "203.0.113.7", nil)
+	require.NoError(t, err)
+	require.Equal(t, ClockMethodIP, method)
+
+	method, err = verifyClockLocation(settings, "2001:db8::1", nil)
+	require.NoError(t, err)
+	require.Equal(t, ClockMethodIP, method)
+
+	method, err = verifyClockLocation(settings, "198.51.100.1", &token)
+	require.NoError(t, err)
+	require.Equal(t, ClockMethodQR, method)
+
+	_, err = verifyClockLocation(settings, "198.51.100.1", &wrong)
+	require.ErrorIs(t, err, ErrLocationNotVerified)
+}
+
+func TestParseIPRange(t *testing.T) {
+	network, err := parseIPRange("10.0.0.5")
+	require.NoError(t, err)
+	require.Equal(t, "10.0.0.5/32", network.String())
+
+	_, err = parseIPRange("10.0.0.0/33")
+	require.ErrorIs(t, err, ErrInvalidIPRange)
+}
+
+func TestBreakMinutes(t *testing.T) {
+	start := time.Date(2025, 3, 3, 12, 0, 0, 0, time.UTC)
+	breaks := []db.TimeEntryBreak{
+		{StartAt: ts(start), EndAt: ts(start.Add(30 * time.Minute))},
+		// a running break counts up to now
+		{StartAt: ts(start.Add(3 * time.Hour))},
+	}
+	require.Equal(t, int32(45), breakMinutes(breaks, start.Add(3*time.Hour+15*time.Minute)))
+}
+
+func TestBuildPayroll(t *testing.T) {
+	day := time.Date(2025, 3, 3, 7, 0, 0, 0, time.UTC)
+	hours := 32.0
+	approved, pending := TimeEntryStatusApproved, TimeEntryStatusPending
+	breaks := int32(30)
+	rows := []db.ListPayrollShiftsRow{
+		{
+			EmployeeID: 1, FirstName: "Sam", ContractHours: &hours,
+			StartDatetime: ts(day), EndDatetime: ts(day.Add(8 * time.Hour)),
+			TimeEntryStatus: &approved, ActualStart: ts(day), ActualEnd: ts(day.Add(9 * time.Hour)), BreakMinutes: &breaks,
+		},
+		{
+			EmployeeID: 1, FirstName: "Sam", ContractHours: &hours,
+			StartDatetime: ts(day.AddDate(0, 0, 1)), EndDatetime: ts(day.AddDate(0, 0, 1).Add(8 * time.Hour)),
+			TimeEntryStatus: &pending, ActualStart: ts(day.AddDate(0, 0, 1)), ActualEnd: ts(day.AddDate(0, 0, 1).Add(4 * time.Hour)),
+		},
+		{
+			EmployeeID: 2, FirstName: "Kim",
+			StartDatetime: ts(day), EndDatetime: ts(day.Add(6 * time.Hour)),
+		},
+	}
+
+	employees := buildPayroll(rows)
+	require.Len(t, employees, 2)
+
+	require.Equal(t, 2, employees[0].Shifts)
+	require.Equal(t, 16.0, employees[0].PlannedHours)
+	require.Equal(t, 16.5, employees[0].WorkedHours)
+	require.Equal(t, 1, employees[0].ApprovedShifts)
+	require.Equal(t, 1, employees[0].UnapprovedShifts)
+
+	require.Equal(t, 6.0, employees[1].WorkedHours)
+	require.Equal(t, 1, employees[1].UnregisteredShifts)
+}
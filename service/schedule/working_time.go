@@ -0,0 +1,495 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	db "maicare_go/db/sqlc"
+	"maicare_go/logger"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+// Rules of the Arbeidstijdenwet that are checked
+const (
+	RuleShiftLength          = "shift_length"
+	RuleNightShiftLength     = "night_shift_length"
+	RuleWeekHours            = "week_hours"
+	RuleAverageWeekHours     = "average_week_hours"
+	RuleDailyRest            = "daily_rest"
+	RuleWeeklyRest           = "weekly_rest"
+	RuleConsecutiveNights    = "consecutive_night_shifts"
+	RuleNightShifts16Weeks   = "night_shifts_16_weeks"
+	RuleRestAfterNightShifts = "rest_after_night_shifts"
+)
+
+// DefaultContractType is the contract type of employees without one
+const DefaultContractType = "none"
+
+const (
+	oneWeek = 7 * 24 * time.Hour
+	// averages are taken over 16 weeks
+	referenceWeeks = 16
+	// a series of this many night shifts needs the longer rest after it
+	nightSeries = 3
+)
+
+var ErrWorkingTimeRulesNotFound = errors.New("working time rules not found")
+
+// WorkingTimeRules are the limits of the Arbeidstijdenwet for a contract type
+type WorkingTimeRules struct {
+	ContractType            string
+	Exempt                  bool
+	MaxShift                time.Duration
+	MaxWeek                 time.Duration
+	MaxAverageWeek          time.Duration
+	MinDailyRest            time.Duration
+	MinWeeklyRest           time.Duration
+	MaxNightShift           time.Duration
+	MaxConsecutiveNights    int
+	MaxNights16Weeks        int
+	MinRestAfterNightSeries time.Duration
+}
+
+// workPeriod is a shift of an employee, ScheduleID is nil for a shift that is not saved yet
+type workPeriod struct {
+	ScheduleID *uuid.UUID
+	Start      time.Time
+	End        time.Time
+}
+
+func (s *scheduleService) ListWorkingTimeRules(ctx context.Context) ([]WorkingTimeRulesResponse, error) {
+	rows, err := s.Store.ListWorkingTimeRules(ctx)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "ListWorkingTimeRules", "Failed to list working time rules", zap.Error(err))
+		return nil, fmt.Errorf("failed to list working time rules")
+	}
+	rules := make([]WorkingTimeRulesResponse, len(rows))
+	for i, row := range rows {
+		rules[i] = *workingTimeRulesResponse(row)
+	}
+	return rules, nil
+}
+
+func (s *scheduleService) UpdateWorkingTimeRules(ctx context.Context, contractType string, req *UpdateWorkingTimeRulesRequest) (*WorkingTimeRulesResponse, error) {
+	row, err := s.Store.UpdateWorkingTimeRules(ctx, db.UpdateWorkingTimeRulesParams{
+		ContractType:                   contractType,
+		Exempt:                         req.Exempt,
+		MaxShiftMinutes:                req.MaxShiftMinutes,
+		MaxWeekMinutes:                 req.MaxWeekMinutes,
+		MaxAverageWeekMinutes:          req.MaxAverageWeekMinutes,
+		MinDailyRestMinutes:            req.MinDailyRestMinutes,
+		MinWeeklyRestMinutes:           req.MinWeeklyRestMinutes,
+		MaxNightShiftMinutes:           req.MaxNightShiftMinutes,
+		MaxConsecutiveNightShifts:      req.MaxConsecutiveNightShifts,
+		MaxNightShifts16Weeks:          req.MaxNightShifts16Weeks,
+		MinRestAfterNightSeriesMinutes: req.MinRestAfterNightSeriesMinutes,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrWorkingTimeRulesNotFound
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "UpdateWorkingTimeRules", "Failed to update working time rules", zap.Error(err), zap.String("contract_type", contractType))
+		return nil, fmt.Errorf("failed to update working time rules")
+	}
+	s.Logger.LogBusinessEvent(logger.LogLevelInfo, "UpdateWorkingTimeRules", "Working time rules updated", zap.String("contract_type", contractType))
+	return workingTimeRulesResponse(row), nil
+}
+
+// GetWorkingTimeCompliance checks the shifts of the employees that work in a period
+// against the rules of their contract type. Shifts before and after the period are taken
+// into account for rest and averages, but only violations within the period are listed.
+func (s *scheduleService) GetWorkingTimeCompliance(ctx context.Context, req *WorkingTimeComplianceRequest) (*WorkingTimeComplianceResponse, error) {
+	start, end, err := parseDateRange(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	employees, err := s.Store.ListComplianceEmployees(ctx, db.ListComplianceEmployeesParams{
+		StartDate:  pgtype.Timestamp{Time: start, Valid: true},
+		EndDate:    pgtype.Timestamp{Time: end, Valid: true},
+		LocationID: req.LocationID,
+		EmployeeID: req.EmployeeID,
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "GetWorkingTimeCompliance", "Failed to list employees", zap.Error(err))
+		return nil, fmt.Errorf("failed to check working time compliance")
+	}
+	rules, err := s.workingTimeRules(ctx, "GetWorkingTimeCompliance")
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &WorkingTimeComplianceResponse{
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+		Employees: []EmployeeCompliance{},
+	}
+	if len(employees) == 0 {
+		return resp, nil
+	}
+	employeeIDs := make([]int64, len(employees))
+	for i, e := range employees {
+		employeeIDs[i] = e.ID
+	}
+	periods, err := s.workPeriods(ctx, "GetWorkingTimeCompliance", employeeIDs, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range employees {
+		item := EmployeeCompliance{
+			EmployeeID:   e.ID,
+			FirstName:    e.FirstName,
+			LastName:     e.LastName,
+			ContractType: e.ContractType,
+			Violations:   []WorkingTimeViolation{},
+		}
+		var worked time.Duration
+		for _, p := range periods[e.ID] {
+			if p.Start.Before(start) || !p.Start.Before(end) {
+				continue
+			}
+			item.Shifts++
+			worked += p.End.Sub(p.Start)
+			if isNightShift(p.Start, p.End) {
+				item.NightShifts++
+			}
+		}
+		item.Hours = roundHours(worked.Hours())
+
+		r, ok := rules[e.ContractType]
+		item.Exempt = !ok || r.Exempt
+		if !item.Exempt {
+			item.Violations = append(item.Violations, checkWorkingTime(r, periods[e.ID], start, end)...)
+		}
+		for _, v := range item.Violations {
+			if v.Severity == SeverityError {
+				item.Errors++
+			} else {
+				item.Warnings++
+			}
+		}
+		resp.Errors += item.Errors
+		resp.Warnings += item.Warnings
+		resp.Employees = append(resp.Employees, item)
+	}
+	return resp, nil
+}
+
+// workingTimeConflicts returns the violations of the working time rules of the employee
+// that saving the slot would cause or make worse
+func (s *scheduleService) workingTimeConflicts(ctx context.Context, slot Slot) ([]Conflict, error) {
+	contract, err := s.Store.GetEmployeeContractDetails(ctx, slot.EmployeeID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		s.Logger.LogBusinessEvent(logger.LogLevelError, "CheckScheduleConflicts", "Failed to get contract details", zap.Error(err), zap.Int64("employee_id", slot.EmployeeID))
+		return nil, fmt.Errorf("failed to check schedule conflicts")
+	}
+	rules, err := s.workingTimeRules(ctx, "CheckScheduleConflicts")
+	if err != nil {
+		return nil, err
+	}
+	r, ok := rules[contractType(contract.ContractType)]
+	if !ok || r.Exempt {
+		return nil, nil
+	}
+
+	from, to := slot.Start.Add(-oneWeek), slot.End.Add(oneWeek)
+	periods, err := s.workPeriods(ctx, "CheckScheduleConflicts", []int64{slot.EmployeeID}, from, to)
+	if err != nil {
+		return nil, err
+	}
+	var others []workPeriod
+	for _, p := range periods[slot.EmployeeID] {
+		if p.ScheduleID == nil || !slot.skips(*p.ScheduleID) {
+			others = append(others, p)
+		}
+	}
+
+	violations := newWorkingTimeViolations(r, others, workPeriod{ScheduleID: slot.ScheduleID, Start: slot.Start, End: slot.End})
+	conflicts := make([]Conflict, len(violations))
+	for i, v := range violations {
+		conflicts[i] = Conflict{
+			Kind:       ConflictWorkingTime,
+			Severity:   v.Severity,
+			EmployeeID: slot.EmployeeID,
+			ScheduleID: v.ScheduleID,
+			StartTime:  v.StartTime,
+			EndTime:    v.EndTime,
+			Message:    v.Message,
+			Rule:       v.Rule,
+		}
+	}
+	return conflicts, nil
+}
+
+// workingTimeRules returns the rules by contract type
+func (s *scheduleService) workingTimeRules(ctx context.Context, operation string) (map[string]WorkingTimeRules, error) {
+	rows, err := s.Store.ListWorkingTimeRules(ctx)
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, operation, "Failed to list working time rules", zap.Error(err))
+		return nil, fmt.Errorf("failed to check working time rules")
+	}
+	rules := make(map[string]WorkingTimeRules, len(rows))
+	for _, row := range rows {
+		rules[row.ContractType] = workingTimeRules(row)
+	}
+	return rules, nil
+}
+
+// workPeriods returns the shifts of the employees around a period, far enough back for
+// the 16 week averages and far enough ahead for the weekly rest
+func (s *scheduleService) workPeriods(ctx context.Context, operation string, employeeIDs []int64, start, end time.Time) (map[int64][]workPeriod, error) {
+	rows, err := s.Store.ListEmployeesSchedulesInRange(ctx, db.ListEmployeesSchedulesInRangeParams{
+		EmployeeIds: employeeIDs,
+		StartDate:   pgtype.Timestamp{Time: start.Add(-referenceWeeks * oneWeek), Valid: true},
+		EndDate:     pgtype.Timestamp{Time: end.Add(oneWeek), Valid: true},
+	})
+	if err != nil {
+		s.Logger.LogBusinessEvent(logger.LogLevelError, operation, "Failed to list shifts", zap.Error(err))
+		return nil, fmt.Errorf("failed to check working time rules")
+	}
+	periods := make(map[int64][]workPeriod)
+	for _, row := range rows {
+		scheduleID := row.ID
+		periods[row.EmployeeID] = append(periods[row.EmployeeID], workPeriod{
+			ScheduleID: &scheduleID,
+			Start:      row.StartDatetime.Time,
+			End:        row.EndDatetime.Time,
+		})
+	}
+	return periods, nil
+}
+
+// newWorkingTimeViolations returns the violations that adding the shift to the other
+// shifts of the employee causes, or changes. Violations that exist without the shift
+// are left out.
+func newWorkingTimeViolations(rules WorkingTimeRules, others []workPeriod, shift workPeriod) []WorkingTimeViolation {
+	from, to := shift.Start.Add(-oneWeek), shift.End.Add(oneWeek)
+	before := make(map[string]bool)
+	for _, v := range checkWorkingTime(rules, others, from, to) {
+		before[violationKey(v)] = true
+	}
+	var violations []WorkingTimeViolation
+	for _, v := range checkWorkingTime(rules, append(slices.Clone(others), shift), from, to) {
+		if !before[violationKey(v)] {
+			violations = append(violations, v)
+		}
+	}
+	return violations
+}
+
+func violationKey(v WorkingTimeViolation) string {
+	return fmt.Sprintf("%s/%d/%d/%g", v.Rule, v.StartTime.Unix(), v.EndTime.Unix(), v.Actual)
+}
+
+// checkWorkingTime checks the shifts of an employee against the rules. Shifts that
+// overlap or follow each other without a break are one period of work. Only shifts,
+// weeks and series of night shifts that start between from and to are checked, the
+// other shifts count towards rest and averages. Exceeding an average over 16 weeks is a
+// warning, as it can still be made up for, the other violations are errors.
+func checkWorkingTime(rules WorkingTimeRules, periods []workPeriod, from, to time.Time) []WorkingTimeViolation {
+	if rules.Exempt {
+		return nil
+	}
+	blocks := workBlocks(periods)
+	in := func(t time.Time) bool { return !t.Before(from) && t.Before(to) }
+	violations := []WorkingTimeViolation{}
+	add := func(rule string, severity ConflictSeverity, scheduleID *uuid.UUID, start, end time.Time, actual, limit float64, message string) {
+		violations = append(violations, WorkingTimeViolation{
+			Rule:       rule,
+			Severity:   severity,
+			ScheduleID: scheduleID,
+			StartTime:  start,
+			EndTime:    end,
+			Actual:     actual,
+			Limit:      limit,
+			Message:    message,
+		})
+	}
+
+	for _, b := range blocks {
+		if !in(b.Start) {
+			continue
+		}
+		length := b.End.Sub(b.Start)
+		if isNightShift(b.Start, b.End) && length > rules.MaxNightShift {
+			add(RuleNightShiftLength, SeverityError, b.ScheduleID, b.Start, b.End, roundHours(length.Hours()), roundHours(rules.MaxNightShift.Hours()),
+				fmt.Sprintf("Night shift of %s hours exceeds the maximum of %s hours", hours(length), hours(rules.MaxNightShift)))
+		} else if length > rules.MaxShift {
+			add(RuleShiftLength, SeverityError, b.ScheduleID, b.Start, b.End, roundHours(length.Hours()), roundHours(rules.MaxShift.Hours()),
+				fmt.Sprintf("Shift of %s hours exceeds the maximum of %s hours", hours(length), hours(rules.MaxShift)))
+		}
+
+		if rest := longestRest(blocks, b.Start, b.Start.Add(24*time.Hour)); rest < rules.MinDailyRest {
+			add(RuleDailyRest, SeverityError, b.ScheduleID, b.Start, b.Start.Add(24*time.Hour), roundHours(rest.Hours()), roundHours(rules.MinDailyRest.Hours()),
+				fmt.Sprintf("Only %s hours of rest within 24 hours of the start of the shift, %s hours required", hours(rest), hours(rules.MinDailyRest)))
+		}
+		if rest := longestRest(blocks, b.Start, b.Start.Add(oneWeek)); rest < rules.MinWeeklyRest {
+			add(RuleWeeklyRest, SeverityError, b.ScheduleID, b.Start, b.Start.Add(oneWeek), roundHours(rest.Hours()), roundHours(rules.MinWeeklyRest.Hours()),
+				fmt.Sprintf("Only %s hours of uninterrupted rest within 7 days of the start of the shift, %s hours required", hours(rest), hours(rules.MinWeeklyRest)))
+		}
+	}
+
+	for w := weekStart(from); w.Before(to); w = w.Add(oneWeek) {
+		if worked := workedIn(blocks, w, w.Add(oneWeek)); worked > rules.MaxWeek {
+			add(RuleWeekHours, SeverityError, nil, w, w.Add(oneWeek), roundHours(worked.Hours()), roundHours(rules.MaxWeek.Hours()),
+				fmt.Sprintf("%s hours in the week exceeds the maximum of %s hours", hours(worked), hours(rules.MaxWeek)))
+		}
+
+		referenceStart := w.Add(-(referenceWeeks - 1) * oneWeek)
+		worked := workedIn(blocks, referenceStart, w.Add(oneWeek))
+		if average := worked / referenceWeeks; average > rules.MaxAverageWeek {
+			add(RuleAverageWeekHours, SeverityWarning, nil, referenceStart, w.Add(oneWeek), roundHours(average.Hours()), roundHours(rules.MaxAverageWeek.Hours()),
+				fmt.Sprintf("Average of %s hours per week over 16 weeks exceeds the maximum of %s hours", hours(average), hours(rules.MaxAverageWeek)))
+		}
+		nights := 0
+		for _, b := range blocks {
+			if !b.Start.Before(referenceStart) && b.Start.Before(w.Add(oneWeek)) && isNightShift(b.Start, b.End) {
+				nights++
+			}
+		}
+		if nights > rules.MaxNights16Weeks {
+			add(RuleNightShifts16Weeks, SeverityWarning, nil, referenceStart, w.Add(oneWeek), float64(nights), float64(rules.MaxNights16Weeks),
+				fmt.Sprintf("%d night shifts in 16 weeks exceeds the maximum of %d", nights, rules.MaxNights16Weeks))
+		}
+	}
+
+	// night shifts are consecutive when the next one starts within 24 hours
+	run := 0
+	var runStart time.Time
+	for i, b := range blocks {
+		night := isNightShift(b.Start, b.End)
+		if run > 0 && (!night || b.Start.Sub(blocks[i-1].End) >= 24*time.Hour) {
+			last := blocks[i-1]
+			if rest := b.Start.Sub(last.End); run >= nightSeries && rest < rules.MinRestAfterNightSeries && in(last.Start) {
+				add(RuleRestAfterNightShifts, SeverityError, b.ScheduleID, last.End, b.Start, roundHours(rest.Hours()), roundHours(rules.MinRestAfterNightSeries.Hours()),
+					fmt.Sprintf("Only %s hours of rest after %d consecutive night shifts, %s hours required", hours(rest), run, hours(rules.MinRestAfterNightSeries)))
+			}
+			run = 0
+		}
+		if !night {
+			continue
+		}
+		if run == 0 {
+			runStart = b.Start
+		}
+		run++
+		if run > rules.MaxConsecutiveNights && in(b.Start) {
+			add(RuleConsecutiveNights, SeverityError, b.ScheduleID, runStart, b.End, float64(run), float64(rules.MaxConsecutiveNights),
+				fmt.Sprintf("%d consecutive night shifts exceeds the maximum of %d", run, rules.MaxConsecutiveNights))
+		}
+	}
+
+	slices.SortStableFunc(violations, func(a, b WorkingTimeViolation) int {
+		return a.StartTime.Compare(b.StartTime)
+	})
+	return violations
+}
+
+// workBlocks sorts the shifts and merges the ones that overlap or follow each other
+// without a break
+func workBlocks(periods []workPeriod) []workPeriod {
+	sorted := slices.Clone(periods)
+	slices.SortFunc(sorted, func(a, b workPeriod) int {
+		return a.Start.Compare(b.Start)
+	})
+	var blocks []workPeriod
+	for _, p := range sorted {
+		if n := len(blocks); n > 0 && !p.Start.After(blocks[n-1].End) {
+			blocks[n-1].End = maxTime(blocks[n-1].End, p.End)
+			continue
+		}
+		blocks = append(blocks, p)
+	}
+	return blocks
+}
+
+// longestRest returns the longest time without work between start and end
+func longestRest(blocks []workPeriod, start, end time.Time) time.Duration {
+	var longest time.Duration
+	cursor := start
+	for _, b := range blocks {
+		if !overlaps(start, end, b.Start, b.End) {
+			continue
+		}
+		if gap := b.Start.Sub(cursor); gap > longest {
+			longest = gap
+		}
+		cursor = maxTime(cursor, b.End)
+	}
+	if gap := end.Sub(cursor); gap > longest {
+		longest = gap
+	}
+	return longest
+}
+
+// workedIn returns the time worked between start and end
+func workedIn(blocks []workPeriod, start, end time.Time) time.Duration {
+	var worked time.Duration
+	for _, b := range blocks {
+		if overlaps(start, end, b.Start, b.End) {
+			worked += minTime(end, b.End).Sub(maxTime(start, b.Start))
+		}
+	}
+	return worked
+}
+
+// weekStart returns the start of the Monday of the week of t
+func weekStart(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
+
+func hours(d time.Duration) string {
+	return strconv.FormatFloat(roundHours(d.Hours()), 'f', -1, 64)
+}
+
+func contractType(value *string) string {
+	if value == nil || *value == "" {
+		return DefaultContractType
+	}
+	return *value
+}
+
+func workingTimeRules(row db.WorkingTimeRule) WorkingTimeRules {
+	return WorkingTimeRules{
+		ContractType:            row.ContractType,
+		Exempt:                  row.Exempt,
+		MaxShift:                time.Duration(row.MaxShiftMinutes) * time.Minute,
+		MaxWeek:                 time.Duration(row.MaxWeekMinutes) * time.Minute,
+		MaxAverageWeek:          time.Duration(row.MaxAverageWeekMinutes) * time.Minute,
+		MinDailyRest:            time.Duration(row.MinDailyRestMinutes) * time.Minute,
+		MinWeeklyRest:           time.Duration(row.MinWeeklyRestMinutes) * time.Minute,
+		MaxNightShift:           time.Duration(row.MaxNightShiftMinutes) * time.Minute,
+		MaxConsecutiveNights:    int(row.MaxConsecutiveNightShifts),
+		MaxNights16Weeks:        int(row.MaxNightShifts16Weeks),
+		MinRestAfterNightSeries: time.Duration(row.MinRestAfterNightSeriesMinutes) * time.Minute,
+	}
+}
+
+func workingTimeRulesResponse(row db.WorkingTimeRule) *WorkingTimeRulesResponse {
+	return &WorkingTimeRulesResponse{
+		ContractType:                   row.ContractType,
+		Exempt:                         row.Exempt,
+		MaxShiftMinutes:                row.MaxShiftMinutes,
+		MaxWeekMinutes:                 row.MaxWeekMinutes,
+		MaxAverageWeekMinutes:          row.MaxAverageWeekMinutes,
+		MinDailyRestMinutes:            row.MinDailyRestMinutes,
+		MinWeeklyRestMinutes:           row.MinWeeklyRestMinutes,
+		MaxNightShiftMinutes:           row.MaxNightShiftMinutes,
+		MaxConsecutiveNightShifts:      row.MaxConsecutiveNightShifts,
+		MaxNightShifts16Weeks:          row.MaxNightShifts16Weeks,
+		MinRestAfterNightSeriesMinutes: row.MinRestAfterNightSeriesMinutes,
+		UpdatedAt:                      row.UpdatedAt.Time,
+	}
+}
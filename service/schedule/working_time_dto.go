@@ -0,0 +1,86 @@
+package schedule
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WorkingTimeRulesResponse are the limits of the Arbeidstijdenwet for a contract type,
+// durations in minutes
+type WorkingTimeRulesResponse struct {
+	ContractType                   string    `json:"contract_type"`
+	Exempt                         bool      `json:"exempt"`
+	MaxShiftMinutes                int32     `json:"max_shift_minutes"`
+	MaxWeekMinutes                 int32     `json:"max_week_minutes"`
+	MaxAverageWeekMinutes          int32     `json:"max_average_week_minutes"`
+	MinDailyRestMinutes            int32     `json:"min_daily_rest_minutes"`
+	MinWeeklyRestMinutes           int32     `json:"min_weekly_rest_minutes"`
+	MaxNightShiftMinutes           int32     `json:"max_night_shift_minutes"`
+	MaxConsecutiveNightShifts      int32     `json:"max_consecutive_night_shifts"`
+	MaxNightShifts16Weeks          int32     `json:"max_night_shifts_16_weeks"`
+	MinRestAfterNightSeriesMinutes int32     `json:"min_rest_after_night_series_minutes"`
+	UpdatedAt                      time.Time `json:"updated_at"`
+}
+
+// UpdateWorkingTimeRulesRequest replaces the limits of a contract type. Exempt contract
+// types, like ZZP, are not checked at all.
+type UpdateWorkingTimeRulesRequest struct {
+	Exempt                         bool  `json:"exempt" example:"false"`
+	MaxShiftMinutes                int32 `json:"max_shift_minutes" binding:"required,min=1" example:"720"`
+	MaxWeekMinutes                 int32 `json:"max_week_minutes" binding:"required,min=1" example:"3600"`
+	MaxAverageWeekMinutes          int32 `json:"max_average_week_minutes" binding:"required,min=1" example:"2880"`
+	MinDailyRestMinutes            int32 `json:"min_daily_rest_minutes" binding:"min=0" example:"660"`
+	MinWeeklyRestMinutes           int32 `json:"min_weekly_rest_minutes" binding:"min=0" example:"2160"`
+	MaxNightShiftMinutes           int32 `json:"max_night_shift_minutes" binding:"required,min=1" example:"600"`
+	MaxConsecutiveNightShifts      int32 `json:"max_consecutive_night_shifts" binding:"required,min=1" example:"5"`
+	MaxNightShifts16Weeks          int32 `json:"max_night_shifts_16_weeks" binding:"required,min=1" example:"36"`
+	MinRestAfterNightSeriesMinutes int32 `json:"min_rest_after_night_series_minutes" binding:"min=0" example:"2760"`
+}
+
+// WorkingTimeComplianceRequest represents the query for the compliance report of the
+// employees with shifts in a period, at a location or of one employee when given
+type WorkingTimeComplianceRequest struct {
+	StartDate  string `form:"start_date" binding:"required" example:"2025-03-01"`
+	EndDate    string `form:"end_date" binding:"required" example:"2025-03-31"`
+	LocationID *int64 `form:"location_id"`
+	EmployeeID *int64 `form:"employee_id"`
+}
+
+// WorkingTimeViolation is a limit of the Arbeidstijdenwet that is exceeded. StartTime and
+// EndTime are the shift, week or rest period it is about. Actual and Limit are in hours,
+// or in night shifts for the rules that count them.
+type WorkingTimeViolation struct {
+	Rule       string           `json:"rule" enums:"shift_length,night_shift_length,week_hours,average_week_hours,daily_rest,weekly_rest,consecutive_night_shifts,night_shifts_16_weeks,rest_after_night_shifts"`
+	Severity   ConflictSeverity `json:"severity" enums:"error,warning"`
+	ScheduleID *uuid.UUID       `json:"schedule_id,omitempty"`
+	StartTime  time.Time        `json:"start_time"`
+	EndTime    time.Time        `json:"end_time"`
+	Actual     float64          `json:"actual"`
+	Limit      float64          `json:"limit"`
+	Message    string           `json:"message"`
+}
+
+// EmployeeCompliance is the compliance of the shifts of an employee in the period
+type EmployeeCompliance struct {
+	EmployeeID   int64                  `json:"employee_id"`
+	FirstName    string                 `json:"first_name"`
+	LastName     string                 `json:"last_name"`
+	ContractType string                 `json:"contract_type"`
+	Exempt       bool                   `json:"exempt"`
+	Shifts       int                    `json:"shifts"`
+	Hours        float64                `json:"hours"`
+	NightShifts  int                    `json:"night_shifts"`
+	Errors       int                    `json:"errors"`
+	Warnings     int                    `json:"warnings"`
+	Violations   []WorkingTimeViolation `json:"violations"`
+}
+
+// WorkingTimeComplianceResponse is the compliance report of a period
+type WorkingTimeComplianceResponse struct {
+	StartDate string               `json:"start_date"`
+	EndDate   string               `json:"end_date"`
+	Errors    int                  `json:"errors"`
+	Warnings  int                  `json:"warnings"`
+	Employees []EmployeeCompliance `json:"employees"`
+}
@@ -0,0 +1,128 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var atw = WorkingTimeRules{
+	ContractType:            "loondienst",
+	MaxShift:                12 * time.Hour,
+	MaxWeek:                 60 * time.Hour,
+	MaxAverageWeek:          48 * time.Hour,
+	MinDailyRest:            11 * time.Hour,
+	MinWeeklyRest:           36 * time.Hour,
+	MaxNightShift:           10 * time.Hour,
+	MaxConsecutiveNights:    5,
+	MaxNights16Weeks:        36,
+	MinRestAfterNightSeries: 46 * time.Hour,
+}
+
+// monday is Monday 3 March 2025
+var monday = time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC)
+
+func work(start time.Time, hours float64) workPeriod {
+	return workPeriod{Start: start, End: start.Add(time.Duration(hours * float64(time.Hour)))}
+}
+
+func violated(violations []WorkingTimeViolation) map[string][]WorkingTimeViolation {
+	rules := make(map[string][]WorkingTimeViolation)
+	for _, v := range violations {
+		rules[v.Rule] = append(rules[v.Rule], v)
+	}
+	return rules
+}
+
+func TestCheckWorkingTimeShiftAndDailyRest(t *testing.T) {
+	periods := []workPeriod{
+		work(monday.Add(7*time.Hour), 13),
+		// only 10 hours of rest after the Monday shift
+		work(monday.Add(30*time.Hour), 8),
+	}
+
+	violations := checkWorkingTime(atw, periods, monday, monday.AddDate(0, 0, 7))
+	require.Len(t, violations, 2)
+	require.Equal(t, RuleShiftLength, violations[0].Rule)
+	require.Equal(t, 13.0, violations[0].Actual)
+	require.Equal(t, 12.0, violations[0].Limit)
+	require.Equal(t, SeverityError, violations[0].Severity)
+	require.Equal(t, RuleDailyRest, violations[1].Rule)
+	require.Equal(t, 10.0, violations[1].Actual)
+	require.Equal(t, "Only 10 hours of rest within 24 hours of the start of the shift, 11 hours required", violations[1].Message)
+
+	atw := atw
+	atw.Exempt = true
+	require.Empty(t, checkWorkingTime(atw, periods, monday, monday.AddDate(0, 0, 7)))
+}
+
+func TestCheckWorkingTimeWeek(t *testing.T) {
+	var periods []workPeriod
+	for i := 0; i < 7; i++ {
+		periods = append(periods, work(monday.AddDate(0, 0, i).Add(8*time.Hour), 9))
+	}
+
+	rules := violated(checkWorkingTime(atw, periods, monday, monday.AddDate(0, 0, 7)))
+	require.Len(t, rules, 2)
+	require.Len(t, rules[RuleWeekHours], 1)
+	require.Equal(t, 63.0, rules[RuleWeekHours][0].Actual)
+	require.Equal(t, monday, rules[RuleWeekHours][0].StartTime)
+	// only the week that starts on Monday lacks 36 hours of rest
+	require.Len(t, rules[RuleWeeklyRest], 1)
+	require.Equal(t, monday.Add(8*time.Hour), rules[RuleWeeklyRest][0].StartTime)
+	require.Equal(t, 15.0, rules[RuleWeeklyRest][0].Actual)
+
+	// more than 48 hours a week on average over 16 weeks is a warning
+	for w := 1; w < 16; w++ {
+		for i := 0; i < 5; i++ {
+			periods = append(periods, work(monday.AddDate(0, 0, -7*w+i).Add(7*time.Hour), 10))
+		}
+	}
+	rules = violated(checkWorkingTime(atw, periods, monday, monday.AddDate(0, 0, 7)))
+	require.Len(t, rules[RuleAverageWeekHours], 1)
+	require.Equal(t, SeverityWarning, rules[RuleAverageWeekHours][0].Severity)
+	require.Equal(t, monday.AddDate(0, 0, -105), rules[RuleAverageWeekHours][0].StartTime)
+}
+
+func TestCheckWorkingTimeNights(t *testing.T) {
+	var periods []workPeriod
+	for i := 0; i < 6; i++ {
+		periods = append(periods, work(monday.AddDate(0, 0, i).Add(23*time.Hour), 8))
+	}
+	// a late shift 32 hours after the last night shift
+	periods = append(periods, work(monday.AddDate(0, 0, 7).Add(15*time.Hour), 8))
+
+	rules := violated(checkWorkingTime(atw, periods, monday, monday.AddDate(0, 0, 7)))
+	require.Len(t, rules[RuleConsecutiveNights], 1)
+	require.Equal(t, 6.0, rules[RuleConsecutiveNights][0].Actual)
+	require.Equal(t, monday.Add(23*time.Hour), rules[RuleConsecutiveNights][0].StartTime)
+	require.Len(t, rules[RuleRestAfterNightShifts], 1)
+	require.Equal(t, 32.0, rules[RuleRestAfterNightShifts][0].Actual)
+	require.Empty(t, rules[RuleNightShiftLength])
+
+	long := []workPeriod{work(monday.Add(22*time.Hour), 11)}
+	rules = violated(checkWorkingTime(atw, long, monday, monday.AddDate(0, 0, 7)))
+	require.Len(t, rules[RuleNightShiftLength], 1)
+	require.Empty(t, rules[RuleShiftLength])
+}
+
+func TestNewWorkingTimeViolations(t *testing.T) {
+	existing := []workPeriod{work(monday.Add(7*time.Hour), 24)}
+
+	// the shift already breaks the rules, a shift within it adds nothing
+	require.Empty(t, newWorkingTimeViolations(atw, existing, work(monday.Add(8*time.Hour), 8)))
+
+	// a shift right after an early shift makes one long shift
+	early := []workPeriod{work(monday.Add(7*time.Hour), 8)}
+	rules := violated(newWorkingTimeViolations(atw, early, work(monday.Add(15*time.Hour), 8)))
+	require.Len(t, rules[RuleShiftLength], 1)
+	require.Equal(t, 16.0, rules[RuleShiftLength][0].Actual)
+	require.Len(t, rules[RuleDailyRest], 1)
+}
+
+func TestWeekStart(t *testing.T) {
+	require.Equal(t, monday, weekStart(monday.Add(5*time.Hour)))
+	require.Equal(t, monday, weekStart(monday.AddDate(0, 0, 6).Add(23*time.Hour)))
+	require.Equal(t, monday.AddDate(0, 0, 7), weekStart(monday.AddDate(0, 0, 7)))
+}
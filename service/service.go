@@ -1,36 +1,48 @@
 package service
 
 import (
+	"maicare_go/async/aclient"
 	"maicare_go/bucket"
 	db "maicare_go/db/sqlc"
+	"maicare_go/hub"
 	"maicare_go/logger"
 	"maicare_go/service/appointment"
 	"maicare_go/service/attachment"
 	"maicare_go/service/auth"
+	"maicare_go/service/availability"
+	"maicare_go/service/calendar"
 	clientp "maicare_go/service/client"
 	contractp "maicare_go/service/contract"
 	"maicare_go/service/deps"
 	"maicare_go/service/ecr"
 	"maicare_go/service/employees"
+	"maicare_go/service/finance"
 	"maicare_go/service/invoice"
+	"maicare_go/service/leave"
+	"maicare_go/service/schedule"
 	"maicare_go/token"
 	"maicare_go/util"
 )
 
 type BusinessService struct {
 	*deps.ServiceDependencies
-	AuthService        auth.AuthService
-	ClientService      clientp.ClientService
-	EmployeeService    employees.EmployeeService
-	InvoiceService     invoice.InvoiceService
-	AppointmentService appointment.AppointmentService
-	AttachmentService  attachment.AttachmentService
-	ContractService    contractp.ContractService
-	ECRService         ecr.ECRService
+	AuthService         auth.AuthService
+	ClientService       clientp.ClientService
+	EmployeeService     employees.EmployeeService
+	InvoiceService      invoice.InvoiceService
+	AppointmentService  appointment.AppointmentService
+	AttachmentService   attachment.AttachmentService
+	ContractService     contractp.ContractService
+	ECRService          ecr.ECRService
+	FinanceService      finance.FinanceService
+	CalendarService     calendar.CalendarService
+	ScheduleService     schedule.ScheduleService
+	LeaveService        leave.LeaveService
+	AvailabilityService availability.AvailabilityService
 }
 
-func NewBusinessService(store *db.Store, tokenMaker token.Maker, logger logger.Logger, config *util.Config, b2Client bucket.ObjectStorageInterface) *BusinessService {
-	deps := deps.NewServiceDependencies(store, tokenMaker, logger, config, b2Client)
+func NewBusinessService(store *db.Store, tokenMaker token.Maker, logger logger.Logger, config *util.Config, b2Client bucket.ObjectStorageInterface, asynqClient aclient.AsynqClientInterface, hubInstance *hub.Hub) *BusinessService {
+	deps := deps.NewServiceDependencies(store, tokenMaker, logger, config, b2Client, asynqClient, hubInstance)
 	authService := auth.NewAuthService(deps)
 	clientService := clientp.NewClientService(deps)
 	employeeService := employees.NewEmployeeService(deps)
@@ -39,6 +51,11 @@ func NewBusinessService(store *db.Store, tokenMaker token.Maker, logger logger.L
 	attachmentService := attachment.NewAttachmentService(deps)
 	contractService := contractp.NewContractService(deps)
 	ecrService := ecr.NewECRService(deps)
+	financeService := finance.NewFinanceService(deps)
+	calendarService := calendar.NewCalendarService(deps)
+	scheduleService := schedule.NewScheduleService(deps)
+	leaveService := leave.NewLeaveService(deps)
+	availabilityService := availability.NewAvailabilityService(deps)
 	return &BusinessService{
 		ServiceDependencies: deps,
 		AuthService:         authService,
@@ -49,6 +66,11 @@ func NewBusinessService(store *db.Store, tokenMaker token.Maker, logger logger.L
 		AttachmentService:   attachmentService,
 		ContractService:     contractService,
 		ECRService:          ecrService,
+		FinanceService:      financeService,
+		CalendarService:     calendarService,
+		ScheduleService:     scheduleService,
+		LeaveService:        leaveService,
+		AvailabilityService: availabilityService,
 	}
 }
 
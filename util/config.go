@@ -37,6 +37,10 @@ type Config struct {
 	Environment           string        `mapstructure:"ENVIRONMENT"`
 	GrpcUrl               string        `mapstructure:"GRPC_URL"`
 	MigrationsPath        string        `mapstructure:"MIGRATIONS_PATH"`
+	// comma separated IPs or CIDRs of the reverse proxies in front of the API, the client IP
+	// of the request logs, the login audit and the clock-in networks is only read from the
+	// forwarding headers of these
+	TrustedProxies []string `mapstructure:"TRUSTED_PROXIES"`
 }
 
 func LoadConfig(path string) (config Config, err error) {
@@ -58,7 +62,7 @@ func LoadConfig(path string) (config Config, err error) {
 		"OPEN_ROUTER_API_KEY", "SMTP_NAME", "SMTP_ADDRESS",
 		"SMTP_AUTH", "SMTP_HOST", "SMTP_PORT", "BREVO_SENDER_NAME",
 		"BREVO_SENDER_EMAIL", "BREVO_API_KEY", "ENVIRONMENT", "GRPC_URL",
-		"MIGRATIONS_PATH",
+		"MIGRATIONS_PATH", "TRUSTED_PROXIES",
 	}
 
 	for _, envVar := range envVars {
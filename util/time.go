@@ -11,3 +11,10 @@ func ConvertTimeToNetherlandsTimezone(t time.Time) time.Time {
 	}
 	return t.In(loc)
 }
+
+// NetherlandsNow returns the current Dutch wall-clock time in UTC, which is how pgx returns the
+// TIMESTAMP columns that store Dutch local times
+func NetherlandsNow() time.Time {
+	t := ConvertTimeToNetherlandsTimezone(time.Now())
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
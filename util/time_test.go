@@ -0,0 +1,20 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetherlandsNow(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Amsterdam")
+	require.NoError(t, err)
+
+	now := NetherlandsNow()
+	local := time.Now().In(loc)
+	require.Equal(t, time.UTC, now.Location())
+	// the Dutch wall clock is read as if it was UTC
+	wall := time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), local.Minute(), local.Second(), local.Nanosecond(), time.UTC)
+	require.WithinDuration(t, wall, now, time.Second)
+}